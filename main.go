@@ -4,8 +4,52 @@
 
 package main
 
-import "github.com/platform-engineering-labs/formae/pkg/plugin/sdk"
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/sdk"
+)
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "check" {
+		os.Exit(runCheck(os.Args[2:], os.Stdin, os.Stdout))
+	}
 	sdk.RunWithManifest(&Plugin{}, sdk.RunConfig{})
 }
+
+// runCheck implements "check [target-config.json]": it reads a target config
+// (the JSON formae passes as TargetConfig) from the file, or from stdin when no
+// file is given, prints the connectivity report as JSON and exits non-zero if
+// the check failed.
+func runCheck(args []string, stdin io.Reader, stdout io.Writer) int {
+	var targetConfig []byte
+	var err error
+	if len(args) > 0 {
+		targetConfig, err = os.ReadFile(args[0])
+	} else {
+		targetConfig, err = io.ReadAll(stdin)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "reading target config: %v\n", err)
+		return 2
+	}
+	if len(targetConfig) == 0 {
+		targetConfig = []byte("{}")
+	}
+	if !json.Valid(targetConfig) {
+		fmt.Fprintln(os.Stderr, "target config is not valid JSON")
+		return 2
+	}
+
+	report := (&Plugin{}).CheckConnectivity(context.Background(), targetConfig)
+	out, _ := json.MarshalIndent(report, "", "  ")
+	fmt.Fprintln(stdout, string(out))
+	if !report.OK {
+		return 1
+	}
+	return 0
+}
@@ -6,15 +6,24 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
+	_ "github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/autoscaling"
 	_ "github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/containerengine"
 	_ "github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/core"
+	_ "github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/dns"
 	_ "github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/identity"
+	_ "github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/kms"
+	_ "github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/loadbalancer"
+	_ "github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/networkloadbalancer"
 	_ "github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/objectstorage"
+	_ "github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/vault"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
 	"github.com/platform-engineering-labs/formae/pkg/model"
 	"github.com/platform-engineering-labs/formae/pkg/plugin"
@@ -33,7 +42,7 @@ var _ plugin.ResourcePlugin = &Plugin{}
 func (p *Plugin) RateLimit() model.RateLimitConfig {
 	return model.RateLimitConfig{
 		Scope:                            model.RateLimitScopeNamespace,
-		MaxRequestsPerSecondForNamespace: 10,
+		MaxRequestsPerSecondForNamespace: client.AdvertisedRequestsPerSecond(),
 	}
 }
 
@@ -45,17 +54,37 @@ func (p *Plugin) LabelConfig() model.LabelConfig {
 	return model.LabelConfig{
 		DefaultQuery: "$.DisplayName",
 		ResourceOverrides: map[string]string{
-			"OCI::Identity::Compartment":     "$.Name",
-			"OCI::Identity::Policy":          "$.Name",
-			"OCI::ContainerEngine::Cluster":  "$.Name",
-			"OCI::ContainerEngine::NodePool": "$.Name",
-			"OCI::ObjectStorage::Bucket":     "$.Name",
+			"OCI::Identity::Compartment":           "$.Name",
+			"OCI::Identity::Policy":                "$.Name",
+			"OCI::ContainerEngine::Cluster":        "$.Name",
+			"OCI::ContainerEngine::NodePool":       "$.Name",
+			"OCI::LoadBalancer::Listener":          "$.Name",
+			"OCI::LoadBalancer::BackendSet":        "$.Name",
+			"OCI::LoadBalancer::Backend":           "$.Name",
+			"OCI::LoadBalancer::RoutingPolicy":     "$.Name",
+			"OCI::LoadBalancer::RuleSet":           "$.Name",
+			"OCI::LoadBalancer::Hostname":          "$.Name",
+			"OCI::LoadBalancer::Certificate":       "$.CertificateName",
+			"OCI::NetworkLoadBalancer::BackendSet": "$.Name",
+			"OCI::NetworkLoadBalancer::Listener":   "$.Name",
+			"OCI::ObjectStorage::Bucket":           "$.Name",
+			"OCI::ObjectStorage::Object":           "$.ObjectName",
+			"OCI::Vault::Secret":                   "$.SecretName",
 		},
 	}
 }
 
+// Dependencies returns the resource types whose resources one of resourceType
+// can reference, so formae can create those first and delete them last instead
+// of running into OCI's 409s for resources still in use.
+func (p *Plugin) Dependencies(resourceType string) []string {
+	return provisioner.Dependencies(resourceType)
+}
+
 func (p *Plugin) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
 	cfg := config.FromTargetConfig(request.TargetConfig)
+	ctx, cancel := context.WithTimeout(client.WithOperation(ctx, request.ResourceType, ""), cfg.OperationDeadline())
+	defer cancel()
 	clients, err := client.NewClients(ctx, cfg)
 	if err != nil {
 		return nil, err
@@ -68,6 +97,11 @@ func (p *Plugin) Create(ctx context.Context, request *resource.CreateRequest) (*
 
 	result, err := prov.Create(ctx, request)
 	if err != nil {
+		if timedOut(ctx, err) {
+			return &resource.CreateResult{
+				ProgressResult: timeoutResult(resource.OperationCreate, request.ResourceType, "", "OperationTimeout", cfg.OperationDeadline()),
+			}, nil
+		}
 		// Try to convert OCI service errors to recoverable errors
 		if handledResult, handledErr := util.HandleCreateError(err, request.ResourceType, request.ResourceType); handledErr == nil && handledResult != nil {
 			return handledResult, nil
@@ -80,6 +114,8 @@ func (p *Plugin) Create(ctx context.Context, request *resource.CreateRequest) (*
 
 func (p *Plugin) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
 	cfg := config.FromTargetConfig(request.TargetConfig)
+	ctx, cancel := context.WithTimeout(client.WithOperation(ctx, request.ResourceType, request.NativeID), cfg.OperationDeadline())
+	defer cancel()
 	clients, err := client.NewClients(ctx, cfg)
 	if err != nil {
 		return nil, err
@@ -92,6 +128,11 @@ func (p *Plugin) Update(ctx context.Context, request *resource.UpdateRequest) (*
 
 	result, err := prov.Update(ctx, request)
 	if err != nil {
+		if timedOut(ctx, err) {
+			return &resource.UpdateResult{
+				ProgressResult: timeoutResult(resource.OperationUpdate, request.ResourceType, request.NativeID, "OperationTimeout", cfg.OperationDeadline()),
+			}, nil
+		}
 		// Try to convert OCI service errors to recoverable errors
 		if handledResult, handledErr := util.HandleUpdateError(err, request.ResourceType, request.NativeID, request.ResourceType); handledErr == nil && handledResult != nil {
 			return handledResult, nil
@@ -104,6 +145,8 @@ func (p *Plugin) Update(ctx context.Context, request *resource.UpdateRequest) (*
 
 func (p *Plugin) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
 	cfg := config.FromTargetConfig(request.TargetConfig)
+	ctx, cancel := context.WithTimeout(client.WithOperation(ctx, request.ResourceType, request.NativeID), cfg.OperationDeadline())
+	defer cancel()
 	clients, err := client.NewClients(ctx, cfg)
 	if err != nil {
 		return nil, err
@@ -116,6 +159,11 @@ func (p *Plugin) Delete(ctx context.Context, request *resource.DeleteRequest) (*
 
 	result, err := prov.Delete(ctx, request)
 	if err != nil {
+		if timedOut(ctx, err) {
+			return &resource.DeleteResult{
+				ProgressResult: timeoutResult(resource.OperationDelete, request.ResourceType, request.NativeID, "OperationTimeout", cfg.OperationDeadline()),
+			}, nil
+		}
 		// Try to convert OCI service errors to recoverable errors
 		if handledResult, handledErr := util.HandleDeleteError(err, request.ResourceType, request.NativeID, request.ResourceType); handledErr == nil && handledResult != nil {
 			return handledResult, nil
@@ -128,6 +176,8 @@ func (p *Plugin) Delete(ctx context.Context, request *resource.DeleteRequest) (*
 
 func (p *Plugin) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
 	cfg := config.FromTargetConfig(request.TargetConfig)
+	ctx, cancel := context.WithTimeout(client.WithOperation(ctx, request.ResourceType, request.NativeID), cfg.StatusDeadline())
+	defer cancel()
 	clients, err := client.NewClients(ctx, cfg)
 	if err != nil {
 		return nil, err
@@ -138,11 +188,19 @@ func (p *Plugin) Status(ctx context.Context, request *resource.StatusRequest) (*
 		return nil, fmt.Errorf("no provisioner registered for resource type: %s", request.ResourceType)
 	}
 
-	return prov.Status(ctx, request)
+	result, err := prov.Status(ctx, request)
+	if err != nil && timedOut(ctx, err) {
+		timeout := timeoutResult(resource.OperationCheckStatus, request.ResourceType, request.NativeID, "StatusTimeout", cfg.StatusDeadline())
+		timeout.RequestID = request.RequestID
+		return &resource.StatusResult{ProgressResult: timeout}, nil
+	}
+	return result, err
 }
 
 func (p *Plugin) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
 	cfg := config.FromTargetConfig(request.TargetConfig)
+	ctx, cancel := context.WithTimeout(client.WithOperation(ctx, request.ResourceType, request.NativeID), cfg.OperationDeadline())
+	defer cancel()
 	clients, err := client.NewClients(ctx, cfg)
 	if err != nil {
 		return nil, err
@@ -153,11 +211,20 @@ func (p *Plugin) Read(ctx context.Context, request *resource.ReadRequest) (*reso
 		return nil, fmt.Errorf("no provisioner registered for resource type: %s", request.ResourceType)
 	}
 
-	return prov.Read(ctx, request)
+	result, err := prov.Read(ctx, request)
+	if err != nil && timedOut(ctx, err) {
+		return &resource.ReadResult{
+			ResourceType: request.ResourceType,
+			ErrorCode:    resource.OperationErrorCodeServiceTimeout,
+		}, nil
+	}
+	return result, err
 }
 
 func (p *Plugin) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
 	cfg := config.FromTargetConfig(request.TargetConfig)
+	ctx, cancel := context.WithTimeout(client.WithOperation(ctx, request.ResourceType, ""), cfg.OperationDeadline())
+	defer cancel()
 	clients, err := client.NewClients(ctx, cfg)
 	if err != nil {
 		return nil, err
@@ -170,5 +237,51 @@ func (p *Plugin) List(ctx context.Context, request *resource.ListRequest) (*reso
 		}, nil
 	}
 
-	return prov.List(ctx, request)
+	result, err := prov.List(ctx, request)
+	if err != nil && timedOut(ctx, err) {
+		return nil, fmt.Errorf("listing %s did not finish within %s; raise OperationTimeout in the target config if it needs longer: %w", request.ResourceType, cfg.OperationDeadline(), err)
+	}
+	return result, err
+}
+
+// ReadBatch reads many resources in one call, for discovery passes that read
+// every NativeID a List returned. Up to the target's DiscoveryReadConcurrency
+// reads run at once; formae counts the batch as one request, so the OCI calls
+// they make are held to the rate limit by the clients instead. Reads in the
+// batch share parent listings through util.WithReadCache.
+// results[i] and errs[i] belong to requests[i].
+func (p *Plugin) ReadBatch(ctx context.Context, requests []*resource.ReadRequest) ([]*resource.ReadResult, []error) {
+	if len(requests) == 0 {
+		return nil, nil
+	}
+	concurrency := config.FromTargetConfig(requests[0].TargetConfig).DiscoveryReadConcurrency
+	return provisioner.ReadBatch(util.WithReadCache(ctx), requests, concurrency, p.Read)
+}
+
+// CheckConnectivity validates the credentials and network path of a target
+// config without touching any resource; see client.CheckConnectivity. It backs
+// the "check" command of the plugin binary.
+func (p *Plugin) CheckConnectivity(ctx context.Context, targetConfig json.RawMessage) *client.ConnectivityReport {
+	cfg := config.FromTargetConfig(targetConfig)
+	ctx, cancel := context.WithTimeout(ctx, cfg.OperationDeadline())
+	defer cancel()
+	return client.CheckConnectivity(ctx, cfg)
+}
+
+// timedOut reports whether err came from ctx running past its deadline. A
+// cancellation by formae itself is not a timeout and is returned as is.
+func timedOut(ctx context.Context, err error) bool {
+	return errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded)
+}
+
+// timeoutResult fails an operation that ran past its deadline with the same
+// ServiceTimeout code an OCI 504 gets, naming the setting that raises it.
+func timeoutResult(operation resource.Operation, resourceType, nativeID, setting string, deadline time.Duration) *resource.ProgressResult {
+	return &resource.ProgressResult{
+		Operation:       operation,
+		OperationStatus: resource.OperationStatusFailure,
+		ErrorCode:       resource.OperationErrorCodeServiceTimeout,
+		StatusMessage:   fmt.Sprintf("%s %s did not finish within %s; raise %s in the target config if it needs longer", resourceType, operation, deadline, setting),
+		NativeID:        nativeID,
+	}
 }
@@ -6,25 +6,43 @@ package client
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
 	"sync"
+	"time"
 
+	"github.com/oracle/oci-go-sdk/v65/autoscaling"
 	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/oracle/oci-go-sdk/v65/containerengine"
 	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/oracle/oci-go-sdk/v65/dns"
 	"github.com/oracle/oci-go-sdk/v65/identity"
+	"github.com/oracle/oci-go-sdk/v65/keymanagement"
+	"github.com/oracle/oci-go-sdk/v65/loadbalancer"
+	"github.com/oracle/oci-go-sdk/v65/networkloadbalancer"
 	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+	"github.com/oracle/oci-go-sdk/v65/secrets"
+	"github.com/oracle/oci-go-sdk/v65/vault"
+	"github.com/oracle/oci-go-sdk/v65/workrequests"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
 )
 
-// noECRetryPolicy disables the OCI SDK's eventual consistency retries.
-// Without this, the SDK retries 404 responses for up to 4 minutes after
-// any write operation, assuming eventual consistency lag. This causes
-// sync reads for deleted resources to hang instead of returning NotFound.
-var noECRetryPolicy = common.DefaultRetryPolicyWithoutEventualConsistency()
+// defaultHTTPTimeout matches the SDK's own per-request timeout, used when only
+// the proxy or connect timeout is configured.
+const defaultHTTPTimeout = 60 * time.Second
 
 // Clients manages OCI service clients with lazy initialization
 type Clients struct {
-	provider common.ConfigurationProvider
+	provider   common.ConfigurationProvider
+	httpClient *http.Client // nil keeps the SDK's default dispatcher
+	logger     *slog.Logger // nil unless API call logging is on
+	limiter    *util.RateLimiter
+	retry      common.RetryPolicy
 
 	mu              sync.Mutex
 	virtualNetwork  *core.VirtualNetworkClient
@@ -33,16 +51,134 @@ type Clients struct {
 	objectStorage   *objectstorage.ObjectStorageClient
 	identity        *identity.IdentityClient
 	containerEngine *containerengine.ContainerEngineClient
+	loadBalancer    *loadbalancer.LoadBalancerClient
+	nlb             *networkloadbalancer.NetworkLoadBalancerClient
+	dns             *dns.DnsClient
+	vaults          *vault.VaultsClient
+	secrets         *secrets.SecretsClient
+	autoScaling     *autoscaling.AutoScalingClient
+	kmsVault        *keymanagement.KmsVaultClient
+	workRequests    *workrequests.WorkRequestClient
+	kmsManagement   map[string]*keymanagement.KmsManagementClient // by management endpoint
 }
 
-// NewClients creates a new Clients instance with the given configuration
+// cachedClients builds the Clients for one target config exactly once, however
+// many operations ask for it concurrently.
+type cachedClients struct {
+	once    sync.Once
+	clients *Clients
+	err     error
+}
+
+var (
+	cacheMu sync.Mutex
+	cache   = map[string]*cachedClients{}
+)
+
+// NewClients returns the Clients for the given configuration. Operations for the
+// same target share one instance, so the credentials are parsed once even when
+// formae runs operations in parallel. A failed build is not cached.
 func NewClients(ctx context.Context, cfg *config.Config) (*Clients, error) {
+	keyBytes, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	key := string(keyBytes)
+
+	cacheMu.Lock()
+	entry, ok := cache[key]
+	if !ok {
+		entry = &cachedClients{}
+		cache[key] = entry
+	}
+	cacheMu.Unlock()
+
+	entry.once.Do(func() {
+		entry.clients, entry.err = newClients(ctx, cfg)
+	})
+	if entry.err != nil {
+		cacheMu.Lock()
+		if cache[key] == entry {
+			delete(cache, key)
+		}
+		cacheMu.Unlock()
+		return nil, entry.err
+	}
+	return entry.clients, nil
+}
+
+func newClients(ctx context.Context, cfg *config.Config) (*Clients, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
 	provider, err := cfg.ToConfigProvider(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Clients{provider: provider}, nil
+	httpClient, err := newHTTPClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Clients{
+		provider:   provider,
+		httpClient: httpClient,
+		logger:     newDebugLogger(cfg),
+		limiter:    sharedLimiter(requestsPerSecond(cfg)),
+		retry:      newRetryPolicy(retryAttempts(cfg)),
+	}, nil
+}
+
+// newHTTPClient builds the dispatcher for the timeout and proxy settings in cfg,
+// or returns nil when none are set.
+func newHTTPClient(cfg *config.Config) (*http.Client, error) {
+	if cfg.HttpTimeout == "" && cfg.ConnectTimeout == "" && cfg.HttpsProxy == "" {
+		return nil, nil
+	}
+
+	timeout := defaultHTTPTimeout
+	if cfg.HttpTimeout != "" {
+		d, err := time.ParseDuration(cfg.HttpTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid HttpTimeout %q: %w", cfg.HttpTimeout, err)
+		}
+		timeout = d
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.ConnectTimeout != "" {
+		d, err := time.ParseDuration(cfg.ConnectTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ConnectTimeout %q: %w", cfg.ConnectTimeout, err)
+		}
+		transport.DialContext = (&net.Dialer{Timeout: d, KeepAlive: 30 * time.Second}).DialContext
+		transport.TLSHandshakeTimeout = d
+	}
+	if cfg.HttpsProxy != "" {
+		proxyURL, err := url.Parse(cfg.HttpsProxy)
+		if err != nil || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid HttpsProxy %q", cfg.HttpsProxy)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	return &http.Client{Timeout: timeout, Transport: transport}, nil
+}
+
+// configure applies the settings shared by every service client.
+func (c *Clients) configure(base *common.BaseClient) {
+	base.SetCustomClientConfiguration(common.CustomClientConfiguration{RetryPolicy: &c.retry})
+	if c.httpClient != nil {
+		base.HTTPClient = c.httpClient
+	}
+	if c.limiter != nil {
+		base.HTTPClient = rateLimitedDispatcher{inner: base.HTTPClient, limiter: c.limiter}
+	}
+	if c.logger != nil {
+		base.HTTPClient = loggingDispatcher{inner: base.HTTPClient, logger: c.logger}
+	}
 }
 
 // GetVirtualNetworkClient returns a cached or newly created VirtualNetworkClient
@@ -55,7 +191,7 @@ func (c *Clients) GetVirtualNetworkClient() (*core.VirtualNetworkClient, error)
 		if err != nil {
 			return nil, err
 		}
-		client.SetCustomClientConfiguration(common.CustomClientConfiguration{RetryPolicy: &noECRetryPolicy})
+		c.configure(&client.BaseClient)
 		c.virtualNetwork = &client
 	}
 	return c.virtualNetwork, nil
@@ -71,7 +207,7 @@ func (c *Clients) GetBlockstorageClient() (*core.BlockstorageClient, error) {
 		if err != nil {
 			return nil, err
 		}
-		client.SetCustomClientConfiguration(common.CustomClientConfiguration{RetryPolicy: &noECRetryPolicy})
+		c.configure(&client.BaseClient)
 		c.blockstorage = &client
 	}
 	return c.blockstorage, nil
@@ -87,12 +223,29 @@ func (c *Clients) GetComputeClient() (*core.ComputeClient, error) {
 		if err != nil {
 			return nil, err
 		}
-		client.SetCustomClientConfiguration(common.CustomClientConfiguration{RetryPolicy: &noECRetryPolicy})
+		c.configure(&client.BaseClient)
 		c.compute = &client
 	}
 	return c.compute, nil
 }
 
+// GetWorkRequestClient returns a cached or newly created WorkRequestClient, for
+// the Core Services work requests (e.g. an instance update) the Compute API returns.
+func (c *Clients) GetWorkRequestClient() (*workrequests.WorkRequestClient, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.workRequests == nil {
+		client, err := workrequests.NewWorkRequestClientWithConfigurationProvider(c.provider)
+		if err != nil {
+			return nil, err
+		}
+		c.configure(&client.BaseClient)
+		c.workRequests = &client
+	}
+	return c.workRequests, nil
+}
+
 // GetObjectStorageClient returns a cached or newly created ObjectStorageClient
 func (c *Clients) GetObjectStorageClient() (*objectstorage.ObjectStorageClient, error) {
 	c.mu.Lock()
@@ -103,7 +256,7 @@ func (c *Clients) GetObjectStorageClient() (*objectstorage.ObjectStorageClient,
 		if err != nil {
 			return nil, err
 		}
-		client.SetCustomClientConfiguration(common.CustomClientConfiguration{RetryPolicy: &noECRetryPolicy})
+		c.configure(&client.BaseClient)
 		c.objectStorage = &client
 	}
 	return c.objectStorage, nil
@@ -119,7 +272,7 @@ func (c *Clients) GetIdentityClient() (*identity.IdentityClient, error) {
 		if err != nil {
 			return nil, err
 		}
-		client.SetCustomClientConfiguration(common.CustomClientConfiguration{RetryPolicy: &noECRetryPolicy})
+		c.configure(&client.BaseClient)
 		c.identity = &client
 	}
 	return c.identity, nil
@@ -135,7 +288,7 @@ func (c *Clients) GetContainerEngineClient() (*containerengine.ContainerEngineCl
 		if err != nil {
 			return nil, err
 		}
-		client.SetCustomClientConfiguration(common.CustomClientConfiguration{RetryPolicy: &noECRetryPolicy})
+		c.configure(&client.BaseClient)
 		c.containerEngine = &client
 	}
 	return c.containerEngine, nil
@@ -145,3 +298,139 @@ func (c *Clients) GetContainerEngineClient() (*containerengine.ContainerEngineCl
 func (c *Clients) GetConfigurationProvider() common.ConfigurationProvider {
 	return c.provider
 }
+
+// GetLoadBalancerClient returns a cached or newly created LoadBalancerClient
+func (c *Clients) GetLoadBalancerClient() (*loadbalancer.LoadBalancerClient, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.loadBalancer == nil {
+		client, err := loadbalancer.NewLoadBalancerClientWithConfigurationProvider(c.provider)
+		if err != nil {
+			return nil, err
+		}
+		c.configure(&client.BaseClient)
+		c.loadBalancer = &client
+	}
+	return c.loadBalancer, nil
+}
+
+// GetNetworkLoadBalancerClient returns a cached or newly created NetworkLoadBalancerClient
+func (c *Clients) GetNetworkLoadBalancerClient() (*networkloadbalancer.NetworkLoadBalancerClient, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.nlb == nil {
+		client, err := networkloadbalancer.NewNetworkLoadBalancerClientWithConfigurationProvider(c.provider)
+		if err != nil {
+			return nil, err
+		}
+		c.configure(&client.BaseClient)
+		c.nlb = &client
+	}
+	return c.nlb, nil
+}
+
+// GetDnsClient returns a cached or newly created DnsClient
+func (c *Clients) GetDnsClient() (*dns.DnsClient, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.dns == nil {
+		client, err := dns.NewDnsClientWithConfigurationProvider(c.provider)
+		if err != nil {
+			return nil, err
+		}
+		c.configure(&client.BaseClient)
+		c.dns = &client
+	}
+	return c.dns, nil
+}
+
+// GetVaultsClient returns a cached or newly created VaultsClient
+func (c *Clients) GetVaultsClient() (*vault.VaultsClient, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.vaults == nil {
+		client, err := vault.NewVaultsClientWithConfigurationProvider(c.provider)
+		if err != nil {
+			return nil, err
+		}
+		c.configure(&client.BaseClient)
+		c.vaults = &client
+	}
+	return c.vaults, nil
+}
+
+// GetSecretsClient returns a cached or newly created SecretsClient, which reads
+// secret bundles (the content of a Vault secret's versions)
+func (c *Clients) GetSecretsClient() (*secrets.SecretsClient, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.secrets == nil {
+		client, err := secrets.NewSecretsClientWithConfigurationProvider(c.provider)
+		if err != nil {
+			return nil, err
+		}
+		c.configure(&client.BaseClient)
+		c.secrets = &client
+	}
+	return c.secrets, nil
+}
+
+// GetAutoScalingClient returns a cached or newly created AutoScalingClient
+func (c *Clients) GetAutoScalingClient() (*autoscaling.AutoScalingClient, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.autoScaling == nil {
+		client, err := autoscaling.NewAutoScalingClientWithConfigurationProvider(c.provider)
+		if err != nil {
+			return nil, err
+		}
+		c.configure(&client.BaseClient)
+		c.autoScaling = &client
+	}
+	return c.autoScaling, nil
+}
+
+// GetKmsVaultClient returns a cached or newly created KmsVaultClient
+func (c *Clients) GetKmsVaultClient() (*keymanagement.KmsVaultClient, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.kmsVault == nil {
+		client, err := keymanagement.NewKmsVaultClientWithConfigurationProvider(c.provider)
+		if err != nil {
+			return nil, err
+		}
+		c.configure(&client.BaseClient)
+		c.kmsVault = &client
+	}
+	return c.kmsVault, nil
+}
+
+// GetKmsManagementClient returns a cached or newly created KmsManagementClient
+// for a vault's management endpoint. Keys are managed through the endpoint of
+// the vault holding them rather than a regional one, so there is one client
+// per vault.
+func (c *Clients) GetKmsManagementClient(managementEndpoint string) (*keymanagement.KmsManagementClient, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if client, ok := c.kmsManagement[managementEndpoint]; ok {
+		return client, nil
+	}
+	client, err := keymanagement.NewKmsManagementClientWithConfigurationProvider(c.provider, managementEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	c.configure(&client.BaseClient)
+	if c.kmsManagement == nil {
+		c.kmsManagement = map[string]*keymanagement.KmsManagementClient{}
+	}
+	c.kmsManagement[managementEndpoint] = &client
+	return &client, nil
+}
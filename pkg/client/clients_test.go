@@ -0,0 +1,274 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHTTPClient_Unset(t *testing.T) {
+	hc, err := newHTTPClient(&config.Config{})
+	require.NoError(t, err)
+	assert.Nil(t, hc)
+}
+
+func TestNewHTTPClient_TimeoutAndProxy(t *testing.T) {
+	hc, err := newHTTPClient(&config.Config{
+		HttpTimeout:    "15s",
+		ConnectTimeout: "3s",
+		HttpsProxy:     "http://proxy.example.com:3128",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, hc)
+	assert.Equal(t, 15*time.Second, hc.Timeout)
+
+	transport := hc.Transport.(*http.Transport)
+	assert.Equal(t, 3*time.Second, transport.TLSHandshakeTimeout)
+	proxy, err := transport.Proxy(&http.Request{URL: &url.URL{Scheme: "https", Host: "iaas.us-chicago-1.oraclecloud.com"}})
+	require.NoError(t, err)
+	assert.Equal(t, "proxy.example.com:3128", proxy.Host)
+}
+
+func TestNewHTTPClient_ProxyOnlyKeepsDefaultTimeout(t *testing.T) {
+	hc, err := newHTTPClient(&config.Config{HttpsProxy: "http://proxy.example.com:3128"})
+	require.NoError(t, err)
+	assert.Equal(t, defaultHTTPTimeout, hc.Timeout)
+}
+
+func TestNewHTTPClient_Invalid(t *testing.T) {
+	_, err := newHTTPClient(&config.Config{HttpTimeout: "soon"})
+	assert.Error(t, err)
+
+	_, err = newHTTPClient(&config.Config{HttpsProxy: "not a url"})
+	assert.Error(t, err)
+}
+
+func TestClientsUseConfiguredHTTPClient(t *testing.T) {
+	hc, err := newHTTPClient(&config.Config{HttpTimeout: "15s"})
+	require.NoError(t, err)
+
+	// The SDK validates the key when building a client; nothing is signed here.
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	c := &Clients{
+		provider: common.NewRawConfigurationProvider(
+			"ocid1.tenancy.oc1..test",
+			"ocid1.user.oc1..test",
+			"us-chicago-1",
+			"aa:bb:cc:dd:ee:ff:11:22:33:44:55:66:77:88:99:00",
+			string(keyPEM),
+			nil,
+		),
+		httpClient: hc,
+		retry:      newRetryPolicy(DefaultMaxRetryAttempts),
+	}
+
+	vcn, err := c.GetVirtualNetworkClient()
+	require.NoError(t, err)
+	assert.Same(t, hc, vcn.HTTPClient)
+
+	compute, err := c.GetComputeClient()
+	require.NoError(t, err)
+	assert.Same(t, hc, compute.HTTPClient)
+}
+
+func TestNewClientsConcurrent(t *testing.T) {
+	cfg := &config.Config{ConfigFilePath: writeTestOCIConfig(t), HttpTimeout: "15s"}
+
+	const workers = 16
+	results := make([]*Clients, workers)
+	var wg sync.WaitGroup
+	for i := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c, err := NewClients(context.Background(), cfg)
+			assert.NoError(t, err)
+			if err != nil {
+				return
+			}
+			_, err = c.GetVirtualNetworkClient()
+			assert.NoError(t, err)
+			_, err = c.GetComputeClient()
+			assert.NoError(t, err)
+			results[i] = c
+		}()
+	}
+	wg.Wait()
+
+	for _, c := range results {
+		assert.Same(t, results[0], c)
+	}
+}
+
+func TestNewClientsErrorNotCached(t *testing.T) {
+	cfg := &config.Config{HttpTimeout: "soon"}
+
+	_, err := NewClients(context.Background(), cfg)
+	require.Error(t, err)
+
+	key, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	assert.NotContains(t, cache, string(key))
+}
+
+func TestNewClientsTenancyOverride(t *testing.T) {
+	c, err := NewClients(context.Background(), &config.Config{
+		ConfigFilePath: writeTestOCIConfig(t),
+		TenancyId:      "ocid1.tenancy.oc1..other",
+	})
+	require.NoError(t, err)
+
+	tenancyId, err := c.GetConfigurationProvider().TenancyOCID()
+	require.NoError(t, err)
+	assert.Equal(t, "ocid1.tenancy.oc1..other", tenancyId)
+
+	// Requests are still signed as the configured user in its own tenancy
+	keyId, err := c.GetConfigurationProvider().KeyID()
+	require.NoError(t, err)
+	assert.Contains(t, keyId, "ocid1.tenancy.oc1..test/")
+}
+
+func TestNewClientsInvalidConfig(t *testing.T) {
+	_, err := NewClients(context.Background(), &config.Config{AuthMethod: config.AuthMethodInstancePrincipal, Profile: "DEFAULT"})
+	assert.ErrorContains(t, err, "does not read the OCI config file")
+}
+
+// writeTestOCIConfig writes an OCI config file with a throwaway key and returns its path.
+func writeTestOCIConfig(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyPath := filepath.Join(dir, "key.pem")
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	require.NoError(t, os.WriteFile(keyPath, keyPEM, 0o600))
+
+	configPath := filepath.Join(dir, "config")
+	contents := "[DEFAULT]\n" +
+		"user=ocid1.user.oc1..test\n" +
+		"fingerprint=aa:bb:cc:dd:ee:ff:11:22:33:44:55:66:77:88:99:00\n" +
+		"tenancy=ocid1.tenancy.oc1..test\n" +
+		"region=us-chicago-1\n" +
+		"key_file=" + keyPath + "\n"
+	require.NoError(t, os.WriteFile(configPath, []byte(contents), 0o600))
+	return configPath
+}
+
+type stubDispatcher struct{ resp *http.Response }
+
+func (s stubDispatcher) Do(req *http.Request) (*http.Response, error) { return s.resp, nil }
+
+func TestLoggingDispatcher(t *testing.T) {
+	var buf bytes.Buffer
+	recorder := httptest.NewRecorder()
+	recorder.Header().Set("opc-request-id", "req-123")
+	recorder.WriteHeader(http.StatusNotFound)
+
+	d := loggingDispatcher{
+		inner:  stubDispatcher{resp: recorder.Result()},
+		logger: slog.New(slog.NewJSONHandler(&buf, nil)),
+	}
+	ctx := WithOperation(context.Background(), "OCI::Core::Vcn", "ocid1.vcn..aaa")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://iaas.us-chicago-1.oraclecloud.com/20160918/vcns/ocid1.vcn..aaa", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Signature secret")
+
+	_, err = d.Do(req)
+	require.NoError(t, err)
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "GET", entry["method"])
+	assert.Equal(t, "/20160918/vcns/ocid1.vcn..aaa", entry["path"])
+	assert.Equal(t, "OCI::Core::Vcn", entry["resourceType"])
+	assert.Equal(t, "ocid1.vcn..aaa", entry["nativeID"])
+	assert.Equal(t, float64(404), entry["status"])
+	assert.Equal(t, "req-123", entry["opcRequestId"])
+	assert.NotContains(t, buf.String(), "secret")
+}
+
+func TestNewDebugLogger(t *testing.T) {
+	t.Setenv(debugEnv, "")
+	assert.Nil(t, newDebugLogger(&config.Config{}))
+	assert.NotNil(t, newDebugLogger(&config.Config{Debug: true}))
+
+	t.Setenv(debugEnv, "1")
+	assert.NotNil(t, newDebugLogger(&config.Config{}))
+}
+
+func TestRateLimitedDispatcher(t *testing.T) {
+	d := rateLimitedDispatcher{
+		inner:   stubDispatcher{resp: httptest.NewRecorder().Result()},
+		limiter: util.NewRateLimiter(20),
+	}
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		req, err := http.NewRequest(http.MethodGet, "https://iaas.us-chicago-1.oraclecloud.com/20160918/vcns", nil)
+		require.NoError(t, err)
+		_, err = d.Do(req)
+		require.NoError(t, err)
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestSharedLimiter(t *testing.T) {
+	assert.Equal(t, DefaultRequestsPerSecond, requestsPerSecond(&config.Config{}))
+	assert.Equal(t, 25, requestsPerSecond(&config.Config{MaxRequestsPerSecond: 25}))
+
+	assert.Same(t, sharedLimiter(25), sharedLimiter(25))
+	assert.NotSame(t, sharedLimiter(25), sharedLimiter(5))
+}
+
+func TestAdvertisedRequestsPerSecond(t *testing.T) {
+	limitersMu.Lock()
+	saved := limiters
+	limiters = map[int]*util.RateLimiter{}
+	limitersMu.Unlock()
+	t.Cleanup(func() {
+		limitersMu.Lock()
+		limiters = saved
+		limitersMu.Unlock()
+	})
+
+	t.Setenv(rateLimitEnv, "")
+	assert.Equal(t, DefaultRequestsPerSecond, AdvertisedRequestsPerSecond())
+
+	t.Setenv(rateLimitEnv, "4")
+	assert.Equal(t, 4, AdvertisedRequestsPerSecond())
+	assert.Equal(t, 4, requestsPerSecond(&config.Config{}))
+	assert.Equal(t, 25, requestsPerSecond(&config.Config{MaxRequestsPerSecond: 25}))
+
+	sharedLimiter(25)
+	sharedLimiter(15)
+	assert.Equal(t, 15, AdvertisedRequestsPerSecond())
+}
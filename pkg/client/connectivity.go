@@ -0,0 +1,84 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/identity"
+	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
+)
+
+// ConnectivityReport is the outcome of CheckConnectivity. On failure Error names
+// the step that failed; the fields resolved before it are still filled in.
+type ConnectivityReport struct {
+	OK          bool   `json:"ok"`
+	TenancyId   string `json:"tenancyId,omitempty"`
+	TenancyName string `json:"tenancyName,omitempty"`
+	Region      string `json:"region,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// CheckConnectivity checks that the credentials in cfg work by reading the
+// tenancy and the Object Storage namespace. Both are cheap reads that any
+// authenticated user may make, so the check changes nothing and a failure
+// points at authentication or the network rather than at missing policies.
+func CheckConnectivity(ctx context.Context, cfg *config.Config) *ConnectivityReport {
+	clients, err := NewClients(ctx, cfg)
+	if err != nil {
+		return &ConnectivityReport{Error: fmt.Sprintf("loading credentials: %v", err)}
+	}
+	return clients.checkConnectivity(ctx)
+}
+
+func (c *Clients) checkConnectivity(ctx context.Context) *ConnectivityReport {
+	report := &ConnectivityReport{}
+	fail := func(step string, err error) *ConnectivityReport {
+		report.Error = fmt.Sprintf("%s: %v", step, err)
+		return report
+	}
+
+	tenancyId, err := c.provider.TenancyOCID()
+	if err != nil {
+		return fail("resolving tenancy", err)
+	}
+	report.TenancyId = tenancyId
+	region, err := c.provider.Region()
+	if err != nil {
+		return fail("resolving region", err)
+	}
+	report.Region = region
+
+	identityClient, err := c.GetIdentityClient()
+	if err != nil {
+		return fail("creating Identity client", err)
+	}
+	tenancy, err := identityClient.GetTenancy(ctx, identity.GetTenancyRequest{TenancyId: common.String(tenancyId)})
+	if err != nil {
+		return fail("reading tenancy", err)
+	}
+	if tenancy.Name != nil {
+		report.TenancyName = *tenancy.Name
+	}
+
+	objectStorageClient, err := c.GetObjectStorageClient()
+	if err != nil {
+		return fail("creating ObjectStorage client", err)
+	}
+	namespace, err := objectStorageClient.GetNamespace(ctx, objectstorage.GetNamespaceRequest{})
+	if err != nil {
+		return fail("reading Object Storage namespace", err)
+	}
+	if namespace.Value != nil {
+		report.Namespace = *namespace.Value
+	}
+
+	report.OK = true
+	return report
+}
@@ -0,0 +1,109 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// redirectTransport sends every request to the test server, whatever OCI
+// endpoint the SDK addressed it to.
+type redirectTransport struct{ target *url.URL }
+
+func (r redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = r.target.Scheme
+	req.URL.Host = r.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func newConnectivityTestClients(t *testing.T, handler http.HandlerFunc) *Clients {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	target, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	return &Clients{
+		provider: common.NewRawConfigurationProvider(
+			"ocid1.tenancy.oc1..test",
+			"ocid1.user.oc1..test",
+			"us-chicago-1",
+			"aa:bb:cc:dd:ee:ff:11:22:33:44:55:66:77:88:99:00",
+			string(keyPEM),
+			nil,
+		),
+		httpClient: &http.Client{Transport: redirectTransport{target: target}},
+		retry:      newRetryPolicy(DefaultMaxRetryAttempts),
+	}
+}
+
+func TestCheckConnectivity(t *testing.T) {
+	t.Run("pass", func(t *testing.T) {
+		var methods []string
+		c := newConnectivityTestClients(t, func(w http.ResponseWriter, r *http.Request) {
+			methods = append(methods, r.Method)
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/20160918/tenancies/ocid1.tenancy.oc1..test":
+				fmt.Fprint(w, `{"id": "ocid1.tenancy.oc1..test", "name": "acme"}`)
+			case "/n", "/n/":
+				fmt.Fprint(w, `"acmens"`)
+			default:
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+				http.NotFound(w, r)
+			}
+		})
+
+		report := c.checkConnectivity(context.Background())
+		assert.Equal(t, &ConnectivityReport{
+			OK:          true,
+			TenancyId:   "ocid1.tenancy.oc1..test",
+			TenancyName: "acme",
+			Region:      "us-chicago-1",
+			Namespace:   "acmens",
+		}, report)
+		assert.Equal(t, []string{"GET", "GET"}, methods, "the check must only read")
+	})
+
+	t.Run("auth failure", func(t *testing.T) {
+		c := newConnectivityTestClients(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"code": "NotAuthenticated", "message": "The required information to complete authentication was not provided or was incorrect."}`)
+		})
+
+		report := c.checkConnectivity(context.Background())
+		assert.False(t, report.OK)
+		assert.Equal(t, "ocid1.tenancy.oc1..test", report.TenancyId)
+		assert.Equal(t, "us-chicago-1", report.Region)
+		assert.Empty(t, report.TenancyName)
+		assert.Contains(t, report.Error, "reading tenancy")
+		assert.Contains(t, report.Error, "NotAuthenticated")
+	})
+
+	t.Run("bad config", func(t *testing.T) {
+		report := CheckConnectivity(context.Background(), &config.Config{HttpTimeout: "soon"})
+		assert.False(t, report.OK)
+		assert.Contains(t, report.Error, "loading credentials")
+	})
+}
@@ -0,0 +1,72 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package client
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
+)
+
+// debugEnv turns on API call logging for every target, without editing them.
+const debugEnv = "FORMAE_OCI_DEBUG"
+
+type operationKey struct{}
+
+type operation struct {
+	resourceType string
+	nativeID     string
+}
+
+// WithOperation tags ctx with the resource an entrypoint is working on, so the
+// API calls made on its behalf can be attributed to it in debug logs.
+func WithOperation(ctx context.Context, resourceType, nativeID string) context.Context {
+	return context.WithValue(ctx, operationKey{}, operation{resourceType: resourceType, nativeID: nativeID})
+}
+
+// newDebugLogger returns the logger for API call logging, or nil when it is off.
+// It writes to stderr because stdout carries the plugin protocol.
+func newDebugLogger(cfg *config.Config) *slog.Logger {
+	if !cfg.Debug && os.Getenv(debugEnv) == "" {
+		return nil
+	}
+	return slog.New(slog.NewJSONHandler(os.Stderr, nil))
+}
+
+// loggingDispatcher logs one line per OCI API call. Only the method, path and
+// response metadata are logged; headers and bodies may hold credentials or data.
+type loggingDispatcher struct {
+	inner  common.HTTPRequestDispatcher
+	logger *slog.Logger
+}
+
+func (d loggingDispatcher) Do(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := d.inner.Do(req)
+
+	attrs := []any{
+		"method", req.Method,
+		"path", req.URL.Path,
+		"latency", time.Since(start),
+	}
+	if op, ok := req.Context().Value(operationKey{}).(operation); ok {
+		attrs = append(attrs, "resourceType", op.resourceType, "nativeID", op.nativeID)
+	}
+	if resp != nil {
+		attrs = append(attrs, "status", resp.StatusCode, "opcRequestId", resp.Header.Get("opc-request-id"))
+	}
+	if err != nil {
+		d.logger.Warn("OCI API call failed", append(attrs, "error", err)...)
+	} else {
+		d.logger.Info("OCI API call", attrs...)
+	}
+
+	return resp, err
+}
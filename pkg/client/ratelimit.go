@@ -0,0 +1,89 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package client
+
+import (
+	"maps"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+)
+
+// DefaultRequestsPerSecond is the OCI API call rate the plugin holds itself to
+// when neither the target nor the environment sets one.
+const DefaultRequestsPerSecond = 10
+
+// rateLimitEnv sets the rate for every target that doesn't set
+// MaxRequestsPerSecond itself. formae asks for the namespace rate limit before
+// it has handed the plugin any target, so this is also how to change what the
+// plugin advertises from the start.
+const rateLimitEnv = "FORMAE_OCI_MAX_REQUESTS_PER_SECOND"
+
+var (
+	limitersMu sync.Mutex
+	limiters   = map[int]*util.RateLimiter{}
+)
+
+// requestsPerSecond returns the target's MaxRequestsPerSecond, or the
+// environment's rate, or the default.
+func requestsPerSecond(cfg *config.Config) int {
+	if cfg.MaxRequestsPerSecond > 0 {
+		return cfg.MaxRequestsPerSecond
+	}
+	return environmentRequestsPerSecond()
+}
+
+func environmentRequestsPerSecond() int {
+	if perSecond, err := strconv.Atoi(os.Getenv(rateLimitEnv)); err == nil && perSecond > 0 {
+		return perSecond
+	}
+	return DefaultRequestsPerSecond
+}
+
+// AdvertisedRequestsPerSecond is the namespace rate limit the plugin reports to
+// formae: the lowest rate any target has been limited to so far, or, before
+// the first target, the environment's rate or the default.
+func AdvertisedRequestsPerSecond() int {
+	limitersMu.Lock()
+	defer limitersMu.Unlock()
+	if len(limiters) == 0 {
+		return environmentRequestsPerSecond()
+	}
+	return slices.Min(slices.Collect(maps.Keys(limiters)))
+}
+
+// sharedLimiter returns the process-wide limiter for the given rate. Targets
+// with the same rate share one, matching formae's namespace-scoped limit, so
+// the internal calls of concurrent operations can't burst past it together.
+func sharedLimiter(perSecond int) *util.RateLimiter {
+	limitersMu.Lock()
+	defer limitersMu.Unlock()
+	l, ok := limiters[perSecond]
+	if !ok {
+		l = util.NewRateLimiter(perSecond)
+		limiters[perSecond] = l
+	}
+	return l
+}
+
+// rateLimitedDispatcher holds every OCI API call, including the extra reads and
+// lookups an operation makes internally, to the limiter's rate.
+type rateLimitedDispatcher struct {
+	inner   common.HTTPRequestDispatcher
+	limiter *util.RateLimiter
+}
+
+func (d rateLimitedDispatcher) Do(req *http.Request) (*http.Response, error) {
+	if err := d.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return d.inner.Do(req)
+}
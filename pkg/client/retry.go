@@ -0,0 +1,86 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package client
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
+)
+
+// DefaultMaxRetryAttempts is how many times an OCI API call is tried when the
+// target doesn't set MaxRetryAttempts. It matches the SDK's own default.
+const DefaultMaxRetryAttempts = 8
+
+const (
+	// maxBackoff caps the exponential backoff between attempts, before jitter.
+	maxBackoff = 30 * time.Second
+	// maxRetryAfter caps how long a retry-after header can make a call wait.
+	maxRetryAfter = 2 * time.Minute
+)
+
+// retryAttempts returns the target's MaxRetryAttempts, or the default.
+func retryAttempts(cfg *config.Config) uint {
+	if cfg.MaxRetryAttempts > 0 {
+		return uint(cfg.MaxRetryAttempts)
+	}
+	return DefaultMaxRetryAttempts
+}
+
+// newRetryPolicy retries throttled (429) and failed (5xx other than 501) calls,
+// plus network errors and the 409 IncorrectState/LockConflict the SDK already
+// treats as transient. It waits as long as OCI's retry-after header asks, or
+// backs off exponentially with jitter when there is none. The SDK gives up
+// early rather than sleep past the context's deadline, returning the last error.
+//
+// The SDK's eventual consistency retries stay off: they retry 404 responses for
+// up to 4 minutes after any write, so a sync Read of a deleted resource would
+// hang instead of returning NotFound.
+func newRetryPolicy(attempts uint) common.RetryPolicy {
+	return common.NewRetryPolicy(attempts, common.DefaultShouldRetryOperation, nextRetryDuration)
+}
+
+// nextRetryDuration is how long to wait before the attempt after r.
+func nextRetryDuration(r common.OCIOperationResponse) time.Duration {
+	if d, ok := retryAfter(r); ok {
+		return d
+	}
+	backoff := time.Duration(math.Pow(2, float64(r.AttemptNumber))) * time.Second
+	if backoff > maxBackoff || backoff <= 0 {
+		backoff = maxBackoff
+	}
+	return backoff + time.Duration(rand.Int63n(int64(time.Second)))
+}
+
+// retryAfter reads the retry-after header of the response, given either in
+// seconds or as an HTTP date.
+func retryAfter(r common.OCIOperationResponse) (time.Duration, bool) {
+	if r.Response == nil {
+		return 0, false
+	}
+	resp := r.Response.HTTPResponse()
+	if resp == nil {
+		return 0, false
+	}
+	value := resp.Header.Get("retry-after")
+	if value == "" {
+		return 0, false
+	}
+
+	var d time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		d = time.Duration(seconds) * time.Second
+	} else if at, err := http.ParseTime(value); err == nil {
+		d = time.Until(at)
+	} else {
+		return 0, false
+	}
+	return min(max(d, 0), maxRetryAfter), true
+}
@@ -0,0 +1,145 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/identity"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryAttempts(t *testing.T) {
+	assert.Equal(t, uint(DefaultMaxRetryAttempts), retryAttempts(&config.Config{}))
+	assert.Equal(t, uint(3), retryAttempts(&config.Config{MaxRetryAttempts: 3}))
+}
+
+type retryTestResponse struct{ raw *http.Response }
+
+func (r retryTestResponse) HTTPResponse() *http.Response { return r.raw }
+
+func retryTestOperationResponse(attempt uint, retryAfter string) common.OCIOperationResponse {
+	raw := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	if retryAfter != "" {
+		raw.Header.Set("retry-after", retryAfter)
+	}
+	return common.OCIOperationResponse{Response: retryTestResponse{raw}, AttemptNumber: attempt}
+}
+
+func TestNextRetryDuration(t *testing.T) {
+	t.Run("retry-after seconds", func(t *testing.T) {
+		assert.Equal(t, 7*time.Second, nextRetryDuration(retryTestOperationResponse(1, "7")))
+	})
+
+	t.Run("retry-after date", func(t *testing.T) {
+		at := time.Now().Add(20 * time.Second).UTC().Format(http.TimeFormat)
+		d := nextRetryDuration(retryTestOperationResponse(1, at))
+		assert.Greater(t, d, 15*time.Second)
+		assert.LessOrEqual(t, d, 20*time.Second)
+	})
+
+	t.Run("retry-after capped", func(t *testing.T) {
+		assert.Equal(t, maxRetryAfter, nextRetryDuration(retryTestOperationResponse(1, "3600")))
+	})
+
+	t.Run("exponential backoff with jitter", func(t *testing.T) {
+		for attempt, base := range map[uint]time.Duration{1: 2 * time.Second, 3: 8 * time.Second, 10: maxBackoff} {
+			d := nextRetryDuration(retryTestOperationResponse(attempt, ""))
+			assert.GreaterOrEqual(t, d, base, "attempt %d", attempt)
+			assert.Less(t, d, base+time.Second, "attempt %d", attempt)
+		}
+	})
+
+	t.Run("unparseable retry-after falls back to backoff", func(t *testing.T) {
+		d := nextRetryDuration(retryTestOperationResponse(1, "soon"))
+		assert.GreaterOrEqual(t, d, 2*time.Second)
+	})
+
+	t.Run("no response", func(t *testing.T) {
+		d := nextRetryDuration(common.OCIOperationResponse{AttemptNumber: 1, Error: fmt.Errorf("connection reset")})
+		assert.GreaterOrEqual(t, d, 2*time.Second)
+	})
+}
+
+func TestClientsRetry(t *testing.T) {
+	getTenancy := func(ctx context.Context, c *Clients) error {
+		identityClient, err := c.GetIdentityClient()
+		require.NoError(t, err)
+		_, err = identityClient.GetTenancy(ctx, identity.GetTenancyRequest{TenancyId: common.String("ocid1.tenancy.oc1..test")})
+		return err
+	}
+
+	t.Run("throttled call succeeds after retry-after", func(t *testing.T) {
+		var calls atomic.Int32
+		c := newConnectivityTestClients(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if calls.Add(1) == 1 {
+				w.Header().Set("retry-after", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				fmt.Fprint(w, `{"code": "TooManyRequests", "message": "slow down"}`)
+				return
+			}
+			fmt.Fprint(w, `{"id": "ocid1.tenancy.oc1..test", "name": "acme"}`)
+		})
+
+		require.NoError(t, getTenancy(context.Background(), c))
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("gives up after MaxRetryAttempts", func(t *testing.T) {
+		var calls atomic.Int32
+		c := newConnectivityTestClients(t, func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("retry-after", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"code": "ServiceUnavailable", "message": "try later"}`)
+		})
+		c.retry = newRetryPolicy(retryAttempts(&config.Config{MaxRetryAttempts: 3}))
+
+		err := getTenancy(context.Background(), c)
+		require.Error(t, err)
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("client errors are not retried", func(t *testing.T) {
+		var calls atomic.Int32
+		c := newConnectivityTestClients(t, func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"code": "InvalidParameter", "message": "bad"}`)
+		})
+
+		require.Error(t, getTenancy(context.Background(), c))
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("does not wait past the deadline", func(t *testing.T) {
+		var calls atomic.Int32
+		c := newConnectivityTestClients(t, func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("retry-after", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, `{"code": "TooManyRequests", "message": "slow down"}`)
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		start := time.Now()
+		require.Error(t, getTenancy(ctx, c))
+		assert.Less(t, time.Since(start), time.Second)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
@@ -7,18 +7,203 @@ package config
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/common/auth"
+)
+
+// Auth methods accepted in AuthMethod
+const (
+	AuthMethodAPIKey            = "api_key"
+	AuthMethodInstancePrincipal = "instance_principal"
+	AuthMethodResourcePrincipal = "resource_principal"
+)
+
+// Defaults for OperationTimeout and StatusTimeout
+const (
+	DefaultOperationTimeout = 10 * time.Minute
+	DefaultStatusTimeout    = 30 * time.Minute
+)
+
+// Defaults for DeleteConfirmAttempts and DeleteConfirmInterval
+const (
+	DefaultDeleteConfirmAttempts = 2
+	DefaultDeleteConfirmInterval = 2 * time.Second
 )
 
 type Config struct {
 	Region         string `json:"Region"`
 	Profile        string `json:"Profile"`
 	ConfigFilePath string `json:"ConfigFilePath"`
+
+	// AuthMethod picks how the plugin authenticates: "api_key" (the default)
+	// reads Profile from the OCI config file, "instance_principal" and
+	// "resource_principal" use the identity of the OCI host the plugin runs on.
+	AuthMethod string `json:"AuthMethod"`
+
+	// TenancyId overrides the tenancy the credentials report, as the root for
+	// compartment paths and compartment discovery. Requests are still signed
+	// with the credentials' own tenancy.
+	TenancyId string `json:"TenancyId"`
+
+	// DefaultCompartmentId scopes List calls that don't pass a CompartmentId.
+	// Compartment discovery falls back to the tenancy when it is empty too.
+	DefaultCompartmentId string `json:"DefaultCompartmentId"`
+
+	// ListPageSize is the Limit sent with every OCI list call. Zero means the
+	// OCI maximum of 1000; smaller pages trade more requests for smaller responses.
+	ListPageSize int `json:"ListPageSize"`
+
+	// AdoptExisting makes Bucket and Compartment creates that collide with an
+	// existing resource of the same name take over that resource instead of
+	// failing. Unset, Compartments are adopted as they always were and Buckets
+	// are not; see AdoptsExisting.
+	AdoptExisting *bool `json:"AdoptExisting"`
+
+	// EmptyBeforeDelete makes Bucket deletes remove every object (and object
+	// version) and abort unfinished multipart uploads first. Off by default; a
+	// non-empty bucket then fails to delete.
+	EmptyBeforeDelete bool `json:"EmptyBeforeDelete"`
+
+	// SkipSubnetOverlapCheck stops Subnet creates from listing the VCN's subnets
+	// to catch an overlapping CidrBlock before OCI does. The check that the block
+	// lies within the VCN still runs.
+	SkipSubnetOverlapCheck bool `json:"SkipSubnetOverlapCheck"`
+
+	// SkipShapeValidation stops Instance and NodePool creates from listing the
+	// compartment's shapes to check a flexible shape's OCPUs and memory before
+	// OCI does.
+	SkipShapeValidation bool `json:"SkipShapeValidation"`
+
+	// PreserveBootVolume makes Instance deletes keep the boot volume instead of
+	// terminating it with the instance. Off by default.
+	PreserveBootVolume bool `json:"PreserveBootVolume"`
+
+	// NodeEvictionGraceDuration overrides how long NodePool deletes wait for pods
+	// to drain, as an ISO 8601 duration from "PT0M" to "PT60M" (OCI's default).
+	// ForceNodePoolDeletion deletes the nodes anyway once that grace period ends.
+	NodeEvictionGraceDuration string `json:"NodeEvictionGraceDuration"`
+	ForceNodePoolDeletion     bool   `json:"ForceNodePoolDeletion"`
+
+	// DefaultFreeformTags and DefaultDefinedTags are added to every resource on
+	// Create and Update unless the resource sets the same key itself. Read hides
+	// them again so they never show up as drift.
+	DefaultFreeformTags []FreeformTag `json:"DefaultFreeformTags"`
+	DefaultDefinedTags  []DefinedTag  `json:"DefaultDefinedTags"`
+
+	// IgnoredTagNamespaces lists defined-tag namespaces that Read leaves out,
+	// on top of the always-ignored Oracle-Tags. Use it for namespaces filled in
+	// by compartment tag defaults, which would otherwise show up as drift.
+	IgnoredTagNamespaces []string `json:"IgnoredTagNamespaces"`
+
+	// KeepHiddenTags makes Read keep every defined-tag namespace, including the
+	// ones HiddenTagNamespaces lists. It isn't a target option: an update that
+	// resends DefinedTags sets it to read back the hidden tags, which OCI would
+	// otherwise drop along with the rest of the map.
+	KeepHiddenTags bool `json:"KeepHiddenTags,omitempty"`
+
+	// NodePoolMinSize and NodePoolMaxSize bound NodePool NodeConfigDetails.size on
+	// create and update. Zero keeps the defaults: 0 (scale to zero allowed) and 1000.
+	NodePoolMinSize int `json:"NodePoolMinSize"`
+	NodePoolMaxSize int `json:"NodePoolMaxSize"`
+
+	// PrivateKubeconfig makes Cluster Reads generate Kubeconfig against the
+	// private API endpoint even when the cluster also has a public one. Clusters
+	// without a public endpoint always get the private one.
+	PrivateKubeconfig bool `json:"PrivateKubeconfig"`
+
+	// BastionId names an OCI Bastion that reaches private cluster endpoints.
+	// Cluster Reads whose Kubeconfig uses the private endpoint then add
+	// BastionPortForward, the port-forwarding session to open through it.
+	BastionId string `json:"BastionId"`
+
+	// BucketApproximateStats makes Bucket Reads ask GetBucket for the
+	// bucket's approximate object count and size. OCI computes them on demand,
+	// which makes the call slightly slower, so they are off by default.
+	BucketApproximateStats bool `json:"BucketApproximateStats"`
+
+	// HttpTimeout bounds each OCI API request and ConnectTimeout the TCP dial,
+	// both as Go durations ("30s", "2m"). Empty keeps the SDK defaults.
+	HttpTimeout    string `json:"HttpTimeout"`
+	ConnectTimeout string `json:"ConnectTimeout"`
+
+	// HttpsProxy routes OCI API traffic through the given proxy URL. Empty falls
+	// back to the HTTPS_PROXY/NO_PROXY environment variables.
+	HttpsProxy string `json:"HttpsProxy"`
+
+	// OperationTimeout bounds a whole Create, Update, Delete, Read or List call,
+	// every OCI request and wait inside it included, and StatusTimeout a whole
+	// Status poll. Both are Go durations; empty keeps DefaultOperationTimeout
+	// and DefaultStatusTimeout.
+	OperationTimeout string `json:"OperationTimeout"`
+	StatusTimeout    string `json:"StatusTimeout"`
+
+	// DeleteConfirmAttempts is how many Reads, DeleteConfirmInterval apart, must
+	// find a resource gone after a synchronous Delete before it reports success.
+	// Zero keeps DefaultDeleteConfirmAttempts and a negative value skips the
+	// check. The interval is a Go duration; empty keeps DefaultDeleteConfirmInterval.
+	// The Reads count against OperationTimeout.
+	DeleteConfirmAttempts int    `json:"DeleteConfirmAttempts"`
+	DeleteConfirmInterval string `json:"DeleteConfirmInterval"`
+
+	// Debug logs every OCI API call to stderr. Setting FORMAE_OCI_DEBUG in the
+	// plugin's environment does the same without touching the target.
+	Debug bool `json:"Debug"`
+
+	// OperationMetrics writes one JSON line per provisioner operation to stderr
+	// with its resource type, outcome and duration. Off by default.
+	OperationMetrics bool `json:"OperationMetrics"`
+
+	// MaxRequestsPerSecond caps the OCI API calls the plugin makes, including the
+	// extra calls inside a single operation. Zero falls back to the
+	// FORMAE_OCI_MAX_REQUESTS_PER_SECOND environment variable, then to 10.
+	MaxRequestsPerSecond int `json:"MaxRequestsPerSecond"`
+
+	// DiscoveryReadConcurrency is how many reads a batch Read runs at once during
+	// discovery. Zero or one reads serially. Their OCI calls still count against
+	// MaxRequestsPerSecond.
+	DiscoveryReadConcurrency int `json:"DiscoveryReadConcurrency"`
+
+	// MaxRetryAttempts is how many times an OCI API call is tried in all when
+	// OCI throttles it (429) or fails it with a 5xx. Zero keeps the default of
+	// 8; one turns retries off.
+	MaxRetryAttempts int `json:"MaxRetryAttempts"`
+}
+
+// FreeformTag mirrors the FreeformTag class in oci.pkl
+type FreeformTag struct {
+	Key   string `json:"Key"`
+	Value string `json:"Value"`
+}
+
+// DefinedTag mirrors the DefinedTag class in oci.pkl
+type DefinedTag struct {
+	Namespace string `json:"Namespace"`
+	Key       string `json:"Key"`
+	Value     any    `json:"Value"`
 }
 
 // ToConfigProvider creates an OCI ConfigurationProvider from the config
 func (c *Config) ToConfigProvider(ctx context.Context) (common.ConfigurationProvider, error) {
+	provider, err := c.authProvider()
+	if err != nil {
+		return nil, err
+	}
+	if c.TenancyId != "" {
+		return tenancyOverride{ConfigurationProvider: provider, tenancyId: c.TenancyId}, nil
+	}
+	return provider, nil
+}
+
+func (c *Config) authProvider() (common.ConfigurationProvider, error) {
+	switch c.AuthMethod {
+	case AuthMethodInstancePrincipal:
+		return auth.InstancePrincipalConfigurationProvider()
+	case AuthMethodResourcePrincipal:
+		return auth.ResourcePrincipalConfigurationProvider()
+	}
+
 	if c.ConfigFilePath == "" && c.Profile == "" {
 		return common.DefaultConfigProvider(), nil
 	}
@@ -37,6 +222,70 @@ func (c *Config) ToConfigProvider(ctx context.Context) (common.ConfigurationProv
 	return common.ConfigurationProviderFromFileWithProfile(configPath, c.Profile, "")
 }
 
+// tenancyOverride reports TenancyId as the tenancy. The embedded provider's own
+// methods, KeyID included, keep using the tenancy of the credentials.
+type tenancyOverride struct {
+	common.ConfigurationProvider
+	tenancyId string
+}
+
+func (p tenancyOverride) TenancyOCID() (string, error) {
+	return p.tenancyId, nil
+}
+
+// OperationDeadline returns how long a Create, Update, Delete, Read or List
+// call may run.
+func (c *Config) OperationDeadline() time.Duration {
+	return durationOr(c.OperationTimeout, DefaultOperationTimeout)
+}
+
+// StatusDeadline returns how long a Status poll may run.
+func (c *Config) StatusDeadline() time.Duration {
+	return durationOr(c.StatusTimeout, DefaultStatusTimeout)
+}
+
+// OracleTagsNamespace holds the CreatedBy/CreatedOn tags OCI adds to every
+// resource. Read always leaves it out.
+const OracleTagsNamespace = "Oracle-Tags"
+
+// HiddenTagNamespaces returns the defined-tag namespaces Read leaves out:
+// Oracle-Tags and IgnoredTagNamespaces, or none when KeepHiddenTags is set.
+func (c *Config) HiddenTagNamespaces() []string {
+	if c.KeepHiddenTags {
+		return nil
+	}
+	return append([]string{OracleTagsNamespace}, c.IgnoredTagNamespaces...)
+}
+
+// AdoptsExisting reports whether a create that collides with an existing
+// resource of the same name takes it over, falling back to byDefault when
+// AdoptExisting is unset.
+func (c *Config) AdoptsExisting(byDefault bool) bool {
+	if c.AdoptExisting == nil {
+		return byDefault
+	}
+	return *c.AdoptExisting
+}
+
+// DeleteConfirmation returns how many Reads confirm a synchronous Delete, and
+// how long to wait before each.
+func (c *Config) DeleteConfirmation() (attempts int, interval time.Duration) {
+	attempts = c.DeleteConfirmAttempts
+	if attempts == 0 {
+		attempts = DefaultDeleteConfirmAttempts
+	}
+	return max(attempts, 0), durationOr(c.DeleteConfirmInterval, DefaultDeleteConfirmInterval)
+}
+
+// durationOr parses value as a Go duration, falling back to def when it is
+// unset or not a positive duration. Validate reports the latter.
+func durationOr(value string, def time.Duration) time.Duration {
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return d
+	}
+	return def
+}
+
 // FromTargetConfig extracts Config from raw JSON config
 func FromTargetConfig(targetConfig json.RawMessage) *Config {
 	if targetConfig == nil {
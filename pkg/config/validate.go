@@ -0,0 +1,116 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxListPageSize is the largest Limit OCI list APIs accept.
+const maxListPageSize = 1000
+
+var nodeEvictionGraceDurationPattern = regexp.MustCompile(`^PT([0-9]+)M$`)
+
+// Validate reports every setting in c that can't work, each with what to
+// change, joined into one error. Settings left unset always pass: they keep
+// their defaults.
+func (c *Config) Validate() error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	switch c.AuthMethod {
+	case "", AuthMethodAPIKey:
+	case AuthMethodInstancePrincipal, AuthMethodResourcePrincipal:
+		if c.Profile != "" || c.ConfigFilePath != "" {
+			fail("AuthMethod %s does not read the OCI config file: remove Profile and ConfigFilePath, or use AuthMethod %s", c.AuthMethod, AuthMethodAPIKey)
+		}
+	default:
+		fail("unknown AuthMethod %q: use %s, %s or %s", c.AuthMethod, AuthMethodAPIKey, AuthMethodInstancePrincipal, AuthMethodResourcePrincipal)
+	}
+
+	if c.TenancyId != "" && !strings.HasPrefix(c.TenancyId, "ocid1.tenancy.") {
+		fail("TenancyId %q is not a tenancy OCID (ocid1.tenancy...)", c.TenancyId)
+	}
+
+	if c.ListPageSize < 0 || c.ListPageSize > maxListPageSize {
+		fail("ListPageSize %d is out of range: use 1 to %d, or leave it unset for %d", c.ListPageSize, maxListPageSize, maxListPageSize)
+	}
+
+	if c.NodeEvictionGraceDuration != "" {
+		m := nodeEvictionGraceDurationPattern.FindStringSubmatch(c.NodeEvictionGraceDuration)
+		if m == nil {
+			fail("NodeEvictionGraceDuration %q is not an ISO 8601 duration in minutes: use \"PT0M\" to \"PT60M\"", c.NodeEvictionGraceDuration)
+		} else if minutes, _ := strconv.Atoi(m[1]); minutes > 60 {
+			fail("NodeEvictionGraceDuration %q is longer than OKE allows: use \"PT0M\" to \"PT60M\"", c.NodeEvictionGraceDuration)
+		}
+	}
+
+	if c.NodePoolMinSize < 0 || c.NodePoolMaxSize < 0 {
+		fail("NodePoolMinSize and NodePoolMaxSize cannot be negative")
+	} else if c.NodePoolMaxSize > 0 && c.NodePoolMinSize > c.NodePoolMaxSize {
+		fail("NodePoolMinSize %d is larger than NodePoolMaxSize %d", c.NodePoolMinSize, c.NodePoolMaxSize)
+	}
+
+	if c.BastionId != "" && !strings.HasPrefix(c.BastionId, "ocid1.bastion.") {
+		fail("BastionId %q is not a bastion OCID (ocid1.bastion...)", c.BastionId)
+	}
+
+	for _, setting := range []struct{ name, value, unset string }{
+		{"HttpTimeout", c.HttpTimeout, "the SDK default"},
+		{"ConnectTimeout", c.ConnectTimeout, "the SDK default"},
+		{"OperationTimeout", c.OperationTimeout, DefaultOperationTimeout.String()},
+		{"StatusTimeout", c.StatusTimeout, DefaultStatusTimeout.String()},
+		{"DeleteConfirmInterval", c.DeleteConfirmInterval, DefaultDeleteConfirmInterval.String()},
+	} {
+		if setting.value == "" {
+			continue
+		}
+		if d, err := time.ParseDuration(setting.value); err != nil {
+			fail("%s %q is not a Go duration such as \"30s\" or \"2m\"", setting.name, setting.value)
+		} else if d <= 0 {
+			fail("%s %q must be positive, or left unset for %s", setting.name, setting.value, setting.unset)
+		}
+	}
+
+	if c.HttpsProxy != "" {
+		if proxyURL, err := url.Parse(c.HttpsProxy); err != nil || proxyURL.Host == "" {
+			fail("HttpsProxy %q is not a proxy URL such as \"http://proxy.corp:3128\"", c.HttpsProxy)
+		}
+	}
+
+	if c.MaxRequestsPerSecond < 0 {
+		fail("MaxRequestsPerSecond %d cannot be negative: leave it unset for the default", c.MaxRequestsPerSecond)
+	}
+	if c.DiscoveryReadConcurrency < 0 {
+		fail("DiscoveryReadConcurrency %d cannot be negative: use 1 to read serially", c.DiscoveryReadConcurrency)
+	}
+	if c.MaxRetryAttempts < 0 {
+		fail("MaxRetryAttempts %d cannot be negative: use 1 to turn retries off", c.MaxRetryAttempts)
+	}
+
+	for i, tag := range c.DefaultFreeformTags {
+		if tag.Key == "" {
+			fail("DefaultFreeformTags[%d] has no Key", i)
+		}
+	}
+	for i, tag := range c.DefaultDefinedTags {
+		if tag.Namespace == "" || tag.Key == "" {
+			fail("DefaultDefinedTags[%d] needs both a Namespace and a Key", i)
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid OCI target config: %w", errors.Join(errs...))
+}
@@ -0,0 +1,128 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr string
+	}{
+		{name: "empty", config: Config{}},
+		{name: "api key with profile", config: Config{AuthMethod: AuthMethodAPIKey, Profile: "DEFAULT", ConfigFilePath: "/home/me/.oci/config"}},
+		{name: "instance principal", config: Config{AuthMethod: AuthMethodInstancePrincipal, Region: "us-chicago-1"}},
+		{name: "resource principal", config: Config{AuthMethod: AuthMethodResourcePrincipal}},
+		{
+			name:    "instance principal with profile",
+			config:  Config{AuthMethod: AuthMethodInstancePrincipal, Profile: "DEFAULT"},
+			wantErr: "AuthMethod instance_principal does not read the OCI config file",
+		},
+		{
+			name:    "resource principal with config file",
+			config:  Config{AuthMethod: AuthMethodResourcePrincipal, ConfigFilePath: "/home/me/.oci/config"},
+			wantErr: "AuthMethod resource_principal does not read the OCI config file",
+		},
+		{name: "unknown auth method", config: Config{AuthMethod: "password"}, wantErr: `unknown AuthMethod "password"`},
+		{name: "tenancy override", config: Config{TenancyId: "ocid1.tenancy.oc1..aaa"}},
+		{name: "tenancy override not a tenancy", config: Config{TenancyId: "ocid1.compartment.oc1..aaa"}, wantErr: "is not a tenancy OCID"},
+		{name: "page size", config: Config{ListPageSize: 100}},
+		{name: "page size too large", config: Config{ListPageSize: 5000}, wantErr: "ListPageSize 5000 is out of range"},
+		{name: "page size negative", config: Config{ListPageSize: -1}, wantErr: "ListPageSize -1 is out of range"},
+		{name: "eviction grace", config: Config{NodeEvictionGraceDuration: "PT0M"}},
+		{name: "eviction grace in seconds", config: Config{NodeEvictionGraceDuration: "PT30S"}, wantErr: "not an ISO 8601 duration in minutes"},
+		{name: "eviction grace too long", config: Config{NodeEvictionGraceDuration: "PT90M"}, wantErr: "longer than OKE allows"},
+		{name: "node pool bounds", config: Config{NodePoolMinSize: 1, NodePoolMaxSize: 10}},
+		{name: "node pool min only", config: Config{NodePoolMinSize: 3}},
+		{name: "node pool bounds crossed", config: Config{NodePoolMinSize: 5, NodePoolMaxSize: 2}, wantErr: "NodePoolMinSize 5 is larger than NodePoolMaxSize 2"},
+		{name: "bastion", config: Config{PrivateKubeconfig: true, BastionId: "ocid1.bastion.oc1.phx.aaa"}},
+		{name: "bastion not a bastion", config: Config{BastionId: "ocid1.instance.oc1.phx.aaa"}, wantErr: "is not a bastion OCID"},
+		{name: "timeouts", config: Config{HttpTimeout: "30s", ConnectTimeout: "5s"}},
+		{name: "timeout not a duration", config: Config{HttpTimeout: "soon"}, wantErr: `HttpTimeout "soon" is not a Go duration`},
+		{name: "delete confirmation", config: Config{DeleteConfirmAttempts: 3, DeleteConfirmInterval: "1s"}},
+		{name: "delete confirm interval not positive", config: Config{DeleteConfirmInterval: "0s"}, wantErr: `DeleteConfirmInterval "0s" must be positive`},
+		{name: "timeout zero", config: Config{ConnectTimeout: "0s"}, wantErr: `ConnectTimeout "0s" must be positive`},
+		{name: "operation timeouts", config: Config{OperationTimeout: "5m", StatusTimeout: "1h"}},
+		{name: "operation timeout not a duration", config: Config{OperationTimeout: "10"}, wantErr: `OperationTimeout "10" is not a Go duration`},
+		{name: "status timeout negative", config: Config{StatusTimeout: "-1m"}, wantErr: `StatusTimeout "-1m" must be positive, or left unset for 30m0s`},
+		{name: "proxy", config: Config{HttpsProxy: "http://proxy.corp:3128"}},
+		{name: "proxy not a url", config: Config{HttpsProxy: "proxy.corp"}, wantErr: `HttpsProxy "proxy.corp" is not a proxy URL`},
+		{name: "negative rate limit", config: Config{MaxRequestsPerSecond: -5}, wantErr: "MaxRequestsPerSecond -5 cannot be negative"},
+		{name: "negative concurrency", config: Config{DiscoveryReadConcurrency: -1}, wantErr: "DiscoveryReadConcurrency -1 cannot be negative"},
+		{name: "negative retry attempts", config: Config{MaxRetryAttempts: -1}, wantErr: "MaxRetryAttempts -1 cannot be negative"},
+		{
+			name: "default tags",
+			config: Config{
+				DefaultFreeformTags: []FreeformTag{{Key: "managed-by", Value: "formae"}},
+				DefaultDefinedTags:  []DefinedTag{{Namespace: "Operations", Key: "CostCenter", Value: "42"}},
+			},
+		},
+		{name: "freeform tag without key", config: Config{DefaultFreeformTags: []FreeformTag{{Value: "formae"}}}, wantErr: "DefaultFreeformTags[0] has no Key"},
+		{name: "defined tag without namespace", config: Config{DefaultDefinedTags: []DefinedTag{{Key: "CostCenter"}}}, wantErr: "DefaultDefinedTags[0] needs both a Namespace and a Key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestValidate_ReportsEveryProblem(t *testing.T) {
+	err := (&Config{AuthMethod: "password", ListPageSize: 5000, HttpTimeout: "soon"}).Validate()
+	assert.ErrorContains(t, err, "unknown AuthMethod")
+	assert.ErrorContains(t, err, "ListPageSize 5000")
+	assert.ErrorContains(t, err, "HttpTimeout")
+}
+
+func TestFromTargetConfig_IgnoresUnknownFields(t *testing.T) {
+	cfg := FromTargetConfig([]byte(`{"Type": "OCI", "Region": "us-chicago-1", "SomeFutureSetting": true}`))
+	assert.Equal(t, "us-chicago-1", cfg.Region)
+	assert.Empty(t, cfg.AuthMethod)
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestOperationDeadlines(t *testing.T) {
+	cfg := FromTargetConfig([]byte(`{"OperationTimeout": "90s"}`))
+	assert.Equal(t, 90*time.Second, cfg.OperationDeadline())
+	assert.Equal(t, DefaultStatusTimeout, cfg.StatusDeadline())
+
+	// Invalid values fall back; Validate is what reports them
+	cfg = &Config{OperationTimeout: "0s", StatusTimeout: "later"}
+	assert.Equal(t, DefaultOperationTimeout, cfg.OperationDeadline())
+	assert.Equal(t, DefaultStatusTimeout, cfg.StatusDeadline())
+}
+
+func TestDeleteConfirmation(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       Config
+		wantAttempts int
+		wantInterval time.Duration
+	}{
+		{name: "defaults", wantAttempts: DefaultDeleteConfirmAttempts, wantInterval: DefaultDeleteConfirmInterval},
+		{name: "set", config: Config{DeleteConfirmAttempts: 5, DeleteConfirmInterval: "500ms"}, wantAttempts: 5, wantInterval: 500 * time.Millisecond},
+		{name: "off", config: Config{DeleteConfirmAttempts: -1}, wantAttempts: 0, wantInterval: DefaultDeleteConfirmInterval},
+		{name: "invalid interval", config: Config{DeleteConfirmInterval: "soon"}, wantAttempts: DefaultDeleteConfirmAttempts, wantInterval: DefaultDeleteConfirmInterval},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts, interval := tt.config.DeleteConfirmation()
+			assert.Equal(t, tt.wantAttempts, attempts)
+			assert.Equal(t, tt.wantInterval, interval)
+		})
+	}
+}
@@ -0,0 +1,733 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package autoscaling
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/oracle/oci-go-sdk/v65/autoscaling"
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+const (
+	policyTypeThreshold = "threshold"
+	policyTypeScheduled = "scheduled"
+
+	resourceTypeInstancePool = "instancePool"
+	scheduleTypeCron         = "cron"
+)
+
+type AutoScalingConfigurationProvisioner struct {
+	clients *client.Clients
+	svc     *autoscaling.AutoScalingClient // nil until first use; injected in tests
+}
+
+var (
+	_ provisioner.Provisioner = &AutoScalingConfigurationProvisioner{}
+	_ provisioner.Immutable   = &AutoScalingConfigurationProvisioner{}
+)
+
+func init() {
+	provisioner.Register("OCI::AutoScaling::AutoScalingConfiguration", NewAutoScalingConfigurationProvisioner)
+	provisioner.DependsOn("OCI::AutoScaling::AutoScalingConfiguration", "OCI::Identity::Compartment")
+}
+
+func NewAutoScalingConfigurationProvisioner(clients *client.Clients) provisioner.Provisioner {
+	return &AutoScalingConfigurationProvisioner{clients: clients}
+}
+
+// NewAutoScalingConfigurationProvisionerWithSvc constructs a provisioner with a pre-built SDK client,
+// for use in tests that point the client at an httptest server.
+func NewAutoScalingConfigurationProvisionerWithSvc(svc *autoscaling.AutoScalingClient) *AutoScalingConfigurationProvisioner {
+	return &AutoScalingConfigurationProvisioner{svc: svc}
+}
+
+// ImmutableFields lists the instance pool being scaled, which a configuration
+// can't be moved to. Policies and CompartmentId are changed in place; see Update.
+func (p *AutoScalingConfigurationProvisioner) ImmutableFields() []string {
+	return []string{"Resource"}
+}
+
+func (p *AutoScalingConfigurationProvisioner) getSvc() (*autoscaling.AutoScalingClient, error) {
+	if p.svc != nil {
+		return p.svc, nil
+	}
+	return p.clients.GetAutoScalingClient()
+}
+
+func (p *AutoScalingConfigurationProvisioner) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get AutoScaling client: %w", err)
+	}
+
+	var props map[string]any
+	if err := json.Unmarshal(request.Properties, &props); err != nil {
+		return nil, fmt.Errorf("failed to parse properties: %w", err)
+	}
+
+	compartmentId, ok := util.ExtractString(props, "CompartmentId")
+	if !ok {
+		return nil, fmt.Errorf("CompartmentId is required")
+	}
+	target, err := parseAutoScalingResource(props)
+	if err != nil {
+		return nil, err
+	}
+	policies, err := parseAutoScalingPolicies(props)
+	if err != nil {
+		return nil, err
+	}
+
+	createDetails := autoscaling.CreateAutoScalingConfigurationDetails{
+		CompartmentId: common.String(compartmentId),
+		Resource:      target,
+		Policies:      policies,
+	}
+	if displayName, ok := util.ExtractString(props, "DisplayName"); ok {
+		createDetails.DisplayName = common.String(displayName)
+	}
+	if coolDown, ok := extractInt(props, "CoolDownInSeconds"); ok {
+		createDetails.CoolDownInSeconds = common.Int(coolDown)
+	}
+	if enabled, ok := util.ExtractBool(props, "IsEnabled"); ok {
+		createDetails.IsEnabled = common.Bool(enabled)
+	}
+	if freeformTags, ok := util.ExtractFreeformTags(props, "FreeformTags"); ok {
+		createDetails.FreeformTags = freeformTags
+	}
+	if definedTags, ok := util.ExtractDefinedTags(props, "DefinedTags"); ok {
+		createDetails.DefinedTags = definedTags
+	}
+
+	resp, err := svc.CreateAutoScalingConfiguration(ctx, autoscaling.CreateAutoScalingConfigurationRequest{
+		OpcRetryToken:                         common.String(util.CreateRetryToken(request)),
+		CreateAutoScalingConfigurationDetails: createDetails,
+	})
+	if err != nil {
+		if result, handleErr := util.HandleCreateError(err, "OCI::AutoScaling::AutoScalingConfiguration", "OCI::AutoScaling::AutoScalingConfiguration"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to create AutoScalingConfiguration: %w", err)
+	}
+
+	// Auto scaling configurations have no lifecycle state; they exist once created
+	return &resource.CreateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationCreate,
+			OperationStatus: resource.OperationStatusSuccess,
+			NativeID:        *resp.Id,
+		},
+	}, nil
+}
+
+func (p *AutoScalingConfigurationProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get AutoScaling client: %w", err)
+	}
+
+	resp, err := svc.GetAutoScalingConfiguration(ctx, autoscaling.GetAutoScalingConfigurationRequest{
+		AutoScalingConfigurationId: common.String(request.NativeID),
+	})
+	if err != nil {
+		if util.IsNotFound(err) {
+			return &resource.ReadResult{
+				ResourceType: "OCI::AutoScaling::AutoScalingConfiguration",
+				ErrorCode:    resource.OperationErrorCodeNotFound,
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to read AutoScalingConfiguration: %w", err)
+	}
+
+	properties := buildAutoScalingConfigurationProperties(resp.AutoScalingConfiguration, config.FromTargetConfig(request.TargetConfig).HiddenTagNamespaces())
+
+	propBytes, err := json.Marshal(properties)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal AutoScalingConfiguration properties: %w", err)
+	}
+
+	return &resource.ReadResult{
+		ResourceType: "OCI::AutoScaling::AutoScalingConfiguration",
+		Properties:   string(propBytes),
+	}, nil
+}
+
+func (p *AutoScalingConfigurationProvisioner) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get AutoScaling client: %w", err)
+	}
+
+	props, err := util.ApplyPatchDocument(ctx, request, p.Read)
+	if err != nil {
+		return nil, err
+	}
+
+	var policies []autoscaling.CreateAutoScalingPolicyDetails
+	if _, ok := props["Policies"]; ok {
+		if policies, err = parseAutoScalingPolicies(props); err != nil {
+			return nil, err
+		}
+	}
+
+	current, err := svc.GetAutoScalingConfiguration(ctx, autoscaling.GetAutoScalingConfigurationRequest{
+		AutoScalingConfigurationId: common.String(request.NativeID),
+	})
+	if err != nil {
+		if result, handleErr := util.HandleUpdateError(err, "OCI::AutoScaling::AutoScalingConfiguration", request.NativeID, "OCI::AutoScaling::AutoScalingConfiguration"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to read AutoScalingConfiguration before update: %w", err)
+	}
+
+	updateDetails := autoscaling.UpdateAutoScalingConfigurationDetails{}
+	if displayName, ok := util.ExtractString(props, "DisplayName"); ok {
+		updateDetails.DisplayName = common.String(displayName)
+	}
+	if coolDown, ok := extractInt(props, "CoolDownInSeconds"); ok {
+		updateDetails.CoolDownInSeconds = common.Int(coolDown)
+	}
+	if enabled, ok := util.ExtractBool(props, "IsEnabled"); ok {
+		updateDetails.IsEnabled = common.Bool(enabled)
+	}
+	if freeformTags, ok := util.ExtractFreeformTags(props, "FreeformTags"); ok {
+		updateDetails.FreeformTags = freeformTags
+	}
+	if definedTags, ok := util.ExtractDefinedTags(props, "DefinedTags"); ok {
+		updateDetails.DefinedTags = definedTags
+	}
+
+	resp, err := svc.UpdateAutoScalingConfiguration(ctx, autoscaling.UpdateAutoScalingConfigurationRequest{
+		AutoScalingConfigurationId:            common.String(request.NativeID),
+		UpdateAutoScalingConfigurationDetails: updateDetails,
+	})
+	if err != nil {
+		if result, handleErr := util.HandleUpdateError(err, "OCI::AutoScaling::AutoScalingConfiguration", request.NativeID, "OCI::AutoScaling::AutoScalingConfiguration"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to update AutoScalingConfiguration: %w", err)
+	}
+
+	if policies != nil {
+		if err := syncAutoScalingPolicies(ctx, svc, request.NativeID, current.Policies, mapList(props, "Policies"), policies); err != nil {
+			return nil, err
+		}
+	}
+
+	if compartmentId, ok := util.ExtractString(props, "CompartmentId"); ok && current.CompartmentId != nil && compartmentId != *current.CompartmentId {
+		if _, err := svc.ChangeAutoScalingConfigurationCompartment(ctx, autoscaling.ChangeAutoScalingConfigurationCompartmentRequest{
+			AutoScalingConfigurationId: common.String(request.NativeID),
+			ChangeCompartmentDetails: autoscaling.ChangeAutoScalingCompartmentDetails{
+				CompartmentId: common.String(compartmentId),
+			},
+		}); err != nil {
+			return nil, fmt.Errorf("failed to move AutoScalingConfiguration to compartment %s: %w", compartmentId, err)
+		}
+	}
+
+	return &resource.UpdateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationUpdate,
+			OperationStatus: resource.OperationStatusSuccess,
+			NativeID:        *resp.Id,
+		},
+	}, nil
+}
+
+func (p *AutoScalingConfigurationProvisioner) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get AutoScaling client: %w", err)
+	}
+
+	readRes, err := p.Read(ctx, &resource.ReadRequest{NativeID: request.NativeID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read AutoScalingConfiguration before delete: %w", err)
+	}
+	if readRes.ErrorCode == resource.OperationErrorCodeNotFound {
+		return &resource.DeleteResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationDelete,
+				OperationStatus: resource.OperationStatusSuccess,
+				NativeID:        request.NativeID,
+			},
+		}, nil
+	}
+
+	_, err = svc.DeleteAutoScalingConfiguration(ctx, autoscaling.DeleteAutoScalingConfigurationRequest{
+		AutoScalingConfigurationId: common.String(request.NativeID),
+	})
+	if err != nil {
+		if result, handleErr := util.HandleDeleteError(err, "OCI::AutoScaling::AutoScalingConfiguration", request.NativeID, "OCI::AutoScaling::AutoScalingConfiguration"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to delete AutoScalingConfiguration: %w", err)
+	}
+
+	return &resource.DeleteResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationDelete,
+			OperationStatus: resource.OperationStatusSuccess,
+			NativeID:        request.NativeID,
+		},
+	}, nil
+}
+
+func (p *AutoScalingConfigurationProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	return util.SyncStatus(ctx, request, p.Read)
+}
+
+func (p *AutoScalingConfigurationProvisioner) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get AutoScaling client: %w", err)
+	}
+
+	compartmentId, ok := util.ListCompartmentId(request)
+	if !ok {
+		return nil, fmt.Errorf("CompartmentId is required for listing AutoScalingConfigurations")
+	}
+
+	listReq := autoscaling.ListAutoScalingConfigurationsRequest{
+		CompartmentId: common.String(compartmentId),
+		Limit:         util.ListPageSize(request.TargetConfig),
+	}
+
+	nativeIDs, err := util.ListAllPages(func(page *string) ([]string, *string, error) {
+		listReq.Page = page
+		resp, err := svc.ListAutoScalingConfigurations(ctx, listReq)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list AutoScalingConfigurations: %w", err)
+		}
+		ids := make([]string, 0, len(resp.Items))
+		for _, configuration := range resp.Items {
+			ids = append(ids, *configuration.Id)
+		}
+		return ids, resp.OpcNextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &resource.ListResult{
+		NativeIDs: nativeIDs,
+	}, nil
+}
+
+// syncAutoScalingPolicies makes the configuration's policies match desired,
+// which Read reports without ids, so policies are paired up by position. A
+// changed policy is updated in place unless its policyType changed, in which
+// case it is replaced. New policies are created before old ones are deleted,
+// so the configuration never drops to none.
+func syncAutoScalingPolicies(ctx context.Context, svc *autoscaling.AutoScalingClient, configurationId string, current []autoscaling.AutoScalingPolicy, desired []map[string]any, details []autoscaling.CreateAutoScalingPolicyDetails) error {
+	type managedPolicy struct {
+		id    string
+		props map[string]any
+	}
+	var managed []managedPolicy
+	for _, policy := range current {
+		if props, ok := buildAutoScalingPolicy(policy); ok && policy.GetId() != nil {
+			managed = append(managed, managedPolicy{id: *policy.GetId(), props: props})
+		}
+	}
+
+	var stale []string
+	for i, want := range desired {
+		if i < len(managed) {
+			have := managed[i]
+			if have.props["policyType"] == want["policyType"] {
+				if samePolicy(have.props, want) {
+					continue
+				}
+				if _, err := svc.UpdateAutoScalingPolicy(ctx, autoscaling.UpdateAutoScalingPolicyRequest{
+					AutoScalingConfigurationId:     common.String(configurationId),
+					AutoScalingPolicyId:            common.String(have.id),
+					UpdateAutoScalingPolicyDetails: toUpdatePolicyDetails(details[i]),
+				}); err != nil {
+					return fmt.Errorf("failed to update AutoScalingPolicy %s: %w", have.id, err)
+				}
+				continue
+			}
+			stale = append(stale, have.id)
+		}
+		if _, err := svc.CreateAutoScalingPolicy(ctx, autoscaling.CreateAutoScalingPolicyRequest{
+			AutoScalingConfigurationId:     common.String(configurationId),
+			CreateAutoScalingPolicyDetails: details[i],
+		}); err != nil {
+			return fmt.Errorf("failed to create AutoScalingPolicy: %w", err)
+		}
+	}
+	for i := len(desired); i < len(managed); i++ {
+		stale = append(stale, managed[i].id)
+	}
+
+	for _, id := range stale {
+		if _, err := svc.DeleteAutoScalingPolicy(ctx, autoscaling.DeleteAutoScalingPolicyRequest{
+			AutoScalingConfigurationId: common.String(configurationId),
+			AutoScalingPolicyId:        common.String(id),
+		}); err != nil && !util.IsNotFound(err) {
+			return fmt.Errorf("failed to delete AutoScalingPolicy %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// samePolicy compares a policy as Read reports it with a desired one, once
+// both are in their JSON form.
+func samePolicy(have, want map[string]any) bool {
+	normalize := func(m map[string]any) any {
+		var v any
+		if b, err := json.Marshal(m); err == nil {
+			_ = json.Unmarshal(b, &v)
+		}
+		return v
+	}
+	return reflect.DeepEqual(normalize(have), normalize(want))
+}
+
+// toUpdatePolicyDetails converts parsed policy details into their update form,
+// which carries the same fields.
+func toUpdatePolicyDetails(details autoscaling.CreateAutoScalingPolicyDetails) autoscaling.UpdateAutoScalingPolicyDetails {
+	switch d := details.(type) {
+	case autoscaling.CreateThresholdPolicyDetails:
+		rules := make([]autoscaling.UpdateConditionDetails, 0, len(d.Rules))
+		for _, rule := range d.Rules {
+			rules = append(rules, autoscaling.UpdateConditionDetails{
+				Action:      rule.Action,
+				Metric:      rule.Metric,
+				DisplayName: rule.DisplayName,
+			})
+		}
+		return autoscaling.UpdateThresholdPolicyDetails{
+			Rules:       rules,
+			Capacity:    d.Capacity,
+			DisplayName: d.DisplayName,
+			IsEnabled:   d.IsEnabled,
+		}
+	case autoscaling.CreateScheduledPolicyDetails:
+		return autoscaling.UpdateScheduledPolicyDetails{
+			ExecutionSchedule: d.ExecutionSchedule,
+			Capacity:          d.Capacity,
+			DisplayName:       d.DisplayName,
+			IsEnabled:         d.IsEnabled,
+		}
+	}
+	return nil
+}
+
+// parseAutoScalingResource reads Resource, a {type, id} object. Instance pools
+// are the only resource OCI autoscales.
+func parseAutoScalingResource(props map[string]any) (autoscaling.Resource, error) {
+	m, ok := props["Resource"].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("Resource is required")
+	}
+	if resourceType, ok := util.ExtractString(m, "type"); ok && resourceType != resourceTypeInstancePool {
+		return nil, fmt.Errorf("unsupported autoscaling resource type %q", resourceType)
+	}
+	id, ok := util.ExtractString(m, "id")
+	if !ok {
+		return nil, fmt.Errorf("Resource.id is required")
+	}
+	return autoscaling.InstancePoolResource{Id: common.String(id)}, nil
+}
+
+// parseAutoScalingPolicies converts the Policies property. Every policy carries
+// a "policyType" discriminator: threshold policies take rules, scheduled
+// policies an executionSchedule.
+func parseAutoScalingPolicies(props map[string]any) ([]autoscaling.CreateAutoScalingPolicyDetails, error) {
+	items := mapList(props, "Policies")
+	if len(items) == 0 {
+		return nil, fmt.Errorf("at least one policy is required")
+	}
+	policies := make([]autoscaling.CreateAutoScalingPolicyDetails, 0, len(items))
+	for _, m := range items {
+		policy, err := parseAutoScalingPolicy(m)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
+
+func parseAutoScalingPolicy(m map[string]any) (autoscaling.CreateAutoScalingPolicyDetails, error) {
+	policyType, _ := util.ExtractString(m, "policyType")
+	displayName := optionalString(m, "displayName")
+	capacity := parseCapacity(m)
+	var isEnabled *bool
+	if enabled, ok := util.ExtractBool(m, "isEnabled"); ok {
+		isEnabled = common.Bool(enabled)
+	}
+
+	switch policyType {
+	case policyTypeThreshold:
+		rules, err := parseConditions(m)
+		if err != nil {
+			return nil, err
+		}
+		return autoscaling.CreateThresholdPolicyDetails{
+			Rules:       rules,
+			Capacity:    capacity,
+			DisplayName: displayName,
+			IsEnabled:   isEnabled,
+		}, nil
+	case policyTypeScheduled:
+		schedule, err := parseExecutionSchedule(m)
+		if err != nil {
+			return nil, err
+		}
+		return autoscaling.CreateScheduledPolicyDetails{
+			ExecutionSchedule: schedule,
+			Capacity:          capacity,
+			DisplayName:       displayName,
+			IsEnabled:         isEnabled,
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported autoscaling policy type %q", policyType)
+}
+
+func parseCapacity(m map[string]any) *autoscaling.Capacity {
+	c, ok := m["capacity"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	capacity := &autoscaling.Capacity{}
+	if v, ok := extractInt(c, "initial"); ok {
+		capacity.Initial = common.Int(v)
+	}
+	if v, ok := extractInt(c, "min"); ok {
+		capacity.Min = common.Int(v)
+	}
+	if v, ok := extractInt(c, "max"); ok {
+		capacity.Max = common.Int(v)
+	}
+	return capacity
+}
+
+// parseConditions reads the rules of a threshold policy, each an
+// {action: {type, value}, metric: {metricType, threshold: {operator, value}}}.
+func parseConditions(m map[string]any) ([]autoscaling.CreateConditionDetails, error) {
+	items := mapList(m, "rules")
+	if len(items) == 0 {
+		return nil, fmt.Errorf("a threshold policy needs at least one rule")
+	}
+	rules := make([]autoscaling.CreateConditionDetails, 0, len(items))
+	for _, r := range items {
+		action, _ := r["action"].(map[string]any)
+		actionValue, ok := extractInt(action, "value")
+		if !ok {
+			return nil, fmt.Errorf("every threshold rule needs an action value")
+		}
+		actionType, ok := util.ExtractString(action, "type")
+		if !ok {
+			actionType = string(autoscaling.ActionTypeChangeCountBy)
+		}
+
+		metric, _ := r["metric"].(map[string]any)
+		metricType, ok := util.ExtractString(metric, "metricType")
+		if !ok {
+			return nil, fmt.Errorf("every threshold rule needs a metric type")
+		}
+		threshold, _ := metric["threshold"].(map[string]any)
+		operator, ok := util.ExtractString(threshold, "operator")
+		if !ok {
+			return nil, fmt.Errorf("every threshold rule needs a threshold operator")
+		}
+		thresholdValue, ok := extractInt(threshold, "value")
+		if !ok {
+			return nil, fmt.Errorf("every threshold rule needs a threshold value")
+		}
+
+		rules = append(rules, autoscaling.CreateConditionDetails{
+			DisplayName: optionalString(r, "displayName"),
+			Action: &autoscaling.Action{
+				Type:  autoscaling.ActionTypeEnum(actionType),
+				Value: common.Int(actionValue),
+			},
+			Metric: autoscaling.Metric{
+				MetricType: autoscaling.MetricMetricTypeEnum(metricType),
+				Threshold: &autoscaling.Threshold{
+					Operator: autoscaling.ThresholdOperatorEnum(operator),
+					Value:    common.Int(thresholdValue),
+				},
+			},
+		})
+	}
+	return rules, nil
+}
+
+// parseExecutionSchedule reads a scheduled policy's {type, timezone, expression}.
+// Cron, in UTC, is the only schedule OCI offers.
+func parseExecutionSchedule(m map[string]any) (autoscaling.ExecutionSchedule, error) {
+	schedule, _ := m["executionSchedule"].(map[string]any)
+	expression, ok := util.ExtractString(schedule, "expression")
+	if !ok {
+		return nil, fmt.Errorf("a scheduled policy needs an executionSchedule expression")
+	}
+	if scheduleType, ok := util.ExtractString(schedule, "type"); ok && scheduleType != scheduleTypeCron {
+		return nil, fmt.Errorf("unsupported execution schedule type %q", scheduleType)
+	}
+	timezone, ok := util.ExtractString(schedule, "timezone")
+	if !ok {
+		timezone = string(autoscaling.ExecutionScheduleTimezoneUtc)
+	}
+	return autoscaling.CronExecutionSchedule{
+		Expression: common.String(expression),
+		Timezone:   autoscaling.ExecutionScheduleTimezoneEnum(timezone),
+	}, nil
+}
+
+func buildAutoScalingConfigurationProperties(configuration autoscaling.AutoScalingConfiguration, ignoredTagNamespaces []string) map[string]any {
+	properties := map[string]any{
+		"Id":       *configuration.Id,
+		"Policies": buildAutoScalingPolicies(configuration.Policies),
+	}
+
+	if configuration.CompartmentId != nil {
+		properties["CompartmentId"] = *configuration.CompartmentId
+	}
+	if r, ok := configuration.Resource.(autoscaling.InstancePoolResource); ok && r.Id != nil {
+		properties["Resource"] = map[string]any{"type": resourceTypeInstancePool, "id": *r.Id}
+	}
+	if configuration.DisplayName != nil {
+		properties["DisplayName"] = *configuration.DisplayName
+	}
+	if configuration.CoolDownInSeconds != nil {
+		properties["CoolDownInSeconds"] = *configuration.CoolDownInSeconds
+	}
+	if configuration.IsEnabled != nil {
+		properties["IsEnabled"] = *configuration.IsEnabled
+	}
+	if configuration.FreeformTags != nil {
+		properties["FreeformTags"] = util.FreeformTagsToList(configuration.FreeformTags)
+	}
+	if configuration.DefinedTags != nil {
+		properties["DefinedTags"] = util.DefinedTagsToList(configuration.DefinedTags, ignoredTagNamespaces)
+	}
+
+	return properties
+}
+
+// buildAutoScalingPolicies keeps the API's order and leaves out the ids and
+// creation times OCI assigns, so a Read diffs cleanly against the desired
+// policies.
+func buildAutoScalingPolicies(policies []autoscaling.AutoScalingPolicy) []map[string]any {
+	result := make([]map[string]any, 0, len(policies))
+	for _, policy := range policies {
+		if m, ok := buildAutoScalingPolicy(policy); ok {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+// buildAutoScalingPolicy reports false for policy types this provisioner does
+// not manage, which are skipped rather than surfaced half-populated.
+func buildAutoScalingPolicy(policy autoscaling.AutoScalingPolicy) (map[string]any, bool) {
+	switch p := policy.(type) {
+	case autoscaling.ThresholdPolicy:
+		m := buildPolicyCommon(policyTypeThreshold, p.DisplayName, p.IsEnabled, p.Capacity)
+		rules := make([]map[string]any, 0, len(p.Rules))
+		for _, rule := range p.Rules {
+			rules = append(rules, buildCondition(rule))
+		}
+		m["rules"] = rules
+		return m, true
+	case autoscaling.ScheduledPolicy:
+		m := buildPolicyCommon(policyTypeScheduled, p.DisplayName, p.IsEnabled, p.Capacity)
+		if s, ok := p.ExecutionSchedule.(autoscaling.CronExecutionSchedule); ok {
+			schedule := map[string]any{"type": scheduleTypeCron, "timezone": string(s.Timezone)}
+			setString(schedule, "expression", s.Expression)
+			m["executionSchedule"] = schedule
+		}
+		return m, true
+	}
+	return nil, false
+}
+
+func buildPolicyCommon(policyType string, displayName *string, isEnabled *bool, capacity *autoscaling.Capacity) map[string]any {
+	m := map[string]any{"policyType": policyType}
+	setString(m, "displayName", displayName)
+	if isEnabled != nil {
+		m["isEnabled"] = *isEnabled
+	}
+	if capacity != nil {
+		c := map[string]any{}
+		setInt(c, "initial", capacity.Initial)
+		setInt(c, "min", capacity.Min)
+		setInt(c, "max", capacity.Max)
+		m["capacity"] = c
+	}
+	return m
+}
+
+func buildCondition(rule autoscaling.Condition) map[string]any {
+	m := map[string]any{}
+	setString(m, "displayName", rule.DisplayName)
+	if rule.Action != nil {
+		action := map[string]any{"type": string(rule.Action.Type)}
+		setInt(action, "value", rule.Action.Value)
+		m["action"] = action
+	}
+	if metric, ok := rule.Metric.(autoscaling.Metric); ok {
+		entry := map[string]any{"metricType": string(metric.MetricType)}
+		if metric.Threshold != nil {
+			threshold := map[string]any{"operator": string(metric.Threshold.Operator)}
+			setInt(threshold, "value", metric.Threshold.Value)
+			entry["threshold"] = threshold
+		}
+		m["metric"] = entry
+	}
+	return m
+}
+
+// mapList returns the objects in the list under key, skipping anything else.
+func mapList(m map[string]any, key string) []map[string]any {
+	items, _ := m[key].([]any)
+	result := make([]map[string]any, 0, len(items))
+	for _, item := range items {
+		if entry, ok := item.(map[string]any); ok {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+func extractInt(m map[string]any, key string) (int, bool) {
+	v, ok := m[key].(float64)
+	if !ok {
+		return 0, false
+	}
+	return int(v), true
+}
+
+func optionalString(m map[string]any, key string) *string {
+	if v, ok := util.ExtractString(m, key); ok {
+		return common.String(v)
+	}
+	return nil
+}
+
+func setString(m map[string]any, key string, v *string) {
+	if v != nil {
+		m[key] = *v
+	}
+}
+
+func setInt(m map[string]any, key string, v *int) {
+	if v != nil {
+		m[key] = *v
+	}
+}
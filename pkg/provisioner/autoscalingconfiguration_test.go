@@ -0,0 +1,212 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build integration
+
+package provisioner_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	ociautoscaling "github.com/oracle/oci-go-sdk/v65/autoscaling"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/autoscaling"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testAutoScalingPolicies are a threshold and a scheduled policy in the shape
+// Read produces, which is also what Create accepts.
+const testAutoScalingPolicies = `[
+	{"policyType": "threshold", "displayName": "cpu", "isEnabled": true, "capacity": {"initial": 2, "min": 1, "max": 4},
+	 "rules": [
+		{"displayName": "scale-out", "action": {"type": "CHANGE_COUNT_BY", "value": 1}, "metric": {"metricType": "CPU_UTILIZATION", "threshold": {"operator": "GT", "value": 80}}},
+		{"displayName": "scale-in", "action": {"type": "CHANGE_COUNT_BY", "value": -1}, "metric": {"metricType": "CPU_UTILIZATION", "threshold": {"operator": "LT", "value": 20}}}
+	 ]},
+	{"policyType": "scheduled", "displayName": "nightly", "isEnabled": false, "capacity": {"initial": 1},
+	 "executionSchedule": {"type": "cron", "timezone": "UTC", "expression": "0 0 22 ? * *"}}
+]`
+
+func TestAutoScalingConfigurationCreate(t *testing.T) {
+	svc := newTestAutoScalingClient(t, map[route]canned{
+		{"POST", "/20181001/autoScalingConfigurations"}: {200, newTestAutoScalingConfigurationBody("ocid1.autoscalingconfiguration..aaa")},
+	})
+	p := autoscaling.NewAutoScalingConfigurationProvisionerWithSvc(svc)
+
+	var policies []any
+	require.NoError(t, json.Unmarshal([]byte(testAutoScalingPolicies), &policies))
+	props, err := json.Marshal(map[string]any{
+		"CompartmentId":     "ocid1.compartment..xxx",
+		"Resource":          map[string]any{"type": "instancePool", "id": "ocid1.instancepool..aaa"},
+		"Policies":          policies,
+		"CoolDownInSeconds": 300,
+	})
+	require.NoError(t, err)
+
+	result, err := p.Create(context.Background(), &resource.CreateRequest{
+		ResourceType: "OCI::AutoScaling::AutoScalingConfiguration",
+		Properties:   props,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	assert.Equal(t, "ocid1.autoscalingconfiguration..aaa", result.ProgressResult.NativeID)
+}
+
+func TestAutoScalingConfigurationCreate_RejectsPolicyWithoutRules(t *testing.T) {
+	p := autoscaling.NewAutoScalingConfigurationProvisionerWithSvc(newTestAutoScalingClient(t, map[route]canned{}))
+
+	_, err := p.Create(context.Background(), &resource.CreateRequest{
+		ResourceType: "OCI::AutoScaling::AutoScalingConfiguration",
+		Properties: []byte(`{"CompartmentId": "ocid1.compartment..xxx",
+			"Resource": {"type": "instancePool", "id": "ocid1.instancepool..aaa"},
+			"Policies": [{"policyType": "threshold", "capacity": {"initial": 1}}]}`),
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "at least one rule")
+}
+
+func TestAutoScalingConfigurationRead_RoundTripsPolicies(t *testing.T) {
+	svc := newTestAutoScalingClient(t, map[route]canned{
+		{"GET", "/20181001/autoScalingConfigurations/ocid1.autoscalingconfiguration..aaa"}: {200, newTestAutoScalingConfigurationBody("ocid1.autoscalingconfiguration..aaa")},
+	})
+	p := autoscaling.NewAutoScalingConfigurationProvisionerWithSvc(svc)
+
+	result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.autoscalingconfiguration..aaa"})
+	require.NoError(t, err)
+
+	var props map[string]any
+	require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+	assert.Equal(t, map[string]any{"type": "instancePool", "id": "ocid1.instancepool..aaa"}, props["Resource"])
+	assert.Equal(t, float64(300), props["CoolDownInSeconds"])
+	assert.Equal(t, true, props["IsEnabled"])
+
+	policies, err := json.Marshal(props["Policies"])
+	require.NoError(t, err)
+	assert.JSONEq(t, testAutoScalingPolicies, string(policies))
+}
+
+func TestAutoScalingConfigurationUpdate_SyncsPoliciesAndCompartment(t *testing.T) {
+	configuration := "/20181001/autoScalingConfigurations/ocid1.autoscalingconfiguration..aaa"
+	// The cpu policy gets a higher max and the nightly one is dropped
+	var policies []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(testAutoScalingPolicies), &policies))
+	policies[0]["capacity"] = map[string]any{"initial": 2, "min": 1, "max": 6}
+	props, err := json.Marshal(map[string]any{
+		"CompartmentId":     "ocid1.compartment..yyy",
+		"Resource":          map[string]any{"type": "instancePool", "id": "ocid1.instancepool..aaa"},
+		"Policies":          policies[:1],
+		"CoolDownInSeconds": 300,
+	})
+	require.NoError(t, err)
+
+	svc := newTestAutoScalingClient(t, map[route]canned{
+		{"GET", configuration}:                                        {200, newTestAutoScalingConfigurationBody("ocid1.autoscalingconfiguration..aaa")},
+		{"PUT", configuration}:                                        {200, newTestAutoScalingConfigurationBody("ocid1.autoscalingconfiguration..aaa")},
+		{"PUT", configuration + "/policies/ocid1.policy..cpu"}:        {200, `{"policyType": "threshold", "id": "ocid1.policy..cpu", "capacity": {"initial": 2, "min": 1, "max": 6}, "rules": []}`},
+		{"DELETE", configuration + "/policies/ocid1.policy..nightly"}: {204, ``},
+		{"POST", configuration + "/actions/changeCompartment"}:        {204, ``},
+	})
+	p := autoscaling.NewAutoScalingConfigurationProvisionerWithSvc(svc)
+
+	result, err := p.Update(context.Background(), &resource.UpdateRequest{
+		NativeID:          "ocid1.autoscalingconfiguration..aaa",
+		ResourceType:      "OCI::AutoScaling::AutoScalingConfiguration",
+		DesiredProperties: props,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+}
+
+func TestAutoScalingConfigurationUpdate_ReplacesPolicyOfAnotherType(t *testing.T) {
+	configuration := "/20181001/autoScalingConfigurations/ocid1.autoscalingconfiguration..aaa"
+	// The nightly scheduled policy becomes a threshold policy
+	var policies []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(testAutoScalingPolicies), &policies))
+	policies[1] = policies[0]
+	props, err := json.Marshal(map[string]any{
+		"CompartmentId": "ocid1.compartment..xxx",
+		"Resource":      map[string]any{"type": "instancePool", "id": "ocid1.instancepool..aaa"},
+		"Policies":      policies,
+	})
+	require.NoError(t, err)
+
+	// No route for the unchanged cpu policy: the dispatcher fails the test if it is updated
+	svc := newTestAutoScalingClient(t, map[route]canned{
+		{"GET", configuration}:                                        {200, newTestAutoScalingConfigurationBody("ocid1.autoscalingconfiguration..aaa")},
+		{"PUT", configuration}:                                        {200, newTestAutoScalingConfigurationBody("ocid1.autoscalingconfiguration..aaa")},
+		{"POST", configuration + "/policies"}:                         {200, `{"policyType": "threshold", "id": "ocid1.policy..new", "capacity": {"initial": 2, "min": 1, "max": 4}, "rules": []}`},
+		{"DELETE", configuration + "/policies/ocid1.policy..nightly"}: {204, ``},
+	})
+	p := autoscaling.NewAutoScalingConfigurationProvisionerWithSvc(svc)
+
+	result, err := p.Update(context.Background(), &resource.UpdateRequest{
+		NativeID:          "ocid1.autoscalingconfiguration..aaa",
+		ResourceType:      "OCI::AutoScaling::AutoScalingConfiguration",
+		DesiredProperties: props,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+}
+
+func TestAutoScalingConfigurationList(t *testing.T) {
+	host := newTestPagedDispatcher(t, nil, map[route][]canned{
+		{"GET", "/20181001/autoScalingConfigurations"}: {
+			{200, `[{"id": "ocid1.autoscalingconfiguration..aaa", "compartmentId": "ocid1.compartment..xxx", "timeCreated": "2025-01-01T00:00:00Z"}]`},
+			{200, `[{"id": "ocid1.autoscalingconfiguration..bbb", "compartmentId": "ocid1.compartment..xxx", "timeCreated": "2025-01-01T00:00:00Z"}]`},
+		},
+	})
+	p := autoscaling.NewAutoScalingConfigurationProvisionerWithSvc(newTestAutoScalingClientAt(t, host))
+
+	result, err := p.List(context.Background(), &resource.ListRequest{
+		ResourceType:         "OCI::AutoScaling::AutoScalingConfiguration",
+		AdditionalProperties: map[string]string{"CompartmentId": "ocid1.compartment..xxx"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ocid1.autoscalingconfiguration..aaa", "ocid1.autoscalingconfiguration..bbb"}, result.NativeIDs)
+}
+
+// Helpers
+
+func newTestAutoScalingClient(t *testing.T, responses map[route]canned) *ociautoscaling.AutoScalingClient {
+	t.Helper()
+	return newTestAutoScalingClientAt(t, newTestDispatcher(t, responses))
+}
+
+func newTestAutoScalingClientAt(t *testing.T, host string) *ociautoscaling.AutoScalingClient {
+	t.Helper()
+	c, err := ociautoscaling.NewAutoScalingClientWithConfigurationProvider(fakeOCIConfigProvider(t))
+	require.NoError(t, err)
+	applyTestRetryPolicy(&c)
+	c.Host = host
+	return &c
+}
+
+// newTestAutoScalingConfigurationBody returns the API's form of
+// testAutoScalingPolicies, with the ids and creation times OCI adds.
+func newTestAutoScalingConfigurationBody(id string) string {
+	return fmt.Sprintf(`{
+		"id": %q,
+		"compartmentId": "ocid1.compartment..xxx",
+		"resource": {"type": "instancePool", "id": "ocid1.instancepool..aaa"},
+		"coolDownInSeconds": 300,
+		"isEnabled": true,
+		"timeCreated": "2025-01-01T00:00:00Z",
+		"policies": [
+			{"policyType": "threshold", "id": "ocid1.policy..cpu", "displayName": "cpu", "isEnabled": true, "timeCreated": "2025-01-01T00:00:00Z",
+			 "capacity": {"initial": 2, "min": 1, "max": 4},
+			 "rules": [
+				{"id": "ocid1.rule..out", "displayName": "scale-out", "action": {"type": "CHANGE_COUNT_BY", "value": 1},
+				 "metric": {"metricSource": "COMPUTE_AGENT", "metricType": "CPU_UTILIZATION", "threshold": {"operator": "GT", "value": 80}}},
+				{"id": "ocid1.rule..in", "displayName": "scale-in", "action": {"type": "CHANGE_COUNT_BY", "value": -1},
+				 "metric": {"metricSource": "COMPUTE_AGENT", "metricType": "CPU_UTILIZATION", "threshold": {"operator": "LT", "value": 20}}}
+			 ]},
+			{"policyType": "scheduled", "id": "ocid1.policy..nightly", "displayName": "nightly", "isEnabled": false, "timeCreated": "2025-01-01T00:00:00Z",
+			 "capacity": {"initial": 1},
+			 "executionSchedule": {"type": "cron", "timezone": "UTC", "expression": "0 0 22 ? * *"}}
+		]
+	}`, id)
+}
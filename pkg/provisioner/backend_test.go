@@ -0,0 +1,142 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build integration
+
+package provisioner_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/loadbalancer"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBackendPath = "/20170115/loadBalancers/ocid1.loadbalancer..aaa/backendSets/web/backends/10.0.1.10:8443"
+
+func TestBackendRead(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc := newTestLoadBalancerClient(t, map[route]canned{
+			{"GET", testBackendPath}: {200, newTestBackendBody(true)},
+		})
+		p := loadbalancer.NewBackendProvisionerWithSvc(svc)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.loadbalancer..aaa/web/10.0.1.10:8443"})
+		require.NoError(t, err)
+		assert.Empty(t, result.ErrorCode)
+
+		var props map[string]any
+		require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+		assert.Equal(t, "ocid1.loadbalancer..aaa", props["LoadBalancerId"])
+		assert.Equal(t, "web", props["BackendSetName"])
+		assert.Equal(t, "10.0.1.10:8443", props["Name"])
+		assert.Equal(t, "10.0.1.10", props["IpAddress"])
+		assert.Equal(t, float64(8443), props["Port"])
+		assert.Equal(t, float64(1), props["Weight"])
+		assert.Equal(t, true, props["Drain"])
+		assert.Equal(t, false, props["Offline"])
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		svc := newTestLoadBalancerClient(t, map[route]canned{
+			{"GET", testBackendPath}: {404, `{"code":"NotAuthorizedOrNotFound","message":"not found"}`},
+		})
+		p := loadbalancer.NewBackendProvisionerWithSvc(svc)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.loadbalancer..aaa/web/10.0.1.10:8443"})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationErrorCodeNotFound, result.ErrorCode)
+	})
+
+	t.Run("invalid_native_id", func(t *testing.T) {
+		p := loadbalancer.NewBackendProvisionerWithSvc(newTestLoadBalancerClient(t, map[route]canned{}))
+
+		_, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.loadbalancer..aaa/web"})
+		assert.ErrorContains(t, err, "{loadBalancerId}/{backendSetName}/{ipAddress:port}")
+	})
+}
+
+func TestBackendUpdateRejected(t *testing.T) {
+	svc := newTestLoadBalancerClient(t, map[route]canned{
+		{"PUT", testBackendPath}: {400, `{"code":"InvalidParameter","message":"weight must be positive"}`},
+	})
+	p := loadbalancer.NewBackendProvisionerWithSvc(svc)
+
+	props, _ := json.Marshal(map[string]any{
+		"LoadBalancerId": "ocid1.loadbalancer..aaa",
+		"BackendSetName": "web",
+		"IpAddress":      "10.0.1.10",
+		"Port":           8443,
+		"Drain":          true,
+	})
+	result, err := p.Update(context.Background(), &resource.UpdateRequest{
+		NativeID:          "ocid1.loadbalancer..aaa/web/10.0.1.10:8443",
+		ResourceType:      "OCI::LoadBalancer::Backend",
+		DesiredProperties: props,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusFailure, result.ProgressResult.OperationStatus)
+	assert.Equal(t, resource.OperationErrorCodeInvalidRequest, result.ProgressResult.ErrorCode)
+	assert.Equal(t, "ocid1.loadbalancer..aaa/web/10.0.1.10:8443", result.ProgressResult.NativeID)
+}
+
+func TestBackendDeleteAlreadyGone(t *testing.T) {
+	svc := newTestLoadBalancerClient(t, map[route]canned{
+		{"GET", testBackendPath}: {404, `{"code":"NotAuthorizedOrNotFound","message":"not found"}`},
+	})
+	p := loadbalancer.NewBackendProvisionerWithSvc(svc)
+
+	result, err := p.Delete(context.Background(), &resource.DeleteRequest{NativeID: "ocid1.loadbalancer..aaa/web/10.0.1.10:8443"})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+}
+
+func TestBackendList(t *testing.T) {
+	t.Run("all_backend_sets", func(t *testing.T) {
+		svc := newTestLoadBalancerClient(t, map[route]canned{
+			{"GET", "/20170115/loadBalancers/ocid1.loadbalancer..aaa"}: {200, newTestLoadBalancerBody("ACTIVE")},
+		})
+		p := loadbalancer.NewBackendProvisionerWithSvc(svc)
+
+		result, err := p.List(context.Background(), &resource.ListRequest{
+			ResourceType:         "OCI::LoadBalancer::Backend",
+			AdditionalProperties: map[string]string{"LoadBalancerId": "ocid1.loadbalancer..aaa"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ocid1.loadbalancer..aaa/web/10.0.1.10:8443"}, result.NativeIDs)
+	})
+
+	t.Run("filtered_by_backend_set", func(t *testing.T) {
+		svc := newTestLoadBalancerClient(t, map[route]canned{
+			{"GET", "/20170115/loadBalancers/ocid1.loadbalancer..aaa"}: {200, newTestLoadBalancerBody("ACTIVE")},
+		})
+		p := loadbalancer.NewBackendProvisionerWithSvc(svc)
+
+		result, err := p.List(context.Background(), &resource.ListRequest{
+			ResourceType:         "OCI::LoadBalancer::Backend",
+			AdditionalProperties: map[string]string{"LoadBalancerId": "ocid1.loadbalancer..aaa", "BackendSetName": "api"},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, result.NativeIDs)
+	})
+}
+
+// Helpers
+
+func newTestBackendBody(drain bool) string {
+	body, _ := json.Marshal(map[string]any{
+		"name":      "10.0.1.10:8443",
+		"ipAddress": "10.0.1.10",
+		"port":      8443,
+		"weight":    1,
+		"drain":     drain,
+		"backup":    false,
+		"offline":   false,
+	})
+	return string(body)
+}
@@ -0,0 +1,145 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build integration
+
+package provisioner_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/loadbalancer"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackendSetRead(t *testing.T) {
+	t.Run("success_with_ssl", func(t *testing.T) {
+		svc := newTestLoadBalancerClient(t, map[route]canned{
+			{"GET", "/20170115/loadBalancers/ocid1.loadbalancer..aaa/backendSets/web"}:        {200, newTestBackendSetBody()},
+			{"GET", "/20170115/loadBalancers/ocid1.loadbalancer..aaa"}:                        {200, newTestLoadBalancerBody("ACTIVE")},
+			{"GET", "/20170115/loadBalancers/ocid1.loadbalancer..aaa/backendSets/web/health"}: {200, newTestBackendSetHealthBody()},
+		})
+		p := loadbalancer.NewBackendSetProvisionerWithSvc(svc)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.loadbalancer..aaa/web"})
+		require.NoError(t, err)
+		assert.Empty(t, result.ErrorCode)
+
+		var props map[string]any
+		require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+		assert.Equal(t, "web", props["Name"])
+		assert.Equal(t, "ROUND_ROBIN", props["Policy"])
+		healthChecker := props["HealthChecker"].(map[string]any)
+		assert.Equal(t, "HTTP", healthChecker["protocol"])
+		assert.Equal(t, "/healthz", healthChecker["urlPath"])
+		backends := props["Backends"].([]any)
+		require.Len(t, backends, 1)
+		assert.Equal(t, "10.0.1.10", backends[0].(map[string]any)["ipAddress"])
+		ssl := props["SslConfiguration"].(map[string]any)
+		assert.Equal(t, []any{"ocid1.certificate..aaa"}, ssl["certificateIds"])
+		assert.Equal(t, true, ssl["verifyPeerCertificate"])
+		assert.Equal(t, []any{"ocid1.cabundle..aaa"}, ssl["trustedCertificateAuthorityIds"])
+		health := props["HealthStatus"].(map[string]any)
+		assert.Equal(t, "CRITICAL", health["status"])
+		assert.Equal(t, []any{"10.0.1.10:8443"}, health["criticalBackends"])
+		assert.Equal(t, float64(1), health["totalBackendCount"])
+	})
+
+	t.Run("health_skipped_while_updating", func(t *testing.T) {
+		svc := newTestLoadBalancerClient(t, map[route]canned{
+			{"GET", "/20170115/loadBalancers/ocid1.loadbalancer..aaa/backendSets/web"}: {200, newTestBackendSetBody()},
+			{"GET", "/20170115/loadBalancers/ocid1.loadbalancer..aaa"}:                 {200, newTestLoadBalancerBody("UPDATING")},
+		})
+		p := loadbalancer.NewBackendSetProvisionerWithSvc(svc)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.loadbalancer..aaa/web"})
+		require.NoError(t, err)
+		assert.Empty(t, result.ErrorCode)
+
+		var props map[string]any
+		require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+		assert.NotContains(t, props, "HealthStatus")
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		svc := newTestLoadBalancerClient(t, map[route]canned{
+			{"GET", "/20170115/loadBalancers/ocid1.loadbalancer..aaa/backendSets/missing"}: {404, `{"code":"NotAuthorizedOrNotFound","message":"not found"}`},
+		})
+		p := loadbalancer.NewBackendSetProvisionerWithSvc(svc)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.loadbalancer..aaa/missing"})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationErrorCodeNotFound, result.ErrorCode)
+	})
+}
+
+func TestBackendSetDeleteAlreadyGone(t *testing.T) {
+	svc := newTestLoadBalancerClient(t, map[route]canned{
+		{"GET", "/20170115/loadBalancers/ocid1.loadbalancer..aaa/backendSets/web"}: {404, `{"code":"NotAuthorizedOrNotFound","message":"not found"}`},
+	})
+	p := loadbalancer.NewBackendSetProvisionerWithSvc(svc)
+
+	result, err := p.Delete(context.Background(), &resource.DeleteRequest{NativeID: "ocid1.loadbalancer..aaa/web"})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+}
+
+func TestBackendSetList(t *testing.T) {
+	svc := newTestLoadBalancerClient(t, map[route]canned{
+		{"GET", "/20170115/loadBalancers/ocid1.loadbalancer..aaa"}: {200, newTestLoadBalancerBody("ACTIVE")},
+	})
+	p := loadbalancer.NewBackendSetProvisionerWithSvc(svc)
+
+	result, err := p.List(context.Background(), &resource.ListRequest{
+		ResourceType:         "OCI::LoadBalancer::BackendSet",
+		AdditionalProperties: map[string]string{"LoadBalancerId": "ocid1.loadbalancer..aaa"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ocid1.loadbalancer..aaa/web"}, result.NativeIDs)
+}
+
+// Helpers
+
+func newTestBackendSetBody() string {
+	return `{
+		"name": "web",
+		"policy": "ROUND_ROBIN",
+		"backends": [{
+			"name": "10.0.1.10:8443",
+			"ipAddress": "10.0.1.10",
+			"port": 8443,
+			"weight": 1,
+			"drain": false,
+			"backup": false,
+			"offline": false
+		}],
+		"healthChecker": {
+			"protocol": "HTTP",
+			"port": 8443,
+			"urlPath": "/healthz",
+			"returnCode": 200,
+			"responseBodyRegex": ""
+		},
+		"sslConfiguration": {
+			"certificateIds": ["ocid1.certificate..aaa"],
+			"trustedCertificateAuthorityIds": ["ocid1.cabundle..aaa"],
+			"verifyPeerCertificate": true,
+			"verifyDepth": 2
+		}
+	}`
+}
+
+func newTestBackendSetHealthBody() string {
+	return `{
+		"status": "CRITICAL",
+		"warningStateBackendNames": [],
+		"criticalStateBackendNames": ["10.0.1.10:8443"],
+		"unknownStateBackendNames": [],
+		"totalBackendCount": 1
+	}`
+}
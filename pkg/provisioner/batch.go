@@ -0,0 +1,43 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package provisioner
+
+import (
+	"context"
+	"sync"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+// ReadFunc reads a single resource, e.g. the plugin's Read entrypoint.
+type ReadFunc func(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error)
+
+// ReadBatch calls read for every request, with at most concurrency reads in
+// flight. The OCI calls they make are paced by the clients' shared rate limiter.
+// results[i] and errs[i] belong to requests[i]; one failed read doesn't stop
+// the others.
+func ReadBatch(ctx context.Context, requests []*resource.ReadRequest, concurrency int, read ReadFunc) ([]*resource.ReadResult, []error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]*resource.ReadResult, len(requests))
+	errs := make([]error, len(requests))
+	slots := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for i, request := range requests {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func(i int, request *resource.ReadRequest) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			results[i], errs[i] = read(ctx, request)
+		}(i, request)
+	}
+	wg.Wait()
+
+	return results, errs
+}
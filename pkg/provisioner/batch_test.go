@@ -0,0 +1,144 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package provisioner
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+func TestReadBatch_BoundsConcurrencyAndKeepsOrder(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	read := func(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		if request.NativeID == "bad" {
+			return nil, errors.New("boom")
+		}
+		return &resource.ReadResult{Properties: request.NativeID}, nil
+	}
+
+	requests := []*resource.ReadRequest{{NativeID: "a"}, {NativeID: "bad"}, {NativeID: "c"}, {NativeID: "d"}, {NativeID: "e"}}
+	results, errs := ReadBatch(context.Background(), requests, 2, read)
+
+	if maxInFlight > 2 {
+		t.Errorf("expected at most 2 reads in flight, got %d", maxInFlight)
+	}
+	for i, request := range requests {
+		if request.NativeID == "bad" {
+			if errs[i] == nil {
+				t.Errorf("expected an error for request %d", i)
+			}
+			continue
+		}
+		if errs[i] != nil {
+			t.Fatalf("unexpected error for request %d: %v", i, errs[i])
+		}
+		if results[i].Properties != request.NativeID {
+			t.Errorf("result %d = %q, want %q", i, results[i].Properties, request.NativeID)
+		}
+	}
+}
+
+func TestReadBatch_HonorsRateLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id": "ocid1.vcn..aaa"}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	// The reads go through the same rate-limited clients the plugin builds
+	clients, err := client.NewClients(context.Background(), &config.Config{
+		ConfigFilePath:       writeTestOCIConfig(t),
+		MaxRequestsPerSecond: 20,
+	})
+	if err != nil {
+		t.Fatalf("failed to build clients: %v", err)
+	}
+	network, err := clients.GetVirtualNetworkClient()
+	if err != nil {
+		t.Fatalf("failed to get VirtualNetwork client: %v", err)
+	}
+	network.Host = srv.URL
+
+	read := func(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+		_, err := network.GetVcn(ctx, core.GetVcnRequest{VcnId: common.String(request.NativeID)})
+		return &resource.ReadResult{}, err
+	}
+	requests := make([]*resource.ReadRequest, 5)
+	for i := range requests {
+		requests[i] = &resource.ReadRequest{NativeID: "ocid1.vcn..aaa"}
+	}
+
+	start := time.Now()
+	_, errs := ReadBatch(context.Background(), requests, 5, read)
+
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("unexpected error for request %d: %v", i, err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("5 reads at 20/s finished in %v, expected at least 200ms", elapsed)
+	}
+}
+
+// writeTestOCIConfig writes an OCI config file with a throwaway key. The test
+// server never checks signatures, so the key only has to parse.
+func writeTestOCIConfig(t *testing.T) string {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "key.pem")
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	if err := os.WriteFile(keyPath, keyPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	configPath := filepath.Join(dir, "config")
+	contents := fmt.Sprintf(`[DEFAULT]
+user=ocid1.user.oc1..test
+fingerprint=aa:bb:cc:dd:ee:ff:11:22:33:44:55:66:77:88:99:00
+key_file=%s
+tenancy=ocid1.tenancy.oc1..test
+region=us-chicago-1
+`, keyPath)
+	if err := os.WriteFile(configPath, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return configPath
+}
@@ -10,6 +10,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	ociobjectstorage "github.com/oracle/oci-go-sdk/v65/objectstorage"
@@ -22,7 +26,8 @@ import (
 func TestBucketRead(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		svc := newTestObjectStorageClient(t, map[route]canned{
-			{"GET", "/n/testnamespace/b/test-bucket"}: {200, newTestBucketBody()},
+			{"GET", "/n/testnamespace/b/test-bucket"}:   {200, newTestBucketBody()},
+			{"GET", "/n/testnamespace/b/test-bucket/l"}: {404, `{"code":"LifecyclePolicyNotFound","message":"not found"}`},
 		})
 		p := objectstorage.NewBucketProvisionerWithSvc(svc)
 
@@ -34,6 +39,60 @@ func TestBucketRead(t *testing.T) {
 		require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
 		assert.Equal(t, "test-bucket", props["Name"])
 		assert.Equal(t, "testnamespace", props["Namespace"])
+		assert.NotContains(t, props, "LifecycleRules")
+	})
+
+	t.Run("lifecycle_rules", func(t *testing.T) {
+		svc := newTestObjectStorageClient(t, map[route]canned{
+			{"GET", "/n/testnamespace/b/test-bucket"}:   {200, newTestBucketBody()},
+			{"GET", "/n/testnamespace/b/test-bucket/l"}: {200, newTestLifecyclePolicyBody()},
+		})
+		p := objectstorage.NewBucketProvisionerWithSvc(svc)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "test-bucket"})
+		require.NoError(t, err)
+
+		var props map[string]any
+		require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+		rules, ok := props["LifecycleRules"].([]any)
+		require.True(t, ok)
+		require.Len(t, rules, 1)
+		rule := rules[0].(map[string]any)
+		assert.Equal(t, "archive-logs", rule["name"])
+		assert.Equal(t, "ARCHIVE", rule["action"])
+		assert.Equal(t, float64(30), rule["timeAmount"])
+		assert.Equal(t, "DAYS", rule["timeUnit"])
+		assert.Equal(t, true, rule["isEnabled"])
+		filter := rule["objectNameFilter"].(map[string]any)
+		assert.Equal(t, []any{"logs/"}, filter["inclusionPrefixes"])
+	})
+
+	t.Run("lifecycle_policy_not_authorized", func(t *testing.T) {
+		svc := newTestObjectStorageClient(t, map[route]canned{
+			{"GET", "/n/testnamespace/b/test-bucket"}:   {200, newTestBucketBody()},
+			{"GET", "/n/testnamespace/b/test-bucket/l"}: {403, `{"code":"NotAllowed","message":"not allowed to read the lifecycle policy"}`},
+		})
+		p := objectstorage.NewBucketProvisionerWithSvc(svc)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "test-bucket"})
+		require.NoError(t, err)
+		assert.Empty(t, result.ErrorCode)
+
+		var props map[string]any
+		require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+		assert.Equal(t, "test-bucket", props["Name"])
+		assert.NotContains(t, props, "LifecycleRules")
+	})
+
+	t.Run("lifecycle_policy_error", func(t *testing.T) {
+		svc := newTestObjectStorageClient(t, map[route]canned{
+			{"GET", "/n/testnamespace/b/test-bucket"}:   {200, newTestBucketBody()},
+			{"GET", "/n/testnamespace/b/test-bucket/l"}: {400, `{"code":"InvalidParameter","message":"bad"}`},
+		})
+		p := objectstorage.NewBucketProvisionerWithSvc(svc)
+
+		_, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "test-bucket"})
+		require.Error(t, err)
 	})
 
 	t.Run("not_found", func(t *testing.T) {
@@ -48,6 +107,93 @@ func TestBucketRead(t *testing.T) {
 	})
 }
 
+func TestBucketReadEncryption(t *testing.T) {
+	tests := map[string]struct {
+		kmsKeyId string
+		want     any
+	}{
+		"oracle_managed": {kmsKeyId: `""`},
+		"null":           {kmsKeyId: `null`},
+		"customer_key":   {kmsKeyId: `"ocid1.key..aaa"`, want: "ocid1.key..aaa"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			body := strings.Replace(newTestBucketBody(), `"storageTier": "Standard"`, `"storageTier": "Standard", "kmsKeyId": `+tt.kmsKeyId, 1)
+			svc := newTestObjectStorageClient(t, map[route]canned{
+				{"GET", "/n/testnamespace/b/test-bucket"}:   {200, body},
+				{"GET", "/n/testnamespace/b/test-bucket/l"}: {404, `{"code":"LifecyclePolicyNotFound","message":"not found"}`},
+			})
+			p := objectstorage.NewBucketProvisionerWithSvc(svc)
+
+			result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "test-bucket"})
+			require.NoError(t, err)
+			var props map[string]any
+			require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+			if tt.want == nil {
+				assert.NotContains(t, props, "KmsKeyId")
+			} else {
+				assert.Equal(t, tt.want, props["KmsKeyId"])
+			}
+		})
+	}
+}
+
+func TestBucketReadApproximateStats(t *testing.T) {
+	tests := map[string]struct {
+		targetConfig string
+		wantFields   string
+	}{
+		"off": {targetConfig: `{}`},
+		"on":  {targetConfig: `{"BucketApproximateStats": true}`, wantFields: "approximateCount,approximateSize"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var gotFields string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.URL.Path {
+				case "/n":
+					fmt.Fprint(w, `"testnamespace"`)
+				case "/n/testnamespace/b/test-bucket":
+					gotFields = r.URL.Query().Get("fields")
+					body := newTestBucketBody()
+					if gotFields != "" {
+						body = strings.Replace(body, `"storageTier": "Standard"`, `"storageTier": "Standard", "approximateCount": 42, "approximateSize": 1048576`, 1)
+					}
+					fmt.Fprint(w, body)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+					fmt.Fprint(w, `{"code":"NotFound","message":"not found"}`)
+				}
+			}))
+			t.Cleanup(srv.Close)
+
+			c, err := ociobjectstorage.NewObjectStorageClientWithConfigurationProvider(fakeOCIConfigProvider(t))
+			require.NoError(t, err)
+			applyTestRetryPolicy(&c)
+			c.Host = srv.URL
+			p := objectstorage.NewBucketProvisionerWithSvc(&c)
+
+			result, err := p.Read(context.Background(), &resource.ReadRequest{
+				NativeID:     "test-bucket",
+				TargetConfig: json.RawMessage(tt.targetConfig),
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFields, gotFields)
+
+			var props map[string]any
+			require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+			if tt.wantFields == "" {
+				assert.NotContains(t, props, "ApproximateCount")
+				assert.NotContains(t, props, "ApproximateSize")
+			} else {
+				assert.Equal(t, float64(42), props["ApproximateCount"])
+				assert.Equal(t, float64(1048576), props["ApproximateSize"])
+			}
+		})
+	}
+}
+
 func TestBucketCreate(t *testing.T) {
 	svc := newTestObjectStorageClient(t, map[route]canned{
 		{"POST", "/n/testnamespace/b"}: {200, newTestBucketBody()},
@@ -70,6 +216,124 @@ func TestBucketCreate(t *testing.T) {
 	assert.Equal(t, "test-bucket", result.ProgressResult.NativeID)
 }
 
+func TestBucketCreateAdoptExisting(t *testing.T) {
+	conflict := canned{409, `{"code":"BucketAlreadyExists","message":"Either the bucket 'test-bucket' in namespace 'testnamespace' already exists or you are not authorized to create it"}`}
+	props, err := json.Marshal(map[string]any{
+		"CompartmentId": "ocid1.compartment..xxx",
+		"Name":          "test-bucket",
+		"Namespace":     "testnamespace",
+	})
+	require.NoError(t, err)
+
+	t.Run("adopted", func(t *testing.T) {
+		svc := newTestObjectStorageClient(t, map[route]canned{
+			{"POST", "/n/testnamespace/b"}:            conflict,
+			{"GET", "/n/testnamespace/b/test-bucket"}: {200, newTestBucketBody()},
+		})
+		p := objectstorage.NewBucketProvisionerWithSvc(svc)
+
+		result, err := p.Create(context.Background(), &resource.CreateRequest{
+			ResourceType: "OCI::ObjectStorage::Bucket",
+			Properties:   props,
+			TargetConfig: json.RawMessage(`{"AdoptExisting": true}`),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+		assert.Equal(t, "test-bucket", result.ProgressResult.NativeID)
+	})
+
+	t.Run("flag_unset", func(t *testing.T) {
+		svc := newTestObjectStorageClient(t, map[route]canned{
+			{"POST", "/n/testnamespace/b"}: conflict,
+		})
+		p := objectstorage.NewBucketProvisionerWithSvc(svc)
+
+		result, err := p.Create(context.Background(), &resource.CreateRequest{
+			ResourceType: "OCI::ObjectStorage::Bucket",
+			Properties:   props,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusFailure, result.ProgressResult.OperationStatus)
+		assert.Equal(t, resource.OperationErrorCodeResourceConflict, result.ProgressResult.ErrorCode)
+	})
+
+	t.Run("other_compartment", func(t *testing.T) {
+		svc := newTestObjectStorageClient(t, map[route]canned{
+			{"POST", "/n/testnamespace/b"}:            conflict,
+			{"GET", "/n/testnamespace/b/test-bucket"}: {200, `{"name": "test-bucket", "compartmentId": "ocid1.compartment..other", "namespace": "testnamespace"}`},
+		})
+		p := objectstorage.NewBucketProvisionerWithSvc(svc)
+
+		result, err := p.Create(context.Background(), &resource.CreateRequest{
+			ResourceType: "OCI::ObjectStorage::Bucket",
+			Properties:   props,
+			TargetConfig: json.RawMessage(`{"AdoptExisting": true}`),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusFailure, result.ProgressResult.OperationStatus)
+		assert.Empty(t, result.ProgressResult.NativeID)
+	})
+}
+
+func TestBucketCreateWithLifecycleRules(t *testing.T) {
+	props, err := json.Marshal(map[string]any{
+		"CompartmentId": "ocid1.compartment..xxx",
+		"Name":          "test-bucket",
+		"Namespace":     "testnamespace",
+		"LifecycleRules": []any{
+			map[string]any{"name": "archive-logs", "action": "ARCHIVE", "timeAmount": 30, "timeUnit": "DAYS"},
+		},
+	})
+	require.NoError(t, err)
+	request := &resource.CreateRequest{ResourceType: "OCI::ObjectStorage::Bucket", Properties: props}
+	policyDenied := canned{400, `{"code":"InsufficientServicePermissions","message":"service not authorized to archive objects"}`}
+
+	t.Run("success", func(t *testing.T) {
+		svc := newTestObjectStorageClient(t, map[route]canned{
+			{"POST", "/n/testnamespace/b"}:              {200, newTestBucketBody()},
+			{"PUT", "/n/testnamespace/b/test-bucket/l"}: {200, newTestLifecyclePolicyBody()},
+		})
+		p := objectstorage.NewBucketProvisionerWithSvc(svc)
+
+		result, err := p.Create(context.Background(), request)
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+		assert.Equal(t, "test-bucket", result.ProgressResult.NativeID)
+	})
+
+	t.Run("policy_fails_rolls_back", func(t *testing.T) {
+		svc := newTestObjectStorageClient(t, map[route]canned{
+			{"POST", "/n/testnamespace/b"}:               {200, newTestBucketBody()},
+			{"PUT", "/n/testnamespace/b/test-bucket/l"}:  policyDenied,
+			{"DELETE", "/n/testnamespace/b/test-bucket"}: {204, ""},
+		})
+		p := objectstorage.NewBucketProvisionerWithSvc(svc)
+
+		result, err := p.Create(context.Background(), request)
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusFailure, result.ProgressResult.OperationStatus)
+		assert.Equal(t, resource.OperationErrorCodeAccessDenied, result.ProgressResult.ErrorCode)
+		assert.Contains(t, result.ProgressResult.StatusMessage, "rolled back")
+		assert.Empty(t, result.ProgressResult.NativeID)
+	})
+
+	t.Run("rollback_fails_keeps_native_id", func(t *testing.T) {
+		svc := newTestObjectStorageClient(t, map[route]canned{
+			{"POST", "/n/testnamespace/b"}:               {200, newTestBucketBody()},
+			{"PUT", "/n/testnamespace/b/test-bucket/l"}:  policyDenied,
+			{"DELETE", "/n/testnamespace/b/test-bucket"}: {409, `{"code":"BucketNotEmpty","message":"bucket is not empty"}`},
+		})
+		p := objectstorage.NewBucketProvisionerWithSvc(svc)
+
+		result, err := p.Create(context.Background(), request)
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusFailure, result.ProgressResult.OperationStatus)
+		assert.Equal(t, resource.OperationErrorCodeAccessDenied, result.ProgressResult.ErrorCode)
+		assert.Contains(t, result.ProgressResult.StatusMessage, "left in place")
+		assert.Equal(t, "test-bucket", result.ProgressResult.NativeID)
+	})
+}
+
 func TestBucketUpdate(t *testing.T) {
 	svc := newTestObjectStorageClient(t, map[route]canned{
 		{"GET", "/n/testnamespace/b/test-bucket"}:  {200, newTestBucketBody()},
@@ -90,10 +354,167 @@ func TestBucketUpdate(t *testing.T) {
 	assert.Equal(t, "test-bucket", result.ProgressResult.NativeID)
 }
 
+func TestBucketUpdateMovesCompartment(t *testing.T) {
+	moved := `{
+		"name": "test-bucket",
+		"compartmentId": "ocid1.compartment..yyy",
+		"namespace": "testnamespace",
+		"publicAccessType": "NoPublicAccess",
+		"storageTier": "Standard"
+	}`
+	svc := newTestObjectStorageClient(t, map[route]canned{
+		{"GET", "/n/testnamespace/b/test-bucket"}:  {200, newTestBucketBody()},
+		{"POST", "/n/testnamespace/b/test-bucket"}: {200, moved},
+	})
+	p := objectstorage.NewBucketProvisionerWithSvc(svc)
+
+	props, err := json.Marshal(map[string]any{"CompartmentId": "ocid1.compartment..yyy"})
+	require.NoError(t, err)
+
+	result, err := p.Update(context.Background(), &resource.UpdateRequest{
+		NativeID:          "test-bucket",
+		ResourceType:      "OCI::ObjectStorage::Bucket",
+		DesiredProperties: props,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	assert.Equal(t, "test-bucket", result.ProgressResult.NativeID)
+}
+
+func TestBucketUpdateEncryptionAndAutoTiering(t *testing.T) {
+	tests := map[string]struct {
+		current string         // extra Bucket fields OCI reports before the update
+		desired map[string]any // desired state on top of the bucket's name
+		want    map[string]any // fields the UpdateBucket call must send; nil means absent
+	}{
+		"set_key": {
+			desired: map[string]any{"KmsKeyId": "ocid1.key..aaa"},
+			want:    map[string]any{"kmsKeyId": "ocid1.key..aaa"},
+		},
+		"change_key": {
+			current: `"kmsKeyId": "ocid1.key..aaa"`,
+			desired: map[string]any{"KmsKeyId": "ocid1.key..bbb"},
+			want:    map[string]any{"kmsKeyId": "ocid1.key..bbb"},
+		},
+		"keep_key": {
+			current: `"kmsKeyId": "ocid1.key..aaa"`,
+			desired: map[string]any{"KmsKeyId": "ocid1.key..aaa"},
+			want:    map[string]any{"kmsKeyId": "ocid1.key..aaa"},
+		},
+		"remove_key": {
+			current: `"kmsKeyId": "ocid1.key..aaa"`,
+			desired: map[string]any{},
+			want:    map[string]any{"kmsKeyId": ""},
+		},
+		"no_key": {
+			current: `"kmsKeyId": ""`,
+			desired: map[string]any{},
+			want:    map[string]any{"kmsKeyId": nil},
+		},
+		"enable_auto_tiering": {
+			current: `"autoTiering": "Disabled"`,
+			desired: map[string]any{"AutoTiering": "InfrequentAccess"},
+			want:    map[string]any{"autoTiering": "InfrequentAccess"},
+		},
+		"disable_auto_tiering": {
+			current: `"autoTiering": "InfrequentAccess"`,
+			desired: map[string]any{"AutoTiering": "Disabled"},
+			want:    map[string]any{"autoTiering": "Disabled"},
+		},
+		"auto_tiering_unset": {
+			current: `"autoTiering": "InfrequentAccess"`,
+			desired: map[string]any{},
+			want:    map[string]any{"autoTiering": nil},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			current := newTestBucketBody()
+			if tt.current != "" {
+				current = strings.Replace(current, `"storageTier": "Standard"`, `"storageTier": "Standard", `+tt.current, 1)
+			}
+			host, sent := newTestBucketRecorder(t, current)
+			p := objectstorage.NewBucketProvisionerWithSvc(newTestObjectStorageClientAt(t, host))
+
+			tt.desired["Name"] = "test-bucket"
+			props, err := json.Marshal(tt.desired)
+			require.NoError(t, err)
+
+			result, err := p.Update(context.Background(), &resource.UpdateRequest{
+				NativeID:          "test-bucket",
+				ResourceType:      "OCI::ObjectStorage::Bucket",
+				DesiredProperties: props,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+
+			body := sent()
+			for field, want := range tt.want {
+				if want == nil {
+					assert.NotContains(t, body, field)
+				} else {
+					assert.Equal(t, want, body[field], field)
+				}
+			}
+		})
+	}
+}
+
+func TestBucketCreateReadsBackEncryptionAndAutoTiering(t *testing.T) {
+	host, sent := newTestBucketRecorder(t, "")
+	p := objectstorage.NewBucketProvisionerWithSvc(newTestObjectStorageClientAt(t, host))
+
+	props, err := json.Marshal(map[string]any{
+		"CompartmentId": "ocid1.compartment..xxx",
+		"Name":          "test-bucket",
+		"KmsKeyId":      "ocid1.key..aaa",
+		"AutoTiering":   "InfrequentAccess",
+	})
+	require.NoError(t, err)
+
+	created, err := p.Create(context.Background(), &resource.CreateRequest{
+		ResourceType: "OCI::ObjectStorage::Bucket",
+		Properties:   props,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, created.ProgressResult.OperationStatus)
+	assert.Equal(t, "ocid1.key..aaa", sent()["kmsKeyId"])
+	assert.Equal(t, "InfrequentAccess", sent()["autoTiering"])
+
+	read, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "test-bucket"})
+	require.NoError(t, err)
+	var got map[string]any
+	require.NoError(t, json.Unmarshal([]byte(read.Properties), &got))
+	assert.Equal(t, "ocid1.key..aaa", got["KmsKeyId"])
+	assert.Equal(t, "InfrequentAccess", got["AutoTiering"])
+}
+
+func TestBucketUpdateStorageTierImmutable(t *testing.T) {
+	svc := newTestObjectStorageClient(t, map[route]canned{
+		{"GET", "/n/testnamespace/b/test-bucket"}: {200, newTestBucketBody()},
+	})
+	p := objectstorage.NewBucketProvisionerWithSvc(svc)
+
+	props, err := json.Marshal(map[string]any{"StorageTier": "Archive"})
+	require.NoError(t, err)
+
+	result, err := p.Update(context.Background(), &resource.UpdateRequest{
+		NativeID:          "test-bucket",
+		ResourceType:      "OCI::ObjectStorage::Bucket",
+		DesiredProperties: props,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusFailure, result.ProgressResult.OperationStatus)
+	assert.Equal(t, resource.OperationErrorCodeNotUpdatable, result.ProgressResult.ErrorCode)
+	assert.Equal(t, "replacement required: StorageTier", result.ProgressResult.StatusMessage)
+}
+
 func TestBucketDelete(t *testing.T) {
 	svc := newTestObjectStorageClient(t, map[route]canned{
-		{"GET", "/n/testnamespace/b/test-bucket"}:    {200, newTestBucketBody()},
-		{"DELETE", "/n/testnamespace/b/test-bucket"}: {204, ""},
+		{"GET", "/n/testnamespace/b/test-bucket"}:                     {200, newTestBucketBody()},
+		{"GET", "/n/testnamespace/b/test-bucket/l"}:                   {404, `{"code":"LifecyclePolicyNotFound","message":"not found"}`},
+		{"GET", "/n/testnamespace/b/test-bucket/replicationPolicies"}: {200, `[]`},
+		{"DELETE", "/n/testnamespace/b/test-bucket"}:                  {204, ""},
 	})
 	p := objectstorage.NewBucketProvisionerWithSvc(svc)
 
@@ -103,6 +524,115 @@ func TestBucketDelete(t *testing.T) {
 	assert.Equal(t, "test-bucket", result.ProgressResult.NativeID)
 }
 
+func TestBucketDeleteCleanup(t *testing.T) {
+	readRoutes := func() map[route]canned {
+		return map[route]canned{
+			{"GET", "/n/testnamespace/b/test-bucket"}:   {200, newTestBucketBody()},
+			{"GET", "/n/testnamespace/b/test-bucket/l"}: {404, `{"code":"LifecyclePolicyNotFound","message":"not found"}`},
+		}
+	}
+
+	t.Run("replication_policy_removed_first", func(t *testing.T) {
+		routes := readRoutes()
+		routes[route{"GET", "/n/testnamespace/b/test-bucket/replicationPolicies"}] = canned{200, `[{
+			"id": "repl-1",
+			"name": "to-phoenix",
+			"destinationRegionName": "us-phoenix-1",
+			"destinationBucketName": "test-bucket-replica",
+			"timeCreated": "2025-01-01T00:00:00.000Z",
+			"timeLastSync": "2025-01-01T00:00:00.000Z",
+			"status": "ACTIVE",
+			"statusMessage": ""
+		}]`}
+		routes[route{"DELETE", "/n/testnamespace/b/test-bucket/replicationPolicies/repl-1"}] = canned{204, ""}
+		routes[route{"DELETE", "/n/testnamespace/b/test-bucket"}] = canned{204, ""}
+		p := objectstorage.NewBucketProvisionerWithSvc(newTestObjectStorageClient(t, routes))
+
+		result, err := p.Delete(context.Background(), &resource.DeleteRequest{NativeID: "test-bucket"})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	})
+
+	t.Run("empty_before_delete", func(t *testing.T) {
+		routes := readRoutes()
+		routes[route{"GET", "/n/testnamespace/b/test-bucket/replicationPolicies"}] = canned{200, `[]`}
+		routes[route{"GET", "/n/testnamespace/b/test-bucket/objectversions"}] = canned{200, `{"items": [
+			{"name": "seed.json", "versionId": "v1", "isDeleteMarker": false, "timeModified": "2025-01-01T00:00:00.000Z"}
+		]}`}
+		routes[route{"DELETE", "/n/testnamespace/b/test-bucket/o/seed.json"}] = canned{204, ""}
+		routes[route{"GET", "/n/testnamespace/b/test-bucket/u"}] = canned{200, `[
+			{"namespace": "testnamespace", "bucket": "test-bucket", "object": "big.bin", "uploadId": "up-1", "timeCreated": "2025-01-01T00:00:00.000Z"}
+		]`}
+		routes[route{"DELETE", "/n/testnamespace/b/test-bucket/u/big.bin"}] = canned{204, ""}
+		routes[route{"DELETE", "/n/testnamespace/b/test-bucket"}] = canned{204, ""}
+		p := objectstorage.NewBucketProvisionerWithSvc(newTestObjectStorageClient(t, routes))
+
+		result, err := p.Delete(context.Background(), &resource.DeleteRequest{
+			NativeID:     "test-bucket",
+			TargetConfig: json.RawMessage(`{"EmptyBeforeDelete": true}`),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	})
+
+	t.Run("empty_before_delete_resumes_in_status", func(t *testing.T) {
+		routes := readRoutes()
+		routes[route{"GET", "/n/testnamespace/b/test-bucket/replicationPolicies"}] = canned{200, `[]`}
+		routes[route{"DELETE", "/n/testnamespace/b/test-bucket/o/a.json"}] = canned{204, ""}
+		routes[route{"DELETE", "/n/testnamespace/b/test-bucket/o/b.json"}] = canned{204, ""}
+		routes[route{"GET", "/n/testnamespace/b/test-bucket/u"}] = canned{200, `[]`}
+		routes[route{"DELETE", "/n/testnamespace/b/test-bucket"}] = canned{204, ""}
+		routes[route{"GET", "/n"}] = canned{200, `"testnamespace"`}
+		host := newTestPagedDispatcher(t, routes, map[route][]canned{
+			{"GET", "/n/testnamespace/b/test-bucket/objectversions"}: {
+				{200, `{"items": [{"name": "a.json", "versionId": "v1", "timeModified": "2025-01-01T00:00:00.000Z"}]}`},
+				{200, `{"items": [{"name": "b.json", "versionId": "v1", "timeModified": "2025-01-01T00:00:00.000Z"}]}`},
+			},
+		})
+		p := objectstorage.NewBucketProvisionerWithSvc(newTestObjectStorageClientAt(t, host))
+		targetConfig := json.RawMessage(`{"EmptyBeforeDelete": true}`)
+
+		deleted, err := p.Delete(context.Background(), &resource.DeleteRequest{NativeID: "test-bucket", TargetConfig: targetConfig})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusInProgress, deleted.ProgressResult.OperationStatus)
+		assert.Equal(t, "test-bucket/page-1", deleted.ProgressResult.RequestID)
+
+		status, err := p.Status(context.Background(), &resource.StatusRequest{
+			RequestID:    deleted.ProgressResult.RequestID,
+			NativeID:     "test-bucket",
+			TargetConfig: targetConfig,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusSuccess, status.ProgressResult.OperationStatus)
+	})
+
+	t.Run("other_conflict_reported_as_is", func(t *testing.T) {
+		routes := readRoutes()
+		routes[route{"GET", "/n/testnamespace/b/test-bucket/replicationPolicies"}] = canned{200, `[]`}
+		routes[route{"DELETE", "/n/testnamespace/b/test-bucket"}] = canned{409, `{"code":"Conflict","message":"bucket has an active retention rule"}`}
+		p := objectstorage.NewBucketProvisionerWithSvc(newTestObjectStorageClient(t, routes))
+
+		result, err := p.Delete(context.Background(), &resource.DeleteRequest{NativeID: "test-bucket"})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationErrorCodeResourceConflict, result.ProgressResult.ErrorCode)
+		assert.Contains(t, result.ProgressResult.StatusMessage, "active retention rule")
+		assert.NotContains(t, result.ProgressResult.StatusMessage, "emptyBeforeDelete")
+	})
+
+	t.Run("not_empty_without_flag", func(t *testing.T) {
+		routes := readRoutes()
+		routes[route{"GET", "/n/testnamespace/b/test-bucket/replicationPolicies"}] = canned{200, `[]`}
+		routes[route{"DELETE", "/n/testnamespace/b/test-bucket"}] = canned{409, `{"code":"BucketNotEmpty","message":"Bucket named 'test-bucket' is not empty"}`}
+		p := objectstorage.NewBucketProvisionerWithSvc(newTestObjectStorageClient(t, routes))
+
+		result, err := p.Delete(context.Background(), &resource.DeleteRequest{NativeID: "test-bucket"})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusFailure, result.ProgressResult.OperationStatus)
+		assert.Equal(t, resource.OperationErrorCodeResourceConflict, result.ProgressResult.ErrorCode)
+		assert.Contains(t, result.ProgressResult.StatusMessage, "set emptyBeforeDelete")
+	})
+}
+
 func TestBucketList(t *testing.T) {
 	svc := newTestObjectStorageClient(t, map[route]canned{
 		{"GET", "/n/testnamespace/b"}: {200, fmt.Sprintf(`[%s]`, newTestBucketBody())},
@@ -126,7 +656,11 @@ func newTestObjectStorageClient(t *testing.T, responses map[route]canned) *ociob
 	// The ObjectStorage client needs a namespace to build URLs.
 	// We add a GetNamespace route that returns "testnamespace".
 	responses[route{"GET", "/n"}] = canned{200, `"testnamespace"`}
-	host := newTestDispatcher(t, responses)
+	return newTestObjectStorageClientAt(t, newTestDispatcher(t, responses))
+}
+
+func newTestObjectStorageClientAt(t *testing.T, host string) *ociobjectstorage.ObjectStorageClient {
+	t.Helper()
 	c, err := ociobjectstorage.NewObjectStorageClientWithConfigurationProvider(fakeOCIConfigProvider(t))
 	require.NoError(t, err)
 	applyTestRetryPolicy(&c)
@@ -134,6 +668,39 @@ func newTestObjectStorageClient(t *testing.T, responses map[route]canned) *ociob
 	return &c
 }
 
+// newTestBucketRecorder serves a single bucket that starts out as current (no
+// bucket when empty). Creates and updates are recorded, and a create's body
+// becomes the bucket later Reads return. sent returns the last body written.
+func newTestBucketRecorder(t *testing.T, current string) (string, func() map[string]any) {
+	t.Helper()
+	var last map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/n":
+			fmt.Fprint(w, `"testnamespace"`)
+		case r.Method == http.MethodGet && r.URL.Path == "/n/testnamespace/b/test-bucket" && current != "":
+			fmt.Fprint(w, current)
+		case r.Method == http.MethodPost && (r.URL.Path == "/n/testnamespace/b" || r.URL.Path == "/n/testnamespace/b/test-bucket"):
+			body, _ := io.ReadAll(r.Body)
+			last = nil
+			_ = json.Unmarshal(body, &last)
+			if r.URL.Path == "/n/testnamespace/b" {
+				bucket := map[string]any{"namespace": "testnamespace"}
+				_ = json.Unmarshal(body, &bucket)
+				stored, _ := json.Marshal(bucket)
+				current = string(stored)
+			}
+			fmt.Fprint(w, newTestBucketBody())
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"code":"NotFound","message":"not found"}`)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL, func() map[string]any { return last }
+}
+
 func newTestBucketBody() string {
 	return `{
 		"name": "test-bucket",
@@ -143,3 +710,16 @@ func newTestBucketBody() string {
 		"storageTier": "Standard"
 	}`
 }
+
+func newTestLifecyclePolicyBody() string {
+	return `{
+		"items": [{
+			"name": "archive-logs",
+			"action": "ARCHIVE",
+			"timeAmount": 30,
+			"timeUnit": "DAYS",
+			"isEnabled": true,
+			"objectNameFilter": {"inclusionPrefixes": ["logs/"]}
+		}]
+	}`
+}
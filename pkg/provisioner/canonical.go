@@ -0,0 +1,116 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package provisioner
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+// Opaque is implemented by provisioners whose resources have map properties
+// keyed by data rather than by field names.
+type Opaque interface {
+	// OpaqueFields names the top-level properties canonicalization leaves alone.
+	OpaqueFields() []string
+}
+
+// canonicalSpec returns the util.CanonicalSpec for p's resources.
+func canonicalSpec(p Provisioner) util.CanonicalSpec {
+	if o, ok := p.(Opaque); ok {
+		return util.CanonicalSpec{Opaque: o.OpaqueFields()}
+	}
+	return util.CanonicalSpec{}
+}
+
+// canonical is a decorator that puts properties into the shape the Pkl schemas
+// give them (see util.CanonicalSpec) on the way in and on the way out: the
+// desired properties of Create and Update, and the properties of Reads and of
+// finished operations. Provisioners then see nested fields under a single
+// spelling, and a Read that builds a property in another shape no longer shows
+// up as drift.
+//
+// The spec also travels in the context, so util.ApplyPatchDocument applies a
+// patch to the canonical Read that formae computed it against. Properties that
+// aren't a JSON object are passed through for the provisioner to report.
+type canonical struct {
+	inner Provisioner
+	spec  util.CanonicalSpec
+}
+
+func (c *canonical) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	ctx = util.WithCanonicalSpec(ctx, c.spec)
+	canonicalRequest := *request
+	canonicalRequest.Properties = c.canonicalize(request.Properties)
+
+	result, err := c.inner.Create(ctx, &canonicalRequest)
+	if result != nil {
+		c.canonicalizeResult(result.ProgressResult)
+	}
+	return result, err
+}
+
+func (c *canonical) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	ctx = util.WithCanonicalSpec(ctx, c.spec)
+	canonicalRequest := *request
+	canonicalRequest.DesiredProperties = c.canonicalize(request.DesiredProperties)
+
+	result, err := c.inner.Update(ctx, &canonicalRequest)
+	if result != nil {
+		c.canonicalizeResult(result.ProgressResult)
+	}
+	return result, err
+}
+
+func (c *canonical) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	return c.inner.Delete(util.WithCanonicalSpec(ctx, c.spec), request)
+}
+
+func (c *canonical) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	result, err := c.inner.Status(util.WithCanonicalSpec(ctx, c.spec), request)
+	if result != nil {
+		c.canonicalizeResult(result.ProgressResult)
+	}
+	return result, err
+}
+
+func (c *canonical) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	result, err := c.inner.Read(util.WithCanonicalSpec(ctx, c.spec), request)
+	if err != nil || result == nil || result.Properties == "" {
+		return result, err
+	}
+	result.Properties = string(c.canonicalize([]byte(result.Properties)))
+	return result, nil
+}
+
+func (c *canonical) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	return c.inner.List(ctx, request)
+}
+
+func (c *canonical) canonicalizeResult(result *resource.ProgressResult) {
+	if result != nil && len(result.ResourceProperties) > 0 {
+		result.ResourceProperties = c.canonicalize(result.ResourceProperties)
+	}
+}
+
+// canonicalize returns the canonical form of a JSON object, or raw itself when
+// it is empty or not an object.
+func (c *canonical) canonicalize(raw []byte) []byte {
+	if len(raw) == 0 {
+		return raw
+	}
+	var props map[string]any
+	if err := json.Unmarshal(raw, &props); err != nil || props == nil {
+		return raw
+	}
+	c.spec.Canonicalize(props)
+	out, err := json.Marshal(props)
+	if err != nil {
+		return raw
+	}
+	return out
+}
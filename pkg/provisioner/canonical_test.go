@@ -0,0 +1,83 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package provisioner
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+func TestCanonical_Create_CanonicalizesDesiredProperties(t *testing.T) {
+	inner := &capturingProvisioner{mockProvisioner: mockProvisioner{
+		createResult: &resource.CreateResult{ProgressResult: &resource.ProgressResult{OperationStatus: resource.OperationStatusInProgress}},
+	}}
+
+	c := &canonical{inner: inner}
+	_, err := c.Create(context.Background(), &resource.CreateRequest{
+		ResourceType: "OCI::Core::RouteTable",
+		Properties:   json.RawMessage(`{"displayName":"public","RouteRules":[{"NetworkEntityId":"ocid1.internetgateway..aaa"}]}`),
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{"DisplayName":"public","RouteRules":[{"networkEntityId":"ocid1.internetgateway..aaa"}]}`
+	if string(inner.createProps) != want {
+		t.Fatalf("expected %s, got %s", want, inner.createProps)
+	}
+}
+
+func TestCanonical_Read_CanonicalizesProperties(t *testing.T) {
+	inner := &mockProvisioner{readResult: &resource.ReadResult{
+		Properties: `{"Id":"ocid1.instance..aaa","FreeformTags":{"env":"prod"},"Metadata":{"User_data":"I2Nsb3Vk"}}`,
+	}}
+
+	c := &canonical{inner: inner, spec: util.CanonicalSpec{Opaque: []string{"Metadata"}}}
+	result, err := c.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.instance..aaa"})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{"FreeformTags":[{"Key":"env","Value":"prod"}],"Id":"ocid1.instance..aaa","Metadata":{"User_data":"I2Nsb3Vk"}}`
+	if result.Properties != want {
+		t.Fatalf("expected %s, got %s", want, result.Properties)
+	}
+}
+
+func TestCanonical_Read_NotFoundPassesThrough(t *testing.T) {
+	inner := &mockProvisioner{readResult: &resource.ReadResult{ErrorCode: resource.OperationErrorCodeNotFound}}
+
+	c := &canonical{inner: inner}
+	result, err := c.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.instance..aaa"})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.ErrorCode != resource.OperationErrorCodeNotFound || result.Properties != "" {
+		t.Fatalf("expected an untouched NotFound, got %+v", result)
+	}
+}
+
+func TestCanonicalSpec_FromOpaqueProvisioner(t *testing.T) {
+	spec := canonicalSpec(&opaqueProvisioner{})
+	if len(spec.Opaque) != 1 || spec.Opaque[0] != "Metadata" {
+		t.Fatalf("expected Metadata to be opaque, got %v", spec.Opaque)
+	}
+	if spec := canonicalSpec(&mockProvisioner{}); len(spec.Opaque) != 0 {
+		t.Fatalf("expected no opaque fields, got %v", spec.Opaque)
+	}
+}
+
+type opaqueProvisioner struct {
+	mockProvisioner
+}
+
+func (o *opaqueProvisioner) OpaqueFields() []string {
+	return []string{"Metadata"}
+}
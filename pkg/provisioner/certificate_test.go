@@ -0,0 +1,68 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build integration
+
+package provisioner_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/loadbalancer"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertificateRead(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc := newTestLoadBalancerClient(t, map[route]canned{
+			{"GET", "/20170115/loadBalancers/ocid1.loadbalancer..aaa"}: {200, newTestLoadBalancerBody("ACTIVE")},
+		})
+		p := loadbalancer.NewCertificateProvisionerWithSvc(svc)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.loadbalancer..aaa/web-cert"})
+		require.NoError(t, err)
+		assert.Empty(t, result.ErrorCode)
+
+		var props map[string]any
+		require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+		assert.Equal(t, "ocid1.loadbalancer..aaa", props["LoadBalancerId"])
+		assert.Equal(t, "web-cert", props["CertificateName"])
+		assert.NotContains(t, props, "PrivateKey")
+		assert.NotContains(t, props, "Passphrase")
+	})
+
+	t.Run("certificate_missing", func(t *testing.T) {
+		svc := newTestLoadBalancerClient(t, map[route]canned{
+			{"GET", "/20170115/loadBalancers/ocid1.loadbalancer..aaa"}: {200, newTestLoadBalancerBody("ACTIVE")},
+		})
+		p := loadbalancer.NewCertificateProvisionerWithSvc(svc)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.loadbalancer..aaa/missing"})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationErrorCodeNotFound, result.ErrorCode)
+	})
+}
+
+func TestCertificateUpdateRequiresReplacement(t *testing.T) {
+	p := loadbalancer.NewCertificateProvisionerWithSvc(newTestLoadBalancerClient(t, map[route]canned{}))
+
+	result, err := p.Update(context.Background(), &resource.UpdateRequest{NativeID: "ocid1.loadbalancer..aaa/web-cert"})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusFailure, result.ProgressResult.OperationStatus)
+	assert.Equal(t, resource.OperationErrorCodeNotUpdatable, result.ProgressResult.ErrorCode)
+	assert.Contains(t, result.ProgressResult.StatusMessage, "replacement required: PublicCertificate")
+}
+
+func TestCertificateImmutableFields(t *testing.T) {
+	factory, err := provisioner.GetFactory("OCI::LoadBalancer::Certificate")
+	require.NoError(t, err)
+	immutable, ok := factory(nil).(provisioner.Immutable)
+	require.True(t, ok, "Certificate should declare its immutable fields")
+	assert.ElementsMatch(t, []string{"LoadBalancerId", "CertificateName", "PublicCertificate", "PrivateKey", "CaCertificate", "Passphrase"}, immutable.ImmutableFields())
+}
@@ -80,6 +80,65 @@ func TestCompartmentCreate(t *testing.T) {
 	assert.Equal(t, "ocid1.compartment..aaa", result.ProgressResult.NativeID)
 }
 
+func TestCompartmentCreateAdoptExisting(t *testing.T) {
+	conflict := canned{409, `{"code":"CompartmentAlreadyExists","message":"Compartment 'test-compartment' already exists"}`}
+	props, err := json.Marshal(map[string]any{
+		"CompartmentId": "ocid1.tenancy..xxx",
+		"Name":          "test-compartment",
+		"Description":   "test description",
+	})
+	require.NoError(t, err)
+
+	t.Run("adopted", func(t *testing.T) {
+		svc := newTestPolicyClient(t, map[route]canned{
+			{"POST", "/20160918/compartments"}: conflict,
+			{"GET", "/20160918/compartments"}:  {200, fmt.Sprintf(`[%s]`, newTestCompartmentBody("ACTIVE"))},
+		})
+		p := identity.NewCompartmentProvisionerWithSvc(svc)
+
+		result, err := p.Create(context.Background(), &resource.CreateRequest{
+			ResourceType: "OCI::Identity::Compartment",
+			Properties:   props,
+			TargetConfig: json.RawMessage(`{"AdoptExisting": true}`),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+		assert.Equal(t, "ocid1.compartment..aaa", result.ProgressResult.NativeID)
+	})
+
+	t.Run("adopted_by_default", func(t *testing.T) {
+		svc := newTestPolicyClient(t, map[route]canned{
+			{"POST", "/20160918/compartments"}: conflict,
+			{"GET", "/20160918/compartments"}:  {200, fmt.Sprintf(`[%s]`, newTestCompartmentBody("ACTIVE"))},
+		})
+		p := identity.NewCompartmentProvisionerWithSvc(svc)
+
+		result, err := p.Create(context.Background(), &resource.CreateRequest{
+			ResourceType: "OCI::Identity::Compartment",
+			Properties:   props,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+		assert.Equal(t, "ocid1.compartment..aaa", result.ProgressResult.NativeID)
+	})
+
+	t.Run("flag_false", func(t *testing.T) {
+		svc := newTestPolicyClient(t, map[route]canned{
+			{"POST", "/20160918/compartments"}: conflict,
+		})
+		p := identity.NewCompartmentProvisionerWithSvc(svc)
+
+		result, err := p.Create(context.Background(), &resource.CreateRequest{
+			ResourceType: "OCI::Identity::Compartment",
+			Properties:   props,
+			TargetConfig: json.RawMessage(`{"AdoptExisting": false}`),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusFailure, result.ProgressResult.OperationStatus)
+		assert.Equal(t, resource.OperationErrorCodeResourceConflict, result.ProgressResult.ErrorCode)
+	})
+}
+
 func TestCompartmentUpdate(t *testing.T) {
 	svc := newTestPolicyClient(t, map[route]canned{
 		{"GET", "/20160918/compartments/ocid1.compartment..aaa"}: {200, newTestCompartmentBody("ACTIVE")},
@@ -132,6 +191,23 @@ func TestCompartmentList(t *testing.T) {
 	assert.Equal(t, []string{"ocid1.compartment..aaa"}, result.NativeIDs)
 }
 
+func TestCompartmentListDefaultCompartment(t *testing.T) {
+	svc := newTestPolicyClient(t, map[route]canned{
+		{"GET", "/20160918/compartments"}: {200, fmt.Sprintf(`[%s]`, newTestCompartmentBody("ACTIVE"))},
+	})
+	p := identity.NewCompartmentProvisionerWithSvc(svc)
+
+	// Without a CompartmentId the default compartment is the discovery root,
+	// so it is listed alongside its children
+	result, err := p.List(context.Background(), &resource.ListRequest{
+		ResourceType:         "OCI::Identity::Compartment",
+		TargetConfig:         json.RawMessage(`{"DefaultCompartmentId": "ocid1.compartment..default"}`),
+		AdditionalProperties: map[string]string{},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ocid1.compartment..default", "ocid1.compartment..aaa"}, result.NativeIDs)
+}
+
 // Helpers
 
 func newTestCompartmentBody(lifecycleState string) string {
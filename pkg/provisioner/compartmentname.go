@@ -0,0 +1,174 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package provisioner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+// compartmentResolver maps a compartment path ("prod/network") to its OCID.
+type compartmentResolver func(ctx context.Context, path string) (string, error)
+
+// resolveCompartmentPath resolves paths relative to the tenancy of clients.
+func resolveCompartmentPath(clients *client.Clients) compartmentResolver {
+	return func(ctx context.Context, path string) (string, error) {
+		identityClient, err := clients.GetIdentityClient()
+		if err != nil {
+			return "", fmt.Errorf("failed to get Identity client: %w", err)
+		}
+		tenancyId, err := clients.GetConfigurationProvider().TenancyOCID()
+		if err != nil {
+			return "", fmt.Errorf("failed to get tenancy OCID: %w", err)
+		}
+		return util.ResolveCompartmentByName(ctx, identityClient, tenancyId, path)
+	}
+}
+
+// compartmentName is a decorator that lets resources name their compartment by
+// path in CompartmentName instead of giving its OCID in CompartmentId. Create
+// and Update get CompartmentName swapped for the resolved CompartmentId, so the
+// provisioners only ever see CompartmentId. Reads report CompartmentId only;
+// CompartmentName is write-only in the schema.
+type compartmentName struct {
+	inner   Provisioner
+	resolve compartmentResolver
+}
+
+func (c *compartmentName) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	props, changed, err := c.resolveProperties(ctx, request.Properties)
+	if err != nil {
+		return nil, err
+	}
+	if !changed {
+		return c.inner.Create(ctx, request)
+	}
+
+	resolved := *request
+	resolved.Properties = props
+	return c.inner.Create(ctx, &resolved)
+}
+
+func (c *compartmentName) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	props, changed, err := c.resolveProperties(ctx, request.DesiredProperties)
+	if err != nil {
+		return nil, err
+	}
+	patch, patchChanged, err := c.resolvePatch(ctx, request.PatchDocument)
+	if err != nil {
+		return nil, err
+	}
+	if !changed && !patchChanged {
+		return c.inner.Update(ctx, request)
+	}
+
+	resolved := *request
+	resolved.DesiredProperties = props
+	resolved.PatchDocument = patch
+	return c.inner.Update(ctx, &resolved)
+}
+
+func (c *compartmentName) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	return c.inner.Delete(ctx, request)
+}
+
+func (c *compartmentName) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	return c.inner.Status(ctx, request)
+}
+
+func (c *compartmentName) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	return c.inner.Read(ctx, request)
+}
+
+func (c *compartmentName) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	return c.inner.List(ctx, request)
+}
+
+// resolveProperties replaces CompartmentName in raw with CompartmentId. It
+// reports false, with raw unchanged, when there is no CompartmentName.
+func (c *compartmentName) resolveProperties(ctx context.Context, raw json.RawMessage) (json.RawMessage, bool, error) {
+	if len(raw) == 0 {
+		return raw, false, nil
+	}
+	var props map[string]any
+	if err := json.Unmarshal(raw, &props); err != nil {
+		return nil, false, fmt.Errorf("failed to parse properties: %w", err)
+	}
+	path, ok := props["CompartmentName"].(string)
+	if !ok {
+		return raw, false, nil
+	}
+	if _, ok := props["CompartmentId"]; ok {
+		return nil, false, fmt.Errorf("set CompartmentId or CompartmentName, not both")
+	}
+
+	id, err := c.resolve(ctx, path)
+	if err != nil {
+		return nil, false, err
+	}
+	delete(props, "CompartmentName")
+	props["CompartmentId"] = id
+
+	resolved, err := json.Marshal(props)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to marshal properties: %w", err)
+	}
+	return resolved, true, nil
+}
+
+// resolvePatch rewrites patch operations on /CompartmentName into operations
+// on /CompartmentId with the resolved OCID. Removing CompartmentName is
+// dropped: the resource keeps its compartment, and switching to CompartmentId
+// arrives as its own operation.
+func (c *compartmentName) resolvePatch(ctx context.Context, patch *string) (*string, bool, error) {
+	if patch == nil || *patch == "" {
+		return patch, false, nil
+	}
+	var ops []map[string]any
+	if err := json.Unmarshal([]byte(*patch), &ops); err != nil {
+		return nil, false, fmt.Errorf("failed to decode patch document: %w", err)
+	}
+
+	changed := false
+	kept := ops[:0]
+	for _, op := range ops {
+		if op["path"] != "/CompartmentName" {
+			kept = append(kept, op)
+			continue
+		}
+		if op["op"] == "remove" {
+			changed = true
+			continue
+		}
+		path, ok := op["value"].(string)
+		if !ok {
+			kept = append(kept, op)
+			continue
+		}
+		id, err := c.resolve(ctx, path)
+		if err != nil {
+			return nil, false, err
+		}
+		op["path"] = "/CompartmentId"
+		op["value"] = id
+		kept = append(kept, op)
+		changed = true
+	}
+	if !changed {
+		return patch, false, nil
+	}
+
+	resolved, err := json.Marshal(kept)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to marshal patch document: %w", err)
+	}
+	s := string(resolved)
+	return &s, true, nil
+}
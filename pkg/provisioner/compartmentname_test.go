@@ -0,0 +1,98 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package provisioner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+func fakeCompartmentResolver(ctx context.Context, path string) (string, error) {
+	if path == "prod/network" {
+		return "ocid1.compartment..network", nil
+	}
+	return "", fmt.Errorf("no active compartment named %q", path)
+}
+
+func TestCompartmentName_Create_ResolvesPath(t *testing.T) {
+	inner := &capturingProvisioner{
+		mockProvisioner: mockProvisioner{
+			createResult: &resource.CreateResult{ProgressResult: &resource.ProgressResult{}},
+		},
+	}
+
+	c := &compartmentName{inner: inner, resolve: fakeCompartmentResolver}
+	_, err := c.Create(context.Background(), &resource.CreateRequest{
+		ResourceType: "OCI::Core::Vcn",
+		Properties:   json.RawMessage(`{"CompartmentName":"prod/network","DisplayName":"vcn"}`),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `{"CompartmentId":"ocid1.compartment..network","DisplayName":"vcn"}`
+	if string(inner.createProps) != want {
+		t.Errorf("expected Create properties %s, got %s", want, inner.createProps)
+	}
+}
+
+func TestCompartmentName_Create_PassesThroughCompartmentId(t *testing.T) {
+	inner := &capturingProvisioner{
+		mockProvisioner: mockProvisioner{
+			createResult: &resource.CreateResult{ProgressResult: &resource.ProgressResult{}},
+		},
+	}
+
+	c := &compartmentName{inner: inner, resolve: fakeCompartmentResolver}
+	props := `{"CompartmentId":"ocid1.compartment..xxx"}`
+	if _, err := c.Create(context.Background(), &resource.CreateRequest{Properties: json.RawMessage(props)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(inner.createProps) != props {
+		t.Errorf("expected properties to pass through unchanged, got %s", inner.createProps)
+	}
+}
+
+func TestCompartmentName_Create_RejectsBoth(t *testing.T) {
+	c := &compartmentName{inner: &mockProvisioner{}, resolve: fakeCompartmentResolver}
+	_, err := c.Create(context.Background(), &resource.CreateRequest{
+		Properties: json.RawMessage(`{"CompartmentId":"ocid1.compartment..xxx","CompartmentName":"prod/network"}`),
+	})
+	if err == nil {
+		t.Fatal("expected an error when both CompartmentId and CompartmentName are set")
+	}
+}
+
+func TestCompartmentName_ResolvePatch(t *testing.T) {
+	c := &compartmentName{resolve: fakeCompartmentResolver}
+	patch := `[{"op":"replace","path":"/CompartmentName","value":"prod/network"}]`
+
+	resolved, changed, err := c.resolvePatch(context.Background(), &patch)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `[{"op":"replace","path":"/CompartmentId","value":"ocid1.compartment..network"}]`
+	if !changed || *resolved != want {
+		t.Errorf("expected patch %s, got %s", want, *resolved)
+	}
+}
+
+func TestCompartmentName_ResolvePatch_DropsRemove(t *testing.T) {
+	c := &compartmentName{resolve: fakeCompartmentResolver}
+	patch := `[{"op":"remove","path":"/CompartmentName"},{"op":"add","path":"/CompartmentId","value":"ocid1.compartment..xxx"}]`
+
+	resolved, changed, err := c.resolvePatch(context.Background(), &patch)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `[{"op":"add","path":"/CompartmentId","value":"ocid1.compartment..xxx"}]`
+	if !changed || *resolved != want {
+		t.Errorf("expected patch %s, got %s", want, *resolved)
+	}
+}
@@ -0,0 +1,128 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package provisioner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+// confirmDelete is a decorator that re-reads a resource after a Delete reports
+// success, to make sure it stays gone. Some OCI services answer 404 right after
+// a delete and then serve the resource again for a few seconds while their
+// replicas catch up; reporting success on the first 404 lets formae move on
+// (deleting the parent, recreating under the same name) and then flap.
+//
+// The target's DeleteConfirmAttempts Reads run DeleteConfirmInterval apart, and
+// every one must find the resource NotFound or in a terminal lifecycle state.
+// A resource that shows up again turns the result into a NotStabilized failure,
+// which formae retries. Async deletes are left alone: their Status polls already
+// wait for the resource to disappear, and so are provisioners that implement
+// UnconfirmedDelete.
+type confirmDelete struct {
+	inner Provisioner
+	skip  bool
+}
+
+// UnconfirmedDelete is implemented by provisioners whose Delete leaves the
+// resource in place, such as the default security list and route table that
+// only go away with their VCN. Re-reading them after a delete would always
+// find them and fail it.
+type UnconfirmedDelete interface {
+	SkipsDeleteConfirmation()
+}
+
+// skipsDeleteConfirmation reports whether p opts out of delete confirmation.
+func skipsDeleteConfirmation(p Provisioner) bool {
+	_, ok := p.(UnconfirmedDelete)
+	return ok
+}
+
+func (c *confirmDelete) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	return c.inner.Create(ctx, request)
+}
+
+func (c *confirmDelete) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	return c.inner.Update(ctx, request)
+}
+
+func (c *confirmDelete) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	result, err := c.inner.Delete(ctx, request)
+	if c.skip || err != nil || result == nil || result.ProgressResult == nil || result.ProgressResult.OperationStatus != resource.OperationStatusSuccess {
+		return result, err
+	}
+
+	attempts, interval := config.FromTargetConfig(request.TargetConfig).DeleteConfirmation()
+	for attempt := 1; attempt <= attempts; attempt++ {
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		gone, err := c.gone(ctx, request.NativeID, request.ResourceType, request.TargetConfig)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			// A failed Read says nothing either way; the next attempt decides.
+			continue
+		}
+		if !gone {
+			pr := result.ProgressResult
+			pr.OperationStatus = resource.OperationStatusFailure
+			pr.ErrorCode = resource.OperationErrorCodeNotStabilized
+			pr.StatusMessage = fmt.Sprintf("%s %s was deleted but could still be read %s later; OCI may not have caught up yet", request.ResourceType, request.NativeID, time.Duration(attempt)*interval)
+			return result, nil
+		}
+	}
+
+	return result, nil
+}
+
+// gone reports whether a Read finds the resource NotFound or in a terminal
+// lifecycle state.
+func (c *confirmDelete) gone(ctx context.Context, nativeID, resourceType string, targetConfig json.RawMessage) (bool, error) {
+	readResp, err := c.inner.Read(ctx, &resource.ReadRequest{
+		NativeID:     nativeID,
+		ResourceType: resourceType,
+		TargetConfig: targetConfig,
+	})
+	if err != nil {
+		return false, err
+	}
+	if readResp.ErrorCode == resource.OperationErrorCodeNotFound {
+		return true, nil
+	}
+	if readResp.ErrorCode != "" {
+		return false, fmt.Errorf("read %s: %s", nativeID, readResp.ErrorCode)
+	}
+
+	var props struct {
+		LifecycleState string `json:"LifecycleState"`
+	}
+	_ = json.Unmarshal([]byte(readResp.Properties), &props)
+	return util.IsTerminal(props.LifecycleState), nil
+}
+
+func (c *confirmDelete) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	return c.inner.Status(ctx, request)
+}
+
+func (c *confirmDelete) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	return c.inner.Read(ctx, request)
+}
+
+func (c *confirmDelete) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	return c.inner.List(ctx, request)
+}
@@ -0,0 +1,107 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package provisioner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+// flappingProvisioner deletes synchronously and answers each Read after that
+// with the next entry of reads; nil means NotFound.
+type flappingProvisioner struct {
+	mockProvisioner
+	deleteStatus resource.OperationStatus
+	reads        []*resource.ReadResult
+	readCount    int
+}
+
+func (f *flappingProvisioner) Delete(_ context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	return &resource.DeleteResult{ProgressResult: &resource.ProgressResult{
+		Operation:       resource.OperationDelete,
+		OperationStatus: f.deleteStatus,
+		NativeID:        request.NativeID,
+	}}, nil
+}
+
+func (f *flappingProvisioner) Read(_ context.Context, _ *resource.ReadRequest) (*resource.ReadResult, error) {
+	f.readCount++
+	if f.readCount > len(f.reads) || f.reads[f.readCount-1] == nil {
+		return &resource.ReadResult{ErrorCode: resource.OperationErrorCodeNotFound}, nil
+	}
+	return f.reads[f.readCount-1], nil
+}
+
+func confirmDeleteRequest(targetConfig string) *resource.DeleteRequest {
+	return &resource.DeleteRequest{
+		NativeID:     "ocid1.policy.oc1..abc",
+		ResourceType: "OCI::Identity::Policy",
+		TargetConfig: json.RawMessage(targetConfig),
+	}
+}
+
+func TestConfirmDelete(t *testing.T) {
+	present := &resource.ReadResult{Properties: `{"Id":"ocid1.policy.oc1..abc","LifecycleState":"ACTIVE"}`}
+	terminated := &resource.ReadResult{Properties: `{"Id":"ocid1.policy.oc1..abc","LifecycleState":"TERMINATED"}`}
+
+	tests := []struct {
+		name         string
+		targetConfig string
+		deleteStatus resource.OperationStatus
+		reads        []*resource.ReadResult
+		wantStatus   resource.OperationStatus
+		wantReads    int
+	}{
+		{name: "stays gone", targetConfig: `{"DeleteConfirmInterval":"1ms"}`, deleteStatus: resource.OperationStatusSuccess,
+			wantStatus: resource.OperationStatusSuccess, wantReads: 2},
+		{name: "terminated counts as gone", targetConfig: `{"DeleteConfirmAttempts":3,"DeleteConfirmInterval":"1ms"}`, deleteStatus: resource.OperationStatusSuccess,
+			reads: []*resource.ReadResult{terminated, nil, terminated}, wantStatus: resource.OperationStatusSuccess, wantReads: 3},
+		{name: "reappears", targetConfig: `{"DeleteConfirmInterval":"1ms"}`, deleteStatus: resource.OperationStatusSuccess,
+			reads: []*resource.ReadResult{nil, present}, wantStatus: resource.OperationStatusFailure, wantReads: 2},
+		{name: "off", targetConfig: `{"DeleteConfirmAttempts":-1}`, deleteStatus: resource.OperationStatusSuccess,
+			reads: []*resource.ReadResult{present}, wantStatus: resource.OperationStatusSuccess, wantReads: 0},
+		{name: "async delete left alone", targetConfig: `{"DeleteConfirmInterval":"1ms"}`, deleteStatus: resource.OperationStatusInProgress,
+			reads: []*resource.ReadResult{present}, wantStatus: resource.OperationStatusInProgress, wantReads: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner := &flappingProvisioner{deleteStatus: tt.deleteStatus, reads: tt.reads}
+			c := &confirmDelete{inner: inner}
+
+			result, err := c.Delete(context.Background(), confirmDeleteRequest(tt.targetConfig))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := result.ProgressResult.OperationStatus; got != tt.wantStatus {
+				t.Fatalf("expected %s, got %s (%s)", tt.wantStatus, got, result.ProgressResult.StatusMessage)
+			}
+			if inner.readCount != tt.wantReads {
+				t.Fatalf("expected %d Reads, got %d", tt.wantReads, inner.readCount)
+			}
+			if tt.wantStatus == resource.OperationStatusFailure && result.ProgressResult.ErrorCode != resource.OperationErrorCodeNotStabilized {
+				t.Fatalf("expected NotStabilized, got %q", result.ProgressResult.ErrorCode)
+			}
+		})
+	}
+}
+
+func TestConfirmDelete_Cancelled(t *testing.T) {
+	inner := &flappingProvisioner{deleteStatus: resource.OperationStatusSuccess}
+	c := &confirmDelete{inner: inner}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := c.Delete(ctx, confirmDeleteRequest(`{"DeleteConfirmInterval":"1h"}`))
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if inner.readCount != 0 {
+		t.Fatalf("expected no Reads after cancellation, got %d", inner.readCount)
+	}
+}
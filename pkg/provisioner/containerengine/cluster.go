@@ -9,10 +9,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
+	"strconv"
 
 	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/oracle/oci-go-sdk/v65/containerengine"
+	"github.com/oracle/oci-go-sdk/v65/core"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
@@ -23,16 +27,30 @@ type ClusterProvisioner struct {
 	clients *client.Clients
 }
 
-var _ provisioner.Provisioner = &ClusterProvisioner{}
+var (
+	_ provisioner.Provisioner = &ClusterProvisioner{}
+	_ provisioner.Opaque      = &ClusterProvisioner{}
+)
 
 func init() {
 	provisioner.Register("OCI::ContainerEngine::Cluster", NewClusterProvisioner)
+	provisioner.DependsOn("OCI::ContainerEngine::Cluster",
+		"OCI::Identity::Compartment",
+		"OCI::Core::VCN",
+		"OCI::Core::Subnet",
+		"OCI::Core::NetworkSecurityGroup",
+	)
 }
 
 func NewClusterProvisioner(clients *client.Clients) provisioner.Provisioner {
 	return &ClusterProvisioner{clients: clients}
 }
 
+// OpaqueFields lists Endpoints, a map from endpoint kind to address.
+func (p *ClusterProvisioner) OpaqueFields() []string {
+	return []string{"Endpoints"}
+}
+
 func (p *ClusterProvisioner) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
 	client, err := p.clients.GetContainerEngineClient()
 	if err != nil {
@@ -44,95 +62,14 @@ func (p *ClusterProvisioner) Create(ctx context.Context, request *resource.Creat
 		return nil, fmt.Errorf("failed to parse properties: %w", err)
 	}
 
-	// Extract required properties - handle both direct strings and resolved references
-	compartmentId, _ := util.ExtractString(props, "CompartmentId")
-	vcnId, _ := util.ExtractString(props, "VcnId")
-	k8sVersion, _ := util.ExtractString(props, "KubernetesVersion")
-
-	createDetails := containerengine.CreateClusterDetails{
-		CompartmentId:     common.String(compartmentId),
-		VcnId:             common.String(vcnId),
-		KubernetesVersion: common.String(k8sVersion),
-	}
-
-	if name, ok := util.ExtractString(props, "Name"); ok {
-		createDetails.Name = common.String(name)
-	}
-
-	if clusterType, ok := util.ExtractString(props, "ClusterType"); ok {
-		createDetails.Type = containerengine.ClusterTypeEnum(clusterType)
-	}
-
-	// Parse EndpointConfig (nested class fields stay camelCase)
-	if endpointConfig, ok := props["EndpointConfig"].(map[string]any); ok {
-		config := &containerengine.CreateClusterEndpointConfigDetails{}
-		if subnetId, ok := util.ExtractString(endpointConfig, "subnetId"); ok {
-			config.SubnetId = common.String(subnetId)
-		} else if subnetId, ok := util.ExtractString(endpointConfig, "SubnetId"); ok {
-			config.SubnetId = common.String(subnetId)
-		}
-		if isPublicIpEnabled, ok := util.ExtractBool(endpointConfig, "isPublicIpEnabled"); ok {
-			config.IsPublicIpEnabled = common.Bool(isPublicIpEnabled)
-		} else if isPublicIpEnabled, ok := util.ExtractBool(endpointConfig, "IsPublicIpEnabled"); ok {
-			config.IsPublicIpEnabled = common.Bool(isPublicIpEnabled)
-		}
-		if nsgIds, ok := util.ExtractStringSlice(endpointConfig, "nsgIds"); ok {
-			config.NsgIds = nsgIds
-		} else if nsgIds, ok := util.ExtractStringSlice(endpointConfig, "NsgIds"); ok {
-			config.NsgIds = nsgIds
-		}
-		createDetails.EndpointConfig = config
-	}
+	createDetails := parseCreateClusterDetails(props)
 
-	// Parse Options (nested class fields stay camelCase - no SubResourceHint)
-	if options, ok := props["Options"].(map[string]any); ok {
-		clusterOptions := &containerengine.ClusterCreateOptions{}
-
-		if serviceLbSubnetIds, ok := util.ExtractStringSlice(options, "serviceLbSubnetIds"); ok {
-			clusterOptions.ServiceLbSubnetIds = serviceLbSubnetIds
-		}
-
-		if kubernetesNetworkConfig, ok := options["kubernetesNetworkConfig"].(map[string]any); ok {
-			networkConfig := &containerengine.KubernetesNetworkConfig{}
-			if podsCidr, ok := util.ExtractString(kubernetesNetworkConfig, "podsCidr"); ok {
-				networkConfig.PodsCidr = common.String(podsCidr)
-			}
-			if servicesCidr, ok := util.ExtractString(kubernetesNetworkConfig, "servicesCidr"); ok {
-				networkConfig.ServicesCidr = common.String(servicesCidr)
-			}
-			clusterOptions.KubernetesNetworkConfig = networkConfig
-		}
-
-		if addOns, ok := options["addOns"].(map[string]any); ok {
-			addOnOptions := &containerengine.AddOnOptions{}
-			if isKubernetesDashboardEnabled, ok := util.ExtractBool(addOns, "isKubernetesDashboardEnabled"); ok {
-				addOnOptions.IsKubernetesDashboardEnabled = common.Bool(isKubernetesDashboardEnabled)
-			}
-			if isTillerEnabled, ok := util.ExtractBool(addOns, "isTillerEnabled"); ok {
-				addOnOptions.IsTillerEnabled = common.Bool(isTillerEnabled)
-			}
-			clusterOptions.AddOns = addOnOptions
-		}
-
-		if admissionControllerOptions, ok := options["admissionControllerOptions"].(map[string]any); ok {
-			admissionOptions := &containerengine.AdmissionControllerOptions{}
-			if isPodSecurityPolicyEnabled, ok := util.ExtractBool(admissionControllerOptions, "isPodSecurityPolicyEnabled"); ok {
-				admissionOptions.IsPodSecurityPolicyEnabled = common.Bool(isPodSecurityPolicyEnabled)
-			}
-			clusterOptions.AdmissionControllerOptions = admissionOptions
-		}
-
-		createDetails.Options = clusterOptions
-	}
-
-	if freeformTags, ok := util.ExtractFreeformTags(props, "FreeformTags"); ok {
-		createDetails.FreeformTags = freeformTags
-	}
-	if definedTags, ok := util.ExtractDefinedTags(props, "DefinedTags"); ok {
-		createDetails.DefinedTags = definedTags
+	if err := p.validateEndpointSubnet(ctx, createDetails); err != nil {
+		return nil, err
 	}
 
 	createReq := containerengine.CreateClusterRequest{
+		OpcRetryToken:        common.String(util.CreateRetryToken(request)),
 		CreateClusterDetails: createDetails,
 	}
 
@@ -284,7 +221,7 @@ func (p *ClusterProvisioner) Read(ctx context.Context, request *resource.ReadReq
 	resp, err := client.GetCluster(ctx, getReq)
 	if err != nil {
 		// Check if not found
-		if serviceErr, ok := common.IsServiceError(err); ok && serviceErr.GetHTTPStatusCode() == 404 {
+		if util.IsNotFound(err) {
 			return &resource.ReadResult{
 				ResourceType: "OCI::ContainerEngine::Cluster",
 				ErrorCode:    resource.OperationErrorCodeNotFound,
@@ -300,112 +237,23 @@ func (p *ClusterProvisioner) Read(ctx context.Context, request *resource.ReadReq
 		}, nil
 	}
 
-	// Build properties map
-	props := map[string]any{
-		"CompartmentId":     *resp.CompartmentId,
-		"Id":                *resp.Id,
-		"VcnId":             *resp.VcnId,
-		"KubernetesVersion": *resp.KubernetesVersion,
-	}
+	cfg := config.FromTargetConfig(request.TargetConfig)
+	props := buildClusterProperties(resp.Cluster, cfg.HiddenTagNamespaces())
 
-	if resp.Name != nil {
-		props["Name"] = *resp.Name
-	}
-	if resp.Type != "" {
-		props["ClusterType"] = string(resp.Type)
-	}
-	if resp.LifecycleState != "" {
-		props["LifecycleState"] = string(resp.LifecycleState)
-	}
-	if resp.Endpoints != nil {
-		endpoints := map[string]any{}
-		if resp.Endpoints.Kubernetes != nil {
-			endpoints["Kubernetes"] = *resp.Endpoints.Kubernetes
-		}
-		if resp.Endpoints.PublicEndpoint != nil {
-			endpoints["PublicEndpoint"] = *resp.Endpoints.PublicEndpoint
-		}
-		if resp.Endpoints.PrivateEndpoint != nil {
-			endpoints["PrivateEndpoint"] = *resp.Endpoints.PrivateEndpoint
-		}
-		if len(endpoints) > 0 {
-			props["Endpoints"] = endpoints
-		}
-	}
-
-	// EndpointConfig - required for patches to work
-	if resp.EndpointConfig != nil {
-		endpointConfig := map[string]any{}
-		if resp.EndpointConfig.SubnetId != nil {
-			endpointConfig["subnetId"] = *resp.EndpointConfig.SubnetId
-		}
-		if resp.EndpointConfig.IsPublicIpEnabled != nil {
-			endpointConfig["isPublicIpEnabled"] = *resp.EndpointConfig.IsPublicIpEnabled
-		}
-		if len(resp.EndpointConfig.NsgIds) > 0 {
-			endpointConfig["nsgIds"] = resp.EndpointConfig.NsgIds
-		}
-		if len(endpointConfig) > 0 {
-			props["EndpointConfig"] = endpointConfig
-		}
-	}
-
-	// Options - required for patches to work
-	if resp.Options != nil {
-		options := map[string]any{}
-		if len(resp.Options.ServiceLbSubnetIds) > 0 {
-			options["serviceLbSubnetIds"] = resp.Options.ServiceLbSubnetIds
-		}
-		if resp.Options.KubernetesNetworkConfig != nil {
-			networkConfig := map[string]any{}
-			if resp.Options.KubernetesNetworkConfig.PodsCidr != nil {
-				networkConfig["podsCidr"] = *resp.Options.KubernetesNetworkConfig.PodsCidr
-			}
-			if resp.Options.KubernetesNetworkConfig.ServicesCidr != nil {
-				networkConfig["servicesCidr"] = *resp.Options.KubernetesNetworkConfig.ServicesCidr
-			}
-			if len(networkConfig) > 0 {
-				options["kubernetesNetworkConfig"] = networkConfig
-			}
-		}
-		if resp.Options.AddOns != nil {
-			addOns := map[string]any{}
-			if resp.Options.AddOns.IsKubernetesDashboardEnabled != nil {
-				addOns["isKubernetesDashboardEnabled"] = *resp.Options.AddOns.IsKubernetesDashboardEnabled
-			}
-			if resp.Options.AddOns.IsTillerEnabled != nil {
-				addOns["isTillerEnabled"] = *resp.Options.AddOns.IsTillerEnabled
-			}
-			if len(addOns) > 0 {
-				options["addOns"] = addOns
-			}
+	// The kubeconfig also carries the CA certificate
+	endpoint := kubeconfigEndpoint(resp.Cluster, cfg.PrivateKubeconfig)
+	if kc, err := fetchKubeconfig(ctx, client, request.NativeID, endpoint); err == nil {
+		props["Kubeconfig"] = kc
+		if ca := kubeconfigCACert(kc); ca != "" {
+			props["CertificateAuthority"] = ca
 		}
-		if resp.Options.AdmissionControllerOptions != nil {
-			admissionOpts := map[string]any{}
-			if resp.Options.AdmissionControllerOptions.IsPodSecurityPolicyEnabled != nil {
-				admissionOpts["isPodSecurityPolicyEnabled"] = *resp.Options.AdmissionControllerOptions.IsPodSecurityPolicyEnabled
+		if endpoint == containerengine.CreateClusterKubeconfigContentDetailsEndpointPrivateEndpoint && cfg.BastionId != "" {
+			if forward, ok := bastionPortForward(cfg.BastionId, resp.Cluster); ok {
+				props["BastionPortForward"] = forward
 			}
-			if len(admissionOpts) > 0 {
-				options["admissionControllerOptions"] = admissionOpts
-			}
-		}
-		if len(options) > 0 {
-			props["Options"] = options
 		}
 	}
 
-	if resp.FreeformTags != nil {
-		props["FreeformTags"] = util.FreeformTagsToList(resp.FreeformTags)
-	}
-	if resp.DefinedTags != nil {
-		props["DefinedTags"] = util.DefinedTagsToList(resp.DefinedTags)
-	}
-
-	// Fetch CA certificate from kubeconfig
-	if ca, err := fetchCACert(ctx, client, request.NativeID); err == nil && ca != "" {
-		props["CertificateAuthority"] = ca
-	}
-
 	propBytes, err := json.Marshal(props)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal Cluster properties: %w", err)
@@ -424,7 +272,7 @@ func (p *ClusterProvisioner) List(ctx context.Context, request *resource.ListReq
 	}
 
 	// CompartmentId is required for listing
-	compartmentId, ok := request.AdditionalProperties["CompartmentId"]
+	compartmentId, ok := util.ListCompartmentId(request)
 	if !ok {
 		return nil, fmt.Errorf("CompartmentId is required for listing Clusters")
 	}
@@ -437,16 +285,23 @@ func (p *ClusterProvisioner) List(ctx context.Context, request *resource.ListReq
 			containerengine.ClusterLifecycleStateActive,
 			containerengine.ClusterLifecycleStateUpdating,
 		},
+		Limit: util.ListPageSize(request.TargetConfig),
 	}
 
-	resp, err := client.ListClusters(ctx, listReq)
+	nativeIDs, err := util.ListAllPages(func(page *string) ([]string, *string, error) {
+		listReq.Page = page
+		resp, err := client.ListClusters(ctx, listReq)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list Clusters: %w", err)
+		}
+		ids := make([]string, 0, len(resp.Items))
+		for _, cluster := range resp.Items {
+			ids = append(ids, *cluster.Id)
+		}
+		return ids, resp.OpcNextPage, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list Clusters: %w", err)
-	}
-
-	nativeIDs := make([]string, 0, len(resp.Items))
-	for _, cluster := range resp.Items {
-		nativeIDs = append(nativeIDs, *cluster.Id)
+		return nil, err
 	}
 
 	return &resource.ListResult{
@@ -463,10 +318,35 @@ type kubeconfig struct {
 	} `yaml:"clusters"`
 }
 
-// fetchCACert retrieves the cluster's CA certificate via the CreateKubeconfig API.
-func fetchCACert(ctx context.Context, ce *containerengine.ContainerEngineClient, clusterID string) (string, error) {
+// kubeconfigEndpoint picks the API endpoint the kubeconfig points at: the
+// public one unless the cluster has none or the target asks for the private
+// one. Clusters that report neither, e.g. while still CREATING, leave the
+// choice to OCI.
+func kubeconfigEndpoint(cluster containerengine.Cluster, private bool) containerengine.CreateClusterKubeconfigContentDetailsEndpointEnum {
+	if cluster.Endpoints == nil {
+		return ""
+	}
+	hasPublic := cluster.Endpoints.PublicEndpoint != nil && *cluster.Endpoints.PublicEndpoint != ""
+	hasPrivate := cluster.Endpoints.PrivateEndpoint != nil && *cluster.Endpoints.PrivateEndpoint != ""
+	switch {
+	case hasPrivate && (private || !hasPublic):
+		return containerengine.CreateClusterKubeconfigContentDetailsEndpointPrivateEndpoint
+	case hasPublic:
+		return containerengine.CreateClusterKubeconfigContentDetailsEndpointPublicEndpoint
+	}
+	return ""
+}
+
+// fetchKubeconfig generates the cluster's kubeconfig via the CreateKubeconfig
+// API. It authenticates through the OCI CLI rather than embedded credentials,
+// so it is safe to report.
+func fetchKubeconfig(ctx context.Context, ce *containerengine.ContainerEngineClient, clusterID string,
+	endpoint containerengine.CreateClusterKubeconfigContentDetailsEndpointEnum) (string, error) {
 	resp, err := ce.CreateKubeconfig(ctx, containerengine.CreateKubeconfigRequest{
 		ClusterId: common.String(clusterID),
+		CreateClusterKubeconfigContentDetails: containerengine.CreateClusterKubeconfigContentDetails{
+			Endpoint: endpoint,
+		},
 	})
 	if err != nil {
 		return "", fmt.Errorf("failed to create kubeconfig: %w", err)
@@ -477,15 +357,289 @@ func fetchCACert(ctx context.Context, ce *containerengine.ContainerEngineClient,
 	if err != nil {
 		return "", fmt.Errorf("failed to read kubeconfig content: %w", err)
 	}
+	return string(body), nil
+}
+
+// kubeconfigCACert returns the CA certificate of the first cluster in kc.
+func kubeconfigCACert(kc string) string {
+	var parsed kubeconfig
+	if err := yaml.Unmarshal([]byte(kc), &parsed); err != nil {
+		return ""
+	}
+	if len(parsed.Clusters) > 0 {
+		return parsed.Clusters[0].Cluster.CertificateAuthorityData
+	}
+	return ""
+}
 
-	var kc kubeconfig
-	if err := yaml.Unmarshal(body, &kc); err != nil {
-		return "", fmt.Errorf("failed to parse kubeconfig YAML: %w", err)
+// bastionPortForward describes the Bastion port-forwarding session that makes
+// a private endpoint reachable from outside the VCN. Once it is open, the
+// kubeconfig's server becomes https://127.0.0.1:<targetPort>, with the private
+// IP as tls-server-name since the API server certificate doesn't cover
+// localhost.
+func bastionPortForward(bastionId string, cluster containerengine.Cluster) (map[string]any, bool) {
+	if cluster.Endpoints == nil || cluster.Endpoints.PrivateEndpoint == nil {
+		return nil, false
 	}
+	host, portText, err := net.SplitHostPort(*cluster.Endpoints.PrivateEndpoint)
+	if err != nil {
+		return nil, false
+	}
+	port, err := strconv.Atoi(portText)
+	if err != nil {
+		return nil, false
+	}
+	return map[string]any{
+		"bastionId":       bastionId,
+		"targetPrivateIp": host,
+		"targetPort":      port,
+		"sessionCommand": fmt.Sprintf("oci bastion session create-port-forwarding --bastion-id %s --target-private-ip %s --target-port %d --ssh-public-key-file <public-key-file>",
+			bastionId, host, port),
+	}, true
+}
 
-	if len(kc.Clusters) > 0 && kc.Clusters[0].Cluster.CertificateAuthorityData != "" {
-		return kc.Clusters[0].Cluster.CertificateAuthorityData, nil
+// validateEndpointSubnet looks up the API endpoint's subnet and checks it
+// against the endpoint config before OCI does. A public endpoint in a private
+// subnet, or a subnet from another VCN, otherwise fails the create work
+// request minutes in, with a message that doesn't name the subnet.
+func (p *ClusterProvisioner) validateEndpointSubnet(ctx context.Context, createDetails containerengine.CreateClusterDetails) error {
+	endpoint := createDetails.EndpointConfig
+	if endpoint == nil || endpoint.SubnetId == nil {
+		return nil
+	}
+
+	network, err := p.clients.GetVirtualNetworkClient()
+	if err != nil {
+		return fmt.Errorf("failed to get VirtualNetwork client: %w", err)
+	}
+	resp, err := network.GetSubnet(ctx, core.GetSubnetRequest{SubnetId: endpoint.SubnetId})
+	if err != nil {
+		if util.IsNotFound(err) {
+			return fmt.Errorf("EndpointConfig.subnetId %s was not found", *endpoint.SubnetId)
+		}
+		return fmt.Errorf("failed to read EndpointConfig subnet %s: %w", *endpoint.SubnetId, err)
+	}
+	return checkEndpointSubnet(endpoint, createDetails.VcnId, resp.Subnet)
+}
+
+// checkEndpointSubnet validates the pairing of an endpoint config with its
+// subnet. A private endpoint may sit in a public subnet; a public one needs a
+// subnet that allows public IPs.
+func checkEndpointSubnet(endpoint *containerengine.CreateClusterEndpointConfigDetails, vcnId *string, subnet core.Subnet) error {
+	subnetId := *endpoint.SubnetId
+	if vcnId != nil && subnet.VcnId != nil && *subnet.VcnId != *vcnId {
+		return fmt.Errorf("EndpointConfig.subnetId %s is in VCN %s, not the cluster's VCN %s", subnetId, *subnet.VcnId, *vcnId)
+	}
+	isPublic := endpoint.IsPublicIpEnabled != nil && *endpoint.IsPublicIpEnabled
+	if isPublic && subnet.ProhibitPublicIpOnVnic != nil && *subnet.ProhibitPublicIpOnVnic {
+		return fmt.Errorf("EndpointConfig.isPublicIpEnabled is true but subnet %s is private (ProhibitPublicIpOnVnic); "+
+			"use a public subnet or set isPublicIpEnabled to false", subnetId)
+	}
+	return nil
+}
+
+// parseCreateClusterDetails maps Cluster properties to CreateClusterDetails.
+func parseCreateClusterDetails(props map[string]any) containerengine.CreateClusterDetails {
+	// Extract required properties - handle both direct strings and resolved references
+	compartmentId, _ := util.ExtractString(props, "CompartmentId")
+	vcnId, _ := util.ExtractString(props, "VcnId")
+	k8sVersion, _ := util.ExtractString(props, "KubernetesVersion")
+
+	createDetails := containerengine.CreateClusterDetails{
+		CompartmentId:     common.String(compartmentId),
+		VcnId:             common.String(vcnId),
+		KubernetesVersion: common.String(k8sVersion),
+	}
+
+	if name, ok := util.ExtractString(props, "Name"); ok {
+		createDetails.Name = common.String(name)
+	}
+
+	if clusterType, ok := util.ExtractString(props, "ClusterType"); ok {
+		createDetails.Type = containerengine.ClusterTypeEnum(clusterType)
+	}
+
+	// Parse EndpointConfig (nested class fields stay camelCase)
+	if endpointConfig, ok := props["EndpointConfig"].(map[string]any); ok {
+		config := &containerengine.CreateClusterEndpointConfigDetails{}
+		if subnetId, ok := util.ExtractString(endpointConfig, "subnetId"); ok {
+			config.SubnetId = common.String(subnetId)
+		} else if subnetId, ok := util.ExtractString(endpointConfig, "SubnetId"); ok {
+			config.SubnetId = common.String(subnetId)
+		}
+		if isPublicIpEnabled, ok := util.ExtractBool(endpointConfig, "isPublicIpEnabled"); ok {
+			config.IsPublicIpEnabled = common.Bool(isPublicIpEnabled)
+		} else if isPublicIpEnabled, ok := util.ExtractBool(endpointConfig, "IsPublicIpEnabled"); ok {
+			config.IsPublicIpEnabled = common.Bool(isPublicIpEnabled)
+		}
+		if nsgIds, ok := util.ExtractNsgIds(endpointConfig); ok {
+			config.NsgIds = nsgIds
+		}
+		createDetails.EndpointConfig = config
+	}
+
+	// Parse Options (nested class fields stay camelCase - no SubResourceHint)
+	if options, ok := props["Options"].(map[string]any); ok {
+		clusterOptions := &containerengine.ClusterCreateOptions{}
+
+		if serviceLbSubnetIds, ok := util.ExtractStringSlice(options, "serviceLbSubnetIds"); ok {
+			clusterOptions.ServiceLbSubnetIds = serviceLbSubnetIds
+		}
+
+		if kubernetesNetworkConfig, ok := options["kubernetesNetworkConfig"].(map[string]any); ok {
+			networkConfig := &containerengine.KubernetesNetworkConfig{}
+			if podsCidr, ok := util.ExtractString(kubernetesNetworkConfig, "podsCidr"); ok {
+				networkConfig.PodsCidr = common.String(podsCidr)
+			}
+			if servicesCidr, ok := util.ExtractString(kubernetesNetworkConfig, "servicesCidr"); ok {
+				networkConfig.ServicesCidr = common.String(servicesCidr)
+			}
+			clusterOptions.KubernetesNetworkConfig = networkConfig
+		}
+
+		if addOns, ok := options["addOns"].(map[string]any); ok {
+			addOnOptions := &containerengine.AddOnOptions{}
+			if isKubernetesDashboardEnabled, ok := util.ExtractBool(addOns, "isKubernetesDashboardEnabled"); ok {
+				addOnOptions.IsKubernetesDashboardEnabled = common.Bool(isKubernetesDashboardEnabled)
+			}
+			if isTillerEnabled, ok := util.ExtractBool(addOns, "isTillerEnabled"); ok {
+				addOnOptions.IsTillerEnabled = common.Bool(isTillerEnabled)
+			}
+			clusterOptions.AddOns = addOnOptions
+		}
+
+		if admissionControllerOptions, ok := options["admissionControllerOptions"].(map[string]any); ok {
+			admissionOptions := &containerengine.AdmissionControllerOptions{}
+			if isPodSecurityPolicyEnabled, ok := util.ExtractBool(admissionControllerOptions, "isPodSecurityPolicyEnabled"); ok {
+				admissionOptions.IsPodSecurityPolicyEnabled = common.Bool(isPodSecurityPolicyEnabled)
+			}
+			clusterOptions.AdmissionControllerOptions = admissionOptions
+		}
+
+		createDetails.Options = clusterOptions
+	}
+
+	if freeformTags, ok := util.ExtractFreeformTags(props, "FreeformTags"); ok {
+		createDetails.FreeformTags = freeformTags
+	}
+	if definedTags, ok := util.ExtractDefinedTags(props, "DefinedTags"); ok {
+		createDetails.DefinedTags = definedTags
+	}
+
+	return createDetails
+}
+
+// buildClusterProperties is the inverse of parseCreateClusterDetails plus the
+// read-only fields. EndpointConfig and Options are required for patches to work.
+func buildClusterProperties(cluster containerengine.Cluster, ignoredTagNamespaces []string) map[string]any {
+	props := map[string]any{
+		"CompartmentId":     *cluster.CompartmentId,
+		"Id":                *cluster.Id,
+		"VcnId":             *cluster.VcnId,
+		"KubernetesVersion": *cluster.KubernetesVersion,
+	}
+
+	if cluster.Name != nil {
+		props["Name"] = *cluster.Name
+	}
+	if cluster.Type != "" {
+		props["ClusterType"] = string(cluster.Type)
+	}
+	if cluster.LifecycleState != "" {
+		props["LifecycleState"] = string(cluster.LifecycleState)
+	}
+	if cluster.Endpoints != nil {
+		endpoints := map[string]any{}
+		if cluster.Endpoints.Kubernetes != nil {
+			endpoints["Kubernetes"] = *cluster.Endpoints.Kubernetes
+		}
+		if cluster.Endpoints.PublicEndpoint != nil {
+			endpoints["PublicEndpoint"] = *cluster.Endpoints.PublicEndpoint
+		}
+		if cluster.Endpoints.PrivateEndpoint != nil {
+			endpoints["PrivateEndpoint"] = *cluster.Endpoints.PrivateEndpoint
+		}
+		if len(endpoints) > 0 {
+			props["Endpoints"] = endpoints
+		}
+	}
+
+	// OKE publishes the OIDC discovery document once the cluster is ACTIVE. Its
+	// URL is also the issuer of the cluster's service account tokens, which is
+	// what IAM workload identity federation matches on.
+	if cluster.LifecycleState == containerengine.ClusterLifecycleStateActive &&
+		cluster.OpenIdConnectDiscoveryEndpoint != nil && *cluster.OpenIdConnectDiscoveryEndpoint != "" {
+		props["OpenIdConnectDiscoveryEndpoint"] = *cluster.OpenIdConnectDiscoveryEndpoint
+		props["WorkloadIdentityIssuer"] = *cluster.OpenIdConnectDiscoveryEndpoint
+	}
+
+	// EndpointConfig - required for patches to work
+	if cluster.EndpointConfig != nil {
+		endpointConfig := map[string]any{}
+		if cluster.EndpointConfig.SubnetId != nil {
+			endpointConfig["subnetId"] = *cluster.EndpointConfig.SubnetId
+		}
+		if cluster.EndpointConfig.IsPublicIpEnabled != nil {
+			endpointConfig["isPublicIpEnabled"] = *cluster.EndpointConfig.IsPublicIpEnabled
+		}
+		if len(cluster.EndpointConfig.NsgIds) > 0 {
+			endpointConfig["nsgIds"] = cluster.EndpointConfig.NsgIds
+		}
+		if len(endpointConfig) > 0 {
+			props["EndpointConfig"] = endpointConfig
+		}
+	}
+
+	// Options - required for patches to work
+	if cluster.Options != nil {
+		options := map[string]any{}
+		if len(cluster.Options.ServiceLbSubnetIds) > 0 {
+			options["serviceLbSubnetIds"] = cluster.Options.ServiceLbSubnetIds
+		}
+		if cluster.Options.KubernetesNetworkConfig != nil {
+			networkConfig := map[string]any{}
+			if cluster.Options.KubernetesNetworkConfig.PodsCidr != nil {
+				networkConfig["podsCidr"] = *cluster.Options.KubernetesNetworkConfig.PodsCidr
+			}
+			if cluster.Options.KubernetesNetworkConfig.ServicesCidr != nil {
+				networkConfig["servicesCidr"] = *cluster.Options.KubernetesNetworkConfig.ServicesCidr
+			}
+			if len(networkConfig) > 0 {
+				options["kubernetesNetworkConfig"] = networkConfig
+			}
+		}
+		if cluster.Options.AddOns != nil {
+			addOns := map[string]any{}
+			if cluster.Options.AddOns.IsKubernetesDashboardEnabled != nil {
+				addOns["isKubernetesDashboardEnabled"] = *cluster.Options.AddOns.IsKubernetesDashboardEnabled
+			}
+			if cluster.Options.AddOns.IsTillerEnabled != nil {
+				addOns["isTillerEnabled"] = *cluster.Options.AddOns.IsTillerEnabled
+			}
+			if len(addOns) > 0 {
+				options["addOns"] = addOns
+			}
+		}
+		if cluster.Options.AdmissionControllerOptions != nil {
+			admissionOpts := map[string]any{}
+			if cluster.Options.AdmissionControllerOptions.IsPodSecurityPolicyEnabled != nil {
+				admissionOpts["isPodSecurityPolicyEnabled"] = *cluster.Options.AdmissionControllerOptions.IsPodSecurityPolicyEnabled
+			}
+			if len(admissionOpts) > 0 {
+				options["admissionControllerOptions"] = admissionOpts
+			}
+		}
+		if len(options) > 0 {
+			props["Options"] = options
+		}
+	}
+
+	if cluster.FreeformTags != nil {
+		props["FreeformTags"] = util.FreeformTagsToList(cluster.FreeformTags)
+	}
+	if cluster.DefinedTags != nil {
+		props["DefinedTags"] = util.DefinedTagsToList(cluster.DefinedTags, ignoredTagNamespaces)
 	}
 
-	return "", nil
+	return props
 }
@@ -0,0 +1,182 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package containerengine
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/containerengine"
+	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// roundTrip pushes properties through JSON the way formae hands them back to Create.
+func roundTrip(t *testing.T, props map[string]any) map[string]any {
+	t.Helper()
+	b, err := json.Marshal(props)
+	require.NoError(t, err)
+	var out map[string]any
+	require.NoError(t, json.Unmarshal(b, &out))
+	return out
+}
+
+func TestClusterReadRoundTripsThroughCreate(t *testing.T) {
+	cluster := containerengine.Cluster{
+		Id:                common.String("ocid1.cluster.oc1..test"),
+		CompartmentId:     common.String("ocid1.compartment.oc1..test"),
+		VcnId:             common.String("ocid1.vcn.oc1..test"),
+		KubernetesVersion: common.String("v1.30.1"),
+		Name:              common.String("prod"),
+		Type:              containerengine.ClusterTypeEnhancedCluster,
+		EndpointConfig: &containerengine.ClusterEndpointConfig{
+			SubnetId:          common.String("ocid1.subnet.oc1..api"),
+			IsPublicIpEnabled: common.Bool(true),
+			NsgIds:            []string{"ocid1.nsg.oc1..api", "ocid1.nsg.oc1..ops"},
+		},
+		Options: &containerengine.ClusterCreateOptions{
+			ServiceLbSubnetIds: []string{"ocid1.subnet.oc1..lb"},
+			KubernetesNetworkConfig: &containerengine.KubernetesNetworkConfig{
+				PodsCidr:     common.String("10.244.0.0/16"),
+				ServicesCidr: common.String("10.96.0.0/16"),
+			},
+			AddOns: &containerengine.AddOnOptions{
+				IsKubernetesDashboardEnabled: common.Bool(false),
+				IsTillerEnabled:              common.Bool(false),
+			},
+			AdmissionControllerOptions: &containerengine.AdmissionControllerOptions{
+				IsPodSecurityPolicyEnabled: common.Bool(false),
+			},
+		},
+		FreeformTags: map[string]string{"Env": "prod"},
+	}
+
+	details := parseCreateClusterDetails(roundTrip(t, buildClusterProperties(cluster, nil)))
+
+	assert.Equal(t, cluster.CompartmentId, details.CompartmentId)
+	assert.Equal(t, cluster.VcnId, details.VcnId)
+	assert.Equal(t, cluster.KubernetesVersion, details.KubernetesVersion)
+	assert.Equal(t, cluster.Name, details.Name)
+	assert.Equal(t, cluster.Type, details.Type)
+	require.NotNil(t, details.EndpointConfig)
+	assert.Equal(t, cluster.EndpointConfig.SubnetId, details.EndpointConfig.SubnetId)
+	assert.Equal(t, cluster.EndpointConfig.IsPublicIpEnabled, details.EndpointConfig.IsPublicIpEnabled)
+	assert.Equal(t, cluster.EndpointConfig.NsgIds, details.EndpointConfig.NsgIds)
+	assert.Equal(t, cluster.Options, details.Options)
+	assert.Equal(t, cluster.FreeformTags, details.FreeformTags)
+}
+
+func TestBuildClusterPropertiesOidcDiscovery(t *testing.T) {
+	cluster := containerengine.Cluster{
+		Id:                             common.String("ocid1.cluster.oc1..test"),
+		CompartmentId:                  common.String("ocid1.compartment.oc1..test"),
+		VcnId:                          common.String("ocid1.vcn.oc1..test"),
+		KubernetesVersion:              common.String("v1.30.1"),
+		LifecycleState:                 containerengine.ClusterLifecycleStateActive,
+		OpenIdConnectDiscoveryEndpoint: common.String("https://objectstorage.us-phoenix-1.oraclecloud.com/n/tenancy/b/oidc/o/abc123"),
+	}
+
+	props := buildClusterProperties(cluster, nil)
+	assert.Equal(t, *cluster.OpenIdConnectDiscoveryEndpoint, props["OpenIdConnectDiscoveryEndpoint"])
+	assert.Equal(t, *cluster.OpenIdConnectDiscoveryEndpoint, props["WorkloadIdentityIssuer"])
+
+	cluster.LifecycleState = containerengine.ClusterLifecycleStateCreating
+	props = buildClusterProperties(cluster, nil)
+	assert.NotContains(t, props, "OpenIdConnectDiscoveryEndpoint")
+	assert.NotContains(t, props, "WorkloadIdentityIssuer")
+}
+
+func TestCheckEndpointSubnet(t *testing.T) {
+	subnet := func(vcnId string, prohibitPublicIp bool) core.Subnet {
+		return core.Subnet{VcnId: common.String(vcnId), ProhibitPublicIpOnVnic: common.Bool(prohibitPublicIp)}
+	}
+	endpoint := func(isPublic *bool) *containerengine.CreateClusterEndpointConfigDetails {
+		return &containerengine.CreateClusterEndpointConfigDetails{SubnetId: common.String("ocid1.subnet.oc1..api"), IsPublicIpEnabled: isPublic}
+	}
+	vcnId := common.String("ocid1.vcn.oc1..test")
+
+	tests := []struct {
+		name     string
+		endpoint *containerengine.CreateClusterEndpointConfigDetails
+		subnet   core.Subnet
+		wantErr  string
+	}{
+		{name: "public_in_public_subnet", endpoint: endpoint(common.Bool(true)), subnet: subnet("ocid1.vcn.oc1..test", false)},
+		{name: "private_in_private_subnet", endpoint: endpoint(common.Bool(false)), subnet: subnet("ocid1.vcn.oc1..test", true)},
+		{name: "private_in_public_subnet", endpoint: endpoint(common.Bool(false)), subnet: subnet("ocid1.vcn.oc1..test", false)},
+		{name: "unset_defaults_to_private", endpoint: endpoint(nil), subnet: subnet("ocid1.vcn.oc1..test", true)},
+		{
+			name:     "public_in_private_subnet",
+			endpoint: endpoint(common.Bool(true)),
+			subnet:   subnet("ocid1.vcn.oc1..test", true),
+			wantErr:  "EndpointConfig.isPublicIpEnabled is true but subnet ocid1.subnet.oc1..api is private",
+		},
+		{
+			name:     "subnet_in_other_vcn",
+			endpoint: endpoint(common.Bool(false)),
+			subnet:   subnet("ocid1.vcn.oc1..other", true),
+			wantErr:  "EndpointConfig.subnetId ocid1.subnet.oc1..api is in VCN ocid1.vcn.oc1..other, not the cluster's VCN ocid1.vcn.oc1..test",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkEndpointSubnet(tt.endpoint, vcnId, tt.subnet)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestKubeconfigEndpoint(t *testing.T) {
+	endpoints := func(public, private string) containerengine.Cluster {
+		return containerengine.Cluster{Endpoints: &containerengine.ClusterEndpoints{
+			PublicEndpoint:  common.String(public),
+			PrivateEndpoint: common.String(private),
+		}}
+	}
+
+	tests := []struct {
+		name    string
+		cluster containerengine.Cluster
+		private bool
+		want    containerengine.CreateClusterKubeconfigContentDetailsEndpointEnum
+	}{
+		{name: "public_cluster", cluster: endpoints("203.0.113.10:6443", "10.0.0.5:6443"), want: containerengine.CreateClusterKubeconfigContentDetailsEndpointPublicEndpoint},
+		{name: "public_cluster_private_requested", cluster: endpoints("203.0.113.10:6443", "10.0.0.5:6443"), private: true, want: containerengine.CreateClusterKubeconfigContentDetailsEndpointPrivateEndpoint},
+		{name: "private_cluster", cluster: endpoints("", "10.0.0.5:6443"), want: containerengine.CreateClusterKubeconfigContentDetailsEndpointPrivateEndpoint},
+		{name: "no_endpoints_yet", cluster: containerengine.Cluster{}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, kubeconfigEndpoint(tt.cluster, tt.private))
+		})
+	}
+}
+
+func TestBastionPortForward(t *testing.T) {
+	cluster := containerengine.Cluster{Endpoints: &containerengine.ClusterEndpoints{PrivateEndpoint: common.String("10.0.0.5:6443")}}
+
+	forward, ok := bastionPortForward("ocid1.bastion.oc1.phx.aaa", cluster)
+	require.True(t, ok)
+	assert.Equal(t, "10.0.0.5", forward["targetPrivateIp"])
+	assert.Equal(t, 6443, forward["targetPort"])
+	assert.Contains(t, forward["sessionCommand"], "--bastion-id ocid1.bastion.oc1.phx.aaa --target-private-ip 10.0.0.5 --target-port 6443")
+
+	_, ok = bastionPortForward("ocid1.bastion.oc1.phx.aaa", containerengine.Cluster{})
+	assert.False(t, ok)
+}
+
+func TestKubeconfigCACert(t *testing.T) {
+	kc := "apiVersion: v1\nclusters:\n- cluster:\n    certificate-authority-data: LS0tLS1CRUdJTg==\n    server: https://10.0.0.5:6443\n  name: cluster-test\n"
+	assert.Equal(t, "LS0tLS1CRUdJTg==", kubeconfigCACert(kc))
+	assert.Empty(t, kubeconfigCACert("not: [yaml"))
+}
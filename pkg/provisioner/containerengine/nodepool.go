@@ -11,7 +11,9 @@ import (
 
 	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/oracle/oci-go-sdk/v65/containerengine"
+	"github.com/oracle/oci-go-sdk/v65/core"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
@@ -19,20 +21,57 @@ import (
 
 type NodePoolProvisioner struct {
 	clients *client.Clients
+	svc     *containerengine.ContainerEngineClient // nil until first use; injected in tests
+	compute *core.ComputeClient                    // nil until first use; injected in tests
 }
 
-var _ provisioner.Provisioner = &NodePoolProvisioner{}
+var (
+	_ provisioner.Provisioner = &NodePoolProvisioner{}
+	_ provisioner.Opaque      = &NodePoolProvisioner{}
+)
 
 func init() {
 	provisioner.Register("OCI::ContainerEngine::NodePool", NewNodePoolProvisioner)
+	provisioner.DependsOn("OCI::ContainerEngine::NodePool",
+		"OCI::Identity::Compartment",
+		"OCI::ContainerEngine::Cluster",
+		"OCI::Core::Subnet",
+		"OCI::Core::NetworkSecurityGroup",
+	)
 }
 
 func NewNodePoolProvisioner(clients *client.Clients) provisioner.Provisioner {
 	return &NodePoolProvisioner{clients: clients}
 }
 
+// NewNodePoolProvisionerWithSvc constructs a provisioner with pre-built SDK
+// clients, for use in tests that point the clients at an httptest server.
+// compute resolves node image names and validates the node shape.
+func NewNodePoolProvisionerWithSvc(svc *containerengine.ContainerEngineClient, compute *core.ComputeClient) *NodePoolProvisioner {
+	return &NodePoolProvisioner{svc: svc, compute: compute}
+}
+
+func (p *NodePoolProvisioner) getSvc() (*containerengine.ContainerEngineClient, error) {
+	if p.svc != nil {
+		return p.svc, nil
+	}
+	return p.clients.GetContainerEngineClient()
+}
+
+func (p *NodePoolProvisioner) getCompute() (*core.ComputeClient, error) {
+	if p.compute != nil {
+		return p.compute, nil
+	}
+	return p.clients.GetComputeClient()
+}
+
+// OpaqueFields lists NodeMetadata, whose keys are passed to the nodes as they are.
+func (p *NodePoolProvisioner) OpaqueFields() []string {
+	return []string{"NodeMetadata"}
+}
+
 func (p *NodePoolProvisioner) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
-	client, err := p.clients.GetContainerEngineClient()
+	client, err := p.getSvc()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get ContainerEngine client: %w", err)
 	}
@@ -42,123 +81,35 @@ func (p *NodePoolProvisioner) Create(ctx context.Context, request *resource.Crea
 		return nil, fmt.Errorf("failed to parse properties: %w", err)
 	}
 
-	createDetails := containerengine.CreateNodePoolDetails{
-		CompartmentId: common.String(props["CompartmentId"].(string)),
-		ClusterId:     common.String(props["ClusterId"].(string)),
-		Name:          common.String(props["Name"].(string)),
-		NodeShape:     common.String(props["NodeShape"].(string)),
+	cfg := config.FromTargetConfig(request.TargetConfig)
+	if err := validateNodePoolSize(props, cfg); err != nil {
+		return nil, err
 	}
 
-	if kubernetesVersion, ok := util.ExtractString(props, "KubernetesVersion"); ok {
-		createDetails.KubernetesVersion = common.String(kubernetesVersion)
+	createDetails, err := parseCreateNodePoolDetails(props)
+	if err != nil {
+		return nil, err
 	}
 
-	if nodeShapeConfig, ok := props["NodeShapeConfig"].(map[string]any); ok {
-		config := &containerengine.CreateNodeShapeConfigDetails{}
-		if ocpus, ok := nodeShapeConfig["ocpus"].(float64); ok {
-			config.Ocpus = common.Float32(float32(ocpus))
-		}
-		if memoryInGBs, ok := nodeShapeConfig["memoryInGBs"].(float64); ok {
-			config.MemoryInGBs = common.Float32(float32(memoryInGBs))
+	if createDetails.NodeSourceDetails == nil {
+		if err := p.resolveNodeImage(ctx, &createDetails, props["NodeSourceDetails"].(map[string]any)); err != nil {
+			return nil, err
 		}
-		createDetails.NodeShapeConfig = config
 	}
 
-	if nodeConfigDetails, ok := props["NodeConfigDetails"].(map[string]any); ok {
-		config := containerengine.CreateNodePoolNodeConfigDetails{}
-
-		if size, ok := nodeConfigDetails["size"].(float64); ok {
-			config.Size = common.Int(int(size))
+	if createDetails.NodeShapeConfig != nil && !cfg.SkipShapeValidation {
+		compute, err := p.getCompute()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Compute client: %w", err)
 		}
-
-		if placementConfigs, ok := nodeConfigDetails["placementConfigs"].([]any); ok {
-			configs := make([]containerengine.NodePoolPlacementConfigDetails, 0, len(placementConfigs))
-			for _, pc := range placementConfigs {
-				if pcMap, ok := pc.(map[string]any); ok {
-					placementConfig := containerengine.NodePoolPlacementConfigDetails{}
-					if ad, ok := util.ExtractString(pcMap, "availabilityDomain"); ok {
-						placementConfig.AvailabilityDomain = common.String(ad)
-					}
-					if subnetId, ok := util.ExtractString(pcMap, "subnetId"); ok {
-						placementConfig.SubnetId = common.String(subnetId)
-					}
-					if capacityReservationId, ok := util.ExtractString(pcMap, "capacityReservationId"); ok {
-						placementConfig.CapacityReservationId = common.String(capacityReservationId)
-					}
-					if faultDomains, ok := util.ExtractStringSlice(pcMap, "faultDomains"); ok {
-						placementConfig.FaultDomains = faultDomains
-					}
-					configs = append(configs, placementConfig)
-				}
-			}
-			config.PlacementConfigs = configs
-		}
-
-		if nsgIds, ok := util.ExtractStringSlice(nodeConfigDetails, "nsgIds"); ok {
-			config.NsgIds = nsgIds
-		}
-		if isPvEncryptionInTransitEnabled, ok := util.ExtractBool(nodeConfigDetails, "isPvEncryptionInTransitEnabled"); ok {
-			config.IsPvEncryptionInTransitEnabled = common.Bool(isPvEncryptionInTransitEnabled)
-		}
-		if freeformTags, ok := util.ExtractFreeformTags(nodeConfigDetails, "freeformTags"); ok {
-			config.FreeformTags = freeformTags
+		if err := util.ValidateShapeConfig(ctx, compute, *createDetails.CompartmentId, "", *createDetails.NodeShape,
+			createDetails.NodeShapeConfig.Ocpus, createDetails.NodeShapeConfig.MemoryInGBs); err != nil {
+			return nil, err
 		}
-		if definedTags, ok := util.ExtractDefinedTags(nodeConfigDetails, "definedTags"); ok {
-			config.DefinedTags = definedTags
-		}
-
-		createDetails.NodeConfigDetails = &config
-	}
-
-	// Parse NodeSourceDetails (required - nested class fields stay camelCase)
-	// User must provide an OKE-optimized image OCID for their region and K8s version
-	// See: https://docs.oracle.com/en-us/iaas/Content/ContEng/Reference/contengimagesshapes.htm
-	if nodeSourceDetails, ok := props["NodeSourceDetails"].(map[string]any); ok {
-		if imageId, ok := util.ExtractString(nodeSourceDetails, "imageId"); ok {
-			sourceDetails := containerengine.NodeSourceViaImageDetails{
-				ImageId: common.String(imageId),
-			}
-			if bootVolumeSizeInGBs, ok := nodeSourceDetails["bootVolumeSizeInGBs"].(float64); ok {
-				sourceDetails.BootVolumeSizeInGBs = common.Int64(int64(bootVolumeSizeInGBs))
-			}
-			createDetails.NodeSourceDetails = sourceDetails
-		} else {
-			return nil, fmt.Errorf("nodeSourceDetails.imageId is required but not provided")
-		}
-	} else {
-		return nil, fmt.Errorf("nodeSourceDetails is required for NodePool creation - specify the OKE-optimized image OCID for your region")
-	}
-
-	if sshPublicKey, ok := util.ExtractString(props, "SshPublicKey"); ok {
-		createDetails.SshPublicKey = common.String(sshPublicKey)
-	}
-
-	// Parse InitialNodeLabels (nested class fields stay camelCase)
-	if initialNodeLabels, ok := props["InitialNodeLabels"].([]any); ok {
-		labels := make([]containerengine.KeyValue, 0, len(initialNodeLabels))
-		for _, label := range initialNodeLabels {
-			if labelMap, ok := label.(map[string]any); ok {
-				kv := containerengine.KeyValue{}
-				if key, ok := util.ExtractString(labelMap, "key"); ok {
-					kv.Key = common.String(key)
-				}
-				if value, ok := util.ExtractString(labelMap, "value"); ok {
-					kv.Value = common.String(value)
-				}
-				labels = append(labels, kv)
-			}
-		}
-		createDetails.InitialNodeLabels = labels
-	}
-
-	if freeformTags, ok := util.ExtractFreeformTags(props, "FreeformTags"); ok {
-		createDetails.FreeformTags = freeformTags
-	}
-	if definedTags, ok := util.ExtractDefinedTags(props, "DefinedTags"); ok {
-		createDetails.DefinedTags = definedTags
 	}
 
 	createReq := containerengine.CreateNodePoolRequest{
+		OpcRetryToken:         common.String(util.CreateRetryToken(request)),
 		CreateNodePoolDetails: createDetails,
 	}
 
@@ -177,7 +128,7 @@ func (p *NodePoolProvisioner) Create(ctx context.Context, request *resource.Crea
 }
 
 func (p *NodePoolProvisioner) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
-	client, err := p.clients.GetContainerEngineClient()
+	client, err := p.getSvc()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get ContainerEngine client: %w", err)
 	}
@@ -187,6 +138,10 @@ func (p *NodePoolProvisioner) Update(ctx context.Context, request *resource.Upda
 		return nil, err
 	}
 
+	if err := validateNodePoolSize(props, config.FromTargetConfig(request.TargetConfig)); err != nil {
+		return nil, err
+	}
+
 	updateDetails := containerengine.UpdateNodePoolDetails{}
 
 	if name, ok := util.ExtractString(props, "Name"); ok {
@@ -195,6 +150,12 @@ func (p *NodePoolProvisioner) Update(ctx context.Context, request *resource.Upda
 	if kubernetesVersion, ok := util.ExtractString(props, "KubernetesVersion"); ok {
 		updateDetails.KubernetesVersion = common.String(kubernetesVersion)
 	}
+	if sshPublicKey, ok := util.ExtractString(props, "SshPublicKey"); ok {
+		updateDetails.SshPublicKey = common.String(sshPublicKey)
+	}
+	if nodeMetadata, ok := extractNodeMetadata(props); ok {
+		updateDetails.NodeMetadata = nodeMetadata
+	}
 
 	// Parse NodeShapeConfig for flexible shapes
 	if nodeShapeConfig, ok := props["NodeShapeConfig"].(map[string]any); ok {
@@ -215,7 +176,7 @@ func (p *NodePoolProvisioner) Update(ctx context.Context, request *resource.Upda
 		if size, ok := nodeConfigDetails["size"].(float64); ok {
 			config.Size = common.Int(int(size))
 		}
-		if nsgIds, ok := util.ExtractStringSlice(nodeConfigDetails, "nsgIds"); ok {
+		if nsgIds, ok := util.ExtractNsgIds(nodeConfigDetails); ok {
 			config.NsgIds = nsgIds
 		}
 		if isPvEncryptionInTransitEnabled, ok := util.ExtractBool(nodeConfigDetails, "isPvEncryptionInTransitEnabled"); ok {
@@ -288,7 +249,7 @@ func (p *NodePoolProvisioner) Update(ctx context.Context, request *resource.Upda
 }
 
 func (p *NodePoolProvisioner) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
-	client, err := p.clients.GetContainerEngineClient()
+	client, err := p.getSvc()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get ContainerEngine client: %w", err)
 	}
@@ -315,6 +276,13 @@ func (p *NodePoolProvisioner) Delete(ctx context.Context, request *resource.Dele
 	deleteReq := containerengine.DeleteNodePoolRequest{
 		NodePoolId: common.String(request.NativeID),
 	}
+	cfg := config.FromTargetConfig(request.TargetConfig)
+	if cfg.NodeEvictionGraceDuration != "" {
+		deleteReq.OverrideEvictionGraceDuration = common.String(cfg.NodeEvictionGraceDuration)
+	}
+	if cfg.ForceNodePoolDeletion {
+		deleteReq.IsForceDeletionAfterOverrideGraceDuration = common.Bool(true)
+	}
 
 	resp, err := client.DeleteNodePool(ctx, deleteReq)
 	if err != nil {
@@ -331,7 +299,7 @@ func (p *NodePoolProvisioner) Delete(ctx context.Context, request *resource.Dele
 }
 
 func (p *NodePoolProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
-	client, err := p.clients.GetContainerEngineClient()
+	client, err := p.getSvc()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get ContainerEngine client: %w", err)
 	}
@@ -349,7 +317,7 @@ func (p *NodePoolProvisioner) Status(ctx context.Context, request *resource.Stat
 }
 
 func (p *NodePoolProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
-	client, err := p.clients.GetContainerEngineClient()
+	client, err := p.getSvc()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get ContainerEngine client: %w", err)
 	}
@@ -361,7 +329,7 @@ func (p *NodePoolProvisioner) Read(ctx context.Context, request *resource.ReadRe
 	resp, err := client.GetNodePool(ctx, getReq)
 	if err != nil {
 		// Check if not found
-		if serviceErr, ok := common.IsServiceError(err); ok && serviceErr.GetHTTPStatusCode() == 404 {
+		if util.IsNotFound(err) {
 			return &resource.ReadResult{
 				ResourceType: "OCI::ContainerEngine::NodePool",
 				ErrorCode:    resource.OperationErrorCodeNotFound,
@@ -377,30 +345,333 @@ func (p *NodePoolProvisioner) Read(ctx context.Context, request *resource.ReadRe
 		}, nil
 	}
 
-	// Build properties map
+	props := buildNodePoolProperties(resp.NodePool, config.FromTargetConfig(request.TargetConfig).HiddenTagNamespaces())
+
+	propBytes, err := json.Marshal(props)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal NodePool properties: %w", err)
+	}
+
+	return &resource.ReadResult{
+		ResourceType: "OCI::ContainerEngine::NodePool",
+		Properties:   string(propBytes),
+	}, nil
+}
+
+func (p *NodePoolProvisioner) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ContainerEngine client: %w", err)
+	}
+
+	var compartmentId string
+	var clusterId string
+
+	// Check if CompartmentId is provided directly
+	if cid, ok := request.AdditionalProperties["CompartmentId"]; ok {
+		compartmentId = cid
+	}
+
+	// Check if ClusterId is provided
+	if clid, ok := request.AdditionalProperties["ClusterId"]; ok {
+		clusterId = clid
+		// If we have ClusterId but no CompartmentId, derive it from the cluster
+		if compartmentId == "" {
+			getReq := containerengine.GetClusterRequest{
+				ClusterId: common.String(clusterId),
+			}
+			resp, err := client.GetCluster(ctx, getReq)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Cluster to derive CompartmentId: %w", err)
+			}
+			compartmentId = *resp.CompartmentId
+		}
+	}
+
+	if compartmentId == "" {
+		compartmentId, _ = util.ListCompartmentId(request)
+	}
+
+	if compartmentId == "" {
+		return nil, fmt.Errorf("CompartmentId is required for listing NodePools (either directly or derived from ClusterId)")
+	}
+
+	listReq := containerengine.ListNodePoolsRequest{
+		CompartmentId: common.String(compartmentId),
+		// Filter out deleted/deleting/failed node pools - only return active or in-progress states
+		LifecycleState: []containerengine.NodePoolLifecycleStateEnum{
+			containerengine.NodePoolLifecycleStateCreating,
+			containerengine.NodePoolLifecycleStateActive,
+			containerengine.NodePoolLifecycleStateUpdating,
+			containerengine.NodePoolLifecycleStateInactive,
+			containerengine.NodePoolLifecycleStateNeedsAttention,
+		},
+		Limit: util.ListPageSize(request.TargetConfig),
+	}
+
+	// Filter by ClusterId if provided
+	if clusterId != "" {
+		listReq.ClusterId = common.String(clusterId)
+	}
+
+	nativeIDs, err := util.ListAllPages(func(page *string) ([]string, *string, error) {
+		listReq.Page = page
+		resp, err := client.ListNodePools(ctx, listReq)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list NodePools: %w", err)
+		}
+		ids := make([]string, 0, len(resp.Items))
+		for _, nodePool := range resp.Items {
+			ids = append(ids, *nodePool.Id)
+		}
+		return ids, resp.OpcNextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &resource.ListResult{
+		NativeIDs: nativeIDs,
+	}, nil
+}
+
+// parseCreateNodePoolDetails maps NodePool properties to CreateNodePoolDetails.
+// extractNodeMetadata reads NodeMetadata (Mapping<String, String>), the
+// instance metadata OKE passes to each worker, e.g. user_data for cloud-init.
+func extractNodeMetadata(props map[string]any) (map[string]string, bool) {
+	raw, ok := props["NodeMetadata"].(map[string]any)
+	if !ok || len(raw) == 0 {
+		return nil, false
+	}
+	metadata := make(map[string]string, len(raw))
+	for k, v := range raw {
+		if s, ok := v.(string); ok {
+			metadata[k] = s
+		}
+	}
+	return metadata, true
+}
+
+// defaultNodePoolMaxSize caps NodeConfigDetails.size when the target doesn't set
+// NodePoolMaxSize, so a stray extra digit can't launch thousands of nodes.
+const defaultNodePoolMaxSize = 1000
+
+// validateNodePoolSize checks NodeConfigDetails.size against the target's bounds
+// and its placementConfigs before OCI sees them. OCI rejects a size without
+// placement configs, or one AD listed twice, with messages that don't say which.
+func validateNodePoolSize(props map[string]any, cfg *config.Config) error {
+	nodeConfigDetails, ok := props["NodeConfigDetails"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	size, ok := nodeConfigDetails["size"].(float64)
+	if !ok {
+		return nil
+	}
+
+	maxSize := cfg.NodePoolMaxSize
+	if maxSize == 0 {
+		maxSize = defaultNodePoolMaxSize
+	}
+	if int(size) < cfg.NodePoolMinSize || int(size) > maxSize {
+		return fmt.Errorf("NodeConfigDetails.size %d is out of range, must be between %d and %d (set nodePoolMinSize/nodePoolMaxSize on the target to change the bounds)",
+			int(size), cfg.NodePoolMinSize, maxSize)
+	}
+
+	placementConfigs, _ := nodeConfigDetails["placementConfigs"].([]any)
+	if size > 0 && len(placementConfigs) == 0 {
+		return fmt.Errorf("NodeConfigDetails.size is %d but placementConfigs is empty: add at least one availabilityDomain and subnetId to place the nodes in", int(size))
+	}
+	seen := make(map[string]bool, len(placementConfigs))
+	for _, pc := range placementConfigs {
+		pcMap, _ := pc.(map[string]any)
+		ad, _ := util.ExtractString(pcMap, "availabilityDomain")
+		if seen[ad] {
+			return fmt.Errorf("NodeConfigDetails.placementConfigs lists availability domain %s more than once", ad)
+		}
+		seen[ad] = true
+	}
+
+	return nil
+}
+
+// resolveNodeImage sets NodeSourceDetails from nodeSourceDetails.imageName.
+func (p *NodePoolProvisioner) resolveNodeImage(ctx context.Context, createDetails *containerengine.CreateNodePoolDetails, nodeSourceDetails map[string]any) error {
+	query, _ := util.ExtractImageQuery(nodeSourceDetails)
+	if createDetails.NodeShape != nil {
+		query.Shape = *createDetails.NodeShape
+	}
+
+	compute, err := p.getCompute()
+	if err != nil {
+		return fmt.Errorf("failed to get Compute client: %w", err)
+	}
+	imageId, err := util.ResolveImageByName(ctx, compute, *createDetails.CompartmentId, query)
+	if err != nil {
+		return err
+	}
+
+	sourceDetails := containerengine.NodeSourceViaImageDetails{ImageId: common.String(imageId)}
+	if bootVolumeSizeInGBs, ok := nodeSourceDetails["bootVolumeSizeInGBs"].(float64); ok {
+		sourceDetails.BootVolumeSizeInGBs = common.Int64(int64(bootVolumeSizeInGBs))
+	}
+	createDetails.NodeSourceDetails = sourceDetails
+	return nil
+}
+
+func parseCreateNodePoolDetails(props map[string]any) (containerengine.CreateNodePoolDetails, error) {
+	createDetails := containerengine.CreateNodePoolDetails{
+		CompartmentId: common.String(props["CompartmentId"].(string)),
+		ClusterId:     common.String(props["ClusterId"].(string)),
+		Name:          common.String(props["Name"].(string)),
+		NodeShape:     common.String(props["NodeShape"].(string)),
+	}
+
+	if kubernetesVersion, ok := util.ExtractString(props, "KubernetesVersion"); ok {
+		createDetails.KubernetesVersion = common.String(kubernetesVersion)
+	}
+
+	if nodeShapeConfig, ok := props["NodeShapeConfig"].(map[string]any); ok {
+		config := &containerengine.CreateNodeShapeConfigDetails{}
+		if ocpus, ok := nodeShapeConfig["ocpus"].(float64); ok {
+			config.Ocpus = common.Float32(float32(ocpus))
+		}
+		if memoryInGBs, ok := nodeShapeConfig["memoryInGBs"].(float64); ok {
+			config.MemoryInGBs = common.Float32(float32(memoryInGBs))
+		}
+		createDetails.NodeShapeConfig = config
+	}
+
+	if nodeConfigDetails, ok := props["NodeConfigDetails"].(map[string]any); ok {
+		config := containerengine.CreateNodePoolNodeConfigDetails{}
+
+		if size, ok := nodeConfigDetails["size"].(float64); ok {
+			config.Size = common.Int(int(size))
+		}
+
+		if placementConfigs, ok := nodeConfigDetails["placementConfigs"].([]any); ok {
+			configs := make([]containerengine.NodePoolPlacementConfigDetails, 0, len(placementConfigs))
+			for _, pc := range placementConfigs {
+				if pcMap, ok := pc.(map[string]any); ok {
+					placementConfig := containerengine.NodePoolPlacementConfigDetails{}
+					if ad, ok := util.ExtractString(pcMap, "availabilityDomain"); ok {
+						placementConfig.AvailabilityDomain = common.String(ad)
+					}
+					if subnetId, ok := util.ExtractString(pcMap, "subnetId"); ok {
+						placementConfig.SubnetId = common.String(subnetId)
+					}
+					if capacityReservationId, ok := util.ExtractString(pcMap, "capacityReservationId"); ok {
+						placementConfig.CapacityReservationId = common.String(capacityReservationId)
+					}
+					if faultDomains, ok := util.ExtractStringSlice(pcMap, "faultDomains"); ok {
+						placementConfig.FaultDomains = faultDomains
+					}
+					configs = append(configs, placementConfig)
+				}
+			}
+			config.PlacementConfigs = configs
+		}
+
+		if nsgIds, ok := util.ExtractNsgIds(nodeConfigDetails); ok {
+			config.NsgIds = nsgIds
+		}
+		if isPvEncryptionInTransitEnabled, ok := util.ExtractBool(nodeConfigDetails, "isPvEncryptionInTransitEnabled"); ok {
+			config.IsPvEncryptionInTransitEnabled = common.Bool(isPvEncryptionInTransitEnabled)
+		}
+		if freeformTags, ok := util.ExtractFreeformTags(nodeConfigDetails, "freeformTags"); ok {
+			config.FreeformTags = freeformTags
+		}
+		if definedTags, ok := util.ExtractDefinedTags(nodeConfigDetails, "definedTags"); ok {
+			config.DefinedTags = definedTags
+		}
+
+		createDetails.NodeConfigDetails = &config
+	}
+
+	// Parse NodeSourceDetails (required - nested class fields stay camelCase)
+	// User must provide an OKE-optimized image OCID for their region and K8s version,
+	// or an imageName that Create resolves to one
+	// See: https://docs.oracle.com/en-us/iaas/Content/ContEng/Reference/contengimagesshapes.htm
+	if nodeSourceDetails, ok := props["NodeSourceDetails"].(map[string]any); ok {
+		if _, hasImageName := util.ExtractImageQuery(nodeSourceDetails); hasImageName && nodeSourceDetails["imageId"] != nil {
+			return createDetails, fmt.Errorf("nodeSourceDetails takes either imageId or imageName, not both")
+		}
+		if imageId, ok := util.ExtractString(nodeSourceDetails, "imageId"); ok {
+			sourceDetails := containerengine.NodeSourceViaImageDetails{
+				ImageId: common.String(imageId),
+			}
+			if bootVolumeSizeInGBs, ok := nodeSourceDetails["bootVolumeSizeInGBs"].(float64); ok {
+				sourceDetails.BootVolumeSizeInGBs = common.Int64(int64(bootVolumeSizeInGBs))
+			}
+			createDetails.NodeSourceDetails = sourceDetails
+		} else if _, ok := util.ExtractImageQuery(nodeSourceDetails); !ok {
+			return createDetails, fmt.Errorf("nodeSourceDetails.imageId or imageName is required but not provided")
+		}
+	} else {
+		return createDetails, fmt.Errorf("nodeSourceDetails is required for NodePool creation - specify the OKE-optimized image OCID for your region")
+	}
+
+	if sshPublicKey, ok := util.ExtractString(props, "SshPublicKey"); ok {
+		createDetails.SshPublicKey = common.String(sshPublicKey)
+	}
+	if nodeMetadata, ok := extractNodeMetadata(props); ok {
+		createDetails.NodeMetadata = nodeMetadata
+	}
+
+	// Parse InitialNodeLabels (nested class fields stay camelCase)
+	if initialNodeLabels, ok := props["InitialNodeLabels"].([]any); ok {
+		labels := make([]containerengine.KeyValue, 0, len(initialNodeLabels))
+		for _, label := range initialNodeLabels {
+			if labelMap, ok := label.(map[string]any); ok {
+				kv := containerengine.KeyValue{}
+				if key, ok := util.ExtractString(labelMap, "key"); ok {
+					kv.Key = common.String(key)
+				}
+				if value, ok := util.ExtractString(labelMap, "value"); ok {
+					kv.Value = common.String(value)
+				}
+				labels = append(labels, kv)
+			}
+		}
+		createDetails.InitialNodeLabels = labels
+	}
+
+	if freeformTags, ok := util.ExtractFreeformTags(props, "FreeformTags"); ok {
+		createDetails.FreeformTags = freeformTags
+	}
+	if definedTags, ok := util.ExtractDefinedTags(props, "DefinedTags"); ok {
+		createDetails.DefinedTags = definedTags
+	}
+
+	return createDetails, nil
+}
+
+// buildNodePoolProperties is the inverse of parseCreateNodePoolDetails plus the
+// read-only fields.
+func buildNodePoolProperties(nodePool containerengine.NodePool, ignoredTagNamespaces []string) map[string]any {
 	props := map[string]any{
-		"CompartmentId": *resp.CompartmentId,
-		"Id":            *resp.Id,
-		"ClusterId":     *resp.ClusterId,
-		"Name":          *resp.Name,
-		"NodeShape":     *resp.NodeShape,
+		"CompartmentId": *nodePool.CompartmentId,
+		"Id":            *nodePool.Id,
+		"ClusterId":     *nodePool.ClusterId,
+		"Name":          *nodePool.Name,
+		"NodeShape":     *nodePool.NodeShape,
 	}
 
-	if resp.KubernetesVersion != nil {
-		props["KubernetesVersion"] = *resp.KubernetesVersion
+	if nodePool.KubernetesVersion != nil {
+		props["KubernetesVersion"] = *nodePool.KubernetesVersion
 	}
-	if resp.LifecycleState != "" {
-		props["LifecycleState"] = string(resp.LifecycleState)
+	if nodePool.LifecycleState != "" {
+		props["LifecycleState"] = string(nodePool.LifecycleState)
 	}
 
 	// NodeShapeConfig
-	if resp.NodeShapeConfig != nil {
+	if nodePool.NodeShapeConfig != nil {
 		shapeConfig := map[string]any{}
-		if resp.NodeShapeConfig.Ocpus != nil {
-			shapeConfig["ocpus"] = *resp.NodeShapeConfig.Ocpus
+		if nodePool.NodeShapeConfig.Ocpus != nil {
+			shapeConfig["ocpus"] = *nodePool.NodeShapeConfig.Ocpus
 		}
-		if resp.NodeShapeConfig.MemoryInGBs != nil {
-			shapeConfig["memoryInGBs"] = *resp.NodeShapeConfig.MemoryInGBs
+		if nodePool.NodeShapeConfig.MemoryInGBs != nil {
+			shapeConfig["memoryInGBs"] = *nodePool.NodeShapeConfig.MemoryInGBs
 		}
 		if len(shapeConfig) > 0 {
 			props["NodeShapeConfig"] = shapeConfig
@@ -408,8 +679,8 @@ func (p *NodePoolProvisioner) Read(ctx context.Context, request *resource.ReadRe
 	}
 
 	// NodeSourceDetails (polymorphic - currently only IMAGE type)
-	if resp.NodeSourceDetails != nil {
-		if imageDetails, ok := resp.NodeSourceDetails.(containerengine.NodeSourceViaImageDetails); ok {
+	if nodePool.NodeSourceDetails != nil {
+		if imageDetails, ok := nodePool.NodeSourceDetails.(containerengine.NodeSourceViaImageDetails); ok {
 			sourceDetails := map[string]any{}
 			if imageDetails.ImageId != nil {
 				sourceDetails["imageId"] = *imageDetails.ImageId
@@ -424,22 +695,22 @@ func (p *NodePoolProvisioner) Read(ctx context.Context, request *resource.ReadRe
 	}
 
 	// NodeConfigDetails
-	if resp.NodeConfigDetails != nil {
+	if nodePool.NodeConfigDetails != nil {
 		nodeConfig := map[string]any{}
-		if resp.NodeConfigDetails.Size != nil {
-			nodeConfig["size"] = *resp.NodeConfigDetails.Size
+		if nodePool.NodeConfigDetails.Size != nil {
+			nodeConfig["size"] = *nodePool.NodeConfigDetails.Size
 		}
-		if resp.NodeConfigDetails.NsgIds != nil {
-			nodeConfig["nsgIds"] = resp.NodeConfigDetails.NsgIds
+		if nodePool.NodeConfigDetails.NsgIds != nil {
+			nodeConfig["nsgIds"] = nodePool.NodeConfigDetails.NsgIds
 		}
-		if resp.NodeConfigDetails.IsPvEncryptionInTransitEnabled != nil {
-			nodeConfig["isPvEncryptionInTransitEnabled"] = *resp.NodeConfigDetails.IsPvEncryptionInTransitEnabled
+		if nodePool.NodeConfigDetails.IsPvEncryptionInTransitEnabled != nil {
+			nodeConfig["isPvEncryptionInTransitEnabled"] = *nodePool.NodeConfigDetails.IsPvEncryptionInTransitEnabled
 		}
 
 		// PlacementConfigs
-		if len(resp.NodeConfigDetails.PlacementConfigs) > 0 {
-			placementConfigs := make([]map[string]any, 0, len(resp.NodeConfigDetails.PlacementConfigs))
-			for _, pc := range resp.NodeConfigDetails.PlacementConfigs {
+		if len(nodePool.NodeConfigDetails.PlacementConfigs) > 0 {
+			placementConfigs := make([]map[string]any, 0, len(nodePool.NodeConfigDetails.PlacementConfigs))
+			for _, pc := range nodePool.NodeConfigDetails.PlacementConfigs {
 				pcMap := map[string]any{}
 				if pc.AvailabilityDomain != nil {
 					pcMap["availabilityDomain"] = *pc.AvailabilityDomain
@@ -458,20 +729,20 @@ func (p *NodePoolProvisioner) Read(ctx context.Context, request *resource.ReadRe
 			nodeConfig["placementConfigs"] = placementConfigs
 		}
 
-		if resp.NodeConfigDetails.FreeformTags != nil {
-			nodeConfig["freeformTags"] = util.FreeformTagsToList(resp.NodeConfigDetails.FreeformTags)
+		if nodePool.NodeConfigDetails.FreeformTags != nil {
+			nodeConfig["freeformTags"] = util.FreeformTagsToList(nodePool.NodeConfigDetails.FreeformTags)
 		}
-		if resp.NodeConfigDetails.DefinedTags != nil {
-			nodeConfig["definedTags"] = util.DefinedTagsToList(resp.NodeConfigDetails.DefinedTags)
+		if nodePool.NodeConfigDetails.DefinedTags != nil {
+			nodeConfig["definedTags"] = util.DefinedTagsToList(nodePool.NodeConfigDetails.DefinedTags, ignoredTagNamespaces)
 		}
 
 		props["NodeConfigDetails"] = nodeConfig
 	}
 
 	// InitialNodeLabels
-	if len(resp.InitialNodeLabels) > 0 {
-		labels := make([]map[string]any, 0, len(resp.InitialNodeLabels))
-		for _, label := range resp.InitialNodeLabels {
+	if len(nodePool.InitialNodeLabels) > 0 {
+		labels := make([]map[string]any, 0, len(nodePool.InitialNodeLabels))
+		for _, label := range nodePool.InitialNodeLabels {
 			labelMap := map[string]any{}
 			if label.Key != nil {
 				labelMap["key"] = *label.Key
@@ -484,89 +755,18 @@ func (p *NodePoolProvisioner) Read(ctx context.Context, request *resource.ReadRe
 		props["InitialNodeLabels"] = labels
 	}
 
-	if resp.SshPublicKey != nil {
-		props["SshPublicKey"] = *resp.SshPublicKey
-	}
-	if resp.FreeformTags != nil {
-		props["FreeformTags"] = util.FreeformTagsToList(resp.FreeformTags)
-	}
-	if resp.DefinedTags != nil {
-		props["DefinedTags"] = util.DefinedTagsToList(resp.DefinedTags)
-	}
-
-	propBytes, err := json.Marshal(props)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal NodePool properties: %w", err)
-	}
-
-	return &resource.ReadResult{
-		ResourceType: "OCI::ContainerEngine::NodePool",
-		Properties:   string(propBytes),
-	}, nil
-}
-
-func (p *NodePoolProvisioner) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
-	client, err := p.clients.GetContainerEngineClient()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get ContainerEngine client: %w", err)
-	}
-
-	var compartmentId string
-	var clusterId string
-
-	// Check if CompartmentId is provided directly
-	if cid, ok := request.AdditionalProperties["CompartmentId"]; ok {
-		compartmentId = cid
+	if nodePool.SshPublicKey != nil {
+		props["SshPublicKey"] = *nodePool.SshPublicKey
 	}
-
-	// Check if ClusterId is provided
-	if clid, ok := request.AdditionalProperties["ClusterId"]; ok {
-		clusterId = clid
-		// If we have ClusterId but no CompartmentId, derive it from the cluster
-		if compartmentId == "" {
-			getReq := containerengine.GetClusterRequest{
-				ClusterId: common.String(clusterId),
-			}
-			resp, err := client.GetCluster(ctx, getReq)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get Cluster to derive CompartmentId: %w", err)
-			}
-			compartmentId = *resp.CompartmentId
-		}
+	if len(nodePool.NodeMetadata) > 0 {
+		props["NodeMetadata"] = nodePool.NodeMetadata
 	}
-
-	if compartmentId == "" {
-		return nil, fmt.Errorf("CompartmentId is required for listing NodePools (either directly or derived from ClusterId)")
+	if nodePool.FreeformTags != nil {
+		props["FreeformTags"] = util.FreeformTagsToList(nodePool.FreeformTags)
 	}
-
-	listReq := containerengine.ListNodePoolsRequest{
-		CompartmentId: common.String(compartmentId),
-		// Filter out deleted/deleting/failed node pools - only return active or in-progress states
-		LifecycleState: []containerengine.NodePoolLifecycleStateEnum{
-			containerengine.NodePoolLifecycleStateCreating,
-			containerengine.NodePoolLifecycleStateActive,
-			containerengine.NodePoolLifecycleStateUpdating,
-			containerengine.NodePoolLifecycleStateInactive,
-			containerengine.NodePoolLifecycleStateNeedsAttention,
-		},
+	if nodePool.DefinedTags != nil {
+		props["DefinedTags"] = util.DefinedTagsToList(nodePool.DefinedTags, ignoredTagNamespaces)
 	}
 
-	// Filter by ClusterId if provided
-	if clusterId != "" {
-		listReq.ClusterId = common.String(clusterId)
-	}
-
-	resp, err := client.ListNodePools(ctx, listReq)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list NodePools: %w", err)
-	}
-
-	nativeIDs := make([]string, 0, len(resp.Items))
-	for _, nodePool := range resp.Items {
-		nativeIDs = append(nativeIDs, *nodePool.Id)
-	}
-
-	return &resource.ListResult{
-		NativeIDs: nativeIDs,
-	}, nil
+	return props
 }
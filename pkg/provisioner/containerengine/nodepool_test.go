@@ -0,0 +1,109 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package containerengine
+
+import (
+	"testing"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/containerengine"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodePoolReadRoundTripsThroughCreate(t *testing.T) {
+	nodePool := containerengine.NodePool{
+		Id:                common.String("ocid1.nodepool.oc1..test"),
+		CompartmentId:     common.String("ocid1.compartment.oc1..test"),
+		ClusterId:         common.String("ocid1.cluster.oc1..test"),
+		Name:              common.String("workers"),
+		NodeShape:         common.String("VM.Standard.E4.Flex"),
+		KubernetesVersion: common.String("v1.30.1"),
+		NodeShapeConfig: &containerengine.NodeShapeConfig{
+			Ocpus:       common.Float32(2),
+			MemoryInGBs: common.Float32(32),
+		},
+		NodeSourceDetails: containerengine.NodeSourceViaImageDetails{
+			ImageId:             common.String("ocid1.image.oc1..test"),
+			BootVolumeSizeInGBs: common.Int64(100),
+		},
+		NodeConfigDetails: &containerengine.NodePoolNodeConfigDetails{
+			Size:                           common.Int(3),
+			NsgIds:                         []string{"ocid1.nsg.oc1..workers"},
+			IsPvEncryptionInTransitEnabled: common.Bool(true),
+			PlacementConfigs: []containerengine.NodePoolPlacementConfigDetails{{
+				AvailabilityDomain: common.String("Uocm:PHX-AD-1"),
+				SubnetId:           common.String("ocid1.subnet.oc1..workers"),
+				FaultDomains:       []string{"FAULT-DOMAIN-1", "FAULT-DOMAIN-2"},
+			}},
+			FreeformTags: map[string]string{"Role": "worker"},
+		},
+		InitialNodeLabels: []containerengine.KeyValue{{Key: common.String("pool"), Value: common.String("workers")}},
+		SshPublicKey:      common.String("ssh-ed25519 AAAA"),
+		NodeMetadata:      map[string]string{"user_data": "I2Nsb3VkLWNvbmZpZwo="},
+		FreeformTags:      map[string]string{"Env": "prod"},
+	}
+
+	details, err := parseCreateNodePoolDetails(roundTrip(t, buildNodePoolProperties(nodePool, nil)))
+	require.NoError(t, err)
+
+	assert.Equal(t, nodePool.CompartmentId, details.CompartmentId)
+	assert.Equal(t, nodePool.ClusterId, details.ClusterId)
+	assert.Equal(t, nodePool.Name, details.Name)
+	assert.Equal(t, nodePool.NodeShape, details.NodeShape)
+	assert.Equal(t, nodePool.KubernetesVersion, details.KubernetesVersion)
+	assert.Equal(t, nodePool.NodeShapeConfig.Ocpus, details.NodeShapeConfig.Ocpus)
+	assert.Equal(t, nodePool.NodeShapeConfig.MemoryInGBs, details.NodeShapeConfig.MemoryInGBs)
+	assert.Equal(t, nodePool.NodeSourceDetails, details.NodeSourceDetails)
+	require.NotNil(t, details.NodeConfigDetails)
+	assert.Equal(t, nodePool.NodeConfigDetails.Size, details.NodeConfigDetails.Size)
+	assert.Equal(t, nodePool.NodeConfigDetails.NsgIds, details.NodeConfigDetails.NsgIds)
+	assert.Equal(t, nodePool.NodeConfigDetails.IsPvEncryptionInTransitEnabled, details.NodeConfigDetails.IsPvEncryptionInTransitEnabled)
+	assert.Equal(t, nodePool.NodeConfigDetails.PlacementConfigs, details.NodeConfigDetails.PlacementConfigs)
+	assert.Equal(t, nodePool.NodeConfigDetails.FreeformTags, details.NodeConfigDetails.FreeformTags)
+	assert.Equal(t, nodePool.InitialNodeLabels, details.InitialNodeLabels)
+	assert.Equal(t, nodePool.SshPublicKey, details.SshPublicKey)
+	assert.Equal(t, nodePool.NodeMetadata, details.NodeMetadata)
+	assert.Equal(t, nodePool.FreeformTags, details.FreeformTags)
+}
+
+func TestValidateNodePoolSize(t *testing.T) {
+	placement := []any{map[string]any{"availabilityDomain": "Uocm:PHX-AD-1", "subnetId": "ocid1.subnet.oc1..workers"}}
+	props := func(size float64, placementConfigs []any) map[string]any {
+		return map[string]any{"NodeConfigDetails": map[string]any{"size": size, "placementConfigs": placementConfigs}}
+	}
+
+	tests := []struct {
+		name    string
+		props   map[string]any
+		cfg     config.Config
+		wantErr string
+	}{
+		{name: "within_defaults", props: props(3, placement)},
+		{name: "scale_to_zero", props: props(0, nil)},
+		{name: "no_node_config", props: map[string]any{}},
+		{name: "above_default_max", props: props(5000, placement), wantErr: "NodeConfigDetails.size 5000 is out of range, must be between 0 and 1000"},
+		{name: "below_configured_min", props: props(0, placement), cfg: config.Config{NodePoolMinSize: 1}, wantErr: "must be between 1 and 1000"},
+		{name: "above_configured_max", props: props(11, placement), cfg: config.Config{NodePoolMaxSize: 10}, wantErr: "must be between 0 and 10"},
+		{name: "no_placement", props: props(3, nil), wantErr: "NodeConfigDetails.size is 3 but placementConfigs is empty"},
+		{
+			name:    "duplicate_ad",
+			props:   props(3, append(append([]any{}, placement...), placement...)),
+			wantErr: "lists availability domain Uocm:PHX-AD-1 more than once",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateNodePoolSize(tt.props, &tt.cfg)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
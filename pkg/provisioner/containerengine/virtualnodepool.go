@@ -12,6 +12,7 @@ import (
 	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/oracle/oci-go-sdk/v65/containerengine"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
@@ -25,6 +26,12 @@ var _ provisioner.Provisioner = &VirtualNodePoolProvisioner{}
 
 func init() {
 	provisioner.Register("OCI::ContainerEngine::VirtualNodePool", NewVirtualNodePoolProvisioner)
+	provisioner.DependsOn("OCI::ContainerEngine::VirtualNodePool",
+		"OCI::Identity::Compartment",
+		"OCI::ContainerEngine::Cluster",
+		"OCI::Core::Subnet",
+		"OCI::Core::NetworkSecurityGroup",
+	)
 }
 
 func NewVirtualNodePoolProvisioner(clients *client.Clients) provisioner.Provisioner {
@@ -79,7 +86,7 @@ func (p *VirtualNodePoolProvisioner) Create(ctx context.Context, request *resour
 		if shape, ok := util.ExtractString(podConfig, "shape"); ok {
 			config.Shape = common.String(shape)
 		}
-		if nsgIds, ok := util.ExtractStringSlice(podConfig, "nsgIds"); ok {
+		if nsgIds, ok := util.ExtractNsgIds(podConfig); ok {
 			config.NsgIds = nsgIds
 		}
 		createDetails.PodConfiguration = config
@@ -89,7 +96,7 @@ func (p *VirtualNodePoolProvisioner) Create(ctx context.Context, request *resour
 		createDetails.Size = common.Int(int(size))
 	}
 
-	if nsgIds, ok := util.ExtractStringSlice(props, "NsgIds"); ok {
+	if nsgIds, ok := util.ExtractNsgIds(props); ok {
 		createDetails.NsgIds = nsgIds
 	}
 
@@ -122,13 +129,13 @@ func (p *VirtualNodePoolProvisioner) Create(ctx context.Context, request *resour
 		for _, taint := range taints {
 			if taintMap, ok := taint.(map[string]any); ok {
 				t := containerengine.Taint{}
-				if key, ok := util.ExtractString(taintMap, "Key"); ok {
+				if key, ok := util.ExtractString(taintMap, "key"); ok {
 					t.Key = common.String(key)
 				}
-				if value, ok := util.ExtractString(taintMap, "Value"); ok {
+				if value, ok := util.ExtractString(taintMap, "value"); ok {
 					t.Value = common.String(value)
 				}
-				if effect, ok := util.ExtractString(taintMap, "Effect"); ok {
+				if effect, ok := util.ExtractString(taintMap, "effect"); ok {
 					t.Effect = common.String(effect)
 				}
 				taintList = append(taintList, t)
@@ -145,6 +152,7 @@ func (p *VirtualNodePoolProvisioner) Create(ctx context.Context, request *resour
 	}
 
 	createReq := containerengine.CreateVirtualNodePoolRequest{
+		OpcRetryToken:                common.String(util.CreateRetryToken(request)),
 		CreateVirtualNodePoolDetails: createDetails,
 	}
 
@@ -209,7 +217,7 @@ func (p *VirtualNodePoolProvisioner) Update(ctx context.Context, request *resour
 		if shape, ok := util.ExtractString(podConfig, "shape"); ok {
 			config.Shape = common.String(shape)
 		}
-		if nsgIds, ok := util.ExtractStringSlice(podConfig, "nsgIds"); ok {
+		if nsgIds, ok := util.ExtractNsgIds(podConfig); ok {
 			config.NsgIds = nsgIds
 		}
 		updateDetails.PodConfiguration = config
@@ -219,7 +227,7 @@ func (p *VirtualNodePoolProvisioner) Update(ctx context.Context, request *resour
 		updateDetails.Size = common.Int(int(size))
 	}
 
-	if nsgIds, ok := util.ExtractStringSlice(props, "NsgIds"); ok {
+	if nsgIds, ok := util.ExtractNsgIds(props); ok {
 		updateDetails.NsgIds = nsgIds
 	}
 
@@ -365,7 +373,7 @@ func (p *VirtualNodePoolProvisioner) Read(ctx context.Context, request *resource
 	resp, err := client.GetVirtualNodePool(ctx, getReq)
 	if err != nil {
 		// Check if not found
-		if serviceErr, ok := common.IsServiceError(err); ok && serviceErr.GetHTTPStatusCode() == 404 {
+		if util.IsNotFound(err) {
 			return &resource.ReadResult{
 				ResourceType: "OCI::ContainerEngine::VirtualNodePool",
 				ErrorCode:    resource.OperationErrorCodeNotFound,
@@ -478,7 +486,7 @@ func (p *VirtualNodePoolProvisioner) Read(ctx context.Context, request *resource
 		props["FreeformTags"] = util.FreeformTagsToList(resp.FreeformTags)
 	}
 	if resp.DefinedTags != nil {
-		props["DefinedTags"] = util.DefinedTagsToList(resp.DefinedTags)
+		props["DefinedTags"] = util.DefinedTagsToList(resp.DefinedTags, config.FromTargetConfig(request.TargetConfig).HiddenTagNamespaces())
 	}
 
 	propBytes, err := json.Marshal(props)
@@ -522,6 +530,10 @@ func (p *VirtualNodePoolProvisioner) List(ctx context.Context, request *resource
 		}
 	}
 
+	if compartmentId == "" {
+		compartmentId, _ = util.ListCompartmentId(request)
+	}
+
 	if compartmentId == "" {
 		return nil, fmt.Errorf("CompartmentId is required for listing VirtualNodePools (either directly or derived from ClusterId)")
 	}
@@ -535,6 +547,7 @@ func (p *VirtualNodePoolProvisioner) List(ctx context.Context, request *resource
 			containerengine.VirtualNodePoolLifecycleStateUpdating,
 			containerengine.VirtualNodePoolLifecycleStateNeedsAttention,
 		},
+		Limit: util.ListPageSize(request.TargetConfig),
 	}
 
 	// Filter by ClusterId if provided
@@ -542,14 +555,20 @@ func (p *VirtualNodePoolProvisioner) List(ctx context.Context, request *resource
 		listReq.ClusterId = common.String(clusterId)
 	}
 
-	resp, err := client.ListVirtualNodePools(ctx, listReq)
+	nativeIDs, err := util.ListAllPages(func(page *string) ([]string, *string, error) {
+		listReq.Page = page
+		resp, err := client.ListVirtualNodePools(ctx, listReq)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list VirtualNodePools: %w", err)
+		}
+		ids := make([]string, 0, len(resp.Items))
+		for _, pool := range resp.Items {
+			ids = append(ids, *pool.Id)
+		}
+		return ids, resp.OpcNextPage, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list VirtualNodePools: %w", err)
-	}
-
-	nativeIDs := make([]string, 0, len(resp.Items))
-	for _, pool := range resp.Items {
-		nativeIDs = append(nativeIDs, *pool.Id)
+		return nil, err
 	}
 
 	return &resource.ListResult{
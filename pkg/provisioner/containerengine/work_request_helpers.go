@@ -11,6 +11,7 @@ import (
 
 	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/oracle/oci-go-sdk/v65/containerengine"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
 )
 
@@ -23,52 +24,40 @@ func CheckWorkRequestStatus(
 	workRequestId string,
 	operation resource.Operation,
 ) (*resource.ProgressResult, error) {
-	resp, err := client.GetWorkRequest(ctx, containerengine.GetWorkRequestRequest{
+	return util.PollWorkRequest(ctx, workRequestPoller{client}, workRequestId, operation)
+}
+
+// workRequestPoller adapts the ContainerEngine client to util.WorkRequestPoller.
+// ContainerEngine work requests list the resources they touched, so successful
+// results carry the NativeID without the caller knowing it.
+type workRequestPoller struct {
+	client *containerengine.ContainerEngineClient
+}
+
+func (p workRequestPoller) GetWorkRequest(ctx context.Context, workRequestId string) (*util.WorkRequest, error) {
+	resp, err := p.client.GetWorkRequest(ctx, containerengine.GetWorkRequestRequest{
 		WorkRequestId: common.String(workRequestId),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get work request %s: %w", workRequestId, err)
 	}
 
+	wr := &util.WorkRequest{Status: string(resp.Status)}
 	switch resp.Status {
 	case containerengine.WorkRequestStatusSucceeded:
-		nativeID := extractResourceId(resp.Resources, containerengine.WorkRequestResourceActionTypeCreated)
-		if nativeID == "" {
+		wr.ResourceID = extractResourceId(resp.Resources, containerengine.WorkRequestResourceActionTypeCreated)
+		if wr.ResourceID == "" {
 			// For updates, try to get the updated resource
-			nativeID = extractResourceId(resp.Resources, containerengine.WorkRequestResourceActionTypeUpdated)
+			wr.ResourceID = extractResourceId(resp.Resources, containerengine.WorkRequestResourceActionTypeUpdated)
 		}
-		if nativeID == "" {
+		if wr.ResourceID == "" {
 			// For deletes or other operations, try to get any related resource
-			nativeID = extractResourceId(resp.Resources, containerengine.WorkRequestResourceActionTypeRelated)
+			wr.ResourceID = extractResourceId(resp.Resources, containerengine.WorkRequestResourceActionTypeRelated)
 		}
-		return &resource.ProgressResult{
-			Operation:       operation,
-			OperationStatus: resource.OperationStatusSuccess,
-			NativeID:        nativeID,
-		}, nil
-
 	case containerengine.WorkRequestStatusFailed:
-		errorMsg := getWorkRequestErrors(ctx, client, workRequestId, resp.CompartmentId)
-		return &resource.ProgressResult{
-			Operation:       operation,
-			OperationStatus: resource.OperationStatusFailure,
-			StatusMessage:   errorMsg,
-		}, nil
-
-	case containerengine.WorkRequestStatusCanceled:
-		return &resource.ProgressResult{
-			Operation:       operation,
-			OperationStatus: resource.OperationStatusFailure,
-			StatusMessage:   "Operation was canceled",
-		}, nil
-
-	default: // ACCEPTED, IN_PROGRESS, CANCELING
-		return &resource.ProgressResult{
-			Operation:       operation,
-			OperationStatus: resource.OperationStatusInProgress,
-			RequestID:       workRequestId,
-		}, nil
+		wr.FailureMessage = getWorkRequestErrors(ctx, p.client, workRequestId, resp.CompartmentId)
 	}
+	return wr, nil
 }
 
 // extractResourceId finds the resource identifier from WorkRequest resources by action type
@@ -0,0 +1,201 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+// DefaultRouteTableProvisioner manages the route table OCI creates with every
+// VCN. That table can't be created or deleted on its own, so Create takes it
+// over and applies the declared rules in place, and Delete leaves it for the
+// VCN to remove.
+type DefaultRouteTableProvisioner struct {
+	clients *client.Clients
+	svc     *core.VirtualNetworkClient // nil until first use; injected in tests
+}
+
+var _ provisioner.Provisioner = &DefaultRouteTableProvisioner{}
+
+func init() {
+	provisioner.Register("OCI::Core::DefaultRouteTable", NewDefaultRouteTableProvisioner)
+	provisioner.DependsOn("OCI::Core::DefaultRouteTable",
+		"OCI::Core::VCN",
+		"OCI::Core::InternetGateway",
+		"OCI::Core::NatGateway",
+		"OCI::Core::ServiceGateway",
+	)
+}
+
+func NewDefaultRouteTableProvisioner(clients *client.Clients) provisioner.Provisioner {
+	return &DefaultRouteTableProvisioner{clients: clients}
+}
+
+// NewDefaultRouteTableProvisionerWithSvc constructs a provisioner with a pre-built SDK client,
+// for use in tests that point the client at an httptest server.
+func NewDefaultRouteTableProvisionerWithSvc(svc *core.VirtualNetworkClient) *DefaultRouteTableProvisioner {
+	return &DefaultRouteTableProvisioner{svc: svc}
+}
+
+func (p *DefaultRouteTableProvisioner) getSvc() (*core.VirtualNetworkClient, error) {
+	if p.svc != nil {
+		return p.svc, nil
+	}
+	return p.clients.GetVirtualNetworkClient()
+}
+
+func (p *DefaultRouteTableProvisioner) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
+	}
+
+	var props map[string]any
+	if err := json.Unmarshal(request.Properties, &props); err != nil {
+		return nil, fmt.Errorf("failed to parse properties: %w", err)
+	}
+
+	vcnId, ok := util.ExtractString(props, "VcnId")
+	if !ok {
+		return nil, fmt.Errorf("VcnId is required")
+	}
+
+	vcn, err := client.GetVcn(ctx, core.GetVcnRequest{VcnId: common.String(vcnId)})
+	if err != nil {
+		if result, handleErr := util.HandleCreateError(err, "OCI::Core::DefaultRouteTable", "OCI::Core::DefaultRouteTable"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to read VCN %s: %w", vcnId, err)
+	}
+	if vcn.DefaultRouteTableId == nil {
+		return nil, fmt.Errorf("VCN %s has no default route table", vcnId)
+	}
+
+	updateDetails, err := routeTableUpdateDetails(props)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.UpdateRouteTable(ctx, core.UpdateRouteTableRequest{
+		RtId:                    vcn.DefaultRouteTableId,
+		UpdateRouteTableDetails: updateDetails,
+	})
+	if err != nil {
+		if result, handleErr := util.HandleCreateError(err, "OCI::Core::DefaultRouteTable", "OCI::Core::DefaultRouteTable"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to update default RouteTable: %w", err)
+	}
+
+	return &resource.CreateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationCreate,
+			OperationStatus: resource.OperationStatusSuccess,
+			NativeID:        *resp.Id,
+		},
+	}, nil
+}
+
+func (p *DefaultRouteTableProvisioner) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
+	}
+
+	props, err := util.ApplyPatchDocument(ctx, request, p.Read)
+	if err != nil {
+		return nil, err
+	}
+
+	updateDetails, err := routeTableUpdateDetails(props)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.UpdateRouteTable(ctx, core.UpdateRouteTableRequest{
+		RtId:                    common.String(request.NativeID),
+		UpdateRouteTableDetails: updateDetails,
+	})
+	if err != nil {
+		if result, handleErr := util.HandleUpdateError(err, "OCI::Core::DefaultRouteTable", request.NativeID, "OCI::Core::DefaultRouteTable"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to update default RouteTable: %w", err)
+	}
+
+	return &resource.UpdateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationUpdate,
+			OperationStatus: resource.OperationStatusSuccess,
+			NativeID:        *resp.Id,
+		},
+	}, nil
+}
+
+// SkipsDeleteConfirmation: Delete leaves the table to the VCN, so a Read
+// after it still finds the table.
+func (p *DefaultRouteTableProvisioner) SkipsDeleteConfirmation() {}
+
+// Delete succeeds without calling OCI: the default route table lives exactly as
+// long as its VCN, and the VCN's own Delete clears its rules.
+func (p *DefaultRouteTableProvisioner) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	return &resource.DeleteResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationDelete,
+			OperationStatus: resource.OperationStatusSuccess,
+			NativeID:        request.NativeID,
+		},
+	}, nil
+}
+
+func (p *DefaultRouteTableProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	return util.SyncStatus(ctx, request, p.Read)
+}
+
+func (p *DefaultRouteTableProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
+	}
+	return readRouteTable(ctx, client, request, "OCI::Core::DefaultRouteTable")
+}
+
+func (p *DefaultRouteTableProvisioner) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
+	}
+
+	vcnId, ok := request.AdditionalProperties["VcnId"]
+	if !ok {
+		return nil, fmt.Errorf("VcnId is required for listing DefaultRouteTables")
+	}
+
+	vcn, err := client.GetVcn(ctx, core.GetVcnRequest{VcnId: common.String(vcnId)})
+	if err != nil {
+		if util.IsNotFound(err) {
+			return &resource.ListResult{NativeIDs: []string{}}, nil
+		}
+		return nil, fmt.Errorf("failed to list DefaultRouteTables: %w", err)
+	}
+
+	nativeIDs := []string{}
+	if vcn.DefaultRouteTableId != nil && !util.IsTerminal(string(vcn.LifecycleState)) {
+		nativeIDs = append(nativeIDs, *vcn.DefaultRouteTableId)
+	}
+
+	return &resource.ListResult{
+		NativeIDs: nativeIDs,
+	}, nil
+}
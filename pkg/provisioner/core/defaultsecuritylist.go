@@ -0,0 +1,197 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+// DefaultSecurityListProvisioner manages the security list OCI creates with
+// every VCN, typically to lock down its allow-SSH-from-anywhere ingress rule.
+// That list can't be created or deleted on its own, so Create takes it
+// over and applies the declared rules in place, and Delete leaves it for the
+// VCN to remove.
+type DefaultSecurityListProvisioner struct {
+	clients *client.Clients
+	svc     *core.VirtualNetworkClient // nil until first use; injected in tests
+}
+
+var _ provisioner.Provisioner = &DefaultSecurityListProvisioner{}
+
+func init() {
+	provisioner.Register("OCI::Core::DefaultSecurityList", NewDefaultSecurityListProvisioner)
+	provisioner.DependsOn("OCI::Core::DefaultSecurityList", "OCI::Core::VCN")
+}
+
+func NewDefaultSecurityListProvisioner(clients *client.Clients) provisioner.Provisioner {
+	return &DefaultSecurityListProvisioner{clients: clients}
+}
+
+// NewDefaultSecurityListProvisionerWithSvc constructs a provisioner with a pre-built SDK client,
+// for use in tests that point the client at an httptest server.
+func NewDefaultSecurityListProvisionerWithSvc(svc *core.VirtualNetworkClient) *DefaultSecurityListProvisioner {
+	return &DefaultSecurityListProvisioner{svc: svc}
+}
+
+func (p *DefaultSecurityListProvisioner) getSvc() (*core.VirtualNetworkClient, error) {
+	if p.svc != nil {
+		return p.svc, nil
+	}
+	return p.clients.GetVirtualNetworkClient()
+}
+
+func (p *DefaultSecurityListProvisioner) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
+	}
+
+	var props map[string]any
+	if err := json.Unmarshal(request.Properties, &props); err != nil {
+		return nil, fmt.Errorf("failed to parse properties: %w", err)
+	}
+
+	vcnId, ok := util.ExtractString(props, "VcnId")
+	if !ok {
+		return nil, fmt.Errorf("VcnId is required")
+	}
+
+	vcn, err := client.GetVcn(ctx, core.GetVcnRequest{VcnId: common.String(vcnId)})
+	if err != nil {
+		if result, handleErr := util.HandleCreateError(err, "OCI::Core::DefaultSecurityList", "OCI::Core::DefaultSecurityList"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to read VCN %s: %w", vcnId, err)
+	}
+	if vcn.DefaultSecurityListId == nil {
+		return nil, fmt.Errorf("VCN %s has no default security list", vcnId)
+	}
+
+	updateDetails, err := securityListUpdateDetails(props)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.UpdateSecurityList(ctx, core.UpdateSecurityListRequest{
+		SecurityListId:            vcn.DefaultSecurityListId,
+		UpdateSecurityListDetails: updateDetails,
+	})
+	if err != nil {
+		if result, handleErr := util.HandleCreateError(err, "OCI::Core::DefaultSecurityList", "OCI::Core::DefaultSecurityList"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to update default SecurityList: %w", err)
+	}
+
+	return &resource.CreateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationCreate,
+			OperationStatus: resource.OperationStatusSuccess,
+			NativeID:        *resp.Id,
+		},
+	}, nil
+}
+
+func (p *DefaultSecurityListProvisioner) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
+	}
+
+	props, err := util.ApplyPatchDocument(ctx, request, p.Read)
+	if err != nil {
+		return nil, err
+	}
+
+	updateDetails, err := securityListUpdateDetails(props)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.UpdateSecurityList(ctx, core.UpdateSecurityListRequest{
+		SecurityListId:            common.String(request.NativeID),
+		UpdateSecurityListDetails: updateDetails,
+	})
+	if err != nil {
+		if result, handleErr := util.HandleUpdateError(err, "OCI::Core::DefaultSecurityList", request.NativeID, "OCI::Core::DefaultSecurityList"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to update default SecurityList: %w", err)
+	}
+
+	return &resource.UpdateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationUpdate,
+			OperationStatus: resource.OperationStatusSuccess,
+			NativeID:        *resp.Id,
+		},
+	}, nil
+}
+
+// SkipsDeleteConfirmation: Delete leaves the list to the VCN, so a Read
+// after it still finds the list.
+func (p *DefaultSecurityListProvisioner) SkipsDeleteConfirmation() {}
+
+// Delete succeeds without calling OCI: the default security list lives exactly
+// as long as its VCN and is removed with it.
+func (p *DefaultSecurityListProvisioner) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	return &resource.DeleteResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationDelete,
+			OperationStatus: resource.OperationStatusSuccess,
+			NativeID:        request.NativeID,
+		},
+	}, nil
+}
+
+func (p *DefaultSecurityListProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	return util.SyncStatus(ctx, request, p.Read)
+}
+
+func (p *DefaultSecurityListProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
+	}
+	return readSecurityList(ctx, client, request, "OCI::Core::DefaultSecurityList")
+}
+
+func (p *DefaultSecurityListProvisioner) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
+	}
+
+	vcnId, ok := request.AdditionalProperties["VcnId"]
+	if !ok {
+		return nil, fmt.Errorf("VcnId is required for listing DefaultSecurityLists")
+	}
+
+	vcn, err := client.GetVcn(ctx, core.GetVcnRequest{VcnId: common.String(vcnId)})
+	if err != nil {
+		if util.IsNotFound(err) {
+			return &resource.ListResult{NativeIDs: []string{}}, nil
+		}
+		return nil, fmt.Errorf("failed to list DefaultSecurityLists: %w", err)
+	}
+
+	nativeIDs := []string{}
+	if vcn.DefaultSecurityListId != nil && !util.IsTerminal(string(vcn.LifecycleState)) {
+		nativeIDs = append(nativeIDs, *vcn.DefaultSecurityListId)
+	}
+
+	return &resource.ListResult{
+		NativeIDs: nativeIDs,
+	}, nil
+}
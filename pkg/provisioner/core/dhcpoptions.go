@@ -12,6 +12,7 @@ import (
 	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/oracle/oci-go-sdk/v65/core"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
@@ -26,6 +27,7 @@ var _ provisioner.Provisioner = &DhcpOptionsProvisioner{}
 
 func init() {
 	provisioner.Register("OCI::Core::DhcpOptions", NewDhcpOptionsProvisioner)
+	provisioner.DependsOn("OCI::Core::DhcpOptions", "OCI::Identity::Compartment", "OCI::Core::VCN")
 }
 
 func NewDhcpOptionsProvisioner(clients *client.Clients) provisioner.Provisioner {
@@ -171,6 +173,7 @@ func (p *DhcpOptionsProvisioner) Create(ctx context.Context, request *resource.C
 	}
 
 	createReq := core.CreateDhcpOptionsRequest{
+		OpcRetryToken:     common.String(util.CreateRetryToken(request)),
 		CreateDhcpDetails: createDetails,
 	}
 
@@ -203,7 +206,7 @@ func (p *DhcpOptionsProvisioner) Read(ctx context.Context, request *resource.Rea
 
 	resp, err := svc.GetDhcpOptions(ctx, getReq)
 	if err != nil {
-		if serviceErr, ok := common.IsServiceError(err); ok && serviceErr.GetHTTPStatusCode() == 404 {
+		if util.IsNotFound(err) {
 			return &resource.ReadResult{
 				ResourceType: "OCI::Core::DhcpOptions",
 				ErrorCode:    resource.OperationErrorCodeNotFound,
@@ -219,7 +222,7 @@ func (p *DhcpOptionsProvisioner) Read(ctx context.Context, request *resource.Rea
 		}, nil
 	}
 
-	properties := buildDhcpOptionsProperties(resp.DhcpOptions)
+	properties := buildDhcpOptionsProperties(resp.DhcpOptions, config.FromTargetConfig(request.TargetConfig).HiddenTagNamespaces())
 
 	propBytes, err := json.Marshal(properties)
 	if err != nil {
@@ -332,13 +335,7 @@ func (p *DhcpOptionsProvisioner) Delete(ctx context.Context, request *resource.D
 }
 
 func (p *DhcpOptionsProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
-	return &resource.StatusResult{
-		ProgressResult: &resource.ProgressResult{
-			Operation:       resource.OperationCheckStatus,
-			OperationStatus: resource.OperationStatusSuccess,
-			RequestID:       request.RequestID,
-		},
-	}, nil
+	return util.SyncStatus(ctx, request, p.Read)
 }
 
 func (p *DhcpOptionsProvisioner) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
@@ -347,27 +344,34 @@ func (p *DhcpOptionsProvisioner) List(ctx context.Context, request *resource.Lis
 		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
 	}
 
-	compartmentId, ok := request.AdditionalProperties["CompartmentId"]
+	compartmentId, ok := util.ListCompartmentId(request)
 	if !ok {
 		return nil, fmt.Errorf("CompartmentId is required for listing DhcpOptions")
 	}
 
 	listReq := core.ListDhcpOptionsRequest{
 		CompartmentId: common.String(compartmentId),
+		Limit:         util.ListPageSize(request.TargetConfig),
 	}
 
 	if vcnId, ok := request.AdditionalProperties["VcnId"]; ok {
 		listReq.VcnId = common.String(vcnId)
 	}
 
-	resp, err := svc.ListDhcpOptions(ctx, listReq)
+	nativeIDs, err := util.ListAllPages(func(page *string) ([]string, *string, error) {
+		listReq.Page = page
+		resp, err := svc.ListDhcpOptions(ctx, listReq)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list DhcpOptions: %w", err)
+		}
+		ids := make([]string, 0, len(resp.Items))
+		for _, item := range resp.Items {
+			ids = append(ids, *item.Id)
+		}
+		return ids, resp.OpcNextPage, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list DhcpOptions: %w", err)
-	}
-
-	nativeIDs := make([]string, 0, len(resp.Items))
-	for _, item := range resp.Items {
-		nativeIDs = append(nativeIDs, *item.Id)
+		return nil, err
 	}
 
 	return &resource.ListResult{
@@ -375,7 +379,7 @@ func (p *DhcpOptionsProvisioner) List(ctx context.Context, request *resource.Lis
 	}, nil
 }
 
-func buildDhcpOptionsProperties(dhcp core.DhcpOptions) map[string]any {
+func buildDhcpOptionsProperties(dhcp core.DhcpOptions, ignoredTagNamespaces []string) map[string]any {
 	properties := map[string]any{
 		"CompartmentId": *dhcp.CompartmentId,
 		"VcnId":         *dhcp.VcnId,
@@ -393,7 +397,7 @@ func buildDhcpOptionsProperties(dhcp core.DhcpOptions) map[string]any {
 		properties["FreeformTags"] = util.FreeformTagsToList(dhcp.FreeformTags)
 	}
 	if dhcp.DefinedTags != nil {
-		properties["DefinedTags"] = util.DefinedTagsToList(dhcp.DefinedTags)
+		properties["DefinedTags"] = util.DefinedTagsToList(dhcp.DefinedTags, ignoredTagNamespaces)
 	}
 
 	return properties
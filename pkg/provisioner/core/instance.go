@@ -6,33 +6,117 @@ package core
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"strings"
 
 	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/oracle/oci-go-sdk/v65/identity"
+	"github.com/oracle/oci-go-sdk/v65/workrequests"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
 )
 
 type InstanceProvisioner struct {
-	clients *client.Clients
+	clients      *client.Clients
+	svc          *core.ComputeClient             // nil until first use; injected in tests
+	identity     *identity.IdentityClient        // nil until first use; injected in tests
+	network      *core.VirtualNetworkClient      // nil until first use; injected in tests
+	blockstorage *core.BlockstorageClient        // nil until first use; injected in tests
+	workRequests *workrequests.WorkRequestClient // nil until first use; injected in tests
 }
 
-var _ provisioner.Provisioner = &InstanceProvisioner{}
+var (
+	_ provisioner.Provisioner = &InstanceProvisioner{}
+	_ provisioner.Immutable   = &InstanceProvisioner{}
+	_ provisioner.Equivalent  = &InstanceProvisioner{}
+	_ provisioner.Opaque      = &InstanceProvisioner{}
+)
 
 func init() {
 	provisioner.Register("OCI::Core::Instance", NewInstanceProvisioner)
+	provisioner.DependsOn("OCI::Core::Instance", "OCI::Identity::Compartment", "OCI::Core::Subnet", "OCI::Core::NetworkSecurityGroup")
 }
 
 func NewInstanceProvisioner(clients *client.Clients) provisioner.Provisioner {
 	return &InstanceProvisioner{clients: clients}
 }
 
+// NewInstanceProvisionerWithSvc constructs a provisioner with pre-built SDK clients,
+// for use in tests that point the clients at an httptest server. identity
+// resolves short availability domain names and may be nil when a test uses
+// full ones; network reads and updates the primary VNIC, blockstorage the
+// boot volume and workRequests polls the update before a compartment move.
+func NewInstanceProvisionerWithSvc(svc *core.ComputeClient, identity *identity.IdentityClient, network *core.VirtualNetworkClient, blockstorage *core.BlockstorageClient, workRequests *workrequests.WorkRequestClient) *InstanceProvisioner {
+	return &InstanceProvisioner{svc: svc, identity: identity, network: network, blockstorage: blockstorage, workRequests: workRequests}
+}
+
+// ImmutableFields lists the availability domain an instance runs in, which it
+// can't change in place. A new FaultDomain moves the instance within its
+// availability domain, and shape changes are resizes; see instanceShapeChanged.
+func (p *InstanceProvisioner) ImmutableFields() []string {
+	return []string{"AvailabilityDomain"}
+}
+
+// OpaqueFields lists Metadata, whose keys (ssh_authorized_keys, user_data, ...)
+// are passed to the instance as they are.
+func (p *InstanceProvisioner) OpaqueFields() []string {
+	return []string{"Metadata"}
+}
+
+func (p *InstanceProvisioner) getSvc() (*core.ComputeClient, error) {
+	if p.svc != nil {
+		return p.svc, nil
+	}
+	return p.clients.GetComputeClient()
+}
+
+func (p *InstanceProvisioner) getIdentity() (*identity.IdentityClient, error) {
+	if p.identity != nil {
+		return p.identity, nil
+	}
+	return p.clients.GetIdentityClient()
+}
+
+func (p *InstanceProvisioner) getNetwork() (*core.VirtualNetworkClient, error) {
+	if p.network != nil {
+		return p.network, nil
+	}
+	return p.clients.GetVirtualNetworkClient()
+}
+
+func (p *InstanceProvisioner) getBlockstorage() (*core.BlockstorageClient, error) {
+	if p.blockstorage != nil {
+		return p.blockstorage, nil
+	}
+	return p.clients.GetBlockstorageClient()
+}
+
+func (p *InstanceProvisioner) getWorkRequests() (*workrequests.WorkRequestClient, error) {
+	if p.workRequests != nil {
+		return p.workRequests, nil
+	}
+	return p.clients.GetWorkRequestClient()
+}
+
+// SameValue compares AvailabilityDomain in resolved form, so a short name in
+// the desired state matches the full name Read reports.
+func (p *InstanceProvisioner) SameValue(ctx context.Context, field string, live map[string]any, desired any) bool {
+	if field != "AvailabilityDomain" {
+		return false
+	}
+	compartmentId, _ := live["CompartmentId"].(string)
+	return sameAvailabilityDomain(ctx, p.getIdentity, compartmentId, live[field], desired)
+}
+
 func (p *InstanceProvisioner) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
-	svc, err := p.clients.GetComputeClient()
+	svc, err := p.getSvc()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get Compute client: %w", err)
 	}
@@ -42,46 +126,67 @@ func (p *InstanceProvisioner) Create(ctx context.Context, request *resource.Crea
 		return nil, fmt.Errorf("failed to parse properties: %w", err)
 	}
 
-	launchDetails := core.LaunchInstanceDetails{
-		CompartmentId:      common.String(props["CompartmentId"].(string)),
-		AvailabilityDomain: common.String(props["AvailabilityDomain"].(string)),
-		Shape:              common.String(props["Shape"].(string)),
+	launchDetails, err := parseLaunchInstanceDetails(props)
+	if err != nil {
+		return nil, err
 	}
 
-	if displayName, ok := util.ExtractString(props, "DisplayName"); ok {
-		launchDetails.DisplayName = common.String(displayName)
+	if source, ok := launchDetails.SourceDetails.(core.InstanceSourceViaImageDetails); ok && source.ImageId == nil {
+		if query, ok := util.ExtractImageQuery(props["SourceDetails"].(map[string]any)); ok {
+			if launchDetails.Shape != nil {
+				query.Shape = *launchDetails.Shape
+			}
+			imageId, err := util.ResolveImageByName(ctx, svc, *launchDetails.CompartmentId, query)
+			if err != nil {
+				return nil, err
+			}
+			source.ImageId = common.String(imageId)
+			launchDetails.SourceDetails = source
+		}
 	}
 
-	if sourceDetails, ok := props["SourceDetails"].(map[string]any); ok {
-		launchDetails.SourceDetails = parseSourceDetails(sourceDetails)
+	availabilityDomain, err := resolveAvailabilityDomain(ctx, p.getIdentity, *launchDetails.CompartmentId, *launchDetails.AvailabilityDomain)
+	if err != nil {
+		return nil, err
 	}
+	launchDetails.AvailabilityDomain = common.String(availabilityDomain)
 
-	if vnicDetails, ok := props["CreateVnicDetails"].(map[string]any); ok {
-		launchDetails.CreateVnicDetails = parseCreateVnicDetails(vnicDetails)
+	if launchDetails.FaultDomain != nil {
+		if err := validateFaultDomain(ctx, p.getIdentity, *launchDetails.CompartmentId, availabilityDomain, *launchDetails.FaultDomain); err != nil {
+			return nil, err
+		}
 	}
 
-	if shapeConfig, ok := props["ShapeConfig"].(map[string]any); ok {
-		launchDetails.ShapeConfig = parseShapeConfig(shapeConfig)
+	if err := validateInstanceOptions(*launchDetails.Shape, launchDetails.InstanceOptions); err != nil {
+		return nil, err
 	}
 
-	if metadata, ok := props["Metadata"].(map[string]any); ok {
-		m := make(map[string]string, len(metadata))
-		for k, v := range metadata {
-			if s, ok := v.(string); ok {
-				m[k] = s
-			}
+	if platformConfig, ok := props["PlatformConfig"].(map[string]any); ok {
+		platformType, _ := extractStringField(platformConfig, "type", "Type")
+		if err := util.ValidatePlatformConfig(ctx, svc, *launchDetails.CompartmentId, availabilityDomain, *launchDetails.Shape,
+			platformType, launchDetails.PlatformConfig); err != nil {
+			return nil, err
 		}
-		launchDetails.Metadata = m
 	}
 
-	if freeformTags, ok := util.ExtractFreeformTags(props, "FreeformTags"); ok {
-		launchDetails.FreeformTags = freeformTags
+	if launchDetails.ShapeConfig != nil && !config.FromTargetConfig(request.TargetConfig).SkipShapeValidation {
+		if err := util.ValidateShapeConfig(ctx, svc, *launchDetails.CompartmentId, availabilityDomain, *launchDetails.Shape,
+			launchDetails.ShapeConfig.Ocpus, launchDetails.ShapeConfig.MemoryInGBs); err != nil {
+			return nil, err
+		}
 	}
-	if definedTags, ok := util.ExtractDefinedTags(props, "DefinedTags"); ok {
-		launchDetails.DefinedTags = definedTags
+
+	if source, ok := launchDetails.SourceDetails.(core.InstanceSourceViaBootVolumeDetails); ok {
+		if err := p.prepareBootVolume(ctx, *source.BootVolumeId, props["SourceDetails"].(map[string]any)); err != nil {
+			if result, handleErr := util.HandleCreateError(err, "OCI::Core::Instance", "OCI::Core::Instance"); result != nil {
+				return result, handleErr
+			}
+			return nil, err
+		}
 	}
 
 	createReq := core.LaunchInstanceRequest{
+		OpcRetryToken:         common.String(util.CreateRetryToken(request)),
 		LaunchInstanceDetails: launchDetails,
 	}
 
@@ -105,7 +210,7 @@ func (p *InstanceProvisioner) Create(ctx context.Context, request *resource.Crea
 }
 
 func (p *InstanceProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
-	svc, err := p.clients.GetComputeClient()
+	svc, err := p.getSvc()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get Compute client: %w", err)
 	}
@@ -116,7 +221,7 @@ func (p *InstanceProvisioner) Read(ctx context.Context, request *resource.ReadRe
 
 	resp, err := svc.GetInstance(ctx, getReq)
 	if err != nil {
-		if serviceErr, ok := common.IsServiceError(err); ok && serviceErr.GetHTTPStatusCode() == 404 {
+		if util.IsNotFound(err) {
 			return &resource.ReadResult{
 				ResourceType: "OCI::Core::Instance",
 				ErrorCode:    resource.OperationErrorCodeNotFound,
@@ -125,15 +230,51 @@ func (p *InstanceProvisioner) Read(ctx context.Context, request *resource.ReadRe
 		return nil, fmt.Errorf("failed to read Instance: %w", err)
 	}
 
-	// Treat terminated instances as not found
-	if resp.LifecycleState == core.InstanceLifecycleStateTerminated {
+	// Treat terminating and terminated instances as not found
+	if util.IsTerminal(string(resp.LifecycleState)) {
 		return &resource.ReadResult{
 			ResourceType: "OCI::Core::Instance",
 			ErrorCode:    resource.OperationErrorCodeNotFound,
 		}, nil
 	}
 
-	properties := buildInstanceProperties(resp.Instance)
+	ignoredTagNamespaces := config.FromTargetConfig(request.TargetConfig).HiddenTagNamespaces()
+	properties := buildInstanceProperties(resp.Instance, ignoredTagNamespaces)
+	vnic, err := p.readPrimaryVnic(ctx, svc, resp.Instance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Instance: %w", err)
+	}
+	if vnic != nil {
+		vnicDetails := buildCreateVnicDetailsProperties(*vnic, ignoredTagNamespaces)
+		publicIp, err := p.readPrimaryPublicIp(ctx, *vnic)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read Instance: %w", err)
+		}
+		if publicIp != nil && publicIp.Lifetime == core.PublicIpLifetimeReserved {
+			// assignPublicIp only asks for an ephemeral IP at launch
+			vnicDetails["assignPublicIp"] = false
+			properties["ReservedPublicIpId"] = *publicIp.Id
+		}
+		properties["CreateVnicDetails"] = vnicDetails
+	}
+	// GetInstance echoes the launch-time source; report the boot volume as it is now
+	if sd, ok := properties["SourceDetails"].(map[string]any); ok {
+		bv, err := p.readBootVolume(ctx, svc, resp.Instance)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read Instance: %w", err)
+		}
+		if bv != nil {
+			if bv.SizeInGBs != nil {
+				sd["bootVolumeSizeInGBs"] = *bv.SizeInGBs
+			}
+			if bv.VpusPerGB != nil {
+				sd["bootVolumeVpusPerGB"] = *bv.VpusPerGB
+			}
+			if kmsKeyId, ok := util.CustomerKmsKeyId(bv.KmsKeyId); ok {
+				sd["kmsKeyId"] = kmsKeyId
+			}
+		}
+	}
 
 	propBytes, err := json.Marshal(properties)
 	if err != nil {
@@ -147,7 +288,7 @@ func (p *InstanceProvisioner) Read(ctx context.Context, request *resource.ReadRe
 }
 
 func (p *InstanceProvisioner) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
-	svc, err := p.clients.GetComputeClient()
+	svc, err := p.getSvc()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get Compute client: %w", err)
 	}
@@ -157,7 +298,24 @@ func (p *InstanceProvisioner) Update(ctx context.Context, request *resource.Upda
 		return nil, err
 	}
 
+	action, err := parseInstanceAction(request, props)
+	if err != nil {
+		return nil, err
+	}
+
+	shapeChanged, err := instanceShapeChanged(request, props)
+	if err != nil {
+		return nil, err
+	}
+	constraint, err := parseUpdateOperationConstraint(props)
+	if err != nil {
+		return nil, err
+	}
+
 	updateDetails := core.UpdateInstanceDetails{}
+	if shapeChanged {
+		updateDetails.UpdateOperationConstraint = constraint
+	}
 
 	if displayName, ok := util.ExtractString(props, "DisplayName"); ok {
 		updateDetails.DisplayName = common.String(displayName)
@@ -165,15 +323,30 @@ func (p *InstanceProvisioner) Update(ctx context.Context, request *resource.Upda
 	if shape, ok := util.ExtractString(props, "Shape"); ok {
 		updateDetails.Shape = common.String(shape)
 	}
+	if faultDomain, ok := util.ExtractString(props, "FaultDomain"); ok {
+		updateDetails.FaultDomain = common.String(faultDomain)
+	}
 	if shapeConfig, ok := props["ShapeConfig"].(map[string]any); ok {
-		updateDetails.ShapeConfig = parseUpdateShapeConfig(shapeConfig)
+		sc, err := parseUpdateShapeConfig(shapeConfig)
+		if err != nil {
+			return nil, err
+		}
+		updateDetails.ShapeConfig = sc
+	}
+	if agentConfig, ok := props["AgentConfig"].(map[string]any); ok {
+		updateDetails.AgentConfig = parseUpdateAgentConfig(agentConfig)
+	}
+	if instanceOptions, ok := props["InstanceOptions"].(map[string]any); ok {
+		updateDetails.InstanceOptions = parseInstanceOptions(instanceOptions)
+		shape, _ := util.ExtractString(props, "Shape")
+		if err := validateInstanceOptions(shape, updateDetails.InstanceOptions); err != nil {
+			return nil, err
+		}
 	}
 	if metadata, ok := props["Metadata"].(map[string]any); ok {
-		m := make(map[string]string, len(metadata))
-		for k, v := range metadata {
-			if s, ok := v.(string); ok {
-				m[k] = s
-			}
+		m, err := parseInstanceMetadata(metadata)
+		if err != nil {
+			return nil, err
 		}
 		updateDetails.Metadata = m
 	}
@@ -192,22 +365,112 @@ func (p *InstanceProvisioner) Update(ctx context.Context, request *resource.Upda
 	resp, err := svc.UpdateInstance(ctx, updateReq)
 	if err != nil {
 		if result, handleErr := util.HandleUpdateError(err, "OCI::Core::Instance", request.NativeID, "OCI::Core::Instance"); result != nil {
+			if shapeChanged && constraint == core.UpdateInstanceDetailsUpdateOperationConstraintAvoidDowntime &&
+				(result.ProgressResult.ErrorCode == resource.OperationErrorCodeInvalidRequest || result.ProgressResult.ErrorCode == resource.OperationErrorCodeResourceConflict) {
+				result.ProgressResult.StatusMessage = "this shape change needs the instance to stop, which UpdateOperationConstraint AVOID_DOWNTIME does not allow; " +
+					"set it to ALLOW_DOWNTIME to let OCI restart the instance. " + result.ProgressResult.StatusMessage
+			}
 			return result, handleErr
 		}
 		return nil, fmt.Errorf("failed to update Instance: %w", err)
 	}
 
+	// OCI rejects a compartment move while the update's work request (e.g. a
+	// resize) is still running, so Status moves the instance once it completes.
+	// The RequestID carries {instanceId}/{workRequestId}/{compartmentId} until then.
+	var moveAfter string
+	moved := false
+	if compartmentId, ok := util.ExtractString(props, "CompartmentId"); ok &&
+		(resp.CompartmentId == nil || *resp.CompartmentId != compartmentId) {
+		if resp.OpcWorkRequestId != nil {
+			moveAfter = util.EncodeCompositeID(request.NativeID, *resp.OpcWorkRequestId, compartmentId)
+		} else {
+			if err := moveInstanceCompartment(ctx, svc, request.NativeID, compartmentId); err != nil {
+				if result, handleErr := util.HandleUpdateError(err, "OCI::Core::Instance", request.NativeID, "OCI::Core::Instance"); result != nil {
+					return result, handleErr
+				}
+				return nil, err
+			}
+			moved = true
+		}
+	}
+
+	var statusMessage string
+	if sourceDetails, ok := props["SourceDetails"].(map[string]any); ok {
+		statusMessage, err = p.updateBootVolume(ctx, svc, request.NativeID, sourceDetails)
+		if err != nil {
+			if result, handleErr := util.HandleUpdateError(err, "OCI::Core::Instance", request.NativeID, "OCI::Core::Instance"); result != nil {
+				return result, handleErr
+			}
+			return nil, err
+		}
+	}
+
+	if err := p.updateReservedPublicIp(ctx, svc, request, props); err != nil {
+		if result, handleErr := util.HandleUpdateError(err, "OCI::Core::Instance", request.NativeID, "OCI::Core::Instance"); result != nil {
+			return result, handleErr
+		}
+		return nil, err
+	}
+
+	if action != "" {
+		_, err := svc.InstanceAction(ctx, core.InstanceActionRequest{
+			InstanceId: common.String(request.NativeID),
+			Action:     action,
+		})
+		if err != nil {
+			if result, handleErr := util.HandleUpdateError(err, "OCI::Core::Instance", request.NativeID, "OCI::Core::Instance"); result != nil {
+				return result, handleErr
+			}
+			return nil, fmt.Errorf("failed to run %s on Instance: %w", action, err)
+		}
+	}
+
+	if action != "" || shapeChanged || moved || moveAfter != "" {
+		// Actions and resizes take the instance through STOPPING/STARTING, and a
+		// compartment move through MOVING — poll lifecycle in Status() until RUNNING
+		requestID := request.NativeID
+		if moveAfter != "" {
+			requestID = moveAfter
+		}
+		return &resource.UpdateResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationUpdate,
+				OperationStatus: resource.OperationStatusInProgress,
+				StatusMessage:   statusMessage,
+				NativeID:        request.NativeID,
+				RequestID:       requestID,
+			},
+		}, nil
+	}
+
 	return &resource.UpdateResult{
 		ProgressResult: &resource.ProgressResult{
 			Operation:       resource.OperationUpdate,
 			OperationStatus: resource.OperationStatusSuccess,
+			StatusMessage:   statusMessage,
 			NativeID:        *resp.Id,
 		},
 	}, nil
 }
 
+// moveInstanceCompartment moves the instance when CompartmentId differs from the
+// compartment it is in now, which also covers an instance moved outside formae.
+// It reports whether a move was started.
+func moveInstanceCompartment(ctx context.Context, svc *core.ComputeClient, instanceId, compartmentId string) error {
+	if _, err := svc.ChangeInstanceCompartment(ctx, core.ChangeInstanceCompartmentRequest{
+		InstanceId: common.String(instanceId),
+		ChangeInstanceCompartmentDetails: core.ChangeInstanceCompartmentDetails{
+			CompartmentId: common.String(compartmentId),
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to move Instance to compartment %s: %w", compartmentId, err)
+	}
+	return nil
+}
+
 func (p *InstanceProvisioner) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
-	svc, err := p.clients.GetComputeClient()
+	svc, err := p.getSvc()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get Compute client: %w", err)
 	}
@@ -231,7 +494,7 @@ func (p *InstanceProvisioner) Delete(ctx context.Context, request *resource.Dele
 
 	deleteReq := core.TerminateInstanceRequest{
 		InstanceId:         common.String(request.NativeID),
-		PreserveBootVolume: common.Bool(false),
+		PreserveBootVolume: common.Bool(config.FromTargetConfig(request.TargetConfig).PreserveBootVolume),
 	}
 
 	_, err = svc.TerminateInstance(ctx, deleteReq)
@@ -254,89 +517,89 @@ func (p *InstanceProvisioner) Delete(ctx context.Context, request *resource.Dele
 }
 
 func (p *InstanceProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
-	svc, err := p.clients.GetComputeClient()
+	svc, err := p.getSvc()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get Compute client: %w", err)
 	}
 
-	getReq := core.GetInstanceRequest{
-		InstanceId: common.String(request.RequestID),
-	}
-
-	resp, err := svc.GetInstance(ctx, getReq)
-	if err != nil {
-		if serviceErr, ok := common.IsServiceError(err); ok && serviceErr.GetHTTPStatusCode() == 404 {
-			// Instance gone — if we were deleting, that's success
-			return &resource.StatusResult{
-				ProgressResult: &resource.ProgressResult{
-					Operation:       resource.OperationCheckStatus,
-					OperationStatus: resource.OperationStatusSuccess,
-					NativeID:        request.RequestID,
-				},
-			}, nil
-		}
-		return nil, fmt.Errorf("failed to check Instance status: %w", err)
+	if parts, err := util.DecodeCompositeID(request.RequestID, 3); err == nil {
+		return p.moveAfterWorkRequest(ctx, svc, parts[0], parts[1], parts[2])
 	}
 
-	switch resp.LifecycleState {
-	case core.InstanceLifecycleStateRunning:
-		properties := buildInstanceProperties(resp.Instance)
-		propertiesBytes, err := json.Marshal(properties)
+	getInstance := func(ctx context.Context) (*util.LifecycleSnapshot, error) {
+		resp, err := svc.GetInstance(ctx, core.GetInstanceRequest{
+			InstanceId: common.String(request.RequestID),
+		})
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal properties: %w", err)
+			if util.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to check Instance status: %w", err)
 		}
-		return &resource.StatusResult{
-			ProgressResult: &resource.ProgressResult{
-				Operation:          resource.OperationCheckStatus,
-				OperationStatus:    resource.OperationStatusSuccess,
-				NativeID:           *resp.Id,
-				ResourceProperties: json.RawMessage(propertiesBytes),
-			},
+		return &util.LifecycleSnapshot{
+			NativeID:   *resp.Id,
+			State:      string(resp.LifecycleState),
+			Properties: buildInstanceProperties(resp.Instance, config.FromTargetConfig(request.TargetConfig).HiddenTagNamespaces()),
 		}, nil
+	}
 
-	case core.InstanceLifecycleStateTerminated:
-		return &resource.StatusResult{
-			ProgressResult: &resource.ProgressResult{
-				Operation:       resource.OperationCheckStatus,
-				OperationStatus: resource.OperationStatusSuccess,
-				NativeID:        *resp.Id,
-			},
-		}, nil
+	// PROVISIONING, STARTING, STOPPING, TERMINATING, etc. stay in progress
+	result, err := util.PollLifecycle(ctx, "Instance", request.RequestID, getInstance, map[string]resource.OperationStatus{
+		string(core.InstanceLifecycleStateRunning):    resource.OperationStatusSuccess,
+		string(core.InstanceLifecycleStateStopped):    resource.OperationStatusSuccess,
+		string(core.InstanceLifecycleStateTerminated): resource.OperationStatusSuccess,
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	case core.InstanceLifecycleStateStopped:
-		properties := buildInstanceProperties(resp.Instance)
-		propertiesBytes, err := json.Marshal(properties)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal properties: %w", err)
-		}
-		return &resource.StatusResult{
-			ProgressResult: &resource.ProgressResult{
-				Operation:          resource.OperationCheckStatus,
-				OperationStatus:    resource.OperationStatusSuccess,
-				NativeID:           *resp.Id,
-				ResourceProperties: json.RawMessage(propertiesBytes),
-			},
-		}, nil
+	return &resource.StatusResult{ProgressResult: result}, nil
+}
 
-	default: // PROVISIONING, STARTING, STOPPING, TERMINATING, etc.
-		return &resource.StatusResult{
-			ProgressResult: &resource.ProgressResult{
-				Operation:       resource.OperationCheckStatus,
-				OperationStatus: resource.OperationStatusInProgress,
-				RequestID:       request.RequestID,
-				StatusMessage:   fmt.Sprintf("Instance lifecycle state: %s", resp.LifecycleState),
-			},
-		}, nil
+// moveAfterWorkRequest waits for the update's work request, then starts the
+// compartment move Update deferred and hands over to the lifecycle poll.
+func (p *InstanceProvisioner) moveAfterWorkRequest(ctx context.Context, svc *core.ComputeClient, instanceId, workRequestId, compartmentId string) (*resource.StatusResult, error) {
+	workRequests, err := p.getWorkRequests()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get WorkRequest client: %w", err)
 	}
+
+	result, err := util.PollWorkRequest(ctx, workRequestPoller{workRequests}, workRequestId, resource.OperationUpdate)
+	if err != nil {
+		return nil, err
+	}
+	result.NativeID = instanceId
+	switch result.OperationStatus {
+	case resource.OperationStatusInProgress:
+		result.RequestID = util.EncodeCompositeID(instanceId, workRequestId, compartmentId)
+		return &resource.StatusResult{ProgressResult: result}, nil
+	case resource.OperationStatusFailure:
+		return &resource.StatusResult{ProgressResult: result}, nil
+	}
+
+	if err := moveInstanceCompartment(ctx, svc, instanceId, compartmentId); err != nil {
+		if result, handleErr := util.HandleUpdateError(err, "OCI::Core::Instance", instanceId, "OCI::Core::Instance"); result != nil {
+			return &resource.StatusResult{ProgressResult: result.ProgressResult}, handleErr
+		}
+		return nil, err
+	}
+	return &resource.StatusResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationUpdate,
+			OperationStatus: resource.OperationStatusInProgress,
+			NativeID:        instanceId,
+			RequestID:       instanceId,
+		},
+	}, nil
 }
 
 func (p *InstanceProvisioner) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
-	svc, err := p.clients.GetComputeClient()
+	svc, err := p.getSvc()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get Compute client: %w", err)
 	}
 
-	compartmentId, ok := request.AdditionalProperties["CompartmentId"]
+	compartmentId, ok := util.ListCompartmentId(request)
 	if !ok {
 		return nil, fmt.Errorf("CompartmentId is required for listing Instances")
 	}
@@ -344,16 +607,23 @@ func (p *InstanceProvisioner) List(ctx context.Context, request *resource.ListRe
 	listReq := core.ListInstancesRequest{
 		CompartmentId:  common.String(compartmentId),
 		LifecycleState: core.InstanceLifecycleStateRunning,
+		Limit:          util.ListPageSize(request.TargetConfig),
 	}
 
-	resp, err := svc.ListInstances(ctx, listReq)
+	nativeIDs, err := util.ListAllPages(func(page *string) ([]string, *string, error) {
+		listReq.Page = page
+		resp, err := svc.ListInstances(ctx, listReq)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list Instances: %w", err)
+		}
+		ids := make([]string, 0, len(resp.Items))
+		for _, inst := range resp.Items {
+			ids = append(ids, *inst.Id)
+		}
+		return ids, resp.OpcNextPage, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list Instances: %w", err)
-	}
-
-	nativeIDs := make([]string, 0, len(resp.Items))
-	for _, inst := range resp.Items {
-		nativeIDs = append(nativeIDs, *inst.Id)
+		return nil, err
 	}
 
 	return &resource.ListResult{
@@ -361,13 +631,101 @@ func (p *InstanceProvisioner) List(ctx context.Context, request *resource.ListRe
 	}, nil
 }
 
-func parseSourceDetails(data map[string]any) core.InstanceSourceDetails {
+// parseLaunchInstanceDetails maps Instance properties to launch details without
+// calling OCI. Availability domain resolution and placement/shape validation are
+// done by Create on top of the result.
+func parseLaunchInstanceDetails(props map[string]any) (core.LaunchInstanceDetails, error) {
+	launchDetails := core.LaunchInstanceDetails{
+		CompartmentId:      common.String(props["CompartmentId"].(string)),
+		AvailabilityDomain: common.String(props["AvailabilityDomain"].(string)),
+		Shape:              common.String(props["Shape"].(string)),
+	}
+
+	if displayName, ok := util.ExtractString(props, "DisplayName"); ok {
+		launchDetails.DisplayName = common.String(displayName)
+	}
+	if faultDomain, ok := util.ExtractString(props, "FaultDomain"); ok {
+		launchDetails.FaultDomain = common.String(faultDomain)
+	}
+	if sourceDetails, ok := props["SourceDetails"].(map[string]any); ok {
+		sd, err := parseSourceDetails(sourceDetails)
+		if err != nil {
+			return launchDetails, err
+		}
+		launchDetails.SourceDetails = sd
+	}
+	if vnicDetails, ok := props["CreateVnicDetails"].(map[string]any); ok {
+		launchDetails.CreateVnicDetails = parseCreateVnicDetails(vnicDetails)
+	}
+	if shapeConfig, ok := props["ShapeConfig"].(map[string]any); ok {
+		sc, err := parseShapeConfig(shapeConfig)
+		if err != nil {
+			return launchDetails, err
+		}
+		launchDetails.ShapeConfig = sc
+	}
+	if agentConfig, ok := props["AgentConfig"].(map[string]any); ok {
+		launchDetails.AgentConfig = parseAgentConfig(agentConfig)
+	}
+	if launchOptions, ok := props["LaunchOptions"].(map[string]any); ok {
+		launchDetails.LaunchOptions = parseLaunchOptions(launchOptions)
+	}
+	if platformConfig, ok := props["PlatformConfig"].(map[string]any); ok {
+		pc, err := parsePlatformConfig(platformConfig)
+		if err != nil {
+			return launchDetails, err
+		}
+		launchDetails.PlatformConfig = pc
+	}
+	if launchMode, ok := util.ExtractString(props, "LaunchMode"); ok {
+		if err := validateLaunchMode(launchMode, launchDetails.LaunchOptions); err != nil {
+			return launchDetails, err
+		}
+	}
+	if instanceOptions, ok := props["InstanceOptions"].(map[string]any); ok {
+		launchDetails.InstanceOptions = parseInstanceOptions(instanceOptions)
+	}
+	if metadata, ok := props["Metadata"].(map[string]any); ok {
+		m, err := parseInstanceMetadata(metadata)
+		if err != nil {
+			return launchDetails, err
+		}
+		launchDetails.Metadata = m
+	}
+	if freeformTags, ok := util.ExtractFreeformTags(props, "FreeformTags"); ok {
+		launchDetails.FreeformTags = freeformTags
+	}
+	if definedTags, ok := util.ExtractDefinedTags(props, "DefinedTags"); ok {
+		launchDetails.DefinedTags = definedTags
+	}
+
+	return launchDetails, nil
+}
+
+// parseSourceDetails builds the launch source. A bootVolume source only carries
+// the volume's OCID, since InstanceSourceViaBootVolumeDetails has no KMS key or
+// VPUs fields; prepareBootVolume applies its size, VPUs and KMS key to the
+// existing volume before launch, and Read reports them from the attached volume.
+func parseSourceDetails(data map[string]any) (core.InstanceSourceDetails, error) {
 	sourceType, _ := extractStringField(data, "sourceType", "SourceType")
+	imageId, hasImage := extractStringField(data, "imageId", "ImageId")
+	_, hasImageName := util.ExtractImageQuery(data)
+	bootVolumeId, hasBootVolume := extractStringField(data, "bootVolumeId", "BootVolumeId")
+	if hasImage && hasImageName {
+		return nil, fmt.Errorf("sourceDetails takes either imageId or imageName, not both")
+	}
+	hasImage = hasImage || hasImageName
+	if hasImage && hasBootVolume {
+		return nil, fmt.Errorf("sourceDetails takes either imageId or bootVolumeId, not both")
+	}
 
 	switch sourceType {
 	case "image":
+		if hasBootVolume {
+			return nil, fmt.Errorf("sourceDetails bootVolumeId needs sourceType \"bootVolume\", not \"image\"")
+		}
 		details := core.InstanceSourceViaImageDetails{}
-		if imageId, ok := extractStringField(data, "imageId", "ImageId"); ok {
+		if imageId != "" {
 			details.ImageId = common.String(imageId)
 		}
 		if bootVolumeSizeInGBs, ok := extractInt64Field(data, "BootVolumeSizeInGBs"); ok {
@@ -375,15 +733,23 @@ func parseSourceDetails(data map[string]any) core.InstanceSourceDetails {
 		} else if bootVolumeSizeInGBs, ok := extractInt64Field(data, "bootVolumeSizeInGBs"); ok {
 			details.BootVolumeSizeInGBs = common.Int64(bootVolumeSizeInGBs)
 		}
-		return details
+		if bootVolumeVpusPerGB, ok := extractInt64Field(data, "bootVolumeVpusPerGB"); ok {
+			details.BootVolumeVpusPerGB = common.Int64(bootVolumeVpusPerGB)
+		}
+		if kmsKeyId, ok := extractStringField(data, "kmsKeyId", "KmsKeyId"); ok {
+			details.KmsKeyId = common.String(kmsKeyId)
+		}
+		return details, nil
 	case "bootVolume":
-		details := core.InstanceSourceViaBootVolumeDetails{}
-		if bootVolumeId, ok := extractStringField(data, "bootVolumeId", "BootVolumeId"); ok {
-			details.BootVolumeId = common.String(bootVolumeId)
+		if hasImage {
+			return nil, fmt.Errorf("sourceDetails imageId or imageName needs sourceType \"image\", not \"bootVolume\"")
 		}
-		return details
+		if !hasBootVolume {
+			return nil, fmt.Errorf("sourceDetails with sourceType \"bootVolume\" needs a bootVolumeId")
+		}
+		return core.InstanceSourceViaBootVolumeDetails{BootVolumeId: common.String(bootVolumeId)}, nil
 	default:
-		return nil
+		return nil, nil
 	}
 }
 
@@ -405,7 +771,7 @@ func parseCreateVnicDetails(data map[string]any) *core.CreateVnicDetails {
 	if hostnameLabel, ok := extractStringField(data, "hostnameLabel", "HostnameLabel"); ok {
 		details.HostnameLabel = common.String(hostnameLabel)
 	}
-	if nsgIds := extractStringSliceField(data, "nsgIds", "NsgIds"); len(nsgIds) > 0 {
+	if nsgIds, ok := util.ExtractNsgIds(data); ok {
 		details.NsgIds = nsgIds
 	}
 	if privateIp, ok := extractStringField(data, "privateIp", "PrivateIp"); ok {
@@ -428,7 +794,7 @@ func parseCreateVnicDetails(data map[string]any) *core.CreateVnicDetails {
 	return details
 }
 
-func parseShapeConfig(data map[string]any) *core.LaunchInstanceShapeConfigDetails {
+func parseShapeConfig(data map[string]any) (*core.LaunchInstanceShapeConfigDetails, error) {
 	config := &core.LaunchInstanceShapeConfigDetails{}
 
 	if ocpus, ok := extractFloatField(data, "ocpus", "Ocpus"); ok {
@@ -438,13 +804,17 @@ func parseShapeConfig(data map[string]any) *core.LaunchInstanceShapeConfigDetail
 		config.MemoryInGBs = common.Float32(float32(memoryInGBs))
 	}
 	if baselineOcpuUtilization, ok := extractStringField(data, "baselineOcpuUtilization", "BaselineOcpuUtilization"); ok {
-		config.BaselineOcpuUtilization = core.LaunchInstanceShapeConfigDetailsBaselineOcpuUtilizationEnum(baselineOcpuUtilization)
+		enum, valid := core.GetMappingLaunchInstanceShapeConfigDetailsBaselineOcpuUtilizationEnum(baselineOcpuUtilization)
+		if !valid {
+			return nil, invalidBaselineOcpuUtilization(baselineOcpuUtilization, core.GetLaunchInstanceShapeConfigDetailsBaselineOcpuUtilizationEnumStringValues())
+		}
+		config.BaselineOcpuUtilization = enum
 	}
 
-	return config
+	return config, nil
 }
 
-func parseUpdateShapeConfig(data map[string]any) *core.UpdateInstanceShapeConfigDetails {
+func parseUpdateShapeConfig(data map[string]any) (*core.UpdateInstanceShapeConfigDetails, error) {
 	config := &core.UpdateInstanceShapeConfigDetails{}
 
 	if ocpus, ok := extractFloatField(data, "ocpus", "Ocpus"); ok {
@@ -454,12 +824,305 @@ func parseUpdateShapeConfig(data map[string]any) *core.UpdateInstanceShapeConfig
 		config.MemoryInGBs = common.Float32(float32(memoryInGBs))
 	}
 	if baselineOcpuUtilization, ok := extractStringField(data, "baselineOcpuUtilization", "BaselineOcpuUtilization"); ok {
-		config.BaselineOcpuUtilization = core.UpdateInstanceShapeConfigDetailsBaselineOcpuUtilizationEnum(baselineOcpuUtilization)
+		enum, valid := core.GetMappingUpdateInstanceShapeConfigDetailsBaselineOcpuUtilizationEnum(baselineOcpuUtilization)
+		if !valid {
+			return nil, invalidBaselineOcpuUtilization(baselineOcpuUtilization, core.GetUpdateInstanceShapeConfigDetailsBaselineOcpuUtilizationEnumStringValues())
+		}
+		config.BaselineOcpuUtilization = enum
+	}
+
+	return config, nil
+}
+
+// instanceActions are the values InstanceAction accepts. Power-state actions
+// (START, STOP, SOFTSTOP) are left out: they would fight the RUNNING instance
+// the rest of the resource describes.
+var instanceActions = []core.InstanceActionActionEnum{
+	core.InstanceActionActionSoftreset,
+	core.InstanceActionActionReset,
+	core.InstanceActionActionSenddiagnosticinterrupt,
+	core.InstanceActionActionRebootmigrate,
+}
+
+// parseInstanceAction returns the action this update should run, or "" for none.
+// InstanceAction is write-only, so with a patch document it only runs when the
+// patch sets it; otherwise every later update would reboot the instance again.
+func parseInstanceAction(request *resource.UpdateRequest, props map[string]any) (core.InstanceActionActionEnum, error) {
+	value, ok := util.ExtractString(props, "InstanceAction")
+	if !ok {
+		return "", nil
+	}
+
+	if request.PatchDocument != nil && *request.PatchDocument != "" {
+		ops, err := decodePatchOps(*request.PatchDocument)
+		if err != nil {
+			return "", err
+		}
+		requested := false
+		for _, op := range ops {
+			if op.Path == "/InstanceAction" && (op.Op == "add" || op.Op == "replace") {
+				requested = true
+			}
+		}
+		if !requested {
+			return "", nil
+		}
+	}
+
+	for _, action := range instanceActions {
+		if string(action) == value {
+			return action, nil
+		}
+	}
+	valid := make([]string, len(instanceActions))
+	for i, action := range instanceActions {
+		valid[i] = string(action)
+	}
+	return "", fmt.Errorf("InstanceAction %q is not valid, must be one of: %s", value, strings.Join(valid, ", "))
+}
+
+type patchOp struct {
+	Op   string `json:"op"`
+	Path string `json:"path"`
+}
+
+func decodePatchOps(patchDocument string) ([]patchOp, error) {
+	var ops []patchOp
+	if err := json.Unmarshal([]byte(patchDocument), &ops); err != nil {
+		return nil, fmt.Errorf("failed to decode patch document: %w", err)
+	}
+	return ops, nil
+}
+
+// patchRemoves reports whether the update's patch document removes path. Updates
+// without a patch never do: they can't tell a removed field from an unset one.
+func patchRemoves(request *resource.UpdateRequest, path string) (bool, error) {
+	if request.PatchDocument == nil || *request.PatchDocument == "" {
+		return false, nil
+	}
+	ops, err := decodePatchOps(*request.PatchDocument)
+	if err != nil {
+		return false, err
+	}
+	for _, op := range ops {
+		if op.Op == "remove" && op.Path == path {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// instanceShapeChanged reports whether an update resizes the instance: a patch
+// touching Shape or ShapeConfig or, without a patch, desired values that differ
+// from the prior ones.
+func instanceShapeChanged(request *resource.UpdateRequest, props map[string]any) (bool, error) {
+	if request.PatchDocument != nil && *request.PatchDocument != "" {
+		ops, err := decodePatchOps(*request.PatchDocument)
+		if err != nil {
+			return false, err
+		}
+		for _, op := range ops {
+			if op.Path == "/Shape" || op.Path == "/ShapeConfig" || strings.HasPrefix(op.Path, "/ShapeConfig/") {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	var prior map[string]any
+	if len(request.PriorProperties) > 0 {
+		if err := json.Unmarshal(request.PriorProperties, &prior); err != nil {
+			return false, fmt.Errorf("failed to parse prior properties: %w", err)
+		}
+	}
+	if _, ok := props["Shape"]; ok && !reflect.DeepEqual(props["Shape"], prior["Shape"]) {
+		return true, nil
+	}
+	if _, ok := props["ShapeConfig"]; ok && !reflect.DeepEqual(props["ShapeConfig"], prior["ShapeConfig"]) {
+		return true, nil
+	}
+	return false, nil
+}
+
+// parseUpdateOperationConstraint reads UpdateOperationConstraint, which decides
+// whether OCI may reboot the instance to apply a shape change.
+func parseUpdateOperationConstraint(props map[string]any) (core.UpdateInstanceDetailsUpdateOperationConstraintEnum, error) {
+	value, ok := util.ExtractString(props, "UpdateOperationConstraint")
+	if !ok {
+		return "", nil
+	}
+	constraint, ok := core.GetMappingUpdateInstanceDetailsUpdateOperationConstraintEnum(value)
+	if !ok {
+		return "", fmt.Errorf("UpdateOperationConstraint %q is not valid, must be one of: %s", value, strings.Join(core.GetUpdateInstanceDetailsUpdateOperationConstraintEnumStringValues(), ", "))
+	}
+	return constraint, nil
+}
+
+// invalidBaselineOcpuUtilization reports a baseline OCI would reject, so a typo
+// fails before the launch or update request instead of as an opaque 400.
+func invalidBaselineOcpuUtilization(value string, valid []string) error {
+	return fmt.Errorf("shapeConfig baselineOcpuUtilization %q is not valid, must be one of: %s", value, strings.Join(valid, ", "))
+}
+
+func parseAgentConfig(data map[string]any) *core.LaunchInstanceAgentConfigDetails {
+	config := &core.LaunchInstanceAgentConfigDetails{}
+
+	if v, ok := extractBoolField(data, "isMonitoringDisabled", "IsMonitoringDisabled"); ok {
+		config.IsMonitoringDisabled = common.Bool(v)
+	}
+	if v, ok := extractBoolField(data, "isManagementDisabled", "IsManagementDisabled"); ok {
+		config.IsManagementDisabled = common.Bool(v)
+	}
+	if v, ok := extractBoolField(data, "areAllPluginsDisabled", "AreAllPluginsDisabled"); ok {
+		config.AreAllPluginsDisabled = common.Bool(v)
 	}
 
 	return config
 }
 
+func parseUpdateAgentConfig(data map[string]any) *core.UpdateInstanceAgentConfigDetails {
+	launch := parseAgentConfig(data)
+	return &core.UpdateInstanceAgentConfigDetails{
+		IsMonitoringDisabled:  launch.IsMonitoringDisabled,
+		IsManagementDisabled:  launch.IsManagementDisabled,
+		AreAllPluginsDisabled: launch.AreAllPluginsDisabled,
+	}
+}
+
+func parseLaunchOptions(data map[string]any) *core.LaunchOptions {
+	options := &core.LaunchOptions{}
+
+	if v, ok := extractStringField(data, "bootVolumeType", "BootVolumeType"); ok {
+		options.BootVolumeType = core.LaunchOptionsBootVolumeTypeEnum(v)
+	}
+	if v, ok := extractStringField(data, "firmware", "Firmware"); ok {
+		options.Firmware = core.LaunchOptionsFirmwareEnum(v)
+	}
+	if v, ok := extractStringField(data, "networkType", "NetworkType"); ok {
+		options.NetworkType = core.LaunchOptionsNetworkTypeEnum(v)
+	}
+	if v, ok := extractStringField(data, "remoteDataVolumeType", "RemoteDataVolumeType"); ok {
+		options.RemoteDataVolumeType = core.LaunchOptionsRemoteDataVolumeTypeEnum(v)
+	}
+	if v, ok := extractBoolField(data, "isPvEncryptionInTransitEnabled", "IsPvEncryptionInTransitEnabled"); ok {
+		options.IsPvEncryptionInTransitEnabled = common.Bool(v)
+	}
+	if v, ok := extractBoolField(data, "isConsistentVolumeNamingEnabled", "IsConsistentVolumeNamingEnabled"); ok {
+		options.IsConsistentVolumeNamingEnabled = common.Bool(v)
+	}
+
+	return options
+}
+
+// parsePlatformConfig builds the Shielded Instance and confidential computing
+// settings of a VM. Bare metal platform configs aren't supported.
+func parsePlatformConfig(data map[string]any) (core.LaunchInstancePlatformConfig, error) {
+	secureBoot, hasSecureBoot := extractBoolField(data, "isSecureBootEnabled", "IsSecureBootEnabled")
+	tpm, hasTpm := extractBoolField(data, "isTrustedPlatformModuleEnabled", "IsTrustedPlatformModuleEnabled")
+	measuredBoot, hasMeasuredBoot := extractBoolField(data, "isMeasuredBootEnabled", "IsMeasuredBootEnabled")
+	memoryEncryption, hasMemoryEncryption := extractBoolField(data, "isMemoryEncryptionEnabled", "IsMemoryEncryptionEnabled")
+	optionalBool := func(v, ok bool) *bool {
+		if !ok {
+			return nil
+		}
+		return common.Bool(v)
+	}
+
+	if measuredBoot && hasTpm && !tpm {
+		return nil, fmt.Errorf("platformConfig.isMeasuredBootEnabled needs isTrustedPlatformModuleEnabled")
+	}
+
+	platformType, _ := extractStringField(data, "type", "Type")
+	switch platformType {
+	case "AMD_VM":
+		return core.AmdVmLaunchInstancePlatformConfig{
+			IsSecureBootEnabled:            optionalBool(secureBoot, hasSecureBoot),
+			IsTrustedPlatformModuleEnabled: optionalBool(tpm, hasTpm),
+			IsMeasuredBootEnabled:          optionalBool(measuredBoot, hasMeasuredBoot),
+			IsMemoryEncryptionEnabled:      optionalBool(memoryEncryption, hasMemoryEncryption),
+		}, nil
+	case "INTEL_VM":
+		return core.IntelVmLaunchInstancePlatformConfig{
+			IsSecureBootEnabled:            optionalBool(secureBoot, hasSecureBoot),
+			IsTrustedPlatformModuleEnabled: optionalBool(tpm, hasTpm),
+			IsMeasuredBootEnabled:          optionalBool(measuredBoot, hasMeasuredBoot),
+			IsMemoryEncryptionEnabled:      optionalBool(memoryEncryption, hasMemoryEncryption),
+		}, nil
+	default:
+		return nil, fmt.Errorf("platformConfig type %q is not supported, must be AMD_VM or INTEL_VM", platformType)
+	}
+}
+
+func parseInstanceOptions(data map[string]any) *core.InstanceOptions {
+	options := &core.InstanceOptions{}
+
+	if v, ok := extractBoolField(data, "areLegacyImdsEndpointsDisabled", "AreLegacyImdsEndpointsDisabled"); ok {
+		options.AreLegacyImdsEndpointsDisabled = common.Bool(v)
+	}
+
+	return options
+}
+
+// legacyImdsOnlyShapes are the first-generation (X5) shape families. Their
+// instances only serve the legacy /v1 metadata endpoints, so disabling those
+// would cut them off from instance metadata altogether.
+var legacyImdsOnlyShapes = []string{"VM.Standard1.", "BM.Standard1.", "VM.DenseIO1.", "BM.DenseIO1.", "BM.HighIO1."}
+
+// validateInstanceOptions rejects requiring IMDSv2 on a shape that can't serve it.
+func validateInstanceOptions(shape string, options *core.InstanceOptions) error {
+	if options == nil || options.AreLegacyImdsEndpointsDisabled == nil || !*options.AreLegacyImdsEndpointsDisabled {
+		return nil
+	}
+	for _, prefix := range legacyImdsOnlyShapes {
+		if strings.HasPrefix(shape, prefix) {
+			return fmt.Errorf("shape %s does not support IMDSv2, so InstanceOptions.areLegacyImdsEndpointsDisabled can't be true", shape)
+		}
+	}
+	return nil
+}
+
+// validateLaunchMode checks LaunchMode against the launch options. LaunchInstance
+// has no launch mode parameter: OCI takes it from the image, or reports CUSTOM
+// when launchOptions are given, so a CUSTOM instance without them would drift.
+func validateLaunchMode(launchMode string, options *core.LaunchOptions) error {
+	if _, ok := core.GetMappingInstanceLaunchModeEnum(launchMode); !ok {
+		return fmt.Errorf("LaunchMode %q is not valid, must be one of: %s", launchMode, strings.Join(core.GetInstanceLaunchModeEnumStringValues(), ", "))
+	}
+	if strings.EqualFold(launchMode, string(core.InstanceLaunchModeCustom)) && options == nil {
+		return fmt.Errorf("LaunchMode CUSTOM needs launchOptions: set the firmware, bootVolumeType and networkType the image expects")
+	}
+	return nil
+}
+
+// userDataPlainKey is a convenience metadata key: its value is base64-encoded into
+// user_data so cloud-init scripts can be written inline without pre-encoding.
+const userDataPlainKey = "UserDataPlain"
+
+// parseInstanceMetadata converts the Metadata map to the string map OCI expects,
+// expanding UserDataPlain into user_data and validating that user_data is base64.
+func parseInstanceMetadata(metadata map[string]any) (map[string]string, error) {
+	m := make(map[string]string, len(metadata))
+	for k, v := range metadata {
+		if s, ok := v.(string); ok {
+			m[k] = s
+		}
+	}
+
+	if plain, ok := m[userDataPlainKey]; ok {
+		if _, exists := m["user_data"]; exists {
+			return nil, fmt.Errorf("metadata cannot set both %s and user_data", userDataPlainKey)
+		}
+		m["user_data"] = base64.StdEncoding.EncodeToString([]byte(plain))
+		delete(m, userDataPlainKey)
+	} else if userData, ok := m["user_data"]; ok {
+		if _, err := base64.StdEncoding.DecodeString(userData); err != nil {
+			return nil, fmt.Errorf("metadata user_data must be base64-encoded (use %s for plain text): %w", userDataPlainKey, err)
+		}
+	}
+
+	return m, nil
+}
+
 func extractFloatField(m map[string]any, lowerKey, upperKey string) (float64, bool) {
 	if v, ok := m[lowerKey].(float64); ok {
 		return v, true
@@ -470,7 +1133,7 @@ func extractFloatField(m map[string]any, lowerKey, upperKey string) (float64, bo
 	return 0, false
 }
 
-func buildInstanceProperties(inst core.Instance) map[string]any {
+func buildInstanceProperties(inst core.Instance, ignoredTagNamespaces []string) map[string]any {
 	properties := map[string]any{
 		"CompartmentId":      *inst.CompartmentId,
 		"AvailabilityDomain": *inst.AvailabilityDomain,
@@ -507,6 +1170,12 @@ func buildInstanceProperties(inst core.Instance) map[string]any {
 			if v.BootVolumeSizeInGBs != nil {
 				sd["bootVolumeSizeInGBs"] = *v.BootVolumeSizeInGBs
 			}
+			if v.BootVolumeVpusPerGB != nil {
+				sd["bootVolumeVpusPerGB"] = *v.BootVolumeVpusPerGB
+			}
+			if kmsKeyId, ok := util.CustomerKmsKeyId(v.KmsKeyId); ok {
+				sd["kmsKeyId"] = kmsKeyId
+			}
 			properties["SourceDetails"] = sd
 		case core.InstanceSourceViaBootVolumeDetails:
 			sd := map[string]any{"sourceType": "bootVolume"}
@@ -533,6 +1202,59 @@ func buildInstanceProperties(inst core.Instance) map[string]any {
 		}
 	}
 
+	if inst.AgentConfig != nil {
+		ac := map[string]any{}
+		if inst.AgentConfig.IsMonitoringDisabled != nil {
+			ac["isMonitoringDisabled"] = *inst.AgentConfig.IsMonitoringDisabled
+		}
+		if inst.AgentConfig.IsManagementDisabled != nil {
+			ac["isManagementDisabled"] = *inst.AgentConfig.IsManagementDisabled
+		}
+		if inst.AgentConfig.AreAllPluginsDisabled != nil {
+			ac["areAllPluginsDisabled"] = *inst.AgentConfig.AreAllPluginsDisabled
+		}
+		if len(ac) > 0 {
+			properties["AgentConfig"] = ac
+		}
+	}
+
+	if pc := buildPlatformConfigProperties(inst.PlatformConfig); pc != nil {
+		properties["PlatformConfig"] = pc
+	}
+	if inst.LaunchMode != "" {
+		properties["LaunchMode"] = string(inst.LaunchMode)
+	}
+	if inst.LaunchOptions != nil {
+		lo := map[string]any{}
+		if inst.LaunchOptions.BootVolumeType != "" {
+			lo["bootVolumeType"] = string(inst.LaunchOptions.BootVolumeType)
+		}
+		if inst.LaunchOptions.Firmware != "" {
+			lo["firmware"] = string(inst.LaunchOptions.Firmware)
+		}
+		if inst.LaunchOptions.NetworkType != "" {
+			lo["networkType"] = string(inst.LaunchOptions.NetworkType)
+		}
+		if inst.LaunchOptions.RemoteDataVolumeType != "" {
+			lo["remoteDataVolumeType"] = string(inst.LaunchOptions.RemoteDataVolumeType)
+		}
+		if inst.LaunchOptions.IsPvEncryptionInTransitEnabled != nil {
+			lo["isPvEncryptionInTransitEnabled"] = *inst.LaunchOptions.IsPvEncryptionInTransitEnabled
+		}
+		if inst.LaunchOptions.IsConsistentVolumeNamingEnabled != nil {
+			lo["isConsistentVolumeNamingEnabled"] = *inst.LaunchOptions.IsConsistentVolumeNamingEnabled
+		}
+		if len(lo) > 0 {
+			properties["LaunchOptions"] = lo
+		}
+	}
+
+	if inst.InstanceOptions != nil && inst.InstanceOptions.AreLegacyImdsEndpointsDisabled != nil {
+		properties["InstanceOptions"] = map[string]any{
+			"areLegacyImdsEndpointsDisabled": *inst.InstanceOptions.AreLegacyImdsEndpointsDisabled,
+		}
+	}
+
 	if len(inst.Metadata) > 0 {
 		properties["Metadata"] = inst.Metadata
 	}
@@ -541,8 +1263,361 @@ func buildInstanceProperties(inst core.Instance) map[string]any {
 		properties["FreeformTags"] = util.FreeformTagsToList(inst.FreeformTags)
 	}
 	if inst.DefinedTags != nil {
-		properties["DefinedTags"] = util.DefinedTagsToList(inst.DefinedTags)
+		properties["DefinedTags"] = util.DefinedTagsToList(inst.DefinedTags, ignoredTagNamespaces)
 	}
 
 	return properties
 }
+
+// buildPlatformConfigProperties reports the platform config of a VM in the shape
+// parsePlatformConfig takes, or nil for bare metal and instances without one.
+func buildPlatformConfigProperties(config core.PlatformConfig) map[string]any {
+	var pc map[string]any
+	switch config.(type) {
+	case core.AmdVmPlatformConfig:
+		pc = map[string]any{"type": "AMD_VM"}
+	case core.IntelVmPlatformConfig:
+		pc = map[string]any{"type": "INTEL_VM"}
+	default:
+		return nil
+	}
+
+	if v := config.GetIsSecureBootEnabled(); v != nil {
+		pc["isSecureBootEnabled"] = *v
+	}
+	if v := config.GetIsTrustedPlatformModuleEnabled(); v != nil {
+		pc["isTrustedPlatformModuleEnabled"] = *v
+	}
+	if v := config.GetIsMeasuredBootEnabled(); v != nil {
+		pc["isMeasuredBootEnabled"] = *v
+	}
+	if v := config.GetIsMemoryEncryptionEnabled(); v != nil {
+		pc["isMemoryEncryptionEnabled"] = *v
+	}
+	return pc
+}
+
+// readPrimaryVnic looks up the instance's primary VNIC so Read can report
+// CreateVnicDetails. It returns nil without an error while no primary VNIC is
+// attached yet; lookup failures are errors, so Read never reports the field as
+// gone when it merely couldn't be read.
+func (p *InstanceProvisioner) readPrimaryVnic(ctx context.Context, compute *core.ComputeClient, inst core.Instance) (*core.Vnic, error) {
+	attachments, err := compute.ListVnicAttachments(ctx, core.ListVnicAttachmentsRequest{
+		CompartmentId: inst.CompartmentId,
+		InstanceId:    inst.Id,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list VNIC attachments: %w", err)
+	}
+
+	network, err := p.getNetwork()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
+	}
+	for _, att := range attachments.Items {
+		if att.VnicId == nil || att.LifecycleState != core.VnicAttachmentLifecycleStateAttached {
+			continue
+		}
+		resp, err := network.GetVnic(ctx, core.GetVnicRequest{VnicId: att.VnicId})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read VNIC %s: %w", *att.VnicId, err)
+		}
+		if resp.IsPrimary != nil && *resp.IsPrimary {
+			return &resp.Vnic, nil
+		}
+	}
+	return nil, nil
+}
+
+// readPrimaryPublicIp looks up the public IP on the primary private IP of vnic,
+// ephemeral or reserved, or nil when it has none.
+func (p *InstanceProvisioner) readPrimaryPublicIp(ctx context.Context, vnic core.Vnic) (*core.PublicIp, error) {
+	if vnic.PublicIp == nil {
+		return nil, nil
+	}
+	network, err := p.getNetwork()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
+	}
+	privateIp, err := primaryPrivateIp(ctx, network, vnic)
+	if err != nil {
+		return nil, err
+	}
+	return publicIpOf(ctx, network, privateIp)
+}
+
+// updateReservedPublicIp moves ReservedPublicIpId onto the primary private IP
+// of the instance's primary VNIC. A private IP holds one public IP at most, so
+// an ephemeral IP there is deleted and another reserved IP unassigned first.
+// Removing ReservedPublicIpId unassigns the reserved IP, which stays reserved.
+func (p *InstanceProvisioner) updateReservedPublicIp(ctx context.Context, compute *core.ComputeClient, request *resource.UpdateRequest, props map[string]any) error {
+	reservedPublicIpId, assign := util.ExtractString(props, "ReservedPublicIpId")
+	if !assign {
+		removed, err := patchRemoves(request, "/ReservedPublicIpId")
+		if err != nil || !removed {
+			return err
+		}
+	}
+
+	resp, err := compute.GetInstance(ctx, core.GetInstanceRequest{InstanceId: common.String(request.NativeID)})
+	if err != nil {
+		return fmt.Errorf("failed to read Instance before public IP update: %w", err)
+	}
+	vnic, err := p.readPrimaryVnic(ctx, compute, resp.Instance)
+	if err != nil {
+		return err
+	}
+	if vnic == nil {
+		return fmt.Errorf("no attached primary VNIC found for Instance %s", request.NativeID)
+	}
+	network, err := p.getNetwork()
+	if err != nil {
+		return fmt.Errorf("failed to get VirtualNetwork client: %w", err)
+	}
+	privateIp, err := primaryPrivateIp(ctx, network, *vnic)
+	if err != nil {
+		return err
+	}
+	current, err := publicIpOf(ctx, network, privateIp)
+	if err != nil {
+		return err
+	}
+
+	if current != nil && *current.Id != reservedPublicIpId {
+		if current.Lifetime == core.PublicIpLifetimeEphemeral {
+			if !assign {
+				return nil
+			}
+			if _, err := network.DeletePublicIp(ctx, core.DeletePublicIpRequest{PublicIpId: current.Id}); err != nil {
+				return fmt.Errorf("failed to delete ephemeral public IP %s: %w", *current.Id, err)
+			}
+		} else if err := assignPublicIp(ctx, network, *current.Id, ""); err != nil {
+			return err
+		}
+	}
+	if !assign || (current != nil && *current.Id == reservedPublicIpId) {
+		return nil
+	}
+	return assignPublicIp(ctx, network, reservedPublicIpId, *privateIp.Id)
+}
+
+// assignPublicIp points a reserved public IP at privateIpId, or unassigns it
+// when privateIpId is empty.
+func assignPublicIp(ctx context.Context, network *core.VirtualNetworkClient, publicIpId, privateIpId string) error {
+	if _, err := network.UpdatePublicIp(ctx, core.UpdatePublicIpRequest{
+		PublicIpId:            common.String(publicIpId),
+		UpdatePublicIpDetails: core.UpdatePublicIpDetails{PrivateIpId: common.String(privateIpId)},
+	}); err != nil {
+		if privateIpId == "" {
+			return fmt.Errorf("failed to unassign reserved public IP %s: %w", publicIpId, err)
+		}
+		return fmt.Errorf("failed to assign reserved public IP %s: %w", publicIpId, err)
+	}
+	return nil
+}
+
+// primaryPrivateIp returns the primary private IP of vnic.
+func primaryPrivateIp(ctx context.Context, network *core.VirtualNetworkClient, vnic core.Vnic) (core.PrivateIp, error) {
+	resp, err := network.ListPrivateIps(ctx, core.ListPrivateIpsRequest{VnicId: vnic.Id})
+	if err != nil {
+		return core.PrivateIp{}, fmt.Errorf("failed to list private IPs of VNIC %s: %w", *vnic.Id, err)
+	}
+	for _, ip := range resp.Items {
+		if ip.IsPrimary != nil && *ip.IsPrimary && ip.Id != nil {
+			return ip, nil
+		}
+	}
+	return core.PrivateIp{}, fmt.Errorf("no primary private IP found on VNIC %s", *vnic.Id)
+}
+
+// publicIpOf returns the public IP assigned to privateIp, or nil when it has none.
+func publicIpOf(ctx context.Context, network *core.VirtualNetworkClient, privateIp core.PrivateIp) (*core.PublicIp, error) {
+	resp, err := network.GetPublicIpByPrivateIpId(ctx, core.GetPublicIpByPrivateIpIdRequest{
+		GetPublicIpByPrivateIpIdDetails: core.GetPublicIpByPrivateIpIdDetails{PrivateIpId: privateIp.Id},
+	})
+	if err != nil {
+		if util.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read public IP of private IP %s: %w", *privateIp.Id, err)
+	}
+	return &resp.PublicIp, nil
+}
+
+// readBootVolume looks up the instance's attached boot volume so Read can report
+// its current size and performance. Like readPrimaryVnic it returns nil without
+// an error while none is attached, and fails rather than let Read fall back to
+// the launch-time values GetInstance echoes.
+func (p *InstanceProvisioner) readBootVolume(ctx context.Context, compute *core.ComputeClient, inst core.Instance) (*core.BootVolume, error) {
+	attachments, err := compute.ListBootVolumeAttachments(ctx, core.ListBootVolumeAttachmentsRequest{
+		AvailabilityDomain: inst.AvailabilityDomain,
+		CompartmentId:      inst.CompartmentId,
+		InstanceId:         inst.Id,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list boot volume attachments: %w", err)
+	}
+
+	blockstorage, err := p.getBlockstorage()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Blockstorage client: %w", err)
+	}
+	for _, att := range attachments.Items {
+		if att.BootVolumeId == nil || att.LifecycleState != core.BootVolumeAttachmentLifecycleStateAttached {
+			continue
+		}
+		resp, err := blockstorage.GetBootVolume(ctx, core.GetBootVolumeRequest{BootVolumeId: att.BootVolumeId})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read boot volume %s: %w", *att.BootVolumeId, err)
+		}
+		return &resp.BootVolume, nil
+	}
+	return nil, nil
+}
+
+// updateBootVolume applies SourceDetails.bootVolumeSizeInGBs, bootVolumeVpusPerGB
+// and kmsKeyId to the running instance's boot volume. Growing the volume doesn't
+// grow the guest's partition, so a resize returns a note for the status message.
+func (p *InstanceProvisioner) updateBootVolume(ctx context.Context, compute *core.ComputeClient, instanceId string, sourceDetails map[string]any) (string, error) {
+	if !hasBootVolumeSettings(sourceDetails) {
+		return "", nil
+	}
+
+	resp, err := compute.GetInstance(ctx, core.GetInstanceRequest{InstanceId: common.String(instanceId)})
+	if err != nil {
+		return "", fmt.Errorf("failed to read Instance before boot volume update: %w", err)
+	}
+	bv, err := p.readBootVolume(ctx, compute, resp.Instance)
+	if err != nil {
+		return "", err
+	}
+	if bv == nil {
+		return "", fmt.Errorf("no attached boot volume found for Instance %s", instanceId)
+	}
+
+	resized, err := p.applyBootVolumeSettings(ctx, bv, sourceDetails)
+	if err != nil || !resized {
+		return "", err
+	}
+	sizeInGBs, _ := extractInt64Field(sourceDetails, "bootVolumeSizeInGBs")
+	return fmt.Sprintf("Boot volume resized to %d GB; rescan the disk and grow the partition inside the guest OS to use the new space", sizeInGBs), nil
+}
+
+// prepareBootVolume applies the size, VPUs and KMS key from a bootVolume source
+// to the existing volume before launch, since LaunchInstance only takes its OCID.
+func (p *InstanceProvisioner) prepareBootVolume(ctx context.Context, bootVolumeId string, sourceDetails map[string]any) error {
+	if !hasBootVolumeSettings(sourceDetails) {
+		return nil
+	}
+
+	blockstorage, err := p.getBlockstorage()
+	if err != nil {
+		return fmt.Errorf("failed to get Blockstorage client: %w", err)
+	}
+	resp, err := blockstorage.GetBootVolume(ctx, core.GetBootVolumeRequest{BootVolumeId: common.String(bootVolumeId)})
+	if err != nil {
+		return fmt.Errorf("failed to read boot volume %s: %w", bootVolumeId, err)
+	}
+
+	_, err = p.applyBootVolumeSettings(ctx, &resp.BootVolume, sourceDetails)
+	return err
+}
+
+func hasBootVolumeSettings(sourceDetails map[string]any) bool {
+	_, hasSize := extractInt64Field(sourceDetails, "bootVolumeSizeInGBs")
+	_, hasVpus := extractInt64Field(sourceDetails, "bootVolumeVpusPerGB")
+	_, hasKmsKey := extractStringField(sourceDetails, "kmsKeyId", "KmsKeyId")
+	return hasSize || hasVpus || hasKmsKey
+}
+
+// planBootVolumeUpdate works out which of the size, VPUs and KMS key in
+// sourceDetails differ from bv. The KMS key to switch to is nil when the key
+// is unchanged.
+func planBootVolumeUpdate(bv core.BootVolume, sourceDetails map[string]any) (core.UpdateBootVolumeDetails, *string, error) {
+	sizeInGBs, hasSize := extractInt64Field(sourceDetails, "bootVolumeSizeInGBs")
+	vpusPerGB, hasVpus := extractInt64Field(sourceDetails, "bootVolumeVpusPerGB")
+	kmsKeyId, hasKmsKey := extractStringField(sourceDetails, "kmsKeyId", "KmsKeyId")
+
+	details := core.UpdateBootVolumeDetails{}
+	if hasSize && bv.SizeInGBs != nil && sizeInGBs != *bv.SizeInGBs {
+		if sizeInGBs < *bv.SizeInGBs {
+			return details, nil, fmt.Errorf("boot volume %s cannot shrink from %d GB to %d GB", *bv.Id, *bv.SizeInGBs, sizeInGBs)
+		}
+		details.SizeInGBs = common.Int64(sizeInGBs)
+	}
+	if hasVpus && (bv.VpusPerGB == nil || vpusPerGB != *bv.VpusPerGB) {
+		details.VpusPerGB = common.Int64(vpusPerGB)
+	}
+	if currentKmsKeyId, _ := util.CustomerKmsKeyId(bv.KmsKeyId); hasKmsKey && kmsKeyId != currentKmsKeyId {
+		return details, common.String(kmsKeyId), nil
+	}
+	return details, nil, nil
+}
+
+// applyBootVolumeSettings brings bv in line with sourceDetails, calling OCI only
+// for the settings that differ. It reports whether the volume was resized.
+func (p *InstanceProvisioner) applyBootVolumeSettings(ctx context.Context, bv *core.BootVolume, sourceDetails map[string]any) (bool, error) {
+	details, kmsKeyId, err := planBootVolumeUpdate(*bv, sourceDetails)
+	if err != nil {
+		return false, err
+	}
+	rekey := kmsKeyId != nil
+	if details.SizeInGBs == nil && details.VpusPerGB == nil && !rekey {
+		return false, nil
+	}
+
+	blockstorage, err := p.getBlockstorage()
+	if err != nil {
+		return false, fmt.Errorf("failed to get Blockstorage client: %w", err)
+	}
+	if details.SizeInGBs != nil || details.VpusPerGB != nil {
+		if _, err := blockstorage.UpdateBootVolume(ctx, core.UpdateBootVolumeRequest{
+			BootVolumeId:            bv.Id,
+			UpdateBootVolumeDetails: details,
+		}); err != nil {
+			return false, fmt.Errorf("failed to update boot volume %s: %w", *bv.Id, err)
+		}
+	}
+	if rekey {
+		if _, err := blockstorage.UpdateBootVolumeKmsKey(ctx, core.UpdateBootVolumeKmsKeyRequest{
+			BootVolumeId:                  bv.Id,
+			UpdateBootVolumeKmsKeyDetails: core.UpdateBootVolumeKmsKeyDetails{KmsKeyId: kmsKeyId},
+		}); err != nil {
+			return false, fmt.Errorf("failed to update KMS key of boot volume %s: %w", *bv.Id, err)
+		}
+	}
+
+	return details.SizeInGBs != nil, nil
+}
+
+// buildCreateVnicDetailsProperties is the inverse of parseCreateVnicDetails.
+func buildCreateVnicDetailsProperties(vnic core.Vnic, ignoredTagNamespaces []string) map[string]any {
+	details := map[string]any{
+		"assignPublicIp": vnic.PublicIp != nil,
+	}
+	if vnic.SubnetId != nil {
+		details["subnetId"] = *vnic.SubnetId
+	}
+	if vnic.DisplayName != nil {
+		details["displayName"] = *vnic.DisplayName
+	}
+	if vnic.HostnameLabel != nil {
+		details["hostnameLabel"] = *vnic.HostnameLabel
+	}
+	if len(vnic.NsgIds) > 0 {
+		details["nsgIds"] = vnic.NsgIds
+	}
+	if vnic.PrivateIp != nil {
+		details["privateIp"] = *vnic.PrivateIp
+	}
+	if vnic.SkipSourceDestCheck != nil {
+		details["skipSourceDestCheck"] = *vnic.SkipSourceDestCheck
+	}
+	if len(vnic.FreeformTags) > 0 {
+		details["freeformTags"] = util.FreeformTagsToList(vnic.FreeformTags)
+	}
+	if len(vnic.DefinedTags) > 0 {
+		details["definedTags"] = util.DefinedTagsToList(vnic.DefinedTags, ignoredTagNamespaces)
+	}
+	return details
+}
@@ -0,0 +1,366 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package core
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// roundTrip pushes properties through JSON the way formae hands them back to Create.
+func roundTrip(t *testing.T, props map[string]any) map[string]any {
+	t.Helper()
+	b, err := json.Marshal(props)
+	require.NoError(t, err)
+	var out map[string]any
+	require.NoError(t, json.Unmarshal(b, &out))
+	return out
+}
+
+func TestInstanceReadRoundTripsThroughCreate(t *testing.T) {
+	inst := core.Instance{
+		Id:                 common.String("ocid1.instance.oc1..test"),
+		CompartmentId:      common.String("ocid1.compartment.oc1..test"),
+		AvailabilityDomain: common.String("Uocm:PHX-AD-1"),
+		FaultDomain:        common.String("FAULT-DOMAIN-2"),
+		Shape:              common.String("VM.Standard.E4.Flex"),
+		DisplayName:        common.String("web-1"),
+		LifecycleState:     core.InstanceLifecycleStateRunning,
+		LaunchMode:         core.InstanceLaunchModeCustom,
+		SourceDetails: core.InstanceSourceViaImageDetails{
+			ImageId:             common.String("ocid1.image.oc1..test"),
+			BootVolumeSizeInGBs: common.Int64(100),
+			BootVolumeVpusPerGB: common.Int64(20),
+		},
+		ShapeConfig: &core.InstanceShapeConfig{
+			Ocpus:       common.Float32(2),
+			MemoryInGBs: common.Float32(16),
+		},
+		AgentConfig: &core.InstanceAgentConfig{
+			IsMonitoringDisabled:  common.Bool(true),
+			IsManagementDisabled:  common.Bool(false),
+			AreAllPluginsDisabled: common.Bool(false),
+		},
+		LaunchOptions: &core.LaunchOptions{
+			BootVolumeType:                 core.LaunchOptionsBootVolumeTypeParavirtualized,
+			Firmware:                       core.LaunchOptionsFirmwareUefi64,
+			NetworkType:                    core.LaunchOptionsNetworkTypeParavirtualized,
+			RemoteDataVolumeType:           core.LaunchOptionsRemoteDataVolumeTypeParavirtualized,
+			IsPvEncryptionInTransitEnabled: common.Bool(true),
+		},
+		PlatformConfig: core.AmdVmPlatformConfig{
+			IsSecureBootEnabled:            common.Bool(true),
+			IsTrustedPlatformModuleEnabled: common.Bool(true),
+			IsMeasuredBootEnabled:          common.Bool(true),
+			IsMemoryEncryptionEnabled:      common.Bool(false),
+		},
+		InstanceOptions: &core.InstanceOptions{AreLegacyImdsEndpointsDisabled: common.Bool(true)},
+		Metadata:        map[string]string{"ssh_authorized_keys": "ssh-ed25519 AAAA"},
+		FreeformTags:    map[string]string{"Env": "prod"},
+	}
+	vnic := core.Vnic{
+		SubnetId:            common.String("ocid1.subnet.oc1..test"),
+		DisplayName:         common.String("web-1"),
+		HostnameLabel:       common.String("web1"),
+		NsgIds:              []string{"ocid1.nsg.oc1..test"},
+		PrivateIp:           common.String("10.0.1.10"),
+		PublicIp:            common.String("203.0.113.10"),
+		SkipSourceDestCheck: common.Bool(false),
+	}
+
+	props := buildInstanceProperties(inst, nil)
+	props["CreateVnicDetails"] = buildCreateVnicDetailsProperties(vnic, nil)
+
+	details, err := parseLaunchInstanceDetails(roundTrip(t, props))
+	require.NoError(t, err)
+
+	assert.Equal(t, inst.CompartmentId, details.CompartmentId)
+	assert.Equal(t, inst.AvailabilityDomain, details.AvailabilityDomain)
+	assert.Equal(t, inst.FaultDomain, details.FaultDomain)
+	assert.Equal(t, inst.Shape, details.Shape)
+	assert.Equal(t, inst.DisplayName, details.DisplayName)
+	assert.Equal(t, inst.SourceDetails, details.SourceDetails)
+	assert.Equal(t, inst.ShapeConfig.Ocpus, details.ShapeConfig.Ocpus)
+	assert.Equal(t, inst.ShapeConfig.MemoryInGBs, details.ShapeConfig.MemoryInGBs)
+	assert.Equal(t, inst.AgentConfig.IsMonitoringDisabled, details.AgentConfig.IsMonitoringDisabled)
+	assert.Equal(t, inst.AgentConfig.IsManagementDisabled, details.AgentConfig.IsManagementDisabled)
+	assert.Equal(t, inst.AgentConfig.AreAllPluginsDisabled, details.AgentConfig.AreAllPluginsDisabled)
+	assert.Equal(t, "CUSTOM", props["LaunchMode"])
+	assert.Equal(t, inst.LaunchOptions, details.LaunchOptions)
+	assert.Equal(t, core.AmdVmLaunchInstancePlatformConfig{
+		IsSecureBootEnabled:            common.Bool(true),
+		IsTrustedPlatformModuleEnabled: common.Bool(true),
+		IsMeasuredBootEnabled:          common.Bool(true),
+		IsMemoryEncryptionEnabled:      common.Bool(false),
+	}, details.PlatformConfig)
+	assert.Equal(t, inst.InstanceOptions, details.InstanceOptions)
+	assert.Equal(t, inst.Metadata, details.Metadata)
+	assert.Equal(t, inst.FreeformTags, details.FreeformTags)
+
+	require.NotNil(t, details.CreateVnicDetails)
+	assert.Equal(t, vnic.SubnetId, details.CreateVnicDetails.SubnetId)
+	assert.Equal(t, vnic.DisplayName, details.CreateVnicDetails.DisplayName)
+	assert.Equal(t, vnic.HostnameLabel, details.CreateVnicDetails.HostnameLabel)
+	assert.Equal(t, vnic.NsgIds, details.CreateVnicDetails.NsgIds)
+	assert.Equal(t, vnic.PrivateIp, details.CreateVnicDetails.PrivateIp)
+	assert.Equal(t, vnic.SkipSourceDestCheck, details.CreateVnicDetails.SkipSourceDestCheck)
+	assert.Equal(t, common.Bool(true), details.CreateVnicDetails.AssignPublicIp)
+}
+
+func TestParseShapeConfigBaselineOcpuUtilization(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		launch, err := parseShapeConfig(map[string]any{"baselineOcpuUtilization": "BASELINE_1_2"})
+		require.NoError(t, err)
+		assert.Equal(t, core.LaunchInstanceShapeConfigDetailsBaselineOcpuUtilization2, launch.BaselineOcpuUtilization)
+
+		update, err := parseUpdateShapeConfig(map[string]any{"baselineOcpuUtilization": "BASELINE_1_8"})
+		require.NoError(t, err)
+		assert.Equal(t, core.UpdateInstanceShapeConfigDetailsBaselineOcpuUtilization8, update.BaselineOcpuUtilization)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := parseShapeConfig(map[string]any{"baselineOcpuUtilization": "BASELINE_1_4"})
+		assert.ErrorContains(t, err, `baselineOcpuUtilization "BASELINE_1_4" is not valid, must be one of: BASELINE_1_8, BASELINE_1_2, BASELINE_1_1`)
+
+		_, err = parseUpdateShapeConfig(map[string]any{"baselineOcpuUtilization": "BASELINE_1_4"})
+		assert.ErrorContains(t, err, "must be one of: BASELINE_1_8, BASELINE_1_2, BASELINE_1_1")
+	})
+}
+
+func TestValidateInstanceOptions(t *testing.T) {
+	imdsV2Only := &core.InstanceOptions{AreLegacyImdsEndpointsDisabled: common.Bool(true)}
+
+	assert.NoError(t, validateInstanceOptions("VM.Standard.E4.Flex", imdsV2Only))
+	assert.NoError(t, validateInstanceOptions("VM.Standard1.2", nil))
+	assert.NoError(t, validateInstanceOptions("VM.Standard1.2", &core.InstanceOptions{AreLegacyImdsEndpointsDisabled: common.Bool(false)}))
+	assert.ErrorContains(t, validateInstanceOptions("BM.DenseIO1.36", imdsV2Only), "shape BM.DenseIO1.36 does not support IMDSv2")
+}
+
+func TestValidateLaunchMode(t *testing.T) {
+	options := &core.LaunchOptions{Firmware: core.LaunchOptionsFirmwareBios}
+
+	assert.NoError(t, validateLaunchMode("NATIVE", nil))
+	assert.NoError(t, validateLaunchMode("PARAVIRTUALIZED", options))
+	assert.NoError(t, validateLaunchMode("CUSTOM", options))
+	assert.ErrorContains(t, validateLaunchMode("CUSTOM", nil), "LaunchMode CUSTOM needs launchOptions")
+	assert.ErrorContains(t, validateLaunchMode("HVM", nil), `LaunchMode "HVM" is not valid`)
+
+	_, err := parseLaunchInstanceDetails(map[string]any{
+		"CompartmentId":      "ocid1.compartment.oc1..test",
+		"AvailabilityDomain": "Uocm:PHX-AD-1",
+		"Shape":              "VM.Standard2.1",
+		"LaunchMode":         "CUSTOM",
+	})
+	assert.ErrorContains(t, err, "LaunchMode CUSTOM needs launchOptions")
+}
+
+func TestParsePlatformConfig(t *testing.T) {
+	pc, err := parsePlatformConfig(map[string]any{"type": "INTEL_VM", "isMemoryEncryptionEnabled": true})
+	require.NoError(t, err)
+	assert.Equal(t, core.IntelVmLaunchInstancePlatformConfig{IsMemoryEncryptionEnabled: common.Bool(true)}, pc)
+
+	_, err = parsePlatformConfig(map[string]any{"type": "GENERIC_BM"})
+	assert.ErrorContains(t, err, `platformConfig type "GENERIC_BM" is not supported`)
+
+	_, err = parsePlatformConfig(map[string]any{"type": "AMD_VM", "isMeasuredBootEnabled": true, "isTrustedPlatformModuleEnabled": false})
+	assert.ErrorContains(t, err, "isMeasuredBootEnabled needs isTrustedPlatformModuleEnabled")
+
+	assert.Nil(t, buildPlatformConfigProperties(core.GenericBmPlatformConfig{IsSecureBootEnabled: common.Bool(true)}))
+	assert.Nil(t, buildPlatformConfigProperties(nil))
+}
+
+func TestParseInstanceAction(t *testing.T) {
+	patch := func(doc string) *resource.UpdateRequest {
+		return &resource.UpdateRequest{PatchDocument: &doc}
+	}
+	props := map[string]any{"InstanceAction": "SOFTRESET"}
+
+	t.Run("set_by_patch", func(t *testing.T) {
+		action, err := parseInstanceAction(patch(`[{"op":"add","path":"/InstanceAction","value":"SOFTRESET"}]`), props)
+		require.NoError(t, err)
+		assert.Equal(t, core.InstanceActionActionSoftreset, action)
+	})
+
+	t.Run("untouched_by_patch", func(t *testing.T) {
+		action, err := parseInstanceAction(patch(`[{"op":"replace","path":"/DisplayName","value":"web"}]`), props)
+		require.NoError(t, err)
+		assert.Empty(t, action)
+	})
+
+	t.Run("desired_properties", func(t *testing.T) {
+		action, err := parseInstanceAction(&resource.UpdateRequest{}, map[string]any{"InstanceAction": "REBOOTMIGRATE"})
+		require.NoError(t, err)
+		assert.Equal(t, core.InstanceActionActionRebootmigrate, action)
+	})
+
+	t.Run("power_state_rejected", func(t *testing.T) {
+		_, err := parseInstanceAction(&resource.UpdateRequest{}, map[string]any{"InstanceAction": "STOP"})
+		assert.ErrorContains(t, err, `InstanceAction "STOP" is not valid, must be one of: SOFTRESET, RESET, SENDDIAGNOSTICINTERRUPT, REBOOTMIGRATE`)
+	})
+}
+
+func TestInstanceShapeChanged(t *testing.T) {
+	patch := func(doc string) *resource.UpdateRequest {
+		return &resource.UpdateRequest{PatchDocument: &doc}
+	}
+
+	cases := []struct {
+		name    string
+		request *resource.UpdateRequest
+		props   map[string]any
+		want    bool
+	}{
+		{
+			name:    "patch_replaces_shape",
+			request: patch(`[{"op":"replace","path":"/Shape","value":"VM.Standard.E5.Flex"}]`),
+			want:    true,
+		},
+		{
+			name:    "patch_touches_ocpus",
+			request: patch(`[{"op":"replace","path":"/ShapeConfig/ocpus","value":4}]`),
+			want:    true,
+		},
+		{
+			name:    "patch_elsewhere",
+			request: patch(`[{"op":"replace","path":"/DisplayName","value":"web"}]`),
+			want:    false,
+		},
+		{
+			name:    "desired_differs_from_prior",
+			request: &resource.UpdateRequest{PriorProperties: json.RawMessage(`{"Shape":"VM.Standard.E4.Flex","ShapeConfig":{"ocpus":2}}`)},
+			props:   map[string]any{"Shape": "VM.Standard.E4.Flex", "ShapeConfig": map[string]any{"ocpus": float64(4)}},
+			want:    true,
+		},
+		{
+			name:    "desired_matches_prior",
+			request: &resource.UpdateRequest{PriorProperties: json.RawMessage(`{"Shape":"VM.Standard.E4.Flex","ShapeConfig":{"ocpus":2}}`)},
+			props:   map[string]any{"Shape": "VM.Standard.E4.Flex", "ShapeConfig": map[string]any{"ocpus": float64(2)}},
+			want:    false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := instanceShapeChanged(tc.request, tc.props)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestParseUpdateOperationConstraint(t *testing.T) {
+	constraint, err := parseUpdateOperationConstraint(map[string]any{"UpdateOperationConstraint": "AVOID_DOWNTIME"})
+	require.NoError(t, err)
+	assert.Equal(t, core.UpdateInstanceDetailsUpdateOperationConstraintAvoidDowntime, constraint)
+
+	constraint, err = parseUpdateOperationConstraint(map[string]any{})
+	require.NoError(t, err)
+	assert.Empty(t, constraint)
+
+	_, err = parseUpdateOperationConstraint(map[string]any{"UpdateOperationConstraint": "NEVER"})
+	assert.ErrorContains(t, err, `UpdateOperationConstraint "NEVER" is not valid`)
+}
+
+func TestParseSourceDetails(t *testing.T) {
+	sd, err := parseSourceDetails(map[string]any{
+		"sourceType":   "bootVolume",
+		"bootVolumeId": "ocid1.bootvolume.oc1..test",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, core.InstanceSourceViaBootVolumeDetails{BootVolumeId: common.String("ocid1.bootvolume.oc1..test")}, sd)
+
+	sd, err = parseSourceDetails(map[string]any{
+		"sourceType": "image",
+		"imageId":    "ocid1.image.oc1..test",
+		"kmsKeyId":   "ocid1.key.oc1..test",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ocid1.key.oc1..test", *sd.(core.InstanceSourceViaImageDetails).KmsKeyId)
+
+	_, err = parseSourceDetails(map[string]any{
+		"sourceType":   "bootVolume",
+		"imageId":      "ocid1.image.oc1..test",
+		"bootVolumeId": "ocid1.bootvolume.oc1..test",
+	})
+	assert.ErrorContains(t, err, "either imageId or bootVolumeId")
+
+	_, err = parseSourceDetails(map[string]any{"sourceType": "bootVolume"})
+	assert.ErrorContains(t, err, "needs a bootVolumeId")
+
+	// imageName is resolved to imageId by Create
+	sd, err = parseSourceDetails(map[string]any{"sourceType": "image", "imageName": "Oracle-Linux-8.x"})
+	require.NoError(t, err)
+	assert.Nil(t, sd.(core.InstanceSourceViaImageDetails).ImageId)
+
+	_, err = parseSourceDetails(map[string]any{
+		"sourceType": "image",
+		"imageId":    "ocid1.image.oc1..test",
+		"imageName":  "Oracle-Linux-8.x",
+	})
+	assert.ErrorContains(t, err, "either imageId or imageName")
+}
+
+func TestPlanBootVolumeUpdate(t *testing.T) {
+	bv := core.BootVolume{
+		Id:        common.String("ocid1.bootvolume.oc1..test"),
+		SizeInGBs: common.Int64(50),
+		VpusPerGB: common.Int64(10),
+	}
+	sourceDetails := map[string]any{
+		"sourceType":          "bootVolume",
+		"bootVolumeId":        "ocid1.bootvolume.oc1..test",
+		"bootVolumeVpusPerGB": float64(20),
+		"kmsKeyId":            "ocid1.key.oc1..test",
+	}
+
+	// The launch source only names the volume; the rest is applied to it
+	sd, err := parseSourceDetails(sourceDetails)
+	require.NoError(t, err)
+	assert.Equal(t, core.InstanceSourceViaBootVolumeDetails{BootVolumeId: common.String("ocid1.bootvolume.oc1..test")}, sd)
+	assert.True(t, hasBootVolumeSettings(sourceDetails))
+
+	details, kmsKeyId, err := planBootVolumeUpdate(bv, sourceDetails)
+	require.NoError(t, err)
+	assert.Nil(t, details.SizeInGBs)
+	assert.Equal(t, int64(20), *details.VpusPerGB)
+	assert.Equal(t, "ocid1.key.oc1..test", *kmsKeyId)
+
+	// Already in line: nothing to call
+	bv.VpusPerGB = common.Int64(20)
+	bv.KmsKeyId = common.String("ocid1.key.oc1..test")
+	details, kmsKeyId, err = planBootVolumeUpdate(bv, sourceDetails)
+	require.NoError(t, err)
+	assert.Nil(t, details.VpusPerGB)
+	assert.Nil(t, kmsKeyId)
+
+	sourceDetails["bootVolumeSizeInGBs"] = float64(40)
+	_, _, err = planBootVolumeUpdate(bv, sourceDetails)
+	assert.ErrorContains(t, err, "cannot shrink")
+}
+
+func TestPatchRemoves(t *testing.T) {
+	patch := func(doc string) *resource.UpdateRequest {
+		return &resource.UpdateRequest{PatchDocument: &doc}
+	}
+
+	removed, err := patchRemoves(patch(`[{"op":"remove","path":"/ReservedPublicIpId"}]`), "/ReservedPublicIpId")
+	require.NoError(t, err)
+	assert.True(t, removed)
+
+	removed, err = patchRemoves(patch(`[{"op":"replace","path":"/ReservedPublicIpId","value":"ocid1.publicip.oc1..test"}]`), "/ReservedPublicIpId")
+	require.NoError(t, err)
+	assert.False(t, removed)
+
+	// Without a patch there is nothing to tell a removed field from an unset one
+	removed, err = patchRemoves(&resource.UpdateRequest{}, "/ReservedPublicIpId")
+	require.NoError(t, err)
+	assert.False(t, removed)
+}
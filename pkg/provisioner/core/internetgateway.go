@@ -12,6 +12,7 @@ import (
 	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/oracle/oci-go-sdk/v65/core"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
@@ -26,6 +27,7 @@ var _ provisioner.Provisioner = &InternetGatewayProvisioner{}
 
 func init() {
 	provisioner.Register("OCI::Core::InternetGateway", NewInternetGatewayProvisioner)
+	provisioner.DependsOn("OCI::Core::InternetGateway", "OCI::Identity::Compartment", "OCI::Core::VCN")
 }
 
 func NewInternetGatewayProvisioner(clients *client.Clients) provisioner.Provisioner {
@@ -73,6 +75,7 @@ func (p *InternetGatewayProvisioner) Create(ctx context.Context, request *resour
 	}
 
 	createReq := core.CreateInternetGatewayRequest{
+		OpcRetryToken:                common.String(util.CreateRetryToken(request)),
 		CreateInternetGatewayDetails: createDetails,
 	}
 
@@ -187,13 +190,7 @@ func (p *InternetGatewayProvisioner) Delete(ctx context.Context, request *resour
 }
 
 func (p *InternetGatewayProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
-	return &resource.StatusResult{
-		ProgressResult: &resource.ProgressResult{
-			Operation:       resource.OperationCheckStatus,
-			OperationStatus: resource.OperationStatusSuccess,
-			RequestID:       request.RequestID,
-		},
-	}, nil
+	return util.SyncStatus(ctx, request, p.Read)
 }
 
 func (p *InternetGatewayProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
@@ -208,7 +205,7 @@ func (p *InternetGatewayProvisioner) Read(ctx context.Context, request *resource
 
 	resp, err := client.GetInternetGateway(ctx, getReq)
 	if err != nil {
-		if serviceErr, ok := common.IsServiceError(err); ok && serviceErr.GetHTTPStatusCode() == 404 {
+		if util.IsNotFound(err) {
 			return &resource.ReadResult{
 				ResourceType: "OCI::Core::InternetGateway",
 				ErrorCode:    resource.OperationErrorCodeNotFound,
@@ -238,7 +235,7 @@ func (p *InternetGatewayProvisioner) Read(ctx context.Context, request *resource
 		props["FreeformTags"] = util.FreeformTagsToList(resp.FreeformTags)
 	}
 	if resp.DefinedTags != nil {
-		props["DefinedTags"] = util.DefinedTagsToList(resp.DefinedTags)
+		props["DefinedTags"] = util.DefinedTagsToList(resp.DefinedTags, config.FromTargetConfig(request.TargetConfig).HiddenTagNamespaces())
 	}
 
 	propBytes, err := json.Marshal(props)
@@ -258,13 +255,14 @@ func (p *InternetGatewayProvisioner) List(ctx context.Context, request *resource
 		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
 	}
 
-	compartmentId, ok := request.AdditionalProperties["CompartmentId"]
+	compartmentId, ok := util.ListCompartmentId(request)
 	if !ok {
 		return nil, fmt.Errorf("CompartmentId is required for listing InternetGateways")
 	}
 
 	listReq := core.ListInternetGatewaysRequest{
 		CompartmentId: common.String(compartmentId),
+		Limit:         util.ListPageSize(request.TargetConfig),
 	}
 
 	// Optional: Filter by VcnId
@@ -272,14 +270,20 @@ func (p *InternetGatewayProvisioner) List(ctx context.Context, request *resource
 		listReq.VcnId = common.String(vcnId)
 	}
 
-	resp, err := client.ListInternetGateways(ctx, listReq)
+	nativeIDs, err := util.ListAllPages(func(page *string) ([]string, *string, error) {
+		listReq.Page = page
+		resp, err := client.ListInternetGateways(ctx, listReq)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list InternetGateways: %w", err)
+		}
+		ids := make([]string, 0, len(resp.Items))
+		for _, ig := range resp.Items {
+			ids = append(ids, *ig.Id)
+		}
+		return ids, resp.OpcNextPage, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list InternetGateways: %w", err)
-	}
-
-	nativeIDs := make([]string, 0, len(resp.Items))
-	for _, ig := range resp.Items {
-		nativeIDs = append(nativeIDs, *ig.Id)
+		return nil, err
 	}
 
 	return &resource.ListResult{
@@ -12,6 +12,7 @@ import (
 	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/oracle/oci-go-sdk/v65/core"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
@@ -26,6 +27,7 @@ var _ provisioner.Provisioner = &NatGatewayProvisioner{}
 
 func init() {
 	provisioner.Register("OCI::Core::NatGateway", NewNatGatewayProvisioner)
+	provisioner.DependsOn("OCI::Core::NatGateway", "OCI::Identity::Compartment", "OCI::Core::VCN")
 }
 
 func NewNatGatewayProvisioner(clients *client.Clients) provisioner.Provisioner {
@@ -75,6 +77,7 @@ func (p *NatGatewayProvisioner) Create(ctx context.Context, request *resource.Cr
 	}
 
 	createReq := core.CreateNatGatewayRequest{
+		OpcRetryToken:           common.String(util.CreateRetryToken(request)),
 		CreateNatGatewayDetails: createDetails,
 	}
 
@@ -189,13 +192,7 @@ func (p *NatGatewayProvisioner) Delete(ctx context.Context, request *resource.De
 }
 
 func (p *NatGatewayProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
-	return &resource.StatusResult{
-		ProgressResult: &resource.ProgressResult{
-			Operation:       resource.OperationCheckStatus,
-			OperationStatus: resource.OperationStatusSuccess,
-			RequestID:       request.RequestID,
-		},
-	}, nil
+	return util.SyncStatus(ctx, request, p.Read)
 }
 
 func (p *NatGatewayProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
@@ -210,7 +207,7 @@ func (p *NatGatewayProvisioner) Read(ctx context.Context, request *resource.Read
 
 	resp, err := client.GetNatGateway(ctx, getReq)
 	if err != nil {
-		if serviceErr, ok := common.IsServiceError(err); ok && serviceErr.GetHTTPStatusCode() == 404 {
+		if util.IsNotFound(err) {
 			return &resource.ReadResult{
 				ResourceType: "OCI::Core::NatGateway",
 				ErrorCode:    resource.OperationErrorCodeNotFound,
@@ -251,7 +248,7 @@ func (p *NatGatewayProvisioner) Read(ctx context.Context, request *resource.Read
 		props["FreeformTags"] = util.FreeformTagsToList(resp.FreeformTags)
 	}
 	if resp.DefinedTags != nil {
-		props["DefinedTags"] = util.DefinedTagsToList(resp.DefinedTags)
+		props["DefinedTags"] = util.DefinedTagsToList(resp.DefinedTags, config.FromTargetConfig(request.TargetConfig).HiddenTagNamespaces())
 	}
 
 	propBytes, err := json.Marshal(props)
@@ -271,13 +268,14 @@ func (p *NatGatewayProvisioner) List(ctx context.Context, request *resource.List
 		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
 	}
 
-	compartmentId, ok := request.AdditionalProperties["CompartmentId"]
+	compartmentId, ok := util.ListCompartmentId(request)
 	if !ok {
 		return nil, fmt.Errorf("CompartmentId is required for listing NatGateways")
 	}
 
 	listReq := core.ListNatGatewaysRequest{
 		CompartmentId: common.String(compartmentId),
+		Limit:         util.ListPageSize(request.TargetConfig),
 	}
 
 	// Optional: Filter by VcnId
@@ -285,14 +283,20 @@ func (p *NatGatewayProvisioner) List(ctx context.Context, request *resource.List
 		listReq.VcnId = common.String(vcnId)
 	}
 
-	resp, err := client.ListNatGateways(ctx, listReq)
+	nativeIDs, err := util.ListAllPages(func(page *string) ([]string, *string, error) {
+		listReq.Page = page
+		resp, err := client.ListNatGateways(ctx, listReq)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list NatGateways: %w", err)
+		}
+		ids := make([]string, 0, len(resp.Items))
+		for _, ng := range resp.Items {
+			ids = append(ids, *ng.Id)
+		}
+		return ids, resp.OpcNextPage, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list NatGateways: %w", err)
-	}
-
-	nativeIDs := make([]string, 0, len(resp.Items))
-	for _, ng := range resp.Items {
-		nativeIDs = append(nativeIDs, *ng.Id)
+		return nil, err
 	}
 
 	return &resource.ListResult{
@@ -12,6 +12,7 @@ import (
 	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/oracle/oci-go-sdk/v65/core"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
@@ -26,6 +27,7 @@ var _ provisioner.Provisioner = &NetworkSecurityGroupProvisioner{}
 
 func init() {
 	provisioner.Register("OCI::Core::NetworkSecurityGroup", NewNetworkSecurityGroupProvisioner)
+	provisioner.DependsOn("OCI::Core::NetworkSecurityGroup", "OCI::Identity::Compartment", "OCI::Core::VCN")
 }
 
 func NewNetworkSecurityGroupProvisioner(clients *client.Clients) provisioner.Provisioner {
@@ -72,6 +74,7 @@ func (p *NetworkSecurityGroupProvisioner) Create(ctx context.Context, request *r
 	}
 
 	createReq := core.CreateNetworkSecurityGroupRequest{
+		OpcRetryToken:                     common.String(util.CreateRetryToken(request)),
 		CreateNetworkSecurityGroupDetails: createDetails,
 	}
 
@@ -184,13 +187,7 @@ func (p *NetworkSecurityGroupProvisioner) Delete(ctx context.Context, request *r
 }
 
 func (p *NetworkSecurityGroupProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
-	return &resource.StatusResult{
-		ProgressResult: &resource.ProgressResult{
-			Operation:       resource.OperationCheckStatus,
-			OperationStatus: resource.OperationStatusSuccess,
-			RequestID:       request.RequestID,
-		},
-	}, nil
+	return util.SyncStatus(ctx, request, p.Read)
 }
 
 func (p *NetworkSecurityGroupProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
@@ -205,7 +202,7 @@ func (p *NetworkSecurityGroupProvisioner) Read(ctx context.Context, request *res
 
 	resp, err := client.GetNetworkSecurityGroup(ctx, getReq)
 	if err != nil {
-		if serviceErr, ok := common.IsServiceError(err); ok && serviceErr.GetHTTPStatusCode() == 404 {
+		if util.IsNotFound(err) {
 			return &resource.ReadResult{
 				ResourceType: "OCI::Core::NetworkSecurityGroup",
 				ErrorCode:    resource.OperationErrorCodeNotFound,
@@ -234,7 +231,7 @@ func (p *NetworkSecurityGroupProvisioner) Read(ctx context.Context, request *res
 		props["FreeformTags"] = util.FreeformTagsToList(resp.FreeformTags)
 	}
 	if resp.DefinedTags != nil {
-		props["DefinedTags"] = util.DefinedTagsToList(resp.DefinedTags)
+		props["DefinedTags"] = util.DefinedTagsToList(resp.DefinedTags, config.FromTargetConfig(request.TargetConfig).HiddenTagNamespaces())
 	}
 
 	propBytes, err := json.Marshal(props)
@@ -254,27 +251,34 @@ func (p *NetworkSecurityGroupProvisioner) List(ctx context.Context, request *res
 		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
 	}
 
-	compartmentId, ok := request.AdditionalProperties["CompartmentId"]
+	compartmentId, ok := util.ListCompartmentId(request)
 	if !ok {
 		return nil, fmt.Errorf("CompartmentId is required for listing NetworkSecurityGroups")
 	}
 
 	listReq := core.ListNetworkSecurityGroupsRequest{
 		CompartmentId: common.String(compartmentId),
+		Limit:         util.ListPageSize(request.TargetConfig),
 	}
 
 	if vcnId, ok := request.AdditionalProperties["VcnId"]; ok {
 		listReq.VcnId = common.String(vcnId)
 	}
 
-	resp, err := client.ListNetworkSecurityGroups(ctx, listReq)
+	nativeIDs, err := util.ListAllPages(func(page *string) ([]string, *string, error) {
+		listReq.Page = page
+		resp, err := client.ListNetworkSecurityGroups(ctx, listReq)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list NetworkSecurityGroups: %w", err)
+		}
+		ids := make([]string, 0, len(resp.Items))
+		for _, nsg := range resp.Items {
+			ids = append(ids, *nsg.Id)
+		}
+		return ids, resp.OpcNextPage, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list NetworkSecurityGroups: %w", err)
-	}
-
-	nativeIDs := make([]string, 0, len(resp.Items))
-	for _, nsg := range resp.Items {
-		nativeIDs = append(nativeIDs, *nsg.Id)
+		return nil, err
 	}
 
 	return &resource.ListResult{
@@ -8,7 +8,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"strings"
 
 	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/oracle/oci-go-sdk/v65/core"
@@ -21,9 +20,9 @@ import (
 // parseNativeID extracts the NSG ID and rule ID from the composite NativeID.
 // Format: {nsgId}/{ruleId}
 func parseNativeID(nativeID string) (nsgId, ruleId string, err error) {
-	parts := strings.SplitN(nativeID, "/", 2)
-	if len(parts) != 2 {
-		return "", "", fmt.Errorf("invalid NativeID format: expected {nsgId}/{ruleId}, got %s", nativeID)
+	parts, err := util.DecodeCompositeID(nativeID, 2)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid NativeID format: expected {nsgId}/{ruleId}: %w", err)
 	}
 	return parts[0], parts[1], nil
 }
@@ -37,6 +36,7 @@ var _ provisioner.Provisioner = &NetworkSecurityGroupSecurityRuleProvisioner{}
 
 func init() {
 	provisioner.Register("OCI::Core::NetworkSecurityGroupSecurityRule", NewNetworkSecurityGroupSecurityRuleProvisioner)
+	provisioner.DependsOn("OCI::Core::NetworkSecurityGroupSecurityRule", "OCI::Core::NetworkSecurityGroup")
 }
 
 func NewNetworkSecurityGroupSecurityRuleProvisioner(clients *client.Clients) provisioner.Provisioner {
@@ -49,6 +49,12 @@ func NewNetworkSecurityGroupSecurityRuleProvisionerWithSvc(svc *core.VirtualNetw
 	return &NetworkSecurityGroupSecurityRuleProvisioner{svc: svc}
 }
 
+// ImmutableFields: the NSG is part of the NativeID, so moving the rule to
+// another one means replacing it.
+func (p *NetworkSecurityGroupSecurityRuleProvisioner) ImmutableFields() []string {
+	return []string{"NetworkSecurityGroupId"}
+}
+
 func (p *NetworkSecurityGroupSecurityRuleProvisioner) getSvc() (*core.VirtualNetworkClient, error) {
 	if p.svc != nil {
 		return p.svc, nil
@@ -56,35 +62,83 @@ func (p *NetworkSecurityGroupSecurityRuleProvisioner) getSvc() (*core.VirtualNet
 	return p.clients.GetVirtualNetworkClient()
 }
 
-func (p *NetworkSecurityGroupSecurityRuleProvisioner) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
-	client, err := p.getSvc()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
+// validateSecurityRuleEndpoint checks that the Source or Destination of a rule
+// agrees with its SourceType or DestinationType. A rule whose peer is another
+// NSG must name it by OCID under NETWORK_SECURITY_GROUP; OCI would otherwise
+// reject the NSG OCID as a malformed CIDR block.
+func validateSecurityRuleEndpoint(field, value, endpointType string) error {
+	isNsg := networkEntityType(value) == "networksecuritygroup"
+	switch {
+	case endpointType == "NETWORK_SECURITY_GROUP" && value == "":
+		return fmt.Errorf("%s is required when %sType is NETWORK_SECURITY_GROUP", field, field)
+	case endpointType == "NETWORK_SECURITY_GROUP" && !isNsg:
+		return fmt.Errorf("%s %q is not a network security group OCID, which %sType NETWORK_SECURITY_GROUP needs", field, value, field)
+	case endpointType != "NETWORK_SECURITY_GROUP" && isNsg:
+		return fmt.Errorf("%s %s is a network security group, which needs %sType NETWORK_SECURITY_GROUP", field, value, field)
 	}
+	return nil
+}
 
-	var props map[string]any
-	if err := json.Unmarshal(request.Properties, &props); err != nil {
-		return nil, fmt.Errorf("failed to parse properties: %w", err)
+// validateSecurityRuleDirection checks that a rule only sets the peer fields of
+// its direction: an INGRESS rule matches traffic by Source, an EGRESS rule by
+// Destination. OCI rejects the other pairing with an error that doesn't say
+// which field is at fault.
+func validateSecurityRuleDirection(direction string, props map[string]any) error {
+	var unexpected []string
+	switch direction {
+	case "INGRESS":
+		unexpected = []string{"Destination", "DestinationType"}
+	case "EGRESS":
+		unexpected = []string{"Source", "SourceType"}
+	default:
+		return nil
+	}
+	for _, field := range unexpected {
+		if v, ok := props[field]; ok && v != nil && v != "" {
+			return fmt.Errorf("%s cannot be set on an %s rule", field, direction)
+		}
+	}
+	return nil
+}
+
+// parseSecurityRule builds the rule from its properties, rejecting field
+// combinations OCI would refuse. Create adds it as is; Update converts it with
+// toUpdateSecurityRuleDetails.
+func parseSecurityRule(props map[string]any) (core.AddSecurityRuleDetails, error) {
+	direction := props["Direction"].(string)
+	if err := validateSecurityRuleDirection(direction, props); err != nil {
+		return core.AddSecurityRuleDetails{}, err
 	}
 
 	securityRule := core.AddSecurityRuleDetails{
-		Direction: core.AddSecurityRuleDetailsDirectionEnum(props["Direction"].(string)),
+		Direction: core.AddSecurityRuleDetailsDirectionEnum(direction),
 		Protocol:  common.String(props["Protocol"].(string)),
 	}
 
 	if description, ok := util.ExtractString(props, "Description"); ok {
 		securityRule.Description = common.String(description)
 	}
-	if destination, ok := util.ExtractString(props, "Destination"); ok {
+	// Source and Destination may reference another NSG, so accept resolved references
+	destination, _ := util.ExtractResolvedReference(props, "Destination")
+	destinationType, _ := util.ExtractString(props, "DestinationType")
+	if err := validateSecurityRuleEndpoint("Destination", destination, destinationType); err != nil {
+		return core.AddSecurityRuleDetails{}, err
+	}
+	if destination != "" {
 		securityRule.Destination = common.String(destination)
 	}
-	if destinationType, ok := util.ExtractString(props, "DestinationType"); ok {
+	if destinationType != "" {
 		securityRule.DestinationType = core.AddSecurityRuleDetailsDestinationTypeEnum(destinationType)
 	}
-	if source, ok := util.ExtractString(props, "Source"); ok {
+	source, _ := util.ExtractResolvedReference(props, "Source")
+	sourceType, _ := util.ExtractString(props, "SourceType")
+	if err := validateSecurityRuleEndpoint("Source", source, sourceType); err != nil {
+		return core.AddSecurityRuleDetails{}, err
+	}
+	if source != "" {
 		securityRule.Source = common.String(source)
 	}
-	if sourceType, ok := util.ExtractString(props, "SourceType"); ok {
+	if sourceType != "" {
 		securityRule.SourceType = core.AddSecurityRuleDetailsSourceTypeEnum(sourceType)
 	}
 	if isStateless, ok := util.ExtractBool(props, "IsStateless"); ok {
@@ -98,7 +152,7 @@ func (p *NetworkSecurityGroupSecurityRuleProvisioner) Create(ctx context.Context
 			minPort, minOk := destPortRange["min"]
 			maxPort, maxOk := destPortRange["max"]
 			if !minOk || !maxOk {
-				return nil, fmt.Errorf("TCP destinationPortRange requires both min and max values")
+				return core.AddSecurityRuleDetails{}, fmt.Errorf("TCP destinationPortRange requires both min and max values")
 			}
 			tcpOpts.DestinationPortRange = &core.PortRange{
 				Min: common.Int(int(minPort.(float64))),
@@ -109,14 +163,16 @@ func (p *NetworkSecurityGroupSecurityRuleProvisioner) Create(ctx context.Context
 			minPort, minOk := srcPortRange["min"]
 			maxPort, maxOk := srcPortRange["max"]
 			if !minOk || !maxOk {
-				return nil, fmt.Errorf("TCP sourcePortRange requires both min and max values")
+				return core.AddSecurityRuleDetails{}, fmt.Errorf("TCP sourcePortRange requires both min and max values")
 			}
 			tcpOpts.SourcePortRange = &core.PortRange{
 				Min: common.Int(int(minPort.(float64))),
 				Max: common.Int(int(maxPort.(float64))),
 			}
 		}
-		securityRule.TcpOptions = tcpOpts
+		if tcpOpts.DestinationPortRange != nil || tcpOpts.SourcePortRange != nil {
+			securityRule.TcpOptions = tcpOpts
+		}
 	}
 
 	// UDP Options
@@ -126,7 +182,7 @@ func (p *NetworkSecurityGroupSecurityRuleProvisioner) Create(ctx context.Context
 			minPort, minOk := destPortRange["min"]
 			maxPort, maxOk := destPortRange["max"]
 			if !minOk || !maxOk {
-				return nil, fmt.Errorf("UDP destinationPortRange requires both min and max values")
+				return core.AddSecurityRuleDetails{}, fmt.Errorf("UDP destinationPortRange requires both min and max values")
 			}
 			udpOpts.DestinationPortRange = &core.PortRange{
 				Min: common.Int(int(minPort.(float64))),
@@ -137,27 +193,59 @@ func (p *NetworkSecurityGroupSecurityRuleProvisioner) Create(ctx context.Context
 			minPort, minOk := srcPortRange["min"]
 			maxPort, maxOk := srcPortRange["max"]
 			if !minOk || !maxOk {
-				return nil, fmt.Errorf("UDP sourcePortRange requires both min and max values")
+				return core.AddSecurityRuleDetails{}, fmt.Errorf("UDP sourcePortRange requires both min and max values")
 			}
 			udpOpts.SourcePortRange = &core.PortRange{
 				Min: common.Int(int(minPort.(float64))),
 				Max: common.Int(int(maxPort.(float64))),
 			}
 		}
-		securityRule.UdpOptions = udpOpts
+		if udpOpts.DestinationPortRange != nil || udpOpts.SourcePortRange != nil {
+			securityRule.UdpOptions = udpOpts
+		}
 	}
 
 	// ICMP Options
 	if icmpOptions, ok := props["IcmpOptions"].(map[string]any); ok {
+		icmpType, ok := extractIntField(icmpOptions, "type", "Type")
+		if !ok {
+			return core.AddSecurityRuleDetails{}, fmt.Errorf("IcmpOptions requires a type")
+		}
 		icmpOpts := &core.IcmpOptions{
-			Type: common.Int(int(icmpOptions["Type"].(float64))),
+			Type: common.Int(icmpType),
 		}
-		if code, ok := icmpOptions["Code"].(float64); ok {
-			icmpOpts.Code = common.Int(int(code))
+		if code, ok := extractIntField(icmpOptions, "code", "Code"); ok {
+			icmpOpts.Code = common.Int(code)
 		}
 		securityRule.IcmpOptions = icmpOpts
 	}
 
+	if err := validateRuleOptions(*securityRule.Protocol, securityRule.TcpOptions, securityRule.UdpOptions, securityRule.IcmpOptions); err != nil {
+		return core.AddSecurityRuleDetails{}, err
+	}
+
+	return securityRule, nil
+}
+
+// HasNoTags: a rule is an entry in its NSG and has no tags of its own.
+func (p *NetworkSecurityGroupSecurityRuleProvisioner) HasNoTags() {}
+
+func (p *NetworkSecurityGroupSecurityRuleProvisioner) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
+	}
+
+	var props map[string]any
+	if err := json.Unmarshal(request.Properties, &props); err != nil {
+		return nil, fmt.Errorf("failed to parse properties: %w", err)
+	}
+
+	securityRule, err := parseSecurityRule(props)
+	if err != nil {
+		return nil, err
+	}
+
 	nsgId, ok := util.ExtractResolvedReference(props, "NetworkSecurityGroupId")
 	if !ok {
 		return nil, fmt.Errorf("NetworkSecurityGroupId is required")
@@ -188,7 +276,7 @@ func (p *NetworkSecurityGroupSecurityRuleProvisioner) Create(ctx context.Context
 
 	// Encode both NSG ID and rule ID in NativeID so Read/Delete can access the NSG ID
 	// Format: {nsgId}/{ruleId}
-	nativeID := fmt.Sprintf("%s/%s", nsgId, ruleID)
+	nativeID := util.EncodeCompositeID(nsgId, ruleID)
 
 	// Validate that the created rule has the expected properties
 	if err := validateCreatedRule(rule, securityRule); err != nil {
@@ -204,9 +292,81 @@ func (p *NetworkSecurityGroupSecurityRuleProvisioner) Create(ctx context.Context
 	}, nil
 }
 
+// toUpdateSecurityRuleDetails carries a parsed rule over to the update form,
+// which names the rule being replaced.
+func toUpdateSecurityRuleDetails(ruleId string, rule core.AddSecurityRuleDetails) core.UpdateSecurityRuleDetails {
+	return core.UpdateSecurityRuleDetails{
+		Id:              common.String(ruleId),
+		Direction:       core.UpdateSecurityRuleDetailsDirectionEnum(rule.Direction),
+		Protocol:        rule.Protocol,
+		Description:     rule.Description,
+		Destination:     rule.Destination,
+		DestinationType: core.UpdateSecurityRuleDetailsDestinationTypeEnum(rule.DestinationType),
+		Source:          rule.Source,
+		SourceType:      core.UpdateSecurityRuleDetailsSourceTypeEnum(rule.SourceType),
+		IsStateless:     rule.IsStateless,
+		TcpOptions:      rule.TcpOptions,
+		UdpOptions:      rule.UdpOptions,
+		IcmpOptions:     rule.IcmpOptions,
+	}
+}
+
+// Update replaces the rule in place with UpdateNetworkSecurityGroupSecurityRules,
+// which keeps its ID and so the NativeID other resources reference.
 func (p *NetworkSecurityGroupSecurityRuleProvisioner) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
-	// NSG rules don't support update - must delete and recreate
-	return nil, fmt.Errorf("update not supported for NetworkSecurityGroupSecurityRule - delete and recreate instead")
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
+	}
+
+	nsgId, ruleId, err := parseNativeID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	props, err := util.ApplyPatchDocument(ctx, request, p.Read)
+	if err != nil {
+		return nil, err
+	}
+
+	securityRule, err := parseSecurityRule(props)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.UpdateNetworkSecurityGroupSecurityRules(ctx, core.UpdateNetworkSecurityGroupSecurityRulesRequest{
+		NetworkSecurityGroupId: common.String(nsgId),
+		UpdateNetworkSecurityGroupSecurityRulesDetails: core.UpdateNetworkSecurityGroupSecurityRulesDetails{
+			SecurityRules: []core.UpdateSecurityRuleDetails{toUpdateSecurityRuleDetails(ruleId, securityRule)},
+		},
+	})
+	if err != nil {
+		if result, handleErr := util.HandleUpdateError(err, "OCI::Core::NetworkSecurityGroupSecurityRule", request.NativeID, "OCI::Core::NetworkSecurityGroupSecurityRule"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to update NetworkSecurityGroupSecurityRule: %w", err)
+	}
+
+	var updated *core.SecurityRule
+	for i := range resp.SecurityRules {
+		if resp.SecurityRules[i].Id != nil && *resp.SecurityRules[i].Id == ruleId {
+			updated = &resp.SecurityRules[i]
+		}
+	}
+	if updated == nil {
+		return nil, fmt.Errorf("security rule %s not returned from OCI", ruleId)
+	}
+	if err := validateCreatedRule(*updated, securityRule); err != nil {
+		return nil, fmt.Errorf("updated rule validation failed: %w", err)
+	}
+
+	return &resource.UpdateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationUpdate,
+			OperationStatus: resource.OperationStatusSuccess,
+			NativeID:        request.NativeID,
+		},
+	}, nil
 }
 
 func (p *NetworkSecurityGroupSecurityRuleProvisioner) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
@@ -229,7 +389,7 @@ func (p *NetworkSecurityGroupSecurityRuleProvisioner) Delete(ctx context.Context
 
 	_, err = client.RemoveNetworkSecurityGroupSecurityRules(ctx, removeReq)
 	if err != nil {
-		if serviceErr, ok := common.IsServiceError(err); ok && serviceErr.GetHTTPStatusCode() == 404 {
+		if util.IsNotFound(err) {
 			// Already deleted
 			return &resource.DeleteResult{
 				ProgressResult: &resource.ProgressResult{
@@ -255,13 +415,7 @@ func (p *NetworkSecurityGroupSecurityRuleProvisioner) Delete(ctx context.Context
 }
 
 func (p *NetworkSecurityGroupSecurityRuleProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
-	return &resource.StatusResult{
-		ProgressResult: &resource.ProgressResult{
-			Operation:       resource.OperationCheckStatus,
-			OperationStatus: resource.OperationStatusSuccess,
-			RequestID:       request.RequestID,
-		},
-	}, nil
+	return util.SyncStatus(ctx, request, p.Read)
 }
 
 func (p *NetworkSecurityGroupSecurityRuleProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
@@ -270,7 +424,7 @@ func (p *NetworkSecurityGroupSecurityRuleProvisioner) Read(ctx context.Context,
 		return nil, err
 	}
 
-	rule, err := p.getSecurityRuleById(ctx, nsgId, ruleId)
+	rule, err := p.getSecurityRuleById(ctx, nsgId, ruleId, util.ListPageSize(request.TargetConfig))
 	if err != nil {
 		return nil, err
 	}
@@ -294,34 +448,50 @@ func (p *NetworkSecurityGroupSecurityRuleProvisioner) Read(ctx context.Context,
 }
 
 // getSecurityRuleById fetches a security rule by listing rules in the NSG and finding the matching one.
-// Returns nil if the rule is not found.
-func (p *NetworkSecurityGroupSecurityRuleProvisioner) getSecurityRuleById(ctx context.Context, nsgId, ruleId string) (*core.SecurityRule, error) {
+// OCI has no call to get a single rule. Within a ReadBatch the listing is shared
+// through util.Cached, so reading every rule of an NSG lists it once rather than
+// once per rule. Returns nil if the rule is not found.
+func (p *NetworkSecurityGroupSecurityRuleProvisioner) getSecurityRuleById(ctx context.Context, nsgId, ruleId string, limit *int) (*core.SecurityRule, error) {
 	client, err := p.getSvc()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
 	}
 
-	listReq := core.ListNetworkSecurityGroupSecurityRulesRequest{
-		NetworkSecurityGroupId: common.String(nsgId),
-	}
-
-	resp, err := client.ListNetworkSecurityGroupSecurityRules(ctx, listReq)
+	rules, err := util.Cached(ctx, "nsg-security-rules/"+client.Host+"/"+nsgId, func() ([]core.SecurityRule, error) {
+		return listSecurityRules(ctx, client, nsgId, limit)
+	})
 	if err != nil {
-		if serviceErr, ok := common.IsServiceError(err); ok && serviceErr.GetHTTPStatusCode() == 404 {
+		if util.IsNotFound(err) {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("failed to list security rules: %w", err)
 	}
 
-	for i := range resp.Items {
-		if *resp.Items[i].Id == ruleId {
-			return &resp.Items[i], nil
+	for i := range rules {
+		if *rules[i].Id == ruleId {
+			return &rules[i], nil
 		}
 	}
 
 	return nil, nil
 }
 
+// listSecurityRules returns every rule of an NSG, following pagination.
+func listSecurityRules(ctx context.Context, client *core.VirtualNetworkClient, nsgId string, limit *int) ([]core.SecurityRule, error) {
+	req := core.ListNetworkSecurityGroupSecurityRulesRequest{
+		NetworkSecurityGroupId: common.String(nsgId),
+		Limit:                  limit,
+	}
+	return util.ListAllPages(func(page *string) ([]core.SecurityRule, *string, error) {
+		req.Page = page
+		resp, err := client.ListNetworkSecurityGroupSecurityRules(ctx, req)
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Items, resp.OpcNextPage, nil
+	})
+}
+
 // buildSecurityRuleProperties builds the properties map from a security rule.
 func buildSecurityRuleProperties(nsgId, ruleId string, rule *core.SecurityRule) map[string]any {
 	props := map[string]any{
@@ -386,7 +556,7 @@ func buildSecurityRuleProperties(nsgId, ruleId string, rule *core.SecurityRule)
 			props["UdpOptions"] = udpOpts
 		}
 	}
-	if rule.IcmpOptions != nil {
+	if rule.IcmpOptions != nil && rule.IcmpOptions.Type != nil {
 		icmpOpts := map[string]any{
 			"type": *rule.IcmpOptions.Type,
 		}
@@ -410,18 +580,14 @@ func (p *NetworkSecurityGroupSecurityRuleProvisioner) List(ctx context.Context,
 		return nil, fmt.Errorf("NetworkSecurityGroupId is required for listing security rules")
 	}
 
-	listReq := core.ListNetworkSecurityGroupSecurityRulesRequest{
-		NetworkSecurityGroupId: common.String(nsgId),
-	}
-
-	resp, err := client.ListNetworkSecurityGroupSecurityRules(ctx, listReq)
+	rules, err := listSecurityRules(ctx, client, nsgId, util.ListPageSize(request.TargetConfig))
 	if err != nil {
 		return nil, fmt.Errorf("failed to list security rules: %w", err)
 	}
 
-	nativeIDs := make([]string, 0, len(resp.Items))
-	for _, rule := range resp.Items {
-		nativeIDs = append(nativeIDs, fmt.Sprintf("%s/%s", nsgId, *rule.Id))
+	nativeIDs := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		nativeIDs = append(nativeIDs, util.EncodeCompositeID(nsgId, *rule.Id))
 	}
 
 	return &resource.ListResult{
@@ -429,7 +595,7 @@ func (p *NetworkSecurityGroupSecurityRuleProvisioner) List(ctx context.Context,
 	}, nil
 }
 
-// validateCreatedRule ensures the created rule matches the requested configuration
+// validateCreatedRule ensures the created or updated rule matches the requested configuration
 func validateCreatedRule(createdRule core.SecurityRule, requestedRule core.AddSecurityRuleDetails) error {
 	// Validate basic properties
 	if createdRule.Direction != core.SecurityRuleDirectionEnum(requestedRule.Direction) {
@@ -0,0 +1,100 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSecurityRuleEndpoint(t *testing.T) {
+	tests := []struct {
+		name         string
+		value        string
+		endpointType string
+		wantErr      string
+	}{
+		{name: "cidr block", value: "10.0.0.0/16", endpointType: "CIDR_BLOCK"},
+		{name: "default type", value: "0.0.0.0/0"},
+		{name: "service cidr", value: "all-phx-services-in-oracle-services-network", endpointType: "SERVICE_CIDR_BLOCK"},
+		{name: "nsg", value: "ocid1.networksecuritygroup.oc1.phx.bbb", endpointType: "NETWORK_SECURITY_GROUP"},
+		{name: "unset", value: ""},
+		{
+			name:         "nsg type without value",
+			endpointType: "NETWORK_SECURITY_GROUP",
+			wantErr:      "Source is required when SourceType is NETWORK_SECURITY_GROUP",
+		},
+		{
+			name:         "nsg type with cidr",
+			value:        "10.0.0.0/16",
+			endpointType: "NETWORK_SECURITY_GROUP",
+			wantErr:      `Source "10.0.0.0/16" is not a network security group OCID`,
+		},
+		{
+			name:         "nsg type with other ocid",
+			value:        "ocid1.subnet.oc1.phx.aaa",
+			endpointType: "NETWORK_SECURITY_GROUP",
+			wantErr:      "is not a network security group OCID",
+		},
+		{
+			name:    "nsg ocid without type",
+			value:   "ocid1.networksecuritygroup.oc1.phx.bbb",
+			wantErr: "needs SourceType NETWORK_SECURITY_GROUP",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSecurityRuleEndpoint("Source", tt.value, tt.endpointType)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestValidateSecurityRuleDirection(t *testing.T) {
+	tests := []struct {
+		name      string
+		direction string
+		props     map[string]any
+		wantErr   string
+	}{
+		{name: "ingress with source", direction: "INGRESS", props: map[string]any{"Source": "10.0.0.0/16", "SourceType": "CIDR_BLOCK"}},
+		{name: "egress with destination", direction: "EGRESS", props: map[string]any{"Destination": "0.0.0.0/0", "DestinationType": "CIDR_BLOCK"}},
+		{name: "ingress with empty destination", direction: "INGRESS", props: map[string]any{"Source": "10.0.0.0/16", "Destination": ""}},
+		{
+			name:      "ingress with destination",
+			direction: "INGRESS",
+			props:     map[string]any{"Source": "10.0.0.0/16", "Destination": "0.0.0.0/0"},
+			wantErr:   "Destination cannot be set on an INGRESS rule",
+		},
+		{
+			name:      "ingress with destination type",
+			direction: "INGRESS",
+			props:     map[string]any{"Source": "10.0.0.0/16", "DestinationType": "CIDR_BLOCK"},
+			wantErr:   "DestinationType cannot be set on an INGRESS rule",
+		},
+		{
+			name:      "egress with source reference",
+			direction: "EGRESS",
+			props:     map[string]any{"Source": map[string]any{"$ref": "formae://app-nsg#/Id"}},
+			wantErr:   "Source cannot be set on an EGRESS rule",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSecurityRuleDirection(tt.direction, tt.props)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/oracle/oci-go-sdk/v65/identity"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+)
+
+// identityGetter returns the Identity client used for AD and fault domain
+// lookups; each provisioner's getIdentity, so tests can inject one.
+type identityGetter func() (*identity.IdentityClient, error)
+
+// resolveAvailabilityDomain expands a short AD reference ("AD-1", "1") to the full
+// name. Fully-qualified names are returned without touching the Identity API.
+func resolveAvailabilityDomain(ctx context.Context, getIdentity identityGetter, compartmentId, name string) (string, error) {
+	if util.IsQualifiedAvailabilityDomain(name) {
+		return name, nil
+	}
+	identityClient, err := getIdentity()
+	if err != nil {
+		return "", fmt.Errorf("failed to get Identity client: %w", err)
+	}
+	return util.ResolveAvailabilityDomain(ctx, identityClient, compartmentId, name)
+}
+
+// sameAvailabilityDomain reports whether desired names the live AD, so a short
+// reference in the desired state isn't mistaken for a move to another AD.
+// Lookup failures count as different; the caller then falls back to replacing.
+func sameAvailabilityDomain(ctx context.Context, getIdentity identityGetter, compartmentId string, live, desired any) bool {
+	liveName, ok := live.(string)
+	if !ok {
+		return false
+	}
+	desiredName, ok := desired.(string)
+	if !ok {
+		return false
+	}
+	if liveName == desiredName {
+		return true
+	}
+	resolved, err := resolveAvailabilityDomain(ctx, getIdentity, compartmentId, desiredName)
+	return err == nil && resolved == liveName
+}
+
+// resolveReplicaAvailabilityDomains expands the AD of each block volume replica
+// in place, as resolveAvailabilityDomain does for the volume itself.
+func resolveReplicaAvailabilityDomains(ctx context.Context, getIdentity identityGetter, compartmentId string, replicas []core.BlockVolumeReplicaDetails) error {
+	for i, replica := range replicas {
+		if replica.AvailabilityDomain == nil {
+			continue
+		}
+		resolved, err := resolveAvailabilityDomain(ctx, getIdentity, compartmentId, *replica.AvailabilityDomain)
+		if err != nil {
+			return fmt.Errorf("replica %d: %w", i, err)
+		}
+		replicas[i].AvailabilityDomain = common.String(resolved)
+	}
+	return nil
+}
+
+func validateFaultDomain(ctx context.Context, getIdentity identityGetter, compartmentId, availabilityDomain, faultDomain string) error {
+	identityClient, err := getIdentity()
+	if err != nil {
+		return fmt.Errorf("failed to get Identity client: %w", err)
+	}
+	return util.ValidateFaultDomain(ctx, identityClient, compartmentId, availabilityDomain, faultDomain)
+}
@@ -0,0 +1,566 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+type PublicIpPoolProvisioner struct {
+	clients *client.Clients
+	svc     *core.VirtualNetworkClient // nil until first use; injected in tests
+}
+
+var _ provisioner.Provisioner = &PublicIpPoolProvisioner{}
+
+func init() {
+	provisioner.Register("OCI::Core::PublicIpPool", NewPublicIpPoolProvisioner)
+	provisioner.DependsOn("OCI::Core::PublicIpPool", "OCI::Identity::Compartment")
+}
+
+func NewPublicIpPoolProvisioner(clients *client.Clients) provisioner.Provisioner {
+	return &PublicIpPoolProvisioner{clients: clients}
+}
+
+// NewPublicIpPoolProvisionerWithSvc constructs a provisioner with a pre-built SDK client,
+// for use in tests that point the client at an httptest server.
+func NewPublicIpPoolProvisionerWithSvc(svc *core.VirtualNetworkClient) *PublicIpPoolProvisioner {
+	return &PublicIpPoolProvisioner{svc: svc}
+}
+
+func (p *PublicIpPoolProvisioner) getSvc() (*core.VirtualNetworkClient, error) {
+	if p.svc != nil {
+		return p.svc, nil
+	}
+	return p.clients.GetVirtualNetworkClient()
+}
+
+func (p *PublicIpPoolProvisioner) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
+	}
+
+	var props map[string]any
+	if err := json.Unmarshal(request.Properties, &props); err != nil {
+		return nil, fmt.Errorf("failed to parse properties: %w", err)
+	}
+
+	cidrBlocks, _ := util.ExtractStringSlice(props, "CidrBlocks")
+	byoipRangeId, hasByoipRange := util.ExtractString(props, "ByoipRangeId")
+	if len(cidrBlocks) > 0 && !hasByoipRange {
+		return nil, fmt.Errorf("ByoipRangeId is required when CidrBlocks are set")
+	}
+
+	createDetails := core.CreatePublicIpPoolDetails{
+		CompartmentId: common.String(props["CompartmentId"].(string)),
+	}
+
+	if displayName, ok := util.ExtractString(props, "DisplayName"); ok {
+		createDetails.DisplayName = common.String(displayName)
+	}
+	if freeformTags, ok := util.ExtractFreeformTags(props, "FreeformTags"); ok {
+		createDetails.FreeformTags = freeformTags
+	}
+	if definedTags, ok := util.ExtractDefinedTags(props, "DefinedTags"); ok {
+		createDetails.DefinedTags = definedTags
+	}
+
+	resp, err := client.CreatePublicIpPool(ctx, core.CreatePublicIpPoolRequest{
+		OpcRetryToken:             common.String(util.CreateRetryToken(request)),
+		CreatePublicIpPoolDetails: createDetails,
+	})
+	if err != nil {
+		if result, handleErr := util.HandleCreateError(err, "OCI::Core::PublicIpPool", "OCI::Core::PublicIpPool"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to create PublicIpPool: %w", err)
+	}
+
+	// The pool takes capacity once it has settled; Status adds each block
+	var changes []publicIpPoolChange
+	for _, cidrBlock := range cidrBlocks {
+		changes = append(changes, publicIpPoolChange{Add: cidrBlock, ByoipRangeId: byoipRangeId})
+	}
+	requestID, err := encodePublicIpPoolRequestID(*resp.Id, changes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resource.CreateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationCreate,
+			OperationStatus: resource.OperationStatusInProgress,
+			NativeID:        *resp.Id,
+			RequestID:       requestID,
+		},
+	}, nil
+}
+
+// publicIpPoolChange adds or removes one CIDR block. The pool is UPDATING while
+// a capacity change runs and OCI refuses another one until it is done, so
+// Create and Update submit at most one and Status submits each following one
+// once the pool has settled.
+type publicIpPoolChange struct {
+	Add          string `json:"add,omitempty"`
+	Remove       string `json:"remove,omitempty"`
+	ByoipRangeId string `json:"byoipRangeId,omitempty"`
+}
+
+// encodePublicIpPoolRequestID carries the changes still to apply in the
+// RequestID, next to the pool they apply to: {poolId}/{json}.
+func encodePublicIpPoolRequestID(poolId string, pending []publicIpPoolChange) (string, error) {
+	if len(pending) == 0 {
+		return poolId, nil
+	}
+	encoded, err := json.Marshal(pending)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode pending PublicIpPool changes: %w", err)
+	}
+	return util.EncodeCompositeID(poolId, string(encoded)), nil
+}
+
+// decodePublicIpPoolRequestID splits a RequestID built by
+// encodePublicIpPoolRequestID. A plain pool OCID has nothing pending.
+func decodePublicIpPoolRequestID(requestID string) (string, []publicIpPoolChange, error) {
+	if !strings.Contains(requestID, "/") {
+		return requestID, nil, nil
+	}
+	parts, err := util.DecodeCompositeID(requestID, 2)
+	if err != nil {
+		return "", nil, err
+	}
+	var pending []publicIpPoolChange
+	if err := json.Unmarshal([]byte(parts[1]), &pending); err != nil {
+		return "", nil, fmt.Errorf("failed to decode pending PublicIpPool changes: %w", err)
+	}
+	return parts[0], pending, nil
+}
+
+// applyPublicIpPoolChange submits one capacity change.
+func applyPublicIpPoolChange(ctx context.Context, client *core.VirtualNetworkClient, poolId string, change publicIpPoolChange) error {
+	if change.Remove != "" {
+		_, err := client.RemovePublicIpPoolCapacity(ctx, core.RemovePublicIpPoolCapacityRequest{
+			PublicIpPoolId: common.String(poolId),
+			RemovePublicIpPoolCapacityDetails: core.RemovePublicIpPoolCapacityDetails{
+				CidrBlock: common.String(change.Remove),
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to remove capacity %s from PublicIpPool: %w", change.Remove, err)
+		}
+		return nil
+	}
+	return addPublicIpPoolCapacity(ctx, client, poolId, change.ByoipRangeId, change.Add)
+}
+
+func (p *PublicIpPoolProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
+	}
+
+	resp, err := client.GetPublicIpPool(ctx, core.GetPublicIpPoolRequest{
+		PublicIpPoolId: common.String(request.NativeID),
+	})
+	if err != nil {
+		if util.IsNotFound(err) {
+			return &resource.ReadResult{
+				ResourceType: "OCI::Core::PublicIpPool",
+				ErrorCode:    resource.OperationErrorCodeNotFound,
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to read PublicIpPool: %w", err)
+	}
+
+	if util.IsTerminal(string(resp.LifecycleState)) {
+		return &resource.ReadResult{
+			ResourceType: "OCI::Core::PublicIpPool",
+			ErrorCode:    resource.OperationErrorCodeNotFound,
+		}, nil
+	}
+
+	props, err := readPublicIpPoolProperties(ctx, client, resp.PublicIpPool, config.FromTargetConfig(request.TargetConfig).HiddenTagNamespaces())
+	if err != nil {
+		return nil, err
+	}
+	propBytes, err := json.Marshal(props)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal PublicIpPool properties: %w", err)
+	}
+
+	return &resource.ReadResult{
+		ResourceType: "OCI::Core::PublicIpPool",
+		Properties:   string(propBytes),
+	}, nil
+}
+
+func (p *PublicIpPoolProvisioner) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
+	}
+
+	props, err := util.ApplyPatchDocument(ctx, request, p.Read)
+	if err != nil {
+		return nil, err
+	}
+
+	updateDetails := core.UpdatePublicIpPoolDetails{}
+
+	if displayName, ok := util.ExtractString(props, "DisplayName"); ok {
+		updateDetails.DisplayName = common.String(displayName)
+	}
+	if freeformTags, ok := util.ExtractFreeformTags(props, "FreeformTags"); ok {
+		updateDetails.FreeformTags = freeformTags
+	}
+	if definedTags, ok := util.ExtractDefinedTags(props, "DefinedTags"); ok {
+		updateDetails.DefinedTags = definedTags
+	}
+
+	resp, err := client.UpdatePublicIpPool(ctx, core.UpdatePublicIpPoolRequest{
+		PublicIpPoolId:            common.String(request.NativeID),
+		UpdatePublicIpPoolDetails: updateDetails,
+	})
+	if err != nil {
+		if result, handleErr := util.HandleUpdateError(err, "OCI::Core::PublicIpPool", request.NativeID, "OCI::Core::PublicIpPool"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to update PublicIpPool: %w", err)
+	}
+
+	// Reconcile CIDR capacity against what the pool currently holds
+	desired, _ := util.ExtractStringSlice(props, "CidrBlocks")
+	byoipRangeId, hasByoipRange := util.ExtractString(props, "ByoipRangeId")
+	var changes []publicIpPoolChange
+	for _, cidrBlock := range resp.CidrBlocks {
+		if !slices.Contains(desired, cidrBlock) {
+			changes = append(changes, publicIpPoolChange{Remove: cidrBlock})
+		}
+	}
+	for _, cidrBlock := range desired {
+		if !slices.Contains(resp.CidrBlocks, cidrBlock) {
+			if !hasByoipRange {
+				return nil, fmt.Errorf("ByoipRangeId is required to add CidrBlocks to PublicIpPool %s", request.NativeID)
+			}
+			changes = append(changes, publicIpPoolChange{Add: cidrBlock, ByoipRangeId: byoipRangeId})
+		}
+	}
+
+	if len(changes) == 0 {
+		return &resource.UpdateResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationUpdate,
+				OperationStatus: resource.OperationStatusSuccess,
+				NativeID:        *resp.Id,
+			},
+		}, nil
+	}
+
+	if err := applyPublicIpPoolChange(ctx, client, request.NativeID, changes[0]); err != nil {
+		if result, handleErr := util.HandleUpdateError(err, "OCI::Core::PublicIpPool", request.NativeID, "OCI::Core::PublicIpPool"); result != nil {
+			return result, handleErr
+		}
+		return nil, err
+	}
+	requestID, err := encodePublicIpPoolRequestID(request.NativeID, changes[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	// Capacity changes are async — return in-progress, Status submits the rest
+	return &resource.UpdateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationUpdate,
+			OperationStatus: resource.OperationStatusInProgress,
+			NativeID:        request.NativeID,
+			RequestID:       requestID,
+		},
+	}, nil
+}
+
+func (p *PublicIpPoolProvisioner) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
+	}
+
+	readRes, err := p.Read(ctx, &resource.ReadRequest{NativeID: request.NativeID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read PublicIpPool before delete: %w", err)
+	}
+	if readRes.ErrorCode == resource.OperationErrorCodeNotFound {
+		return &resource.DeleteResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationDelete,
+				OperationStatus: resource.OperationStatusSuccess,
+				NativeID:        request.NativeID,
+			},
+		}, nil
+	}
+
+	_, err = client.DeletePublicIpPool(ctx, core.DeletePublicIpPoolRequest{
+		PublicIpPoolId: common.String(request.NativeID),
+	})
+	if err != nil {
+		if result, handleErr := util.HandleDeleteError(err, "OCI::Core::PublicIpPool", request.NativeID, "OCI::Core::PublicIpPool"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to delete PublicIpPool: %w", err)
+	}
+
+	// PublicIpPool deletion is async — return in-progress, poll lifecycle in Status()
+	return &resource.DeleteResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationDelete,
+			OperationStatus: resource.OperationStatusInProgress,
+			NativeID:        request.NativeID,
+			RequestID:       request.NativeID,
+		},
+	}, nil
+}
+
+func (p *PublicIpPoolProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
+	}
+
+	poolId, pending, err := decodePublicIpPoolRequestID(request.RequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	getPool := func(ctx context.Context) (*util.LifecycleSnapshot, error) {
+		resp, err := client.GetPublicIpPool(ctx, core.GetPublicIpPoolRequest{
+			PublicIpPoolId: common.String(poolId),
+		})
+		if err != nil {
+			if util.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to check PublicIpPool status: %w", err)
+		}
+		props, err := readPublicIpPoolProperties(ctx, client, resp.PublicIpPool, config.FromTargetConfig(request.TargetConfig).HiddenTagNamespaces())
+		if err != nil {
+			return nil, err
+		}
+		return &util.LifecycleSnapshot{
+			NativeID:   *resp.Id,
+			State:      string(resp.LifecycleState),
+			Properties: props,
+		}, nil
+	}
+
+	// A pool without capacity is INACTIVE, which is a valid end state.
+	// UPDATING and DELETING stay in progress.
+	result, err := util.PollLifecycle(ctx, "PublicIpPool", request.RequestID, getPool, map[string]resource.OperationStatus{
+		string(core.PublicIpPoolLifecycleStateActive):   resource.OperationStatusSuccess,
+		string(core.PublicIpPoolLifecycleStateInactive): resource.OperationStatusSuccess,
+		string(core.PublicIpPoolLifecycleStateDeleted):  resource.OperationStatusSuccess,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The previous change is done; submit the next one
+	if result.OperationStatus == resource.OperationStatusSuccess && len(pending) > 0 {
+		if err := applyPublicIpPoolChange(ctx, client, poolId, pending[0]); err != nil {
+			// The pool may not show UPDATING for the change just submitted yet
+			if code, _ := util.HandleOCIServiceError(err); code == resource.OperationErrorCodeNotStabilized {
+				return &resource.StatusResult{
+					ProgressResult: &resource.ProgressResult{
+						Operation:       resource.OperationCheckStatus,
+						OperationStatus: resource.OperationStatusInProgress,
+						NativeID:        poolId,
+						RequestID:       request.RequestID,
+					},
+				}, nil
+			}
+			return &resource.StatusResult{
+				ProgressResult: &resource.ProgressResult{
+					Operation:       resource.OperationCheckStatus,
+					OperationStatus: resource.OperationStatusFailure,
+					StatusMessage:   fmt.Sprintf("failed to update PublicIpPool capacity: %v", err),
+					NativeID:        poolId,
+				},
+			}, nil
+		}
+		requestID, err := encodePublicIpPoolRequestID(poolId, pending[1:])
+		if err != nil {
+			return nil, err
+		}
+		result = &resource.ProgressResult{
+			Operation:       resource.OperationCheckStatus,
+			OperationStatus: resource.OperationStatusInProgress,
+			NativeID:        poolId,
+			RequestID:       requestID,
+		}
+	}
+
+	return &resource.StatusResult{ProgressResult: result}, nil
+}
+
+func (p *PublicIpPoolProvisioner) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
+	}
+
+	compartmentId, ok := util.ListCompartmentId(request)
+	if !ok {
+		return nil, fmt.Errorf("CompartmentId is required for listing PublicIpPools")
+	}
+
+	listReq := core.ListPublicIpPoolsRequest{
+		CompartmentId: common.String(compartmentId),
+		Limit:         util.ListPageSize(request.TargetConfig),
+	}
+
+	nativeIDs, err := util.ListAllPages(func(page *string) ([]string, *string, error) {
+		listReq.Page = page
+		resp, err := client.ListPublicIpPools(ctx, listReq)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list PublicIpPools: %w", err)
+		}
+		ids := make([]string, 0, len(resp.Items))
+		for _, pool := range resp.Items {
+			if util.IsTerminal(string(pool.LifecycleState)) {
+				continue
+			}
+			ids = append(ids, *pool.Id)
+		}
+		return ids, resp.OpcNextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &resource.ListResult{
+		NativeIDs: nativeIDs,
+	}, nil
+}
+
+func addPublicIpPoolCapacity(ctx context.Context, client *core.VirtualNetworkClient, poolId, byoipRangeId, cidrBlock string) error {
+	_, err := client.AddPublicIpPoolCapacity(ctx, core.AddPublicIpPoolCapacityRequest{
+		PublicIpPoolId: common.String(poolId),
+		AddPublicIpPoolCapacityDetails: core.AddPublicIpPoolCapacityDetails{
+			ByoipRangeId: common.String(byoipRangeId),
+			CidrBlock:    common.String(cidrBlock),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add capacity %s to PublicIpPool: %w", cidrBlock, err)
+	}
+	return nil
+}
+
+// readPublicIpPoolProperties builds the pool's properties, adding the BYOIP
+// range its CIDR blocks were carved from. The pool doesn't record the range, so
+// it is found among the ranges in the pool's compartment by their allocations.
+func readPublicIpPoolProperties(ctx context.Context, client *core.VirtualNetworkClient, pool core.PublicIpPool, ignoredTagNamespaces []string) (map[string]any, error) {
+	props := buildPublicIpPoolProperties(pool, ignoredTagNamespaces)
+	if len(pool.CidrBlocks) == 0 {
+		return props, nil
+	}
+	byoipRangeId, err := publicIpPoolByoipRange(ctx, client, pool)
+	if err != nil {
+		return nil, err
+	}
+	if byoipRangeId != "" {
+		props["ByoipRangeId"] = byoipRangeId
+	}
+	return props, nil
+}
+
+// publicIpPoolByoipRange returns the BYOIP range allocated to pool, or "" when
+// none in its compartment is.
+func publicIpPoolByoipRange(ctx context.Context, client *core.VirtualNetworkClient, pool core.PublicIpPool) (string, error) {
+	rangeIds, err := util.ListAllPages(func(page *string) ([]string, *string, error) {
+		resp, err := client.ListByoipRanges(ctx, core.ListByoipRangesRequest{
+			CompartmentId: pool.CompartmentId,
+			Page:          page,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list BYOIP ranges: %w", err)
+		}
+		ids := make([]string, 0, len(resp.Items))
+		for _, r := range resp.Items {
+			if r.Id != nil && !util.IsTerminal(string(r.LifecycleState)) {
+				ids = append(ids, *r.Id)
+			}
+		}
+		return ids, resp.OpcNextPage, nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	for _, rangeId := range rangeIds {
+		allocated, err := util.ListAllPages(func(page *string) ([]string, *string, error) {
+			resp, err := client.ListByoipAllocatedRanges(ctx, core.ListByoipAllocatedRangesRequest{
+				ByoipRangeId: common.String(rangeId),
+				Page:         page,
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to list allocations of BYOIP range %s: %w", rangeId, err)
+			}
+			var poolIds []string
+			for _, a := range resp.Items {
+				if a.PublicIpPoolId != nil {
+					poolIds = append(poolIds, *a.PublicIpPoolId)
+				}
+			}
+			return poolIds, resp.OpcNextPage, nil
+		})
+		if err != nil {
+			return "", err
+		}
+		if slices.Contains(allocated, *pool.Id) {
+			return rangeId, nil
+		}
+	}
+	return "", nil
+}
+
+func buildPublicIpPoolProperties(pool core.PublicIpPool, ignoredTagNamespaces []string) map[string]any {
+	props := map[string]any{
+		"Id":            *pool.Id,
+		"CompartmentId": *pool.CompartmentId,
+	}
+
+	if pool.DisplayName != nil {
+		props["DisplayName"] = *pool.DisplayName
+	}
+	if len(pool.CidrBlocks) > 0 {
+		props["CidrBlocks"] = pool.CidrBlocks
+	}
+	if pool.LifecycleState != "" {
+		props["LifecycleState"] = string(pool.LifecycleState)
+	}
+	if pool.TimeCreated != nil {
+		props["TimeCreated"] = pool.TimeCreated.Format("2006-01-02T15:04:05.000Z")
+	}
+	if pool.FreeformTags != nil {
+		props["FreeformTags"] = util.FreeformTagsToList(pool.FreeformTags)
+	}
+	if pool.DefinedTags != nil {
+		props["DefinedTags"] = util.DefinedTagsToList(pool.DefinedTags, ignoredTagNamespaces)
+	}
+
+	return props
+}
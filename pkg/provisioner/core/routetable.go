@@ -8,10 +8,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/netip"
+	"strings"
 
 	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/oracle/oci-go-sdk/v65/core"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
@@ -26,6 +29,13 @@ var _ provisioner.Provisioner = &RouteTableProvisioner{}
 
 func init() {
 	provisioner.Register("OCI::Core::RouteTable", NewRouteTableProvisioner)
+	provisioner.DependsOn("OCI::Core::RouteTable",
+		"OCI::Identity::Compartment",
+		"OCI::Core::VCN",
+		"OCI::Core::InternetGateway",
+		"OCI::Core::NatGateway",
+		"OCI::Core::ServiceGateway",
+	)
 }
 
 func NewRouteTableProvisioner(clients *client.Clients) provisioner.Provisioner {
@@ -93,12 +103,59 @@ func parseRouteRules(routeRulesData any) ([]core.RouteRule, error) {
 			rule.Description = common.String(description)
 		}
 
+		if err := validateRouteRule(rule); err != nil {
+			return nil, fmt.Errorf("RouteRule %d: %w", i, err)
+		}
+
 		routeRules = append(routeRules, rule)
 	}
 
 	return routeRules, nil
 }
 
+// validateRouteRule checks that a rule's destination, destinationType and the
+// kind of gateway its networkEntityId names fit together, which OCI otherwise
+// only reports once the whole route table is rejected. The entity kind comes
+// from the OCID, so rules naming something that isn't an OCID are left to OCI.
+func validateRouteRule(rule core.RouteRule) error {
+	destinationType := rule.DestinationType
+	if destinationType == "" {
+		destinationType = core.RouteRuleDestinationTypeCidrBlock
+	}
+	if _, ok := core.GetMappingRouteRuleDestinationTypeEnum(string(destinationType)); !ok {
+		return fmt.Errorf("DestinationType %q is not valid, must be one of: %s", rule.DestinationType, strings.Join(core.GetRouteRuleDestinationTypeEnumStringValues(), ", "))
+	}
+
+	if rule.Destination != nil {
+		_, cidrErr := netip.ParsePrefix(*rule.Destination)
+		switch {
+		case destinationType == core.RouteRuleDestinationTypeCidrBlock && cidrErr != nil:
+			return fmt.Errorf("Destination %q is not a CIDR block, which DestinationType CIDR_BLOCK needs", *rule.Destination)
+		case destinationType == core.RouteRuleDestinationTypeServiceCidrBlock && cidrErr == nil:
+			return fmt.Errorf("Destination %q is a CIDR block, but DestinationType SERVICE_CIDR_BLOCK needs a service CIDR label such as \"all-phx-services-in-oracle-services-network\"", *rule.Destination)
+		}
+	}
+
+	entity := networkEntityType(*rule.NetworkEntityId)
+	switch {
+	case entity == "servicegateway" && destinationType != core.RouteRuleDestinationTypeServiceCidrBlock:
+		return fmt.Errorf("NetworkEntityId %s is a service gateway, which needs DestinationType SERVICE_CIDR_BLOCK", *rule.NetworkEntityId)
+	case destinationType == core.RouteRuleDestinationTypeServiceCidrBlock && entity != "" && entity != "servicegateway" && entity != "natgateway":
+		return fmt.Errorf("NetworkEntityId %s (%s) cannot be the target of a SERVICE_CIDR_BLOCK route, only a service or NAT gateway can", *rule.NetworkEntityId, entity)
+	}
+	return nil
+}
+
+// networkEntityType returns the resource type an OCID names, e.g. "natgateway"
+// for "ocid1.natgateway.oc1.phx.aaa", or "" when id isn't an OCID.
+func networkEntityType(id string) string {
+	parts := strings.SplitN(id, ".", 3)
+	if len(parts) < 3 || parts[0] != "ocid1" {
+		return ""
+	}
+	return parts[1]
+}
+
 func (p *RouteTableProvisioner) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
 	client, err := p.getSvc()
 	if err != nil {
@@ -133,6 +190,7 @@ func (p *RouteTableProvisioner) Create(ctx context.Context, request *resource.Cr
 	}
 
 	createReq := core.CreateRouteTableRequest{
+		OpcRetryToken:           common.String(util.CreateRetryToken(request)),
 		CreateRouteTableDetails: createDetails,
 	}
 
@@ -153,17 +211,9 @@ func (p *RouteTableProvisioner) Create(ctx context.Context, request *resource.Cr
 	}, nil
 }
 
-func (p *RouteTableProvisioner) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
-	client, err := p.getSvc()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
-	}
-
-	props, err := util.ApplyPatchDocument(ctx, request, p.Read)
-	if err != nil {
-		return nil, err
-	}
-
+// routeTableUpdateDetails builds the mutable part of a route table from its
+// resolved properties. DefaultRouteTable uses it for both Create and Update.
+func routeTableUpdateDetails(props map[string]any) (core.UpdateRouteTableDetails, error) {
 	updateDetails := core.UpdateRouteTableDetails{}
 
 	if displayName, ok := util.ExtractString(props, "DisplayName"); ok {
@@ -173,7 +223,7 @@ func (p *RouteTableProvisioner) Update(ctx context.Context, request *resource.Up
 	if routeRulesData, ok := props["RouteRules"]; ok {
 		routeRules, err := parseRouteRules(routeRulesData)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse RouteRules: %w", err)
+			return updateDetails, fmt.Errorf("failed to parse RouteRules: %w", err)
 		}
 		updateDetails.RouteRules = routeRules
 	}
@@ -186,6 +236,25 @@ func (p *RouteTableProvisioner) Update(ctx context.Context, request *resource.Up
 		updateDetails.DefinedTags = definedTags
 	}
 
+	return updateDetails, nil
+}
+
+func (p *RouteTableProvisioner) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
+	}
+
+	props, err := util.ApplyPatchDocument(ctx, request, p.Read)
+	if err != nil {
+		return nil, err
+	}
+
+	updateDetails, err := routeTableUpdateDetails(props)
+	if err != nil {
+		return nil, err
+	}
+
 	updateReq := core.UpdateRouteTableRequest{
 		RtId:                    common.String(request.NativeID),
 		UpdateRouteTableDetails: updateDetails,
@@ -254,13 +323,7 @@ func (p *RouteTableProvisioner) Delete(ctx context.Context, request *resource.De
 }
 
 func (p *RouteTableProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
-	return &resource.StatusResult{
-		ProgressResult: &resource.ProgressResult{
-			Operation:       resource.OperationCheckStatus,
-			OperationStatus: resource.OperationStatusSuccess,
-			RequestID:       request.RequestID,
-		},
-	}, nil
+	return util.SyncStatus(ctx, request, p.Read)
 }
 
 func (p *RouteTableProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
@@ -268,16 +331,21 @@ func (p *RouteTableProvisioner) Read(ctx context.Context, request *resource.Read
 	if err != nil {
 		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
 	}
+	return readRouteTable(ctx, client, request, "OCI::Core::RouteTable")
+}
 
+// readRouteTable reads a route table and reports it as resourceType, which is
+// either OCI::Core::RouteTable or OCI::Core::DefaultRouteTable.
+func readRouteTable(ctx context.Context, client *core.VirtualNetworkClient, request *resource.ReadRequest, resourceType string) (*resource.ReadResult, error) {
 	getReq := core.GetRouteTableRequest{
 		RtId: common.String(request.NativeID),
 	}
 
 	resp, err := client.GetRouteTable(ctx, getReq)
 	if err != nil {
-		if serviceErr, ok := common.IsServiceError(err); ok && serviceErr.GetHTTPStatusCode() == 404 {
+		if util.IsNotFound(err) {
 			return &resource.ReadResult{
-				ResourceType: "OCI::Core::RouteTable",
+				ResourceType: resourceType,
 				ErrorCode:    resource.OperationErrorCodeNotFound,
 			}, nil
 		}
@@ -286,7 +354,7 @@ func (p *RouteTableProvisioner) Read(ctx context.Context, request *resource.Read
 
 	if util.IsTerminal(string(resp.LifecycleState)) {
 		return &resource.ReadResult{
-			ResourceType: "OCI::Core::RouteTable",
+			ResourceType: resourceType,
 			ErrorCode:    resource.OperationErrorCodeNotFound,
 		}, nil
 	}
@@ -325,7 +393,7 @@ func (p *RouteTableProvisioner) Read(ctx context.Context, request *resource.Read
 		props["FreeformTags"] = util.FreeformTagsToList(resp.FreeformTags)
 	}
 	if resp.DefinedTags != nil {
-		props["DefinedTags"] = util.DefinedTagsToList(resp.DefinedTags)
+		props["DefinedTags"] = util.DefinedTagsToList(resp.DefinedTags, config.FromTargetConfig(request.TargetConfig).HiddenTagNamespaces())
 	}
 
 	propBytes, err := json.Marshal(props)
@@ -334,7 +402,7 @@ func (p *RouteTableProvisioner) Read(ctx context.Context, request *resource.Read
 	}
 
 	return &resource.ReadResult{
-		ResourceType: "OCI::Core::RouteTable",
+		ResourceType: resourceType,
 		Properties:   string(propBytes),
 	}, nil
 }
@@ -345,13 +413,14 @@ func (p *RouteTableProvisioner) List(ctx context.Context, request *resource.List
 		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
 	}
 
-	compartmentId, ok := request.AdditionalProperties["CompartmentId"]
+	compartmentId, ok := util.ListCompartmentId(request)
 	if !ok {
 		return nil, fmt.Errorf("CompartmentId is required for listing RouteTables")
 	}
 
 	listReq := core.ListRouteTablesRequest{
 		CompartmentId: common.String(compartmentId),
+		Limit:         util.ListPageSize(request.TargetConfig),
 	}
 
 	// Optional: Filter by VcnId
@@ -359,14 +428,20 @@ func (p *RouteTableProvisioner) List(ctx context.Context, request *resource.List
 		listReq.VcnId = common.String(vcnId)
 	}
 
-	resp, err := client.ListRouteTables(ctx, listReq)
+	nativeIDs, err := util.ListAllPages(func(page *string) ([]string, *string, error) {
+		listReq.Page = page
+		resp, err := client.ListRouteTables(ctx, listReq)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list RouteTables: %w", err)
+		}
+		ids := make([]string, 0, len(resp.Items))
+		for _, rt := range resp.Items {
+			ids = append(ids, *rt.Id)
+		}
+		return ids, resp.OpcNextPage, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list RouteTables: %w", err)
-	}
-
-	nativeIDs := make([]string, 0, len(resp.Items))
-	for _, rt := range resp.Items {
-		nativeIDs = append(nativeIDs, *rt.Id)
+		return nil, err
 	}
 
 	return &resource.ListResult{
@@ -0,0 +1,71 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRouteRulesValidation(t *testing.T) {
+	rule := func(networkEntityId, destination, destinationType string) []any {
+		r := map[string]any{"networkEntityId": networkEntityId, "destination": destination}
+		if destinationType != "" {
+			r["destinationType"] = destinationType
+		}
+		return []any{r}
+	}
+
+	tests := []struct {
+		name    string
+		rules   []any
+		wantErr string
+	}{
+		{name: "internet gateway", rules: rule("ocid1.internetgateway.oc1.phx.aaa", "0.0.0.0/0", "CIDR_BLOCK")},
+		{name: "default destination type", rules: rule("ocid1.natgateway.oc1.phx.aaa", "0.0.0.0/0", "")},
+		{name: "service gateway", rules: rule("ocid1.servicegateway.oc1.phx.aaa", "all-phx-services-in-oracle-services-network", "SERVICE_CIDR_BLOCK")},
+		{name: "ipv6 cidr", rules: rule("ocid1.internetgateway.oc1.phx.aaa", "::/0", "CIDR_BLOCK")},
+		{name: "unresolved entity left to OCI", rules: rule("my-gateway", "oci-phx-objectstorage", "SERVICE_CIDR_BLOCK")},
+		{
+			name:    "unknown destination type",
+			rules:   rule("ocid1.internetgateway.oc1.phx.aaa", "0.0.0.0/0", "CIDR"),
+			wantErr: `RouteRule 0: DestinationType "CIDR" is not valid`,
+		},
+		{
+			name:    "service label as cidr block",
+			rules:   rule("ocid1.natgateway.oc1.phx.aaa", "all-phx-services-in-oracle-services-network", "CIDR_BLOCK"),
+			wantErr: `RouteRule 0: Destination "all-phx-services-in-oracle-services-network" is not a CIDR block`,
+		},
+		{
+			name:    "cidr block as service label",
+			rules:   rule("ocid1.servicegateway.oc1.phx.aaa", "10.0.0.0/16", "SERVICE_CIDR_BLOCK"),
+			wantErr: "needs a service CIDR label",
+		},
+		{
+			name:    "service gateway with cidr block",
+			rules:   rule("ocid1.servicegateway.oc1.phx.aaa", "0.0.0.0/0", "CIDR_BLOCK"),
+			wantErr: "is a service gateway, which needs DestinationType SERVICE_CIDR_BLOCK",
+		},
+		{
+			name:    "service cidr through internet gateway",
+			rules:   rule("ocid1.internetgateway.oc1.phx.aaa", "oci-phx-objectstorage", "SERVICE_CIDR_BLOCK"),
+			wantErr: "(internetgateway) cannot be the target of a SERVICE_CIDR_BLOCK route",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := parseRouteRules(tt.rules)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				assert.Len(t, rules, 1)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
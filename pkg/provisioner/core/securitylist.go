@@ -8,10 +8,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 
 	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/oracle/oci-go-sdk/v65/core"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
@@ -26,6 +28,7 @@ var _ provisioner.Provisioner = &SecurityListProvisioner{}
 
 func init() {
 	provisioner.Register("OCI::Core::SecurityList", NewSecurityListProvisioner)
+	provisioner.DependsOn("OCI::Core::SecurityList", "OCI::Identity::Compartment", "OCI::Core::VCN")
 }
 
 func NewSecurityListProvisioner(clients *client.Clients) provisioner.Provisioner {
@@ -155,6 +158,15 @@ func parseIcmpOptions(data map[string]any) *core.IcmpOptions {
 	return opts
 }
 
+// validateRuleOptions rejects port and ICMP options on protocol "all" rules,
+// which OCI refuses since "all" already covers every port and ICMP type.
+func validateRuleOptions(protocol string, tcp *core.TcpOptions, udp *core.UdpOptions, icmp *core.IcmpOptions) error {
+	if protocol == "all" && (tcp != nil || udp != nil || icmp != nil) {
+		return fmt.Errorf(`protocol "all" can't have tcpOptions, udpOptions or icmpOptions`)
+	}
+	return nil
+}
+
 func parseIngressSecurityRules(rulesData any) ([]core.IngressSecurityRule, error) {
 	if rulesData == nil {
 		return []core.IngressSecurityRule{}, nil
@@ -211,9 +223,16 @@ func parseIngressSecurityRules(rulesData any) ([]core.IngressSecurityRule, error
 			rule.Description = common.String(description)
 		}
 
+		if err := validateRuleOptions(protocol, rule.TcpOptions, rule.UdpOptions, rule.IcmpOptions); err != nil {
+			return nil, fmt.Errorf("IngressSecurityRule %d: %w", i, err)
+		}
+
 		rules = append(rules, rule)
 	}
 
+	sort.SliceStable(rules, func(i, j int) bool {
+		return ingressRuleKey(rules[i]) < ingressRuleKey(rules[j])
+	})
 	return rules, nil
 }
 
@@ -273,78 +292,110 @@ func parseEgressSecurityRules(rulesData any) ([]core.EgressSecurityRule, error)
 			rule.Description = common.String(description)
 		}
 
+		if err := validateRuleOptions(protocol, rule.TcpOptions, rule.UdpOptions, rule.IcmpOptions); err != nil {
+			return nil, fmt.Errorf("EgressSecurityRule %d: %w", i, err)
+		}
+
 		rules = append(rules, rule)
 	}
 
+	sort.SliceStable(rules, func(i, j int) bool {
+		return egressRuleKey(rules[i]) < egressRuleKey(rules[j])
+	})
 	return rules, nil
 }
 
+// Security list rules have no identity of their own and OCI doesn't promise to
+// return them in the order they were sent, so both directions keep them in one
+// canonical order: by protocol, then source or destination, then everything else
+// (ports, ICMP type, statelessness, description). Declaring the same rules in a
+// different order is then not drift.
+func securityRuleKey(rule map[string]any, endpointKey string) string {
+	rest, _ := json.Marshal(rule)
+	return fmt.Sprintf("%v\x00%v\x00%s", rule["protocol"], rule[endpointKey], rest)
+}
+
+func ingressRuleKey(rule core.IngressSecurityRule) string {
+	return securityRuleKey(serializeIngressRule(rule), "source")
+}
+
+func egressRuleKey(rule core.EgressSecurityRule) string {
+	return securityRuleKey(serializeEgressRule(rule), "destination")
+}
+
 // serializeIngressRules converts ingress rules to maps with camelCase keys to match Pkl schema.
 // Note: Nested objects don't get outputKeyTransformation, so must match schema case exactly.
 func serializeIngressRules(rules []core.IngressSecurityRule) []map[string]any {
 	result := make([]map[string]any, len(rules))
 	for i, rule := range rules {
-		ruleMap := map[string]any{
-			"protocol": *rule.Protocol,
-			"source":   *rule.Source,
-		}
-		if rule.SourceType != "" {
-			ruleMap["sourceType"] = string(rule.SourceType)
-		}
-		if rule.IsStateless != nil && *rule.IsStateless {
-			ruleMap["isStateless"] = *rule.IsStateless
-		}
-		if rule.Description != nil {
-			ruleMap["description"] = *rule.Description
-		}
-		if rule.TcpOptions != nil {
-			tcpOpts := map[string]any{}
-			if rule.TcpOptions.DestinationPortRange != nil {
-				tcpOpts["destinationPortRange"] = map[string]any{
-					"min": *rule.TcpOptions.DestinationPortRange.Min,
-					"max": *rule.TcpOptions.DestinationPortRange.Max,
-				}
-			}
-			if rule.TcpOptions.SourcePortRange != nil {
-				tcpOpts["sourcePortRange"] = map[string]any{
-					"min": *rule.TcpOptions.SourcePortRange.Min,
-					"max": *rule.TcpOptions.SourcePortRange.Max,
-				}
-			}
-			if len(tcpOpts) > 0 {
-				ruleMap["tcpOptions"] = tcpOpts
+		result[i] = serializeIngressRule(rule)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return securityRuleKey(result[i], "source") < securityRuleKey(result[j], "source")
+	})
+	return result
+}
+
+func serializeIngressRule(rule core.IngressSecurityRule) map[string]any {
+	ruleMap := map[string]any{
+		"protocol": *rule.Protocol,
+		"source":   *rule.Source,
+	}
+	if rule.SourceType != "" {
+		ruleMap["sourceType"] = string(rule.SourceType)
+	}
+	if rule.IsStateless != nil && *rule.IsStateless {
+		ruleMap["isStateless"] = *rule.IsStateless
+	}
+	if rule.Description != nil {
+		ruleMap["description"] = *rule.Description
+	}
+	if rule.TcpOptions != nil {
+		tcpOpts := map[string]any{}
+		if rule.TcpOptions.DestinationPortRange != nil {
+			tcpOpts["destinationPortRange"] = map[string]any{
+				"min": *rule.TcpOptions.DestinationPortRange.Min,
+				"max": *rule.TcpOptions.DestinationPortRange.Max,
 			}
 		}
-		if rule.UdpOptions != nil {
-			udpOpts := map[string]any{}
-			if rule.UdpOptions.DestinationPortRange != nil {
-				udpOpts["destinationPortRange"] = map[string]any{
-					"min": *rule.UdpOptions.DestinationPortRange.Min,
-					"max": *rule.UdpOptions.DestinationPortRange.Max,
-				}
-			}
-			if rule.UdpOptions.SourcePortRange != nil {
-				udpOpts["sourcePortRange"] = map[string]any{
-					"min": *rule.UdpOptions.SourcePortRange.Min,
-					"max": *rule.UdpOptions.SourcePortRange.Max,
-				}
-			}
-			if len(udpOpts) > 0 {
-				ruleMap["udpOptions"] = udpOpts
+		if rule.TcpOptions.SourcePortRange != nil {
+			tcpOpts["sourcePortRange"] = map[string]any{
+				"min": *rule.TcpOptions.SourcePortRange.Min,
+				"max": *rule.TcpOptions.SourcePortRange.Max,
 			}
 		}
-		if rule.IcmpOptions != nil {
-			icmpOpts := map[string]any{
-				"type": *rule.IcmpOptions.Type,
+		if len(tcpOpts) > 0 {
+			ruleMap["tcpOptions"] = tcpOpts
+		}
+	}
+	if rule.UdpOptions != nil {
+		udpOpts := map[string]any{}
+		if rule.UdpOptions.DestinationPortRange != nil {
+			udpOpts["destinationPortRange"] = map[string]any{
+				"min": *rule.UdpOptions.DestinationPortRange.Min,
+				"max": *rule.UdpOptions.DestinationPortRange.Max,
 			}
-			if rule.IcmpOptions.Code != nil {
-				icmpOpts["code"] = *rule.IcmpOptions.Code
+		}
+		if rule.UdpOptions.SourcePortRange != nil {
+			udpOpts["sourcePortRange"] = map[string]any{
+				"min": *rule.UdpOptions.SourcePortRange.Min,
+				"max": *rule.UdpOptions.SourcePortRange.Max,
 			}
-			ruleMap["icmpOptions"] = icmpOpts
 		}
-		result[i] = ruleMap
+		if len(udpOpts) > 0 {
+			ruleMap["udpOptions"] = udpOpts
+		}
 	}
-	return result
+	if rule.IcmpOptions != nil && rule.IcmpOptions.Type != nil {
+		icmpOpts := map[string]any{
+			"type": *rule.IcmpOptions.Type,
+		}
+		if rule.IcmpOptions.Code != nil {
+			icmpOpts["code"] = *rule.IcmpOptions.Code
+		}
+		ruleMap["icmpOptions"] = icmpOpts
+	}
+	return ruleMap
 }
 
 // serializeEgressRules converts egress rules to maps with camelCase keys to match Pkl schema.
@@ -352,67 +403,74 @@ func serializeIngressRules(rules []core.IngressSecurityRule) []map[string]any {
 func serializeEgressRules(rules []core.EgressSecurityRule) []map[string]any {
 	result := make([]map[string]any, len(rules))
 	for i, rule := range rules {
-		ruleMap := map[string]any{
-			"protocol":    *rule.Protocol,
-			"destination": *rule.Destination,
-		}
-		if rule.DestinationType != "" {
-			ruleMap["destinationType"] = string(rule.DestinationType)
-		}
-		if rule.IsStateless != nil && *rule.IsStateless {
-			ruleMap["isStateless"] = *rule.IsStateless
-		}
-		if rule.Description != nil {
-			ruleMap["description"] = *rule.Description
-		}
-		if rule.TcpOptions != nil {
-			tcpOpts := map[string]any{}
-			if rule.TcpOptions.DestinationPortRange != nil {
-				tcpOpts["destinationPortRange"] = map[string]any{
-					"min": *rule.TcpOptions.DestinationPortRange.Min,
-					"max": *rule.TcpOptions.DestinationPortRange.Max,
-				}
-			}
-			if rule.TcpOptions.SourcePortRange != nil {
-				tcpOpts["sourcePortRange"] = map[string]any{
-					"min": *rule.TcpOptions.SourcePortRange.Min,
-					"max": *rule.TcpOptions.SourcePortRange.Max,
-				}
-			}
-			if len(tcpOpts) > 0 {
-				ruleMap["tcpOptions"] = tcpOpts
+		result[i] = serializeEgressRule(rule)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return securityRuleKey(result[i], "destination") < securityRuleKey(result[j], "destination")
+	})
+	return result
+}
+
+func serializeEgressRule(rule core.EgressSecurityRule) map[string]any {
+	ruleMap := map[string]any{
+		"protocol":    *rule.Protocol,
+		"destination": *rule.Destination,
+	}
+	if rule.DestinationType != "" {
+		ruleMap["destinationType"] = string(rule.DestinationType)
+	}
+	if rule.IsStateless != nil && *rule.IsStateless {
+		ruleMap["isStateless"] = *rule.IsStateless
+	}
+	if rule.Description != nil {
+		ruleMap["description"] = *rule.Description
+	}
+	if rule.TcpOptions != nil {
+		tcpOpts := map[string]any{}
+		if rule.TcpOptions.DestinationPortRange != nil {
+			tcpOpts["destinationPortRange"] = map[string]any{
+				"min": *rule.TcpOptions.DestinationPortRange.Min,
+				"max": *rule.TcpOptions.DestinationPortRange.Max,
 			}
 		}
-		if rule.UdpOptions != nil {
-			udpOpts := map[string]any{}
-			if rule.UdpOptions.DestinationPortRange != nil {
-				udpOpts["destinationPortRange"] = map[string]any{
-					"min": *rule.UdpOptions.DestinationPortRange.Min,
-					"max": *rule.UdpOptions.DestinationPortRange.Max,
-				}
-			}
-			if rule.UdpOptions.SourcePortRange != nil {
-				udpOpts["sourcePortRange"] = map[string]any{
-					"min": *rule.UdpOptions.SourcePortRange.Min,
-					"max": *rule.UdpOptions.SourcePortRange.Max,
-				}
-			}
-			if len(udpOpts) > 0 {
-				ruleMap["udpOptions"] = udpOpts
+		if rule.TcpOptions.SourcePortRange != nil {
+			tcpOpts["sourcePortRange"] = map[string]any{
+				"min": *rule.TcpOptions.SourcePortRange.Min,
+				"max": *rule.TcpOptions.SourcePortRange.Max,
 			}
 		}
-		if rule.IcmpOptions != nil {
-			icmpOpts := map[string]any{
-				"type": *rule.IcmpOptions.Type,
+		if len(tcpOpts) > 0 {
+			ruleMap["tcpOptions"] = tcpOpts
+		}
+	}
+	if rule.UdpOptions != nil {
+		udpOpts := map[string]any{}
+		if rule.UdpOptions.DestinationPortRange != nil {
+			udpOpts["destinationPortRange"] = map[string]any{
+				"min": *rule.UdpOptions.DestinationPortRange.Min,
+				"max": *rule.UdpOptions.DestinationPortRange.Max,
 			}
-			if rule.IcmpOptions.Code != nil {
-				icmpOpts["code"] = *rule.IcmpOptions.Code
+		}
+		if rule.UdpOptions.SourcePortRange != nil {
+			udpOpts["sourcePortRange"] = map[string]any{
+				"min": *rule.UdpOptions.SourcePortRange.Min,
+				"max": *rule.UdpOptions.SourcePortRange.Max,
 			}
-			ruleMap["icmpOptions"] = icmpOpts
 		}
-		result[i] = ruleMap
+		if len(udpOpts) > 0 {
+			ruleMap["udpOptions"] = udpOpts
+		}
 	}
-	return result
+	if rule.IcmpOptions != nil && rule.IcmpOptions.Type != nil {
+		icmpOpts := map[string]any{
+			"type": *rule.IcmpOptions.Type,
+		}
+		if rule.IcmpOptions.Code != nil {
+			icmpOpts["code"] = *rule.IcmpOptions.Code
+		}
+		ruleMap["icmpOptions"] = icmpOpts
+	}
+	return ruleMap
 }
 
 func (p *SecurityListProvisioner) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
@@ -454,6 +512,7 @@ func (p *SecurityListProvisioner) Create(ctx context.Context, request *resource.
 	}
 
 	createReq := core.CreateSecurityListRequest{
+		OpcRetryToken:             common.String(util.CreateRetryToken(request)),
 		CreateSecurityListDetails: createDetails,
 	}
 
@@ -474,17 +533,9 @@ func (p *SecurityListProvisioner) Create(ctx context.Context, request *resource.
 	}, nil
 }
 
-func (p *SecurityListProvisioner) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
-	client, err := p.getSvc()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
-	}
-
-	props, err := util.ApplyPatchDocument(ctx, request, p.Read)
-	if err != nil {
-		return nil, err
-	}
-
+// securityListUpdateDetails builds the mutable part of a security list from its
+// resolved properties. DefaultSecurityList uses it for both Create and Update.
+func securityListUpdateDetails(props map[string]any) (core.UpdateSecurityListDetails, error) {
 	updateDetails := core.UpdateSecurityListDetails{}
 
 	if displayName, ok := util.ExtractString(props, "DisplayName"); ok {
@@ -494,7 +545,7 @@ func (p *SecurityListProvisioner) Update(ctx context.Context, request *resource.
 	if ingressRulesData, ok := props["IngressSecurityRules"]; ok {
 		ingressRules, err := parseIngressSecurityRules(ingressRulesData)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse IngressSecurityRules: %w", err)
+			return updateDetails, fmt.Errorf("failed to parse IngressSecurityRules: %w", err)
 		}
 		updateDetails.IngressSecurityRules = ingressRules
 	}
@@ -502,7 +553,7 @@ func (p *SecurityListProvisioner) Update(ctx context.Context, request *resource.
 	if egressRulesData, ok := props["EgressSecurityRules"]; ok {
 		egressRules, err := parseEgressSecurityRules(egressRulesData)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse EgressSecurityRules: %w", err)
+			return updateDetails, fmt.Errorf("failed to parse EgressSecurityRules: %w", err)
 		}
 		updateDetails.EgressSecurityRules = egressRules
 	}
@@ -515,6 +566,25 @@ func (p *SecurityListProvisioner) Update(ctx context.Context, request *resource.
 		updateDetails.DefinedTags = definedTags
 	}
 
+	return updateDetails, nil
+}
+
+func (p *SecurityListProvisioner) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
+	}
+
+	props, err := util.ApplyPatchDocument(ctx, request, p.Read)
+	if err != nil {
+		return nil, err
+	}
+
+	updateDetails, err := securityListUpdateDetails(props)
+	if err != nil {
+		return nil, err
+	}
+
 	updateReq := core.UpdateSecurityListRequest{
 		SecurityListId:            common.String(request.NativeID),
 		UpdateSecurityListDetails: updateDetails,
@@ -582,13 +652,7 @@ func (p *SecurityListProvisioner) Delete(ctx context.Context, request *resource.
 }
 
 func (p *SecurityListProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
-	return &resource.StatusResult{
-		ProgressResult: &resource.ProgressResult{
-			Operation:       resource.OperationCheckStatus,
-			OperationStatus: resource.OperationStatusSuccess,
-			RequestID:       request.RequestID,
-		},
-	}, nil
+	return util.SyncStatus(ctx, request, p.Read)
 }
 
 func (p *SecurityListProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
@@ -596,16 +660,21 @@ func (p *SecurityListProvisioner) Read(ctx context.Context, request *resource.Re
 	if err != nil {
 		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
 	}
+	return readSecurityList(ctx, client, request, "OCI::Core::SecurityList")
+}
 
+// readSecurityList reads a security list and reports it as resourceType, which
+// is either OCI::Core::SecurityList or OCI::Core::DefaultSecurityList.
+func readSecurityList(ctx context.Context, client *core.VirtualNetworkClient, request *resource.ReadRequest, resourceType string) (*resource.ReadResult, error) {
 	getReq := core.GetSecurityListRequest{
 		SecurityListId: common.String(request.NativeID),
 	}
 
 	resp, err := client.GetSecurityList(ctx, getReq)
 	if err != nil {
-		if serviceErr, ok := common.IsServiceError(err); ok && serviceErr.GetHTTPStatusCode() == 404 {
+		if util.IsNotFound(err) {
 			return &resource.ReadResult{
-				ResourceType: "OCI::Core::SecurityList",
+				ResourceType: resourceType,
 				ErrorCode:    resource.OperationErrorCodeNotFound,
 			}, nil
 		}
@@ -614,7 +683,7 @@ func (p *SecurityListProvisioner) Read(ctx context.Context, request *resource.Re
 
 	if util.IsTerminal(string(resp.LifecycleState)) {
 		return &resource.ReadResult{
-			ResourceType: "OCI::Core::SecurityList",
+			ResourceType: resourceType,
 			ErrorCode:    resource.OperationErrorCodeNotFound,
 		}, nil
 	}
@@ -634,7 +703,7 @@ func (p *SecurityListProvisioner) Read(ctx context.Context, request *resource.Re
 		props["FreeformTags"] = util.FreeformTagsToList(resp.FreeformTags)
 	}
 	if resp.DefinedTags != nil {
-		props["DefinedTags"] = util.DefinedTagsToList(resp.DefinedTags)
+		props["DefinedTags"] = util.DefinedTagsToList(resp.DefinedTags, config.FromTargetConfig(request.TargetConfig).HiddenTagNamespaces())
 	}
 
 	propBytes, err := json.Marshal(props)
@@ -643,7 +712,7 @@ func (p *SecurityListProvisioner) Read(ctx context.Context, request *resource.Re
 	}
 
 	return &resource.ReadResult{
-		ResourceType: "OCI::Core::SecurityList",
+		ResourceType: resourceType,
 		Properties:   string(propBytes),
 	}, nil
 }
@@ -654,27 +723,34 @@ func (p *SecurityListProvisioner) List(ctx context.Context, request *resource.Li
 		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
 	}
 
-	compartmentId, ok := request.AdditionalProperties["CompartmentId"]
+	compartmentId, ok := util.ListCompartmentId(request)
 	if !ok {
 		return nil, fmt.Errorf("CompartmentId is required for listing SecurityLists")
 	}
 
 	listReq := core.ListSecurityListsRequest{
 		CompartmentId: common.String(compartmentId),
+		Limit:         util.ListPageSize(request.TargetConfig),
 	}
 
 	if vcnId, ok := request.AdditionalProperties["VcnId"]; ok {
 		listReq.VcnId = common.String(vcnId)
 	}
 
-	resp, err := client.ListSecurityLists(ctx, listReq)
+	nativeIDs, err := util.ListAllPages(func(page *string) ([]string, *string, error) {
+		listReq.Page = page
+		resp, err := client.ListSecurityLists(ctx, listReq)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list SecurityLists: %w", err)
+		}
+		ids := make([]string, 0, len(resp.Items))
+		for _, sl := range resp.Items {
+			ids = append(ids, *sl.Id)
+		}
+		return ids, resp.OpcNextPage, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list SecurityLists: %w", err)
-	}
-
-	nativeIDs := make([]string, 0, len(resp.Items))
-	for _, sl := range resp.Items {
-		nativeIDs = append(nativeIDs, *sl.Id)
+		return nil, err
 	}
 
 	return &resource.ListResult{
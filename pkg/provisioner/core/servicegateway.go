@@ -12,6 +12,7 @@ import (
 	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/oracle/oci-go-sdk/v65/core"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
@@ -25,6 +26,7 @@ var _ provisioner.Provisioner = &ServiceGatewayProvisioner{}
 
 func init() {
 	provisioner.Register("OCI::Core::ServiceGateway", NewServiceGatewayProvisioner)
+	provisioner.DependsOn("OCI::Core::ServiceGateway", "OCI::Identity::Compartment", "OCI::Core::VCN", "OCI::Core::RouteTable")
 }
 
 func NewServiceGatewayProvisioner(clients *client.Clients) provisioner.Provisioner {
@@ -42,33 +44,9 @@ func (p *ServiceGatewayProvisioner) Create(ctx context.Context, request *resourc
 		return nil, fmt.Errorf("failed to parse properties: %w", err)
 	}
 
-	// Extract services array
-	services, ok := props["Services"].([]any)
-	if !ok {
-		return nil, fmt.Errorf("services is required and must be an array")
-	}
-
-	serviceList := make([]core.ServiceIdRequestDetails, 0, len(services))
-	for _, svc := range services {
-		svcMap, ok := svc.(map[string]any)
-		if !ok {
-			return nil, fmt.Errorf("each service must be an object with serviceId")
-		}
-		// ServiceGatewayService is a plain class, so fields stay camelCase (not transformed)
-		// Try both camelCase and PascalCase for compatibility
-		serviceId, ok := svcMap["serviceId"].(string)
-		if !ok {
-			if serviceIdUpper, okUpper := svcMap["ServiceId"].(string); okUpper {
-				serviceId = serviceIdUpper
-				ok = true
-			}
-		}
-		if !ok {
-			return nil, fmt.Errorf("serviceId is required for each service")
-		}
-		serviceList = append(serviceList, core.ServiceIdRequestDetails{
-			ServiceId: common.String(serviceId),
-		})
+	serviceList, err := parseServiceGatewayServices(ctx, client, props)
+	if err != nil {
+		return nil, err
 	}
 
 	createDetails := core.CreateServiceGatewayDetails{
@@ -88,6 +66,7 @@ func (p *ServiceGatewayProvisioner) Create(ctx context.Context, request *resourc
 	}
 
 	createReq := core.CreateServiceGatewayRequest{
+		OpcRetryToken:               common.String(util.CreateRetryToken(request)),
 		CreateServiceGatewayDetails: createDetails,
 	}
 
@@ -122,28 +101,10 @@ func (p *ServiceGatewayProvisioner) Update(ctx context.Context, request *resourc
 	updateDetails := core.UpdateServiceGatewayDetails{}
 
 	// Services can be updated
-	if services, ok := props["Services"].([]any); ok {
-		serviceList := make([]core.ServiceIdRequestDetails, 0, len(services))
-		for _, svc := range services {
-			svcMap, ok := svc.(map[string]any)
-			if !ok {
-				return nil, fmt.Errorf("each service must be an object with serviceId")
-			}
-			// ServiceGatewayService is a plain class, so fields stay camelCase
-			// Try both camelCase and PascalCase for compatibility
-			serviceId, ok := svcMap["serviceId"].(string)
-			if !ok {
-				if serviceIdUpper, okUpper := svcMap["ServiceId"].(string); okUpper {
-					serviceId = serviceIdUpper
-					ok = true
-				}
-			}
-			if !ok {
-				return nil, fmt.Errorf("serviceId is required for each service")
-			}
-			serviceList = append(serviceList, core.ServiceIdRequestDetails{
-				ServiceId: common.String(serviceId),
-			})
+	if _, ok := props["Services"].([]any); ok {
+		serviceList, err := parseServiceGatewayServices(ctx, client, props)
+		if err != nil {
+			return nil, err
 		}
 		updateDetails.Services = serviceList
 	}
@@ -226,13 +187,7 @@ func (p *ServiceGatewayProvisioner) Delete(ctx context.Context, request *resourc
 }
 
 func (p *ServiceGatewayProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
-	return &resource.StatusResult{
-		ProgressResult: &resource.ProgressResult{
-			Operation:       resource.OperationCheckStatus,
-			OperationStatus: resource.OperationStatusSuccess,
-			RequestID:       request.RequestID,
-		},
-	}, nil
+	return util.SyncStatus(ctx, request, p.Read)
 }
 
 func (p *ServiceGatewayProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
@@ -247,7 +202,7 @@ func (p *ServiceGatewayProvisioner) Read(ctx context.Context, request *resource.
 
 	resp, err := client.GetServiceGateway(ctx, getReq)
 	if err != nil {
-		if serviceErr, ok := common.IsServiceError(err); ok && serviceErr.GetHTTPStatusCode() == 404 {
+		if util.IsNotFound(err) {
 			return &resource.ReadResult{
 				ResourceType: "OCI::Core::ServiceGateway",
 				ErrorCode:    resource.OperationErrorCodeNotFound,
@@ -296,7 +251,7 @@ func (p *ServiceGatewayProvisioner) Read(ctx context.Context, request *resource.
 		props["FreeformTags"] = util.FreeformTagsToList(resp.FreeformTags)
 	}
 	if resp.DefinedTags != nil {
-		props["DefinedTags"] = util.DefinedTagsToList(resp.DefinedTags)
+		props["DefinedTags"] = util.DefinedTagsToList(resp.DefinedTags, config.FromTargetConfig(request.TargetConfig).HiddenTagNamespaces())
 	}
 
 	propBytes, err := json.Marshal(props)
@@ -316,13 +271,14 @@ func (p *ServiceGatewayProvisioner) List(ctx context.Context, request *resource.
 		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
 	}
 
-	compartmentId, ok := request.AdditionalProperties["CompartmentId"]
+	compartmentId, ok := util.ListCompartmentId(request)
 	if !ok {
 		return nil, fmt.Errorf("CompartmentId is required for listing ServiceGateways")
 	}
 
 	listReq := core.ListServiceGatewaysRequest{
 		CompartmentId: common.String(compartmentId),
+		Limit:         util.ListPageSize(request.TargetConfig),
 	}
 
 	// Optional: Filter by VcnId
@@ -330,17 +286,58 @@ func (p *ServiceGatewayProvisioner) List(ctx context.Context, request *resource.
 		listReq.VcnId = common.String(vcnId)
 	}
 
-	resp, err := client.ListServiceGateways(ctx, listReq)
+	nativeIDs, err := util.ListAllPages(func(page *string) ([]string, *string, error) {
+		listReq.Page = page
+		resp, err := client.ListServiceGateways(ctx, listReq)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list ServiceGateways: %w", err)
+		}
+		ids := make([]string, 0, len(resp.Items))
+		for _, sg := range resp.Items {
+			ids = append(ids, *sg.Id)
+		}
+		return ids, resp.OpcNextPage, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list ServiceGateways: %w", err)
-	}
-
-	nativeIDs := make([]string, 0, len(resp.Items))
-	for _, sg := range resp.Items {
-		nativeIDs = append(nativeIDs, *sg.Id)
+		return nil, err
 	}
 
 	return &resource.ListResult{
 		NativeIDs: nativeIDs,
 	}, nil
 }
+
+// parseServiceGatewayServices reads Services, resolving each serviceName to its
+// OCID in the client's region. ServiceGatewayService is a plain class, so its
+// fields stay camelCase; PascalCase is accepted too for compatibility.
+func parseServiceGatewayServices(ctx context.Context, client *core.VirtualNetworkClient, props map[string]any) ([]core.ServiceIdRequestDetails, error) {
+	services, ok := props["Services"].([]any)
+	if !ok {
+		return nil, fmt.Errorf("services is required and must be an array")
+	}
+
+	serviceList := make([]core.ServiceIdRequestDetails, 0, len(services))
+	for _, svc := range services {
+		svcMap, ok := svc.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("each service must be an object with serviceId or serviceName")
+		}
+		serviceId, hasId := extractStringField(svcMap, "serviceId", "ServiceId")
+		serviceName, hasName := extractStringField(svcMap, "serviceName", "ServiceName")
+		switch {
+		case hasId:
+		case hasName:
+			resolved, err := util.ResolveServiceId(ctx, client, serviceName)
+			if err != nil {
+				return nil, err
+			}
+			serviceId = resolved
+		default:
+			return nil, fmt.Errorf("serviceId or serviceName is required for each service")
+		}
+		serviceList = append(serviceList, core.ServiceIdRequestDetails{
+			ServiceId: common.String(serviceId),
+		})
+	}
+	return serviceList, nil
+}
@@ -8,34 +8,57 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/netip"
 
 	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/oracle/oci-go-sdk/v65/identity"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
 )
 
 type SubnetProvisioner struct {
-	clients *client.Clients
-	svc     *core.VirtualNetworkClient // nil until first use; injected in tests
+	clients  *client.Clients
+	svc      *core.VirtualNetworkClient // nil until first use; injected in tests
+	identity *identity.IdentityClient   // nil until first use; injected in tests
 }
 
-var _ provisioner.Provisioner = &SubnetProvisioner{}
+var (
+	_ provisioner.Provisioner = &SubnetProvisioner{}
+	_ provisioner.Immutable   = &SubnetProvisioner{}
+	_ provisioner.Equivalent  = &SubnetProvisioner{}
+)
 
 func init() {
 	provisioner.Register("OCI::Core::Subnet", NewSubnetProvisioner)
+	provisioner.DependsOn("OCI::Core::Subnet",
+		"OCI::Identity::Compartment",
+		"OCI::Core::VCN",
+		"OCI::Core::RouteTable",
+		"OCI::Core::SecurityList",
+		"OCI::Core::DhcpOptions",
+	)
 }
 
 func NewSubnetProvisioner(clients *client.Clients) provisioner.Provisioner {
 	return &SubnetProvisioner{clients: clients}
 }
 
-// NewSubnetProvisionerWithSvc constructs a provisioner with a pre-built SDK client,
-// for use in tests that point the client at an httptest server.
-func NewSubnetProvisionerWithSvc(svc *core.VirtualNetworkClient) *SubnetProvisioner {
-	return &SubnetProvisioner{svc: svc}
+// NewSubnetProvisionerWithSvc constructs a provisioner with pre-built SDK clients,
+// for use in tests that point the clients at an httptest server. identity
+// resolves short availability domain names and may be nil when a test uses
+// full ones.
+func NewSubnetProvisionerWithSvc(svc *core.VirtualNetworkClient, identity *identity.IdentityClient) *SubnetProvisioner {
+	return &SubnetProvisioner{svc: svc, identity: identity}
+}
+
+// ImmutableFields lists what UpdateSubnet can't change, AvailabilityDomain
+// included: a regional subnet can't become AD-specific or the other way round.
+func (p *SubnetProvisioner) ImmutableFields() []string {
+	return []string{"VcnId", "CidrBlock", "AvailabilityDomain", "DnsLabel", "ProhibitPublicIpOnVnic", "ProhibitInternetIngress"}
 }
 
 func (p *SubnetProvisioner) getSvc() (*core.VirtualNetworkClient, error) {
@@ -45,6 +68,23 @@ func (p *SubnetProvisioner) getSvc() (*core.VirtualNetworkClient, error) {
 	return p.clients.GetVirtualNetworkClient()
 }
 
+func (p *SubnetProvisioner) getIdentity() (*identity.IdentityClient, error) {
+	if p.identity != nil {
+		return p.identity, nil
+	}
+	return p.clients.GetIdentityClient()
+}
+
+// SameValue compares AvailabilityDomain in resolved form, so a short name in
+// the desired state matches the full name Read reports.
+func (p *SubnetProvisioner) SameValue(ctx context.Context, field string, live map[string]any, desired any) bool {
+	if field != "AvailabilityDomain" {
+		return false
+	}
+	compartmentId, _ := live["CompartmentId"].(string)
+	return sameAvailabilityDomain(ctx, p.getIdentity, compartmentId, live[field], desired)
+}
+
 func (p *SubnetProvisioner) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
 	client, err := p.getSvc()
 	if err != nil {
@@ -63,7 +103,11 @@ func (p *SubnetProvisioner) Create(ctx context.Context, request *resource.Create
 	}
 
 	if ad, ok := util.ExtractString(props, "AvailabilityDomain"); ok {
-		createDetails.AvailabilityDomain = common.String(ad)
+		resolved, err := resolveAvailabilityDomain(ctx, p.getIdentity, *createDetails.CompartmentId, ad)
+		if err != nil {
+			return nil, err
+		}
+		createDetails.AvailabilityDomain = common.String(resolved)
 	}
 	if displayName, ok := util.ExtractString(props, "DisplayName"); ok {
 		createDetails.DisplayName = common.String(displayName)
@@ -96,7 +140,21 @@ func (p *SubnetProvisioner) Create(ctx context.Context, request *resource.Create
 		createDetails.DefinedTags = definedTags
 	}
 
+	if problem, err := checkSubnetCidr(ctx, client, config.FromTargetConfig(request.TargetConfig), createDetails); err != nil {
+		return nil, err
+	} else if problem != "" {
+		return &resource.CreateResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationCreate,
+				OperationStatus: resource.OperationStatusFailure,
+				ErrorCode:       resource.OperationErrorCodeInvalidRequest,
+				StatusMessage:   problem,
+			},
+		}, nil
+	}
+
 	createReq := core.CreateSubnetRequest{
+		OpcRetryToken:       common.String(util.CreateRetryToken(request)),
 		CreateSubnetDetails: createDetails,
 	}
 
@@ -218,13 +276,7 @@ func (p *SubnetProvisioner) Delete(ctx context.Context, request *resource.Delete
 }
 
 func (p *SubnetProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
-	return &resource.StatusResult{
-		ProgressResult: &resource.ProgressResult{
-			Operation:       resource.OperationCheckStatus,
-			OperationStatus: resource.OperationStatusSuccess,
-			RequestID:       request.RequestID,
-		},
-	}, nil
+	return util.SyncStatus(ctx, request, p.Read)
 }
 
 func (p *SubnetProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
@@ -239,7 +291,7 @@ func (p *SubnetProvisioner) Read(ctx context.Context, request *resource.ReadRequ
 
 	resp, err := client.GetSubnet(ctx, getReq)
 	if err != nil {
-		if serviceErr, ok := common.IsServiceError(err); ok && serviceErr.GetHTTPStatusCode() == 404 {
+		if util.IsNotFound(err) {
 			return &resource.ReadResult{
 				ResourceType: "OCI::Core::Subnet",
 				ErrorCode:    resource.OperationErrorCodeNotFound,
@@ -283,6 +335,9 @@ func (p *SubnetProvisioner) Read(ctx context.Context, request *resource.ReadRequ
 	if resp.SecurityListIds != nil {
 		props["SecurityListIds"] = resp.SecurityListIds
 	}
+	if count, ok := usableIpCount(*resp.CidrBlock); ok {
+		props["UsableIpCount"] = count
+	}
 	if resp.VirtualRouterIp != nil {
 		props["VirtualRouterIp"] = *resp.VirtualRouterIp
 	}
@@ -299,7 +354,7 @@ func (p *SubnetProvisioner) Read(ctx context.Context, request *resource.ReadRequ
 		props["FreeformTags"] = util.FreeformTagsToList(resp.FreeformTags)
 	}
 	if resp.DefinedTags != nil {
-		props["DefinedTags"] = util.DefinedTagsToList(resp.DefinedTags)
+		props["DefinedTags"] = util.DefinedTagsToList(resp.DefinedTags, config.FromTargetConfig(request.TargetConfig).HiddenTagNamespaces())
 	}
 
 	propBytes, err := json.Marshal(props)
@@ -319,13 +374,14 @@ func (p *SubnetProvisioner) List(ctx context.Context, request *resource.ListRequ
 		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
 	}
 
-	compartmentId, ok := request.AdditionalProperties["CompartmentId"]
+	compartmentId, ok := util.ListCompartmentId(request)
 	if !ok {
 		return nil, fmt.Errorf("CompartmentId is required for listing Subnets")
 	}
 
 	listReq := core.ListSubnetsRequest{
 		CompartmentId: common.String(compartmentId),
+		Limit:         util.ListPageSize(request.TargetConfig),
 	}
 
 	// Optional: Filter by VcnId
@@ -333,17 +389,99 @@ func (p *SubnetProvisioner) List(ctx context.Context, request *resource.ListRequ
 		listReq.VcnId = common.String(vcnId)
 	}
 
-	resp, err := client.ListSubnets(ctx, listReq)
+	nativeIDs, err := util.ListAllPages(func(page *string) ([]string, *string, error) {
+		listReq.Page = page
+		resp, err := client.ListSubnets(ctx, listReq)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list Subnets: %w", err)
+		}
+		ids := make([]string, 0, len(resp.Items))
+		for _, subnet := range resp.Items {
+			ids = append(ids, *subnet.Id)
+		}
+		return ids, resp.OpcNextPage, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list Subnets: %w", err)
-	}
-
-	nativeIDs := make([]string, 0, len(resp.Items))
-	for _, subnet := range resp.Items {
-		nativeIDs = append(nativeIDs, *subnet.Id)
+		return nil, err
 	}
 
 	return &resource.ListResult{
 		NativeIDs: nativeIDs,
 	}, nil
 }
+
+// checkSubnetCidr looks up the VCN and, unless the target skips it, the VCN's
+// subnets in the same compartment, and returns why the new subnet's CidrBlock
+// can't be used there, or "" when it can. OCI rejects both cases too, but with
+// a message that doesn't say which CIDR is at fault.
+func checkSubnetCidr(ctx context.Context, client *core.VirtualNetworkClient, cfg *config.Config, details core.CreateSubnetDetails) (string, error) {
+	vcn, err := client.GetVcn(ctx, core.GetVcnRequest{VcnId: details.VcnId})
+	if err != nil {
+		// Let CreateSubnet report a missing VCN the usual way
+		if util.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read VCN %s: %w", *details.VcnId, err)
+	}
+
+	var existing []core.Subnet
+	if !cfg.SkipSubnetOverlapCheck {
+		req := core.ListSubnetsRequest{CompartmentId: details.CompartmentId, VcnId: details.VcnId}
+		existing, err = util.ListAllPages(func(page *string) ([]core.Subnet, *string, error) {
+			req.Page = page
+			resp, err := client.ListSubnets(ctx, req)
+			if err != nil {
+				return nil, nil, err
+			}
+			return resp.Items, resp.OpcNextPage, nil
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to list Subnets of VCN %s: %w", *details.VcnId, err)
+		}
+	}
+
+	return subnetCidrProblem(*details.CidrBlock, vcn.CidrBlocks, existing), nil
+}
+
+// subnetCidrProblem returns why cidrBlock can't hold a subnet in a VCN with
+// vcnCidrs alongside existing, or "" when it can.
+func subnetCidrProblem(cidrBlock string, vcnCidrs []string, existing []core.Subnet) string {
+	prefix, err := netip.ParsePrefix(cidrBlock)
+	if err != nil {
+		return fmt.Sprintf("CidrBlock %q is not a CIDR block such as \"10.0.1.0/24\"", cidrBlock)
+	}
+	prefix = prefix.Masked()
+
+	within := false
+	for _, cidr := range vcnCidrs {
+		vcnPrefix, err := netip.ParsePrefix(cidr)
+		if err == nil && vcnPrefix.Bits() <= prefix.Bits() && vcnPrefix.Contains(prefix.Addr()) {
+			within = true
+			break
+		}
+	}
+	if !within {
+		return fmt.Sprintf("CidrBlock %s is outside the VCN's CIDR blocks %v", cidrBlock, vcnCidrs)
+	}
+
+	for _, subnet := range existing {
+		if subnet.CidrBlock == nil || subnet.Id == nil || util.IsTerminal(string(subnet.LifecycleState)) {
+			continue
+		}
+		other, err := netip.ParsePrefix(*subnet.CidrBlock)
+		if err == nil && prefix.Overlaps(other) {
+			return fmt.Sprintf("CidrBlock %s overlaps %s of existing Subnet %s", cidrBlock, *subnet.CidrBlock, *subnet.Id)
+		}
+	}
+	return ""
+}
+
+// usableIpCount is how many addresses of an IPv4 subnet CIDR can be given to
+// VNICs: OCI reserves the first two and the last address of every subnet.
+func usableIpCount(cidrBlock string) (int64, bool) {
+	prefix, err := netip.ParsePrefix(cidrBlock)
+	if err != nil || !prefix.Addr().Is4() {
+		return 0, false
+	}
+	return max(int64(1)<<(32-prefix.Bits())-3, 0), true
+}
@@ -0,0 +1,59 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package core
+
+import (
+	"testing"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubnetCidrProblem(t *testing.T) {
+	vcnCidrs := []string{"10.0.0.0/16", "172.16.0.0/20"}
+	existing := []core.Subnet{
+		{Id: common.String("ocid1.subnet..public"), CidrBlock: common.String("10.0.1.0/24"), LifecycleState: core.SubnetLifecycleStateAvailable},
+		{Id: common.String("ocid1.subnet..gone"), CidrBlock: common.String("10.0.9.0/24"), LifecycleState: core.SubnetLifecycleStateTerminated},
+	}
+
+	tests := []struct {
+		name      string
+		cidrBlock string
+		want      string
+	}{
+		{name: "free block", cidrBlock: "10.0.2.0/24"},
+		{name: "second VCN block", cidrBlock: "172.16.4.0/24"},
+		{name: "terminated subnet's block", cidrBlock: "10.0.9.0/24"},
+		{name: "not a CIDR", cidrBlock: "10.0.2.0", want: `CidrBlock "10.0.2.0" is not a CIDR block`},
+		{name: "outside the VCN", cidrBlock: "192.168.0.0/24", want: "CidrBlock 192.168.0.0/24 is outside the VCN's CIDR blocks"},
+		{name: "larger than the VCN", cidrBlock: "10.0.0.0/8", want: "is outside the VCN's CIDR blocks"},
+		{name: "overlapping", cidrBlock: "10.0.0.0/23", want: "CidrBlock 10.0.0.0/23 overlaps 10.0.1.0/24 of existing Subnet ocid1.subnet..public"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := subnetCidrProblem(tt.cidrBlock, vcnCidrs, existing)
+			if tt.want == "" {
+				assert.Empty(t, got)
+				return
+			}
+			assert.Contains(t, got, tt.want)
+		})
+	}
+}
+
+func TestUsableIpCount(t *testing.T) {
+	count, ok := usableIpCount("10.0.1.0/24")
+	assert.True(t, ok)
+	assert.Equal(t, int64(253), count)
+
+	count, ok = usableIpCount("10.0.0.0/30")
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), count)
+
+	_, ok = usableIpCount("2001:db8::/64")
+	assert.False(t, ok)
+}
@@ -8,10 +8,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 
 	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/oracle/oci-go-sdk/v65/core"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
@@ -26,6 +30,7 @@ var _ provisioner.Provisioner = &VCNProvisioner{}
 
 func init() {
 	provisioner.Register("OCI::Core::VCN", NewVCNProvisioner)
+	provisioner.DependsOn("OCI::Core::VCN", "OCI::Identity::Compartment")
 }
 
 func NewVCNProvisioner(clients *client.Clients) provisioner.Provisioner {
@@ -78,11 +83,15 @@ func (p *VCNProvisioner) Create(ctx context.Context, request *resource.CreateReq
 	if freeformTags, ok := util.ExtractFreeformTags(props, "FreeformTags"); ok {
 		createDetails.FreeformTags = freeformTags
 	}
+	if cascade, _ := util.ExtractBool(props, "CascadeDelete"); cascade {
+		createDetails.FreeformTags = withCascadeDeleteTag(createDetails.FreeformTags, true)
+	}
 	if definedTags, ok := util.ExtractDefinedTags(props, "DefinedTags"); ok {
 		createDetails.DefinedTags = definedTags
 	}
 
 	createReq := core.CreateVcnRequest{
+		OpcRetryToken:    common.String(util.CreateRetryToken(request)),
 		CreateVcnDetails: createDetails,
 	}
 
@@ -119,8 +128,29 @@ func (p *VCNProvisioner) Update(ctx context.Context, request *resource.UpdateReq
 	if displayName, ok := util.ExtractString(props, "DisplayName"); ok {
 		updateDetails.DisplayName = common.String(displayName)
 	}
+	cascade, _ := util.ExtractBool(props, "CascadeDelete")
 	if freeformTags, ok := util.ExtractFreeformTags(props, "FreeformTags"); ok {
-		updateDetails.FreeformTags = freeformTags
+		updateDetails.FreeformTags = withCascadeDeleteTag(freeformTags, cascade)
+	} else {
+		// Without FreeformTags the update leaves tags alone, so only send them
+		// when CascadeDelete changes, keeping the ones the VCN already has. The
+		// update decorators have already read the VCN, so this costs no call.
+		current, err := util.SharedRead(ctx, &resource.ReadRequest{
+			NativeID:     request.NativeID,
+			ResourceType: request.ResourceType,
+			TargetConfig: request.TargetConfig,
+		}, p.Read)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read VCN before update: %w", err)
+		}
+		var currentProps map[string]any
+		if err := json.Unmarshal([]byte(current.Properties), &currentProps); err != nil {
+			return nil, fmt.Errorf("failed to parse VCN properties: %w", err)
+		}
+		if currentCascade, _ := util.ExtractBool(currentProps, "CascadeDelete"); currentCascade != cascade {
+			currentTags, _ := util.ExtractFreeformTags(currentProps, "FreeformTags")
+			updateDetails.FreeformTags = withCascadeDeleteTag(currentTags, cascade)
+		}
 	}
 	if definedTags, ok := util.ExtractDefinedTags(props, "DefinedTags"); ok {
 		updateDetails.DefinedTags = definedTags
@@ -173,36 +203,105 @@ func (p *VCNProvisioner) Delete(ctx context.Context, request *resource.DeleteReq
 		}, nil
 	}
 
-	deleteReq := core.DeleteVcnRequest{
-		VcnId: common.String(request.NativeID),
+	var vcnProps map[string]any
+	if err := json.Unmarshal([]byte(readRes.Properties), &vcnProps); err != nil {
+		return nil, fmt.Errorf("failed to parse VCN properties: %w", err)
+	}
+	if cascade, _ := util.ExtractBool(vcnProps, "CascadeDelete"); cascade {
+		progress, err := cascadeDeleteVCN(ctx, client, request.NativeID, readRes.Properties)
+		if err != nil {
+			return nil, err
+		}
+		return &resource.DeleteResult{ProgressResult: progress}, nil
 	}
 
-	_, err = client.DeleteVcn(ctx, deleteReq)
+	progress, err := deleteVCN(ctx, client, request.NativeID, readRes.Properties)
 	if err != nil {
-		if result, handleErr := util.HandleDeleteError(err, "OCI::Core::VCN", request.NativeID, "OCI::Core::VCN"); result != nil {
-			return result, handleErr
+		return nil, err
+	}
+	return &resource.DeleteResult{ProgressResult: progress}, nil
+}
+
+// cascadeDeleteRequest is the second part of the {vcnId}/cascade-delete
+// RequestID a cascade delete reports while the VCN's children terminate.
+const cascadeDeleteRequest = "cascade-delete"
+
+// cascadeDeleteVCN runs one step of a CascadeDelete Delete: it deletes the next
+// stage of the VCN's children and, while any of them is still around, reports
+// InProgress with a {vcnId}/cascade-delete RequestID for Status to carry on
+// from. Once every child has terminated it deletes the VCN.
+func cascadeDeleteVCN(ctx context.Context, client *core.VirtualNetworkClient, vcnId string, vcnProperties string) (*resource.ProgressResult, error) {
+	done, err := cascadeDeleteVCNChildren(ctx, client, vcnId, vcnProperties)
+	if err != nil {
+		if result, handleErr := util.HandleDeleteError(err, "OCI::Core::VCN", vcnId, "OCI::Core::VCN"); result != nil {
+			return result.ProgressResult, handleErr
+		}
+		return nil, fmt.Errorf("failed to delete VCN dependents: %w", err)
+	}
+	if !done {
+		return &resource.ProgressResult{
+			Operation:       resource.OperationDelete,
+			OperationStatus: resource.OperationStatusInProgress,
+			NativeID:        vcnId,
+			RequestID:       util.EncodeCompositeID(vcnId, cascadeDeleteRequest),
+		}, nil
+	}
+	return deleteVCN(ctx, client, vcnId, vcnProperties)
+}
+
+// deleteVCN deletes the VCN itself. A conflict lists the children that still
+// block it.
+func deleteVCN(ctx context.Context, client *core.VirtualNetworkClient, vcnId string, vcnProperties string) (*resource.ProgressResult, error) {
+	_, err := client.DeleteVcn(ctx, core.DeleteVcnRequest{VcnId: common.String(vcnId)})
+	if err != nil {
+		if result, handleErr := util.HandleDeleteError(err, "OCI::Core::VCN", vcnId, "OCI::Core::VCN"); result != nil {
+			if result.ProgressResult.ErrorCode == resource.OperationErrorCodeResourceConflict {
+				if blocking := findVCNDependents(ctx, client, vcnId, vcnProperties); len(blocking) > 0 {
+					result.ProgressResult.StatusMessage = fmt.Sprintf("%s; VCN still contains %s, delete these first or set cascadeDelete on the VCN",
+						result.ProgressResult.StatusMessage, strings.Join(blocking, ", "))
+				}
+			}
+			return result.ProgressResult, handleErr
 		}
 		return nil, fmt.Errorf("failed to delete VCN: %w", err)
 	}
 
-	return &resource.DeleteResult{
-		ProgressResult: &resource.ProgressResult{
-			Operation:       resource.OperationDelete,
-			OperationStatus: resource.OperationStatusSuccess,
-			NativeID:        request.NativeID,
-		},
+	return &resource.ProgressResult{
+		Operation:       resource.OperationDelete,
+		OperationStatus: resource.OperationStatusSuccess,
+		NativeID:        vcnId,
 	}, nil
 }
 
 func (p *VCNProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
-	// VCN operations are synchronous, no status check needed
-	return &resource.StatusResult{
-		ProgressResult: &resource.ProgressResult{
-			Operation:       resource.OperationCheckStatus,
-			OperationStatus: resource.OperationStatusSuccess,
-			RequestID:       request.RequestID,
-		},
-	}, nil
+	parts, err := util.DecodeCompositeID(request.RequestID, 2)
+	if err != nil || parts[1] != cascadeDeleteRequest {
+		return util.SyncStatus(ctx, request, p.Read)
+	}
+
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
+	}
+	readRes, err := p.Read(ctx, &resource.ReadRequest{NativeID: parts[0], TargetConfig: request.TargetConfig})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read VCN during cascade delete: %w", err)
+	}
+	if readRes.ErrorCode == resource.OperationErrorCodeNotFound {
+		return &resource.StatusResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationDelete,
+				OperationStatus: resource.OperationStatusSuccess,
+				NativeID:        parts[0],
+			},
+		}, nil
+	}
+
+	progress, err := cascadeDeleteVCN(ctx, client, parts[0], readRes.Properties)
+	if err != nil {
+		return nil, err
+	}
+	return &resource.StatusResult{ProgressResult: progress}, nil
 }
 
 func (p *VCNProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
@@ -218,7 +317,7 @@ func (p *VCNProvisioner) Read(ctx context.Context, request *resource.ReadRequest
 	resp, err := client.GetVcn(ctx, getReq)
 	if err != nil {
 		// Check if not found
-		if serviceErr, ok := common.IsServiceError(err); ok && serviceErr.GetHTTPStatusCode() == 404 {
+		if util.IsNotFound(err) {
 			return &resource.ReadResult{
 				ResourceType: "OCI::Core::VCN",
 				ErrorCode:    resource.OperationErrorCodeNotFound,
@@ -264,10 +363,15 @@ func (p *VCNProvisioner) Read(ctx context.Context, request *resource.ReadRequest
 		props["DefaultSecurityListId"] = *resp.DefaultSecurityListId
 	}
 	if resp.FreeformTags != nil {
-		props["FreeformTags"] = util.FreeformTagsToList(resp.FreeformTags)
+		tags := maps.Clone(resp.FreeformTags)
+		if tags[cascadeDeleteTag] == "true" {
+			props["CascadeDelete"] = true
+		}
+		delete(tags, cascadeDeleteTag)
+		props["FreeformTags"] = util.FreeformTagsToList(tags)
 	}
 	if resp.DefinedTags != nil {
-		props["DefinedTags"] = util.DefinedTagsToList(resp.DefinedTags)
+		props["DefinedTags"] = util.DefinedTagsToList(resp.DefinedTags, config.FromTargetConfig(request.TargetConfig).HiddenTagNamespaces())
 	}
 
 	propBytes, err := json.Marshal(props)
@@ -288,26 +392,297 @@ func (p *VCNProvisioner) List(ctx context.Context, request *resource.ListRequest
 	}
 
 	// CompartmentId is required for listing
-	compartmentId, ok := request.AdditionalProperties["CompartmentId"]
+	compartmentId, ok := util.ListCompartmentId(request)
 	if !ok {
 		return nil, fmt.Errorf("CompartmentId is required for listing VCNs")
 	}
 
 	listReq := core.ListVcnsRequest{
 		CompartmentId: common.String(compartmentId),
+		Limit:         util.ListPageSize(request.TargetConfig),
 	}
 
-	resp, err := client.ListVcns(ctx, listReq)
+	nativeIDs, err := util.ListAllPages(func(page *string) ([]string, *string, error) {
+		listReq.Page = page
+		resp, err := client.ListVcns(ctx, listReq)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list VCNs: %w", err)
+		}
+		ids := make([]string, 0, len(resp.Items))
+		for _, vcn := range resp.Items {
+			ids = append(ids, *vcn.Id)
+		}
+		return ids, resp.OpcNextPage, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list VCNs: %w", err)
-	}
-
-	nativeIDs := make([]string, 0, len(resp.Items))
-	for _, vcn := range resp.Items {
-		nativeIDs = append(nativeIDs, *vcn.Id)
+		return nil, err
 	}
 
 	return &resource.ListResult{
 		NativeIDs: nativeIDs,
 	}, nil
 }
+
+// cascadeDeleteTag is the freeform tag that records a VCN's CascadeDelete
+// property. Delete requests carry no properties, so Delete finds the opt-in on
+// the VCN itself; Read reports it as CascadeDelete and hides the tag.
+const cascadeDeleteTag = "formae-cascade-delete"
+
+// withCascadeDeleteTag returns a copy of tags with the cascade delete marker set
+// or removed.
+func withCascadeDeleteTag(tags map[string]string, cascade bool) map[string]string {
+	tags = maps.Clone(tags)
+	if tags == nil {
+		tags = map[string]string{}
+	}
+	delete(tags, cascadeDeleteTag)
+	if cascade {
+		tags[cascadeDeleteTag] = "true"
+	}
+	return tags
+}
+
+// vcnChild is a resource inside a VCN that hasn't terminated yet.
+type vcnChild struct {
+	id       string
+	deleting bool // already TERMINATING, so not deleted again
+}
+
+// vcnChildren holds the resources that belong to a VCN. The VCN's default route
+// table, security list and DHCP options are excluded because OCI removes them
+// together with the VCN.
+type vcnChildren struct {
+	subnets               []vcnChild
+	internetGateways      []vcnChild
+	natGateways           []vcnChild
+	serviceGateways       []vcnChild
+	networkSecurityGroups []vcnChild
+	routeTables           []vcnChild
+	securityLists         []vcnChild
+	dhcpOptions           []vcnChild
+}
+
+// listVCNChildren lists the live child resources of a VCN. vcnProperties is the
+// serialized output of VCNProvisioner.Read.
+func listVCNChildren(ctx context.Context, client *core.VirtualNetworkClient, vcnId string, vcnProperties string) (*vcnChildren, error) {
+	var vcnProps map[string]any
+	if err := json.Unmarshal([]byte(vcnProperties), &vcnProps); err != nil {
+		return nil, fmt.Errorf("failed to parse VCN properties: %w", err)
+	}
+	compartmentId, ok := vcnProps["CompartmentId"].(string)
+	if !ok {
+		return nil, fmt.Errorf("VCN %s has no CompartmentId", vcnId)
+	}
+	defaults := map[string]bool{}
+	for _, key := range []string{"DefaultRouteTableId", "DefaultSecurityListId", "DefaultDhcpOptionsId"} {
+		if id, ok := vcnProps[key].(string); ok {
+			defaults[id] = true
+		}
+	}
+
+	// collect appends id until the child has finished terminating
+	collect := func(ids []vcnChild, id *string, state string) []vcnChild {
+		if id == nil || defaults[*id] || state == "TERMINATED" {
+			return ids
+		}
+		return append(ids, vcnChild{id: *id, deleting: util.IsTerminal(state)})
+	}
+
+	compartment := common.String(compartmentId)
+	vcn := common.String(vcnId)
+	children := &vcnChildren{}
+
+	subnets, err := client.ListSubnets(ctx, core.ListSubnetsRequest{CompartmentId: compartment, VcnId: vcn})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subnets: %w", err)
+	}
+	for _, item := range subnets.Items {
+		children.subnets = collect(children.subnets, item.Id, string(item.LifecycleState))
+	}
+
+	internetGateways, err := client.ListInternetGateways(ctx, core.ListInternetGatewaysRequest{CompartmentId: compartment, VcnId: vcn})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list internet gateways: %w", err)
+	}
+	for _, item := range internetGateways.Items {
+		children.internetGateways = collect(children.internetGateways, item.Id, string(item.LifecycleState))
+	}
+
+	natGateways, err := client.ListNatGateways(ctx, core.ListNatGatewaysRequest{CompartmentId: compartment, VcnId: vcn})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list NAT gateways: %w", err)
+	}
+	for _, item := range natGateways.Items {
+		children.natGateways = collect(children.natGateways, item.Id, string(item.LifecycleState))
+	}
+
+	serviceGateways, err := client.ListServiceGateways(ctx, core.ListServiceGatewaysRequest{CompartmentId: compartment, VcnId: vcn})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service gateways: %w", err)
+	}
+	for _, item := range serviceGateways.Items {
+		children.serviceGateways = collect(children.serviceGateways, item.Id, string(item.LifecycleState))
+	}
+
+	nsgs, err := client.ListNetworkSecurityGroups(ctx, core.ListNetworkSecurityGroupsRequest{CompartmentId: compartment, VcnId: vcn})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list network security groups: %w", err)
+	}
+	for _, item := range nsgs.Items {
+		children.networkSecurityGroups = collect(children.networkSecurityGroups, item.Id, string(item.LifecycleState))
+	}
+
+	routeTables, err := client.ListRouteTables(ctx, core.ListRouteTablesRequest{CompartmentId: compartment, VcnId: vcn})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list route tables: %w", err)
+	}
+	for _, item := range routeTables.Items {
+		children.routeTables = collect(children.routeTables, item.Id, string(item.LifecycleState))
+	}
+
+	securityLists, err := client.ListSecurityLists(ctx, core.ListSecurityListsRequest{CompartmentId: compartment, VcnId: vcn})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list security lists: %w", err)
+	}
+	for _, item := range securityLists.Items {
+		children.securityLists = collect(children.securityLists, item.Id, string(item.LifecycleState))
+	}
+
+	dhcpOptions, err := client.ListDhcpOptions(ctx, core.ListDhcpOptionsRequest{CompartmentId: compartment, VcnId: vcn})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list DHCP options: %w", err)
+	}
+	for _, item := range dhcpOptions.Items {
+		children.dhcpOptions = collect(children.dhcpOptions, item.Id, string(item.LifecycleState))
+	}
+
+	return children, nil
+}
+
+// findVCNDependents returns the child resource types that still block deletion of
+// the VCN. Lookup is best-effort: a failed list call yields no enrichment rather
+// than masking the original conflict.
+func findVCNDependents(ctx context.Context, client *core.VirtualNetworkClient, vcnId string, vcnProperties string) []string {
+	children, err := listVCNChildren(ctx, client, vcnId, vcnProperties)
+	if err != nil {
+		return nil
+	}
+
+	var blocking []string
+	for _, kind := range []struct {
+		name string
+		ids  []vcnChild
+	}{
+		{"subnets", children.subnets},
+		{"internet gateways", children.internetGateways},
+		{"NAT gateways", children.natGateways},
+		{"service gateways", children.serviceGateways},
+		{"network security groups", children.networkSecurityGroups},
+		{"route tables", children.routeTables},
+		{"security lists", children.securityLists},
+		{"DHCP options", children.dhcpOptions},
+	} {
+		if len(kind.ids) > 0 {
+			blocking = append(blocking, kind.name)
+		}
+	}
+	return blocking
+}
+
+// cascadeDeleteVCNChildren removes everything inside a VCN in dependency order, the
+// same way the console's "Delete All" does, and reports whether nothing is left.
+// Each call deletes one stage and returns until its children have terminated:
+// subnets first, then route tables (the default one is emptied so it no longer
+// targets any gateway), network security groups, security lists and DHCP
+// options, and the gateways last. Not-found errors are ignored so a partially
+// completed cascade can be retried.
+func cascadeDeleteVCNChildren(ctx context.Context, client *core.VirtualNetworkClient, vcnId string, vcnProperties string) (bool, error) {
+	children, err := listVCNChildren(ctx, client, vcnId, vcnProperties)
+	if err != nil {
+		return false, err
+	}
+
+	// deleteAll deletes the children not already terminating
+	deleteAll := func(kind string, items []vcnChild, del func(id *string) error) error {
+		for _, item := range items {
+			if item.deleting {
+				continue
+			}
+			if err := del(common.String(item.id)); err != nil && !util.IsNotFound(err) {
+				return fmt.Errorf("failed to delete %s %s: %w", kind, item.id, err)
+			}
+		}
+		return nil
+	}
+
+	// Route tables and security lists stay referenced until their subnets are gone
+	if len(children.subnets) > 0 {
+		return false, deleteAll("subnet", children.subnets, func(id *string) error {
+			_, err := client.DeleteSubnet(ctx, core.DeleteSubnetRequest{SubnetId: id})
+			return err
+		})
+	}
+
+	// Gateways stay referenced until the route tables that target them are gone
+	if len(children.routeTables) > 0 || len(children.networkSecurityGroups) > 0 ||
+		len(children.securityLists) > 0 || len(children.dhcpOptions) > 0 {
+		if err := deleteAll("route table", children.routeTables, func(id *string) error {
+			_, err := client.DeleteRouteTable(ctx, core.DeleteRouteTableRequest{RtId: id})
+			return err
+		}); err != nil {
+			return false, err
+		}
+		if err := deleteAll("network security group", children.networkSecurityGroups, func(id *string) error {
+			_, err := client.DeleteNetworkSecurityGroup(ctx, core.DeleteNetworkSecurityGroupRequest{NetworkSecurityGroupId: id})
+			return err
+		}); err != nil {
+			return false, err
+		}
+		if err := deleteAll("security list", children.securityLists, func(id *string) error {
+			_, err := client.DeleteSecurityList(ctx, core.DeleteSecurityListRequest{SecurityListId: id})
+			return err
+		}); err != nil {
+			return false, err
+		}
+		return false, deleteAll("DHCP options", children.dhcpOptions, func(id *string) error {
+			_, err := client.DeleteDhcpOptions(ctx, core.DeleteDhcpOptionsRequest{DhcpId: id})
+			return err
+		})
+	}
+
+	gateways := slices.Concat(children.internetGateways, children.natGateways, children.serviceGateways)
+	if len(gateways) == 0 {
+		return true, nil
+	}
+	if !slices.ContainsFunc(gateways, func(c vcnChild) bool { return !c.deleting }) {
+		return false, nil
+	}
+
+	var vcnProps map[string]any
+	_ = json.Unmarshal([]byte(vcnProperties), &vcnProps)
+	if defaultRouteTableId, ok := vcnProps["DefaultRouteTableId"].(string); ok {
+		_, err := client.UpdateRouteTable(ctx, core.UpdateRouteTableRequest{
+			RtId:                    common.String(defaultRouteTableId),
+			UpdateRouteTableDetails: core.UpdateRouteTableDetails{RouteRules: []core.RouteRule{}},
+		})
+		if err != nil && !util.IsNotFound(err) {
+			return false, fmt.Errorf("failed to clear rules of default route table %s: %w", defaultRouteTableId, err)
+		}
+	}
+	if err := deleteAll("internet gateway", children.internetGateways, func(id *string) error {
+		_, err := client.DeleteInternetGateway(ctx, core.DeleteInternetGatewayRequest{IgId: id})
+		return err
+	}); err != nil {
+		return false, err
+	}
+	if err := deleteAll("NAT gateway", children.natGateways, func(id *string) error {
+		_, err := client.DeleteNatGateway(ctx, core.DeleteNatGatewayRequest{NatGatewayId: id})
+		return err
+	}); err != nil {
+		return false, err
+	}
+	return false, deleteAll("service gateway", children.serviceGateways, func(id *string) error {
+		_, err := client.DeleteServiceGateway(ctx, core.DeleteServiceGatewayRequest{ServiceGatewayId: id})
+		return err
+	})
+}
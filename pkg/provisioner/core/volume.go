@@ -11,31 +11,47 @@ import (
 
 	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/oracle/oci-go-sdk/v65/identity"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
 )
 
 type VolumeProvisioner struct {
-	clients *client.Clients
-	svc     *core.BlockstorageClient // nil until first use; injected in tests
+	clients  *client.Clients
+	svc      *core.BlockstorageClient // nil until first use; injected in tests
+	identity *identity.IdentityClient // nil until first use; injected in tests
 }
 
-var _ provisioner.Provisioner = &VolumeProvisioner{}
+var (
+	_ provisioner.Provisioner = &VolumeProvisioner{}
+	_ provisioner.Immutable   = &VolumeProvisioner{}
+	_ provisioner.Equivalent  = &VolumeProvisioner{}
+)
 
 func init() {
 	provisioner.Register("OCI::Core::Volume", NewVolumeProvisioner)
+	provisioner.DependsOn("OCI::Core::Volume", "OCI::Identity::Compartment")
 }
 
 func NewVolumeProvisioner(clients *client.Clients) provisioner.Provisioner {
 	return &VolumeProvisioner{clients: clients}
 }
 
-// NewVolumeProvisionerWithSvc constructs a provisioner with a pre-built SDK client,
-// for use in tests that point the client at an httptest server.
-func NewVolumeProvisionerWithSvc(svc *core.BlockstorageClient) *VolumeProvisioner {
-	return &VolumeProvisioner{svc: svc}
+// NewVolumeProvisionerWithSvc constructs a provisioner with pre-built SDK clients,
+// for use in tests that point the clients at an httptest server. identity
+// resolves short availability domain names and may be nil when a test uses
+// full ones.
+func NewVolumeProvisionerWithSvc(svc *core.BlockstorageClient, identity *identity.IdentityClient) *VolumeProvisioner {
+	return &VolumeProvisioner{svc: svc, identity: identity}
+}
+
+// ImmutableFields lists what a volume can't change in place: it can't leave
+// its availability domain.
+func (p *VolumeProvisioner) ImmutableFields() []string {
+	return []string{"AvailabilityDomain"}
 }
 
 func (p *VolumeProvisioner) getSvc() (*core.BlockstorageClient, error) {
@@ -45,6 +61,23 @@ func (p *VolumeProvisioner) getSvc() (*core.BlockstorageClient, error) {
 	return p.clients.GetBlockstorageClient()
 }
 
+func (p *VolumeProvisioner) getIdentity() (*identity.IdentityClient, error) {
+	if p.identity != nil {
+		return p.identity, nil
+	}
+	return p.clients.GetIdentityClient()
+}
+
+// SameValue compares AvailabilityDomain in resolved form, so a short name in
+// the desired state matches the full name Read reports.
+func (p *VolumeProvisioner) SameValue(ctx context.Context, field string, live map[string]any, desired any) bool {
+	if field != "AvailabilityDomain" {
+		return false
+	}
+	compartmentId, _ := live["CompartmentId"].(string)
+	return sameAvailabilityDomain(ctx, p.getIdentity, compartmentId, live[field], desired)
+}
+
 func (p *VolumeProvisioner) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
 	svc, err := p.getSvc()
 	if err != nil {
@@ -56,9 +89,14 @@ func (p *VolumeProvisioner) Create(ctx context.Context, request *resource.Create
 		return nil, fmt.Errorf("failed to parse properties: %w", err)
 	}
 
+	availabilityDomain, err := resolveAvailabilityDomain(ctx, p.getIdentity, props["CompartmentId"].(string), props["AvailabilityDomain"].(string))
+	if err != nil {
+		return nil, err
+	}
+
 	createDetails := core.CreateVolumeDetails{
 		CompartmentId:      common.String(props["CompartmentId"].(string)),
-		AvailabilityDomain: common.String(props["AvailabilityDomain"].(string)),
+		AvailabilityDomain: common.String(availabilityDomain),
 	}
 
 	if displayName, ok := util.ExtractString(props, "DisplayName"); ok {
@@ -76,6 +114,15 @@ func (p *VolumeProvisioner) Create(ctx context.Context, request *resource.Create
 	if kmsKeyId, ok := util.ExtractString(props, "KmsKeyId"); ok {
 		createDetails.KmsKeyId = common.String(kmsKeyId)
 	}
+	if policies, ok := parseAutotunePolicies(props); ok {
+		createDetails.AutotunePolicies = policies
+	}
+	if replicas, ok := parseBlockVolumeReplicas(props); ok {
+		if err := resolveReplicaAvailabilityDomains(ctx, p.getIdentity, *createDetails.CompartmentId, replicas); err != nil {
+			return nil, err
+		}
+		createDetails.BlockVolumeReplicas = replicas
+	}
 	if freeformTags, ok := util.ExtractFreeformTags(props, "FreeformTags"); ok {
 		createDetails.FreeformTags = freeformTags
 	}
@@ -84,6 +131,7 @@ func (p *VolumeProvisioner) Create(ctx context.Context, request *resource.Create
 	}
 
 	createReq := core.CreateVolumeRequest{
+		OpcRetryToken:       common.String(util.CreateRetryToken(request)),
 		CreateVolumeDetails: createDetails,
 	}
 
@@ -118,7 +166,7 @@ func (p *VolumeProvisioner) Read(ctx context.Context, request *resource.ReadRequ
 
 	resp, err := svc.GetVolume(ctx, getReq)
 	if err != nil {
-		if serviceErr, ok := common.IsServiceError(err); ok && serviceErr.GetHTTPStatusCode() == 404 {
+		if util.IsNotFound(err) {
 			return &resource.ReadResult{
 				ResourceType: "OCI::Core::Volume",
 				ErrorCode:    resource.OperationErrorCodeNotFound,
@@ -134,7 +182,7 @@ func (p *VolumeProvisioner) Read(ctx context.Context, request *resource.ReadRequ
 		}, nil
 	}
 
-	properties := buildVolumeProperties(resp.Volume)
+	properties := buildVolumeProperties(resp.Volume, config.FromTargetConfig(request.TargetConfig).HiddenTagNamespaces())
 
 	propBytes, err := json.Marshal(properties)
 	if err != nil {
@@ -158,6 +206,14 @@ func (p *VolumeProvisioner) Update(ctx context.Context, request *resource.Update
 		return nil, err
 	}
 
+	current, err := svc.GetVolume(ctx, core.GetVolumeRequest{VolumeId: common.String(request.NativeID)})
+	if err != nil {
+		if result, handleErr := util.HandleUpdateError(err, "OCI::Core::Volume", request.NativeID, "OCI::Core::Volume"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to read Volume before update: %w", err)
+	}
+
 	updateDetails := core.UpdateVolumeDetails{}
 
 	if displayName, ok := util.ExtractString(props, "DisplayName"); ok {
@@ -172,6 +228,22 @@ func (p *VolumeProvisioner) Update(ctx context.Context, request *resource.Update
 	if isAutoTuneEnabled, ok := util.ExtractBool(props, "IsAutoTuneEnabled"); ok {
 		updateDetails.IsAutoTuneEnabled = common.Bool(isAutoTuneEnabled)
 	}
+	if policies, ok := parseAutotunePolicies(props); ok {
+		updateDetails.AutotunePolicies = policies
+	}
+
+	// Replication is switched off by sending an empty list. Only send the list when
+	// it differs, since resubmitting an unchanged one restarts replica provisioning.
+	desiredReplicas, _ := parseBlockVolumeReplicas(props)
+	if err := resolveReplicaAvailabilityDomains(ctx, p.getIdentity, *current.CompartmentId, desiredReplicas); err != nil {
+		return nil, err
+	}
+	if blockVolumeReplicasChanged(desiredReplicas, current.BlockVolumeReplicas) {
+		if desiredReplicas == nil {
+			desiredReplicas = []core.BlockVolumeReplicaDetails{}
+		}
+		updateDetails.BlockVolumeReplicas = desiredReplicas
+	}
 	if freeformTags, ok := util.ExtractFreeformTags(props, "FreeformTags"); ok {
 		updateDetails.FreeformTags = freeformTags
 	}
@@ -192,6 +264,13 @@ func (p *VolumeProvisioner) Update(ctx context.Context, request *resource.Update
 		return nil, fmt.Errorf("failed to update Volume: %w", err)
 	}
 
+	if err := applyVolumeKmsKey(ctx, svc, current.Volume, props); err != nil {
+		if result, handleErr := util.HandleUpdateError(err, "OCI::Core::Volume", request.NativeID, "OCI::Core::Volume"); result != nil {
+			return result, handleErr
+		}
+		return nil, err
+	}
+
 	return &resource.UpdateResult{
 		ProgressResult: &resource.ProgressResult{
 			Operation:       resource.OperationUpdate,
@@ -201,6 +280,29 @@ func (p *VolumeProvisioner) Update(ctx context.Context, request *resource.Update
 	}, nil
 }
 
+// applyVolumeKmsKey rekeys the volume in place when KmsKeyId changes. Dropping
+// KmsKeyId returns the volume to Oracle-managed encryption.
+func applyVolumeKmsKey(ctx context.Context, svc *core.BlockstorageClient, volume core.Volume, props map[string]any) error {
+	kmsKeyId, hasKmsKey := util.ExtractString(props, "KmsKeyId")
+	currentKmsKeyId, hasCurrentKmsKey := util.CustomerKmsKeyId(volume.KmsKeyId)
+	switch {
+	case hasKmsKey && kmsKeyId != currentKmsKeyId:
+		if _, err := svc.UpdateVolumeKmsKey(ctx, core.UpdateVolumeKmsKeyRequest{
+			VolumeId:                  volume.Id,
+			UpdateVolumeKmsKeyDetails: core.UpdateVolumeKmsKeyDetails{KmsKeyId: common.String(kmsKeyId)},
+		}); err != nil {
+			return fmt.Errorf("failed to update KMS key of Volume: %w", err)
+		}
+	case !hasKmsKey && hasCurrentKmsKey:
+		if _, err := svc.DeleteVolumeKmsKey(ctx, core.DeleteVolumeKmsKeyRequest{
+			VolumeId: volume.Id,
+		}); err != nil {
+			return fmt.Errorf("failed to remove KMS key of Volume: %w", err)
+		}
+	}
+	return nil
+}
+
 func (p *VolumeProvisioner) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
 	svc, err := p.getSvc()
 	if err != nil {
@@ -253,70 +355,34 @@ func (p *VolumeProvisioner) Status(ctx context.Context, request *resource.Status
 		return nil, fmt.Errorf("failed to get Blockstorage client: %w", err)
 	}
 
-	getReq := core.GetVolumeRequest{
-		VolumeId: common.String(request.RequestID),
-	}
-
-	resp, err := svc.GetVolume(ctx, getReq)
-	if err != nil {
-		if serviceErr, ok := common.IsServiceError(err); ok && serviceErr.GetHTTPStatusCode() == 404 {
-			// Volume gone — if we were deleting, that's success
-			return &resource.StatusResult{
-				ProgressResult: &resource.ProgressResult{
-					Operation:       resource.OperationCheckStatus,
-					OperationStatus: resource.OperationStatusSuccess,
-					NativeID:        request.RequestID,
-				},
-			}, nil
-		}
-		return nil, fmt.Errorf("failed to check Volume status: %w", err)
-	}
-
-	switch resp.LifecycleState {
-	case core.VolumeLifecycleStateAvailable:
-		properties := buildVolumeProperties(resp.Volume)
-		propertiesBytes, err := json.Marshal(properties)
+	getVolume := func(ctx context.Context) (*util.LifecycleSnapshot, error) {
+		resp, err := svc.GetVolume(ctx, core.GetVolumeRequest{
+			VolumeId: common.String(request.RequestID),
+		})
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal properties: %w", err)
+			if util.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to check Volume status: %w", err)
 		}
-		return &resource.StatusResult{
-			ProgressResult: &resource.ProgressResult{
-				Operation:          resource.OperationCheckStatus,
-				OperationStatus:    resource.OperationStatusSuccess,
-				NativeID:           *resp.Id,
-				ResourceProperties: json.RawMessage(propertiesBytes),
-			},
-		}, nil
-
-	case core.VolumeLifecycleStateTerminated:
-		return &resource.StatusResult{
-			ProgressResult: &resource.ProgressResult{
-				Operation:       resource.OperationCheckStatus,
-				OperationStatus: resource.OperationStatusSuccess,
-				NativeID:        *resp.Id,
-			},
-		}, nil
-
-	case core.VolumeLifecycleStateFaulty:
-		return &resource.StatusResult{
-			ProgressResult: &resource.ProgressResult{
-				Operation:       resource.OperationCheckStatus,
-				OperationStatus: resource.OperationStatusFailure,
-				NativeID:        *resp.Id,
-				StatusMessage:   "Volume is in FAULTY state",
-			},
+		return &util.LifecycleSnapshot{
+			NativeID:   *resp.Id,
+			State:      string(resp.LifecycleState),
+			Properties: buildVolumeProperties(resp.Volume, config.FromTargetConfig(request.TargetConfig).HiddenTagNamespaces()),
 		}, nil
+	}
 
-	default: // PROVISIONING, RESTORING, TERMINATING
-		return &resource.StatusResult{
-			ProgressResult: &resource.ProgressResult{
-				Operation:       resource.OperationCheckStatus,
-				OperationStatus: resource.OperationStatusInProgress,
-				RequestID:       request.RequestID,
-				StatusMessage:   fmt.Sprintf("Volume lifecycle state: %s", resp.LifecycleState),
-			},
-		}, nil
+	// PROVISIONING, RESTORING, TERMINATING stay in progress
+	result, err := util.PollLifecycle(ctx, "Volume", request.RequestID, getVolume, map[string]resource.OperationStatus{
+		string(core.VolumeLifecycleStateAvailable):  resource.OperationStatusSuccess,
+		string(core.VolumeLifecycleStateTerminated): resource.OperationStatusSuccess,
+		string(core.VolumeLifecycleStateFaulty):     resource.OperationStatusFailure,
+	})
+	if err != nil {
+		return nil, err
 	}
+
+	return &resource.StatusResult{ProgressResult: result}, nil
 }
 
 func (p *VolumeProvisioner) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
@@ -325,23 +391,30 @@ func (p *VolumeProvisioner) List(ctx context.Context, request *resource.ListRequ
 		return nil, fmt.Errorf("failed to get Blockstorage client: %w", err)
 	}
 
-	compartmentId, ok := request.AdditionalProperties["CompartmentId"]
+	compartmentId, ok := util.ListCompartmentId(request)
 	if !ok {
 		return nil, fmt.Errorf("CompartmentId is required for listing Volumes")
 	}
 
 	listReq := core.ListVolumesRequest{
 		CompartmentId: common.String(compartmentId),
+		Limit:         util.ListPageSize(request.TargetConfig),
 	}
 
-	resp, err := svc.ListVolumes(ctx, listReq)
+	nativeIDs, err := util.ListAllPages(func(page *string) ([]string, *string, error) {
+		listReq.Page = page
+		resp, err := svc.ListVolumes(ctx, listReq)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list Volumes: %w", err)
+		}
+		ids := make([]string, 0, len(resp.Items))
+		for _, vol := range resp.Items {
+			ids = append(ids, *vol.Id)
+		}
+		return ids, resp.OpcNextPage, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list Volumes: %w", err)
-	}
-
-	nativeIDs := make([]string, 0, len(resp.Items))
-	for _, vol := range resp.Items {
-		nativeIDs = append(nativeIDs, *vol.Id)
+		return nil, err
 	}
 
 	return &resource.ListResult{
@@ -356,7 +429,118 @@ func extractInt64Field(props map[string]any, key string) (int64, bool) {
 	return 0, false
 }
 
-func buildVolumeProperties(vol core.Volume) map[string]any {
+// parseAutotunePolicies reads AutotunePolicies, a list of
+// {autotuneType: DETACHED_VOLUME|PERFORMANCE_BASED, maxVpusPerGB}.
+func parseAutotunePolicies(props map[string]any) ([]core.AutotunePolicy, bool) {
+	items, ok := props["AutotunePolicies"].([]any)
+	if !ok {
+		return nil, false
+	}
+	policies := make([]core.AutotunePolicy, 0, len(items))
+	for _, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		autotuneType, _ := extractStringField(m, "autotuneType", "AutotuneType")
+		switch autotuneType {
+		case "DETACHED_VOLUME":
+			policies = append(policies, core.DetachedVolumeAutotunePolicy{})
+		case "PERFORMANCE_BASED":
+			policy := core.PerformanceBasedAutotunePolicy{}
+			if maxVpusPerGB, ok := extractInt64Field(m, "maxVpusPerGB"); ok {
+				policy.MaxVpusPerGB = common.Int64(maxVpusPerGB)
+			}
+			policies = append(policies, policy)
+		}
+	}
+	return policies, true
+}
+
+func buildAutotunePolicies(policies []core.AutotunePolicy) []map[string]any {
+	result := make([]map[string]any, 0, len(policies))
+	for _, policy := range policies {
+		switch v := policy.(type) {
+		case core.DetachedVolumeAutotunePolicy:
+			result = append(result, map[string]any{"autotuneType": "DETACHED_VOLUME"})
+		case core.PerformanceBasedAutotunePolicy:
+			m := map[string]any{"autotuneType": "PERFORMANCE_BASED"}
+			if v.MaxVpusPerGB != nil {
+				m["maxVpusPerGB"] = *v.MaxVpusPerGB
+			}
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+// parseBlockVolumeReplicas reads BlockVolumeReplicas, a list of
+// {availabilityDomain, displayName} targets for continuous replication.
+// blockVolumeReplicaId is output-only and ignored here.
+func parseBlockVolumeReplicas(props map[string]any) ([]core.BlockVolumeReplicaDetails, bool) {
+	items, ok := props["BlockVolumeReplicas"].([]any)
+	if !ok {
+		return nil, false
+	}
+	replicas := make([]core.BlockVolumeReplicaDetails, 0, len(items))
+	for _, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		replica := core.BlockVolumeReplicaDetails{}
+		if ad, ok := extractStringField(m, "availabilityDomain", "AvailabilityDomain"); ok {
+			replica.AvailabilityDomain = common.String(ad)
+		}
+		if displayName, ok := extractStringField(m, "displayName", "DisplayName"); ok {
+			replica.DisplayName = common.String(displayName)
+		}
+		replicas = append(replicas, replica)
+	}
+	return replicas, true
+}
+
+func blockVolumeReplicasChanged(desired []core.BlockVolumeReplicaDetails, current []core.BlockVolumeReplicaInfo) bool {
+	if len(desired) != len(current) {
+		return true
+	}
+	for i := range desired {
+		if !ptrStringEqual(desired[i].AvailabilityDomain, current[i].AvailabilityDomain) {
+			return true
+		}
+		if desired[i].DisplayName != nil && !ptrStringEqual(desired[i].DisplayName, current[i].DisplayName) {
+			return true
+		}
+	}
+	return false
+}
+
+func ptrStringEqual(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func buildBlockVolumeReplicas(replicas []core.BlockVolumeReplicaInfo) []map[string]any {
+	result := make([]map[string]any, 0, len(replicas))
+	for _, replica := range replicas {
+		m := map[string]any{}
+		if replica.BlockVolumeReplicaId != nil {
+			m["blockVolumeReplicaId"] = *replica.BlockVolumeReplicaId
+		}
+		if replica.AvailabilityDomain != nil {
+			m["availabilityDomain"] = *replica.AvailabilityDomain
+		}
+		if replica.DisplayName != nil {
+			m["displayName"] = *replica.DisplayName
+		}
+		result = append(result, m)
+	}
+	return result
+}
+
+func buildVolumeProperties(vol core.Volume, ignoredTagNamespaces []string) map[string]any {
 	properties := map[string]any{
 		"CompartmentId":      *vol.CompartmentId,
 		"AvailabilityDomain": *vol.AvailabilityDomain,
@@ -375,8 +559,14 @@ func buildVolumeProperties(vol core.Volume) map[string]any {
 	if vol.IsAutoTuneEnabled != nil {
 		properties["IsAutoTuneEnabled"] = *vol.IsAutoTuneEnabled
 	}
-	if vol.KmsKeyId != nil {
-		properties["KmsKeyId"] = *vol.KmsKeyId
+	if kmsKeyId, ok := util.CustomerKmsKeyId(vol.KmsKeyId); ok {
+		properties["KmsKeyId"] = kmsKeyId
+	}
+	if policies := buildAutotunePolicies(vol.AutotunePolicies); len(policies) > 0 {
+		properties["AutotunePolicies"] = policies
+	}
+	if replicas := buildBlockVolumeReplicas(vol.BlockVolumeReplicas); len(replicas) > 0 {
+		properties["BlockVolumeReplicas"] = replicas
 	}
 	if vol.LifecycleState != "" {
 		properties["LifecycleState"] = string(vol.LifecycleState)
@@ -388,7 +578,7 @@ func buildVolumeProperties(vol core.Volume) map[string]any {
 		properties["FreeformTags"] = util.FreeformTagsToList(vol.FreeformTags)
 	}
 	if vol.DefinedTags != nil {
-		properties["DefinedTags"] = util.DefinedTagsToList(vol.DefinedTags)
+		properties["DefinedTags"] = util.DefinedTagsToList(vol.DefinedTags, ignoredTagNamespaces)
 	}
 
 	return properties
@@ -0,0 +1,448 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+type VolumeAttachmentProvisioner struct {
+	clients *client.Clients
+	svc     *core.ComputeClient // nil until first use; injected in tests
+}
+
+var _ provisioner.Provisioner = &VolumeAttachmentProvisioner{}
+
+func init() {
+	provisioner.Register("OCI::Core::VolumeAttachment", NewVolumeAttachmentProvisioner)
+	provisioner.DependsOn("OCI::Core::VolumeAttachment", "OCI::Core::Instance", "OCI::Core::Volume")
+}
+
+func NewVolumeAttachmentProvisioner(clients *client.Clients) provisioner.Provisioner {
+	return &VolumeAttachmentProvisioner{clients: clients}
+}
+
+// NewVolumeAttachmentProvisionerWithSvc constructs a provisioner with a pre-built SDK client,
+// for use in tests that point the client at an httptest server.
+func NewVolumeAttachmentProvisionerWithSvc(svc *core.ComputeClient) *VolumeAttachmentProvisioner {
+	return &VolumeAttachmentProvisioner{svc: svc}
+}
+
+func (p *VolumeAttachmentProvisioner) getSvc() (*core.ComputeClient, error) {
+	if p.svc != nil {
+		return p.svc, nil
+	}
+	return p.clients.GetComputeClient()
+}
+
+// HasNoTags: OCI doesn't tag volume attachments.
+func (p *VolumeAttachmentProvisioner) HasNoTags() {}
+
+func (p *VolumeAttachmentProvisioner) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Compute client: %w", err)
+	}
+
+	var props map[string]any
+	if err := json.Unmarshal(request.Properties, &props); err != nil {
+		return nil, fmt.Errorf("failed to parse properties: %w", err)
+	}
+
+	attachDetails, err := parseAttachVolumeDetails(props)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := svc.AttachVolume(ctx, core.AttachVolumeRequest{
+		OpcRetryToken:       common.String(util.CreateRetryToken(request)),
+		AttachVolumeDetails: attachDetails,
+	})
+	if err != nil {
+		if result, handleErr := util.HandleCreateError(err, "OCI::Core::VolumeAttachment", "OCI::Core::VolumeAttachment"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to attach Volume: %w", err)
+	}
+
+	// Attaching is async — return in-progress, poll lifecycle in Status()
+	id := *resp.VolumeAttachment.GetId()
+	return &resource.CreateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationCreate,
+			OperationStatus: resource.OperationStatusInProgress,
+			NativeID:        id,
+			RequestID:       id,
+		},
+	}, nil
+}
+
+func (p *VolumeAttachmentProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Compute client: %w", err)
+	}
+
+	resp, err := svc.GetVolumeAttachment(ctx, core.GetVolumeAttachmentRequest{
+		VolumeAttachmentId: common.String(request.NativeID),
+	})
+	if err != nil {
+		if util.IsNotFound(err) {
+			return &resource.ReadResult{
+				ResourceType: "OCI::Core::VolumeAttachment",
+				ErrorCode:    resource.OperationErrorCodeNotFound,
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to read VolumeAttachment: %w", err)
+	}
+
+	// OCI keeps detached attachments around for a while; they are gone as far as formae
+	// is concerned, and so are ones still detaching
+	if isDetached(resp.VolumeAttachment.GetLifecycleState()) {
+		return &resource.ReadResult{
+			ResourceType: "OCI::Core::VolumeAttachment",
+			ErrorCode:    resource.OperationErrorCodeNotFound,
+		}, nil
+	}
+
+	properties, err := p.readVolumeAttachmentProperties(ctx, svc, resp.VolumeAttachment)
+	if err != nil {
+		return nil, err
+	}
+
+	propBytes, err := json.Marshal(properties)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal VolumeAttachment properties: %w", err)
+	}
+
+	return &resource.ReadResult{
+		ResourceType: "OCI::Core::VolumeAttachment",
+		Properties:   string(propBytes),
+	}, nil
+}
+
+func (p *VolumeAttachmentProvisioner) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	// Every attachment setting is fixed at attach time - must detach and reattach
+	return nil, fmt.Errorf("update not supported for VolumeAttachment - delete and recreate instead")
+}
+
+func (p *VolumeAttachmentProvisioner) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Compute client: %w", err)
+	}
+
+	readRes, err := p.Read(ctx, &resource.ReadRequest{NativeID: request.NativeID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read VolumeAttachment before delete: %w", err)
+	}
+	if readRes.ErrorCode == resource.OperationErrorCodeNotFound {
+		return &resource.DeleteResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationDelete,
+				OperationStatus: resource.OperationStatusSuccess,
+				NativeID:        request.NativeID,
+			},
+		}, nil
+	}
+
+	_, err = svc.DetachVolume(ctx, core.DetachVolumeRequest{
+		VolumeAttachmentId: common.String(request.NativeID),
+	})
+	if err != nil {
+		if result, handleErr := util.HandleDeleteError(err, "OCI::Core::VolumeAttachment", request.NativeID, "OCI::Core::VolumeAttachment"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to detach Volume: %w", err)
+	}
+
+	// Detaching is async — return in-progress, poll lifecycle in Status()
+	return &resource.DeleteResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationDelete,
+			OperationStatus: resource.OperationStatusInProgress,
+			NativeID:        request.NativeID,
+			RequestID:       request.NativeID,
+		},
+	}, nil
+}
+
+func (p *VolumeAttachmentProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Compute client: %w", err)
+	}
+
+	getVolumeAttachment := func(ctx context.Context) (*util.LifecycleSnapshot, error) {
+		resp, err := svc.GetVolumeAttachment(ctx, core.GetVolumeAttachmentRequest{
+			VolumeAttachmentId: common.String(request.RequestID),
+		})
+		if err != nil {
+			if util.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to check VolumeAttachment status: %w", err)
+		}
+		snapshot := &util.LifecycleSnapshot{
+			NativeID: *resp.VolumeAttachment.GetId(),
+			State:    string(resp.VolumeAttachment.GetLifecycleState()),
+		}
+		if resp.VolumeAttachment.GetLifecycleState() == core.VolumeAttachmentLifecycleStateAttached {
+			if snapshot.Properties, err = p.readVolumeAttachmentProperties(ctx, svc, resp.VolumeAttachment); err != nil {
+				return nil, err
+			}
+		}
+		return snapshot, nil
+	}
+
+	// ATTACHING and DETACHING stay in progress
+	result, err := util.PollLifecycle(ctx, "VolumeAttachment", request.RequestID, getVolumeAttachment, map[string]resource.OperationStatus{
+		string(core.VolumeAttachmentLifecycleStateAttached): resource.OperationStatusSuccess,
+		string(core.VolumeAttachmentLifecycleStateDetached): resource.OperationStatusSuccess,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &resource.StatusResult{ProgressResult: result}, nil
+}
+
+// List returns the live attachments of the instance or volume named by the
+// InstanceId or VolumeId additional property. CompartmentId is optional when
+// InstanceId is given; it is taken from the instance.
+func (p *VolumeAttachmentProvisioner) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Compute client: %w", err)
+	}
+
+	instanceId, hasInstance := request.AdditionalProperties["InstanceId"]
+	volumeId, hasVolume := request.AdditionalProperties["VolumeId"]
+	if !hasInstance && !hasVolume {
+		return nil, fmt.Errorf("InstanceId or VolumeId is required for listing VolumeAttachments")
+	}
+
+	compartmentId, ok := util.ListCompartmentId(request)
+	if !ok && hasInstance {
+		resp, err := svc.GetInstance(ctx, core.GetInstanceRequest{InstanceId: common.String(instanceId)})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Instance to derive CompartmentId: %w", err)
+		}
+		compartmentId, ok = *resp.CompartmentId, true
+	}
+	if !ok {
+		return nil, fmt.Errorf("CompartmentId is required for listing VolumeAttachments (either directly or derived from InstanceId)")
+	}
+
+	listReq := core.ListVolumeAttachmentsRequest{
+		CompartmentId: common.String(compartmentId),
+		Limit:         util.ListPageSize(request.TargetConfig),
+	}
+	if hasInstance {
+		listReq.InstanceId = common.String(instanceId)
+	}
+	if hasVolume {
+		listReq.VolumeId = common.String(volumeId)
+	}
+
+	nativeIDs, err := util.ListAllPages(func(page *string) ([]string, *string, error) {
+		listReq.Page = page
+		resp, err := svc.ListVolumeAttachments(ctx, listReq)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list VolumeAttachments: %w", err)
+		}
+		ids := make([]string, 0, len(resp.Items))
+		for _, att := range resp.Items {
+			if isDetached(att.GetLifecycleState()) {
+				continue
+			}
+			ids = append(ids, *att.GetId())
+		}
+		return ids, resp.OpcNextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &resource.ListResult{
+		NativeIDs: nativeIDs,
+	}, nil
+}
+
+// parseAttachVolumeDetails builds the attach request for the attachment Type:
+// "paravirtualized" (the default), "iscsi" or "emulated".
+func parseAttachVolumeDetails(props map[string]any) (core.AttachVolumeDetails, error) {
+	instanceId, _ := util.ExtractString(props, "InstanceId")
+	volumeId, _ := util.ExtractString(props, "VolumeId")
+	if instanceId == "" || volumeId == "" {
+		return nil, fmt.Errorf("InstanceId and VolumeId are required to attach a Volume")
+	}
+
+	var displayName, device *string
+	if v, ok := util.ExtractString(props, "DisplayName"); ok {
+		displayName = common.String(v)
+	}
+	if v, ok := util.ExtractString(props, "Device"); ok {
+		device = common.String(v)
+	}
+	var isReadOnly, isShareable *bool
+	if v, ok := util.ExtractBool(props, "IsReadOnly"); ok {
+		isReadOnly = common.Bool(v)
+	}
+	if v, ok := util.ExtractBool(props, "IsShareable"); ok {
+		isShareable = common.Bool(v)
+	}
+
+	attachmentType, _ := util.ExtractString(props, "Type")
+	switch attachmentType {
+	case "", "paravirtualized":
+		details := core.AttachParavirtualizedVolumeDetails{
+			InstanceId:  common.String(instanceId),
+			VolumeId:    common.String(volumeId),
+			DisplayName: displayName,
+			Device:      device,
+			IsReadOnly:  isReadOnly,
+			IsShareable: isShareable,
+		}
+		if v, ok := util.ExtractBool(props, "IsPvEncryptionInTransitEnabled"); ok {
+			details.IsPvEncryptionInTransitEnabled = common.Bool(v)
+		}
+		return details, nil
+	case "iscsi":
+		details := core.AttachIScsiVolumeDetails{
+			InstanceId:  common.String(instanceId),
+			VolumeId:    common.String(volumeId),
+			DisplayName: displayName,
+			Device:      device,
+			IsReadOnly:  isReadOnly,
+			IsShareable: isShareable,
+		}
+		if v, ok := util.ExtractBool(props, "UseChap"); ok {
+			details.UseChap = common.Bool(v)
+		}
+		if v, ok := util.ExtractBool(props, "IsAgentAutoIscsiLoginEnabled"); ok {
+			details.IsAgentAutoIscsiLoginEnabled = common.Bool(v)
+		}
+		return details, nil
+	case "emulated":
+		return core.AttachEmulatedVolumeDetails{
+			InstanceId:  common.String(instanceId),
+			VolumeId:    common.String(volumeId),
+			DisplayName: displayName,
+			Device:      device,
+			IsReadOnly:  isReadOnly,
+			IsShareable: isShareable,
+		}, nil
+	default:
+		return nil, fmt.Errorf("VolumeAttachment Type %q is not valid; use \"paravirtualized\", \"iscsi\" or \"emulated\"", attachmentType)
+	}
+}
+
+// readVolumeAttachmentProperties builds the attachment's properties, including
+// AttachedInstanceIds: every instance the volume is attached to, so a shared
+// volume's other attachments show up when diffing any one of them.
+func (p *VolumeAttachmentProvisioner) readVolumeAttachmentProperties(ctx context.Context, svc *core.ComputeClient, att core.VolumeAttachment) (map[string]any, error) {
+	properties := buildVolumeAttachmentProperties(att)
+
+	if att.GetIsShareable() == nil || !*att.GetIsShareable() {
+		return properties, nil
+	}
+
+	resp, err := svc.ListVolumeAttachments(ctx, core.ListVolumeAttachmentsRequest{
+		CompartmentId: att.GetCompartmentId(),
+		VolumeId:      att.GetVolumeId(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attachments of Volume %s: %w", *att.GetVolumeId(), err)
+	}
+	properties["AttachedInstanceIds"] = attachedInstanceIds(resp.Items)
+
+	return properties, nil
+}
+
+// attachedInstanceIds returns the sorted, de-duplicated instance OCIDs of the
+// attachments that are attached or on their way there.
+func attachedInstanceIds(attachments []core.VolumeAttachment) []string {
+	seen := map[string]bool{}
+	ids := []string{}
+	for _, att := range attachments {
+		switch att.GetLifecycleState() {
+		case core.VolumeAttachmentLifecycleStateAttaching, core.VolumeAttachmentLifecycleStateAttached:
+		default:
+			continue
+		}
+		if id := att.GetInstanceId(); id != nil && !seen[*id] {
+			seen[*id] = true
+			ids = append(ids, *id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// isDetached reports whether an attachment is detached or detaching. Such
+// attachments are on their way out and count as gone for Read and List.
+func isDetached(state core.VolumeAttachmentLifecycleStateEnum) bool {
+	return state == core.VolumeAttachmentLifecycleStateDetaching || state == core.VolumeAttachmentLifecycleStateDetached
+}
+
+func buildVolumeAttachmentProperties(att core.VolumeAttachment) map[string]any {
+	properties := map[string]any{
+		"Id":            *att.GetId(),
+		"InstanceId":    *att.GetInstanceId(),
+		"VolumeId":      *att.GetVolumeId(),
+		"CompartmentId": *att.GetCompartmentId(),
+	}
+
+	switch v := att.(type) {
+	case core.ParavirtualizedVolumeAttachment:
+		properties["Type"] = "paravirtualized"
+	case core.IScsiVolumeAttachment:
+		properties["Type"] = "iscsi"
+		if v.IsAgentAutoIscsiLoginEnabled != nil {
+			properties["IsAgentAutoIscsiLoginEnabled"] = *v.IsAgentAutoIscsiLoginEnabled
+		}
+		properties["UseChap"] = v.ChapUsername != nil
+	case core.EmulatedVolumeAttachment:
+		properties["Type"] = "emulated"
+	}
+
+	if att.GetAvailabilityDomain() != nil {
+		properties["AvailabilityDomain"] = *att.GetAvailabilityDomain()
+	}
+	if att.GetDisplayName() != nil {
+		properties["DisplayName"] = *att.GetDisplayName()
+	}
+	if att.GetDevice() != nil {
+		properties["Device"] = *att.GetDevice()
+	}
+	if att.GetIsReadOnly() != nil {
+		properties["IsReadOnly"] = *att.GetIsReadOnly()
+	}
+	if att.GetIsShareable() != nil {
+		properties["IsShareable"] = *att.GetIsShareable()
+	}
+	if att.GetIsPvEncryptionInTransitEnabled() != nil {
+		properties["IsPvEncryptionInTransitEnabled"] = *att.GetIsPvEncryptionInTransitEnabled()
+	}
+	if att.GetLifecycleState() != "" {
+		properties["LifecycleState"] = string(att.GetLifecycleState())
+	}
+	if att.GetTimeCreated() != nil {
+		properties["TimeCreated"] = att.GetTimeCreated().Format("2006-01-02T15:04:05.000Z")
+	}
+
+	return properties
+}
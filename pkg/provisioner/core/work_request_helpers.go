@@ -0,0 +1,60 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/workrequests"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+)
+
+// workRequestPoller adapts the Core Services WorkRequest client to
+// util.WorkRequestPoller, for the work requests Compute returns from e.g.
+// UpdateInstance.
+type workRequestPoller struct {
+	client *workrequests.WorkRequestClient
+}
+
+func (p workRequestPoller) GetWorkRequest(ctx context.Context, workRequestId string) (*util.WorkRequest, error) {
+	resp, err := p.client.GetWorkRequest(ctx, workrequests.GetWorkRequestRequest{
+		WorkRequestId: common.String(workRequestId),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work request %s: %w", workRequestId, err)
+	}
+
+	wr := &util.WorkRequest{Status: string(resp.Status)}
+	if resp.Status == workrequests.WorkRequestStatusFailed {
+		wr.FailureMessage = getWorkRequestErrors(ctx, p.client, workRequestId)
+	}
+	return wr, nil
+}
+
+// getWorkRequestErrors retrieves error messages from a failed WorkRequest
+func getWorkRequestErrors(ctx context.Context, client *workrequests.WorkRequestClient, workRequestId string) string {
+	resp, err := client.ListWorkRequestErrors(ctx, workrequests.ListWorkRequestErrorsRequest{
+		WorkRequestId: common.String(workRequestId),
+	})
+	if err != nil {
+		return fmt.Sprintf("Work request failed (could not retrieve error details: %v)", err)
+	}
+
+	var messages []string
+	for _, item := range resp.Items {
+		if item.Message != nil {
+			messages = append(messages, *item.Message)
+		}
+	}
+
+	if len(messages) == 0 {
+		return "Work request failed (no error details available)"
+	}
+
+	return strings.Join(messages, "; ")
+}
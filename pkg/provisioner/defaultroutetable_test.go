@@ -0,0 +1,96 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build integration
+
+package provisioner_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/core"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultRouteTableCreate(t *testing.T) {
+	t.Run("updates_the_vcn_default", func(t *testing.T) {
+		svc := newTestVirtualNetworkClient(t, map[route]canned{
+			{"GET", "/20160918/vcns/ocid1.vcn..aaa"}:                   {200, newTestVCNBody("AVAILABLE")},
+			{"PUT", "/20160918/routeTables/ocid1.routetable..default"}: {200, newTestRouteTableBody("AVAILABLE")},
+		})
+		p := core.NewDefaultRouteTableProvisionerWithSvc(svc)
+
+		props, err := json.Marshal(map[string]any{
+			"VcnId": "ocid1.vcn..aaa",
+			"RouteRules": []map[string]any{
+				{"networkEntityId": "ocid1.internetgateway..aaa", "destination": "0.0.0.0/0"},
+			},
+		})
+		require.NoError(t, err)
+
+		result, err := p.Create(context.Background(), &resource.CreateRequest{
+			ResourceType: "OCI::Core::DefaultRouteTable",
+			Properties:   props,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	})
+
+	t.Run("vcn_not_found", func(t *testing.T) {
+		svc := newTestVirtualNetworkClient(t, map[route]canned{
+			{"GET", "/20160918/vcns/ocid1.vcn..missing"}: {404, `{"code":"NotAuthorizedOrNotFound","message":"not found"}`},
+		})
+		p := core.NewDefaultRouteTableProvisionerWithSvc(svc)
+
+		props, err := json.Marshal(map[string]any{"VcnId": "ocid1.vcn..missing"})
+		require.NoError(t, err)
+
+		result, err := p.Create(context.Background(), &resource.CreateRequest{
+			ResourceType: "OCI::Core::DefaultRouteTable",
+			Properties:   props,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusFailure, result.ProgressResult.OperationStatus)
+		assert.Equal(t, resource.OperationErrorCodeNotFound, result.ProgressResult.ErrorCode)
+	})
+}
+
+func TestDefaultRouteTableRead(t *testing.T) {
+	svc := newTestVirtualNetworkClient(t, map[route]canned{
+		{"GET", "/20160918/routeTables/ocid1.routetable..aaa"}: {200, newTestRouteTableBody("AVAILABLE")},
+	})
+	p := core.NewDefaultRouteTableProvisionerWithSvc(svc)
+
+	result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.routetable..aaa"})
+	require.NoError(t, err)
+	assert.Equal(t, "OCI::Core::DefaultRouteTable", result.ResourceType)
+	assert.Contains(t, result.Properties, "ocid1.internetgateway..aaa")
+}
+
+func TestDefaultRouteTableDelete(t *testing.T) {
+	// No routes: deleting must not touch OCI
+	svc := newTestVirtualNetworkClient(t, map[route]canned{})
+	p := core.NewDefaultRouteTableProvisionerWithSvc(svc)
+
+	result, err := p.Delete(context.Background(), &resource.DeleteRequest{NativeID: "ocid1.routetable..default"})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+}
+
+func TestDefaultRouteTableList(t *testing.T) {
+	svc := newTestVirtualNetworkClient(t, map[route]canned{
+		{"GET", "/20160918/vcns/ocid1.vcn..aaa"}: {200, newTestVCNBody("AVAILABLE")},
+	})
+	p := core.NewDefaultRouteTableProvisionerWithSvc(svc)
+
+	result, err := p.List(context.Background(), &resource.ListRequest{
+		AdditionalProperties: map[string]string{"VcnId": "ocid1.vcn..aaa"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ocid1.routetable..default"}, result.NativeIDs)
+}
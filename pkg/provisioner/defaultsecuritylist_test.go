@@ -0,0 +1,83 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build integration
+
+package provisioner_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/core"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultSecurityListCreate(t *testing.T) {
+	svc := newTestVirtualNetworkClient(t, map[route]canned{
+		{"GET", "/20160918/vcns/ocid1.vcn..aaa"}:                       {200, newTestVCNBody("AVAILABLE")},
+		{"PUT", "/20160918/securityLists/ocid1.securitylist..default"}: {200, newTestSecurityListBody("AVAILABLE")},
+	})
+	p := core.NewDefaultSecurityListProvisionerWithSvc(svc)
+
+	props, err := json.Marshal(map[string]any{
+		"VcnId":                "ocid1.vcn..aaa",
+		"IngressSecurityRules": []any{},
+	})
+	require.NoError(t, err)
+
+	result, err := p.Create(context.Background(), &resource.CreateRequest{
+		ResourceType: "OCI::Core::DefaultSecurityList",
+		Properties:   props,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+}
+
+func TestDefaultSecurityListUpdate(t *testing.T) {
+	svc := newTestVirtualNetworkClient(t, map[route]canned{
+		{"GET", "/20160918/securityLists/ocid1.securitylist..aaa"}: {200, newTestSecurityListBody("AVAILABLE")},
+		{"PUT", "/20160918/securityLists/ocid1.securitylist..aaa"}: {200, newTestSecurityListBody("AVAILABLE")},
+	})
+	p := core.NewDefaultSecurityListProvisionerWithSvc(svc)
+
+	props, err := json.Marshal(map[string]any{"DisplayName": "locked-down"})
+	require.NoError(t, err)
+
+	result, err := p.Update(context.Background(), &resource.UpdateRequest{
+		NativeID:          "ocid1.securitylist..aaa",
+		ResourceType:      "OCI::Core::DefaultSecurityList",
+		DesiredProperties: props,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+}
+
+func TestDefaultSecurityListDelete(t *testing.T) {
+	// No routes: deleting must not touch OCI
+	svc := newTestVirtualNetworkClient(t, map[route]canned{})
+	p := core.NewDefaultSecurityListProvisionerWithSvc(svc)
+
+	result, err := p.Delete(context.Background(), &resource.DeleteRequest{NativeID: "ocid1.securitylist..default"})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+}
+
+func TestDefaultSecurityListDelete_SkipsConfirmation(t *testing.T) {
+	// Through the decorator chain: a confirming Read would find the list still
+	// there and fail the delete. No clients, so any OCI call would panic.
+	p := provisioner.Get("OCI::Core::DefaultSecurityList", nil)
+
+	result, err := p.Delete(context.Background(), &resource.DeleteRequest{
+		NativeID:     "ocid1.securitylist..default",
+		ResourceType: "OCI::Core::DefaultSecurityList",
+		TargetConfig: json.RawMessage(`{"DeleteConfirmInterval": "1ms"}`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+}
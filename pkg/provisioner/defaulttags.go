@@ -0,0 +1,135 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package provisioner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+// defaultTags is a decorator that applies the target's DefaultFreeformTags and
+// DefaultDefinedTags. Create gets them merged into its properties; Read has them
+// stripped again so they never show up as drift. Updates merge them back in
+// util.ApplyPatchDocument, which every Update path goes through.
+//
+// Create and Update also check the resource's own tags against OCI's tag
+// limits and fail without calling OCI when one is broken.
+//
+// Resource types without tags implement Untagged and are passed through.
+type defaultTags struct {
+	inner    Provisioner
+	untagged bool
+}
+
+// Untagged is implemented by provisioners of resource types that have no
+// FreeformTags or DefinedTags, such as NSG rules and load balancer children.
+// The target's default tags are never added to them.
+type Untagged interface {
+	HasNoTags()
+}
+
+// untagged reports whether p opts out of default tags.
+func untagged(p Provisioner) bool {
+	_, ok := p.(Untagged)
+	return ok
+}
+
+func (d *defaultTags) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	if d.untagged {
+		return d.inner.Create(ctx, request)
+	}
+
+	var props map[string]any
+	if err := json.Unmarshal(request.Properties, &props); err != nil {
+		return nil, fmt.Errorf("failed to parse properties: %w", err)
+	}
+	if err := util.ValidateTags(props); err != nil {
+		return &resource.CreateResult{ProgressResult: invalidTagsResult(resource.OperationCreate, "", err)}, nil
+	}
+
+	cfg := config.FromTargetConfig(request.TargetConfig)
+	if len(cfg.DefaultFreeformTags) == 0 && len(cfg.DefaultDefinedTags) == 0 {
+		return d.inner.Create(ctx, request)
+	}
+
+	util.MergeDefaultTags(props, cfg)
+	merged, err := json.Marshal(props)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal properties: %w", err)
+	}
+
+	tagged := *request
+	tagged.Properties = merged
+	return d.inner.Create(ctx, &tagged)
+}
+
+func (d *defaultTags) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	if d.untagged {
+		return d.inner.Update(util.WithoutDefaultTags(ctx), request)
+	}
+
+	if len(request.DesiredProperties) > 0 {
+		var props map[string]any
+		if err := json.Unmarshal(request.DesiredProperties, &props); err != nil {
+			return nil, fmt.Errorf("failed to parse properties: %w", err)
+		}
+		if err := util.ValidateTags(props); err != nil {
+			return &resource.UpdateResult{ProgressResult: invalidTagsResult(resource.OperationUpdate, request.NativeID, err)}, nil
+		}
+	}
+	return d.inner.Update(ctx, request)
+}
+
+func (d *defaultTags) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	return d.inner.Delete(ctx, request)
+}
+
+func (d *defaultTags) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	return d.inner.Status(ctx, request)
+}
+
+func (d *defaultTags) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	result, err := d.inner.Read(ctx, request)
+	if d.untagged || err != nil || result == nil || result.Properties == "" {
+		return result, err
+	}
+
+	cfg := config.FromTargetConfig(request.TargetConfig)
+	if len(cfg.DefaultFreeformTags) == 0 && len(cfg.DefaultDefinedTags) == 0 {
+		return result, nil
+	}
+
+	var props map[string]any
+	if err := json.Unmarshal([]byte(result.Properties), &props); err != nil {
+		return nil, fmt.Errorf("failed to parse properties: %w", err)
+	}
+	util.StripDefaultTags(props, cfg)
+	stripped, err := json.Marshal(props)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal properties: %w", err)
+	}
+	result.Properties = string(stripped)
+
+	return result, nil
+}
+
+func (d *defaultTags) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	return d.inner.List(ctx, request)
+}
+
+func invalidTagsResult(operation resource.Operation, nativeID string, err error) *resource.ProgressResult {
+	return &resource.ProgressResult{
+		Operation:       operation,
+		OperationStatus: resource.OperationStatusFailure,
+		ErrorCode:       resource.OperationErrorCodeInvalidRequest,
+		StatusMessage:   fmt.Sprintf("invalid tags: %v", err),
+		NativeID:        nativeID,
+	}
+}
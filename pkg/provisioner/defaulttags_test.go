@@ -0,0 +1,154 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package provisioner
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+const defaultTagsTargetConfig = `{"DefaultFreeformTags":[{"Key":"managed-by","Value":"formae"}]}`
+
+// capturingProvisioner records the properties Create was called with
+type capturingProvisioner struct {
+	mockProvisioner
+	createProps json.RawMessage
+}
+
+func (c *capturingProvisioner) Create(_ context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	c.createProps = request.Properties
+	return c.createResult, c.createErr
+}
+
+func TestDefaultTags_Create_MergesDefaults(t *testing.T) {
+	inner := &capturingProvisioner{
+		mockProvisioner: mockProvisioner{
+			createResult: &resource.CreateResult{ProgressResult: &resource.ProgressResult{}},
+		},
+	}
+
+	d := &defaultTags{inner: inner}
+	_, err := d.Create(context.Background(), &resource.CreateRequest{
+		ResourceType: "OCI::Core::Vcn",
+		Properties:   json.RawMessage(`{"FreeformTags":[{"Key":"Environment","Value":"dev"}]}`),
+		TargetConfig: json.RawMessage(defaultTagsTargetConfig),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `{"FreeformTags":[{"Key":"Environment","Value":"dev"},{"Key":"managed-by","Value":"formae"}]}`
+	if string(inner.createProps) != want {
+		t.Errorf("expected Create properties %s, got %s", want, inner.createProps)
+	}
+}
+
+func TestDefaultTags_Untagged_PassesThrough(t *testing.T) {
+	inner := &capturingProvisioner{
+		mockProvisioner: mockProvisioner{
+			createResult: &resource.CreateResult{ProgressResult: &resource.ProgressResult{}},
+		},
+	}
+
+	props := `{"NetworkSecurityGroupId":"ocid1.networksecuritygroup.oc1..abc","Direction":"INGRESS"}`
+	d := &defaultTags{inner: inner, untagged: true}
+	_, err := d.Create(context.Background(), &resource.CreateRequest{
+		ResourceType: "OCI::Core::NetworkSecurityGroupSecurityRule",
+		Properties:   json.RawMessage(props),
+		TargetConfig: json.RawMessage(defaultTagsTargetConfig),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(inner.createProps) != props {
+		t.Errorf("expected no default tags on an untagged type, got %s", inner.createProps)
+	}
+}
+
+func TestDefaultTags_Read_StripsDefaults(t *testing.T) {
+	inner := &mockProvisioner{
+		readResult: &resource.ReadResult{
+			Properties: `{"DisplayName":"vcn","FreeformTags":[{"Key":"managed-by","Value":"formae"}]}`,
+		},
+	}
+
+	d := &defaultTags{inner: inner}
+	result, err := d.Read(context.Background(), &resource.ReadRequest{
+		NativeID:     "ocid1.vcn.oc1..abc",
+		ResourceType: "OCI::Core::Vcn",
+		TargetConfig: json.RawMessage(defaultTagsTargetConfig),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Properties != `{"DisplayName":"vcn"}` {
+		t.Errorf("expected default tag to be stripped, got %s", result.Properties)
+	}
+}
+
+func TestDefaultTags_Read_NoDefaultsPassesThrough(t *testing.T) {
+	props := `{"FreeformTags":[{"Key":"managed-by","Value":"formae"}]}`
+	inner := &mockProvisioner{readResult: &resource.ReadResult{Properties: props}}
+
+	d := &defaultTags{inner: inner}
+	result, err := d.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.vcn.oc1..abc"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Properties != props {
+		t.Errorf("expected properties unchanged, got %s", result.Properties)
+	}
+}
+
+func TestDefaultTags_Create_RejectsInvalidTags(t *testing.T) {
+	inner := &capturingProvisioner{}
+
+	d := &defaultTags{inner: inner}
+	result, err := d.Create(context.Background(), &resource.CreateRequest{
+		ResourceType: "OCI::Core::Vcn",
+		Properties:   json.RawMessage(`{"FreeformTags":[{"Key":"cost center","Value":"42"}]}`),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if inner.createProps != nil {
+		t.Error("expected Create not to reach the provisioner")
+	}
+	if result.ProgressResult.ErrorCode != resource.OperationErrorCodeInvalidRequest {
+		t.Errorf("expected InvalidRequest, got %q", result.ProgressResult.ErrorCode)
+	}
+	if !strings.Contains(result.ProgressResult.StatusMessage, `FreeformTag "cost center"`) {
+		t.Errorf("expected the status message to name the tag, got %q", result.ProgressResult.StatusMessage)
+	}
+}
+
+func TestDefaultTags_Update_RejectsInvalidTags(t *testing.T) {
+	inner := &mockProvisioner{}
+
+	d := &defaultTags{inner: inner}
+	result, err := d.Update(context.Background(), &resource.UpdateRequest{
+		NativeID:          "ocid1.vcn.oc1..abc",
+		ResourceType:      "OCI::Core::Vcn",
+		DesiredProperties: json.RawMessage(`{"DefinedTags":[{"Namespace":"Finance","Key":"Cost.Center","Value":"42"}]}`),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if inner.updateCalled {
+		t.Error("expected Update not to reach the provisioner")
+	}
+	if result.ProgressResult.OperationStatus != resource.OperationStatusFailure {
+		t.Errorf("expected failure, got %q", result.ProgressResult.OperationStatus)
+	}
+}
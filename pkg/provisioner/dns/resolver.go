@@ -0,0 +1,668 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package dns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	ocicore "github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/oracle/oci-go-sdk/v65/dns"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+// ResolverProvisioner manages the private DNS resolver of a VCN. OCI creates
+// and deletes the resolver with its VCN, so Create adopts the VCN's resolver
+// and Delete only removes what this provisioner adds to it: endpoints,
+// forwarding rules and attached views.
+type ResolverProvisioner struct {
+	clients *client.Clients
+	svc     *dns.DnsClient                // nil until first use; injected in tests
+	vnet    *ocicore.VirtualNetworkClient // nil until first use; injected in tests
+}
+
+var _ provisioner.Provisioner = &ResolverProvisioner{}
+
+func init() {
+	provisioner.Register("OCI::DNS::Resolver", NewResolverProvisioner)
+	provisioner.DependsOn("OCI::DNS::Resolver", "OCI::DNS::View")
+}
+
+func NewResolverProvisioner(clients *client.Clients) provisioner.Provisioner {
+	return &ResolverProvisioner{clients: clients}
+}
+
+// NewResolverProvisionerWithSvc constructs a provisioner with pre-built SDK clients,
+// for use in tests that point the clients at an httptest server.
+func NewResolverProvisionerWithSvc(svc *dns.DnsClient, vnet *ocicore.VirtualNetworkClient) *ResolverProvisioner {
+	return &ResolverProvisioner{svc: svc, vnet: vnet}
+}
+
+func (p *ResolverProvisioner) getSvc() (*dns.DnsClient, error) {
+	if p.svc != nil {
+		return p.svc, nil
+	}
+	return p.clients.GetDnsClient()
+}
+
+func (p *ResolverProvisioner) getVnet() (*ocicore.VirtualNetworkClient, error) {
+	if p.vnet != nil {
+		return p.vnet, nil
+	}
+	return p.clients.GetVirtualNetworkClient()
+}
+
+// resolverEndpoint is one entry of the Endpoints property.
+type resolverEndpoint struct {
+	name              string
+	subnetId          string
+	isForwarding      bool
+	isListening       bool
+	forwardingAddress string
+	listeningAddress  string
+	nsgIds            []string
+}
+
+func (p *ResolverProvisioner) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	var props map[string]any
+	if err := json.Unmarshal(request.Properties, &props); err != nil {
+		return nil, fmt.Errorf("failed to parse properties: %w", err)
+	}
+
+	vcnId, ok := util.ExtractResolvedReference(props, "VcnId")
+	if !ok {
+		return nil, fmt.Errorf("VcnId is required")
+	}
+
+	vnet, err := p.getVnet()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VirtualNetwork client: %w", err)
+	}
+	assoc, err := vnet.GetVcnDnsResolverAssociation(ctx, ocicore.GetVcnDnsResolverAssociationRequest{
+		VcnId: common.String(vcnId),
+	})
+	if err != nil {
+		if result, handleErr := util.HandleCreateError(err, "OCI::DNS::Resolver", "OCI::DNS::Resolver"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to get DNS resolver of VCN %s: %w", vcnId, err)
+	}
+	if assoc.DnsResolverId == nil {
+		return nil, fmt.Errorf("VCN %s has no DNS resolver yet", vcnId)
+	}
+	resolverId := *assoc.DnsResolverId
+
+	if err := p.apply(ctx, svc, resolverId, props); err != nil {
+		if result, handleErr := util.HandleCreateError(err, "OCI::DNS::Resolver", "OCI::DNS::Resolver"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to configure Resolver: %w", err)
+	}
+
+	// Endpoints come up asynchronously — return in-progress, poll in Status()
+	return &resource.CreateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationCreate,
+			OperationStatus: resource.OperationStatusInProgress,
+			NativeID:        resolverId,
+			RequestID:       resolverId,
+		},
+	}, nil
+}
+
+func (p *ResolverProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	resp, err := svc.GetResolver(ctx, dns.GetResolverRequest{
+		ResolverId: common.String(request.NativeID),
+	})
+	if err != nil {
+		if util.IsNotFound(err) {
+			return &resource.ReadResult{
+				ResourceType: "OCI::DNS::Resolver",
+				ErrorCode:    resource.OperationErrorCodeNotFound,
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to read Resolver: %w", err)
+	}
+
+	// Treat terminal lifecycle states as NotFound
+	if util.IsTerminal(string(resp.LifecycleState)) {
+		return &resource.ReadResult{
+			ResourceType: "OCI::DNS::Resolver",
+			ErrorCode:    resource.OperationErrorCodeNotFound,
+		}, nil
+	}
+
+	properties, err := p.readResolverProperties(ctx, svc, resp.Resolver, config.FromTargetConfig(request.TargetConfig).HiddenTagNamespaces())
+	if err != nil {
+		return nil, err
+	}
+
+	propBytes, err := json.Marshal(properties)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Resolver properties: %w", err)
+	}
+
+	return &resource.ReadResult{
+		ResourceType: "OCI::DNS::Resolver",
+		Properties:   string(propBytes),
+	}, nil
+}
+
+func (p *ResolverProvisioner) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	props, err := util.ApplyPatchDocument(ctx, request, p.Read)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.apply(ctx, svc, request.NativeID, props); err != nil {
+		if result, handleErr := util.HandleUpdateError(err, "OCI::DNS::Resolver", request.NativeID, "OCI::DNS::Resolver"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to update Resolver: %w", err)
+	}
+
+	return &resource.UpdateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationUpdate,
+			OperationStatus: resource.OperationStatusInProgress,
+			NativeID:        request.NativeID,
+			RequestID:       request.NativeID,
+		},
+	}, nil
+}
+
+// Delete detaches the views, drops the rules and deletes every endpoint. The
+// resolver itself stays until its VCN is deleted.
+func (p *ResolverProvisioner) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	readRes, err := p.Read(ctx, &resource.ReadRequest{NativeID: request.NativeID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Resolver before delete: %w", err)
+	}
+	if readRes.ErrorCode == resource.OperationErrorCodeNotFound {
+		return &resource.DeleteResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationDelete,
+				OperationStatus: resource.OperationStatusSuccess,
+				NativeID:        request.NativeID,
+			},
+		}, nil
+	}
+
+	// Rules go first, since they may forward through the endpoints
+	_, err = svc.UpdateResolver(ctx, dns.UpdateResolverRequest{
+		ResolverId: common.String(request.NativeID),
+		UpdateResolverDetails: dns.UpdateResolverDetails{
+			AttachedViews: []dns.AttachedViewDetails{},
+			Rules:         []dns.ResolverRuleDetails{},
+		},
+	})
+	if err != nil {
+		if result, handleErr := util.HandleDeleteError(err, "OCI::DNS::Resolver", request.NativeID, "OCI::DNS::Resolver"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to clear Resolver rules and views: %w", err)
+	}
+
+	existing, err := listResolverEndpoints(ctx, svc, request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+	for _, endpoint := range existing {
+		if err := deleteResolverEndpoint(ctx, svc, request.NativeID, *endpoint.GetName()); err != nil {
+			return nil, err
+		}
+	}
+
+	return &resource.DeleteResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationDelete,
+			OperationStatus: resource.OperationStatusInProgress,
+			NativeID:        request.NativeID,
+			RequestID:       request.NativeID,
+		},
+	}, nil
+}
+
+// Status reports the resolver as ACTIVE once it and all of its endpoints are,
+// and as FAILED when an endpoint failed to come up.
+func (p *ResolverProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	getResolver := func(ctx context.Context) (*util.LifecycleSnapshot, error) {
+		resp, err := svc.GetResolver(ctx, dns.GetResolverRequest{
+			ResolverId: common.String(request.RequestID),
+		})
+		if err != nil {
+			if util.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to check Resolver status: %w", err)
+		}
+		endpoints, err := listResolverEndpoints(ctx, svc, request.RequestID)
+		if err != nil {
+			return nil, err
+		}
+
+		snapshot := &util.LifecycleSnapshot{
+			NativeID: *resp.Id,
+			State:    resolverState(resp.LifecycleState, endpoints),
+		}
+		if snapshot.State == string(dns.ResolverLifecycleStateActive) {
+			if snapshot.Properties, err = p.readResolverProperties(ctx, svc, resp.Resolver, config.FromTargetConfig(request.TargetConfig).HiddenTagNamespaces()); err != nil {
+				return nil, err
+			}
+		}
+		return snapshot, nil
+	}
+
+	result, err := util.PollLifecycle(ctx, "Resolver", request.RequestID, getResolver, map[string]resource.OperationStatus{
+		string(dns.ResolverLifecycleStateActive): resource.OperationStatusSuccess,
+		string(dns.ResolverLifecycleStateFailed): resource.OperationStatusFailure,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &resource.StatusResult{ProgressResult: result}, nil
+}
+
+// resolverState folds the resolver's endpoints into its lifecycle state: the
+// first endpoint that isn't ACTIVE decides it, with FAILED taking precedence.
+func resolverState(state dns.ResolverLifecycleStateEnum, endpoints []dns.ResolverEndpointSummary) string {
+	if state != dns.ResolverLifecycleStateActive {
+		return string(state)
+	}
+	pending := ""
+	for _, endpoint := range endpoints {
+		switch endpoint.GetLifecycleState() {
+		case dns.ResolverEndpointSummaryLifecycleStateActive:
+		case dns.ResolverEndpointSummaryLifecycleStateFailed:
+			return string(dns.ResolverLifecycleStateFailed)
+		default:
+			if pending == "" {
+				pending = string(endpoint.GetLifecycleState())
+			}
+		}
+	}
+	if pending != "" {
+		return pending
+	}
+	return string(state)
+}
+
+func (p *ResolverProvisioner) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	compartmentId, ok := util.ListCompartmentId(request)
+	if !ok {
+		return nil, fmt.Errorf("CompartmentId is required for listing Resolvers")
+	}
+
+	listReq := dns.ListResolversRequest{
+		CompartmentId: common.String(compartmentId),
+		Limit:         common.Int64(int64(*util.ListPageSize(request.TargetConfig))),
+	}
+
+	nativeIDs, err := util.ListAllPages(func(page *string) ([]string, *string, error) {
+		listReq.Page = page
+		resp, err := svc.ListResolvers(ctx, listReq)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list Resolvers: %w", err)
+		}
+		ids := make([]string, 0, len(resp.Items))
+		for _, resolver := range resp.Items {
+			if util.IsTerminal(string(resolver.LifecycleState)) {
+				continue
+			}
+			ids = append(ids, *resolver.Id)
+		}
+		return ids, resp.OpcNextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &resource.ListResult{
+		NativeIDs: nativeIDs,
+	}, nil
+}
+
+// apply brings the resolver in line with props. New endpoints are created
+// before the resolver update so rules can forward through them, and dropped
+// ones deleted after it, once no rule refers to them.
+func (p *ResolverProvisioner) apply(ctx context.Context, svc *dns.DnsClient, resolverId string, props map[string]any) error {
+	desired, err := parseResolverEndpoints(props)
+	if err != nil {
+		return err
+	}
+	existing, err := listResolverEndpoints(ctx, svc, resolverId)
+	if err != nil {
+		return err
+	}
+	current := make(map[string]dns.ResolverEndpointSummary, len(existing))
+	for _, endpoint := range existing {
+		current[*endpoint.GetName()] = endpoint
+	}
+
+	for _, endpoint := range desired {
+		if _, ok := current[endpoint.name]; !ok {
+			if err := createResolverEndpoint(ctx, svc, resolverId, endpoint); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := updateResolverEndpoint(ctx, svc, resolverId, endpoint); err != nil {
+			return err
+		}
+	}
+
+	updateDetails := dns.UpdateResolverDetails{
+		AttachedViews: []dns.AttachedViewDetails{},
+		Rules:         parseResolverRules(props),
+	}
+	if viewIds, ok := util.ExtractStringSlice(props, "AttachedViewIds"); ok {
+		for _, viewId := range viewIds {
+			updateDetails.AttachedViews = append(updateDetails.AttachedViews, dns.AttachedViewDetails{ViewId: common.String(viewId)})
+		}
+	}
+	if displayName, ok := util.ExtractString(props, "DisplayName"); ok {
+		updateDetails.DisplayName = common.String(displayName)
+	}
+	if freeformTags, ok := util.ExtractFreeformTags(props, "FreeformTags"); ok {
+		updateDetails.FreeformTags = freeformTags
+	}
+	if definedTags, ok := util.ExtractDefinedTags(props, "DefinedTags"); ok {
+		updateDetails.DefinedTags = definedTags
+	}
+	if _, err := svc.UpdateResolver(ctx, dns.UpdateResolverRequest{
+		ResolverId:            common.String(resolverId),
+		UpdateResolverDetails: updateDetails,
+	}); err != nil {
+		return err
+	}
+
+	for name := range current {
+		if !slices.ContainsFunc(desired, func(e resolverEndpoint) bool { return e.name == name }) {
+			if err := deleteResolverEndpoint(ctx, svc, resolverId, name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// parseResolverEndpoints reads Endpoints, a list of {name, subnetId,
+// isForwarding, isListening, forwardingAddress, listeningAddress, nsgIds}.
+func parseResolverEndpoints(props map[string]any) ([]resolverEndpoint, error) {
+	var endpoints []resolverEndpoint
+	for _, m := range mapList(props, "Endpoints") {
+		endpoint := resolverEndpoint{}
+		var ok bool
+		if endpoint.name, ok = util.ExtractString(m, "name"); !ok {
+			return nil, fmt.Errorf("every resolver endpoint needs a name")
+		}
+		if endpoint.subnetId, ok = util.ExtractResolvedReference(m, "subnetId"); !ok {
+			return nil, fmt.Errorf("resolver endpoint %s needs a subnetId", endpoint.name)
+		}
+		endpoint.isForwarding, _ = util.ExtractBool(m, "isForwarding")
+		endpoint.isListening, _ = util.ExtractBool(m, "isListening")
+		if !endpoint.isForwarding && !endpoint.isListening {
+			return nil, fmt.Errorf("resolver endpoint %s must be forwarding, listening or both", endpoint.name)
+		}
+		endpoint.forwardingAddress, _ = util.ExtractString(m, "forwardingAddress")
+		endpoint.listeningAddress, _ = util.ExtractString(m, "listeningAddress")
+		endpoint.nsgIds, _ = util.ExtractStringSlice(m, "nsgIds")
+		endpoints = append(endpoints, endpoint)
+	}
+	return endpoints, nil
+}
+
+// parseResolverRules reads Rules, a list of forwarding rules {action:
+// FORWARD, sourceEndpointName, destinationAddresses, clientAddressConditions,
+// qnameCoverConditions}. FORWARD is the only action OCI offers.
+func parseResolverRules(props map[string]any) []dns.ResolverRuleDetails {
+	rules := []dns.ResolverRuleDetails{}
+	for _, m := range mapList(props, "Rules") {
+		rule := dns.ResolverForwardRuleDetails{
+			SourceEndpointName: optionalString(m, "sourceEndpointName"),
+		}
+		rule.DestinationAddresses, _ = util.ExtractStringSlice(m, "destinationAddresses")
+		rule.ClientAddressConditions, _ = util.ExtractStringSlice(m, "clientAddressConditions")
+		rule.QnameCoverConditions, _ = util.ExtractStringSlice(m, "qnameCoverConditions")
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+func createResolverEndpoint(ctx context.Context, svc *dns.DnsClient, resolverId string, endpoint resolverEndpoint) error {
+	details := dns.CreateResolverVnicEndpointDetails{
+		Name:         common.String(endpoint.name),
+		SubnetId:     common.String(endpoint.subnetId),
+		IsForwarding: common.Bool(endpoint.isForwarding),
+		IsListening:  common.Bool(endpoint.isListening),
+		NsgIds:       endpoint.nsgIds,
+	}
+	if endpoint.forwardingAddress != "" {
+		details.ForwardingAddress = common.String(endpoint.forwardingAddress)
+	}
+	if endpoint.listeningAddress != "" {
+		details.ListeningAddress = common.String(endpoint.listeningAddress)
+	}
+	if _, err := svc.CreateResolverEndpoint(ctx, dns.CreateResolverEndpointRequest{
+		ResolverId:                    common.String(resolverId),
+		CreateResolverEndpointDetails: details,
+	}); err != nil {
+		return fmt.Errorf("failed to create resolver endpoint %s: %w", endpoint.name, err)
+	}
+	return nil
+}
+
+// updateResolverEndpoint applies the one endpoint field OCI can change in
+// place, its NSGs. The others are fixed, so changing one means renaming the
+// endpoint to replace it.
+func updateResolverEndpoint(ctx context.Context, svc *dns.DnsClient, resolverId string, endpoint resolverEndpoint) error {
+	resp, err := svc.GetResolverEndpoint(ctx, dns.GetResolverEndpointRequest{
+		ResolverId:           common.String(resolverId),
+		ResolverEndpointName: common.String(endpoint.name),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read resolver endpoint %s: %w", endpoint.name, err)
+	}
+	current, ok := resp.ResolverEndpoint.(dns.ResolverVnicEndpoint)
+	if !ok {
+		return fmt.Errorf("resolver endpoint %s is not a VNIC endpoint", endpoint.name)
+	}
+
+	if (current.SubnetId != nil && *current.SubnetId != endpoint.subnetId) ||
+		*current.IsForwarding != endpoint.isForwarding ||
+		*current.IsListening != endpoint.isListening ||
+		(endpoint.forwardingAddress != "" && current.ForwardingAddress != nil && *current.ForwardingAddress != endpoint.forwardingAddress) ||
+		(endpoint.listeningAddress != "" && current.ListeningAddress != nil && *current.ListeningAddress != endpoint.listeningAddress) {
+		return fmt.Errorf("resolver endpoint %s can only change its nsgIds; give it a new name to replace it", endpoint.name)
+	}
+
+	if slices.Equal(current.NsgIds, endpoint.nsgIds) {
+		return nil
+	}
+	nsgIds := endpoint.nsgIds
+	if nsgIds == nil {
+		nsgIds = []string{}
+	}
+	if _, err := svc.UpdateResolverEndpoint(ctx, dns.UpdateResolverEndpointRequest{
+		ResolverId:                    common.String(resolverId),
+		ResolverEndpointName:          common.String(endpoint.name),
+		UpdateResolverEndpointDetails: dns.UpdateResolverVnicEndpointDetails{NsgIds: nsgIds},
+	}); err != nil {
+		return fmt.Errorf("failed to update resolver endpoint %s: %w", endpoint.name, err)
+	}
+	return nil
+}
+
+func deleteResolverEndpoint(ctx context.Context, svc *dns.DnsClient, resolverId, name string) error {
+	_, err := svc.DeleteResolverEndpoint(ctx, dns.DeleteResolverEndpointRequest{
+		ResolverId:           common.String(resolverId),
+		ResolverEndpointName: common.String(name),
+	})
+	if err != nil && !util.IsNotFound(err) {
+		return fmt.Errorf("failed to delete resolver endpoint %s: %w", name, err)
+	}
+	return nil
+}
+
+// listResolverEndpoints returns the endpoints of the resolver that still
+// exist, including ones being created or deleted.
+func listResolverEndpoints(ctx context.Context, svc *dns.DnsClient, resolverId string) ([]dns.ResolverEndpointSummary, error) {
+	listReq := dns.ListResolverEndpointsRequest{
+		ResolverId: common.String(resolverId),
+	}
+
+	return util.ListAllPages(func(page *string) ([]dns.ResolverEndpointSummary, *string, error) {
+		listReq.Page = page
+		resp, err := svc.ListResolverEndpoints(ctx, listReq)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list resolver endpoints: %w", err)
+		}
+		endpoints := make([]dns.ResolverEndpointSummary, 0, len(resp.Items))
+		for _, endpoint := range resp.Items {
+			if endpoint.GetLifecycleState() == dns.ResolverEndpointSummaryLifecycleStateDeleted {
+				continue
+			}
+			endpoints = append(endpoints, endpoint)
+		}
+		return endpoints, resp.OpcNextPage, nil
+	})
+}
+
+// readResolverProperties builds the resolver's properties. Endpoints carry
+// the addresses OCI assigned, so forwarding rules on other resolvers and
+// on-premises DNS servers can refer to them; those being deleted are left out.
+func (p *ResolverProvisioner) readResolverProperties(ctx context.Context, svc *dns.DnsClient, resolver dns.Resolver, ignoredTagNamespaces []string) (map[string]any, error) {
+	properties := map[string]any{
+		"Id":    *resolver.Id,
+		"Rules": buildResolverRules(resolver.Rules),
+	}
+
+	if resolver.AttachedVcnId != nil {
+		properties["VcnId"] = *resolver.AttachedVcnId
+	}
+	if resolver.CompartmentId != nil {
+		properties["CompartmentId"] = *resolver.CompartmentId
+	}
+	if resolver.DisplayName != nil {
+		properties["DisplayName"] = *resolver.DisplayName
+	}
+	if resolver.DefaultViewId != nil {
+		properties["DefaultViewId"] = *resolver.DefaultViewId
+	}
+	viewIds := make([]string, 0, len(resolver.AttachedViews))
+	for _, view := range resolver.AttachedViews {
+		if view.ViewId != nil {
+			viewIds = append(viewIds, *view.ViewId)
+		}
+	}
+	properties["AttachedViewIds"] = viewIds
+	if resolver.FreeformTags != nil {
+		properties["FreeformTags"] = util.FreeformTagsToList(resolver.FreeformTags)
+	}
+	if resolver.DefinedTags != nil {
+		properties["DefinedTags"] = util.DefinedTagsToList(resolver.DefinedTags, ignoredTagNamespaces)
+	}
+
+	summaries, err := listResolverEndpoints(ctx, svc, *resolver.Id)
+	if err != nil {
+		return nil, err
+	}
+	endpoints := make([]map[string]any, 0, len(summaries))
+	for _, summary := range summaries {
+		if util.IsTerminal(string(summary.GetLifecycleState())) {
+			continue
+		}
+		resp, err := svc.GetResolverEndpoint(ctx, dns.GetResolverEndpointRequest{
+			ResolverId:           resolver.Id,
+			ResolverEndpointName: summary.GetName(),
+		})
+		if err != nil {
+			if util.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read resolver endpoint %s: %w", *summary.GetName(), err)
+		}
+		endpoint, ok := resp.ResolverEndpoint.(dns.ResolverVnicEndpoint)
+		if !ok {
+			continue
+		}
+		m := map[string]any{
+			"name":         *endpoint.Name,
+			"isForwarding": *endpoint.IsForwarding,
+			"isListening":  *endpoint.IsListening,
+		}
+		setString(m, "subnetId", endpoint.SubnetId)
+		setString(m, "forwardingAddress", endpoint.ForwardingAddress)
+		setString(m, "listeningAddress", endpoint.ListeningAddress)
+		if len(endpoint.NsgIds) > 0 {
+			m["nsgIds"] = endpoint.NsgIds
+		}
+		endpoints = append(endpoints, m)
+	}
+	properties["Endpoints"] = endpoints
+
+	return properties, nil
+}
+
+func buildResolverRules(rules []dns.ResolverRule) []map[string]any {
+	result := make([]map[string]any, 0, len(rules))
+	for _, rule := range rules {
+		r, ok := rule.(dns.ResolverForwardRule)
+		if !ok {
+			continue
+		}
+		m := map[string]any{
+			"action":               string(dns.ResolverRuleActionForward),
+			"destinationAddresses": r.DestinationAddresses,
+		}
+		setString(m, "sourceEndpointName", r.SourceEndpointName)
+		if len(r.ClientAddressConditions) > 0 {
+			m["clientAddressConditions"] = r.ClientAddressConditions
+		}
+		if len(r.QnameCoverConditions) > 0 {
+			m["qnameCoverConditions"] = r.QnameCoverConditions
+		}
+		result = append(result, m)
+	}
+	return result
+}
@@ -0,0 +1,375 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package dns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/dns"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+// RrSetProvisioner manages the records of one type at one domain of a zone.
+// The set has no OCID; its NativeID is {zoneNameOrId}/{domain}/{rtype}.
+type RrSetProvisioner struct {
+	clients *client.Clients
+	svc     *dns.DnsClient // nil until first use; injected in tests
+}
+
+var _ provisioner.Provisioner = &RrSetProvisioner{}
+
+func init() {
+	provisioner.Register("OCI::DNS::RrSet", NewRrSetProvisioner)
+	provisioner.DependsOn("OCI::DNS::RrSet", "OCI::DNS::Zone")
+}
+
+func NewRrSetProvisioner(clients *client.Clients) provisioner.Provisioner {
+	return &RrSetProvisioner{clients: clients}
+}
+
+// NewRrSetProvisionerWithSvc constructs a provisioner with a pre-built SDK client,
+// for use in tests that point the client at an httptest server.
+func NewRrSetProvisionerWithSvc(svc *dns.DnsClient) *RrSetProvisioner {
+	return &RrSetProvisioner{svc: svc}
+}
+
+// ImmutableFields: the zone, domain and type make up the NativeID.
+func (p *RrSetProvisioner) ImmutableFields() []string {
+	return []string{"ZoneNameOrId", "Domain", "Rtype"}
+}
+
+func (p *RrSetProvisioner) getSvc() (*dns.DnsClient, error) {
+	if p.svc != nil {
+		return p.svc, nil
+	}
+	return p.clients.GetDnsClient()
+}
+
+// rrSetKey names a record set: its zone, domain and record type.
+type rrSetKey struct {
+	zone, domain, rtype string
+}
+
+func (k rrSetKey) nativeID() string {
+	return util.EncodeCompositeID(k.zone, k.domain, k.rtype)
+}
+
+func parseRrSetNativeID(nativeID string) (rrSetKey, error) {
+	parts, err := util.DecodeCompositeID(nativeID, 3)
+	if err != nil {
+		return rrSetKey{}, err
+	}
+	return rrSetKey{zone: parts[0], domain: parts[1], rtype: parts[2]}, nil
+}
+
+// HasNoTags: records belong to their zone, which holds the tags.
+func (p *RrSetProvisioner) HasNoTags() {}
+
+// Create adds the records with PatchDomainRecords, leaving the domain's other
+// record types alone. Records of the same type already at the domain are kept
+// too, so the first Read shows them and the next Update replaces them.
+func (p *RrSetProvisioner) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	var props map[string]any
+	if err := json.Unmarshal(request.Properties, &props); err != nil {
+		return nil, fmt.Errorf("failed to parse properties: %w", err)
+	}
+
+	key, err := parseRrSetKey(props)
+	if err != nil {
+		return nil, err
+	}
+	records, err := parseRrSetItems(props, key)
+	if err != nil {
+		return nil, err
+	}
+
+	operations := make([]dns.RecordOperation, 0, len(records))
+	for _, record := range records {
+		operations = append(operations, dns.RecordOperation{
+			Domain:    record.Domain,
+			Rdata:     record.Rdata,
+			Rtype:     record.Rtype,
+			Ttl:       record.Ttl,
+			Operation: dns.RecordOperationOperationAdd,
+		})
+	}
+
+	_, err = svc.PatchDomainRecords(ctx, dns.PatchDomainRecordsRequest{
+		ZoneNameOrId:              common.String(key.zone),
+		Domain:                    common.String(key.domain),
+		PatchDomainRecordsDetails: dns.PatchDomainRecordsDetails{Items: operations},
+	})
+	if err != nil {
+		if result, handleErr := util.HandleCreateError(err, "OCI::DNS::RrSet", "OCI::DNS::RrSet"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to create RrSet: %w", err)
+	}
+
+	return &resource.CreateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationCreate,
+			OperationStatus: resource.OperationStatusSuccess,
+			NativeID:        key.nativeID(),
+		},
+	}, nil
+}
+
+func (p *RrSetProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	key, err := parseRrSetNativeID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := util.ListAllPages(func(page *string) ([]dns.Record, *string, error) {
+		resp, err := svc.GetRRSet(ctx, dns.GetRRSetRequest{
+			ZoneNameOrId: common.String(key.zone),
+			Domain:       common.String(key.domain),
+			Rtype:        common.String(key.rtype),
+			Page:         page,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Items, resp.OpcNextPage, nil
+	})
+	if err != nil {
+		if util.IsNotFound(err) {
+			return &resource.ReadResult{
+				ResourceType: "OCI::DNS::RrSet",
+				ErrorCode:    resource.OperationErrorCodeNotFound,
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to read RrSet: %w", err)
+	}
+
+	// An rrset with no records doesn't exist
+	if len(records) == 0 {
+		return &resource.ReadResult{
+			ResourceType: "OCI::DNS::RrSet",
+			ErrorCode:    resource.OperationErrorCodeNotFound,
+		}, nil
+	}
+
+	propBytes, err := json.Marshal(buildRrSetProperties(key, records))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal RrSet properties: %w", err)
+	}
+
+	return &resource.ReadResult{
+		ResourceType: "OCI::DNS::RrSet",
+		Properties:   string(propBytes),
+	}, nil
+}
+
+// Update replaces the whole set with UpdateRRSet.
+func (p *RrSetProvisioner) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	key, err := parseRrSetNativeID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	props, err := util.ApplyPatchDocument(ctx, request, p.Read)
+	if err != nil {
+		return nil, err
+	}
+	records, err := parseRrSetItems(props, key)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = svc.UpdateRRSet(ctx, dns.UpdateRRSetRequest{
+		ZoneNameOrId:       common.String(key.zone),
+		Domain:             common.String(key.domain),
+		Rtype:              common.String(key.rtype),
+		UpdateRrSetDetails: dns.UpdateRrSetDetails{Items: records},
+	})
+	if err != nil {
+		if result, handleErr := util.HandleUpdateError(err, "OCI::DNS::RrSet", request.NativeID, "OCI::DNS::RrSet"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to update RrSet: %w", err)
+	}
+
+	return &resource.UpdateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationUpdate,
+			OperationStatus: resource.OperationStatusSuccess,
+			NativeID:        request.NativeID,
+		},
+	}, nil
+}
+
+func (p *RrSetProvisioner) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	key, err := parseRrSetNativeID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = svc.DeleteRRSet(ctx, dns.DeleteRRSetRequest{
+		ZoneNameOrId: common.String(key.zone),
+		Domain:       common.String(key.domain),
+		Rtype:        common.String(key.rtype),
+	})
+	// A missing zone takes its records with it
+	if err != nil && !util.IsNotFound(err) {
+		if result, handleErr := util.HandleDeleteError(err, "OCI::DNS::RrSet", request.NativeID, "OCI::DNS::RrSet"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to delete RrSet: %w", err)
+	}
+
+	return &resource.DeleteResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationDelete,
+			OperationStatus: resource.OperationStatusSuccess,
+			NativeID:        request.NativeID,
+		},
+	}, nil
+}
+
+func (p *RrSetProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	return util.SyncStatus(ctx, request, p.Read)
+}
+
+// List returns one NativeID per domain and type in the zone named by the
+// ZoneId additional property, skipping the SOA and apex NS records OCI
+// protects.
+func (p *RrSetProvisioner) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	zoneId, ok := request.AdditionalProperties["ZoneId"]
+	if !ok || zoneId == "" {
+		return nil, fmt.Errorf("ZoneId is required for listing RrSets")
+	}
+
+	seen := map[rrSetKey]bool{}
+	nativeIDs, err := util.ListAllPages(func(page *string) ([]string, *string, error) {
+		resp, err := svc.GetZoneRecords(ctx, dns.GetZoneRecordsRequest{
+			ZoneNameOrId: common.String(zoneId),
+			Limit:        common.Int64(int64(*util.ListPageSize(request.TargetConfig))),
+			Page:         page,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		ids := []string{}
+		for _, record := range resp.Items {
+			if record.Domain == nil || record.Rtype == nil || (record.IsProtected != nil && *record.IsProtected) {
+				continue
+			}
+			key := rrSetKey{zone: zoneId, domain: *record.Domain, rtype: *record.Rtype}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			ids = append(ids, key.nativeID())
+		}
+		return ids, resp.OpcNextPage, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list RrSets: %w", err)
+	}
+
+	return &resource.ListResult{
+		NativeIDs: nativeIDs,
+	}, nil
+}
+
+func parseRrSetKey(props map[string]any) (rrSetKey, error) {
+	var key rrSetKey
+	var ok bool
+	if key.zone, ok = util.ExtractResolvedReference(props, "ZoneNameOrId"); !ok {
+		return key, fmt.Errorf("ZoneNameOrId is required")
+	}
+	if key.domain, ok = util.ExtractString(props, "Domain"); !ok {
+		return key, fmt.Errorf("Domain is required")
+	}
+	if key.rtype, ok = util.ExtractString(props, "Rtype"); !ok {
+		return key, fmt.Errorf("Rtype is required")
+	}
+	return key, nil
+}
+
+// parseRrSetItems reads Items, a list of {rdata, ttl, rtype}. An item's rtype
+// defaults to the set's and must match it.
+func parseRrSetItems(props map[string]any, key rrSetKey) ([]dns.RecordDetails, error) {
+	items := mapList(props, "Items")
+	if len(items) == 0 {
+		return nil, fmt.Errorf("Items needs at least one record")
+	}
+
+	records := make([]dns.RecordDetails, 0, len(items))
+	for i, m := range items {
+		rdata, ok := util.ExtractString(m, "rdata")
+		if !ok {
+			return nil, fmt.Errorf("Items[%d] needs rdata", i)
+		}
+		ttl, ok := extractInt(m, "ttl")
+		if !ok {
+			return nil, fmt.Errorf("Items[%d] needs a ttl", i)
+		}
+		if rtype, ok := util.ExtractString(m, "rtype"); ok && !strings.EqualFold(rtype, key.rtype) {
+			return nil, fmt.Errorf("Items[%d] has rtype %s but the set is %s", i, rtype, key.rtype)
+		}
+		records = append(records, dns.RecordDetails{
+			Domain: common.String(key.domain),
+			Rdata:  common.String(rdata),
+			Rtype:  common.String(key.rtype),
+			Ttl:    common.Int(ttl),
+		})
+	}
+	return records, nil
+}
+
+func buildRrSetProperties(key rrSetKey, records []dns.Record) map[string]any {
+	items := make([]map[string]any, 0, len(records))
+	for _, record := range records {
+		item := map[string]any{}
+		setString(item, "rdata", record.Rdata)
+		setString(item, "rtype", record.Rtype)
+		if record.Ttl != nil {
+			item["ttl"] = *record.Ttl
+		}
+		items = append(items, item)
+	}
+
+	return map[string]any{
+		"ZoneNameOrId": key.zone,
+		"Domain":       key.domain,
+		"Rtype":        key.rtype,
+		"Items":        items,
+	}
+}
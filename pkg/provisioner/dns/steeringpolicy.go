@@ -0,0 +1,693 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package dns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/dns"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+type SteeringPolicyProvisioner struct {
+	clients *client.Clients
+	svc     *dns.DnsClient // nil until first use; injected in tests
+}
+
+var _ provisioner.Provisioner = &SteeringPolicyProvisioner{}
+
+func init() {
+	provisioner.Register("OCI::DNS::SteeringPolicy", NewSteeringPolicyProvisioner)
+	provisioner.DependsOn("OCI::DNS::SteeringPolicy", "OCI::Identity::Compartment")
+}
+
+func NewSteeringPolicyProvisioner(clients *client.Clients) provisioner.Provisioner {
+	return &SteeringPolicyProvisioner{clients: clients}
+}
+
+// NewSteeringPolicyProvisionerWithSvc constructs a provisioner with a pre-built SDK client,
+// for use in tests that point the client at an httptest server.
+func NewSteeringPolicyProvisionerWithSvc(svc *dns.DnsClient) *SteeringPolicyProvisioner {
+	return &SteeringPolicyProvisioner{svc: svc}
+}
+
+func (p *SteeringPolicyProvisioner) getSvc() (*dns.DnsClient, error) {
+	if p.svc != nil {
+		return p.svc, nil
+	}
+	return p.clients.GetDnsClient()
+}
+
+func (p *SteeringPolicyProvisioner) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	var props map[string]any
+	if err := json.Unmarshal(request.Properties, &props); err != nil {
+		return nil, fmt.Errorf("failed to parse properties: %w", err)
+	}
+
+	compartmentId, ok := util.ExtractString(props, "CompartmentId")
+	if !ok {
+		return nil, fmt.Errorf("CompartmentId is required")
+	}
+	displayName, ok := util.ExtractString(props, "DisplayName")
+	if !ok {
+		return nil, fmt.Errorf("DisplayName is required")
+	}
+	template, ok := util.ExtractString(props, "Template")
+	if !ok {
+		return nil, fmt.Errorf("Template is required")
+	}
+
+	rules, err := parseSteeringPolicyRules(props)
+	if err != nil {
+		return nil, err
+	}
+
+	createDetails := dns.CreateSteeringPolicyDetails{
+		CompartmentId: common.String(compartmentId),
+		DisplayName:   common.String(displayName),
+		Template:      dns.CreateSteeringPolicyDetailsTemplateEnum(template),
+		Answers:       parseSteeringPolicyAnswers(props),
+		Rules:         rules,
+	}
+	if ttl, ok := extractInt(props, "Ttl"); ok {
+		createDetails.Ttl = common.Int(ttl)
+	}
+	if monitorId, ok := util.ExtractString(props, "HealthCheckMonitorId"); ok {
+		createDetails.HealthCheckMonitorId = common.String(monitorId)
+	}
+	if freeformTags, ok := util.ExtractFreeformTags(props, "FreeformTags"); ok {
+		createDetails.FreeformTags = freeformTags
+	}
+	if definedTags, ok := util.ExtractDefinedTags(props, "DefinedTags"); ok {
+		createDetails.DefinedTags = definedTags
+	}
+
+	resp, err := svc.CreateSteeringPolicy(ctx, dns.CreateSteeringPolicyRequest{
+		OpcRetryToken:               common.String(util.CreateRetryToken(request)),
+		CreateSteeringPolicyDetails: createDetails,
+	})
+	if err != nil {
+		if result, handleErr := util.HandleCreateError(err, "OCI::DNS::SteeringPolicy", "OCI::DNS::SteeringPolicy"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to create SteeringPolicy: %w", err)
+	}
+
+	// The policy starts out CREATING — return in-progress, poll lifecycle in Status()
+	return &resource.CreateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationCreate,
+			OperationStatus: resource.OperationStatusInProgress,
+			NativeID:        *resp.Id,
+			RequestID:       *resp.Id,
+		},
+	}, nil
+}
+
+func (p *SteeringPolicyProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	resp, err := svc.GetSteeringPolicy(ctx, dns.GetSteeringPolicyRequest{
+		SteeringPolicyId: common.String(request.NativeID),
+	})
+	if err != nil {
+		if util.IsNotFound(err) {
+			return &resource.ReadResult{
+				ResourceType: "OCI::DNS::SteeringPolicy",
+				ErrorCode:    resource.OperationErrorCodeNotFound,
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to read SteeringPolicy: %w", err)
+	}
+
+	// Treat terminal lifecycle states as NotFound
+	if util.IsTerminal(string(resp.LifecycleState)) {
+		return &resource.ReadResult{
+			ResourceType: "OCI::DNS::SteeringPolicy",
+			ErrorCode:    resource.OperationErrorCodeNotFound,
+		}, nil
+	}
+
+	properties := buildSteeringPolicyProperties(resp.SteeringPolicy, config.FromTargetConfig(request.TargetConfig).HiddenTagNamespaces())
+
+	propBytes, err := json.Marshal(properties)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal SteeringPolicy properties: %w", err)
+	}
+
+	return &resource.ReadResult{
+		ResourceType: "OCI::DNS::SteeringPolicy",
+		Properties:   string(propBytes),
+	}, nil
+}
+
+// Update replaces the answers and rules as a whole; OCI has no per-entry
+// operations for either list.
+func (p *SteeringPolicyProvisioner) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	props, err := util.ApplyPatchDocument(ctx, request, p.Read)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := parseSteeringPolicyRules(props)
+	if err != nil {
+		return nil, err
+	}
+
+	updateDetails := dns.UpdateSteeringPolicyDetails{
+		Answers: parseSteeringPolicyAnswers(props),
+		Rules:   rules,
+	}
+	if displayName, ok := util.ExtractString(props, "DisplayName"); ok {
+		updateDetails.DisplayName = common.String(displayName)
+	}
+	if template, ok := util.ExtractString(props, "Template"); ok {
+		updateDetails.Template = dns.UpdateSteeringPolicyDetailsTemplateEnum(template)
+	}
+	if ttl, ok := extractInt(props, "Ttl"); ok {
+		updateDetails.Ttl = common.Int(ttl)
+	}
+	if monitorId, ok := util.ExtractString(props, "HealthCheckMonitorId"); ok {
+		updateDetails.HealthCheckMonitorId = common.String(monitorId)
+	}
+	if freeformTags, ok := util.ExtractFreeformTags(props, "FreeformTags"); ok {
+		updateDetails.FreeformTags = freeformTags
+	}
+	if definedTags, ok := util.ExtractDefinedTags(props, "DefinedTags"); ok {
+		updateDetails.DefinedTags = definedTags
+	}
+
+	resp, err := svc.UpdateSteeringPolicy(ctx, dns.UpdateSteeringPolicyRequest{
+		SteeringPolicyId:            common.String(request.NativeID),
+		UpdateSteeringPolicyDetails: updateDetails,
+	})
+	if err != nil {
+		if result, handleErr := util.HandleUpdateError(err, "OCI::DNS::SteeringPolicy", request.NativeID, "OCI::DNS::SteeringPolicy"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to update SteeringPolicy: %w", err)
+	}
+
+	return &resource.UpdateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationUpdate,
+			OperationStatus: resource.OperationStatusSuccess,
+			NativeID:        *resp.Id,
+		},
+	}, nil
+}
+
+func (p *SteeringPolicyProvisioner) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	readRes, err := p.Read(ctx, &resource.ReadRequest{NativeID: request.NativeID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SteeringPolicy before delete: %w", err)
+	}
+	if readRes.ErrorCode == resource.OperationErrorCodeNotFound {
+		return &resource.DeleteResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationDelete,
+				OperationStatus: resource.OperationStatusSuccess,
+				NativeID:        request.NativeID,
+			},
+		}, nil
+	}
+
+	_, err = svc.DeleteSteeringPolicy(ctx, dns.DeleteSteeringPolicyRequest{
+		SteeringPolicyId: common.String(request.NativeID),
+	})
+	if err != nil {
+		if result, handleErr := util.HandleDeleteError(err, "OCI::DNS::SteeringPolicy", request.NativeID, "OCI::DNS::SteeringPolicy"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to delete SteeringPolicy: %w", err)
+	}
+
+	return &resource.DeleteResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationDelete,
+			OperationStatus: resource.OperationStatusInProgress,
+			NativeID:        request.NativeID,
+			RequestID:       request.NativeID,
+		},
+	}, nil
+}
+
+func (p *SteeringPolicyProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	getSteeringPolicy := func(ctx context.Context) (*util.LifecycleSnapshot, error) {
+		resp, err := svc.GetSteeringPolicy(ctx, dns.GetSteeringPolicyRequest{
+			SteeringPolicyId: common.String(request.RequestID),
+		})
+		if err != nil {
+			if util.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to check SteeringPolicy status: %w", err)
+		}
+		return &util.LifecycleSnapshot{
+			NativeID:   *resp.Id,
+			State:      string(resp.LifecycleState),
+			Properties: buildSteeringPolicyProperties(resp.SteeringPolicy, config.FromTargetConfig(request.TargetConfig).HiddenTagNamespaces()),
+		}, nil
+	}
+
+	// CREATING and DELETING stay in progress
+	result, err := util.PollLifecycle(ctx, "SteeringPolicy", request.RequestID, getSteeringPolicy, map[string]resource.OperationStatus{
+		string(dns.SteeringPolicyLifecycleStateActive):  resource.OperationStatusSuccess,
+		string(dns.SteeringPolicyLifecycleStateDeleted): resource.OperationStatusSuccess,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &resource.StatusResult{ProgressResult: result}, nil
+}
+
+func (p *SteeringPolicyProvisioner) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	compartmentId, ok := util.ListCompartmentId(request)
+	if !ok {
+		return nil, fmt.Errorf("CompartmentId is required for listing SteeringPolicies")
+	}
+
+	listReq := dns.ListSteeringPoliciesRequest{
+		CompartmentId: common.String(compartmentId),
+		Limit:         common.Int64(int64(*util.ListPageSize(request.TargetConfig))),
+	}
+
+	nativeIDs, err := util.ListAllPages(func(page *string) ([]string, *string, error) {
+		listReq.Page = page
+		resp, err := svc.ListSteeringPolicies(ctx, listReq)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list SteeringPolicies: %w", err)
+		}
+		ids := make([]string, 0, len(resp.Items))
+		for _, policy := range resp.Items {
+			if util.IsTerminal(string(policy.LifecycleState)) {
+				continue
+			}
+			ids = append(ids, *policy.Id)
+		}
+		return ids, resp.OpcNextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &resource.ListResult{
+		NativeIDs: nativeIDs,
+	}, nil
+}
+
+// parseSteeringPolicyAnswers reads Answers, a list of
+// {name, rtype, rdata, pool, isDisabled}.
+func parseSteeringPolicyAnswers(props map[string]any) []dns.SteeringPolicyAnswer {
+	items, _ := props["Answers"].([]any)
+	answers := make([]dns.SteeringPolicyAnswer, 0, len(items))
+	for _, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		answer := dns.SteeringPolicyAnswer{
+			Name:  optionalString(m, "name"),
+			Rtype: optionalString(m, "rtype"),
+			Rdata: optionalString(m, "rdata"),
+			Pool:  optionalString(m, "pool"),
+		}
+		if disabled, ok := util.ExtractBool(m, "isDisabled"); ok {
+			answer.IsDisabled = common.Bool(disabled)
+		}
+		answers = append(answers, answer)
+	}
+	return answers
+}
+
+// parseSteeringPolicyRules converts the Rules property. Every rule carries a
+// "ruleType" discriminator; cases and defaultAnswerData take the fields that
+// rule type uses: shouldKeep for FILTER, value for PRIORITY and WEIGHTED, and
+// count/defaultCount for LIMIT.
+func parseSteeringPolicyRules(props map[string]any) ([]dns.SteeringPolicyRule, error) {
+	items, _ := props["Rules"].([]any)
+	rules := make([]dns.SteeringPolicyRule, 0, len(items))
+	for _, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		rule, err := parseSteeringPolicyRule(m)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func parseSteeringPolicyRule(m map[string]any) (dns.SteeringPolicyRule, error) {
+	ruleType, _ := util.ExtractString(m, "ruleType")
+	description := optionalString(m, "description")
+	cases := mapList(m, "cases")
+	switch dns.SteeringPolicyRuleRuleTypeEnum(ruleType) {
+	case dns.SteeringPolicyRuleRuleTypeFilter:
+		rule := dns.SteeringPolicyFilterRule{Description: description, DefaultAnswerData: parseFilterAnswerData(m, "defaultAnswerData")}
+		for _, c := range cases {
+			rule.Cases = append(rule.Cases, dns.SteeringPolicyFilterRuleCase{
+				CaseCondition: optionalString(c, "caseCondition"),
+				AnswerData:    parseFilterAnswerData(c, "answerData"),
+			})
+		}
+		return rule, nil
+	case dns.SteeringPolicyRuleRuleTypeHealth:
+		rule := dns.SteeringPolicyHealthRule{Description: description}
+		for _, c := range cases {
+			rule.Cases = append(rule.Cases, dns.SteeringPolicyHealthRuleCase{CaseCondition: optionalString(c, "caseCondition")})
+		}
+		return rule, nil
+	case dns.SteeringPolicyRuleRuleTypeLimit:
+		rule := dns.SteeringPolicyLimitRule{Description: description}
+		if count, ok := extractInt(m, "defaultCount"); ok {
+			rule.DefaultCount = common.Int(count)
+		}
+		for _, c := range cases {
+			count, ok := extractInt(c, "count")
+			if !ok {
+				return nil, fmt.Errorf("every LIMIT rule case needs a count")
+			}
+			rule.Cases = append(rule.Cases, dns.SteeringPolicyLimitRuleCase{
+				CaseCondition: optionalString(c, "caseCondition"),
+				Count:         common.Int(count),
+			})
+		}
+		return rule, nil
+	case dns.SteeringPolicyRuleRuleTypePriority:
+		defaults, err := parsePriorityAnswerData(m, "defaultAnswerData")
+		if err != nil {
+			return nil, err
+		}
+		rule := dns.SteeringPolicyPriorityRule{Description: description, DefaultAnswerData: defaults}
+		for _, c := range cases {
+			answerData, err := parsePriorityAnswerData(c, "answerData")
+			if err != nil {
+				return nil, err
+			}
+			rule.Cases = append(rule.Cases, dns.SteeringPolicyPriorityRuleCase{
+				CaseCondition: optionalString(c, "caseCondition"),
+				AnswerData:    answerData,
+			})
+		}
+		return rule, nil
+	case dns.SteeringPolicyRuleRuleTypeWeighted:
+		defaults, err := parseWeightedAnswerData(m, "defaultAnswerData")
+		if err != nil {
+			return nil, err
+		}
+		rule := dns.SteeringPolicyWeightedRule{Description: description, DefaultAnswerData: defaults}
+		for _, c := range cases {
+			answerData, err := parseWeightedAnswerData(c, "answerData")
+			if err != nil {
+				return nil, err
+			}
+			rule.Cases = append(rule.Cases, dns.SteeringPolicyWeightedRuleCase{
+				CaseCondition: optionalString(c, "caseCondition"),
+				AnswerData:    answerData,
+			})
+		}
+		return rule, nil
+	}
+	return nil, fmt.Errorf("unsupported steering policy rule type %q", ruleType)
+}
+
+func parseFilterAnswerData(m map[string]any, key string) []dns.SteeringPolicyFilterAnswerData {
+	var data []dns.SteeringPolicyFilterAnswerData
+	for _, d := range mapList(m, key) {
+		entry := dns.SteeringPolicyFilterAnswerData{AnswerCondition: optionalString(d, "answerCondition")}
+		if keep, ok := util.ExtractBool(d, "shouldKeep"); ok {
+			entry.ShouldKeep = common.Bool(keep)
+		}
+		data = append(data, entry)
+	}
+	return data
+}
+
+func parsePriorityAnswerData(m map[string]any, key string) ([]dns.SteeringPolicyPriorityAnswerData, error) {
+	var data []dns.SteeringPolicyPriorityAnswerData
+	for _, d := range mapList(m, key) {
+		value, ok := extractInt(d, "value")
+		if !ok {
+			return nil, fmt.Errorf("every PRIORITY rule %s entry needs a value", key)
+		}
+		data = append(data, dns.SteeringPolicyPriorityAnswerData{
+			AnswerCondition: optionalString(d, "answerCondition"),
+			Value:           common.Int(value),
+		})
+	}
+	return data, nil
+}
+
+func parseWeightedAnswerData(m map[string]any, key string) ([]dns.SteeringPolicyWeightedAnswerData, error) {
+	var data []dns.SteeringPolicyWeightedAnswerData
+	for _, d := range mapList(m, key) {
+		value, ok := extractInt(d, "value")
+		if !ok {
+			return nil, fmt.Errorf("every WEIGHTED rule %s entry needs a value", key)
+		}
+		data = append(data, dns.SteeringPolicyWeightedAnswerData{
+			AnswerCondition: optionalString(d, "answerCondition"),
+			Value:           common.Int(value),
+		})
+	}
+	return data, nil
+}
+
+func buildSteeringPolicyProperties(policy dns.SteeringPolicy, ignoredTagNamespaces []string) map[string]any {
+	properties := map[string]any{
+		"Id":       *policy.Id,
+		"Template": string(policy.Template),
+		"Answers":  buildSteeringPolicyAnswers(policy.Answers),
+		"Rules":    buildSteeringPolicyRules(policy.Rules),
+	}
+
+	if policy.CompartmentId != nil {
+		properties["CompartmentId"] = *policy.CompartmentId
+	}
+	if policy.DisplayName != nil {
+		properties["DisplayName"] = *policy.DisplayName
+	}
+	if policy.Ttl != nil {
+		properties["Ttl"] = *policy.Ttl
+	}
+	if policy.HealthCheckMonitorId != nil {
+		properties["HealthCheckMonitorId"] = *policy.HealthCheckMonitorId
+	}
+	if policy.FreeformTags != nil {
+		properties["FreeformTags"] = util.FreeformTagsToList(policy.FreeformTags)
+	}
+	if policy.DefinedTags != nil {
+		properties["DefinedTags"] = util.DefinedTagsToList(policy.DefinedTags, ignoredTagNamespaces)
+	}
+
+	return properties
+}
+
+// buildSteeringPolicyAnswers keeps the API's order, which rules refer to
+// through answer conditions and priorities.
+func buildSteeringPolicyAnswers(answers []dns.SteeringPolicyAnswer) []map[string]any {
+	result := make([]map[string]any, 0, len(answers))
+	for _, answer := range answers {
+		m := map[string]any{}
+		setString(m, "name", answer.Name)
+		setString(m, "rtype", answer.Rtype)
+		setString(m, "rdata", answer.Rdata)
+		setString(m, "pool", answer.Pool)
+		if answer.IsDisabled != nil {
+			m["isDisabled"] = *answer.IsDisabled
+		}
+		result = append(result, m)
+	}
+	return result
+}
+
+func buildSteeringPolicyRules(rules []dns.SteeringPolicyRule) []map[string]any {
+	result := make([]map[string]any, 0, len(rules))
+	for _, rule := range rules {
+		m := map[string]any{}
+		var cases []map[string]any
+		switch r := rule.(type) {
+		case dns.SteeringPolicyFilterRule:
+			m["ruleType"] = string(dns.SteeringPolicyRuleRuleTypeFilter)
+			setString(m, "description", r.Description)
+			for _, c := range r.Cases {
+				entry := map[string]any{}
+				setString(entry, "caseCondition", c.CaseCondition)
+				setList(entry, "answerData", buildFilterAnswerData(c.AnswerData))
+				cases = append(cases, entry)
+			}
+			setList(m, "defaultAnswerData", buildFilterAnswerData(r.DefaultAnswerData))
+		case dns.SteeringPolicyHealthRule:
+			m["ruleType"] = string(dns.SteeringPolicyRuleRuleTypeHealth)
+			setString(m, "description", r.Description)
+			for _, c := range r.Cases {
+				entry := map[string]any{}
+				setString(entry, "caseCondition", c.CaseCondition)
+				cases = append(cases, entry)
+			}
+		case dns.SteeringPolicyLimitRule:
+			m["ruleType"] = string(dns.SteeringPolicyRuleRuleTypeLimit)
+			setString(m, "description", r.Description)
+			for _, c := range r.Cases {
+				entry := map[string]any{}
+				setString(entry, "caseCondition", c.CaseCondition)
+				if c.Count != nil {
+					entry["count"] = *c.Count
+				}
+				cases = append(cases, entry)
+			}
+			if r.DefaultCount != nil {
+				m["defaultCount"] = *r.DefaultCount
+			}
+		case dns.SteeringPolicyPriorityRule:
+			m["ruleType"] = string(dns.SteeringPolicyRuleRuleTypePriority)
+			setString(m, "description", r.Description)
+			for _, c := range r.Cases {
+				entry := map[string]any{}
+				setString(entry, "caseCondition", c.CaseCondition)
+				setList(entry, "answerData", buildPriorityAnswerData(c.AnswerData))
+				cases = append(cases, entry)
+			}
+			setList(m, "defaultAnswerData", buildPriorityAnswerData(r.DefaultAnswerData))
+		case dns.SteeringPolicyWeightedRule:
+			m["ruleType"] = string(dns.SteeringPolicyRuleRuleTypeWeighted)
+			setString(m, "description", r.Description)
+			for _, c := range r.Cases {
+				entry := map[string]any{}
+				setString(entry, "caseCondition", c.CaseCondition)
+				setList(entry, "answerData", buildWeightedAnswerData(c.AnswerData))
+				cases = append(cases, entry)
+			}
+			setList(m, "defaultAnswerData", buildWeightedAnswerData(r.DefaultAnswerData))
+		default:
+			// Rule types this provisioner does not manage are skipped rather
+			// than surfaced half-populated
+			continue
+		}
+		setList(m, "cases", cases)
+		result = append(result, m)
+	}
+	return result
+}
+
+func buildFilterAnswerData(data []dns.SteeringPolicyFilterAnswerData) []map[string]any {
+	result := make([]map[string]any, 0, len(data))
+	for _, d := range data {
+		m := map[string]any{}
+		setString(m, "answerCondition", d.AnswerCondition)
+		if d.ShouldKeep != nil {
+			m["shouldKeep"] = *d.ShouldKeep
+		}
+		result = append(result, m)
+	}
+	return result
+}
+
+func buildPriorityAnswerData(data []dns.SteeringPolicyPriorityAnswerData) []map[string]any {
+	result := make([]map[string]any, 0, len(data))
+	for _, d := range data {
+		m := map[string]any{}
+		setString(m, "answerCondition", d.AnswerCondition)
+		if d.Value != nil {
+			m["value"] = *d.Value
+		}
+		result = append(result, m)
+	}
+	return result
+}
+
+func buildWeightedAnswerData(data []dns.SteeringPolicyWeightedAnswerData) []map[string]any {
+	result := make([]map[string]any, 0, len(data))
+	for _, d := range data {
+		m := map[string]any{}
+		setString(m, "answerCondition", d.AnswerCondition)
+		if d.Value != nil {
+			m["value"] = *d.Value
+		}
+		result = append(result, m)
+	}
+	return result
+}
+
+// mapList returns the objects in the list under key, skipping anything else.
+func mapList(m map[string]any, key string) []map[string]any {
+	items, _ := m[key].([]any)
+	result := make([]map[string]any, 0, len(items))
+	for _, item := range items {
+		if entry, ok := item.(map[string]any); ok {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+func extractInt(m map[string]any, key string) (int, bool) {
+	v, ok := m[key].(float64)
+	if !ok {
+		return 0, false
+	}
+	return int(v), true
+}
+
+func optionalString(m map[string]any, key string) *string {
+	if v, ok := util.ExtractString(m, key); ok {
+		return common.String(v)
+	}
+	return nil
+}
+
+func setString(m map[string]any, key string, v *string) {
+	if v != nil {
+		m[key] = *v
+	}
+}
+
+// setList sets key only for a non-empty list, so an omitted list and one the
+// API returns empty read back the same.
+func setList(m map[string]any, key string, v []map[string]any) {
+	if len(v) > 0 {
+		m[key] = v
+	}
+}
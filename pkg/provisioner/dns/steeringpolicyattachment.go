@@ -0,0 +1,342 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package dns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/dns"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+type SteeringPolicyAttachmentProvisioner struct {
+	clients *client.Clients
+	svc     *dns.DnsClient // nil until first use; injected in tests
+}
+
+var _ provisioner.Provisioner = &SteeringPolicyAttachmentProvisioner{}
+
+func init() {
+	provisioner.Register("OCI::DNS::SteeringPolicyAttachment", NewSteeringPolicyAttachmentProvisioner)
+	provisioner.DependsOn("OCI::DNS::SteeringPolicyAttachment", "OCI::DNS::SteeringPolicy", "OCI::DNS::Zone")
+}
+
+func NewSteeringPolicyAttachmentProvisioner(clients *client.Clients) provisioner.Provisioner {
+	return &SteeringPolicyAttachmentProvisioner{clients: clients}
+}
+
+// NewSteeringPolicyAttachmentProvisionerWithSvc constructs a provisioner with a pre-built SDK client,
+// for use in tests that point the client at an httptest server.
+func NewSteeringPolicyAttachmentProvisionerWithSvc(svc *dns.DnsClient) *SteeringPolicyAttachmentProvisioner {
+	return &SteeringPolicyAttachmentProvisioner{svc: svc}
+}
+
+func (p *SteeringPolicyAttachmentProvisioner) getSvc() (*dns.DnsClient, error) {
+	if p.svc != nil {
+		return p.svc, nil
+	}
+	return p.clients.GetDnsClient()
+}
+
+// HasNoTags: attachments take no tags; the steering policy does.
+func (p *SteeringPolicyAttachmentProvisioner) HasNoTags() {}
+
+func (p *SteeringPolicyAttachmentProvisioner) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	var props map[string]any
+	if err := json.Unmarshal(request.Properties, &props); err != nil {
+		return nil, fmt.Errorf("failed to parse properties: %w", err)
+	}
+
+	steeringPolicyId, ok := util.ExtractResolvedReference(props, "SteeringPolicyId")
+	if !ok {
+		return nil, fmt.Errorf("SteeringPolicyId is required")
+	}
+	zoneId, ok := util.ExtractResolvedReference(props, "ZoneId")
+	if !ok {
+		return nil, fmt.Errorf("ZoneId is required")
+	}
+	domainName, ok := util.ExtractString(props, "DomainName")
+	if !ok {
+		return nil, fmt.Errorf("DomainName is required")
+	}
+
+	createDetails := dns.CreateSteeringPolicyAttachmentDetails{
+		SteeringPolicyId: common.String(steeringPolicyId),
+		ZoneId:           common.String(zoneId),
+		DomainName:       common.String(domainName),
+	}
+	if displayName, ok := util.ExtractString(props, "DisplayName"); ok {
+		createDetails.DisplayName = common.String(displayName)
+	}
+
+	resp, err := svc.CreateSteeringPolicyAttachment(ctx, dns.CreateSteeringPolicyAttachmentRequest{
+		OpcRetryToken:                         common.String(util.CreateRetryToken(request)),
+		CreateSteeringPolicyAttachmentDetails: createDetails,
+	})
+	if err != nil {
+		if result, handleErr := util.HandleCreateError(err, "OCI::DNS::SteeringPolicyAttachment", "OCI::DNS::SteeringPolicyAttachment"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to create SteeringPolicyAttachment: %w", err)
+	}
+
+	// The attachment starts out CREATING while the zone records are
+	// generated — return in-progress, poll lifecycle in Status()
+	return &resource.CreateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationCreate,
+			OperationStatus: resource.OperationStatusInProgress,
+			NativeID:        *resp.Id,
+			RequestID:       *resp.Id,
+		},
+	}, nil
+}
+
+func (p *SteeringPolicyAttachmentProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	resp, err := svc.GetSteeringPolicyAttachment(ctx, dns.GetSteeringPolicyAttachmentRequest{
+		SteeringPolicyAttachmentId: common.String(request.NativeID),
+	})
+	if err != nil {
+		if util.IsNotFound(err) {
+			return &resource.ReadResult{
+				ResourceType: "OCI::DNS::SteeringPolicyAttachment",
+				ErrorCode:    resource.OperationErrorCodeNotFound,
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to read SteeringPolicyAttachment: %w", err)
+	}
+
+	// Treat terminal lifecycle states as NotFound
+	if util.IsTerminal(string(resp.LifecycleState)) {
+		return &resource.ReadResult{
+			ResourceType: "OCI::DNS::SteeringPolicyAttachment",
+			ErrorCode:    resource.OperationErrorCodeNotFound,
+		}, nil
+	}
+
+	propBytes, err := json.Marshal(buildSteeringPolicyAttachmentProperties(resp.SteeringPolicyAttachment))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal SteeringPolicyAttachment properties: %w", err)
+	}
+
+	return &resource.ReadResult{
+		ResourceType: "OCI::DNS::SteeringPolicyAttachment",
+		Properties:   string(propBytes),
+	}, nil
+}
+
+// Update can only rename the attachment; the policy, zone and domain are
+// fixed at creation.
+func (p *SteeringPolicyAttachmentProvisioner) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	props, err := util.ApplyPatchDocument(ctx, request, p.Read)
+	if err != nil {
+		return nil, err
+	}
+
+	updateDetails := dns.UpdateSteeringPolicyAttachmentDetails{}
+	if displayName, ok := util.ExtractString(props, "DisplayName"); ok {
+		updateDetails.DisplayName = common.String(displayName)
+	}
+
+	resp, err := svc.UpdateSteeringPolicyAttachment(ctx, dns.UpdateSteeringPolicyAttachmentRequest{
+		SteeringPolicyAttachmentId:            common.String(request.NativeID),
+		UpdateSteeringPolicyAttachmentDetails: updateDetails,
+	})
+	if err != nil {
+		if result, handleErr := util.HandleUpdateError(err, "OCI::DNS::SteeringPolicyAttachment", request.NativeID, "OCI::DNS::SteeringPolicyAttachment"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to update SteeringPolicyAttachment: %w", err)
+	}
+
+	return &resource.UpdateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationUpdate,
+			OperationStatus: resource.OperationStatusSuccess,
+			NativeID:        *resp.Id,
+		},
+	}, nil
+}
+
+func (p *SteeringPolicyAttachmentProvisioner) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	readRes, err := p.Read(ctx, &resource.ReadRequest{NativeID: request.NativeID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SteeringPolicyAttachment before delete: %w", err)
+	}
+	if readRes.ErrorCode == resource.OperationErrorCodeNotFound {
+		return &resource.DeleteResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationDelete,
+				OperationStatus: resource.OperationStatusSuccess,
+				NativeID:        request.NativeID,
+			},
+		}, nil
+	}
+
+	_, err = svc.DeleteSteeringPolicyAttachment(ctx, dns.DeleteSteeringPolicyAttachmentRequest{
+		SteeringPolicyAttachmentId: common.String(request.NativeID),
+	})
+	if err != nil {
+		if result, handleErr := util.HandleDeleteError(err, "OCI::DNS::SteeringPolicyAttachment", request.NativeID, "OCI::DNS::SteeringPolicyAttachment"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to delete SteeringPolicyAttachment: %w", err)
+	}
+
+	// Deleting is async — return in-progress, poll until the attachment is gone
+	return &resource.DeleteResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationDelete,
+			OperationStatus: resource.OperationStatusInProgress,
+			NativeID:        request.NativeID,
+			RequestID:       request.NativeID,
+		},
+	}, nil
+}
+
+func (p *SteeringPolicyAttachmentProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	getAttachment := func(ctx context.Context) (*util.LifecycleSnapshot, error) {
+		resp, err := svc.GetSteeringPolicyAttachment(ctx, dns.GetSteeringPolicyAttachmentRequest{
+			SteeringPolicyAttachmentId: common.String(request.RequestID),
+		})
+		if err != nil {
+			if util.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to check SteeringPolicyAttachment status: %w", err)
+		}
+		return &util.LifecycleSnapshot{
+			NativeID:   *resp.Id,
+			State:      string(resp.LifecycleState),
+			Properties: buildSteeringPolicyAttachmentProperties(resp.SteeringPolicyAttachment),
+		}, nil
+	}
+
+	// CREATING and DELETING stay in progress; a deleted attachment is gone
+	result, err := util.PollLifecycle(ctx, "SteeringPolicyAttachment", request.RequestID, getAttachment, map[string]resource.OperationStatus{
+		string(dns.SteeringPolicyAttachmentLifecycleStateActive): resource.OperationStatusSuccess,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &resource.StatusResult{ProgressResult: result}, nil
+}
+
+// List returns the attachments in the compartment, narrowed to one zone or
+// policy when the ZoneId or SteeringPolicyId additional property is set.
+// CompartmentId is optional when SteeringPolicyId is given; it is taken from
+// the policy.
+func (p *SteeringPolicyAttachmentProvisioner) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	policyId, hasPolicy := request.AdditionalProperties["SteeringPolicyId"]
+	compartmentId, ok := util.ListCompartmentId(request)
+	if !ok && hasPolicy {
+		resp, err := svc.GetSteeringPolicy(ctx, dns.GetSteeringPolicyRequest{SteeringPolicyId: common.String(policyId)})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get SteeringPolicy to derive CompartmentId: %w", err)
+		}
+		compartmentId, ok = *resp.CompartmentId, true
+	}
+	if !ok {
+		return nil, fmt.Errorf("CompartmentId is required for listing SteeringPolicyAttachments (either directly or derived from SteeringPolicyId)")
+	}
+
+	listReq := dns.ListSteeringPolicyAttachmentsRequest{
+		CompartmentId: common.String(compartmentId),
+		Limit:         common.Int64(int64(*util.ListPageSize(request.TargetConfig))),
+	}
+	if hasPolicy {
+		listReq.SteeringPolicyId = common.String(policyId)
+	}
+	if zoneId, ok := request.AdditionalProperties["ZoneId"]; ok {
+		listReq.ZoneId = common.String(zoneId)
+	}
+
+	nativeIDs, err := util.ListAllPages(func(page *string) ([]string, *string, error) {
+		listReq.Page = page
+		resp, err := svc.ListSteeringPolicyAttachments(ctx, listReq)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list SteeringPolicyAttachments: %w", err)
+		}
+		ids := make([]string, 0, len(resp.Items))
+		for _, attachment := range resp.Items {
+			if util.IsTerminal(string(attachment.LifecycleState)) {
+				continue
+			}
+			ids = append(ids, *attachment.Id)
+		}
+		return ids, resp.OpcNextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &resource.ListResult{
+		NativeIDs: nativeIDs,
+	}, nil
+}
+
+func buildSteeringPolicyAttachmentProperties(attachment dns.SteeringPolicyAttachment) map[string]any {
+	properties := map[string]any{
+		"Id": *attachment.Id,
+	}
+
+	if attachment.SteeringPolicyId != nil {
+		properties["SteeringPolicyId"] = *attachment.SteeringPolicyId
+	}
+	if attachment.ZoneId != nil {
+		properties["ZoneId"] = *attachment.ZoneId
+	}
+	if attachment.DomainName != nil {
+		properties["DomainName"] = *attachment.DomainName
+	}
+	if attachment.DisplayName != nil {
+		properties["DisplayName"] = *attachment.DisplayName
+	}
+	if attachment.CompartmentId != nil {
+		properties["CompartmentId"] = *attachment.CompartmentId
+	}
+	if attachment.Rtypes != nil {
+		properties["Rtypes"] = attachment.Rtypes
+	}
+
+	return properties
+}
@@ -0,0 +1,322 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package dns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/dns"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+type ViewProvisioner struct {
+	clients *client.Clients
+	svc     *dns.DnsClient // nil until first use; injected in tests
+}
+
+var _ provisioner.Provisioner = &ViewProvisioner{}
+
+func init() {
+	provisioner.Register("OCI::DNS::View", NewViewProvisioner)
+	provisioner.DependsOn("OCI::DNS::View", "OCI::Identity::Compartment")
+}
+
+func NewViewProvisioner(clients *client.Clients) provisioner.Provisioner {
+	return &ViewProvisioner{clients: clients}
+}
+
+// NewViewProvisionerWithSvc constructs a provisioner with a pre-built SDK client,
+// for use in tests that point the client at an httptest server.
+func NewViewProvisionerWithSvc(svc *dns.DnsClient) *ViewProvisioner {
+	return &ViewProvisioner{svc: svc}
+}
+
+func (p *ViewProvisioner) getSvc() (*dns.DnsClient, error) {
+	if p.svc != nil {
+		return p.svc, nil
+	}
+	return p.clients.GetDnsClient()
+}
+
+func (p *ViewProvisioner) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	var props map[string]any
+	if err := json.Unmarshal(request.Properties, &props); err != nil {
+		return nil, fmt.Errorf("failed to parse properties: %w", err)
+	}
+
+	compartmentId, ok := util.ExtractString(props, "CompartmentId")
+	if !ok {
+		return nil, fmt.Errorf("CompartmentId is required")
+	}
+
+	createDetails := dns.CreateViewDetails{
+		CompartmentId: common.String(compartmentId),
+	}
+	if displayName, ok := util.ExtractString(props, "DisplayName"); ok {
+		createDetails.DisplayName = common.String(displayName)
+	}
+	if freeformTags, ok := util.ExtractFreeformTags(props, "FreeformTags"); ok {
+		createDetails.FreeformTags = freeformTags
+	}
+	if definedTags, ok := util.ExtractDefinedTags(props, "DefinedTags"); ok {
+		createDetails.DefinedTags = definedTags
+	}
+
+	resp, err := svc.CreateView(ctx, dns.CreateViewRequest{
+		OpcRetryToken:     common.String(util.CreateRetryToken(request)),
+		CreateViewDetails: createDetails,
+	})
+	if err != nil {
+		if result, handleErr := util.HandleCreateError(err, "OCI::DNS::View", "OCI::DNS::View"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to create View: %w", err)
+	}
+
+	return &resource.CreateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationCreate,
+			OperationStatus: resource.OperationStatusInProgress,
+			NativeID:        *resp.Id,
+			RequestID:       *resp.Id,
+		},
+	}, nil
+}
+
+func (p *ViewProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	resp, err := svc.GetView(ctx, dns.GetViewRequest{
+		ViewId: common.String(request.NativeID),
+	})
+	if err != nil {
+		if util.IsNotFound(err) {
+			return &resource.ReadResult{
+				ResourceType: "OCI::DNS::View",
+				ErrorCode:    resource.OperationErrorCodeNotFound,
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to read View: %w", err)
+	}
+
+	// Treat terminal lifecycle states as NotFound
+	if util.IsTerminal(string(resp.LifecycleState)) {
+		return &resource.ReadResult{
+			ResourceType: "OCI::DNS::View",
+			ErrorCode:    resource.OperationErrorCodeNotFound,
+		}, nil
+	}
+
+	properties := buildViewProperties(resp.View, config.FromTargetConfig(request.TargetConfig).HiddenTagNamespaces())
+
+	propBytes, err := json.Marshal(properties)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal View properties: %w", err)
+	}
+
+	return &resource.ReadResult{
+		ResourceType: "OCI::DNS::View",
+		Properties:   string(propBytes),
+	}, nil
+}
+
+func (p *ViewProvisioner) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	props, err := util.ApplyPatchDocument(ctx, request, p.Read)
+	if err != nil {
+		return nil, err
+	}
+
+	updateDetails := dns.UpdateViewDetails{}
+	if displayName, ok := util.ExtractString(props, "DisplayName"); ok {
+		updateDetails.DisplayName = common.String(displayName)
+	}
+	if freeformTags, ok := util.ExtractFreeformTags(props, "FreeformTags"); ok {
+		updateDetails.FreeformTags = freeformTags
+	}
+	if definedTags, ok := util.ExtractDefinedTags(props, "DefinedTags"); ok {
+		updateDetails.DefinedTags = definedTags
+	}
+
+	resp, err := svc.UpdateView(ctx, dns.UpdateViewRequest{
+		ViewId:            common.String(request.NativeID),
+		UpdateViewDetails: updateDetails,
+	})
+	if err != nil {
+		if result, handleErr := util.HandleUpdateError(err, "OCI::DNS::View", request.NativeID, "OCI::DNS::View"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to update View: %w", err)
+	}
+
+	return &resource.UpdateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationUpdate,
+			OperationStatus: resource.OperationStatusInProgress,
+			NativeID:        *resp.Id,
+			RequestID:       *resp.Id,
+		},
+	}, nil
+}
+
+func (p *ViewProvisioner) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	readRes, err := p.Read(ctx, &resource.ReadRequest{NativeID: request.NativeID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read View before delete: %w", err)
+	}
+	if readRes.ErrorCode == resource.OperationErrorCodeNotFound {
+		return &resource.DeleteResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationDelete,
+				OperationStatus: resource.OperationStatusSuccess,
+				NativeID:        request.NativeID,
+			},
+		}, nil
+	}
+
+	_, err = svc.DeleteView(ctx, dns.DeleteViewRequest{
+		ViewId: common.String(request.NativeID),
+	})
+	if err != nil {
+		if result, handleErr := util.HandleDeleteError(err, "OCI::DNS::View", request.NativeID, "OCI::DNS::View"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to delete View: %w", err)
+	}
+
+	return &resource.DeleteResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationDelete,
+			OperationStatus: resource.OperationStatusInProgress,
+			NativeID:        request.NativeID,
+			RequestID:       request.NativeID,
+		},
+	}, nil
+}
+
+func (p *ViewProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	getView := func(ctx context.Context) (*util.LifecycleSnapshot, error) {
+		resp, err := svc.GetView(ctx, dns.GetViewRequest{
+			ViewId: common.String(request.RequestID),
+		})
+		if err != nil {
+			if util.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to check View status: %w", err)
+		}
+		return &util.LifecycleSnapshot{
+			NativeID:   *resp.Id,
+			State:      string(resp.LifecycleState),
+			Properties: buildViewProperties(resp.View, config.FromTargetConfig(request.TargetConfig).HiddenTagNamespaces()),
+		}, nil
+	}
+
+	// UPDATING and DELETING stay in progress
+	result, err := util.PollLifecycle(ctx, "View", request.RequestID, getView, map[string]resource.OperationStatus{
+		string(dns.ViewLifecycleStateActive):  resource.OperationStatusSuccess,
+		string(dns.ViewLifecycleStateDeleted): resource.OperationStatusSuccess,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &resource.StatusResult{ProgressResult: result}, nil
+}
+
+// List skips protected views: the default view of every VCN resolver is
+// created and deleted with the VCN, not managed on its own.
+func (p *ViewProvisioner) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	compartmentId, ok := util.ListCompartmentId(request)
+	if !ok {
+		return nil, fmt.Errorf("CompartmentId is required for listing Views")
+	}
+
+	listReq := dns.ListViewsRequest{
+		CompartmentId: common.String(compartmentId),
+		Limit:         common.Int64(int64(*util.ListPageSize(request.TargetConfig))),
+	}
+
+	nativeIDs, err := util.ListAllPages(func(page *string) ([]string, *string, error) {
+		listReq.Page = page
+		resp, err := svc.ListViews(ctx, listReq)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list Views: %w", err)
+		}
+		ids := make([]string, 0, len(resp.Items))
+		for _, view := range resp.Items {
+			if util.IsTerminal(string(view.LifecycleState)) || (view.IsProtected != nil && *view.IsProtected) {
+				continue
+			}
+			ids = append(ids, *view.Id)
+		}
+		return ids, resp.OpcNextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &resource.ListResult{
+		NativeIDs: nativeIDs,
+	}, nil
+}
+
+func buildViewProperties(view dns.View, ignoredTagNamespaces []string) map[string]any {
+	properties := map[string]any{
+		"Id": *view.Id,
+	}
+
+	if view.CompartmentId != nil {
+		properties["CompartmentId"] = *view.CompartmentId
+	}
+	if view.DisplayName != nil {
+		properties["DisplayName"] = *view.DisplayName
+	}
+	if view.IsProtected != nil {
+		properties["IsProtected"] = *view.IsProtected
+	}
+	if view.FreeformTags != nil {
+		properties["FreeformTags"] = util.FreeformTagsToList(view.FreeformTags)
+	}
+	if view.DefinedTags != nil {
+		properties["DefinedTags"] = util.DefinedTagsToList(view.DefinedTags, ignoredTagNamespaces)
+	}
+
+	return properties
+}
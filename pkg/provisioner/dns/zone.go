@@ -0,0 +1,411 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package dns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/dns"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+type ZoneProvisioner struct {
+	clients *client.Clients
+	svc     *dns.DnsClient // nil until first use; injected in tests
+}
+
+var _ provisioner.Provisioner = &ZoneProvisioner{}
+
+func init() {
+	provisioner.Register("OCI::DNS::Zone", NewZoneProvisioner)
+	provisioner.DependsOn("OCI::DNS::Zone", "OCI::Identity::Compartment", "OCI::DNS::View")
+}
+
+func NewZoneProvisioner(clients *client.Clients) provisioner.Provisioner {
+	return &ZoneProvisioner{clients: clients}
+}
+
+// NewZoneProvisionerWithSvc constructs a provisioner with a pre-built SDK client,
+// for use in tests that point the client at an httptest server.
+func NewZoneProvisionerWithSvc(svc *dns.DnsClient) *ZoneProvisioner {
+	return &ZoneProvisioner{svc: svc}
+}
+
+// ImmutableFields: a zone can't be renamed, retyped or moved between global
+// and private scope.
+func (p *ZoneProvisioner) ImmutableFields() []string {
+	return []string{"Name", "ZoneType", "Scope", "ViewId"}
+}
+
+func (p *ZoneProvisioner) getSvc() (*dns.DnsClient, error) {
+	if p.svc != nil {
+		return p.svc, nil
+	}
+	return p.clients.GetDnsClient()
+}
+
+// Create makes a PRIMARY zone, whose records are managed in OCI, or a
+// SECONDARY one that transfers its records from ExternalMasters.
+func (p *ZoneProvisioner) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	var props map[string]any
+	if err := json.Unmarshal(request.Properties, &props); err != nil {
+		return nil, fmt.Errorf("failed to parse properties: %w", err)
+	}
+
+	name, ok := util.ExtractString(props, "Name")
+	if !ok {
+		return nil, fmt.Errorf("Name is required")
+	}
+	compartmentId, ok := util.ExtractString(props, "CompartmentId")
+	if !ok {
+		return nil, fmt.Errorf("CompartmentId is required")
+	}
+
+	zoneType := dns.CreateZoneDetailsZoneTypePrimary
+	if v, ok := util.ExtractString(props, "ZoneType"); ok {
+		zoneType, ok = dns.GetMappingCreateZoneDetailsZoneTypeEnum(v)
+		if !ok {
+			return nil, fmt.Errorf("ZoneType %q must be PRIMARY or SECONDARY", v)
+		}
+	}
+	externalMasters := parseExternalMasters(props)
+	if zoneType == dns.CreateZoneDetailsZoneTypeSecondary && len(externalMasters) == 0 {
+		return nil, fmt.Errorf("ExternalMasters is required for a SECONDARY zone")
+	}
+
+	createDetails := dns.CreateZoneDetails{
+		Name:            common.String(name),
+		CompartmentId:   common.String(compartmentId),
+		ZoneType:        zoneType,
+		ExternalMasters: externalMasters,
+		ViewId:          optionalResolvedReference(props, "ViewId"),
+	}
+	if scope, ok := util.ExtractString(props, "Scope"); ok {
+		createDetails.Scope = dns.ScopeEnum(scope)
+	}
+	if freeformTags, ok := util.ExtractFreeformTags(props, "FreeformTags"); ok {
+		createDetails.FreeformTags = freeformTags
+	}
+	if definedTags, ok := util.ExtractDefinedTags(props, "DefinedTags"); ok {
+		createDetails.DefinedTags = definedTags
+	}
+
+	resp, err := svc.CreateZone(ctx, dns.CreateZoneRequest{
+		OpcRetryToken:     common.String(util.CreateRetryToken(request)),
+		CreateZoneDetails: createDetails,
+	})
+	if err != nil {
+		if result, handleErr := util.HandleCreateError(err, "OCI::DNS::Zone", "OCI::DNS::Zone"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to create Zone: %w", err)
+	}
+
+	return &resource.CreateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationCreate,
+			OperationStatus: resource.OperationStatusInProgress,
+			NativeID:        *resp.Id,
+			RequestID:       *resp.Id,
+		},
+	}, nil
+}
+
+func (p *ZoneProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	resp, err := svc.GetZone(ctx, dns.GetZoneRequest{
+		ZoneNameOrId: common.String(request.NativeID),
+	})
+	if err != nil {
+		if util.IsNotFound(err) {
+			return &resource.ReadResult{
+				ResourceType: "OCI::DNS::Zone",
+				ErrorCode:    resource.OperationErrorCodeNotFound,
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to read Zone: %w", err)
+	}
+
+	// Treat terminal lifecycle states as NotFound
+	if util.IsTerminal(string(resp.LifecycleState)) {
+		return &resource.ReadResult{
+			ResourceType: "OCI::DNS::Zone",
+			ErrorCode:    resource.OperationErrorCodeNotFound,
+		}, nil
+	}
+
+	properties := buildZoneProperties(resp.Zone, config.FromTargetConfig(request.TargetConfig).HiddenTagNamespaces())
+
+	propBytes, err := json.Marshal(properties)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Zone properties: %w", err)
+	}
+
+	return &resource.ReadResult{
+		ResourceType: "OCI::DNS::Zone",
+		Properties:   string(propBytes),
+	}, nil
+}
+
+// Update changes the tags and, for a SECONDARY zone, the masters it
+// transfers from.
+func (p *ZoneProvisioner) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	props, err := util.ApplyPatchDocument(ctx, request, p.Read)
+	if err != nil {
+		return nil, err
+	}
+
+	updateDetails := dns.UpdateZoneDetails{}
+	if zoneType, _ := util.ExtractString(props, "ZoneType"); zoneType == string(dns.ZoneZoneTypeSecondary) {
+		updateDetails.ExternalMasters = parseExternalMasters(props)
+	}
+	if freeformTags, ok := util.ExtractFreeformTags(props, "FreeformTags"); ok {
+		updateDetails.FreeformTags = freeformTags
+	}
+	if definedTags, ok := util.ExtractDefinedTags(props, "DefinedTags"); ok {
+		updateDetails.DefinedTags = definedTags
+	}
+
+	resp, err := svc.UpdateZone(ctx, dns.UpdateZoneRequest{
+		ZoneNameOrId:      common.String(request.NativeID),
+		UpdateZoneDetails: updateDetails,
+	})
+	if err != nil {
+		if result, handleErr := util.HandleUpdateError(err, "OCI::DNS::Zone", request.NativeID, "OCI::DNS::Zone"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to update Zone: %w", err)
+	}
+
+	return &resource.UpdateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationUpdate,
+			OperationStatus: resource.OperationStatusInProgress,
+			NativeID:        *resp.Id,
+			RequestID:       *resp.Id,
+		},
+	}, nil
+}
+
+func (p *ZoneProvisioner) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	readRes, err := p.Read(ctx, &resource.ReadRequest{NativeID: request.NativeID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Zone before delete: %w", err)
+	}
+	if readRes.ErrorCode == resource.OperationErrorCodeNotFound {
+		return &resource.DeleteResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationDelete,
+				OperationStatus: resource.OperationStatusSuccess,
+				NativeID:        request.NativeID,
+			},
+		}, nil
+	}
+
+	_, err = svc.DeleteZone(ctx, dns.DeleteZoneRequest{
+		ZoneNameOrId: common.String(request.NativeID),
+	})
+	if err != nil {
+		// Gone between the read and the delete
+		if util.IsNotFound(err) {
+			return &resource.DeleteResult{
+				ProgressResult: &resource.ProgressResult{
+					Operation:       resource.OperationDelete,
+					OperationStatus: resource.OperationStatusSuccess,
+					NativeID:        request.NativeID,
+				},
+			}, nil
+		}
+		if result, handleErr := util.HandleDeleteError(err, "OCI::DNS::Zone", request.NativeID, "OCI::DNS::Zone"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to delete Zone: %w", err)
+	}
+
+	return &resource.DeleteResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationDelete,
+			OperationStatus: resource.OperationStatusInProgress,
+			NativeID:        request.NativeID,
+			RequestID:       request.NativeID,
+		},
+	}, nil
+}
+
+func (p *ZoneProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	getZone := func(ctx context.Context) (*util.LifecycleSnapshot, error) {
+		resp, err := svc.GetZone(ctx, dns.GetZoneRequest{
+			ZoneNameOrId: common.String(request.RequestID),
+		})
+		if err != nil {
+			if util.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to check Zone status: %w", err)
+		}
+		return &util.LifecycleSnapshot{
+			NativeID:   *resp.Id,
+			State:      string(resp.LifecycleState),
+			Properties: buildZoneProperties(resp.Zone, config.FromTargetConfig(request.TargetConfig).HiddenTagNamespaces()),
+		}, nil
+	}
+
+	// CREATING, UPDATING and DELETING stay in progress
+	result, err := util.PollLifecycle(ctx, "Zone", request.RequestID, getZone, map[string]resource.OperationStatus{
+		string(dns.ZoneLifecycleStateActive):  resource.OperationStatusSuccess,
+		string(dns.ZoneLifecycleStateDeleted): resource.OperationStatusSuccess,
+		string(dns.ZoneLifecycleStateFailed):  resource.OperationStatusFailure,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &resource.StatusResult{ProgressResult: result}, nil
+}
+
+// List skips protected zones: the private zones OCI creates for every VCN
+// resolver come and go with the VCN.
+func (p *ZoneProvisioner) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	compartmentId, ok := util.ListCompartmentId(request)
+	if !ok {
+		return nil, fmt.Errorf("CompartmentId is required for listing Zones")
+	}
+
+	nativeIDs, err := util.ListAllPages(func(page *string) ([]string, *string, error) {
+		resp, err := svc.ListZones(ctx, dns.ListZonesRequest{
+			CompartmentId: common.String(compartmentId),
+			Limit:         common.Int64(int64(*util.ListPageSize(request.TargetConfig))),
+			Page:          page,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		ids := make([]string, 0, len(resp.Items))
+		for _, zone := range resp.Items {
+			if util.IsTerminal(string(zone.LifecycleState)) || (zone.IsProtected != nil && *zone.IsProtected) {
+				continue
+			}
+			ids = append(ids, *zone.Id)
+		}
+		return ids, resp.OpcNextPage, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Zones: %w", err)
+	}
+
+	return &resource.ListResult{
+		NativeIDs: nativeIDs,
+	}, nil
+}
+
+// parseExternalMasters reads ExternalMasters, a list of {address, port,
+// tsigKeyId}.
+func parseExternalMasters(props map[string]any) []dns.ExternalMaster {
+	var masters []dns.ExternalMaster
+	for _, m := range mapList(props, "ExternalMasters") {
+		master := dns.ExternalMaster{
+			Address:   optionalString(m, "address"),
+			TsigKeyId: optionalResolvedReference(m, "tsigKeyId"),
+		}
+		if port, ok := extractInt(m, "port"); ok {
+			master.Port = common.Int(port)
+		}
+		masters = append(masters, master)
+	}
+	return masters
+}
+
+func optionalResolvedReference(m map[string]any, key string) *string {
+	if v, ok := util.ExtractResolvedReference(m, key); ok {
+		return common.String(v)
+	}
+	return nil
+}
+
+func buildZoneProperties(zone dns.Zone, ignoredTagNamespaces []string) map[string]any {
+	properties := map[string]any{
+		"Id":       *zone.Id,
+		"ZoneType": string(zone.ZoneType),
+		"Scope":    string(zone.Scope),
+	}
+
+	setString(properties, "Name", zone.Name)
+	setString(properties, "CompartmentId", zone.CompartmentId)
+	setString(properties, "ViewId", zone.ViewId)
+	setString(properties, "Version", zone.Version)
+	if zone.Serial != nil {
+		properties["Serial"] = *zone.Serial
+	}
+	if zone.IsProtected != nil {
+		properties["IsProtected"] = *zone.IsProtected
+	}
+
+	masters := make([]map[string]any, 0, len(zone.ExternalMasters))
+	for _, master := range zone.ExternalMasters {
+		m := map[string]any{}
+		setString(m, "address", master.Address)
+		setString(m, "tsigKeyId", master.TsigKeyId)
+		if master.Port != nil {
+			m["port"] = *master.Port
+		}
+		masters = append(masters, m)
+	}
+	setList(properties, "ExternalMasters", masters)
+
+	if len(zone.Nameservers) > 0 {
+		nameservers := make([]string, 0, len(zone.Nameservers))
+		for _, ns := range zone.Nameservers {
+			if ns.Hostname != nil {
+				nameservers = append(nameservers, *ns.Hostname)
+			}
+		}
+		properties["Nameservers"] = nameservers
+	}
+
+	if zone.FreeformTags != nil {
+		properties["FreeformTags"] = util.FreeformTagsToList(zone.FreeformTags)
+	}
+	if zone.DefinedTags != nil {
+		properties["DefinedTags"] = util.DefinedTagsToList(zone.DefinedTags, ignoredTagNamespaces)
+	}
+
+	return properties
+}
@@ -0,0 +1,55 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build integration
+
+package provisioner_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/loadbalancer"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHostnameRead(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc := newTestLoadBalancerClient(t, map[route]canned{
+			{"GET", "/20170115/loadBalancers/ocid1.loadbalancer..aaa/hostnames/app"}: {200, `{"name": "app", "hostname": "app.example.com"}`},
+		})
+		p := loadbalancer.NewHostnameProvisionerWithSvc(svc)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.loadbalancer..aaa/app"})
+		require.NoError(t, err)
+		assert.Empty(t, result.ErrorCode)
+
+		var props map[string]any
+		require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+		assert.Equal(t, "ocid1.loadbalancer..aaa", props["LoadBalancerId"])
+		assert.Equal(t, "app", props["Name"])
+		assert.Equal(t, "app.example.com", props["Hostname"])
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		svc := newTestLoadBalancerClient(t, map[route]canned{
+			{"GET", "/20170115/loadBalancers/ocid1.loadbalancer..aaa/hostnames/missing"}: {404, `{"code":"NotAuthorizedOrNotFound","message":"not found"}`},
+		})
+		p := loadbalancer.NewHostnameProvisionerWithSvc(svc)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.loadbalancer..aaa/missing"})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationErrorCodeNotFound, result.ErrorCode)
+	})
+
+	t.Run("invalid_native_id", func(t *testing.T) {
+		p := loadbalancer.NewHostnameProvisionerWithSvc(newTestLoadBalancerClient(t, map[route]canned{}))
+
+		_, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.loadbalancer..aaa"})
+		assert.ErrorContains(t, err, "{loadBalancerId}/{name}")
+	})
+}
@@ -37,14 +37,57 @@ type canned struct {
 // for the given routes. Any unregistered request fails the test.
 // Returns the server URL, to be set as client.Host.
 func newTestDispatcher(t *testing.T, responses map[route]canned) string {
+	t.Helper()
+	return newTestPagedDispatcher(t, responses, nil)
+}
+
+// newTestPagedDispatcher is newTestDispatcher for list calls that span pages.
+// Each route in pages answers with its responses in order: every response but
+// the last carries an opc-next-page token, and the next one is only served
+// when the request sends that token back as the page query parameter.
+func newTestPagedDispatcher(t *testing.T, responses map[route]canned, pages map[route][]canned) string {
+	t.Helper()
+	return newTestServer(t, responses, pages, nil)
+}
+
+// newTestHeaderDispatcher is newTestDispatcher for calls whose result travels
+// in response headers, such as the opc-work-request-id of async operations.
+func newTestHeaderDispatcher(t *testing.T, responses map[route]canned, headers map[route]map[string]string) string {
+	t.Helper()
+	return newTestServer(t, responses, nil, headers)
+}
+
+func newTestServer(t *testing.T, responses map[route]canned, pages map[route][]canned, headers map[route]map[string]string) string {
 	t.Helper()
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		c, ok := responses[route{r.Method, r.URL.Path}]
+		key := route{r.Method, r.URL.Path}
+		if paged, ok := pages[key]; ok {
+			i := 0
+			if token := r.URL.Query().Get("page"); token != "" {
+				if _, err := fmt.Sscanf(token, "page-%d", &i); err != nil || i <= 0 || i >= len(paged) {
+					t.Errorf("unexpected page token %q for %s %s", token, r.Method, r.URL.Path)
+					http.NotFound(w, r)
+					return
+				}
+			}
+			if i < len(paged)-1 {
+				w.Header().Set("opc-next-page", fmt.Sprintf("page-%d", i+1))
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(paged[i].status)
+			fmt.Fprint(w, paged[i].body)
+			return
+		}
+
+		c, ok := responses[key]
 		if !ok {
 			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
 			http.NotFound(w, r)
 			return
 		}
+		for name, value := range headers[key] {
+			w.Header().Set(name, value)
+		}
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(c.status)
 		fmt.Fprint(w, c.body)
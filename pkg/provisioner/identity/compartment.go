@@ -13,6 +13,7 @@ import (
 	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/oracle/oci-go-sdk/v65/identity"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
@@ -71,15 +72,16 @@ func (p *CompartmentProvisioner) Create(ctx context.Context, request *resource.C
 	}
 
 	createReq := identity.CreateCompartmentRequest{
+		OpcRetryToken:            common.String(util.CreateRetryToken(request)),
 		CreateCompartmentDetails: createDetails,
 	}
 
 	resp, err := client.CreateCompartment(ctx, createReq)
 	if err != nil {
-		// If the compartment already exists, look it up by name and return it.
-		// Compartment names are unique within a parent, so this is safe.
-		errorCode, ok := util.HandleOCIServiceError(err)
-		if ok && errorCode == resource.OperationErrorCodeAlreadyExists {
+		// If the compartment already exists, look it up by name and return it
+		// unless the target sets AdoptExisting to false. Compartment names are
+		// unique within a parent, so this is safe.
+		if config.FromTargetConfig(request.TargetConfig).AdoptsExisting(true) && util.IsNameConflict(err) {
 			existingID, lookupErr := p.findCompartmentByName(ctx, *createDetails.CompartmentId, *createDetails.Name)
 			if lookupErr == nil && existingID != "" {
 				return &resource.CreateResult{
@@ -142,7 +144,7 @@ func (p *CompartmentProvisioner) Read(ctx context.Context, request *resource.Rea
 
 	resp, err := client.GetCompartment(ctx, getReq)
 	if err != nil {
-		if serviceErr, ok := common.IsServiceError(err); ok && serviceErr.GetHTTPStatusCode() == 404 {
+		if util.IsNotFound(err) {
 			return &resource.ReadResult{
 				ResourceType: "OCI::Identity::Compartment",
 				ErrorCode:    resource.OperationErrorCodeNotFound,
@@ -185,7 +187,7 @@ func (p *CompartmentProvisioner) Read(ctx context.Context, request *resource.Rea
 		properties["FreeformTags"] = util.FreeformTagsToList(resp.FreeformTags)
 	}
 	if resp.DefinedTags != nil {
-		properties["DefinedTags"] = util.DefinedTagsToList(resp.DefinedTags)
+		properties["DefinedTags"] = util.DefinedTagsToList(resp.DefinedTags, config.FromTargetConfig(request.TargetConfig).HiddenTagNamespaces())
 	}
 
 	propertiesBytes, err := json.Marshal(properties)
@@ -322,7 +324,7 @@ func (p *CompartmentProvisioner) Status(ctx context.Context, request *resource.S
 		CompartmentId: common.String(request.RequestID),
 	})
 	if err != nil {
-		if serviceErr, ok := common.IsServiceError(err); ok && serviceErr.GetHTTPStatusCode() == 404 {
+		if util.IsNotFound(err) {
 			return &resource.StatusResult{
 				ProgressResult: &resource.ProgressResult{
 					Operation:       resource.OperationCheckStatus,
@@ -369,11 +371,10 @@ func (p *CompartmentProvisioner) List(ctx context.Context, request *resource.Lis
 		return nil, fmt.Errorf("failed to get Identity client: %w", err)
 	}
 
-	// Get CompartmentId from request, or use tenancy OCID as root
-	var compartmentId string
-	if id, ok := request.AdditionalProperties["CompartmentId"]; ok {
-		compartmentId = id
-	} else {
+	// Get CompartmentId from request or the target's default, or use tenancy
+	// OCID as root
+	compartmentId, ok := util.ListCompartmentId(request)
+	if !ok {
 		// No CompartmentId provided - use tenancy OCID from config provider
 		provider := p.clients.GetConfigurationProvider()
 		tenancyID, err := provider.TenancyOCID()
@@ -387,23 +388,33 @@ func (p *CompartmentProvisioner) List(ctx context.Context, request *resource.Lis
 		CompartmentId:          common.String(compartmentId),
 		CompartmentIdInSubtree: common.Bool(false), // Only direct children - natural tree traversal
 		AccessLevel:            identity.ListCompartmentsAccessLevelAccessible,
-	}
-
-	resp, err := client.ListCompartments(ctx, listReq)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list Compartments: %w", err)
+		Limit:                  util.ListPageSize(request.TargetConfig),
 	}
 
 	var nativeIDs []string
 
-	// If no CompartmentId was provided in the request, we're at the root (tenancy level)
-	// Include the root compartment (tenancy) itself as a discoverable resource
+	// If no CompartmentId was provided in the request, we're at the root (the
+	// tenancy or the default compartment). Include the root itself as a
+	// discoverable resource
 	if _, ok := request.AdditionalProperties["CompartmentId"]; !ok {
 		nativeIDs = append(nativeIDs, compartmentId)
 	}
-	for _, compartment := range resp.Items {
-		nativeIDs = append(nativeIDs, *compartment.Id)
+	children, err := util.ListAllPages(func(page *string) ([]string, *string, error) {
+		listReq.Page = page
+		resp, err := client.ListCompartments(ctx, listReq)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list Compartments: %w", err)
+		}
+		ids := make([]string, 0, len(resp.Items))
+		for _, compartment := range resp.Items {
+			ids = append(ids, *compartment.Id)
+		}
+		return ids, resp.OpcNextPage, nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	nativeIDs = append(nativeIDs, children...)
 
 	return &resource.ListResult{
 		NativeIDs: nativeIDs,
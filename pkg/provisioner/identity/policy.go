@@ -13,6 +13,7 @@ import (
 	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/oracle/oci-go-sdk/v65/identity"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
@@ -27,6 +28,7 @@ var _ provisioner.Provisioner = &PolicyProvisioner{}
 
 func init() {
 	provisioner.Register("OCI::Identity::Policy", NewPolicyProvisioner)
+	provisioner.DependsOn("OCI::Identity::Policy", "OCI::Identity::Compartment")
 }
 
 func NewPolicyProvisioner(clients *client.Clients) provisioner.Provisioner {
@@ -79,6 +81,7 @@ func (p *PolicyProvisioner) Create(ctx context.Context, request *resource.Create
 	}
 
 	createReq := identity.CreatePolicyRequest{
+		OpcRetryToken:       common.String(util.CreateRetryToken(request)),
 		CreatePolicyDetails: createDetails,
 	}
 
@@ -111,7 +114,7 @@ func (p *PolicyProvisioner) Read(ctx context.Context, request *resource.ReadRequ
 
 	resp, err := svc.GetPolicy(ctx, getReq)
 	if err != nil {
-		if serviceErr, ok := common.IsServiceError(err); ok && serviceErr.GetHTTPStatusCode() == 404 {
+		if util.IsNotFound(err) {
 			return &resource.ReadResult{
 				ResourceType: "OCI::Identity::Policy",
 				ErrorCode:    resource.OperationErrorCodeNotFound,
@@ -128,7 +131,7 @@ func (p *PolicyProvisioner) Read(ctx context.Context, request *resource.ReadRequ
 		}, nil
 	}
 
-	properties := buildPolicyProperties(resp.Policy)
+	properties := buildPolicyProperties(resp.Policy, config.FromTargetConfig(request.TargetConfig).HiddenTagNamespaces())
 
 	propBytes, err := json.Marshal(properties)
 	if err != nil {
@@ -254,23 +257,30 @@ func (p *PolicyProvisioner) List(ctx context.Context, request *resource.ListRequ
 		return nil, fmt.Errorf("failed to get Identity client: %w", err)
 	}
 
-	compartmentId, ok := request.AdditionalProperties["CompartmentId"]
+	compartmentId, ok := util.ListCompartmentId(request)
 	if !ok {
 		return nil, fmt.Errorf("CompartmentId is required for listing Policies")
 	}
 
 	listReq := identity.ListPoliciesRequest{
 		CompartmentId: common.String(compartmentId),
+		Limit:         util.ListPageSize(request.TargetConfig),
 	}
 
-	resp, err := svc.ListPolicies(ctx, listReq)
+	nativeIDs, err := util.ListAllPages(func(page *string) ([]string, *string, error) {
+		listReq.Page = page
+		resp, err := svc.ListPolicies(ctx, listReq)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list Policies: %w", err)
+		}
+		ids := make([]string, 0, len(resp.Items))
+		for _, policy := range resp.Items {
+			ids = append(ids, *policy.Id)
+		}
+		return ids, resp.OpcNextPage, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list Policies: %w", err)
-	}
-
-	nativeIDs := make([]string, 0, len(resp.Items))
-	for _, policy := range resp.Items {
-		nativeIDs = append(nativeIDs, *policy.Id)
+		return nil, err
 	}
 
 	return &resource.ListResult{
@@ -278,7 +288,7 @@ func (p *PolicyProvisioner) List(ctx context.Context, request *resource.ListRequ
 	}, nil
 }
 
-func buildPolicyProperties(policy identity.Policy) map[string]any {
+func buildPolicyProperties(policy identity.Policy, ignoredTagNamespaces []string) map[string]any {
 	properties := map[string]any{
 		"Id": *policy.Id,
 	}
@@ -302,7 +312,7 @@ func buildPolicyProperties(policy identity.Policy) map[string]any {
 		properties["FreeformTags"] = util.FreeformTagsToList(policy.FreeformTags)
 	}
 	if policy.DefinedTags != nil {
-		properties["DefinedTags"] = util.DefinedTagsToList(policy.DefinedTags)
+		properties["DefinedTags"] = util.DefinedTagsToList(policy.DefinedTags, ignoredTagNamespaces)
 	}
 
 	return properties
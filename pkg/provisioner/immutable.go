@@ -0,0 +1,133 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package provisioner
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+// Immutable is implemented by provisioners whose resources have properties OCI
+// only sets at create time.
+type Immutable interface {
+	// ImmutableFields names the top-level properties that can't change in place.
+	ImmutableFields() []string
+}
+
+// immutableFields returns p's immutable fields, or nil when it declares none.
+func immutableFields(p Provisioner) []string {
+	if i, ok := p.(Immutable); ok {
+		return i.ImmutableFields()
+	}
+	return nil
+}
+
+// Equivalent is implemented by provisioners whose immutable fields accept more
+// than one spelling of the same value, such as a short availability domain
+// name that Read reports in full.
+type Equivalent interface {
+	// SameValue reports whether desired names the same value as the field's
+	// value in live, the resource's current properties.
+	SameValue(ctx context.Context, field string, live map[string]any, desired any) bool
+}
+
+// equivalent returns p as an Equivalent, or nil when it doesn't implement one.
+func equivalent(p Provisioner) Equivalent {
+	if e, ok := p.(Equivalent); ok {
+		return e
+	}
+	return nil
+}
+
+// replaceOnChange is a decorator that fails an Update changing one of the
+// resource's immutable fields with util.ReplacementRequired, so formae can
+// plan a replacement instead of the change being dropped or failing with a
+// raw OCI error.
+//
+// Like noOpUpdate it only judges updates with a patch document: the patch is
+// applied to a fresh Read and each immutable field compared before and after.
+// A field the patch removes is left alone, since that only hands it back to
+// the OCI default. Values the provisioner reports as Equivalent aren't changes.
+// Anything unexpected falls through to the real Update.
+type replaceOnChange struct {
+	inner      Provisioner
+	fields     []string
+	equivalent Equivalent
+}
+
+func (r *replaceOnChange) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	return r.inner.Create(ctx, request)
+}
+
+func (r *replaceOnChange) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	if len(r.fields) == 0 || request.PatchDocument == nil || *request.PatchDocument == "" {
+		return r.inner.Update(ctx, request)
+	}
+
+	if changed := r.changedBy(ctx, request); len(changed) > 0 {
+		return util.ReplacementRequired(request.NativeID, changed...), nil
+	}
+	return r.inner.Update(ctx, request)
+}
+
+func (r *replaceOnChange) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	return r.inner.Delete(ctx, request)
+}
+
+func (r *replaceOnChange) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	return r.inner.Status(ctx, request)
+}
+
+func (r *replaceOnChange) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	return r.inner.Read(ctx, request)
+}
+
+func (r *replaceOnChange) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	return r.inner.List(ctx, request)
+}
+
+// changedBy returns the immutable fields request's patch gives a new value.
+func (r *replaceOnChange) changedBy(ctx context.Context, request *resource.UpdateRequest) []string {
+	readResult, err := r.inner.Read(ctx, &resource.ReadRequest{
+		NativeID:     request.NativeID,
+		ResourceType: request.ResourceType,
+		TargetConfig: request.TargetConfig,
+	})
+	if err != nil || readResult == nil || readResult.ErrorCode != "" || readResult.Properties == "" {
+		return nil
+	}
+
+	patch, err := jsonpatch.DecodePatch([]byte(*request.PatchDocument))
+	if err != nil {
+		return nil
+	}
+	patched, err := patch.Apply([]byte(readResult.Properties))
+	if err != nil {
+		return nil
+	}
+
+	var before, after map[string]any
+	if json.Unmarshal([]byte(readResult.Properties), &before) != nil || json.Unmarshal(patched, &after) != nil {
+		return nil
+	}
+
+	var changed []string
+	for _, field := range r.fields {
+		value, ok := after[field]
+		if ok && !reflect.DeepEqual(before[field], value) && !r.sameValue(ctx, field, before, value) {
+			changed = append(changed, field)
+		}
+	}
+	return changed
+}
+
+func (r *replaceOnChange) sameValue(ctx context.Context, field string, live map[string]any, desired any) bool {
+	return r.equivalent != nil && r.equivalent.SameValue(ctx, field, live, desired)
+}
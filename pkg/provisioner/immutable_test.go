@@ -0,0 +1,117 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package provisioner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+const immutableSubnet = `{"Id":"ocid1.subnet.oc1..abc","CidrBlock":"10.0.1.0/24","DisplayName":"app","AvailabilityDomain":"Uocm:PHX-AD-1"}`
+
+func TestReplaceOnChange_ImmutableFieldChanged(t *testing.T) {
+	inner := newNoOpUpdateMock(immutableSubnet)
+
+	r := &replaceOnChange{inner: inner, fields: []string{"CidrBlock", "AvailabilityDomain", "DnsLabel"}}
+	result, err := r.Update(context.Background(), noOpUpdateRequest("OCI::Core::Subnet",
+		`[{"op":"replace","path":"/CidrBlock","value":"10.0.2.0/24"},{"op":"add","path":"/DnsLabel","value":"app"}]`))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if inner.updateCalled {
+		t.Fatal("expected Update to be refused")
+	}
+	if result.ProgressResult.ErrorCode != resource.OperationErrorCodeNotUpdatable {
+		t.Fatalf("expected NotUpdatable, got %s", result.ProgressResult.ErrorCode)
+	}
+	if got := result.ProgressResult.StatusMessage; got != "replacement required: CidrBlock, DnsLabel" {
+		t.Fatalf("unexpected message %q", got)
+	}
+}
+
+func TestReplaceOnChange_MutableFieldPassesThrough(t *testing.T) {
+	inner := newNoOpUpdateMock(immutableSubnet)
+
+	r := &replaceOnChange{inner: inner, fields: []string{"CidrBlock", "AvailabilityDomain"}}
+	_, err := r.Update(context.Background(), noOpUpdateRequest("OCI::Core::Subnet",
+		`[{"op":"replace","path":"/DisplayName","value":"web"},{"op":"replace","path":"/CidrBlock","value":"10.0.1.0/24"}]`))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !inner.updateCalled {
+		t.Fatal("expected Update to go through when no immutable field changes")
+	}
+}
+
+func TestReplaceOnChange_RemovedFieldPassesThrough(t *testing.T) {
+	inner := newNoOpUpdateMock(immutableSubnet)
+
+	r := &replaceOnChange{inner: inner, fields: []string{"AvailabilityDomain"}}
+	_, err := r.Update(context.Background(), noOpUpdateRequest("OCI::Core::Subnet",
+		`[{"op":"remove","path":"/AvailabilityDomain"}]`))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !inner.updateCalled {
+		t.Fatal("expected Update to go through when the patch only removes an immutable field")
+	}
+}
+
+// shortADs treats "PHX-AD-1" as the same availability domain as "Uocm:PHX-AD-1".
+type shortADs struct{}
+
+func (shortADs) SameValue(_ context.Context, field string, live map[string]any, desired any) bool {
+	return field == "AvailabilityDomain" && live[field] == "Uocm:"+desired.(string)
+}
+
+func TestReplaceOnChange_EquivalentValuePassesThrough(t *testing.T) {
+	inner := newNoOpUpdateMock(immutableSubnet)
+
+	r := &replaceOnChange{inner: inner, fields: []string{"AvailabilityDomain"}, equivalent: shortADs{}}
+	_, err := r.Update(context.Background(), noOpUpdateRequest("OCI::Core::Subnet",
+		`[{"op":"replace","path":"/AvailabilityDomain","value":"PHX-AD-1"}]`))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !inner.updateCalled {
+		t.Fatal("expected Update to go through when the new value names the same AD")
+	}
+
+	inner = newNoOpUpdateMock(immutableSubnet)
+	r.inner = inner
+	result, err := r.Update(context.Background(), noOpUpdateRequest("OCI::Core::Subnet",
+		`[{"op":"replace","path":"/AvailabilityDomain","value":"PHX-AD-2"}]`))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if inner.updateCalled || result.ProgressResult.ErrorCode != resource.OperationErrorCodeNotUpdatable {
+		t.Fatal("expected a move to another AD to be refused")
+	}
+}
+
+func TestReplaceOnChange_NoPatchPassesThrough(t *testing.T) {
+	inner := newNoOpUpdateMock(immutableSubnet)
+
+	r := &replaceOnChange{inner: inner, fields: []string{"CidrBlock"}}
+	_, err := r.Update(context.Background(), &resource.UpdateRequest{
+		NativeID:          "ocid1.subnet.oc1..abc",
+		ResourceType:      "OCI::Core::Subnet",
+		DesiredProperties: []byte(`{"CidrBlock":"10.0.2.0/24"}`),
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !inner.updateCalled {
+		t.Fatal("expected Update without a patch document to go through")
+	}
+}
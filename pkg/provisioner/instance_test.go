@@ -0,0 +1,348 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build integration
+
+package provisioner_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	ociworkrequests "github.com/oracle/oci-go-sdk/v65/workrequests"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/core"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstanceUpdateMovesCompartment(t *testing.T) {
+	svc := newTestComputeClient(t, map[route]canned{
+		{"PUT", "/20160918/instances/ocid1.instance..aaa"}:                            {200, newTestInstanceBody("ocid1.compartment..xxx", "RUNNING")},
+		{"POST", "/20160918/instances/ocid1.instance..aaa/actions/changeCompartment"}: {200, ``},
+	})
+	p := core.NewInstanceProvisionerWithSvc(svc, nil, nil, nil, nil)
+
+	props, err := json.Marshal(map[string]any{"CompartmentId": "ocid1.compartment..yyy", "DisplayName": "web"})
+	require.NoError(t, err)
+
+	result, err := p.Update(context.Background(), &resource.UpdateRequest{
+		NativeID:          "ocid1.instance..aaa",
+		ResourceType:      "OCI::Core::Instance",
+		DesiredProperties: props,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+	assert.Equal(t, "ocid1.instance..aaa", result.ProgressResult.RequestID)
+}
+
+func TestInstanceUpdateSameCompartment(t *testing.T) {
+	// No changeCompartment route: the dispatcher fails the test if it is called
+	svc := newTestComputeClient(t, map[route]canned{
+		{"PUT", "/20160918/instances/ocid1.instance..aaa"}: {200, newTestInstanceBody("ocid1.compartment..xxx", "RUNNING")},
+	})
+	p := core.NewInstanceProvisionerWithSvc(svc, nil, nil, nil, nil)
+
+	props, err := json.Marshal(map[string]any{"CompartmentId": "ocid1.compartment..xxx", "DisplayName": "web"})
+	require.NoError(t, err)
+
+	result, err := p.Update(context.Background(), &resource.UpdateRequest{
+		NativeID:          "ocid1.instance..aaa",
+		ResourceType:      "OCI::Core::Instance",
+		DesiredProperties: props,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+}
+
+func TestInstanceUpdateMovesFaultDomain(t *testing.T) {
+	var updated map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/20160918/instances/ocid1.instance..aaa" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &updated)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, newTestInstanceBody("ocid1.compartment..xxx", "RUNNING"))
+	}))
+	t.Cleanup(srv.Close)
+	p := core.NewInstanceProvisionerWithSvc(newTestComputeClientAt(t, srv.URL), nil, nil, nil, nil)
+
+	props, err := json.Marshal(map[string]any{"CompartmentId": "ocid1.compartment..xxx", "FaultDomain": "FAULT-DOMAIN-3"})
+	require.NoError(t, err)
+
+	result, err := p.Update(context.Background(), &resource.UpdateRequest{
+		NativeID:          "ocid1.instance..aaa",
+		ResourceType:      "OCI::Core::Instance",
+		DesiredProperties: props,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	assert.Equal(t, "FAULT-DOMAIN-3", updated["faultDomain"])
+}
+
+func TestInstanceUpdateMovesCompartmentAfterWorkRequest(t *testing.T) {
+	update := route{"PUT", "/20160918/instances/ocid1.instance..aaa"}
+	workRequest := route{"GET", "/20160918/workRequests/ocid1.workrequest..aaa"}
+	move := route{"POST", "/20160918/instances/ocid1.instance..aaa/actions/changeCompartment"}
+	headers := map[route]map[string]string{update: {"opc-work-request-id": "ocid1.workrequest..aaa"}}
+	props, err := json.Marshal(map[string]any{"CompartmentId": "ocid1.compartment..yyy", "Shape": "VM.Standard.E5.Flex"})
+	require.NoError(t, err)
+
+	// No changeCompartment route: the move must wait for the resize's work request
+	host := newTestHeaderDispatcher(t, map[route]canned{
+		update: {200, newTestInstanceBody("ocid1.compartment..xxx", "RUNNING")},
+	}, headers)
+	p := core.NewInstanceProvisionerWithSvc(newTestComputeClientAt(t, host), nil, nil, nil, nil)
+
+	result, err := p.Update(context.Background(), &resource.UpdateRequest{
+		NativeID:          "ocid1.instance..aaa",
+		ResourceType:      "OCI::Core::Instance",
+		DesiredProperties: props,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+	requestID := result.ProgressResult.RequestID
+	assert.Equal(t, "ocid1.instance..aaa/ocid1.workrequest..aaa/ocid1.compartment..yyy", requestID)
+
+	t.Run("waits while the work request runs", func(t *testing.T) {
+		host := newTestDispatcher(t, map[route]canned{
+			workRequest: {200, newTestWorkRequestBody("IN_PROGRESS")},
+		})
+		p := core.NewInstanceProvisionerWithSvc(newTestComputeClientAt(t, host), nil, nil, nil, newTestWorkRequestClientAt(t, host))
+
+		status, err := p.Status(context.Background(), &resource.StatusRequest{RequestID: requestID})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusInProgress, status.ProgressResult.OperationStatus)
+		assert.Equal(t, requestID, status.ProgressResult.RequestID)
+	})
+
+	t.Run("moves once the work request succeeds", func(t *testing.T) {
+		host := newTestDispatcher(t, map[route]canned{
+			workRequest: {200, newTestWorkRequestBody("SUCCEEDED")},
+			move:        {200, ``},
+		})
+		p := core.NewInstanceProvisionerWithSvc(newTestComputeClientAt(t, host), nil, nil, nil, newTestWorkRequestClientAt(t, host))
+
+		status, err := p.Status(context.Background(), &resource.StatusRequest{RequestID: requestID})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusInProgress, status.ProgressResult.OperationStatus)
+		assert.Equal(t, "ocid1.instance..aaa", status.ProgressResult.RequestID)
+	})
+
+	t.Run("does not move when the work request fails", func(t *testing.T) {
+		host := newTestDispatcher(t, map[route]canned{
+			workRequest: {200, newTestWorkRequestBody("FAILED")},
+			{"GET", "/20160918/workRequests/ocid1.workrequest..aaa/errors"}: {200, `[{"code": "InternalError", "message": "resize failed", "timestamp": "2025-01-01T00:00:00.000Z"}]`},
+		})
+		p := core.NewInstanceProvisionerWithSvc(newTestComputeClientAt(t, host), nil, nil, nil, newTestWorkRequestClientAt(t, host))
+
+		status, err := p.Status(context.Background(), &resource.StatusRequest{RequestID: requestID})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusFailure, status.ProgressResult.OperationStatus)
+		assert.Equal(t, "resize failed", status.ProgressResult.StatusMessage)
+	})
+}
+
+func TestInstanceStatusMoving(t *testing.T) {
+	svc := newTestComputeClient(t, map[route]canned{
+		{"GET", "/20160918/instances/ocid1.instance..aaa"}: {200, newTestInstanceBody("ocid1.compartment..yyy", "MOVING")},
+	})
+	p := core.NewInstanceProvisionerWithSvc(svc, nil, nil, nil, nil)
+
+	result, err := p.Status(context.Background(), &resource.StatusRequest{RequestID: "ocid1.instance..aaa"})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+}
+
+func TestInstanceReadTerminating(t *testing.T) {
+	for _, state := range []string{"TERMINATING", "TERMINATED"} {
+		t.Run(state, func(t *testing.T) {
+			svc := newTestComputeClient(t, map[route]canned{
+				{"GET", "/20160918/instances/ocid1.instance..aaa"}: {200, newTestInstanceBody("ocid1.compartment..xxx", state)},
+			})
+			p := core.NewInstanceProvisionerWithSvc(svc, nil, nil, nil, nil)
+
+			result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.instance..aaa"})
+			require.NoError(t, err)
+			assert.Equal(t, resource.OperationErrorCodeNotFound, result.ErrorCode)
+			assert.Empty(t, result.Properties)
+		})
+	}
+}
+
+func TestInstanceReadPrimaryVnic(t *testing.T) {
+	routes := func() map[route]canned {
+		return map[route]canned{
+			{"GET", "/20160918/instances/ocid1.instance..aaa"}: {200, newTestInstanceBody("ocid1.compartment..xxx", "RUNNING")},
+			{"GET", "/20160918/vnicAttachments"}: {200, `[{"id": "ocid1.vnicattachment..aaa", "instanceId": "ocid1.instance..aaa",
+				"vnicId": "ocid1.vnic..aaa", "lifecycleState": "ATTACHED", "availabilityDomain": "US-CHICAGO-1-AD-1",
+				"compartmentId": "ocid1.compartment..xxx", "timeCreated": "2025-01-01T00:00:00.000Z"}]`},
+			{"GET", "/20160918/vnics/ocid1.vnic..aaa"}: {200, `{"id": "ocid1.vnic..aaa", "isPrimary": true, "subnetId": "ocid1.subnet..aaa",
+				"publicIp": "203.0.113.10", "privateIp": "10.0.1.5", "lifecycleState": "AVAILABLE"}`},
+			{"GET", "/20160918/privateIps"}:                          {200, `[{"id": "ocid1.privateip..aaa", "isPrimary": true}]`},
+			{"POST", "/20160918/publicIps/actions/getByPrivateIpId"}: {200, `{"id": "ocid1.publicip..aaa", "lifetime": "RESERVED"}`},
+		}
+	}
+
+	t.Run("reserved public ip", func(t *testing.T) {
+		host := newTestDispatcher(t, routes())
+		p := core.NewInstanceProvisionerWithSvc(newTestComputeClientAt(t, host), nil, newTestVirtualNetworkClientAt(t, host), nil, nil)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.instance..aaa"})
+		require.NoError(t, err)
+
+		var props map[string]any
+		require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+		assert.Equal(t, "ocid1.publicip..aaa", props["ReservedPublicIpId"])
+		assert.Equal(t, map[string]any{"assignPublicIp": false, "subnetId": "ocid1.subnet..aaa", "privateIp": "10.0.1.5"}, props["CreateVnicDetails"])
+	})
+
+	t.Run("vnic lookup fails", func(t *testing.T) {
+		responses := routes()
+		responses[route{"GET", "/20160918/vnics/ocid1.vnic..aaa"}] = canned{400, `{"code": "InvalidParameter", "message": "bad"}`}
+		host := newTestDispatcher(t, responses)
+		p := core.NewInstanceProvisionerWithSvc(newTestComputeClientAt(t, host), nil, newTestVirtualNetworkClientAt(t, host), nil, nil)
+
+		_, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.instance..aaa"})
+		require.Error(t, err)
+	})
+}
+
+func TestInstanceReadBootVolume(t *testing.T) {
+	instance := strings.Replace(newTestInstanceBody("ocid1.compartment..xxx", "RUNNING"), `"shape":`,
+		`"sourceDetails": {"sourceType": "image", "imageId": "ocid1.image..aaa", "bootVolumeSizeInGBs": 50}, "shape":`, 1)
+	routes := func() map[route]canned {
+		return map[route]canned{
+			{"GET", "/20160918/instances/ocid1.instance..aaa"}: {200, instance},
+			{"GET", "/20160918/vnicAttachments"}:               {200, `[]`},
+			{"GET", "/20160918/bootVolumeAttachments"}: {200, `[{"id": "ocid1.bootvolumeattachment..aaa", "instanceId": "ocid1.instance..aaa",
+				"bootVolumeId": "ocid1.bootvolume..aaa", "lifecycleState": "ATTACHED", "availabilityDomain": "US-CHICAGO-1-AD-1",
+				"compartmentId": "ocid1.compartment..xxx", "timeCreated": "2025-01-01T00:00:00.000Z"}]`},
+			{"GET", "/20160918/bootVolumes/ocid1.bootvolume..aaa"}: {200, `{"id": "ocid1.bootvolume..aaa", "sizeInGBs": 100, "vpusPerGB": 20,
+				"availabilityDomain": "US-CHICAGO-1-AD-1", "compartmentId": "ocid1.compartment..xxx", "lifecycleState": "AVAILABLE",
+				"timeCreated": "2025-01-01T00:00:00.000Z"}`},
+		}
+	}
+
+	t.Run("reports the current size", func(t *testing.T) {
+		host := newTestDispatcher(t, routes())
+		p := core.NewInstanceProvisionerWithSvc(newTestComputeClientAt(t, host), nil, newTestVirtualNetworkClientAt(t, host), newTestBlockstorageClientAt(t, host), nil)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.instance..aaa"})
+		require.NoError(t, err)
+
+		var props map[string]any
+		require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+		sourceDetails := props["SourceDetails"].(map[string]any)
+		assert.Equal(t, float64(100), sourceDetails["bootVolumeSizeInGBs"])
+		assert.Equal(t, float64(20), sourceDetails["bootVolumeVpusPerGB"])
+	})
+
+	t.Run("reports the KMS key of an existing boot volume", func(t *testing.T) {
+		responses := routes()
+		responses[route{"GET", "/20160918/instances/ocid1.instance..aaa"}] = canned{200, strings.Replace(newTestInstanceBody("ocid1.compartment..xxx", "RUNNING"), `"shape":`,
+			`"sourceDetails": {"sourceType": "bootVolume", "bootVolumeId": "ocid1.bootvolume..aaa"}, "shape":`, 1)}
+		responses[route{"GET", "/20160918/bootVolumes/ocid1.bootvolume..aaa"}] = canned{200, `{"id": "ocid1.bootvolume..aaa", "sizeInGBs": 100,
+			"kmsKeyId": "ocid1.key..aaa", "availabilityDomain": "US-CHICAGO-1-AD-1", "compartmentId": "ocid1.compartment..xxx",
+			"lifecycleState": "AVAILABLE", "timeCreated": "2025-01-01T00:00:00.000Z"}`}
+		host := newTestDispatcher(t, responses)
+		p := core.NewInstanceProvisionerWithSvc(newTestComputeClientAt(t, host), nil, newTestVirtualNetworkClientAt(t, host), newTestBlockstorageClientAt(t, host), nil)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.instance..aaa"})
+		require.NoError(t, err)
+
+		var props map[string]any
+		require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+		assert.Equal(t, map[string]any{
+			"sourceType":          "bootVolume",
+			"bootVolumeId":        "ocid1.bootvolume..aaa",
+			"bootVolumeSizeInGBs": float64(100),
+			"kmsKeyId":            "ocid1.key..aaa",
+		}, props["SourceDetails"])
+	})
+
+	t.Run("boot volume lookup fails", func(t *testing.T) {
+		responses := routes()
+		responses[route{"GET", "/20160918/bootVolumes/ocid1.bootvolume..aaa"}] = canned{400, `{"code": "InvalidParameter", "message": "bad"}`}
+		host := newTestDispatcher(t, responses)
+		p := core.NewInstanceProvisionerWithSvc(newTestComputeClientAt(t, host), nil, newTestVirtualNetworkClientAt(t, host), newTestBlockstorageClientAt(t, host), nil)
+
+		_, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.instance..aaa"})
+		require.Error(t, err)
+	})
+}
+
+func TestInstanceCreateFromBootVolumeWithKmsKey(t *testing.T) {
+	// The existing boot volume is switched to the key before the launch
+	host := newTestDispatcher(t, map[route]canned{
+		{"GET", "/20160918/bootVolumes/ocid1.bootvolume..aaa"}: {200, `{"id": "ocid1.bootvolume..aaa", "sizeInGBs": 100,
+			"availabilityDomain": "Twhb:US-CHICAGO-1-AD-1", "compartmentId": "ocid1.compartment..xxx",
+			"lifecycleState": "AVAILABLE", "timeCreated": "2025-01-01T00:00:00.000Z"}`},
+		{"PUT", "/20160918/bootVolumes/ocid1.bootvolume..aaa/kmsKey"}: {200, `{"kmsKeyId": "ocid1.key..aaa"}`},
+		{"POST", "/20160918/instances"}:                               {200, newTestInstanceBody("ocid1.compartment..xxx", "PROVISIONING")},
+	})
+	p := core.NewInstanceProvisionerWithSvc(newTestComputeClientAt(t, host), nil, nil, newTestBlockstorageClientAt(t, host), nil)
+
+	props, err := json.Marshal(map[string]any{
+		"CompartmentId":      "ocid1.compartment..xxx",
+		"AvailabilityDomain": "Twhb:US-CHICAGO-1-AD-1",
+		"Shape":              "VM.Standard.E4.Flex",
+		"SourceDetails":      map[string]any{"sourceType": "bootVolume", "bootVolumeId": "ocid1.bootvolume..aaa", "kmsKeyId": "ocid1.key..aaa"},
+	})
+	require.NoError(t, err)
+
+	result, err := p.Create(context.Background(), &resource.CreateRequest{
+		ResourceType: "OCI::Core::Instance",
+		Properties:   props,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+	assert.Equal(t, "ocid1.instance..aaa", result.ProgressResult.NativeID)
+}
+
+// Helpers
+
+func newTestInstanceBody(compartmentId, lifecycleState string) string {
+	return fmt.Sprintf(`{
+		"id": "ocid1.instance..aaa",
+		"compartmentId": %q,
+		"availabilityDomain": "US-CHICAGO-1-AD-1",
+		"displayName": "web",
+		"shape": "VM.Standard.E4.Flex",
+		"region": "us-chicago-1",
+		"lifecycleState": %q,
+		"timeCreated": "2025-01-01T00:00:00.000Z"
+	}`, compartmentId, lifecycleState)
+}
+
+func newTestWorkRequestClientAt(t *testing.T, host string) *ociworkrequests.WorkRequestClient {
+	t.Helper()
+	c, err := ociworkrequests.NewWorkRequestClientWithConfigurationProvider(fakeOCIConfigProvider(t))
+	require.NoError(t, err)
+	applyTestRetryPolicy(&c)
+	c.Host = host
+	return &c
+}
+
+func newTestWorkRequestBody(status string) string {
+	return fmt.Sprintf(`{
+		"id": "ocid1.workrequest..aaa",
+		"operationType": "UpdateInstance",
+		"status": %q,
+		"compartmentId": "ocid1.compartment..xxx",
+		"resources": [],
+		"percentComplete": 50,
+		"timeAccepted": "2025-01-01T00:00:00.000Z"
+	}`, status)
+}
@@ -0,0 +1,509 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package kms
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/keymanagement"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+// ManagementClientFunc returns the KmsManagementClient for a vault's
+// management endpoint.
+type ManagementClientFunc func(managementEndpoint string) (*keymanagement.KmsManagementClient, error)
+
+// KeyProvisioner manages master encryption keys. Keys are only reachable
+// through the management endpoint of their vault, so every operation looks the
+// vault up first, and the NativeID carries the vault: {vaultId}/{keyId}.
+type KeyProvisioner struct {
+	clients          *client.Clients
+	vaultSvc         *keymanagement.KmsVaultClient // nil until first use; injected in tests
+	managementClient ManagementClientFunc          // nil outside tests
+}
+
+var _ provisioner.Provisioner = &KeyProvisioner{}
+
+func init() {
+	provisioner.Register("OCI::KeyManagement::Key", NewKeyProvisioner)
+	provisioner.DependsOn("OCI::KeyManagement::Key", "OCI::Identity::Compartment", "OCI::KeyManagement::Vault")
+}
+
+func NewKeyProvisioner(clients *client.Clients) provisioner.Provisioner {
+	return &KeyProvisioner{clients: clients}
+}
+
+// NewKeyProvisionerWithSvc constructs a provisioner with a pre-built vault
+// client and a constructor for management clients, for use in tests that point
+// the clients at an httptest server.
+func NewKeyProvisionerWithSvc(vaultSvc *keymanagement.KmsVaultClient, managementClient ManagementClientFunc) *KeyProvisioner {
+	return &KeyProvisioner{vaultSvc: vaultSvc, managementClient: managementClient}
+}
+
+// ImmutableFields: a key's vault, algorithm and protection mode are fixed at
+// creation.
+func (p *KeyProvisioner) ImmutableFields() []string {
+	return []string{"VaultId", "KeyShape", "ProtectionMode"}
+}
+
+func (p *KeyProvisioner) getVaultSvc() (*keymanagement.KmsVaultClient, error) {
+	if p.vaultSvc != nil {
+		return p.vaultSvc, nil
+	}
+	return p.clients.GetKmsVaultClient()
+}
+
+// getVault fetches the vault holding a key. Within a ReadBatch the lookup is
+// shared through util.Cached, so reading every key of a vault gets it once. It
+// returns nil when the vault no longer exists.
+func (p *KeyProvisioner) getVault(ctx context.Context, vaultId string) (*keymanagement.Vault, error) {
+	svc, err := p.getVaultSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get KmsVault client: %w", err)
+	}
+
+	vault, err := util.Cached(ctx, "kms-vault/"+svc.Host+"/"+vaultId, func() (*keymanagement.Vault, error) {
+		resp, err := svc.GetVault(ctx, keymanagement.GetVaultRequest{VaultId: common.String(vaultId)})
+		if err != nil {
+			return nil, err
+		}
+		return &resp.Vault, nil
+	})
+	if err != nil {
+		if util.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read Vault %s: %w", vaultId, err)
+	}
+	if util.IsTerminal(string(vault.LifecycleState)) {
+		return nil, nil
+	}
+	return vault, nil
+}
+
+// getManagementSvc returns the client for vault's management endpoint.
+func (p *KeyProvisioner) getManagementSvc(vault *keymanagement.Vault) (*keymanagement.KmsManagementClient, error) {
+	if vault.ManagementEndpoint == nil {
+		return nil, fmt.Errorf("Vault %s has no management endpoint yet", *vault.Id)
+	}
+	if p.managementClient != nil {
+		return p.managementClient(*vault.ManagementEndpoint)
+	}
+	return p.clients.GetKmsManagementClient(*vault.ManagementEndpoint)
+}
+
+// parseKeyNativeID extracts the vault ID and key ID from the composite NativeID.
+// Format: {vaultId}/{keyId}
+func parseKeyNativeID(nativeID string) (vaultId, keyId string, err error) {
+	parts, err := util.DecodeCompositeID(nativeID, 2)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid NativeID format: expected {vaultId}/{keyId}: %w", err)
+	}
+	return parts[0], parts[1], nil
+}
+
+// parseKeyShape reads the nested KeyShape, e.g. {algorithm: "AES", length: 32}.
+func parseKeyShape(props map[string]any) (*keymanagement.KeyShape, error) {
+	data, ok := props["KeyShape"].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("KeyShape is required")
+	}
+	algorithm, ok := util.ExtractString(data, "algorithm")
+	if !ok {
+		return nil, fmt.Errorf("KeyShape requires an algorithm")
+	}
+	length, ok := data["length"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("KeyShape requires a length")
+	}
+
+	shape := &keymanagement.KeyShape{
+		Algorithm: keymanagement.KeyShapeAlgorithmEnum(algorithm),
+		Length:    common.Int(int(length)),
+	}
+	if curveId, ok := util.ExtractString(data, "curveId"); ok {
+		shape.CurveId = keymanagement.KeyShapeCurveIdEnum(curveId)
+	}
+	return shape, nil
+}
+
+func (p *KeyProvisioner) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	var props map[string]any
+	if err := json.Unmarshal(request.Properties, &props); err != nil {
+		return nil, fmt.Errorf("failed to parse properties: %w", err)
+	}
+
+	vaultId, ok := util.ExtractResolvedReference(props, "VaultId")
+	if !ok {
+		return nil, fmt.Errorf("VaultId is required")
+	}
+	compartmentId, ok := util.ExtractString(props, "CompartmentId")
+	if !ok {
+		return nil, fmt.Errorf("CompartmentId is required")
+	}
+	displayName, ok := util.ExtractString(props, "DisplayName")
+	if !ok {
+		return nil, fmt.Errorf("DisplayName is required")
+	}
+	keyShape, err := parseKeyShape(props)
+	if err != nil {
+		return nil, err
+	}
+
+	vault, err := p.getVault(ctx, vaultId)
+	if err != nil {
+		return nil, err
+	}
+	if vault == nil {
+		return nil, fmt.Errorf("Vault %s not found", vaultId)
+	}
+	svc, err := p.getManagementSvc(vault)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get KmsManagement client: %w", err)
+	}
+
+	createDetails := keymanagement.CreateKeyDetails{
+		CompartmentId: common.String(compartmentId),
+		DisplayName:   common.String(displayName),
+		KeyShape:      keyShape,
+	}
+	if protectionMode, ok := util.ExtractString(props, "ProtectionMode"); ok {
+		createDetails.ProtectionMode = keymanagement.CreateKeyDetailsProtectionModeEnum(protectionMode)
+	}
+	if freeformTags, ok := util.ExtractFreeformTags(props, "FreeformTags"); ok {
+		createDetails.FreeformTags = freeformTags
+	}
+	if definedTags, ok := util.ExtractDefinedTags(props, "DefinedTags"); ok {
+		createDetails.DefinedTags = definedTags
+	}
+
+	resp, err := svc.CreateKey(ctx, keymanagement.CreateKeyRequest{
+		OpcRetryToken:    common.String(util.CreateRetryToken(request)),
+		CreateKeyDetails: createDetails,
+	})
+	if err != nil {
+		if result, handleErr := util.HandleCreateError(err, "OCI::KeyManagement::Key", "OCI::KeyManagement::Key"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to create Key: %w", err)
+	}
+
+	// Creation is async — return in-progress, poll lifecycle in Status()
+	nativeID := util.EncodeCompositeID(vaultId, *resp.Id)
+	return &resource.CreateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationCreate,
+			OperationStatus: resource.OperationStatusInProgress,
+			NativeID:        nativeID,
+			RequestID:       nativeID,
+		},
+	}, nil
+}
+
+// getKey fetches a key through its vault's management endpoint. It returns a
+// nil key when the key or its vault no longer exists.
+func (p *KeyProvisioner) getKey(ctx context.Context, nativeID string) (*keymanagement.Key, *keymanagement.KmsManagementClient, error) {
+	vaultId, keyId, err := parseKeyNativeID(nativeID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	vault, err := p.getVault(ctx, vaultId)
+	if err != nil || vault == nil {
+		return nil, nil, err
+	}
+	svc, err := p.getManagementSvc(vault)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get KmsManagement client: %w", err)
+	}
+
+	resp, err := svc.GetKey(ctx, keymanagement.GetKeyRequest{KeyId: common.String(keyId)})
+	if err != nil {
+		if util.IsNotFound(err) {
+			return nil, svc, nil
+		}
+		return nil, nil, fmt.Errorf("failed to read Key: %w", err)
+	}
+	return &resp.Key, svc, nil
+}
+
+func (p *KeyProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	key, _, err := p.getKey(ctx, request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+	// A key scheduled for deletion is gone as far as formae is concerned
+	if key == nil || isKeyDeleted(key.LifecycleState) {
+		return &resource.ReadResult{
+			ResourceType: "OCI::KeyManagement::Key",
+			ErrorCode:    resource.OperationErrorCodeNotFound,
+		}, nil
+	}
+
+	properties := buildKeyProperties(*key, config.FromTargetConfig(request.TargetConfig).HiddenTagNamespaces())
+
+	propBytes, err := json.Marshal(properties)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Key properties: %w", err)
+	}
+
+	return &resource.ReadResult{
+		ResourceType: "OCI::KeyManagement::Key",
+		Properties:   string(propBytes),
+	}, nil
+}
+
+// Update moves the key to another compartment when CompartmentId changed, then
+// applies the display name and tags.
+func (p *KeyProvisioner) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	props, err := util.ApplyPatchDocument(ctx, request, p.Read)
+	if err != nil {
+		return nil, err
+	}
+
+	key, svc, err := p.getKey(ctx, request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return &resource.UpdateResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationUpdate,
+				OperationStatus: resource.OperationStatusFailure,
+				ErrorCode:       resource.OperationErrorCodeNotFound,
+				NativeID:        request.NativeID,
+			},
+		}, nil
+	}
+
+	if compartmentId, ok := util.ExtractString(props, "CompartmentId"); ok && compartmentId != *key.CompartmentId {
+		if _, err := svc.ChangeKeyCompartment(ctx, keymanagement.ChangeKeyCompartmentRequest{
+			KeyId:                       key.Id,
+			ChangeKeyCompartmentDetails: keymanagement.ChangeKeyCompartmentDetails{CompartmentId: common.String(compartmentId)},
+		}); err != nil {
+			if result, handleErr := util.HandleUpdateError(err, "OCI::KeyManagement::Key", request.NativeID, "OCI::KeyManagement::Key"); result != nil {
+				return result, handleErr
+			}
+			return nil, fmt.Errorf("failed to move Key to compartment %s: %w", compartmentId, err)
+		}
+	}
+
+	updateDetails := keymanagement.UpdateKeyDetails{}
+	if displayName, ok := util.ExtractString(props, "DisplayName"); ok {
+		updateDetails.DisplayName = common.String(displayName)
+	}
+	if freeformTags, ok := util.ExtractFreeformTags(props, "FreeformTags"); ok {
+		updateDetails.FreeformTags = freeformTags
+	}
+	if definedTags, ok := util.ExtractDefinedTags(props, "DefinedTags"); ok {
+		updateDetails.DefinedTags = definedTags
+	}
+
+	if _, err := svc.UpdateKey(ctx, keymanagement.UpdateKeyRequest{
+		KeyId:            key.Id,
+		UpdateKeyDetails: updateDetails,
+	}); err != nil {
+		if result, handleErr := util.HandleUpdateError(err, "OCI::KeyManagement::Key", request.NativeID, "OCI::KeyManagement::Key"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to update Key: %w", err)
+	}
+
+	return &resource.UpdateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationUpdate,
+			OperationStatus: resource.OperationStatusInProgress,
+			NativeID:        request.NativeID,
+			RequestID:       request.NativeID,
+		},
+	}, nil
+}
+
+// Delete schedules the key's deletion at the earliest time OCI allows (seven
+// days out). Until then the deletion can still be cancelled; Status reports
+// when it will be deleted for good.
+func (p *KeyProvisioner) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	key, svc, err := p.getKey(ctx, request.NativeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Key before delete: %w", err)
+	}
+	if key == nil || isKeyDeleted(key.LifecycleState) {
+		return &resource.DeleteResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationDelete,
+				OperationStatus: resource.OperationStatusSuccess,
+				NativeID:        request.NativeID,
+			},
+		}, nil
+	}
+
+	_, err = svc.ScheduleKeyDeletion(ctx, keymanagement.ScheduleKeyDeletionRequest{
+		KeyId: key.Id,
+	})
+	if err != nil {
+		if result, handleErr := util.HandleDeleteError(err, "OCI::KeyManagement::Key", request.NativeID, "OCI::KeyManagement::Key"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to schedule Key deletion: %w", err)
+	}
+
+	return &resource.DeleteResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationDelete,
+			OperationStatus: resource.OperationStatusInProgress,
+			NativeID:        request.NativeID,
+			RequestID:       request.NativeID,
+		},
+	}, nil
+}
+
+func (p *KeyProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	var timeOfDeletion *common.SDKTime
+	getKey := func(ctx context.Context) (*util.LifecycleSnapshot, error) {
+		key, _, err := p.getKey(ctx, request.RequestID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check Key status: %w", err)
+		}
+		if key == nil {
+			return nil, nil
+		}
+		snapshot := &util.LifecycleSnapshot{
+			NativeID: request.RequestID,
+			State:    string(key.LifecycleState),
+		}
+		if key.LifecycleState == keymanagement.KeyLifecycleStatePendingDeletion {
+			timeOfDeletion = key.TimeOfDeletion
+		} else {
+			snapshot.Properties = buildKeyProperties(*key, config.FromTargetConfig(request.TargetConfig).HiddenTagNamespaces())
+		}
+		return snapshot, nil
+	}
+
+	// CREATING, ENABLING, UPDATING and SCHEDULING_DELETION stay in progress.
+	// A DISABLED key is settled too: it was disabled outside formae.
+	result, err := util.PollLifecycle(ctx, "Key", request.RequestID, getKey, map[string]resource.OperationStatus{
+		string(keymanagement.KeyLifecycleStateEnabled):         resource.OperationStatusSuccess,
+		string(keymanagement.KeyLifecycleStateDisabled):        resource.OperationStatusSuccess,
+		string(keymanagement.KeyLifecycleStatePendingDeletion): resource.OperationStatusSuccess,
+		string(keymanagement.KeyLifecycleStateDeleted):         resource.OperationStatusSuccess,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if timeOfDeletion != nil {
+		result.StatusMessage = fmt.Sprintf("Key is pending deletion until %s", timeOfDeletion.Format(time.RFC3339))
+	}
+
+	return &resource.StatusResult{ProgressResult: result}, nil
+}
+
+// List returns the keys of the VaultId vault. The compartment defaults to the
+// vault's own, where keys usually live.
+func (p *KeyProvisioner) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	vaultId, ok := request.AdditionalProperties["VaultId"]
+	if !ok {
+		return nil, fmt.Errorf("VaultId is required for listing Keys")
+	}
+
+	vault, err := p.getVault(ctx, vaultId)
+	if err != nil {
+		return nil, err
+	}
+	if vault == nil {
+		return &resource.ListResult{NativeIDs: []string{}}, nil
+	}
+	svc, err := p.getManagementSvc(vault)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get KmsManagement client: %w", err)
+	}
+
+	compartmentId, ok := request.AdditionalProperties["CompartmentId"]
+	if !ok {
+		compartmentId = *vault.CompartmentId
+	}
+
+	nativeIDs, err := util.ListAllPages(func(page *string) ([]string, *string, error) {
+		resp, err := svc.ListKeys(ctx, keymanagement.ListKeysRequest{
+			CompartmentId: common.String(compartmentId),
+			Limit:         util.ListPageSize(request.TargetConfig),
+			Page:          page,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		ids := make([]string, 0, len(resp.Items))
+		for _, key := range resp.Items {
+			if isKeyDeleted(keymanagement.KeyLifecycleStateEnum(key.LifecycleState)) {
+				continue
+			}
+			ids = append(ids, util.EncodeCompositeID(vaultId, *key.Id))
+		}
+		return ids, resp.OpcNextPage, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Keys: %w", err)
+	}
+
+	return &resource.ListResult{
+		NativeIDs: nativeIDs,
+	}, nil
+}
+
+// isKeyDeleted reports whether the key is deleted or on its way there,
+// including the waiting period of a scheduled deletion.
+func isKeyDeleted(state keymanagement.KeyLifecycleStateEnum) bool {
+	switch state {
+	case keymanagement.KeyLifecycleStateSchedulingDeletion, keymanagement.KeyLifecycleStatePendingDeletion:
+		return true
+	}
+	return util.IsTerminal(string(state))
+}
+
+func buildKeyProperties(key keymanagement.Key, ignoredTagNamespaces []string) map[string]any {
+	properties := map[string]any{
+		"Id":             *key.Id,
+		"ProtectionMode": string(key.ProtectionMode),
+	}
+
+	if key.VaultId != nil {
+		properties["VaultId"] = *key.VaultId
+	}
+	if key.CompartmentId != nil {
+		properties["CompartmentId"] = *key.CompartmentId
+	}
+	if key.DisplayName != nil {
+		properties["DisplayName"] = *key.DisplayName
+	}
+	// Use camelCase for nested objects to match the Pkl schema
+	if key.KeyShape != nil {
+		shape := map[string]any{"algorithm": string(key.KeyShape.Algorithm)}
+		if key.KeyShape.Length != nil {
+			shape["length"] = *key.KeyShape.Length
+		}
+		if key.KeyShape.CurveId != "" {
+			shape["curveId"] = string(key.KeyShape.CurveId)
+		}
+		properties["KeyShape"] = shape
+	}
+	if key.CurrentKeyVersion != nil {
+		properties["CurrentKeyVersion"] = *key.CurrentKeyVersion
+	}
+	if key.FreeformTags != nil {
+		properties["FreeformTags"] = util.FreeformTagsToList(key.FreeformTags)
+	}
+	if key.DefinedTags != nil {
+		properties["DefinedTags"] = util.DefinedTagsToList(key.DefinedTags, ignoredTagNamespaces)
+	}
+
+	return properties
+}
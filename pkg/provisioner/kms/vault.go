@@ -0,0 +1,381 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package kms
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/keymanagement"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+type VaultProvisioner struct {
+	clients *client.Clients
+	svc     *keymanagement.KmsVaultClient // nil until first use; injected in tests
+}
+
+var _ provisioner.Provisioner = &VaultProvisioner{}
+
+func init() {
+	provisioner.Register("OCI::KeyManagement::Vault", NewVaultProvisioner)
+	provisioner.DependsOn("OCI::KeyManagement::Vault", "OCI::Identity::Compartment")
+}
+
+func NewVaultProvisioner(clients *client.Clients) provisioner.Provisioner {
+	return &VaultProvisioner{clients: clients}
+}
+
+// NewVaultProvisionerWithSvc constructs a provisioner with a pre-built SDK client,
+// for use in tests that point the client at an httptest server.
+func NewVaultProvisionerWithSvc(svc *keymanagement.KmsVaultClient) *VaultProvisioner {
+	return &VaultProvisioner{svc: svc}
+}
+
+// ImmutableFields: the vault type decides where its keys live (shared or
+// dedicated HSM partition) and can't change.
+func (p *VaultProvisioner) ImmutableFields() []string {
+	return []string{"VaultType"}
+}
+
+func (p *VaultProvisioner) getSvc() (*keymanagement.KmsVaultClient, error) {
+	if p.svc != nil {
+		return p.svc, nil
+	}
+	return p.clients.GetKmsVaultClient()
+}
+
+func (p *VaultProvisioner) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get KmsVault client: %w", err)
+	}
+
+	var props map[string]any
+	if err := json.Unmarshal(request.Properties, &props); err != nil {
+		return nil, fmt.Errorf("failed to parse properties: %w", err)
+	}
+
+	compartmentId, ok := util.ExtractString(props, "CompartmentId")
+	if !ok {
+		return nil, fmt.Errorf("CompartmentId is required")
+	}
+	displayName, ok := util.ExtractString(props, "DisplayName")
+	if !ok {
+		return nil, fmt.Errorf("DisplayName is required")
+	}
+
+	createDetails := keymanagement.CreateVaultDetails{
+		CompartmentId: common.String(compartmentId),
+		DisplayName:   common.String(displayName),
+		VaultType:     keymanagement.CreateVaultDetailsVaultTypeDefault,
+	}
+	if vaultType, ok := util.ExtractString(props, "VaultType"); ok {
+		createDetails.VaultType = keymanagement.CreateVaultDetailsVaultTypeEnum(vaultType)
+	}
+	if freeformTags, ok := util.ExtractFreeformTags(props, "FreeformTags"); ok {
+		createDetails.FreeformTags = freeformTags
+	}
+	if definedTags, ok := util.ExtractDefinedTags(props, "DefinedTags"); ok {
+		createDetails.DefinedTags = definedTags
+	}
+
+	resp, err := svc.CreateVault(ctx, keymanagement.CreateVaultRequest{
+		OpcRetryToken:      common.String(util.CreateRetryToken(request)),
+		CreateVaultDetails: createDetails,
+	})
+	if err != nil {
+		if result, handleErr := util.HandleCreateError(err, "OCI::KeyManagement::Vault", "OCI::KeyManagement::Vault"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to create Vault: %w", err)
+	}
+
+	// Creation is async — return in-progress, poll lifecycle in Status()
+	return &resource.CreateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationCreate,
+			OperationStatus: resource.OperationStatusInProgress,
+			NativeID:        *resp.Id,
+			RequestID:       *resp.Id,
+		},
+	}, nil
+}
+
+func (p *VaultProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get KmsVault client: %w", err)
+	}
+
+	resp, err := svc.GetVault(ctx, keymanagement.GetVaultRequest{
+		VaultId: common.String(request.NativeID),
+	})
+	if err != nil {
+		if util.IsNotFound(err) {
+			return &resource.ReadResult{
+				ResourceType: "OCI::KeyManagement::Vault",
+				ErrorCode:    resource.OperationErrorCodeNotFound,
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to read Vault: %w", err)
+	}
+
+	// A vault scheduled for deletion is gone as far as formae is concerned
+	if isVaultDeleted(resp.LifecycleState) {
+		return &resource.ReadResult{
+			ResourceType: "OCI::KeyManagement::Vault",
+			ErrorCode:    resource.OperationErrorCodeNotFound,
+		}, nil
+	}
+
+	properties := buildVaultProperties(resp.Vault, config.FromTargetConfig(request.TargetConfig).HiddenTagNamespaces())
+
+	propBytes, err := json.Marshal(properties)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Vault properties: %w", err)
+	}
+
+	return &resource.ReadResult{
+		ResourceType: "OCI::KeyManagement::Vault",
+		Properties:   string(propBytes),
+	}, nil
+}
+
+// Update moves the vault to another compartment when CompartmentId changed,
+// then applies the display name and tags.
+func (p *VaultProvisioner) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get KmsVault client: %w", err)
+	}
+
+	props, err := util.ApplyPatchDocument(ctx, request, p.Read)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := svc.GetVault(ctx, keymanagement.GetVaultRequest{VaultId: common.String(request.NativeID)})
+	if err != nil {
+		if result, handleErr := util.HandleUpdateError(err, "OCI::KeyManagement::Vault", request.NativeID, "OCI::KeyManagement::Vault"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to read Vault before update: %w", err)
+	}
+	if compartmentId, ok := util.ExtractString(props, "CompartmentId"); ok && compartmentId != *current.CompartmentId {
+		if _, err := svc.ChangeVaultCompartment(ctx, keymanagement.ChangeVaultCompartmentRequest{
+			VaultId:                       common.String(request.NativeID),
+			ChangeVaultCompartmentDetails: keymanagement.ChangeVaultCompartmentDetails{CompartmentId: common.String(compartmentId)},
+		}); err != nil {
+			if result, handleErr := util.HandleUpdateError(err, "OCI::KeyManagement::Vault", request.NativeID, "OCI::KeyManagement::Vault"); result != nil {
+				return result, handleErr
+			}
+			return nil, fmt.Errorf("failed to move Vault to compartment %s: %w", compartmentId, err)
+		}
+	}
+
+	updateDetails := keymanagement.UpdateVaultDetails{}
+	if displayName, ok := util.ExtractString(props, "DisplayName"); ok {
+		updateDetails.DisplayName = common.String(displayName)
+	}
+	if freeformTags, ok := util.ExtractFreeformTags(props, "FreeformTags"); ok {
+		updateDetails.FreeformTags = freeformTags
+	}
+	if definedTags, ok := util.ExtractDefinedTags(props, "DefinedTags"); ok {
+		updateDetails.DefinedTags = definedTags
+	}
+
+	resp, err := svc.UpdateVault(ctx, keymanagement.UpdateVaultRequest{
+		VaultId:            common.String(request.NativeID),
+		UpdateVaultDetails: updateDetails,
+	})
+	if err != nil {
+		if result, handleErr := util.HandleUpdateError(err, "OCI::KeyManagement::Vault", request.NativeID, "OCI::KeyManagement::Vault"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to update Vault: %w", err)
+	}
+
+	return &resource.UpdateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationUpdate,
+			OperationStatus: resource.OperationStatusInProgress,
+			NativeID:        *resp.Id,
+			RequestID:       *resp.Id,
+		},
+	}, nil
+}
+
+// Delete schedules the vault's deletion at the earliest time OCI allows (seven
+// days out), deleting its keys with it. Until then the deletion can still be
+// cancelled; Status reports when it will be deleted for good.
+func (p *VaultProvisioner) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get KmsVault client: %w", err)
+	}
+
+	readRes, err := p.Read(ctx, &resource.ReadRequest{NativeID: request.NativeID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Vault before delete: %w", err)
+	}
+	if readRes.ErrorCode == resource.OperationErrorCodeNotFound {
+		return &resource.DeleteResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationDelete,
+				OperationStatus: resource.OperationStatusSuccess,
+				NativeID:        request.NativeID,
+			},
+		}, nil
+	}
+
+	_, err = svc.ScheduleVaultDeletion(ctx, keymanagement.ScheduleVaultDeletionRequest{
+		VaultId: common.String(request.NativeID),
+	})
+	if err != nil {
+		if result, handleErr := util.HandleDeleteError(err, "OCI::KeyManagement::Vault", request.NativeID, "OCI::KeyManagement::Vault"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to schedule Vault deletion: %w", err)
+	}
+
+	return &resource.DeleteResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationDelete,
+			OperationStatus: resource.OperationStatusInProgress,
+			NativeID:        request.NativeID,
+			RequestID:       request.NativeID,
+		},
+	}, nil
+}
+
+func (p *VaultProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get KmsVault client: %w", err)
+	}
+
+	var timeOfDeletion *common.SDKTime
+	getVault := func(ctx context.Context) (*util.LifecycleSnapshot, error) {
+		resp, err := svc.GetVault(ctx, keymanagement.GetVaultRequest{
+			VaultId: common.String(request.RequestID),
+		})
+		if err != nil {
+			if util.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to check Vault status: %w", err)
+		}
+		snapshot := &util.LifecycleSnapshot{
+			NativeID: *resp.Id,
+			State:    string(resp.LifecycleState),
+		}
+		if resp.LifecycleState == keymanagement.VaultLifecycleStatePendingDeletion {
+			timeOfDeletion = resp.TimeOfDeletion
+		} else {
+			snapshot.Properties = buildVaultProperties(resp.Vault, config.FromTargetConfig(request.TargetConfig).HiddenTagNamespaces())
+		}
+		return snapshot, nil
+	}
+
+	// CREATING, UPDATING and SCHEDULING_DELETION stay in progress. The KMS
+	// vault API has no work requests, so the vault's own state is the progress.
+	result, err := util.PollLifecycle(ctx, "Vault", request.RequestID, getVault, map[string]resource.OperationStatus{
+		string(keymanagement.VaultLifecycleStateActive):          resource.OperationStatusSuccess,
+		string(keymanagement.VaultLifecycleStatePendingDeletion): resource.OperationStatusSuccess,
+		string(keymanagement.VaultLifecycleStateDeleted):         resource.OperationStatusSuccess,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if timeOfDeletion != nil {
+		result.StatusMessage = fmt.Sprintf("Vault is pending deletion until %s", timeOfDeletion.Format(time.RFC3339))
+	}
+
+	return &resource.StatusResult{ProgressResult: result}, nil
+}
+
+func (p *VaultProvisioner) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get KmsVault client: %w", err)
+	}
+
+	compartmentId, ok := util.ListCompartmentId(request)
+	if !ok {
+		return nil, fmt.Errorf("CompartmentId is required for listing Vaults")
+	}
+
+	nativeIDs, err := util.ListAllPages(func(page *string) ([]string, *string, error) {
+		resp, err := svc.ListVaults(ctx, keymanagement.ListVaultsRequest{
+			CompartmentId: common.String(compartmentId),
+			Limit:         util.ListPageSize(request.TargetConfig),
+			Page:          page,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		ids := make([]string, 0, len(resp.Items))
+		for _, v := range resp.Items {
+			if isVaultDeleted(keymanagement.VaultLifecycleStateEnum(v.LifecycleState)) {
+				continue
+			}
+			ids = append(ids, *v.Id)
+		}
+		return ids, resp.OpcNextPage, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Vaults: %w", err)
+	}
+
+	return &resource.ListResult{
+		NativeIDs: nativeIDs,
+	}, nil
+}
+
+// isVaultDeleted reports whether the vault is deleted or on its way there,
+// including the waiting period of a scheduled deletion.
+func isVaultDeleted(state keymanagement.VaultLifecycleStateEnum) bool {
+	switch state {
+	case keymanagement.VaultLifecycleStateSchedulingDeletion, keymanagement.VaultLifecycleStatePendingDeletion:
+		return true
+	}
+	return util.IsTerminal(string(state))
+}
+
+func buildVaultProperties(v keymanagement.Vault, ignoredTagNamespaces []string) map[string]any {
+	properties := map[string]any{
+		"Id":        *v.Id,
+		"VaultType": string(v.VaultType),
+	}
+
+	if v.CompartmentId != nil {
+		properties["CompartmentId"] = *v.CompartmentId
+	}
+	if v.DisplayName != nil {
+		properties["DisplayName"] = *v.DisplayName
+	}
+	if v.ManagementEndpoint != nil {
+		properties["ManagementEndpoint"] = *v.ManagementEndpoint
+	}
+	if v.CryptoEndpoint != nil {
+		properties["CryptoEndpoint"] = *v.CryptoEndpoint
+	}
+	if v.FreeformTags != nil {
+		properties["FreeformTags"] = util.FreeformTagsToList(v.FreeformTags)
+	}
+	if v.DefinedTags != nil {
+		properties["DefinedTags"] = util.DefinedTagsToList(v.DefinedTags, ignoredTagNamespaces)
+	}
+
+	return properties
+}
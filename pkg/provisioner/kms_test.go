@@ -0,0 +1,196 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build integration
+
+package provisioner_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/oracle/oci-go-sdk/v65/keymanagement"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/kms"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testManagementEndpoint = "https://aaa-management.kms.us-ashburn-1.oraclecloud.com"
+
+func TestKmsVaultCreate(t *testing.T) {
+	svc, _ := newTestKmsClients(t, map[route]canned{
+		{"POST", "/20180608/vaults"}: {200, newTestKmsVaultBody("CREATING")},
+	})
+	p := kms.NewVaultProvisionerWithSvc(svc)
+
+	props, err := json.Marshal(map[string]any{
+		"CompartmentId": "ocid1.compartment..xxx",
+		"DisplayName":   "app-vault",
+	})
+	require.NoError(t, err)
+
+	result, err := p.Create(context.Background(), &resource.CreateRequest{
+		ResourceType: "OCI::KeyManagement::Vault",
+		Properties:   props,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+	assert.Equal(t, "ocid1.vault..aaa", result.ProgressResult.RequestID)
+}
+
+func TestKmsVaultStatus(t *testing.T) {
+	svc, _ := newTestKmsClients(t, map[route]canned{
+		{"GET", "/20180608/vaults/ocid1.vault..aaa"}: {200, newTestKmsVaultBody("ACTIVE")},
+	})
+	p := kms.NewVaultProvisionerWithSvc(svc)
+
+	result, err := p.Status(context.Background(), &resource.StatusRequest{RequestID: "ocid1.vault..aaa"})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+
+	var props map[string]any
+	require.NoError(t, json.Unmarshal(result.ProgressResult.ResourceProperties, &props))
+	assert.Equal(t, testManagementEndpoint, props["ManagementEndpoint"])
+}
+
+func TestKmsVaultRead_PendingDeletionIsNotFound(t *testing.T) {
+	svc, _ := newTestKmsClients(t, map[route]canned{
+		{"GET", "/20180608/vaults/ocid1.vault..aaa"}: {200, newTestKmsVaultBody("PENDING_DELETION")},
+	})
+	p := kms.NewVaultProvisionerWithSvc(svc)
+
+	result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.vault..aaa"})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationErrorCodeNotFound, result.ErrorCode)
+}
+
+func TestKmsKeyCreate_UsesVaultManagementEndpoint(t *testing.T) {
+	svc, management := newTestKmsClients(t, map[route]canned{
+		{"GET", "/20180608/vaults/ocid1.vault..aaa"}: {200, newTestKmsVaultBody("ACTIVE")},
+		{"POST", "/20180608/keys"}:                   {200, newTestKmsKeyBody("CREATING")},
+	})
+	var endpoints []string
+	p := kms.NewKeyProvisionerWithSvc(svc, func(endpoint string) (*keymanagement.KmsManagementClient, error) {
+		endpoints = append(endpoints, endpoint)
+		return management, nil
+	})
+
+	props, err := json.Marshal(map[string]any{
+		"VaultId":       "ocid1.vault..aaa",
+		"CompartmentId": "ocid1.compartment..xxx",
+		"DisplayName":   "app-key",
+		"KeyShape":      map[string]any{"algorithm": "AES", "length": 32},
+	})
+	require.NoError(t, err)
+
+	result, err := p.Create(context.Background(), &resource.CreateRequest{
+		ResourceType: "OCI::KeyManagement::Key",
+		Properties:   props,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+	assert.Equal(t, "ocid1.vault..aaa/ocid1.key..aaa", result.ProgressResult.NativeID)
+	assert.Equal(t, []string{testManagementEndpoint}, endpoints)
+}
+
+func TestKmsKeyRead(t *testing.T) {
+	t.Run("enabled", func(t *testing.T) {
+		svc, management := newTestKmsClients(t, map[route]canned{
+			{"GET", "/20180608/vaults/ocid1.vault..aaa"}: {200, newTestKmsVaultBody("ACTIVE")},
+			{"GET", "/20180608/keys/ocid1.key..aaa"}:     {200, newTestKmsKeyBody("ENABLED")},
+		})
+		p := kms.NewKeyProvisionerWithSvc(svc, func(string) (*keymanagement.KmsManagementClient, error) { return management, nil })
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.vault..aaa/ocid1.key..aaa"})
+		require.NoError(t, err)
+		assert.Empty(t, result.ErrorCode)
+
+		var props map[string]any
+		require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+		assert.Equal(t, "ocid1.key..aaa", props["Id"])
+		assert.Equal(t, map[string]any{"algorithm": "AES", "length": float64(32)}, props["KeyShape"])
+		assert.Equal(t, "HSM", props["ProtectionMode"])
+		assert.Equal(t, "ocid1.keyversion..aaa", props["CurrentKeyVersion"])
+	})
+
+	t.Run("vault_gone", func(t *testing.T) {
+		svc, management := newTestKmsClients(t, map[route]canned{
+			{"GET", "/20180608/vaults/ocid1.vault..aaa"}: {404, `{"code":"NotAuthorizedOrNotFound","message":"not found"}`},
+		})
+		p := kms.NewKeyProvisionerWithSvc(svc, func(string) (*keymanagement.KmsManagementClient, error) { return management, nil })
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.vault..aaa/ocid1.key..aaa"})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationErrorCodeNotFound, result.ErrorCode)
+	})
+}
+
+func TestKmsKeyList(t *testing.T) {
+	svc, management := newTestKmsClients(t, map[route]canned{
+		{"GET", "/20180608/vaults/ocid1.vault..aaa"}: {200, newTestKmsVaultBody("ACTIVE")},
+		{"GET", "/20180608/keys"}: {200, `[
+			{"id": "ocid1.key..aaa", "compartmentId": "ocid1.compartment..xxx", "displayName": "app-key",
+			 "lifecycleState": "ENABLED", "timeCreated": "2025-01-01T00:00:00.000Z", "vaultId": "ocid1.vault..aaa"},
+			{"id": "ocid1.key..old", "compartmentId": "ocid1.compartment..xxx", "displayName": "old-key",
+			 "lifecycleState": "PENDING_DELETION", "timeCreated": "2025-01-01T00:00:00.000Z", "vaultId": "ocid1.vault..aaa"}
+		]`},
+	})
+	p := kms.NewKeyProvisionerWithSvc(svc, func(string) (*keymanagement.KmsManagementClient, error) { return management, nil })
+
+	result, err := p.List(context.Background(), &resource.ListRequest{
+		ResourceType:         "OCI::KeyManagement::Key",
+		AdditionalProperties: map[string]string{"VaultId": "ocid1.vault..aaa"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ocid1.vault..aaa/ocid1.key..aaa"}, result.NativeIDs)
+}
+
+// Helpers
+
+// newTestKmsClients returns a vault client and a management client served by
+// the same test server; the vault body names testManagementEndpoint, which
+// tests map to the management client.
+func newTestKmsClients(t *testing.T, responses map[route]canned) (*keymanagement.KmsVaultClient, *keymanagement.KmsManagementClient) {
+	t.Helper()
+	host := newTestDispatcher(t, responses)
+	vaultClient, err := keymanagement.NewKmsVaultClientWithConfigurationProvider(fakeOCIConfigProvider(t))
+	require.NoError(t, err)
+	applyTestRetryPolicy(&vaultClient)
+	vaultClient.Host = host
+	managementClient, err := keymanagement.NewKmsManagementClientWithConfigurationProvider(fakeOCIConfigProvider(t), host)
+	require.NoError(t, err)
+	applyTestRetryPolicy(&managementClient)
+	return &vaultClient, &managementClient
+}
+
+func newTestKmsVaultBody(lifecycleState string) string {
+	return fmt.Sprintf(`{
+		"id": "ocid1.vault..aaa",
+		"compartmentId": "ocid1.compartment..xxx",
+		"displayName": "app-vault",
+		"vaultType": "DEFAULT",
+		"lifecycleState": %q,
+		"managementEndpoint": %q,
+		"cryptoEndpoint": "https://aaa-crypto.kms.us-ashburn-1.oraclecloud.com",
+		"wrappingkeyId": "ocid1.key..wrap",
+		"timeCreated": "2025-01-01T00:00:00.000Z"
+	}`, lifecycleState, testManagementEndpoint)
+}
+
+func newTestKmsKeyBody(lifecycleState string) string {
+	return fmt.Sprintf(`{
+		"id": "ocid1.key..aaa",
+		"compartmentId": "ocid1.compartment..xxx",
+		"vaultId": "ocid1.vault..aaa",
+		"displayName": "app-key",
+		"keyShape": {"algorithm": "AES", "length": 32},
+		"protectionMode": "HSM",
+		"currentKeyVersion": "ocid1.keyversion..aaa",
+		"lifecycleState": %q,
+		"timeCreated": "2025-01-01T00:00:00.000Z"
+	}`, lifecycleState)
+}
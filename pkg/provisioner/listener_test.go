@@ -0,0 +1,174 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build integration
+
+package provisioner_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	ociloadbalancer "github.com/oracle/oci-go-sdk/v65/loadbalancer"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/loadbalancer"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListenerRead(t *testing.T) {
+	t.Run("success_with_ssl", func(t *testing.T) {
+		svc := newTestLoadBalancerClient(t, map[route]canned{
+			{"GET", "/20170115/loadBalancers/ocid1.loadbalancer..aaa"}: {200, newTestLoadBalancerBody("ACTIVE")},
+		})
+		p := loadbalancer.NewListenerProvisionerWithSvc(svc)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.loadbalancer..aaa/https"})
+		require.NoError(t, err)
+		assert.Empty(t, result.ErrorCode)
+
+		var props map[string]any
+		require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+		assert.Equal(t, "ocid1.loadbalancer..aaa", props["LoadBalancerId"])
+		assert.Equal(t, "https", props["Name"])
+		assert.Equal(t, float64(443), props["Port"])
+		ssl := props["SslConfiguration"].(map[string]any)
+		assert.Equal(t, "web-cert", ssl["certificateName"])
+		assert.Equal(t, false, ssl["verifyPeerCertificate"])
+		assert.Equal(t, []any{"TLSv1.2", "TLSv1.3"}, ssl["protocols"])
+		assert.Equal(t, "oci-default-ssl-cipher-suite-v1", ssl["cipherSuiteName"])
+	})
+
+	t.Run("listener_missing", func(t *testing.T) {
+		svc := newTestLoadBalancerClient(t, map[route]canned{
+			{"GET", "/20170115/loadBalancers/ocid1.loadbalancer..aaa"}: {200, newTestLoadBalancerBody("ACTIVE")},
+		})
+		p := loadbalancer.NewListenerProvisionerWithSvc(svc)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.loadbalancer..aaa/missing"})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationErrorCodeNotFound, result.ErrorCode)
+	})
+
+	t.Run("load_balancer_deleted", func(t *testing.T) {
+		svc := newTestLoadBalancerClient(t, map[route]canned{
+			{"GET", "/20170115/loadBalancers/ocid1.loadbalancer..aaa"}: {200, newTestLoadBalancerBody("DELETED")},
+		})
+		p := loadbalancer.NewListenerProvisionerWithSvc(svc)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.loadbalancer..aaa/https"})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationErrorCodeNotFound, result.ErrorCode)
+	})
+}
+
+func TestListenerStatus(t *testing.T) {
+	cases := []struct {
+		state    string
+		expected resource.OperationStatus
+	}{
+		{"SUCCEEDED", resource.OperationStatusSuccess},
+		{"IN_PROGRESS", resource.OperationStatusInProgress},
+		{"FAILED", resource.OperationStatusFailure},
+	}
+	for _, tc := range cases {
+		t.Run(tc.state, func(t *testing.T) {
+			svc := newTestLoadBalancerClient(t, map[route]canned{
+				{"GET", "/20170115/loadBalancerWorkRequests/ocid1.loadbalancerworkrequest..aaa"}: {200, newTestLBWorkRequestBody(tc.state)},
+			})
+			p := loadbalancer.NewListenerProvisionerWithSvc(svc)
+
+			result, err := p.Status(context.Background(), &resource.StatusRequest{
+				RequestID: "ocid1.loadbalancerworkrequest..aaa",
+				NativeID:  "ocid1.loadbalancer..aaa/https",
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result.ProgressResult.OperationStatus)
+			assert.Equal(t, "ocid1.loadbalancer..aaa/https", result.ProgressResult.NativeID)
+			if tc.state == "FAILED" {
+				assert.Equal(t, "certificate web-cert not found", result.ProgressResult.StatusMessage)
+			}
+		})
+	}
+}
+
+func TestListenerList(t *testing.T) {
+	svc := newTestLoadBalancerClient(t, map[route]canned{
+		{"GET", "/20170115/loadBalancers/ocid1.loadbalancer..aaa"}: {200, newTestLoadBalancerBody("ACTIVE")},
+	})
+	p := loadbalancer.NewListenerProvisionerWithSvc(svc)
+
+	result, err := p.List(context.Background(), &resource.ListRequest{
+		ResourceType:         "OCI::LoadBalancer::Listener",
+		AdditionalProperties: map[string]string{"LoadBalancerId": "ocid1.loadbalancer..aaa"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ocid1.loadbalancer..aaa/http", "ocid1.loadbalancer..aaa/https"}, result.NativeIDs)
+}
+
+// Helpers
+
+func newTestLoadBalancerClient(t *testing.T, responses map[route]canned) *ociloadbalancer.LoadBalancerClient {
+	t.Helper()
+	host := newTestDispatcher(t, responses)
+	c, err := ociloadbalancer.NewLoadBalancerClientWithConfigurationProvider(fakeOCIConfigProvider(t))
+	require.NoError(t, err)
+	applyTestRetryPolicy(&c)
+	c.Host = host
+	return &c
+}
+
+func newTestLoadBalancerBody(lifecycleState string) string {
+	return fmt.Sprintf(`{
+		"id": "ocid1.loadbalancer..aaa",
+		"compartmentId": "ocid1.compartment..xxx",
+		"displayName": "test-lb",
+		"lifecycleState": %q,
+		"timeCreated": "2025-01-01T00:00:00.000Z",
+		"shapeName": "flexible",
+		"listeners": {
+			"http": {
+				"name": "http",
+				"defaultBackendSetName": "web",
+				"port": 80,
+				"protocol": "HTTP"
+			},
+			"https": {
+				"name": "https",
+				"defaultBackendSetName": "web",
+				"port": 443,
+				"protocol": "HTTP",
+				"sslConfiguration": {
+					"certificateName": "web-cert",
+					"verifyPeerCertificate": false,
+					"verifyDepth": 1,
+					"protocols": ["TLSv1.2", "TLSv1.3"],
+					"cipherSuiteName": "oci-default-ssl-cipher-suite-v1"
+				}
+			}
+		},
+		"backendSets": {"web": %s},
+		"certificates": {
+			"web-cert": {
+				"certificateName": "web-cert",
+				"publicCertificate": "-----BEGIN CERTIFICATE-----",
+				"caCertificate": "-----BEGIN CERTIFICATE-----"
+			}
+		}
+	}`, lifecycleState, newTestBackendSetBody())
+}
+
+func newTestLBWorkRequestBody(lifecycleState string) string {
+	return fmt.Sprintf(`{
+		"id": "ocid1.loadbalancerworkrequest..aaa",
+		"loadBalancerId": "ocid1.loadbalancer..aaa",
+		"type": "CreateListener",
+		"lifecycleState": %q,
+		"message": "",
+		"timeAccepted": "2025-01-01T00:00:00.000Z",
+		"errorDetails": [{"errorCode": "BAD_INPUT", "message": "certificate web-cert not found"}]
+	}`, lifecycleState)
+}
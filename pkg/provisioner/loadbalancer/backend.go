@@ -0,0 +1,334 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package loadbalancer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/loadbalancer"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+// BackendProvisioner manages a single backend server of a backend set, so that
+// backends can be registered and drained independently of the set itself.
+type BackendProvisioner struct {
+	clients *client.Clients
+	svc     *loadbalancer.LoadBalancerClient // nil until first use; injected in tests
+}
+
+var (
+	_ provisioner.Provisioner = &BackendProvisioner{}
+	_ provisioner.Immutable   = &BackendProvisioner{}
+)
+
+func init() {
+	provisioner.Register("OCI::LoadBalancer::Backend", NewBackendProvisioner)
+	provisioner.DependsOn("OCI::LoadBalancer::Backend", "OCI::LoadBalancer::LoadBalancer", "OCI::LoadBalancer::BackendSet")
+}
+
+func NewBackendProvisioner(clients *client.Clients) provisioner.Provisioner {
+	return &BackendProvisioner{clients: clients}
+}
+
+// NewBackendProvisionerWithSvc constructs a provisioner with a pre-built SDK client,
+// for use in tests that point the client at an httptest server.
+func NewBackendProvisionerWithSvc(svc *loadbalancer.LoadBalancerClient) *BackendProvisioner {
+	return &BackendProvisioner{svc: svc}
+}
+
+// ImmutableFields lists the backend's address: it is the backend's name, so
+// moving a backend means registering a new one.
+func (p *BackendProvisioner) ImmutableFields() []string {
+	return []string{"LoadBalancerId", "BackendSetName", "IpAddress", "Port"}
+}
+
+func (p *BackendProvisioner) getSvc() (*loadbalancer.LoadBalancerClient, error) {
+	if p.svc != nil {
+		return p.svc, nil
+	}
+	return p.clients.GetLoadBalancerClient()
+}
+
+// parseBackendID splits the NativeID of a backend.
+// Format: {loadBalancerId}/{backendSetName}/{ipAddress:port}
+func parseBackendID(nativeID string) (loadBalancerId, backendSetName, name string, err error) {
+	parts, err := util.DecodeCompositeID(nativeID, 3)
+	if err != nil {
+		return "", "", "", fmt.Errorf("invalid NativeID format: expected {loadBalancerId}/{backendSetName}/{ipAddress:port}: %w", err)
+	}
+	return parts[0], parts[1], parts[2], nil
+}
+
+// backendName is the name OCI gives a backend: its address and port.
+func backendName(ipAddress string, port int) string {
+	return fmt.Sprintf("%s:%d", ipAddress, port)
+}
+
+// HasNoTags: backends are entries in a backend set and take no tags.
+func (p *BackendProvisioner) HasNoTags() {}
+
+func (p *BackendProvisioner) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	var props map[string]any
+	if err := json.Unmarshal(request.Properties, &props); err != nil {
+		return nil, fmt.Errorf("failed to parse properties: %w", err)
+	}
+
+	loadBalancerId := props["LoadBalancerId"].(string)
+	backendSetName := props["BackendSetName"].(string)
+	ipAddress, ok := util.ExtractResolvedReference(props, "IpAddress")
+	if !ok {
+		return nil, fmt.Errorf("IpAddress is required")
+	}
+	port, ok := extractInt(props, "Port")
+	if !ok {
+		return nil, fmt.Errorf("Port is required")
+	}
+
+	createDetails := loadbalancer.CreateBackendDetails{
+		IpAddress: common.String(ipAddress),
+		Port:      common.Int(port),
+	}
+	if weight, ok := extractInt(props, "Weight"); ok {
+		createDetails.Weight = common.Int(weight)
+	}
+	if backup, ok := util.ExtractBool(props, "Backup"); ok {
+		createDetails.Backup = common.Bool(backup)
+	}
+	if drain, ok := util.ExtractBool(props, "Drain"); ok {
+		createDetails.Drain = common.Bool(drain)
+	}
+	if offline, ok := util.ExtractBool(props, "Offline"); ok {
+		createDetails.Offline = common.Bool(offline)
+	}
+
+	resp, err := client.CreateBackend(ctx, loadbalancer.CreateBackendRequest{
+		LoadBalancerId:       common.String(loadBalancerId),
+		BackendSetName:       common.String(backendSetName),
+		CreateBackendDetails: createDetails,
+		OpcRetryToken:        common.String(util.CreateRetryToken(request)),
+	})
+	if err != nil {
+		if result, handleErr := util.HandleCreateError(err, "OCI::LoadBalancer::Backend", "OCI::LoadBalancer::Backend"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to create Backend: %w", err)
+	}
+
+	nativeID := util.EncodeCompositeID(loadBalancerId, backendSetName, backendName(ipAddress, port))
+	return &resource.CreateResult{
+		ProgressResult: CreateInProgressResult(resource.OperationCreate, *resp.OpcWorkRequestId, nativeID),
+	}, nil
+}
+
+// Update changes the weight and the backup, drain and offline flags. Draining a
+// backend stops new connections while letting existing ones finish, which is
+// how a rollout takes an instance out of rotation gracefully.
+func (p *BackendProvisioner) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	loadBalancerId, backendSetName, name, err := parseBackendID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	props, err := util.ApplyPatchDocument(ctx, request, p.Read)
+	if err != nil {
+		return nil, err
+	}
+
+	// UpdateBackend requires every field; a property dropped from the patched
+	// document falls back to the OCI default.
+	weight, ok := extractInt(props, "Weight")
+	if !ok {
+		weight = 1
+	}
+	backup, _ := util.ExtractBool(props, "Backup")
+	drain, _ := util.ExtractBool(props, "Drain")
+	offline, _ := util.ExtractBool(props, "Offline")
+
+	resp, err := client.UpdateBackend(ctx, loadbalancer.UpdateBackendRequest{
+		LoadBalancerId: common.String(loadBalancerId),
+		BackendSetName: common.String(backendSetName),
+		BackendName:    common.String(name),
+		UpdateBackendDetails: loadbalancer.UpdateBackendDetails{
+			Weight:  common.Int(weight),
+			Backup:  common.Bool(backup),
+			Drain:   common.Bool(drain),
+			Offline: common.Bool(offline),
+		},
+	})
+	if err != nil {
+		if result, handleErr := util.HandleUpdateError(err, "OCI::LoadBalancer::Backend", request.NativeID, "OCI::LoadBalancer::Backend"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to update Backend: %w", err)
+	}
+
+	return &resource.UpdateResult{
+		ProgressResult: CreateInProgressResult(resource.OperationUpdate, *resp.OpcWorkRequestId, request.NativeID),
+	}, nil
+}
+
+func (p *BackendProvisioner) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	loadBalancerId, backendSetName, name, err := parseBackendID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	readRes, err := p.Read(ctx, &resource.ReadRequest{NativeID: request.NativeID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Backend before delete: %w", err)
+	}
+	if readRes.ErrorCode == resource.OperationErrorCodeNotFound {
+		return &resource.DeleteResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationDelete,
+				OperationStatus: resource.OperationStatusSuccess,
+				NativeID:        request.NativeID,
+			},
+		}, nil
+	}
+
+	resp, err := client.DeleteBackend(ctx, loadbalancer.DeleteBackendRequest{
+		LoadBalancerId: common.String(loadBalancerId),
+		BackendSetName: common.String(backendSetName),
+		BackendName:    common.String(name),
+	})
+	if err != nil {
+		if result, handleErr := util.HandleDeleteError(err, "OCI::LoadBalancer::Backend", request.NativeID, "OCI::LoadBalancer::Backend"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to delete Backend: %w", err)
+	}
+
+	return &resource.DeleteResult{
+		ProgressResult: CreateInProgressResult(resource.OperationDelete, *resp.OpcWorkRequestId, request.NativeID),
+	}, nil
+}
+
+func (p *BackendProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	result, err := CheckWorkRequestStatus(ctx, client, request.RequestID, request.NativeID, resource.OperationCheckStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resource.StatusResult{
+		ProgressResult: result,
+	}, nil
+}
+
+func (p *BackendProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	loadBalancerId, backendSetName, name, err := parseBackendID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetBackend(ctx, loadbalancer.GetBackendRequest{
+		LoadBalancerId: common.String(loadBalancerId),
+		BackendSetName: common.String(backendSetName),
+		BackendName:    common.String(name),
+	})
+	if err != nil {
+		if util.IsNotFound(err) {
+			return &resource.ReadResult{
+				ResourceType: "OCI::LoadBalancer::Backend",
+				ErrorCode:    resource.OperationErrorCodeNotFound,
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to read Backend: %w", err)
+	}
+
+	props := map[string]any{
+		"LoadBalancerId": loadBalancerId,
+		"BackendSetName": backendSetName,
+		"Name":           *resp.Name,
+		"IpAddress":      *resp.IpAddress,
+		"Port":           *resp.Port,
+		"Weight":         *resp.Weight,
+		"Backup":         *resp.Backup,
+		"Drain":          *resp.Drain,
+		"Offline":        *resp.Offline,
+	}
+
+	propBytes, err := json.Marshal(props)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Backend properties: %w", err)
+	}
+
+	return &resource.ReadResult{
+		ResourceType: "OCI::LoadBalancer::Backend",
+		Properties:   string(propBytes),
+	}, nil
+}
+
+// List returns the backends of every backend set on the load balancer, or of
+// a single set when BackendSetName is given.
+func (p *BackendProvisioner) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	loadBalancerId, ok := request.AdditionalProperties["LoadBalancerId"]
+	if !ok {
+		return nil, fmt.Errorf("LoadBalancerId is required for listing Backends")
+	}
+	backendSetFilter, hasFilter := request.AdditionalProperties["BackendSetName"]
+
+	lb, err := getActiveLoadBalancer(ctx, client, loadBalancerId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Backends: %w", err)
+	}
+
+	nativeIDs := []string{}
+	if lb != nil {
+		for setName, backendSet := range lb.BackendSets {
+			if hasFilter && setName != backendSetFilter {
+				continue
+			}
+			for _, b := range backendSet.Backends {
+				if b.Name == nil {
+					continue
+				}
+				nativeIDs = append(nativeIDs, util.EncodeCompositeID(loadBalancerId, setName, *b.Name))
+			}
+		}
+		sort.Strings(nativeIDs)
+	}
+
+	return &resource.ListResult{
+		NativeIDs: nativeIDs,
+	}, nil
+}
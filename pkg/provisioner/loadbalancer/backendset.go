@@ -0,0 +1,420 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package loadbalancer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/loadbalancer"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+type BackendSetProvisioner struct {
+	clients *client.Clients
+	svc     *loadbalancer.LoadBalancerClient // nil until first use; injected in tests
+}
+
+var _ provisioner.Provisioner = &BackendSetProvisioner{}
+
+func init() {
+	provisioner.Register("OCI::LoadBalancer::BackendSet", NewBackendSetProvisioner)
+	provisioner.DependsOn("OCI::LoadBalancer::BackendSet", "OCI::LoadBalancer::LoadBalancer")
+}
+
+func NewBackendSetProvisioner(clients *client.Clients) provisioner.Provisioner {
+	return &BackendSetProvisioner{clients: clients}
+}
+
+// NewBackendSetProvisionerWithSvc constructs a provisioner with a pre-built SDK client,
+// for use in tests that point the client at an httptest server.
+func NewBackendSetProvisionerWithSvc(svc *loadbalancer.LoadBalancerClient) *BackendSetProvisioner {
+	return &BackendSetProvisioner{svc: svc}
+}
+
+func (p *BackendSetProvisioner) getSvc() (*loadbalancer.LoadBalancerClient, error) {
+	if p.svc != nil {
+		return p.svc, nil
+	}
+	return p.clients.GetLoadBalancerClient()
+}
+
+func parseHealthChecker(props map[string]any) *loadbalancer.HealthCheckerDetails {
+	m, ok := props["HealthChecker"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	details := &loadbalancer.HealthCheckerDetails{}
+	if protocol, ok := util.ExtractString(m, "protocol"); ok {
+		details.Protocol = common.String(protocol)
+	}
+	if port, ok := extractInt(m, "port"); ok {
+		details.Port = common.Int(port)
+	}
+	if urlPath, ok := util.ExtractString(m, "urlPath"); ok {
+		details.UrlPath = common.String(urlPath)
+	}
+	if returnCode, ok := extractInt(m, "returnCode"); ok {
+		details.ReturnCode = common.Int(returnCode)
+	}
+	if retries, ok := extractInt(m, "retries"); ok {
+		details.Retries = common.Int(retries)
+	}
+	if timeout, ok := extractInt(m, "timeoutInMillis"); ok {
+		details.TimeoutInMillis = common.Int(timeout)
+	}
+	if interval, ok := extractInt(m, "intervalInMillis"); ok {
+		details.IntervalInMillis = common.Int(interval)
+	}
+	if regex, ok := util.ExtractString(m, "responseBodyRegex"); ok {
+		details.ResponseBodyRegex = common.String(regex)
+	}
+	if isForcePlainText, ok := util.ExtractBool(m, "isForcePlainText"); ok {
+		details.IsForcePlainText = common.Bool(isForcePlainText)
+	}
+	return details
+}
+
+func parseBackends(props map[string]any) []loadbalancer.BackendDetails {
+	items, _ := props["Backends"].([]any)
+	backends := make([]loadbalancer.BackendDetails, 0, len(items))
+	for _, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		backend := loadbalancer.BackendDetails{}
+		if ipAddress, ok := util.ExtractString(m, "ipAddress"); ok {
+			backend.IpAddress = common.String(ipAddress)
+		}
+		if port, ok := extractInt(m, "port"); ok {
+			backend.Port = common.Int(port)
+		}
+		if weight, ok := extractInt(m, "weight"); ok {
+			backend.Weight = common.Int(weight)
+		}
+		if maxConnections, ok := extractInt(m, "maxConnections"); ok {
+			backend.MaxConnections = common.Int(maxConnections)
+		}
+		if backup, ok := util.ExtractBool(m, "backup"); ok {
+			backend.Backup = common.Bool(backup)
+		}
+		if drain, ok := util.ExtractBool(m, "drain"); ok {
+			backend.Drain = common.Bool(drain)
+		}
+		if offline, ok := util.ExtractBool(m, "offline"); ok {
+			backend.Offline = common.Bool(offline)
+		}
+		backends = append(backends, backend)
+	}
+	return backends
+}
+
+// HasNoTags: backend sets are part of the load balancer, which holds the tags.
+func (p *BackendSetProvisioner) HasNoTags() {}
+
+func (p *BackendSetProvisioner) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	var props map[string]any
+	if err := json.Unmarshal(request.Properties, &props); err != nil {
+		return nil, fmt.Errorf("failed to parse properties: %w", err)
+	}
+
+	loadBalancerId := props["LoadBalancerId"].(string)
+	name := props["Name"].(string)
+
+	createDetails := loadbalancer.CreateBackendSetDetails{
+		Name:          common.String(name),
+		Policy:        common.String(props["Policy"].(string)),
+		HealthChecker: parseHealthChecker(props),
+		Backends:      parseBackends(props),
+	}
+
+	if maxConnections, ok := extractInt(props, "BackendMaxConnections"); ok {
+		createDetails.BackendMaxConnections = common.Int(maxConnections)
+	}
+	if sslConfig, ok := parseSslConfiguration(props); ok {
+		createDetails.SslConfiguration = sslConfig
+	}
+
+	resp, err := client.CreateBackendSet(ctx, loadbalancer.CreateBackendSetRequest{
+		LoadBalancerId:          common.String(loadBalancerId),
+		CreateBackendSetDetails: createDetails,
+		OpcRetryToken:           common.String(util.CreateRetryToken(request)),
+	})
+	if err != nil {
+		if result, handleErr := util.HandleCreateError(err, "OCI::LoadBalancer::BackendSet", "OCI::LoadBalancer::BackendSet"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to create BackendSet: %w", err)
+	}
+
+	return &resource.CreateResult{
+		ProgressResult: CreateInProgressResult(resource.OperationCreate, *resp.OpcWorkRequestId, util.EncodeCompositeID(loadBalancerId, name)),
+	}, nil
+}
+
+func (p *BackendSetProvisioner) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	loadBalancerId, name, err := parseSubResourceID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	props, err := util.ApplyPatchDocument(ctx, request, p.Read)
+	if err != nil {
+		return nil, err
+	}
+
+	// UpdateBackendSet replaces the backend list and SSL settings as a whole
+	updateDetails := loadbalancer.UpdateBackendSetDetails{
+		Policy:        common.String(props["Policy"].(string)),
+		HealthChecker: parseHealthChecker(props),
+		Backends:      parseBackends(props),
+	}
+
+	if maxConnections, ok := extractInt(props, "BackendMaxConnections"); ok {
+		updateDetails.BackendMaxConnections = common.Int(maxConnections)
+	}
+	if sslConfig, ok := parseSslConfiguration(props); ok {
+		updateDetails.SslConfiguration = sslConfig
+	}
+
+	resp, err := client.UpdateBackendSet(ctx, loadbalancer.UpdateBackendSetRequest{
+		LoadBalancerId:          common.String(loadBalancerId),
+		BackendSetName:          common.String(name),
+		UpdateBackendSetDetails: updateDetails,
+	})
+	if err != nil {
+		if result, handleErr := util.HandleUpdateError(err, "OCI::LoadBalancer::BackendSet", request.NativeID, "OCI::LoadBalancer::BackendSet"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to update BackendSet: %w", err)
+	}
+
+	return &resource.UpdateResult{
+		ProgressResult: CreateInProgressResult(resource.OperationUpdate, *resp.OpcWorkRequestId, request.NativeID),
+	}, nil
+}
+
+func (p *BackendSetProvisioner) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	loadBalancerId, name, err := parseSubResourceID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	readRes, err := p.Read(ctx, &resource.ReadRequest{NativeID: request.NativeID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read BackendSet before delete: %w", err)
+	}
+	if readRes.ErrorCode == resource.OperationErrorCodeNotFound {
+		return &resource.DeleteResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationDelete,
+				OperationStatus: resource.OperationStatusSuccess,
+				NativeID:        request.NativeID,
+			},
+		}, nil
+	}
+
+	resp, err := client.DeleteBackendSet(ctx, loadbalancer.DeleteBackendSetRequest{
+		LoadBalancerId: common.String(loadBalancerId),
+		BackendSetName: common.String(name),
+	})
+	if err != nil {
+		if result, handleErr := util.HandleDeleteError(err, "OCI::LoadBalancer::BackendSet", request.NativeID, "OCI::LoadBalancer::BackendSet"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to delete BackendSet: %w", err)
+	}
+
+	return &resource.DeleteResult{
+		ProgressResult: CreateInProgressResult(resource.OperationDelete, *resp.OpcWorkRequestId, request.NativeID),
+	}, nil
+}
+
+func (p *BackendSetProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	result, err := CheckWorkRequestStatus(ctx, client, request.RequestID, request.NativeID, resource.OperationCheckStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resource.StatusResult{
+		ProgressResult: result,
+	}, nil
+}
+
+func (p *BackendSetProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	loadBalancerId, name, err := parseSubResourceID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetBackendSet(ctx, loadbalancer.GetBackendSetRequest{
+		LoadBalancerId: common.String(loadBalancerId),
+		BackendSetName: common.String(name),
+	})
+	if err != nil {
+		if util.IsNotFound(err) {
+			return &resource.ReadResult{
+				ResourceType: "OCI::LoadBalancer::BackendSet",
+				ErrorCode:    resource.OperationErrorCodeNotFound,
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to read BackendSet: %w", err)
+	}
+
+	props := buildBackendSetProperties(loadBalancerId, resp.BackendSet)
+	if health := readBackendSetHealth(ctx, client, loadBalancerId, name); health != nil {
+		props["HealthStatus"] = health
+	}
+
+	propBytes, err := json.Marshal(props)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal BackendSet properties: %w", err)
+	}
+
+	return &resource.ReadResult{
+		ResourceType: "OCI::LoadBalancer::BackendSet",
+		Properties:   string(propBytes),
+	}, nil
+}
+
+func buildBackendSetProperties(loadBalancerId string, backendSet loadbalancer.BackendSet) map[string]any {
+	props := map[string]any{
+		"LoadBalancerId": loadBalancerId,
+		"Name":           *backendSet.Name,
+		"Policy":         *backendSet.Policy,
+	}
+
+	if hc := backendSet.HealthChecker; hc != nil {
+		healthChecker := map[string]any{}
+		if hc.Protocol != nil {
+			healthChecker["protocol"] = *hc.Protocol
+		}
+		if hc.Port != nil {
+			healthChecker["port"] = *hc.Port
+		}
+		if hc.UrlPath != nil {
+			healthChecker["urlPath"] = *hc.UrlPath
+		}
+		if hc.ReturnCode != nil {
+			healthChecker["returnCode"] = *hc.ReturnCode
+		}
+		if hc.Retries != nil {
+			healthChecker["retries"] = *hc.Retries
+		}
+		if hc.TimeoutInMillis != nil {
+			healthChecker["timeoutInMillis"] = *hc.TimeoutInMillis
+		}
+		if hc.IntervalInMillis != nil {
+			healthChecker["intervalInMillis"] = *hc.IntervalInMillis
+		}
+		if hc.ResponseBodyRegex != nil && *hc.ResponseBodyRegex != "" {
+			healthChecker["responseBodyRegex"] = *hc.ResponseBodyRegex
+		}
+		if hc.IsForcePlainText != nil {
+			healthChecker["isForcePlainText"] = *hc.IsForcePlainText
+		}
+		props["HealthChecker"] = healthChecker
+	}
+
+	if len(backendSet.Backends) > 0 {
+		backends := make([]map[string]any, 0, len(backendSet.Backends))
+		for _, b := range backendSet.Backends {
+			backend := map[string]any{}
+			if b.IpAddress != nil {
+				backend["ipAddress"] = *b.IpAddress
+			}
+			if b.Port != nil {
+				backend["port"] = *b.Port
+			}
+			if b.Weight != nil {
+				backend["weight"] = *b.Weight
+			}
+			if b.MaxConnections != nil {
+				backend["maxConnections"] = *b.MaxConnections
+			}
+			if b.Backup != nil {
+				backend["backup"] = *b.Backup
+			}
+			if b.Drain != nil {
+				backend["drain"] = *b.Drain
+			}
+			if b.Offline != nil {
+				backend["offline"] = *b.Offline
+			}
+			backends = append(backends, backend)
+		}
+		props["Backends"] = backends
+	}
+
+	if backendSet.BackendMaxConnections != nil {
+		props["BackendMaxConnections"] = *backendSet.BackendMaxConnections
+	}
+	if backendSet.SslConfiguration != nil {
+		props["SslConfiguration"] = buildSslConfiguration(backendSet.SslConfiguration)
+	}
+
+	return props
+}
+
+func (p *BackendSetProvisioner) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	loadBalancerId, ok := request.AdditionalProperties["LoadBalancerId"]
+	if !ok {
+		return nil, fmt.Errorf("LoadBalancerId is required for listing BackendSets")
+	}
+
+	lb, err := getActiveLoadBalancer(ctx, client, loadBalancerId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list BackendSets: %w", err)
+	}
+
+	nativeIDs := []string{}
+	if lb != nil {
+		for name := range lb.BackendSets {
+			nativeIDs = append(nativeIDs, util.EncodeCompositeID(loadBalancerId, name))
+		}
+		sort.Strings(nativeIDs)
+	}
+
+	return &resource.ListResult{
+		NativeIDs: nativeIDs,
+	}, nil
+}
@@ -0,0 +1,62 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package loadbalancer
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/loadbalancer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHealthCheckerRoundTrip sends a health checker through Create's parser and
+// reads it back the way OCI returns it, so a field dropped on either side shows
+// up as drift here rather than as a perpetual diff.
+func TestHealthCheckerRoundTrip(t *testing.T) {
+	healthChecker := map[string]any{
+		"protocol":          "HTTP",
+		"port":              8443,
+		"urlPath":           "/healthz",
+		"returnCode":        204,
+		"retries":           5,
+		"timeoutInMillis":   2500,
+		"intervalInMillis":  15000,
+		"responseBodyRegex": "^ok$",
+		"isForcePlainText":  true,
+	}
+	props := roundTrip(t, map[string]any{"HealthChecker": healthChecker})
+
+	details := parseHealthChecker(props)
+	require.NotNil(t, details)
+
+	// HealthCheckerDetails and HealthChecker share their JSON shape, which is
+	// how the API echoes the request back
+	b, err := json.Marshal(details)
+	require.NoError(t, err)
+	var readBack loadbalancer.HealthChecker
+	require.NoError(t, json.Unmarshal(b, &readBack))
+
+	read := roundTrip(t, buildBackendSetProperties("ocid1.loadbalancer..aaa", loadbalancer.BackendSet{
+		Name:          common.String("web"),
+		Policy:        common.String("ROUND_ROBIN"),
+		HealthChecker: &readBack,
+	}))
+	assert.Equal(t, props["HealthChecker"], read["HealthChecker"])
+}
+
+func TestHealthCheckerOmitsEmptyResponseBodyRegex(t *testing.T) {
+	props := buildBackendSetProperties("ocid1.loadbalancer..aaa", loadbalancer.BackendSet{
+		Name:   common.String("web"),
+		Policy: common.String("ROUND_ROBIN"),
+		HealthChecker: &loadbalancer.HealthChecker{
+			Protocol:          common.String("TCP"),
+			ResponseBodyRegex: common.String(""),
+		},
+	})
+	assert.NotContains(t, props["HealthChecker"], "responseBodyRegex")
+}
@@ -0,0 +1,251 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package loadbalancer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/loadbalancer"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+type CertificateProvisioner struct {
+	clients *client.Clients
+	svc     *loadbalancer.LoadBalancerClient // nil until first use; injected in tests
+}
+
+var (
+	_ provisioner.Provisioner = &CertificateProvisioner{}
+	_ provisioner.Immutable   = &CertificateProvisioner{}
+)
+
+func init() {
+	provisioner.Register("OCI::LoadBalancer::Certificate", NewCertificateProvisioner)
+	provisioner.DependsOn("OCI::LoadBalancer::Certificate", "OCI::LoadBalancer::LoadBalancer")
+}
+
+func NewCertificateProvisioner(clients *client.Clients) provisioner.Provisioner {
+	return &CertificateProvisioner{clients: clients}
+}
+
+// NewCertificateProvisionerWithSvc constructs a provisioner with a pre-built SDK client,
+// for use in tests that point the client at an httptest server.
+func NewCertificateProvisionerWithSvc(svc *loadbalancer.LoadBalancerClient) *CertificateProvisioner {
+	return &CertificateProvisioner{svc: svc}
+}
+
+func (p *CertificateProvisioner) getSvc() (*loadbalancer.LoadBalancerClient, error) {
+	if p.svc != nil {
+		return p.svc, nil
+	}
+	return p.clients.GetLoadBalancerClient()
+}
+
+// ImmutableFields lists every field: a certificate is named within its load
+// balancer and its bundle can't be changed once uploaded, so any change is a
+// replacement.
+func (p *CertificateProvisioner) ImmutableFields() []string {
+	return append([]string{"LoadBalancerId", "CertificateName"}, certificateBundleFields...)
+}
+
+// HasNoTags: load balancer certificate bundles take no tags.
+func (p *CertificateProvisioner) HasNoTags() {}
+
+func (p *CertificateProvisioner) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	var props map[string]any
+	if err := json.Unmarshal(request.Properties, &props); err != nil {
+		return nil, fmt.Errorf("failed to parse properties: %w", err)
+	}
+
+	loadBalancerId := props["LoadBalancerId"].(string)
+	certificateName := props["CertificateName"].(string)
+
+	details := loadbalancer.CreateCertificateDetails{
+		CertificateName: common.String(certificateName),
+	}
+	if publicCertificate, ok := util.ExtractString(props, "PublicCertificate"); ok {
+		details.PublicCertificate = common.String(publicCertificate)
+	}
+	if privateKey, ok := util.ExtractString(props, "PrivateKey"); ok {
+		details.PrivateKey = common.String(privateKey)
+	}
+	if caCertificate, ok := util.ExtractString(props, "CaCertificate"); ok {
+		details.CaCertificate = common.String(caCertificate)
+	}
+	if passphrase, ok := util.ExtractString(props, "Passphrase"); ok {
+		details.Passphrase = common.String(passphrase)
+	}
+
+	resp, err := client.CreateCertificate(ctx, loadbalancer.CreateCertificateRequest{
+		LoadBalancerId:           common.String(loadBalancerId),
+		CreateCertificateDetails: details,
+		OpcRetryToken:            common.String(util.CreateRetryToken(request)),
+	})
+	if err != nil {
+		if result, handleErr := util.HandleCreateError(err, "OCI::LoadBalancer::Certificate", "OCI::LoadBalancer::Certificate"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to create Certificate: %w", err)
+	}
+
+	return &resource.CreateResult{
+		ProgressResult: CreateInProgressResult(resource.OperationCreate, *resp.OpcWorkRequestId, util.EncodeCompositeID(loadBalancerId, certificateName)),
+	}, nil
+}
+
+// certificateBundleFields are the parts of a certificate bundle, none of which
+// can be changed once it is uploaded.
+var certificateBundleFields = []string{"PublicCertificate", "PrivateKey", "CaCertificate", "Passphrase"}
+
+// Update always asks for a replacement: load balancer certificates have no
+// update API, so a changed bundle is uploaded as a new certificate. Changes
+// with a patch document are caught earlier, field by field; see ImmutableFields.
+func (p *CertificateProvisioner) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	return util.ReplacementRequired(request.NativeID, certificateBundleFields...), nil
+}
+
+func (p *CertificateProvisioner) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	loadBalancerId, certificateName, err := parseSubResourceID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	readRes, err := p.Read(ctx, &resource.ReadRequest{NativeID: request.NativeID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Certificate before delete: %w", err)
+	}
+	if readRes.ErrorCode == resource.OperationErrorCodeNotFound {
+		return &resource.DeleteResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationDelete,
+				OperationStatus: resource.OperationStatusSuccess,
+				NativeID:        request.NativeID,
+			},
+		}, nil
+	}
+
+	resp, err := client.DeleteCertificate(ctx, loadbalancer.DeleteCertificateRequest{
+		LoadBalancerId:  common.String(loadBalancerId),
+		CertificateName: common.String(certificateName),
+	})
+	if err != nil {
+		if result, handleErr := util.HandleDeleteError(err, "OCI::LoadBalancer::Certificate", request.NativeID, "OCI::LoadBalancer::Certificate"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to delete Certificate: %w", err)
+	}
+
+	return &resource.DeleteResult{
+		ProgressResult: CreateInProgressResult(resource.OperationDelete, *resp.OpcWorkRequestId, request.NativeID),
+	}, nil
+}
+
+func (p *CertificateProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	result, err := CheckWorkRequestStatus(ctx, client, request.RequestID, request.NativeID, resource.OperationCheckStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resource.StatusResult{
+		ProgressResult: result,
+	}, nil
+}
+
+// Read only confirms the certificate exists. OCI never returns the private key
+// or passphrase, so the bundle contents are write-only and not reported back.
+func (p *CertificateProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	loadBalancerId, certificateName, err := parseSubResourceID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	lb, err := getActiveLoadBalancer(ctx, client, loadBalancerId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Certificate: %w", err)
+	}
+
+	var certificate loadbalancer.Certificate
+	found := false
+	if lb != nil {
+		certificate, found = lb.Certificates[certificateName]
+	}
+	if !found {
+		return &resource.ReadResult{
+			ResourceType: "OCI::LoadBalancer::Certificate",
+			ErrorCode:    resource.OperationErrorCodeNotFound,
+		}, nil
+	}
+
+	props := map[string]any{
+		"LoadBalancerId":  loadBalancerId,
+		"CertificateName": *certificate.CertificateName,
+	}
+
+	propBytes, err := json.Marshal(props)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Certificate properties: %w", err)
+	}
+
+	return &resource.ReadResult{
+		ResourceType: "OCI::LoadBalancer::Certificate",
+		Properties:   string(propBytes),
+	}, nil
+}
+
+func (p *CertificateProvisioner) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	loadBalancerId, ok := request.AdditionalProperties["LoadBalancerId"]
+	if !ok {
+		return nil, fmt.Errorf("LoadBalancerId is required for listing Certificates")
+	}
+
+	lb, err := getActiveLoadBalancer(ctx, client, loadBalancerId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Certificates: %w", err)
+	}
+
+	nativeIDs := []string{}
+	if lb != nil {
+		for name := range lb.Certificates {
+			nativeIDs = append(nativeIDs, util.EncodeCompositeID(loadBalancerId, name))
+		}
+		sort.Strings(nativeIDs)
+	}
+
+	return &resource.ListResult{
+		NativeIDs: nativeIDs,
+	}, nil
+}
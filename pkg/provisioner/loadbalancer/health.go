@@ -0,0 +1,79 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package loadbalancer
+
+import (
+	"context"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/loadbalancer"
+)
+
+// readBackendSetHealth returns the output-only HealthStatus of a backend set,
+// or nil when it can't be determined. Health is only meaningful while the load
+// balancer is ACTIVE, and it is diagnostic rather than configuration, so any
+// failure to fetch it leaves the property out instead of failing the Read.
+func readBackendSetHealth(ctx context.Context, client *loadbalancer.LoadBalancerClient, loadBalancerId, name string) map[string]any {
+	lbResp, err := client.GetLoadBalancer(ctx, loadbalancer.GetLoadBalancerRequest{
+		LoadBalancerId: common.String(loadBalancerId),
+	})
+	if err != nil || lbResp.LifecycleState != loadbalancer.LoadBalancerLifecycleStateActive {
+		return nil
+	}
+
+	resp, err := client.GetBackendSetHealth(ctx, loadbalancer.GetBackendSetHealthRequest{
+		LoadBalancerId: common.String(loadBalancerId),
+		BackendSetName: common.String(name),
+	})
+	if err != nil {
+		return nil
+	}
+
+	health := map[string]any{
+		"status":           string(resp.Status),
+		"warningBackends":  nonNilStrings(resp.WarningStateBackendNames),
+		"criticalBackends": nonNilStrings(resp.CriticalStateBackendNames),
+		"unknownBackends":  nonNilStrings(resp.UnknownStateBackendNames),
+	}
+	if resp.TotalBackendCount != nil {
+		health["totalBackendCount"] = *resp.TotalBackendCount
+	}
+	return health
+}
+
+// readLoadBalancerHealth returns the output-only HealthStatus of the load
+// balancer as a whole, rolled up from its backend sets. Like the backend set
+// health it is only fetched while the load balancer is ACTIVE and left out on
+// any error.
+func readLoadBalancerHealth(ctx context.Context, client *loadbalancer.LoadBalancerClient, lb loadbalancer.LoadBalancer) map[string]any {
+	if lb.LifecycleState != loadbalancer.LoadBalancerLifecycleStateActive {
+		return nil
+	}
+
+	resp, err := client.GetLoadBalancerHealth(ctx, loadbalancer.GetLoadBalancerHealthRequest{
+		LoadBalancerId: lb.Id,
+	})
+	if err != nil {
+		return nil
+	}
+
+	health := map[string]any{
+		"status":              string(resp.Status),
+		"warningBackendSets":  nonNilStrings(resp.WarningStateBackendSetNames),
+		"criticalBackendSets": nonNilStrings(resp.CriticalStateBackendSetNames),
+		"unknownBackendSets":  nonNilStrings(resp.UnknownStateBackendSetNames),
+	}
+	if resp.TotalBackendSetCount != nil {
+		health["totalBackendSetCount"] = *resp.TotalBackendSetCount
+	}
+	return health
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,251 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package loadbalancer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/loadbalancer"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+type HostnameProvisioner struct {
+	clients *client.Clients
+	svc     *loadbalancer.LoadBalancerClient // nil until first use; injected in tests
+}
+
+var _ provisioner.Provisioner = &HostnameProvisioner{}
+
+func init() {
+	provisioner.Register("OCI::LoadBalancer::Hostname", NewHostnameProvisioner)
+	provisioner.DependsOn("OCI::LoadBalancer::Hostname", "OCI::LoadBalancer::LoadBalancer")
+}
+
+func NewHostnameProvisioner(clients *client.Clients) provisioner.Provisioner {
+	return &HostnameProvisioner{clients: clients}
+}
+
+// NewHostnameProvisionerWithSvc constructs a provisioner with a pre-built SDK client,
+// for use in tests that point the client at an httptest server.
+func NewHostnameProvisionerWithSvc(svc *loadbalancer.LoadBalancerClient) *HostnameProvisioner {
+	return &HostnameProvisioner{svc: svc}
+}
+
+func (p *HostnameProvisioner) getSvc() (*loadbalancer.LoadBalancerClient, error) {
+	if p.svc != nil {
+		return p.svc, nil
+	}
+	return p.clients.GetLoadBalancerClient()
+}
+
+// HasNoTags: hostnames are part of the load balancer, which holds the tags.
+func (p *HostnameProvisioner) HasNoTags() {}
+
+func (p *HostnameProvisioner) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	var props map[string]any
+	if err := json.Unmarshal(request.Properties, &props); err != nil {
+		return nil, fmt.Errorf("failed to parse properties: %w", err)
+	}
+
+	loadBalancerId := props["LoadBalancerId"].(string)
+	name := props["Name"].(string)
+
+	resp, err := client.CreateHostname(ctx, loadbalancer.CreateHostnameRequest{
+		LoadBalancerId: common.String(loadBalancerId),
+		CreateHostnameDetails: loadbalancer.CreateHostnameDetails{
+			Name:     common.String(name),
+			Hostname: common.String(props["Hostname"].(string)),
+		},
+		OpcRetryToken: common.String(util.CreateRetryToken(request)),
+	})
+	if err != nil {
+		if result, handleErr := util.HandleCreateError(err, "OCI::LoadBalancer::Hostname", "OCI::LoadBalancer::Hostname"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to create Hostname: %w", err)
+	}
+
+	return &resource.CreateResult{
+		ProgressResult: CreateInProgressResult(resource.OperationCreate, *resp.OpcWorkRequestId, util.EncodeCompositeID(loadBalancerId, name)),
+	}, nil
+}
+
+func (p *HostnameProvisioner) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	loadBalancerId, name, err := parseSubResourceID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	props, err := util.ApplyPatchDocument(ctx, request, p.Read)
+	if err != nil {
+		return nil, err
+	}
+
+	updateDetails := loadbalancer.UpdateHostnameDetails{}
+	if hostname, ok := util.ExtractString(props, "Hostname"); ok {
+		updateDetails.Hostname = common.String(hostname)
+	}
+
+	resp, err := client.UpdateHostname(ctx, loadbalancer.UpdateHostnameRequest{
+		LoadBalancerId:        common.String(loadBalancerId),
+		Name:                  common.String(name),
+		UpdateHostnameDetails: updateDetails,
+	})
+	if err != nil {
+		if result, handleErr := util.HandleUpdateError(err, "OCI::LoadBalancer::Hostname", request.NativeID, "OCI::LoadBalancer::Hostname"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to update Hostname: %w", err)
+	}
+
+	return &resource.UpdateResult{
+		ProgressResult: CreateInProgressResult(resource.OperationUpdate, *resp.OpcWorkRequestId, request.NativeID),
+	}, nil
+}
+
+func (p *HostnameProvisioner) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	loadBalancerId, name, err := parseSubResourceID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	readRes, err := p.Read(ctx, &resource.ReadRequest{NativeID: request.NativeID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Hostname before delete: %w", err)
+	}
+	if readRes.ErrorCode == resource.OperationErrorCodeNotFound {
+		return &resource.DeleteResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationDelete,
+				OperationStatus: resource.OperationStatusSuccess,
+				NativeID:        request.NativeID,
+			},
+		}, nil
+	}
+
+	resp, err := client.DeleteHostname(ctx, loadbalancer.DeleteHostnameRequest{
+		LoadBalancerId: common.String(loadBalancerId),
+		Name:           common.String(name),
+	})
+	if err != nil {
+		if result, handleErr := util.HandleDeleteError(err, "OCI::LoadBalancer::Hostname", request.NativeID, "OCI::LoadBalancer::Hostname"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to delete Hostname: %w", err)
+	}
+
+	return &resource.DeleteResult{
+		ProgressResult: CreateInProgressResult(resource.OperationDelete, *resp.OpcWorkRequestId, request.NativeID),
+	}, nil
+}
+
+func (p *HostnameProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	result, err := CheckWorkRequestStatus(ctx, client, request.RequestID, request.NativeID, resource.OperationCheckStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resource.StatusResult{
+		ProgressResult: result,
+	}, nil
+}
+
+func (p *HostnameProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	loadBalancerId, name, err := parseSubResourceID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetHostname(ctx, loadbalancer.GetHostnameRequest{
+		LoadBalancerId: common.String(loadBalancerId),
+		Name:           common.String(name),
+	})
+	if err != nil {
+		if util.IsNotFound(err) {
+			return &resource.ReadResult{
+				ResourceType: "OCI::LoadBalancer::Hostname",
+				ErrorCode:    resource.OperationErrorCodeNotFound,
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to read Hostname: %w", err)
+	}
+
+	props := map[string]any{
+		"LoadBalancerId": loadBalancerId,
+		"Name":           *resp.Name,
+		"Hostname":       *resp.Hostname.Hostname,
+	}
+
+	propBytes, err := json.Marshal(props)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Hostname properties: %w", err)
+	}
+
+	return &resource.ReadResult{
+		ResourceType: "OCI::LoadBalancer::Hostname",
+		Properties:   string(propBytes),
+	}, nil
+}
+
+func (p *HostnameProvisioner) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	loadBalancerId, ok := request.AdditionalProperties["LoadBalancerId"]
+	if !ok {
+		return nil, fmt.Errorf("LoadBalancerId is required for listing Hostnames")
+	}
+
+	lb, err := getActiveLoadBalancer(ctx, client, loadBalancerId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Hostnames: %w", err)
+	}
+
+	nativeIDs := []string{}
+	if lb != nil {
+		for name := range lb.Hostnames {
+			nativeIDs = append(nativeIDs, util.EncodeCompositeID(loadBalancerId, name))
+		}
+		sort.Strings(nativeIDs)
+	}
+
+	return &resource.ListResult{
+		NativeIDs: nativeIDs,
+	}, nil
+}
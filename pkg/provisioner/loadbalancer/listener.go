@@ -0,0 +1,346 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package loadbalancer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/loadbalancer"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+type ListenerProvisioner struct {
+	clients *client.Clients
+	svc     *loadbalancer.LoadBalancerClient // nil until first use; injected in tests
+}
+
+var _ provisioner.Provisioner = &ListenerProvisioner{}
+
+func init() {
+	provisioner.Register("OCI::LoadBalancer::Listener", NewListenerProvisioner)
+	provisioner.DependsOn("OCI::LoadBalancer::Listener",
+		"OCI::LoadBalancer::LoadBalancer",
+		"OCI::LoadBalancer::BackendSet",
+		"OCI::LoadBalancer::Certificate",
+		"OCI::LoadBalancer::Hostname",
+		"OCI::LoadBalancer::RoutingPolicy",
+		"OCI::LoadBalancer::RuleSet",
+	)
+}
+
+func NewListenerProvisioner(clients *client.Clients) provisioner.Provisioner {
+	return &ListenerProvisioner{clients: clients}
+}
+
+// NewListenerProvisionerWithSvc constructs a provisioner with a pre-built SDK client,
+// for use in tests that point the client at an httptest server.
+func NewListenerProvisionerWithSvc(svc *loadbalancer.LoadBalancerClient) *ListenerProvisioner {
+	return &ListenerProvisioner{svc: svc}
+}
+
+func (p *ListenerProvisioner) getSvc() (*loadbalancer.LoadBalancerClient, error) {
+	if p.svc != nil {
+		return p.svc, nil
+	}
+	return p.clients.GetLoadBalancerClient()
+}
+
+func parseConnectionConfiguration(props map[string]any) (*loadbalancer.ConnectionConfiguration, bool) {
+	m, ok := props["ConnectionConfiguration"].(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	config := &loadbalancer.ConnectionConfiguration{}
+	if idleTimeout, ok := extractInt(m, "idleTimeout"); ok {
+		config.IdleTimeout = common.Int64(int64(idleTimeout))
+	}
+	if version, ok := extractInt(m, "backendTcpProxyProtocolVersion"); ok {
+		config.BackendTcpProxyProtocolVersion = common.Int(version)
+	}
+	return config, true
+}
+
+// HasNoTags: listeners are part of the load balancer, which holds the tags.
+func (p *ListenerProvisioner) HasNoTags() {}
+
+func (p *ListenerProvisioner) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	var props map[string]any
+	if err := json.Unmarshal(request.Properties, &props); err != nil {
+		return nil, fmt.Errorf("failed to parse properties: %w", err)
+	}
+
+	loadBalancerId := props["LoadBalancerId"].(string)
+	name := props["Name"].(string)
+
+	createDetails := loadbalancer.CreateListenerDetails{
+		Name:                  common.String(name),
+		DefaultBackendSetName: common.String(props["DefaultBackendSetName"].(string)),
+		Port:                  common.Int(int(props["Port"].(float64))),
+		Protocol:              common.String(props["Protocol"].(string)),
+	}
+
+	if hostnameNames, ok := util.ExtractStringSlice(props, "HostnameNames"); ok {
+		createDetails.HostnameNames = hostnameNames
+	}
+	if routingPolicyName, ok := util.ExtractString(props, "RoutingPolicyName"); ok {
+		createDetails.RoutingPolicyName = common.String(routingPolicyName)
+	}
+	if ruleSetNames, ok := util.ExtractStringSlice(props, "RuleSetNames"); ok {
+		createDetails.RuleSetNames = ruleSetNames
+	}
+	if sslConfig, ok := parseSslConfiguration(props); ok {
+		createDetails.SslConfiguration = sslConfig
+	}
+	if connectionConfig, ok := parseConnectionConfiguration(props); ok {
+		createDetails.ConnectionConfiguration = connectionConfig
+	}
+
+	resp, err := client.CreateListener(ctx, loadbalancer.CreateListenerRequest{
+		LoadBalancerId:        common.String(loadBalancerId),
+		CreateListenerDetails: createDetails,
+		OpcRetryToken:         common.String(util.CreateRetryToken(request)),
+	})
+	if err != nil {
+		if result, handleErr := util.HandleCreateError(err, "OCI::LoadBalancer::Listener", "OCI::LoadBalancer::Listener"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to create Listener: %w", err)
+	}
+
+	// Listener changes are applied by an LB work request
+	return &resource.CreateResult{
+		ProgressResult: CreateInProgressResult(resource.OperationCreate, *resp.OpcWorkRequestId, util.EncodeCompositeID(loadBalancerId, name)),
+	}, nil
+}
+
+func (p *ListenerProvisioner) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	loadBalancerId, name, err := parseSubResourceID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	props, err := util.ApplyPatchDocument(ctx, request, p.Read)
+	if err != nil {
+		return nil, err
+	}
+
+	// UpdateListener replaces the whole listener, so everything not set here
+	// (SSL, hostnames, rule sets) is removed
+	updateDetails := loadbalancer.UpdateListenerDetails{
+		DefaultBackendSetName: common.String(props["DefaultBackendSetName"].(string)),
+		Port:                  common.Int(int(props["Port"].(float64))),
+		Protocol:              common.String(props["Protocol"].(string)),
+	}
+
+	if hostnameNames, ok := util.ExtractStringSlice(props, "HostnameNames"); ok {
+		updateDetails.HostnameNames = hostnameNames
+	}
+	if routingPolicyName, ok := util.ExtractString(props, "RoutingPolicyName"); ok {
+		updateDetails.RoutingPolicyName = common.String(routingPolicyName)
+	}
+	if ruleSetNames, ok := util.ExtractStringSlice(props, "RuleSetNames"); ok {
+		updateDetails.RuleSetNames = ruleSetNames
+	}
+	if sslConfig, ok := parseSslConfiguration(props); ok {
+		updateDetails.SslConfiguration = sslConfig
+	}
+	if connectionConfig, ok := parseConnectionConfiguration(props); ok {
+		updateDetails.ConnectionConfiguration = connectionConfig
+	}
+
+	resp, err := client.UpdateListener(ctx, loadbalancer.UpdateListenerRequest{
+		LoadBalancerId:        common.String(loadBalancerId),
+		ListenerName:          common.String(name),
+		UpdateListenerDetails: updateDetails,
+	})
+	if err != nil {
+		if result, handleErr := util.HandleUpdateError(err, "OCI::LoadBalancer::Listener", request.NativeID, "OCI::LoadBalancer::Listener"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to update Listener: %w", err)
+	}
+
+	return &resource.UpdateResult{
+		ProgressResult: CreateInProgressResult(resource.OperationUpdate, *resp.OpcWorkRequestId, request.NativeID),
+	}, nil
+}
+
+func (p *ListenerProvisioner) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	loadBalancerId, name, err := parseSubResourceID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	readRes, err := p.Read(ctx, &resource.ReadRequest{NativeID: request.NativeID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Listener before delete: %w", err)
+	}
+	if readRes.ErrorCode == resource.OperationErrorCodeNotFound {
+		return &resource.DeleteResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationDelete,
+				OperationStatus: resource.OperationStatusSuccess,
+				NativeID:        request.NativeID,
+			},
+		}, nil
+	}
+
+	resp, err := client.DeleteListener(ctx, loadbalancer.DeleteListenerRequest{
+		LoadBalancerId: common.String(loadBalancerId),
+		ListenerName:   common.String(name),
+	})
+	if err != nil {
+		if result, handleErr := util.HandleDeleteError(err, "OCI::LoadBalancer::Listener", request.NativeID, "OCI::LoadBalancer::Listener"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to delete Listener: %w", err)
+	}
+
+	return &resource.DeleteResult{
+		ProgressResult: CreateInProgressResult(resource.OperationDelete, *resp.OpcWorkRequestId, request.NativeID),
+	}, nil
+}
+
+func (p *ListenerProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	result, err := CheckWorkRequestStatus(ctx, client, request.RequestID, request.NativeID, resource.OperationCheckStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resource.StatusResult{
+		ProgressResult: result,
+	}, nil
+}
+
+func (p *ListenerProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	loadBalancerId, name, err := parseSubResourceID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	// There is no GetListener; listeners are only returned embedded in their
+	// load balancer
+	lb, err := getActiveLoadBalancer(ctx, client, loadBalancerId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Listener: %w", err)
+	}
+	var listener loadbalancer.Listener
+	found := false
+	if lb != nil {
+		listener, found = lb.Listeners[name]
+	}
+	if !found {
+		return &resource.ReadResult{
+			ResourceType: "OCI::LoadBalancer::Listener",
+			ErrorCode:    resource.OperationErrorCodeNotFound,
+		}, nil
+	}
+
+	propBytes, err := json.Marshal(buildListenerProperties(loadBalancerId, listener))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Listener properties: %w", err)
+	}
+
+	return &resource.ReadResult{
+		ResourceType: "OCI::LoadBalancer::Listener",
+		Properties:   string(propBytes),
+	}, nil
+}
+
+func buildListenerProperties(loadBalancerId string, listener loadbalancer.Listener) map[string]any {
+	props := map[string]any{
+		"LoadBalancerId":        loadBalancerId,
+		"Name":                  *listener.Name,
+		"DefaultBackendSetName": *listener.DefaultBackendSetName,
+		"Port":                  *listener.Port,
+		"Protocol":              *listener.Protocol,
+	}
+
+	if len(listener.HostnameNames) > 0 {
+		props["HostnameNames"] = listener.HostnameNames
+	}
+	if listener.RoutingPolicyName != nil {
+		props["RoutingPolicyName"] = *listener.RoutingPolicyName
+	}
+	if len(listener.RuleSetNames) > 0 {
+		props["RuleSetNames"] = listener.RuleSetNames
+	}
+	if listener.SslConfiguration != nil {
+		props["SslConfiguration"] = buildSslConfiguration(listener.SslConfiguration)
+	}
+	if cc := listener.ConnectionConfiguration; cc != nil {
+		connectionConfig := map[string]any{}
+		if cc.IdleTimeout != nil {
+			connectionConfig["idleTimeout"] = *cc.IdleTimeout
+		}
+		if cc.BackendTcpProxyProtocolVersion != nil {
+			connectionConfig["backendTcpProxyProtocolVersion"] = *cc.BackendTcpProxyProtocolVersion
+		}
+		props["ConnectionConfiguration"] = connectionConfig
+	}
+
+	return props
+}
+
+func (p *ListenerProvisioner) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	loadBalancerId, ok := request.AdditionalProperties["LoadBalancerId"]
+	if !ok {
+		return nil, fmt.Errorf("LoadBalancerId is required for listing Listeners")
+	}
+
+	lb, err := getActiveLoadBalancer(ctx, client, loadBalancerId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Listeners: %w", err)
+	}
+
+	nativeIDs := []string{}
+	if lb != nil {
+		for name := range lb.Listeners {
+			nativeIDs = append(nativeIDs, util.EncodeCompositeID(loadBalancerId, name))
+		}
+		sort.Strings(nativeIDs)
+	}
+
+	return &resource.ListResult{
+		NativeIDs: nativeIDs,
+	}, nil
+}
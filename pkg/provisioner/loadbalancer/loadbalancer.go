@@ -0,0 +1,555 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package loadbalancer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/loadbalancer"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+type LoadBalancerProvisioner struct {
+	clients *client.Clients
+	svc     *loadbalancer.LoadBalancerClient // nil until first use; injected in tests
+}
+
+var (
+	_ provisioner.Provisioner = &LoadBalancerProvisioner{}
+	_ provisioner.Immutable   = &LoadBalancerProvisioner{}
+)
+
+func init() {
+	provisioner.Register("OCI::LoadBalancer::LoadBalancer", NewLoadBalancerProvisioner)
+	provisioner.DependsOn("OCI::LoadBalancer::LoadBalancer",
+		"OCI::Identity::Compartment",
+		"OCI::Core::Subnet",
+		"OCI::Core::NetworkSecurityGroup",
+	)
+}
+
+func NewLoadBalancerProvisioner(clients *client.Clients) provisioner.Provisioner {
+	return &LoadBalancerProvisioner{clients: clients}
+}
+
+// NewLoadBalancerProvisionerWithSvc constructs a provisioner with a pre-built SDK client,
+// for use in tests that point the client at an httptest server.
+func NewLoadBalancerProvisionerWithSvc(svc *loadbalancer.LoadBalancerClient) *LoadBalancerProvisioner {
+	return &LoadBalancerProvisioner{svc: svc}
+}
+
+// ImmutableFields lists the placement of the load balancer: OCI has no API to
+// move it to other subnets or to switch it between public and private.
+func (p *LoadBalancerProvisioner) ImmutableFields() []string {
+	return []string{"SubnetIds", "IsPrivate"}
+}
+
+func (p *LoadBalancerProvisioner) getSvc() (*loadbalancer.LoadBalancerClient, error) {
+	if p.svc != nil {
+		return p.svc, nil
+	}
+	return p.clients.GetLoadBalancerClient()
+}
+
+// parseShapeDetails reads the bandwidth limits of a flexible shape.
+func parseShapeDetails(props map[string]any) (*loadbalancer.ShapeDetails, bool) {
+	m, ok := props["ShapeDetails"].(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	details := &loadbalancer.ShapeDetails{}
+	if minimum, ok := extractInt(m, "minimumBandwidthInMbps"); ok {
+		details.MinimumBandwidthInMbps = common.Int(minimum)
+	}
+	if maximum, ok := extractInt(m, "maximumBandwidthInMbps"); ok {
+		details.MaximumBandwidthInMbps = common.Int(maximum)
+	}
+	return details, true
+}
+
+func (p *LoadBalancerProvisioner) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	var props map[string]any
+	if err := json.Unmarshal(request.Properties, &props); err != nil {
+		return nil, fmt.Errorf("failed to parse properties: %w", err)
+	}
+
+	subnetIds, ok := util.ExtractStringSlice(props, "SubnetIds")
+	if !ok {
+		return nil, fmt.Errorf("SubnetIds is required")
+	}
+
+	createDetails := loadbalancer.CreateLoadBalancerDetails{
+		CompartmentId: common.String(props["CompartmentId"].(string)),
+		DisplayName:   common.String(props["DisplayName"].(string)),
+		ShapeName:     common.String(props["ShapeName"].(string)),
+		SubnetIds:     subnetIds,
+	}
+
+	if shapeDetails, ok := parseShapeDetails(props); ok {
+		createDetails.ShapeDetails = shapeDetails
+	}
+	if isPrivate, ok := util.ExtractBool(props, "IsPrivate"); ok {
+		createDetails.IsPrivate = common.Bool(isPrivate)
+	}
+	if nsgIds, ok := util.ExtractNsgIds(props); ok {
+		createDetails.NetworkSecurityGroupIds = nsgIds
+	}
+	if deleteProtection, ok := util.ExtractBool(props, "IsDeleteProtectionEnabled"); ok {
+		createDetails.IsDeleteProtectionEnabled = common.Bool(deleteProtection)
+	}
+	if freeformTags, ok := util.ExtractFreeformTags(props, "FreeformTags"); ok {
+		createDetails.FreeformTags = freeformTags
+	}
+	if definedTags, ok := util.ExtractDefinedTags(props, "DefinedTags"); ok {
+		createDetails.DefinedTags = definedTags
+	}
+
+	resp, err := client.CreateLoadBalancer(ctx, loadbalancer.CreateLoadBalancerRequest{
+		CreateLoadBalancerDetails: createDetails,
+		OpcRetryToken:             common.String(util.CreateRetryToken(request)),
+	})
+	if err != nil {
+		if result, handleErr := util.HandleCreateError(err, "OCI::LoadBalancer::LoadBalancer", "OCI::LoadBalancer::LoadBalancer"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to create LoadBalancer: %w", err)
+	}
+
+	// The OCID is only known once the work request names it; Status picks it up
+	return &resource.CreateResult{
+		ProgressResult: CreateInProgressResult(resource.OperationCreate, *resp.OpcWorkRequestId, ""),
+	}, nil
+}
+
+// loadBalancerChange is one step of a load balancer update. Each step is its
+// own work request, and OCI rejects a new work request while another one is
+// running on the same load balancer, so Update submits the first step and
+// Status submits each following one once the previous has succeeded.
+type loadBalancerChange struct {
+	CompartmentId string                                       `json:"compartmentId,omitempty"`
+	Shape         *loadbalancer.UpdateLoadBalancerShapeDetails `json:"shape,omitempty"`
+	NsgIds        []string                                     `json:"nsgIds,omitempty"`
+	UpdateNsgs    bool                                         `json:"updateNsgs,omitempty"`
+	Details       *loadbalancer.UpdateLoadBalancerDetails      `json:"details,omitempty"`
+}
+
+// encodeUpdateRequestID carries the changes still to apply in the RequestID,
+// next to the work request of the one in flight: {workRequestId}/{json}.
+func encodeUpdateRequestID(workRequestId string, pending []loadBalancerChange) (string, error) {
+	if len(pending) == 0 {
+		return workRequestId, nil
+	}
+	encoded, err := json.Marshal(pending)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode pending LoadBalancer changes: %w", err)
+	}
+	return util.EncodeCompositeID(workRequestId, string(encoded)), nil
+}
+
+// decodeUpdateRequestID splits a RequestID built by encodeUpdateRequestID. A
+// plain work request OCID has nothing pending.
+func decodeUpdateRequestID(requestID string) (string, []loadBalancerChange, error) {
+	if !strings.Contains(requestID, "/") {
+		return requestID, nil, nil
+	}
+	parts, err := util.DecodeCompositeID(requestID, 2)
+	if err != nil {
+		return "", nil, err
+	}
+	var pending []loadBalancerChange
+	if err := json.Unmarshal([]byte(parts[1]), &pending); err != nil {
+		return "", nil, fmt.Errorf("failed to decode pending LoadBalancer changes: %w", err)
+	}
+	return parts[0], pending, nil
+}
+
+// applyChange submits one update step and returns its work request.
+func applyChange(ctx context.Context, client *loadbalancer.LoadBalancerClient, loadBalancerId string, change loadBalancerChange) (string, error) {
+	switch {
+	case change.CompartmentId != "":
+		resp, err := client.ChangeLoadBalancerCompartment(ctx, loadbalancer.ChangeLoadBalancerCompartmentRequest{
+			LoadBalancerId: common.String(loadBalancerId),
+			ChangeLoadBalancerCompartmentDetails: loadbalancer.ChangeLoadBalancerCompartmentDetails{
+				CompartmentId: common.String(change.CompartmentId),
+			},
+		})
+		if err != nil {
+			return "", err
+		}
+		return *resp.OpcWorkRequestId, nil
+	case change.Shape != nil:
+		resp, err := client.UpdateLoadBalancerShape(ctx, loadbalancer.UpdateLoadBalancerShapeRequest{
+			LoadBalancerId:                 common.String(loadBalancerId),
+			UpdateLoadBalancerShapeDetails: *change.Shape,
+		})
+		if err != nil {
+			return "", err
+		}
+		return *resp.OpcWorkRequestId, nil
+	case change.UpdateNsgs:
+		nsgIds := change.NsgIds
+		if nsgIds == nil {
+			nsgIds = []string{}
+		}
+		resp, err := client.UpdateNetworkSecurityGroups(ctx, loadbalancer.UpdateNetworkSecurityGroupsRequest{
+			LoadBalancerId: common.String(loadBalancerId),
+			UpdateNetworkSecurityGroupsDetails: loadbalancer.UpdateNetworkSecurityGroupsDetails{
+				NetworkSecurityGroupIds: nsgIds,
+			},
+		})
+		if err != nil {
+			return "", err
+		}
+		return *resp.OpcWorkRequestId, nil
+	case change.Details != nil:
+		resp, err := client.UpdateLoadBalancer(ctx, loadbalancer.UpdateLoadBalancerRequest{
+			LoadBalancerId:            common.String(loadBalancerId),
+			UpdateLoadBalancerDetails: *change.Details,
+		})
+		if err != nil {
+			return "", err
+		}
+		return *resp.OpcWorkRequestId, nil
+	}
+	return "", fmt.Errorf("empty LoadBalancer change")
+}
+
+// plannedChanges lists the update steps that take lb to props, in the order
+// they are applied. The UpdateLoadBalancer step always comes last so tags and
+// the display name are reapplied even when nothing else changed.
+func plannedChanges(lb loadbalancer.LoadBalancer, props map[string]any) []loadBalancerChange {
+	var changes []loadBalancerChange
+
+	if compartmentId, ok := util.ExtractString(props, "CompartmentId"); ok && lb.CompartmentId != nil && compartmentId != *lb.CompartmentId {
+		changes = append(changes, loadBalancerChange{CompartmentId: compartmentId})
+	}
+
+	if shapeName, ok := util.ExtractString(props, "ShapeName"); ok {
+		shape := &loadbalancer.UpdateLoadBalancerShapeDetails{ShapeName: common.String(shapeName)}
+		shape.ShapeDetails, _ = parseShapeDetails(props)
+		if shapeChanged(lb, shape) {
+			changes = append(changes, loadBalancerChange{Shape: shape})
+		}
+	}
+
+	nsgIds, _ := util.ExtractNsgIds(props)
+	if !sameStrings(nsgIds, lb.NetworkSecurityGroupIds) {
+		changes = append(changes, loadBalancerChange{NsgIds: nsgIds, UpdateNsgs: true})
+	}
+
+	details := &loadbalancer.UpdateLoadBalancerDetails{}
+	if displayName, ok := util.ExtractString(props, "DisplayName"); ok {
+		details.DisplayName = common.String(displayName)
+	}
+	if deleteProtection, ok := util.ExtractBool(props, "IsDeleteProtectionEnabled"); ok {
+		details.IsDeleteProtectionEnabled = common.Bool(deleteProtection)
+	}
+	if freeformTags, ok := util.ExtractFreeformTags(props, "FreeformTags"); ok {
+		details.FreeformTags = freeformTags
+	}
+	if definedTags, ok := util.ExtractDefinedTags(props, "DefinedTags"); ok {
+		details.DefinedTags = definedTags
+	}
+	changes = append(changes, loadBalancerChange{Details: details})
+
+	return changes
+}
+
+// shapeChanged reports whether applying shape would change lb. Unset bandwidth
+// limits keep the current ones.
+func shapeChanged(lb loadbalancer.LoadBalancer, shape *loadbalancer.UpdateLoadBalancerShapeDetails) bool {
+	if lb.ShapeName == nil || *lb.ShapeName != *shape.ShapeName {
+		return true
+	}
+	if shape.ShapeDetails == nil {
+		return false
+	}
+	if lb.ShapeDetails == nil {
+		return true
+	}
+	return !intPtrEqual(shape.ShapeDetails.MinimumBandwidthInMbps, lb.ShapeDetails.MinimumBandwidthInMbps) ||
+		!intPtrEqual(shape.ShapeDetails.MaximumBandwidthInMbps, lb.ShapeDetails.MaximumBandwidthInMbps)
+}
+
+func intPtrEqual(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// sameStrings compares two ID lists ignoring order.
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
+
+func (p *LoadBalancerProvisioner) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	props, err := util.ApplyPatchDocument(ctx, request, p.Read)
+	if err != nil {
+		return nil, err
+	}
+
+	lb, err := getActiveLoadBalancer(ctx, client, request.NativeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read LoadBalancer before update: %w", err)
+	}
+	if lb == nil {
+		return &resource.UpdateResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationUpdate,
+				OperationStatus: resource.OperationStatusFailure,
+				ErrorCode:       resource.OperationErrorCodeNotFound,
+				StatusMessage:   fmt.Sprintf("LoadBalancer %s not found", request.NativeID),
+				NativeID:        request.NativeID,
+			},
+		}, nil
+	}
+
+	changes := plannedChanges(*lb, props)
+	workRequestId, err := applyChange(ctx, client, request.NativeID, changes[0])
+	if err != nil {
+		if result, handleErr := util.HandleUpdateError(err, "OCI::LoadBalancer::LoadBalancer", request.NativeID, "OCI::LoadBalancer::LoadBalancer"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to update LoadBalancer: %w", err)
+	}
+
+	requestID, err := encodeUpdateRequestID(workRequestId, changes[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	return &resource.UpdateResult{
+		ProgressResult: CreateInProgressResult(resource.OperationUpdate, requestID, request.NativeID),
+	}, nil
+}
+
+func (p *LoadBalancerProvisioner) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	lb, err := getActiveLoadBalancer(ctx, client, request.NativeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read LoadBalancer before delete: %w", err)
+	}
+	if lb == nil {
+		return &resource.DeleteResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationDelete,
+				OperationStatus: resource.OperationStatusSuccess,
+				NativeID:        request.NativeID,
+			},
+		}, nil
+	}
+
+	resp, err := client.DeleteLoadBalancer(ctx, loadbalancer.DeleteLoadBalancerRequest{
+		LoadBalancerId: common.String(request.NativeID),
+	})
+	if err != nil {
+		if result, handleErr := util.HandleDeleteError(err, "OCI::LoadBalancer::LoadBalancer", request.NativeID, "OCI::LoadBalancer::LoadBalancer"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to delete LoadBalancer: %w", err)
+	}
+
+	return &resource.DeleteResult{
+		ProgressResult: CreateInProgressResult(resource.OperationDelete, *resp.OpcWorkRequestId, request.NativeID),
+	}, nil
+}
+
+func (p *LoadBalancerProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	workRequestId, pending, err := decodeUpdateRequestID(request.RequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := CheckWorkRequestStatus(ctx, client, workRequestId, request.NativeID, resource.OperationCheckStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	// The previous update step is done; submit the next one
+	if result.OperationStatus == resource.OperationStatusSuccess && len(pending) > 0 {
+		nextWorkRequestId, err := applyChange(ctx, client, result.NativeID, pending[0])
+		if err != nil {
+			return &resource.StatusResult{
+				ProgressResult: &resource.ProgressResult{
+					Operation:       resource.OperationCheckStatus,
+					OperationStatus: resource.OperationStatusFailure,
+					StatusMessage:   fmt.Sprintf("failed to update LoadBalancer: %v", err),
+					NativeID:        result.NativeID,
+				},
+			}, nil
+		}
+		requestID, err := encodeUpdateRequestID(nextWorkRequestId, pending[1:])
+		if err != nil {
+			return nil, err
+		}
+		result = CreateInProgressResult(resource.OperationCheckStatus, requestID, result.NativeID)
+	}
+
+	return &resource.StatusResult{
+		ProgressResult: result,
+	}, nil
+}
+
+func (p *LoadBalancerProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	lb, err := getActiveLoadBalancer(ctx, client, request.NativeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read LoadBalancer: %w", err)
+	}
+	if lb == nil {
+		return &resource.ReadResult{
+			ResourceType: "OCI::LoadBalancer::LoadBalancer",
+			ErrorCode:    resource.OperationErrorCodeNotFound,
+		}, nil
+	}
+
+	props := buildLoadBalancerProperties(*lb, config.FromTargetConfig(request.TargetConfig).HiddenTagNamespaces())
+	if health := readLoadBalancerHealth(ctx, client, *lb); health != nil {
+		props["HealthStatus"] = health
+	}
+
+	propBytes, err := json.Marshal(props)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal LoadBalancer properties: %w", err)
+	}
+
+	return &resource.ReadResult{
+		ResourceType: "OCI::LoadBalancer::LoadBalancer",
+		Properties:   string(propBytes),
+	}, nil
+}
+
+func buildLoadBalancerProperties(lb loadbalancer.LoadBalancer, ignoredTagNamespaces []string) map[string]any {
+	props := map[string]any{
+		"Id":            *lb.Id,
+		"CompartmentId": *lb.CompartmentId,
+		"DisplayName":   *lb.DisplayName,
+		"ShapeName":     *lb.ShapeName,
+	}
+
+	if lb.ShapeDetails != nil {
+		shapeDetails := map[string]any{}
+		if lb.ShapeDetails.MinimumBandwidthInMbps != nil {
+			shapeDetails["minimumBandwidthInMbps"] = *lb.ShapeDetails.MinimumBandwidthInMbps
+		}
+		if lb.ShapeDetails.MaximumBandwidthInMbps != nil {
+			shapeDetails["maximumBandwidthInMbps"] = *lb.ShapeDetails.MaximumBandwidthInMbps
+		}
+		props["ShapeDetails"] = shapeDetails
+	}
+	if len(lb.SubnetIds) > 0 {
+		props["SubnetIds"] = lb.SubnetIds
+	}
+	if lb.IsPrivate != nil {
+		props["IsPrivate"] = *lb.IsPrivate
+	}
+	if len(lb.NetworkSecurityGroupIds) > 0 {
+		props["NetworkSecurityGroupIds"] = lb.NetworkSecurityGroupIds
+	}
+	if lb.IsDeleteProtectionEnabled != nil {
+		props["IsDeleteProtectionEnabled"] = *lb.IsDeleteProtectionEnabled
+	}
+	if len(lb.IpAddresses) > 0 {
+		ipAddresses := make([]map[string]any, 0, len(lb.IpAddresses))
+		for _, ip := range lb.IpAddresses {
+			if ip.IpAddress == nil {
+				continue
+			}
+			address := map[string]any{"ipAddress": *ip.IpAddress}
+			if ip.IsPublic != nil {
+				address["isPublic"] = *ip.IsPublic
+			}
+			ipAddresses = append(ipAddresses, address)
+		}
+		props["IpAddresses"] = ipAddresses
+	}
+	if lb.FreeformTags != nil {
+		props["FreeformTags"] = util.FreeformTagsToList(lb.FreeformTags)
+	}
+	if lb.DefinedTags != nil {
+		props["DefinedTags"] = util.DefinedTagsToList(lb.DefinedTags, ignoredTagNamespaces)
+	}
+
+	return props
+}
+
+func (p *LoadBalancerProvisioner) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	compartmentId, ok := util.ListCompartmentId(request)
+	if !ok {
+		return nil, fmt.Errorf("CompartmentId is required for listing LoadBalancers")
+	}
+
+	listReq := loadbalancer.ListLoadBalancersRequest{
+		CompartmentId: common.String(compartmentId),
+		Limit:         common.Int64(int64(*util.ListPageSize(request.TargetConfig))),
+	}
+
+	nativeIDs, err := util.ListAllPages(func(page *string) ([]string, *string, error) {
+		listReq.Page = page
+		resp, err := client.ListLoadBalancers(ctx, listReq)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list LoadBalancers: %w", err)
+		}
+		ids := make([]string, 0, len(resp.Items))
+		for _, lb := range resp.Items {
+			if util.IsTerminal(string(lb.LifecycleState)) {
+				continue
+			}
+			ids = append(ids, *lb.Id)
+		}
+		return ids, resp.OpcNextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &resource.ListResult{
+		NativeIDs: nativeIDs,
+	}, nil
+}
@@ -0,0 +1,336 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package loadbalancer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/loadbalancer"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+type RoutingPolicyProvisioner struct {
+	clients *client.Clients
+	svc     *loadbalancer.LoadBalancerClient // nil until first use; injected in tests
+}
+
+var _ provisioner.Provisioner = &RoutingPolicyProvisioner{}
+
+func init() {
+	provisioner.Register("OCI::LoadBalancer::RoutingPolicy", NewRoutingPolicyProvisioner)
+	provisioner.DependsOn("OCI::LoadBalancer::RoutingPolicy", "OCI::LoadBalancer::LoadBalancer", "OCI::LoadBalancer::BackendSet")
+}
+
+func NewRoutingPolicyProvisioner(clients *client.Clients) provisioner.Provisioner {
+	return &RoutingPolicyProvisioner{clients: clients}
+}
+
+// NewRoutingPolicyProvisionerWithSvc constructs a provisioner with a pre-built SDK client,
+// for use in tests that point the client at an httptest server.
+func NewRoutingPolicyProvisionerWithSvc(svc *loadbalancer.LoadBalancerClient) *RoutingPolicyProvisioner {
+	return &RoutingPolicyProvisioner{svc: svc}
+}
+
+func (p *RoutingPolicyProvisioner) getSvc() (*loadbalancer.LoadBalancerClient, error) {
+	if p.svc != nil {
+		return p.svc, nil
+	}
+	return p.clients.GetLoadBalancerClient()
+}
+
+// parseRoutingRules converts the ordered Rules property. OCI evaluates the
+// rules top to bottom and the first matching condition wins, so order is kept.
+func parseRoutingRules(props map[string]any) ([]loadbalancer.RoutingRule, error) {
+	items, _ := props["Rules"].([]any)
+	rules := make([]loadbalancer.RoutingRule, 0, len(items))
+	for _, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		rule := loadbalancer.RoutingRule{Actions: []loadbalancer.Action{}}
+		if name, ok := util.ExtractString(m, "name"); ok {
+			rule.Name = common.String(name)
+		}
+		if condition, ok := util.ExtractString(m, "condition"); ok {
+			rule.Condition = common.String(condition)
+		}
+		actions, _ := m["actions"].([]any)
+		for _, a := range actions {
+			am, ok := a.(map[string]any)
+			if !ok {
+				continue
+			}
+			name, _ := util.ExtractString(am, "name")
+			switch name {
+			case "FORWARD_TO_BACKENDSET", "":
+				action := loadbalancer.ForwardToBackendSet{}
+				if backendSetName, ok := util.ExtractString(am, "backendSetName"); ok {
+					action.BackendSetName = common.String(backendSetName)
+				}
+				rule.Actions = append(rule.Actions, action)
+			default:
+				return nil, fmt.Errorf("unsupported routing rule action %q", name)
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func buildRoutingRules(rules []loadbalancer.RoutingRule) []map[string]any {
+	result := make([]map[string]any, 0, len(rules))
+	for _, rule := range rules {
+		m := map[string]any{}
+		if rule.Name != nil {
+			m["name"] = *rule.Name
+		}
+		if rule.Condition != nil {
+			m["condition"] = *rule.Condition
+		}
+		actions := make([]map[string]any, 0, len(rule.Actions))
+		for _, action := range rule.Actions {
+			if forward, ok := action.(loadbalancer.ForwardToBackendSet); ok {
+				am := map[string]any{"name": "FORWARD_TO_BACKENDSET"}
+				if forward.BackendSetName != nil {
+					am["backendSetName"] = *forward.BackendSetName
+				}
+				actions = append(actions, am)
+			}
+		}
+		m["actions"] = actions
+		result = append(result, m)
+	}
+	return result
+}
+
+// HasNoTags: routing policies are part of the load balancer, which holds the tags.
+func (p *RoutingPolicyProvisioner) HasNoTags() {}
+
+func (p *RoutingPolicyProvisioner) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	var props map[string]any
+	if err := json.Unmarshal(request.Properties, &props); err != nil {
+		return nil, fmt.Errorf("failed to parse properties: %w", err)
+	}
+
+	loadBalancerId := props["LoadBalancerId"].(string)
+	name := props["Name"].(string)
+
+	rules, err := parseRoutingRules(props)
+	if err != nil {
+		return nil, err
+	}
+
+	createDetails := loadbalancer.CreateRoutingPolicyDetails{
+		Name:                     common.String(name),
+		ConditionLanguageVersion: loadbalancer.CreateRoutingPolicyDetailsConditionLanguageVersionV1,
+		Rules:                    rules,
+	}
+	if version, ok := util.ExtractString(props, "ConditionLanguageVersion"); ok {
+		createDetails.ConditionLanguageVersion = loadbalancer.CreateRoutingPolicyDetailsConditionLanguageVersionEnum(version)
+	}
+
+	resp, err := client.CreateRoutingPolicy(ctx, loadbalancer.CreateRoutingPolicyRequest{
+		LoadBalancerId:             common.String(loadBalancerId),
+		CreateRoutingPolicyDetails: createDetails,
+		OpcRetryToken:              common.String(util.CreateRetryToken(request)),
+	})
+	if err != nil {
+		if result, handleErr := util.HandleCreateError(err, "OCI::LoadBalancer::RoutingPolicy", "OCI::LoadBalancer::RoutingPolicy"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to create RoutingPolicy: %w", err)
+	}
+
+	return &resource.CreateResult{
+		ProgressResult: CreateInProgressResult(resource.OperationCreate, *resp.OpcWorkRequestId, util.EncodeCompositeID(loadBalancerId, name)),
+	}, nil
+}
+
+func (p *RoutingPolicyProvisioner) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	loadBalancerId, name, err := parseSubResourceID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	props, err := util.ApplyPatchDocument(ctx, request, p.Read)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := parseRoutingRules(props)
+	if err != nil {
+		return nil, err
+	}
+
+	updateDetails := loadbalancer.UpdateRoutingPolicyDetails{
+		Rules: rules,
+	}
+	if version, ok := util.ExtractString(props, "ConditionLanguageVersion"); ok {
+		updateDetails.ConditionLanguageVersion = loadbalancer.UpdateRoutingPolicyDetailsConditionLanguageVersionEnum(version)
+	}
+
+	resp, err := client.UpdateRoutingPolicy(ctx, loadbalancer.UpdateRoutingPolicyRequest{
+		LoadBalancerId:             common.String(loadBalancerId),
+		RoutingPolicyName:          common.String(name),
+		UpdateRoutingPolicyDetails: updateDetails,
+	})
+	if err != nil {
+		if result, handleErr := util.HandleUpdateError(err, "OCI::LoadBalancer::RoutingPolicy", request.NativeID, "OCI::LoadBalancer::RoutingPolicy"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to update RoutingPolicy: %w", err)
+	}
+
+	return &resource.UpdateResult{
+		ProgressResult: CreateInProgressResult(resource.OperationUpdate, *resp.OpcWorkRequestId, request.NativeID),
+	}, nil
+}
+
+func (p *RoutingPolicyProvisioner) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	loadBalancerId, name, err := parseSubResourceID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	readRes, err := p.Read(ctx, &resource.ReadRequest{NativeID: request.NativeID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read RoutingPolicy before delete: %w", err)
+	}
+	if readRes.ErrorCode == resource.OperationErrorCodeNotFound {
+		return &resource.DeleteResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationDelete,
+				OperationStatus: resource.OperationStatusSuccess,
+				NativeID:        request.NativeID,
+			},
+		}, nil
+	}
+
+	resp, err := client.DeleteRoutingPolicy(ctx, loadbalancer.DeleteRoutingPolicyRequest{
+		LoadBalancerId:    common.String(loadBalancerId),
+		RoutingPolicyName: common.String(name),
+	})
+	if err != nil {
+		if result, handleErr := util.HandleDeleteError(err, "OCI::LoadBalancer::RoutingPolicy", request.NativeID, "OCI::LoadBalancer::RoutingPolicy"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to delete RoutingPolicy: %w", err)
+	}
+
+	return &resource.DeleteResult{
+		ProgressResult: CreateInProgressResult(resource.OperationDelete, *resp.OpcWorkRequestId, request.NativeID),
+	}, nil
+}
+
+func (p *RoutingPolicyProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	result, err := CheckWorkRequestStatus(ctx, client, request.RequestID, request.NativeID, resource.OperationCheckStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resource.StatusResult{
+		ProgressResult: result,
+	}, nil
+}
+
+func (p *RoutingPolicyProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	loadBalancerId, name, err := parseSubResourceID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetRoutingPolicy(ctx, loadbalancer.GetRoutingPolicyRequest{
+		LoadBalancerId:    common.String(loadBalancerId),
+		RoutingPolicyName: common.String(name),
+	})
+	if err != nil {
+		if util.IsNotFound(err) {
+			return &resource.ReadResult{
+				ResourceType: "OCI::LoadBalancer::RoutingPolicy",
+				ErrorCode:    resource.OperationErrorCodeNotFound,
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to read RoutingPolicy: %w", err)
+	}
+
+	props := map[string]any{
+		"LoadBalancerId":           loadBalancerId,
+		"Name":                     *resp.Name,
+		"ConditionLanguageVersion": string(resp.ConditionLanguageVersion),
+		"Rules":                    buildRoutingRules(resp.Rules),
+	}
+
+	propBytes, err := json.Marshal(props)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal RoutingPolicy properties: %w", err)
+	}
+
+	return &resource.ReadResult{
+		ResourceType: "OCI::LoadBalancer::RoutingPolicy",
+		Properties:   string(propBytes),
+	}, nil
+}
+
+func (p *RoutingPolicyProvisioner) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	loadBalancerId, ok := request.AdditionalProperties["LoadBalancerId"]
+	if !ok {
+		return nil, fmt.Errorf("LoadBalancerId is required for listing RoutingPolicies")
+	}
+
+	lb, err := getActiveLoadBalancer(ctx, client, loadBalancerId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list RoutingPolicies: %w", err)
+	}
+
+	nativeIDs := []string{}
+	if lb != nil {
+		for name := range lb.RoutingPolicies {
+			nativeIDs = append(nativeIDs, util.EncodeCompositeID(loadBalancerId, name))
+		}
+		sort.Strings(nativeIDs)
+	}
+
+	return &resource.ListResult{
+		NativeIDs: nativeIDs,
+	}, nil
+}
@@ -0,0 +1,474 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package loadbalancer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/loadbalancer"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+type RuleSetProvisioner struct {
+	clients *client.Clients
+	svc     *loadbalancer.LoadBalancerClient // nil until first use; injected in tests
+}
+
+var _ provisioner.Provisioner = &RuleSetProvisioner{}
+
+func init() {
+	provisioner.Register("OCI::LoadBalancer::RuleSet", NewRuleSetProvisioner)
+	provisioner.DependsOn("OCI::LoadBalancer::RuleSet", "OCI::LoadBalancer::LoadBalancer")
+}
+
+func NewRuleSetProvisioner(clients *client.Clients) provisioner.Provisioner {
+	return &RuleSetProvisioner{clients: clients}
+}
+
+// NewRuleSetProvisionerWithSvc constructs a provisioner with a pre-built SDK client,
+// for use in tests that point the client at an httptest server.
+func NewRuleSetProvisionerWithSvc(svc *loadbalancer.LoadBalancerClient) *RuleSetProvisioner {
+	return &RuleSetProvisioner{svc: svc}
+}
+
+func (p *RuleSetProvisioner) getSvc() (*loadbalancer.LoadBalancerClient, error) {
+	if p.svc != nil {
+		return p.svc, nil
+	}
+	return p.clients.GetLoadBalancerClient()
+}
+
+// parseRuleSetItems converts the Items property. Every item carries an
+// "action" discriminator and only the fields that action uses, mirroring the
+// polymorphic Rule type of the API.
+func parseRuleSetItems(props map[string]any) ([]loadbalancer.Rule, error) {
+	items, _ := props["Items"].([]any)
+	rules := make([]loadbalancer.Rule, 0, len(items))
+	for _, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		rule, err := parseRule(m)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func parseRule(m map[string]any) (loadbalancer.Rule, error) {
+	action, _ := util.ExtractString(m, "action")
+	header := optionalString(m, "header")
+	switch loadbalancer.RuleActionEnum(action) {
+	case loadbalancer.RuleActionAddHttpRequestHeader:
+		return loadbalancer.AddHttpRequestHeaderRule{Header: header, Value: optionalString(m, "value")}, nil
+	case loadbalancer.RuleActionAddHttpResponseHeader:
+		return loadbalancer.AddHttpResponseHeaderRule{Header: header, Value: optionalString(m, "value")}, nil
+	case loadbalancer.RuleActionExtendHttpRequestHeaderValue:
+		return loadbalancer.ExtendHttpRequestHeaderValueRule{Header: header, Prefix: optionalString(m, "prefix"), Suffix: optionalString(m, "suffix")}, nil
+	case loadbalancer.RuleActionExtendHttpResponseHeaderValue:
+		return loadbalancer.ExtendHttpResponseHeaderValueRule{Header: header, Prefix: optionalString(m, "prefix"), Suffix: optionalString(m, "suffix")}, nil
+	case loadbalancer.RuleActionRemoveHttpRequestHeader:
+		return loadbalancer.RemoveHttpRequestHeaderRule{Header: header}, nil
+	case loadbalancer.RuleActionRemoveHttpResponseHeader:
+		return loadbalancer.RemoveHttpResponseHeaderRule{Header: header}, nil
+	case loadbalancer.RuleActionAllow:
+		return loadbalancer.AllowRule{Conditions: parseRuleConditions(m), Description: optionalString(m, "description")}, nil
+	case loadbalancer.RuleActionControlAccessUsingHttpMethods:
+		rule := loadbalancer.ControlAccessUsingHttpMethodsRule{}
+		if methods, ok := util.ExtractStringSlice(m, "allowedMethods"); ok {
+			rule.AllowedMethods = methods
+		}
+		if statusCode, ok := extractInt(m, "statusCode"); ok {
+			rule.StatusCode = common.Int(statusCode)
+		}
+		return rule, nil
+	case loadbalancer.RuleActionRedirect:
+		rule := loadbalancer.RedirectRule{Conditions: parseRuleConditions(m)}
+		if responseCode, ok := extractInt(m, "responseCode"); ok {
+			rule.ResponseCode = common.Int(responseCode)
+		}
+		if uri, ok := m["redirectUri"].(map[string]any); ok {
+			rule.RedirectUri = &loadbalancer.RedirectUri{
+				Protocol: optionalString(uri, "protocol"),
+				Host:     optionalString(uri, "host"),
+				Path:     optionalString(uri, "path"),
+				Query:    optionalString(uri, "query"),
+			}
+			if port, ok := extractInt(uri, "port"); ok {
+				rule.RedirectUri.Port = common.Int(port)
+			}
+		}
+		return rule, nil
+	case loadbalancer.RuleActionHttpHeader:
+		rule := loadbalancer.HttpHeaderRule{}
+		if allowed, ok := util.ExtractBool(m, "areInvalidCharactersAllowed"); ok {
+			rule.AreInvalidCharactersAllowed = common.Bool(allowed)
+		}
+		if size, ok := extractInt(m, "httpLargeHeaderSizeInKB"); ok {
+			rule.HttpLargeHeaderSizeInKB = common.Int(size)
+		}
+		return rule, nil
+	}
+	return nil, fmt.Errorf("unsupported rule set action %q", action)
+}
+
+func parseRuleConditions(m map[string]any) []loadbalancer.RuleCondition {
+	items, _ := m["conditions"].([]any)
+	conditions := make([]loadbalancer.RuleCondition, 0, len(items))
+	for _, item := range items {
+		c, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		value := optionalString(c, "attributeValue")
+		attributeName, _ := util.ExtractString(c, "attributeName")
+		switch loadbalancer.RuleConditionAttributeNameEnum(attributeName) {
+		case loadbalancer.RuleConditionAttributeNamePath:
+			operator, _ := util.ExtractString(c, "operator")
+			conditions = append(conditions, loadbalancer.PathMatchCondition{
+				AttributeValue: value,
+				Operator:       loadbalancer.PathMatchConditionOperatorEnum(operator),
+			})
+		case loadbalancer.RuleConditionAttributeNameSourceIpAddress:
+			conditions = append(conditions, loadbalancer.SourceIpAddressCondition{AttributeValue: value})
+		case loadbalancer.RuleConditionAttributeNameSourceVcnId:
+			conditions = append(conditions, loadbalancer.SourceVcnIdCondition{AttributeValue: value})
+		case loadbalancer.RuleConditionAttributeNameSourceVcnIpAddress:
+			conditions = append(conditions, loadbalancer.SourceVcnIpAddressCondition{AttributeValue: value})
+		}
+	}
+	return conditions
+}
+
+func optionalString(m map[string]any, key string) *string {
+	if v, ok := util.ExtractString(m, key); ok {
+		return common.String(v)
+	}
+	return nil
+}
+
+func buildRuleSetItems(rules []loadbalancer.Rule) []map[string]any {
+	result := make([]map[string]any, 0, len(rules))
+	for _, rule := range rules {
+		m := map[string]any{}
+		switch r := rule.(type) {
+		case loadbalancer.AddHttpRequestHeaderRule:
+			m["action"] = string(loadbalancer.RuleActionAddHttpRequestHeader)
+			setString(m, "header", r.Header)
+			setString(m, "value", r.Value)
+		case loadbalancer.AddHttpResponseHeaderRule:
+			m["action"] = string(loadbalancer.RuleActionAddHttpResponseHeader)
+			setString(m, "header", r.Header)
+			setString(m, "value", r.Value)
+		case loadbalancer.ExtendHttpRequestHeaderValueRule:
+			m["action"] = string(loadbalancer.RuleActionExtendHttpRequestHeaderValue)
+			setString(m, "header", r.Header)
+			setString(m, "prefix", r.Prefix)
+			setString(m, "suffix", r.Suffix)
+		case loadbalancer.ExtendHttpResponseHeaderValueRule:
+			m["action"] = string(loadbalancer.RuleActionExtendHttpResponseHeaderValue)
+			setString(m, "header", r.Header)
+			setString(m, "prefix", r.Prefix)
+			setString(m, "suffix", r.Suffix)
+		case loadbalancer.RemoveHttpRequestHeaderRule:
+			m["action"] = string(loadbalancer.RuleActionRemoveHttpRequestHeader)
+			setString(m, "header", r.Header)
+		case loadbalancer.RemoveHttpResponseHeaderRule:
+			m["action"] = string(loadbalancer.RuleActionRemoveHttpResponseHeader)
+			setString(m, "header", r.Header)
+		case loadbalancer.AllowRule:
+			m["action"] = string(loadbalancer.RuleActionAllow)
+			m["conditions"] = buildRuleConditions(r.Conditions)
+			setString(m, "description", r.Description)
+		case loadbalancer.ControlAccessUsingHttpMethodsRule:
+			m["action"] = string(loadbalancer.RuleActionControlAccessUsingHttpMethods)
+			m["allowedMethods"] = r.AllowedMethods
+			if r.StatusCode != nil {
+				m["statusCode"] = *r.StatusCode
+			}
+		case loadbalancer.RedirectRule:
+			m["action"] = string(loadbalancer.RuleActionRedirect)
+			m["conditions"] = buildRuleConditions(r.Conditions)
+			if r.ResponseCode != nil {
+				m["responseCode"] = *r.ResponseCode
+			}
+			if u := r.RedirectUri; u != nil {
+				uri := map[string]any{}
+				setString(uri, "protocol", u.Protocol)
+				setString(uri, "host", u.Host)
+				setString(uri, "path", u.Path)
+				setString(uri, "query", u.Query)
+				if u.Port != nil {
+					uri["port"] = *u.Port
+				}
+				m["redirectUri"] = uri
+			}
+		case loadbalancer.HttpHeaderRule:
+			m["action"] = string(loadbalancer.RuleActionHttpHeader)
+			if r.AreInvalidCharactersAllowed != nil {
+				m["areInvalidCharactersAllowed"] = *r.AreInvalidCharactersAllowed
+			}
+			if r.HttpLargeHeaderSizeInKB != nil {
+				m["httpLargeHeaderSizeInKB"] = *r.HttpLargeHeaderSizeInKB
+			}
+		default:
+			// Actions this provisioner does not manage are skipped rather
+			// than surfaced half-populated
+			continue
+		}
+		result = append(result, m)
+	}
+	return result
+}
+
+func buildRuleConditions(conditions []loadbalancer.RuleCondition) []map[string]any {
+	result := make([]map[string]any, 0, len(conditions))
+	for _, condition := range conditions {
+		m := map[string]any{}
+		switch c := condition.(type) {
+		case loadbalancer.PathMatchCondition:
+			m["attributeName"] = string(loadbalancer.RuleConditionAttributeNamePath)
+			setString(m, "attributeValue", c.AttributeValue)
+			m["operator"] = string(c.Operator)
+		case loadbalancer.SourceIpAddressCondition:
+			m["attributeName"] = string(loadbalancer.RuleConditionAttributeNameSourceIpAddress)
+			setString(m, "attributeValue", c.AttributeValue)
+		case loadbalancer.SourceVcnIdCondition:
+			m["attributeName"] = string(loadbalancer.RuleConditionAttributeNameSourceVcnId)
+			setString(m, "attributeValue", c.AttributeValue)
+		case loadbalancer.SourceVcnIpAddressCondition:
+			m["attributeName"] = string(loadbalancer.RuleConditionAttributeNameSourceVcnIpAddress)
+			setString(m, "attributeValue", c.AttributeValue)
+		default:
+			continue
+		}
+		result = append(result, m)
+	}
+	return result
+}
+
+func setString(m map[string]any, key string, v *string) {
+	if v != nil {
+		m[key] = *v
+	}
+}
+
+// HasNoTags: rule sets are part of the load balancer, which holds the tags.
+func (p *RuleSetProvisioner) HasNoTags() {}
+
+func (p *RuleSetProvisioner) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	var props map[string]any
+	if err := json.Unmarshal(request.Properties, &props); err != nil {
+		return nil, fmt.Errorf("failed to parse properties: %w", err)
+	}
+
+	loadBalancerId := props["LoadBalancerId"].(string)
+	name := props["Name"].(string)
+
+	items, err := parseRuleSetItems(props)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.CreateRuleSet(ctx, loadbalancer.CreateRuleSetRequest{
+		LoadBalancerId: common.String(loadBalancerId),
+		CreateRuleSetDetails: loadbalancer.CreateRuleSetDetails{
+			Name:  common.String(name),
+			Items: items,
+		},
+		OpcRetryToken: common.String(util.CreateRetryToken(request)),
+	})
+	if err != nil {
+		if result, handleErr := util.HandleCreateError(err, "OCI::LoadBalancer::RuleSet", "OCI::LoadBalancer::RuleSet"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to create RuleSet: %w", err)
+	}
+
+	return &resource.CreateResult{
+		ProgressResult: CreateInProgressResult(resource.OperationCreate, *resp.OpcWorkRequestId, util.EncodeCompositeID(loadBalancerId, name)),
+	}, nil
+}
+
+func (p *RuleSetProvisioner) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	loadBalancerId, name, err := parseSubResourceID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	props, err := util.ApplyPatchDocument(ctx, request, p.Read)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := parseRuleSetItems(props)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.UpdateRuleSet(ctx, loadbalancer.UpdateRuleSetRequest{
+		LoadBalancerId:       common.String(loadBalancerId),
+		RuleSetName:          common.String(name),
+		UpdateRuleSetDetails: loadbalancer.UpdateRuleSetDetails{Items: items},
+	})
+	if err != nil {
+		if result, handleErr := util.HandleUpdateError(err, "OCI::LoadBalancer::RuleSet", request.NativeID, "OCI::LoadBalancer::RuleSet"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to update RuleSet: %w", err)
+	}
+
+	return &resource.UpdateResult{
+		ProgressResult: CreateInProgressResult(resource.OperationUpdate, *resp.OpcWorkRequestId, request.NativeID),
+	}, nil
+}
+
+func (p *RuleSetProvisioner) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	loadBalancerId, name, err := parseSubResourceID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	readRes, err := p.Read(ctx, &resource.ReadRequest{NativeID: request.NativeID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read RuleSet before delete: %w", err)
+	}
+	if readRes.ErrorCode == resource.OperationErrorCodeNotFound {
+		return &resource.DeleteResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationDelete,
+				OperationStatus: resource.OperationStatusSuccess,
+				NativeID:        request.NativeID,
+			},
+		}, nil
+	}
+
+	resp, err := client.DeleteRuleSet(ctx, loadbalancer.DeleteRuleSetRequest{
+		LoadBalancerId: common.String(loadBalancerId),
+		RuleSetName:    common.String(name),
+	})
+	if err != nil {
+		if result, handleErr := util.HandleDeleteError(err, "OCI::LoadBalancer::RuleSet", request.NativeID, "OCI::LoadBalancer::RuleSet"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to delete RuleSet: %w", err)
+	}
+
+	return &resource.DeleteResult{
+		ProgressResult: CreateInProgressResult(resource.OperationDelete, *resp.OpcWorkRequestId, request.NativeID),
+	}, nil
+}
+
+func (p *RuleSetProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	result, err := CheckWorkRequestStatus(ctx, client, request.RequestID, request.NativeID, resource.OperationCheckStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resource.StatusResult{
+		ProgressResult: result,
+	}, nil
+}
+
+func (p *RuleSetProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	loadBalancerId, name, err := parseSubResourceID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetRuleSet(ctx, loadbalancer.GetRuleSetRequest{
+		LoadBalancerId: common.String(loadBalancerId),
+		RuleSetName:    common.String(name),
+	})
+	if err != nil {
+		if util.IsNotFound(err) {
+			return &resource.ReadResult{
+				ResourceType: "OCI::LoadBalancer::RuleSet",
+				ErrorCode:    resource.OperationErrorCodeNotFound,
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to read RuleSet: %w", err)
+	}
+
+	props := map[string]any{
+		"LoadBalancerId": loadBalancerId,
+		"Name":           *resp.Name,
+		"Items":          buildRuleSetItems(resp.Items),
+	}
+
+	propBytes, err := json.Marshal(props)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal RuleSet properties: %w", err)
+	}
+
+	return &resource.ReadResult{
+		ResourceType: "OCI::LoadBalancer::RuleSet",
+		Properties:   string(propBytes),
+	}, nil
+}
+
+func (p *RuleSetProvisioner) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancer client: %w", err)
+	}
+
+	loadBalancerId, ok := request.AdditionalProperties["LoadBalancerId"]
+	if !ok {
+		return nil, fmt.Errorf("LoadBalancerId is required for listing RuleSets")
+	}
+
+	lb, err := getActiveLoadBalancer(ctx, client, loadBalancerId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list RuleSets: %w", err)
+	}
+
+	nativeIDs := []string{}
+	if lb != nil {
+		for name := range lb.RuleSets {
+			nativeIDs = append(nativeIDs, util.EncodeCompositeID(loadBalancerId, name))
+		}
+		sort.Strings(nativeIDs)
+	}
+
+	return &resource.ListResult{
+		NativeIDs: nativeIDs,
+	}, nil
+}
@@ -0,0 +1,65 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package loadbalancer
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/loadbalancer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// roundTrip pushes properties through JSON the way formae hands them back to Create.
+func roundTrip(t *testing.T, props map[string]any) map[string]any {
+	t.Helper()
+	b, err := json.Marshal(props)
+	require.NoError(t, err)
+	var out map[string]any
+	require.NoError(t, json.Unmarshal(b, &out))
+	return out
+}
+
+func TestRuleSetItemsRoundTrip(t *testing.T) {
+	rules := []loadbalancer.Rule{
+		loadbalancer.AddHttpRequestHeaderRule{Header: common.String("X-Forwarded-Proto"), Value: common.String("https")},
+		loadbalancer.RemoveHttpResponseHeaderRule{Header: common.String("Server")},
+		loadbalancer.ControlAccessUsingHttpMethodsRule{AllowedMethods: []string{"GET", "POST"}, StatusCode: common.Int(405)},
+		loadbalancer.RedirectRule{
+			Conditions: []loadbalancer.RuleCondition{
+				loadbalancer.PathMatchCondition{AttributeValue: common.String("/old"), Operator: loadbalancer.PathMatchConditionOperatorPrefixMatch},
+			},
+			ResponseCode: common.Int(301),
+			RedirectUri:  &loadbalancer.RedirectUri{Protocol: common.String("HTTPS"), Port: common.Int(443), Path: common.String("/new")},
+		},
+	}
+
+	props := roundTrip(t, map[string]any{"Items": buildRuleSetItems(rules)})
+	parsed, err := parseRuleSetItems(props)
+	require.NoError(t, err)
+	assert.Equal(t, rules, parsed)
+}
+
+func TestParseRuleSetItemsRejectsUnknownAction(t *testing.T) {
+	_, err := parseRuleSetItems(map[string]any{
+		"Items": []any{map[string]any{"action": "REWRITE_URL"}},
+	})
+	assert.ErrorContains(t, err, "REWRITE_URL")
+}
+
+func TestRoutingRulesRoundTrip(t *testing.T) {
+	rules := []loadbalancer.RoutingRule{{
+		Name:      common.String("api"),
+		Condition: common.String("any(http.request.url.path sw (i '/api'))"),
+		Actions:   []loadbalancer.Action{loadbalancer.ForwardToBackendSet{BackendSetName: common.String("api")}},
+	}}
+
+	props := roundTrip(t, map[string]any{"Rules": buildRoutingRules(rules)})
+	parsed, err := parseRoutingRules(props)
+	require.NoError(t, err)
+	assert.Equal(t, rules, parsed)
+}
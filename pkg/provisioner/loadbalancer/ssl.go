@@ -0,0 +1,93 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package loadbalancer
+
+import (
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/loadbalancer"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+)
+
+// parseSslConfiguration reads the SslConfiguration property shared by
+// listeners (TLS termination) and backend sets (TLS to the backends).
+// Certificates are referenced either by CertificateName, for a certificate
+// bundle uploaded to the load balancer, or by CertificateIds, for
+// certificates managed in the OCI Certificates service.
+func parseSslConfiguration(props map[string]any) (*loadbalancer.SslConfigurationDetails, bool) {
+	m, ok := props["SslConfiguration"].(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	details := &loadbalancer.SslConfigurationDetails{}
+	if certificateName, ok := util.ExtractString(m, "certificateName"); ok {
+		details.CertificateName = common.String(certificateName)
+	}
+	if certificateIds, ok := util.ExtractStringSlice(m, "certificateIds"); ok {
+		details.CertificateIds = certificateIds
+	}
+	if caIds, ok := util.ExtractStringSlice(m, "trustedCertificateAuthorityIds"); ok {
+		details.TrustedCertificateAuthorityIds = caIds
+	}
+	if verifyPeerCertificate, ok := util.ExtractBool(m, "verifyPeerCertificate"); ok {
+		details.VerifyPeerCertificate = common.Bool(verifyPeerCertificate)
+	}
+	if verifyDepth, ok := extractInt(m, "verifyDepth"); ok {
+		details.VerifyDepth = common.Int(verifyDepth)
+	}
+	if protocols, ok := util.ExtractStringSlice(m, "protocols"); ok {
+		details.Protocols = protocols
+	}
+	if cipherSuiteName, ok := util.ExtractString(m, "cipherSuiteName"); ok {
+		details.CipherSuiteName = common.String(cipherSuiteName)
+	}
+	if serverOrderPreference, ok := util.ExtractString(m, "serverOrderPreference"); ok {
+		details.ServerOrderPreference = loadbalancer.SslConfigurationDetailsServerOrderPreferenceEnum(serverOrderPreference)
+	}
+	if hasSessionResumption, ok := util.ExtractBool(m, "hasSessionResumption"); ok {
+		details.HasSessionResumption = common.Bool(hasSessionResumption)
+	}
+	return details, true
+}
+
+func buildSslConfiguration(ssl *loadbalancer.SslConfiguration) map[string]any {
+	m := map[string]any{}
+	if ssl.CertificateName != nil {
+		m["certificateName"] = *ssl.CertificateName
+	}
+	if len(ssl.CertificateIds) > 0 {
+		m["certificateIds"] = ssl.CertificateIds
+	}
+	if len(ssl.TrustedCertificateAuthorityIds) > 0 {
+		m["trustedCertificateAuthorityIds"] = ssl.TrustedCertificateAuthorityIds
+	}
+	if ssl.VerifyPeerCertificate != nil {
+		m["verifyPeerCertificate"] = *ssl.VerifyPeerCertificate
+	}
+	if ssl.VerifyDepth != nil {
+		m["verifyDepth"] = *ssl.VerifyDepth
+	}
+	if len(ssl.Protocols) > 0 {
+		m["protocols"] = ssl.Protocols
+	}
+	if ssl.CipherSuiteName != nil {
+		m["cipherSuiteName"] = *ssl.CipherSuiteName
+	}
+	if ssl.ServerOrderPreference != "" {
+		m["serverOrderPreference"] = string(ssl.ServerOrderPreference)
+	}
+	if ssl.HasSessionResumption != nil {
+		m["hasSessionResumption"] = *ssl.HasSessionResumption
+	}
+	return m
+}
+
+// extractInt reads a JSON number as an int.
+func extractInt(m map[string]any, key string) (int, bool) {
+	v, ok := m[key].(float64)
+	if !ok {
+		return 0, false
+	}
+	return int(v), true
+}
@@ -0,0 +1,43 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package loadbalancer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/loadbalancer"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+)
+
+// parseSubResourceID splits the NativeID of a named load balancer child
+// (listener, backend set, ...). Format: {loadBalancerId}/{name}
+func parseSubResourceID(nativeID string) (loadBalancerId, name string, err error) {
+	parts, err := util.DecodeCompositeID(nativeID, 2)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid NativeID format: expected {loadBalancerId}/{name}: %w", err)
+	}
+	return parts[0], parts[1], nil
+}
+
+// getActiveLoadBalancer fetches the parent load balancer. It returns nil
+// without error when the load balancer is gone or being deleted, in which case
+// every child of it is gone as well.
+func getActiveLoadBalancer(ctx context.Context, client *loadbalancer.LoadBalancerClient, loadBalancerId string) (*loadbalancer.LoadBalancer, error) {
+	resp, err := client.GetLoadBalancer(ctx, loadbalancer.GetLoadBalancerRequest{
+		LoadBalancerId: common.String(loadBalancerId),
+	})
+	if err != nil {
+		if util.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if util.IsTerminal(string(resp.LifecycleState)) {
+		return nil, nil
+	}
+	return &resp.LoadBalancer, nil
+}
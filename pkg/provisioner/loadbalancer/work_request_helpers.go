@@ -0,0 +1,90 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package loadbalancer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/loadbalancer"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+// CheckWorkRequestStatus polls a LoadBalancer WorkRequest and converts it to a
+// formae ProgressResult. Unlike ContainerEngine, LB work requests only name the
+// load balancer they touched, so the caller passes the NativeID it already
+// knows (e.g. the {loadBalancerId}/{name} composite of a listener). An empty
+// nativeID, as when the load balancer itself is being created, falls back to
+// the work request's load balancer.
+func CheckWorkRequestStatus(
+	ctx context.Context,
+	client *loadbalancer.LoadBalancerClient,
+	workRequestId string,
+	nativeID string,
+	operation resource.Operation,
+) (*resource.ProgressResult, error) {
+	result, err := util.PollWorkRequest(ctx, workRequestPoller{client}, workRequestId, operation)
+	if err != nil {
+		return nil, err
+	}
+	if nativeID != "" {
+		result.NativeID = nativeID
+	}
+	return result, nil
+}
+
+// workRequestPoller adapts the LoadBalancer client to util.WorkRequestPoller.
+type workRequestPoller struct {
+	client *loadbalancer.LoadBalancerClient
+}
+
+func (p workRequestPoller) GetWorkRequest(ctx context.Context, workRequestId string) (*util.WorkRequest, error) {
+	resp, err := p.client.GetWorkRequest(ctx, loadbalancer.GetWorkRequestRequest{
+		WorkRequestId: common.String(workRequestId),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work request %s: %w", workRequestId, err)
+	}
+
+	wr := &util.WorkRequest{Status: string(resp.LifecycleState)}
+	if resp.LoadBalancerId != nil {
+		wr.ResourceID = *resp.LoadBalancerId
+	}
+	if resp.LifecycleState == loadbalancer.WorkRequestLifecycleStateFailed {
+		wr.FailureMessage = workRequestErrorMessage(resp.WorkRequest)
+	}
+	return wr, nil
+}
+
+// workRequestErrorMessage joins the error details of a failed WorkRequest,
+// falling back to its status message.
+func workRequestErrorMessage(wr loadbalancer.WorkRequest) string {
+	var messages []string
+	for _, e := range wr.ErrorDetails {
+		if e.Message != nil {
+			messages = append(messages, *e.Message)
+		}
+	}
+	if len(messages) > 0 {
+		return strings.Join(messages, "; ")
+	}
+	if wr.Message != nil && *wr.Message != "" {
+		return *wr.Message
+	}
+	return "Work request failed (no error details available)"
+}
+
+// CreateInProgressResult creates a standard in-progress result with a WorkRequest ID
+func CreateInProgressResult(operation resource.Operation, workRequestId string, nativeID string) *resource.ProgressResult {
+	return &resource.ProgressResult{
+		Operation:       operation,
+		OperationStatus: resource.OperationStatusInProgress,
+		RequestID:       workRequestId,
+		NativeID:        nativeID,
+	}
+}
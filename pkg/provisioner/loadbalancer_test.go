@@ -0,0 +1,215 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build integration
+
+package provisioner_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	ociloadbalancer "github.com/oracle/oci-go-sdk/v65/loadbalancer"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/loadbalancer"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testLoadBalancerPath = "/20170115/loadBalancers/ocid1.loadbalancer..aaa"
+
+func TestLoadBalancerCreateThenStatus(t *testing.T) {
+	svc := newTestLoadBalancerClientWithHeaders(t, map[route]canned{
+		{"POST", "/20170115/loadBalancers"}:                                              {204, ""},
+		{"GET", "/20170115/loadBalancerWorkRequests/ocid1.loadbalancerworkrequest..aaa"}: {200, newTestLBWorkRequestBody("SUCCEEDED")},
+	}, map[route]map[string]string{
+		{"POST", "/20170115/loadBalancers"}: {"opc-work-request-id": "ocid1.loadbalancerworkrequest..aaa"},
+	})
+	p := loadbalancer.NewLoadBalancerProvisionerWithSvc(svc)
+
+	props, _ := json.Marshal(map[string]any{
+		"CompartmentId": "ocid1.compartment..xxx",
+		"DisplayName":   "test-lb",
+		"ShapeName":     "flexible",
+		"ShapeDetails":  map[string]any{"minimumBandwidthInMbps": 10, "maximumBandwidthInMbps": 100},
+		"SubnetIds":     []any{"ocid1.subnet..aaa"},
+	})
+	created, err := p.Create(context.Background(), &resource.CreateRequest{
+		ResourceType: "OCI::LoadBalancer::LoadBalancer",
+		Properties:   props,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, created.ProgressResult.OperationStatus)
+	assert.Equal(t, "ocid1.loadbalancerworkrequest..aaa", created.ProgressResult.RequestID)
+	assert.Empty(t, created.ProgressResult.NativeID)
+
+	// The OCID isn't known until the work request names the load balancer
+	status, err := p.Status(context.Background(), &resource.StatusRequest{
+		RequestID: created.ProgressResult.RequestID,
+		NativeID:  created.ProgressResult.NativeID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, status.ProgressResult.OperationStatus)
+	assert.Equal(t, "ocid1.loadbalancer..aaa", status.ProgressResult.NativeID)
+}
+
+func TestLoadBalancerRead(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc := newTestLoadBalancerClient(t, map[route]canned{
+			{"GET", testLoadBalancerPath}: {200, newTestLoadBalancerDetailBody("ACTIVE")},
+			{"GET", testLoadBalancerPath + "/health"}: {200, `{
+				"status": "WARNING",
+				"warningStateBackendSetNames": ["web"],
+				"criticalStateBackendSetNames": [],
+				"unknownStateBackendSetNames": [],
+				"totalBackendSetCount": 2
+			}`},
+		})
+		p := loadbalancer.NewLoadBalancerProvisionerWithSvc(svc)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.loadbalancer..aaa"})
+		require.NoError(t, err)
+		assert.Empty(t, result.ErrorCode)
+
+		var props map[string]any
+		require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+		assert.Equal(t, "ocid1.loadbalancer..aaa", props["Id"])
+		assert.Equal(t, "flexible", props["ShapeName"])
+		assert.Equal(t, map[string]any{"minimumBandwidthInMbps": float64(10), "maximumBandwidthInMbps": float64(100)}, props["ShapeDetails"])
+		assert.Equal(t, []any{"ocid1.subnet..aaa"}, props["SubnetIds"])
+		assert.Equal(t, false, props["IsPrivate"])
+		assert.Equal(t, []any{"ocid1.networksecuritygroup..aaa"}, props["NetworkSecurityGroupIds"])
+		assert.Equal(t, []any{map[string]any{"ipAddress": "203.0.113.10", "isPublic": true}}, props["IpAddresses"])
+		health := props["HealthStatus"].(map[string]any)
+		assert.Equal(t, "WARNING", health["status"])
+		assert.Equal(t, []any{"web"}, health["warningBackendSets"])
+		assert.Equal(t, []any{}, health["criticalBackendSets"])
+		assert.Equal(t, float64(2), health["totalBackendSetCount"])
+	})
+
+	t.Run("health_skipped_while_updating", func(t *testing.T) {
+		svc := newTestLoadBalancerClient(t, map[route]canned{
+			{"GET", testLoadBalancerPath}: {200, newTestLoadBalancerDetailBody("UPDATING")},
+		})
+		p := loadbalancer.NewLoadBalancerProvisionerWithSvc(svc)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.loadbalancer..aaa"})
+		require.NoError(t, err)
+		assert.Empty(t, result.ErrorCode)
+
+		var props map[string]any
+		require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+		assert.NotContains(t, props, "HealthStatus")
+	})
+
+	t.Run("deleted", func(t *testing.T) {
+		svc := newTestLoadBalancerClient(t, map[route]canned{
+			{"GET", testLoadBalancerPath}: {200, newTestLoadBalancerDetailBody("DELETED")},
+		})
+		p := loadbalancer.NewLoadBalancerProvisionerWithSvc(svc)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.loadbalancer..aaa"})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationErrorCodeNotFound, result.ErrorCode)
+	})
+}
+
+func TestLoadBalancerUpdateChainsWorkRequests(t *testing.T) {
+	svc := newTestLoadBalancerClientWithHeaders(t, map[route]canned{
+		{"GET", testLoadBalancerPath}:                                                    {200, newTestLoadBalancerDetailBody("ACTIVE")},
+		{"PUT", testLoadBalancerPath + "/networkSecurityGroups"}:                         {204, ""},
+		{"GET", "/20170115/loadBalancerWorkRequests/ocid1.loadbalancerworkrequest..aaa"}: {200, newTestLBWorkRequestBody("SUCCEEDED")},
+		{"PUT", testLoadBalancerPath}:                                                    {204, ""},
+	}, map[route]map[string]string{
+		{"PUT", testLoadBalancerPath + "/networkSecurityGroups"}: {"opc-work-request-id": "ocid1.loadbalancerworkrequest..aaa"},
+		{"PUT", testLoadBalancerPath}:                            {"opc-work-request-id": "ocid1.loadbalancerworkrequest..bbb"},
+	})
+	p := loadbalancer.NewLoadBalancerProvisionerWithSvc(svc)
+
+	desired, _ := json.Marshal(map[string]any{
+		"CompartmentId":           "ocid1.compartment..xxx",
+		"DisplayName":             "renamed-lb",
+		"ShapeName":               "flexible",
+		"ShapeDetails":            map[string]any{"minimumBandwidthInMbps": 10, "maximumBandwidthInMbps": 100},
+		"SubnetIds":               []any{"ocid1.subnet..aaa"},
+		"NetworkSecurityGroupIds": []any{"ocid1.networksecuritygroup..bbb"},
+	})
+	updated, err := p.Update(context.Background(), &resource.UpdateRequest{
+		NativeID:          "ocid1.loadbalancer..aaa",
+		DesiredProperties: desired,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, updated.ProgressResult.OperationStatus)
+
+	// The NSG change finished, so Status submits the UpdateLoadBalancer step
+	status, err := p.Status(context.Background(), &resource.StatusRequest{
+		RequestID: updated.ProgressResult.RequestID,
+		NativeID:  "ocid1.loadbalancer..aaa",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, status.ProgressResult.OperationStatus)
+	assert.Equal(t, "ocid1.loadbalancerworkrequest..bbb", status.ProgressResult.RequestID)
+}
+
+func TestLoadBalancerList(t *testing.T) {
+	svc := newTestLoadBalancerClient(t, map[route]canned{
+		{"GET", "/20170115/loadBalancers"}: {200, fmt.Sprintf(`[%s, %s]`,
+			newTestLoadBalancerDetailBody("ACTIVE"),
+			`{"id": "ocid1.loadbalancer..gone", "compartmentId": "ocid1.compartment..xxx", "displayName": "gone",
+			  "lifecycleState": "DELETED", "timeCreated": "2025-01-01T00:00:00.000Z", "shapeName": "flexible"}`)},
+	})
+	p := loadbalancer.NewLoadBalancerProvisionerWithSvc(svc)
+
+	result, err := p.List(context.Background(), &resource.ListRequest{
+		ResourceType:         "OCI::LoadBalancer::LoadBalancer",
+		AdditionalProperties: map[string]string{"CompartmentId": "ocid1.compartment..xxx"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ocid1.loadbalancer..aaa"}, result.NativeIDs)
+}
+
+func TestLoadBalancerChildrenDependOnLoadBalancer(t *testing.T) {
+	for _, resourceType := range []string{
+		"OCI::LoadBalancer::Backend",
+		"OCI::LoadBalancer::BackendSet",
+		"OCI::LoadBalancer::Certificate",
+		"OCI::LoadBalancer::Hostname",
+		"OCI::LoadBalancer::Listener",
+		"OCI::LoadBalancer::RoutingPolicy",
+		"OCI::LoadBalancer::RuleSet",
+	} {
+		assert.Contains(t, provisioner.Dependencies(resourceType), "OCI::LoadBalancer::LoadBalancer", resourceType)
+	}
+}
+
+// Helpers
+
+func newTestLoadBalancerClientWithHeaders(t *testing.T, responses map[route]canned, headers map[route]map[string]string) *ociloadbalancer.LoadBalancerClient {
+	t.Helper()
+	host := newTestHeaderDispatcher(t, responses, headers)
+	c, err := ociloadbalancer.NewLoadBalancerClientWithConfigurationProvider(fakeOCIConfigProvider(t))
+	require.NoError(t, err)
+	applyTestRetryPolicy(&c)
+	c.Host = host
+	return &c
+}
+
+func newTestLoadBalancerDetailBody(lifecycleState string) string {
+	return fmt.Sprintf(`{
+		"id": "ocid1.loadbalancer..aaa",
+		"compartmentId": "ocid1.compartment..xxx",
+		"displayName": "test-lb",
+		"lifecycleState": %q,
+		"timeCreated": "2025-01-01T00:00:00.000Z",
+		"shapeName": "flexible",
+		"shapeDetails": {"minimumBandwidthInMbps": 10, "maximumBandwidthInMbps": 100},
+		"isPrivate": false,
+		"subnetIds": ["ocid1.subnet..aaa"],
+		"networkSecurityGroupIds": ["ocid1.networksecuritygroup..aaa"],
+		"ipAddresses": [{"ipAddress": "203.0.113.10", "isPublic": true}]
+	}`, lifecycleState)
+}
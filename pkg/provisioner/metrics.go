@@ -0,0 +1,161 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package provisioner
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+// OperationHook is told about every provisioner operation once it returns.
+// status is the operation's result status; an operation that returned an error
+// reports OperationStatusFailure. Async operations report OperationStatusInProgress
+// for the call that started them and again for each Status poll.
+type OperationHook interface {
+	OperationDone(resourceType string, operation resource.Operation, status resource.OperationStatus, duration time.Duration)
+}
+
+var (
+	hookMu sync.RWMutex
+	hook   OperationHook
+)
+
+// SetOperationHook installs h for every target, e.g. to feed Prometheus
+// counters. nil removes it. Without a hook, targets with OperationMetrics set
+// get one JSON line per operation on stderr; all others record nothing.
+func SetOperationHook(h OperationHook) {
+	hookMu.Lock()
+	defer hookMu.Unlock()
+	hook = h
+}
+
+func operationHook(targetConfig json.RawMessage) OperationHook {
+	hookMu.RLock()
+	defer hookMu.RUnlock()
+	if hook != nil {
+		return hook
+	}
+	if config.FromTargetConfig(targetConfig).OperationMetrics {
+		return logHook{logger: slog.New(slog.NewJSONHandler(os.Stderr, nil))}
+	}
+	return nil
+}
+
+// logHook writes operation metrics to stderr, which stays clear of the plugin
+// protocol on stdout.
+type logHook struct {
+	logger *slog.Logger
+}
+
+func (h logHook) OperationDone(resourceType string, operation resource.Operation, status resource.OperationStatus, duration time.Duration) {
+	h.logger.Info("operation",
+		"resourceType", resourceType,
+		"operation", string(operation),
+		"status", string(status),
+		"durationMs", duration.Milliseconds(),
+	)
+}
+
+// timed is the outermost decorator: it times each operation, including the
+// Reads that noOpUpdate, confirmDelete and readAfterWrite add, and reports it
+// to the operation hook.
+type timed struct {
+	inner Provisioner
+}
+
+func (t *timed) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	start := time.Now()
+	result, err := t.inner.Create(ctx, request)
+	if h := operationHook(request.TargetConfig); h != nil {
+		var pr *resource.ProgressResult
+		if result != nil {
+			pr = result.ProgressResult
+		}
+		h.OperationDone(request.ResourceType, resource.OperationCreate, progressStatus(pr, err), time.Since(start))
+	}
+	return result, err
+}
+
+func (t *timed) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	start := time.Now()
+	result, err := t.inner.Update(ctx, request)
+	if h := operationHook(request.TargetConfig); h != nil {
+		var pr *resource.ProgressResult
+		if result != nil {
+			pr = result.ProgressResult
+		}
+		h.OperationDone(request.ResourceType, resource.OperationUpdate, progressStatus(pr, err), time.Since(start))
+	}
+	return result, err
+}
+
+func (t *timed) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	start := time.Now()
+	result, err := t.inner.Delete(ctx, request)
+	if h := operationHook(request.TargetConfig); h != nil {
+		var pr *resource.ProgressResult
+		if result != nil {
+			pr = result.ProgressResult
+		}
+		h.OperationDone(request.ResourceType, resource.OperationDelete, progressStatus(pr, err), time.Since(start))
+	}
+	return result, err
+}
+
+func (t *timed) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	start := time.Now()
+	result, err := t.inner.Status(ctx, request)
+	if h := operationHook(request.TargetConfig); h != nil {
+		var pr *resource.ProgressResult
+		if result != nil {
+			pr = result.ProgressResult
+		}
+		h.OperationDone(request.ResourceType, resource.OperationCheckStatus, progressStatus(pr, err), time.Since(start))
+	}
+	return result, err
+}
+
+func (t *timed) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	start := time.Now()
+	result, err := t.inner.Read(ctx, request)
+	if h := operationHook(request.TargetConfig); h != nil {
+		// NotFound is an answer, not a failure
+		status := resource.OperationStatusSuccess
+		if err != nil {
+			status = resource.OperationStatusFailure
+		}
+		h.OperationDone(request.ResourceType, resource.OperationRead, status, time.Since(start))
+	}
+	return result, err
+}
+
+func (t *timed) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	start := time.Now()
+	result, err := t.inner.List(ctx, request)
+	if h := operationHook(request.TargetConfig); h != nil {
+		status := resource.OperationStatusSuccess
+		if err != nil {
+			status = resource.OperationStatusFailure
+		}
+		h.OperationDone(request.ResourceType, resource.OperationList, status, time.Since(start))
+	}
+	return result, err
+}
+
+// progressStatus is the status an operation ended with: its result's status, or
+// failure when it returned an error or no result.
+func progressStatus(pr *resource.ProgressResult, err error) resource.OperationStatus {
+	if err != nil || pr == nil {
+		return resource.OperationStatusFailure
+	}
+	return pr.OperationStatus
+}
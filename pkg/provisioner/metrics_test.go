@@ -0,0 +1,70 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package provisioner
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+type operationRecord struct {
+	resourceType string
+	operation    resource.Operation
+	status       resource.OperationStatus
+}
+
+type recordingHook struct {
+	records []operationRecord
+}
+
+func (h *recordingHook) OperationDone(resourceType string, operation resource.Operation, status resource.OperationStatus, _ time.Duration) {
+	h.records = append(h.records, operationRecord{resourceType, operation, status})
+}
+
+func TestTimed_ReportsOperations(t *testing.T) {
+	h := &recordingHook{}
+	SetOperationHook(h)
+	defer SetOperationHook(nil)
+
+	inner := &mockProvisioner{
+		createResult: &resource.CreateResult{
+			ProgressResult: &resource.ProgressResult{OperationStatus: resource.OperationStatusInProgress},
+		},
+		updateErr:  errors.New("boom"),
+		readResult: &resource.ReadResult{ErrorCode: resource.OperationErrorCodeNotFound},
+	}
+	p := &timed{inner: inner}
+
+	_, _ = p.Create(context.Background(), &resource.CreateRequest{ResourceType: "OCI::Core::Instance"})
+	_, _ = p.Update(context.Background(), &resource.UpdateRequest{ResourceType: "OCI::Core::Vcn"})
+	_, _ = p.Read(context.Background(), &resource.ReadRequest{ResourceType: "OCI::Core::Subnet"})
+
+	want := []operationRecord{
+		{"OCI::Core::Instance", resource.OperationCreate, resource.OperationStatusInProgress},
+		{"OCI::Core::Vcn", resource.OperationUpdate, resource.OperationStatusFailure},
+		{"OCI::Core::Subnet", resource.OperationRead, resource.OperationStatusSuccess},
+	}
+	if len(h.records) != len(want) {
+		t.Fatalf("expected %d records, got %d: %v", len(want), len(h.records), h.records)
+	}
+	for i := range want {
+		if h.records[i] != want[i] {
+			t.Errorf("record %d = %v, want %v", i, h.records[i], want[i])
+		}
+	}
+}
+
+func TestOperationHook_DefaultsToNone(t *testing.T) {
+	if h := operationHook(nil); h != nil {
+		t.Errorf("expected no hook without configuration, got %T", h)
+	}
+	if _, ok := operationHook([]byte(`{"OperationMetrics":true}`)).(logHook); !ok {
+		t.Error("expected the stderr hook when OperationMetrics is set")
+	}
+}
@@ -0,0 +1,399 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package networkloadbalancer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/networkloadbalancer"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+type BackendSetProvisioner struct {
+	clients *client.Clients
+	svc     *networkloadbalancer.NetworkLoadBalancerClient // nil until first use; injected in tests
+}
+
+var _ provisioner.Provisioner = &BackendSetProvisioner{}
+
+func init() {
+	provisioner.Register("OCI::NetworkLoadBalancer::BackendSet", NewBackendSetProvisioner)
+	provisioner.DependsOn("OCI::NetworkLoadBalancer::BackendSet", "OCI::NetworkLoadBalancer::NetworkLoadBalancer")
+}
+
+func NewBackendSetProvisioner(clients *client.Clients) provisioner.Provisioner {
+	return &BackendSetProvisioner{clients: clients}
+}
+
+// NewBackendSetProvisionerWithSvc constructs a provisioner with a pre-built SDK client,
+// for use in tests that point the client at an httptest server.
+func NewBackendSetProvisionerWithSvc(svc *networkloadbalancer.NetworkLoadBalancerClient) *BackendSetProvisioner {
+	return &BackendSetProvisioner{svc: svc}
+}
+
+func (p *BackendSetProvisioner) getSvc() (*networkloadbalancer.NetworkLoadBalancerClient, error) {
+	if p.svc != nil {
+		return p.svc, nil
+	}
+	return p.clients.GetNetworkLoadBalancerClient()
+}
+
+func parseHealthChecker(props map[string]any) *networkloadbalancer.HealthCheckerDetails {
+	m, ok := props["HealthChecker"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	details := &networkloadbalancer.HealthCheckerDetails{}
+	if protocol, ok := util.ExtractString(m, "protocol"); ok {
+		details.Protocol = networkloadbalancer.HealthCheckProtocolsEnum(protocol)
+	}
+	if port, ok := extractInt(m, "port"); ok {
+		details.Port = common.Int(port)
+	}
+	if urlPath, ok := util.ExtractString(m, "urlPath"); ok {
+		details.UrlPath = common.String(urlPath)
+	}
+	if returnCode, ok := extractInt(m, "returnCode"); ok {
+		details.ReturnCode = common.Int(returnCode)
+	}
+	if retries, ok := extractInt(m, "retries"); ok {
+		details.Retries = common.Int(retries)
+	}
+	if timeout, ok := extractInt(m, "timeoutInMillis"); ok {
+		details.TimeoutInMillis = common.Int(timeout)
+	}
+	if interval, ok := extractInt(m, "intervalInMillis"); ok {
+		details.IntervalInMillis = common.Int(interval)
+	}
+	if regex, ok := util.ExtractString(m, "responseBodyRegex"); ok {
+		details.ResponseBodyRegex = common.String(regex)
+	}
+	return details
+}
+
+func parseBackends(props map[string]any) []networkloadbalancer.BackendDetails {
+	items, _ := props["Backends"].([]any)
+	backends := make([]networkloadbalancer.BackendDetails, 0, len(items))
+	for _, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		backend := networkloadbalancer.BackendDetails{}
+		if ipAddress, ok := util.ExtractString(m, "ipAddress"); ok {
+			backend.IpAddress = common.String(ipAddress)
+		}
+		if targetId, ok := util.ExtractString(m, "targetId"); ok {
+			backend.TargetId = common.String(targetId)
+		}
+		if port, ok := extractInt(m, "port"); ok {
+			backend.Port = common.Int(port)
+		}
+		if weight, ok := extractInt(m, "weight"); ok {
+			backend.Weight = common.Int(weight)
+		}
+		if isBackup, ok := util.ExtractBool(m, "isBackup"); ok {
+			backend.IsBackup = common.Bool(isBackup)
+		}
+		if isDrain, ok := util.ExtractBool(m, "isDrain"); ok {
+			backend.IsDrain = common.Bool(isDrain)
+		}
+		if isOffline, ok := util.ExtractBool(m, "isOffline"); ok {
+			backend.IsOffline = common.Bool(isOffline)
+		}
+		backends = append(backends, backend)
+	}
+	return backends
+}
+
+// HasNoTags: NLB backend sets take no tags; the network load balancer does.
+func (p *BackendSetProvisioner) HasNoTags() {}
+
+func (p *BackendSetProvisioner) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get NetworkLoadBalancer client: %w", err)
+	}
+
+	var props map[string]any
+	if err := json.Unmarshal(request.Properties, &props); err != nil {
+		return nil, fmt.Errorf("failed to parse properties: %w", err)
+	}
+
+	networkLoadBalancerId := props["NetworkLoadBalancerId"].(string)
+	name := props["Name"].(string)
+
+	createDetails := networkloadbalancer.CreateBackendSetDetails{
+		Name:          common.String(name),
+		Policy:        networkloadbalancer.NetworkLoadBalancingPolicyEnum(props["Policy"].(string)),
+		HealthChecker: parseHealthChecker(props),
+		Backends:      parseBackends(props),
+	}
+
+	if isPreserveSource, ok := util.ExtractBool(props, "IsPreserveSource"); ok {
+		createDetails.IsPreserveSource = common.Bool(isPreserveSource)
+	}
+
+	resp, err := client.CreateBackendSet(ctx, networkloadbalancer.CreateBackendSetRequest{
+		NetworkLoadBalancerId:   common.String(networkLoadBalancerId),
+		CreateBackendSetDetails: createDetails,
+		OpcRetryToken:           common.String(util.CreateRetryToken(request)),
+	})
+	if err != nil {
+		if result, handleErr := util.HandleCreateError(err, "OCI::NetworkLoadBalancer::BackendSet", "OCI::NetworkLoadBalancer::BackendSet"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to create BackendSet: %w", err)
+	}
+
+	return &resource.CreateResult{
+		ProgressResult: CreateInProgressResult(resource.OperationCreate, *resp.OpcWorkRequestId, util.EncodeCompositeID(networkLoadBalancerId, name)),
+	}, nil
+}
+
+func (p *BackendSetProvisioner) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get NetworkLoadBalancer client: %w", err)
+	}
+
+	networkLoadBalancerId, name, err := parseSubResourceID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	props, err := util.ApplyPatchDocument(ctx, request, p.Read)
+	if err != nil {
+		return nil, err
+	}
+
+	// UpdateBackendSet replaces the backend list as a whole
+	updateDetails := networkloadbalancer.UpdateBackendSetDetails{
+		Policy:        common.String(props["Policy"].(string)),
+		HealthChecker: parseHealthChecker(props),
+		Backends:      parseBackends(props),
+	}
+
+	if isPreserveSource, ok := util.ExtractBool(props, "IsPreserveSource"); ok {
+		updateDetails.IsPreserveSource = common.Bool(isPreserveSource)
+	}
+
+	resp, err := client.UpdateBackendSet(ctx, networkloadbalancer.UpdateBackendSetRequest{
+		NetworkLoadBalancerId:   common.String(networkLoadBalancerId),
+		BackendSetName:          common.String(name),
+		UpdateBackendSetDetails: updateDetails,
+	})
+	if err != nil {
+		if result, handleErr := util.HandleUpdateError(err, "OCI::NetworkLoadBalancer::BackendSet", request.NativeID, "OCI::NetworkLoadBalancer::BackendSet"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to update BackendSet: %w", err)
+	}
+
+	return &resource.UpdateResult{
+		ProgressResult: CreateInProgressResult(resource.OperationUpdate, *resp.OpcWorkRequestId, request.NativeID),
+	}, nil
+}
+
+func (p *BackendSetProvisioner) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get NetworkLoadBalancer client: %w", err)
+	}
+
+	networkLoadBalancerId, name, err := parseSubResourceID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	readRes, err := p.Read(ctx, &resource.ReadRequest{NativeID: request.NativeID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read BackendSet before delete: %w", err)
+	}
+	if readRes.ErrorCode == resource.OperationErrorCodeNotFound {
+		return &resource.DeleteResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationDelete,
+				OperationStatus: resource.OperationStatusSuccess,
+				NativeID:        request.NativeID,
+			},
+		}, nil
+	}
+
+	resp, err := client.DeleteBackendSet(ctx, networkloadbalancer.DeleteBackendSetRequest{
+		NetworkLoadBalancerId: common.String(networkLoadBalancerId),
+		BackendSetName:        common.String(name),
+	})
+	if err != nil {
+		if result, handleErr := util.HandleDeleteError(err, "OCI::NetworkLoadBalancer::BackendSet", request.NativeID, "OCI::NetworkLoadBalancer::BackendSet"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to delete BackendSet: %w", err)
+	}
+
+	return &resource.DeleteResult{
+		ProgressResult: CreateInProgressResult(resource.OperationDelete, *resp.OpcWorkRequestId, request.NativeID),
+	}, nil
+}
+
+func (p *BackendSetProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get NetworkLoadBalancer client: %w", err)
+	}
+
+	result, err := CheckWorkRequestStatus(ctx, client, request.RequestID, request.NativeID, resource.OperationCheckStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resource.StatusResult{
+		ProgressResult: result,
+	}, nil
+}
+
+func (p *BackendSetProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get NetworkLoadBalancer client: %w", err)
+	}
+
+	networkLoadBalancerId, name, err := parseSubResourceID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetBackendSet(ctx, networkloadbalancer.GetBackendSetRequest{
+		NetworkLoadBalancerId: common.String(networkLoadBalancerId),
+		BackendSetName:        common.String(name),
+	})
+	if err != nil {
+		if util.IsNotFound(err) {
+			return &resource.ReadResult{
+				ResourceType: "OCI::NetworkLoadBalancer::BackendSet",
+				ErrorCode:    resource.OperationErrorCodeNotFound,
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to read BackendSet: %w", err)
+	}
+
+	propBytes, err := json.Marshal(buildBackendSetProperties(networkLoadBalancerId, resp.BackendSet))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal BackendSet properties: %w", err)
+	}
+
+	return &resource.ReadResult{
+		ResourceType: "OCI::NetworkLoadBalancer::BackendSet",
+		Properties:   string(propBytes),
+	}, nil
+}
+
+func buildBackendSetProperties(networkLoadBalancerId string, backendSet networkloadbalancer.BackendSet) map[string]any {
+	props := map[string]any{
+		"NetworkLoadBalancerId": networkLoadBalancerId,
+		"Name":                  *backendSet.Name,
+		"Policy":                string(backendSet.Policy),
+	}
+
+	if backendSet.IsPreserveSource != nil {
+		props["IsPreserveSource"] = *backendSet.IsPreserveSource
+	}
+
+	if hc := backendSet.HealthChecker; hc != nil {
+		healthChecker := map[string]any{
+			"protocol": string(hc.Protocol),
+		}
+		if hc.Port != nil {
+			healthChecker["port"] = *hc.Port
+		}
+		if hc.UrlPath != nil {
+			healthChecker["urlPath"] = *hc.UrlPath
+		}
+		if hc.ReturnCode != nil {
+			healthChecker["returnCode"] = *hc.ReturnCode
+		}
+		if hc.Retries != nil {
+			healthChecker["retries"] = *hc.Retries
+		}
+		if hc.TimeoutInMillis != nil {
+			healthChecker["timeoutInMillis"] = *hc.TimeoutInMillis
+		}
+		if hc.IntervalInMillis != nil {
+			healthChecker["intervalInMillis"] = *hc.IntervalInMillis
+		}
+		if hc.ResponseBodyRegex != nil && *hc.ResponseBodyRegex != "" {
+			healthChecker["responseBodyRegex"] = *hc.ResponseBodyRegex
+		}
+		props["HealthChecker"] = healthChecker
+	}
+
+	if len(backendSet.Backends) > 0 {
+		backends := make([]map[string]any, 0, len(backendSet.Backends))
+		for _, b := range backendSet.Backends {
+			backend := map[string]any{}
+			if b.IpAddress != nil {
+				backend["ipAddress"] = *b.IpAddress
+			}
+			if b.TargetId != nil {
+				backend["targetId"] = *b.TargetId
+			}
+			if b.Port != nil {
+				backend["port"] = *b.Port
+			}
+			if b.Weight != nil {
+				backend["weight"] = *b.Weight
+			}
+			if b.IsBackup != nil {
+				backend["isBackup"] = *b.IsBackup
+			}
+			if b.IsDrain != nil {
+				backend["isDrain"] = *b.IsDrain
+			}
+			if b.IsOffline != nil {
+				backend["isOffline"] = *b.IsOffline
+			}
+			backends = append(backends, backend)
+		}
+		props["Backends"] = backends
+	}
+
+	return props
+}
+
+func (p *BackendSetProvisioner) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get NetworkLoadBalancer client: %w", err)
+	}
+
+	networkLoadBalancerId, ok := request.AdditionalProperties["NetworkLoadBalancerId"]
+	if !ok {
+		return nil, fmt.Errorf("NetworkLoadBalancerId is required for listing BackendSets")
+	}
+
+	nlb, err := getActiveNetworkLoadBalancer(ctx, client, networkLoadBalancerId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list BackendSets: %w", err)
+	}
+
+	nativeIDs := []string{}
+	if nlb != nil {
+		for name := range nlb.BackendSets {
+			nativeIDs = append(nativeIDs, util.EncodeCompositeID(networkLoadBalancerId, name))
+		}
+		sort.Strings(nativeIDs)
+	}
+
+	return &resource.ListResult{
+		NativeIDs: nativeIDs,
+	}, nil
+}
@@ -0,0 +1,57 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package networkloadbalancer
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/networkloadbalancer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// roundTrip pushes properties through JSON the way formae hands them back to Create.
+func roundTrip(t *testing.T, props map[string]any) map[string]any {
+	t.Helper()
+	b, err := json.Marshal(props)
+	require.NoError(t, err)
+	var out map[string]any
+	require.NoError(t, json.Unmarshal(b, &out))
+	return out
+}
+
+// TestHealthCheckerRoundTrip mirrors the load balancer test of the same name.
+// The NLB health checker has no isForcePlainText and types protocol as an
+// enum, which is where the two mappings drift apart.
+func TestHealthCheckerRoundTrip(t *testing.T) {
+	healthChecker := map[string]any{
+		"protocol":          "HTTPS",
+		"port":              8443,
+		"urlPath":           "/healthz",
+		"returnCode":        204,
+		"retries":           5,
+		"timeoutInMillis":   2500,
+		"intervalInMillis":  15000,
+		"responseBodyRegex": "^ok$",
+	}
+	props := roundTrip(t, map[string]any{"HealthChecker": healthChecker})
+
+	details := parseHealthChecker(props)
+	require.NotNil(t, details)
+
+	b, err := json.Marshal(details)
+	require.NoError(t, err)
+	var readBack networkloadbalancer.HealthChecker
+	require.NoError(t, json.Unmarshal(b, &readBack))
+
+	read := roundTrip(t, buildBackendSetProperties("ocid1.networkloadbalancer..aaa", networkloadbalancer.BackendSet{
+		Name:          common.String("tcp"),
+		Policy:        networkloadbalancer.NetworkLoadBalancingPolicyFiveTuple,
+		HealthChecker: &readBack,
+	}))
+	assert.Equal(t, props["HealthChecker"], read["HealthChecker"])
+}
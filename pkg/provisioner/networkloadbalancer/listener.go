@@ -0,0 +1,274 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package networkloadbalancer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/networkloadbalancer"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+type ListenerProvisioner struct {
+	clients *client.Clients
+	svc     *networkloadbalancer.NetworkLoadBalancerClient // nil until first use; injected in tests
+}
+
+var _ provisioner.Provisioner = &ListenerProvisioner{}
+
+func init() {
+	provisioner.Register("OCI::NetworkLoadBalancer::Listener", NewListenerProvisioner)
+	provisioner.DependsOn("OCI::NetworkLoadBalancer::Listener", "OCI::NetworkLoadBalancer::NetworkLoadBalancer", "OCI::NetworkLoadBalancer::BackendSet")
+}
+
+func NewListenerProvisioner(clients *client.Clients) provisioner.Provisioner {
+	return &ListenerProvisioner{clients: clients}
+}
+
+// NewListenerProvisionerWithSvc constructs a provisioner with a pre-built SDK client,
+// for use in tests that point the client at an httptest server.
+func NewListenerProvisionerWithSvc(svc *networkloadbalancer.NetworkLoadBalancerClient) *ListenerProvisioner {
+	return &ListenerProvisioner{svc: svc}
+}
+
+func (p *ListenerProvisioner) getSvc() (*networkloadbalancer.NetworkLoadBalancerClient, error) {
+	if p.svc != nil {
+		return p.svc, nil
+	}
+	return p.clients.GetNetworkLoadBalancerClient()
+}
+
+// HasNoTags: NLB listeners take no tags; the network load balancer does.
+func (p *ListenerProvisioner) HasNoTags() {}
+
+func (p *ListenerProvisioner) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get NetworkLoadBalancer client: %w", err)
+	}
+
+	var props map[string]any
+	if err := json.Unmarshal(request.Properties, &props); err != nil {
+		return nil, fmt.Errorf("failed to parse properties: %w", err)
+	}
+
+	networkLoadBalancerId := props["NetworkLoadBalancerId"].(string)
+	name := props["Name"].(string)
+	port, _ := extractInt(props, "Port")
+
+	createDetails := networkloadbalancer.CreateListenerDetails{
+		Name:                  common.String(name),
+		DefaultBackendSetName: common.String(props["DefaultBackendSetName"].(string)),
+		Port:                  common.Int(port),
+		Protocol:              networkloadbalancer.ListenerProtocolsEnum(props["Protocol"].(string)),
+	}
+
+	if ipVersion, ok := util.ExtractString(props, "IpVersion"); ok {
+		createDetails.IpVersion = networkloadbalancer.IpVersionEnum(ipVersion)
+	}
+
+	resp, err := client.CreateListener(ctx, networkloadbalancer.CreateListenerRequest{
+		NetworkLoadBalancerId: common.String(networkLoadBalancerId),
+		CreateListenerDetails: createDetails,
+		OpcRetryToken:         common.String(util.CreateRetryToken(request)),
+	})
+	if err != nil {
+		if result, handleErr := util.HandleCreateError(err, "OCI::NetworkLoadBalancer::Listener", "OCI::NetworkLoadBalancer::Listener"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to create Listener: %w", err)
+	}
+
+	return &resource.CreateResult{
+		ProgressResult: CreateInProgressResult(resource.OperationCreate, *resp.OpcWorkRequestId, util.EncodeCompositeID(networkLoadBalancerId, name)),
+	}, nil
+}
+
+func (p *ListenerProvisioner) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get NetworkLoadBalancer client: %w", err)
+	}
+
+	networkLoadBalancerId, name, err := parseSubResourceID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	props, err := util.ApplyPatchDocument(ctx, request, p.Read)
+	if err != nil {
+		return nil, err
+	}
+
+	updateDetails := networkloadbalancer.UpdateListenerDetails{}
+	if defaultBackendSetName, ok := util.ExtractString(props, "DefaultBackendSetName"); ok {
+		updateDetails.DefaultBackendSetName = common.String(defaultBackendSetName)
+	}
+	if port, ok := extractInt(props, "Port"); ok {
+		updateDetails.Port = common.Int(port)
+	}
+	if protocol, ok := util.ExtractString(props, "Protocol"); ok {
+		updateDetails.Protocol = networkloadbalancer.ListenerProtocolsEnum(protocol)
+	}
+	if ipVersion, ok := util.ExtractString(props, "IpVersion"); ok {
+		updateDetails.IpVersion = networkloadbalancer.IpVersionEnum(ipVersion)
+	}
+
+	resp, err := client.UpdateListener(ctx, networkloadbalancer.UpdateListenerRequest{
+		NetworkLoadBalancerId: common.String(networkLoadBalancerId),
+		ListenerName:          common.String(name),
+		UpdateListenerDetails: updateDetails,
+	})
+	if err != nil {
+		if result, handleErr := util.HandleUpdateError(err, "OCI::NetworkLoadBalancer::Listener", request.NativeID, "OCI::NetworkLoadBalancer::Listener"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to update Listener: %w", err)
+	}
+
+	return &resource.UpdateResult{
+		ProgressResult: CreateInProgressResult(resource.OperationUpdate, *resp.OpcWorkRequestId, request.NativeID),
+	}, nil
+}
+
+func (p *ListenerProvisioner) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get NetworkLoadBalancer client: %w", err)
+	}
+
+	networkLoadBalancerId, name, err := parseSubResourceID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	readRes, err := p.Read(ctx, &resource.ReadRequest{NativeID: request.NativeID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Listener before delete: %w", err)
+	}
+	if readRes.ErrorCode == resource.OperationErrorCodeNotFound {
+		return &resource.DeleteResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationDelete,
+				OperationStatus: resource.OperationStatusSuccess,
+				NativeID:        request.NativeID,
+			},
+		}, nil
+	}
+
+	resp, err := client.DeleteListener(ctx, networkloadbalancer.DeleteListenerRequest{
+		NetworkLoadBalancerId: common.String(networkLoadBalancerId),
+		ListenerName:          common.String(name),
+	})
+	if err != nil {
+		if result, handleErr := util.HandleDeleteError(err, "OCI::NetworkLoadBalancer::Listener", request.NativeID, "OCI::NetworkLoadBalancer::Listener"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to delete Listener: %w", err)
+	}
+
+	return &resource.DeleteResult{
+		ProgressResult: CreateInProgressResult(resource.OperationDelete, *resp.OpcWorkRequestId, request.NativeID),
+	}, nil
+}
+
+func (p *ListenerProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get NetworkLoadBalancer client: %w", err)
+	}
+
+	result, err := CheckWorkRequestStatus(ctx, client, request.RequestID, request.NativeID, resource.OperationCheckStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resource.StatusResult{
+		ProgressResult: result,
+	}, nil
+}
+
+func (p *ListenerProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get NetworkLoadBalancer client: %w", err)
+	}
+
+	networkLoadBalancerId, name, err := parseSubResourceID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetListener(ctx, networkloadbalancer.GetListenerRequest{
+		NetworkLoadBalancerId: common.String(networkLoadBalancerId),
+		ListenerName:          common.String(name),
+	})
+	if err != nil {
+		if util.IsNotFound(err) {
+			return &resource.ReadResult{
+				ResourceType: "OCI::NetworkLoadBalancer::Listener",
+				ErrorCode:    resource.OperationErrorCodeNotFound,
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to read Listener: %w", err)
+	}
+
+	props := map[string]any{
+		"NetworkLoadBalancerId": networkLoadBalancerId,
+		"Name":                  *resp.Name,
+		"DefaultBackendSetName": *resp.DefaultBackendSetName,
+		"Port":                  *resp.Port,
+		"Protocol":              string(resp.Protocol),
+	}
+	if resp.IpVersion != "" {
+		props["IpVersion"] = string(resp.IpVersion)
+	}
+
+	propBytes, err := json.Marshal(props)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Listener properties: %w", err)
+	}
+
+	return &resource.ReadResult{
+		ResourceType: "OCI::NetworkLoadBalancer::Listener",
+		Properties:   string(propBytes),
+	}, nil
+}
+
+func (p *ListenerProvisioner) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get NetworkLoadBalancer client: %w", err)
+	}
+
+	networkLoadBalancerId, ok := request.AdditionalProperties["NetworkLoadBalancerId"]
+	if !ok {
+		return nil, fmt.Errorf("NetworkLoadBalancerId is required for listing Listeners")
+	}
+
+	nlb, err := getActiveNetworkLoadBalancer(ctx, client, networkLoadBalancerId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Listeners: %w", err)
+	}
+
+	nativeIDs := []string{}
+	if nlb != nil {
+		for name := range nlb.Listeners {
+			nativeIDs = append(nativeIDs, util.EncodeCompositeID(networkLoadBalancerId, name))
+		}
+		sort.Strings(nativeIDs)
+	}
+
+	return &resource.ListResult{
+		NativeIDs: nativeIDs,
+	}, nil
+}
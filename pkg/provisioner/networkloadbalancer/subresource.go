@@ -0,0 +1,51 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package networkloadbalancer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/networkloadbalancer"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+)
+
+// parseSubResourceID splits the NativeID of a named network load balancer
+// child (listener, backend set). Format: {networkLoadBalancerId}/{name}
+func parseSubResourceID(nativeID string) (networkLoadBalancerId, name string, err error) {
+	parts, err := util.DecodeCompositeID(nativeID, 2)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid NativeID format: expected {networkLoadBalancerId}/{name}: %w", err)
+	}
+	return parts[0], parts[1], nil
+}
+
+// getActiveNetworkLoadBalancer fetches the parent network load balancer. It
+// returns nil without error when it is gone or being deleted, in which case
+// every child of it is gone as well.
+func getActiveNetworkLoadBalancer(ctx context.Context, client *networkloadbalancer.NetworkLoadBalancerClient, networkLoadBalancerId string) (*networkloadbalancer.NetworkLoadBalancer, error) {
+	resp, err := client.GetNetworkLoadBalancer(ctx, networkloadbalancer.GetNetworkLoadBalancerRequest{
+		NetworkLoadBalancerId: common.String(networkLoadBalancerId),
+	})
+	if err != nil {
+		if util.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if util.IsTerminal(string(resp.LifecycleState)) {
+		return nil, nil
+	}
+	return &resp.NetworkLoadBalancer, nil
+}
+
+func extractInt(m map[string]any, key string) (int, bool) {
+	v, ok := m[key].(float64)
+	if !ok {
+		return 0, false
+	}
+	return int(v), true
+}
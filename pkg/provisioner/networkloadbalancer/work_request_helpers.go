@@ -0,0 +1,93 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package networkloadbalancer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/networkloadbalancer"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+// CheckWorkRequestStatus polls a NetworkLoadBalancer WorkRequest and converts it
+// to a formae ProgressResult. As with the LoadBalancer service, the caller passes
+// the NativeID it already knows (e.g. the {networkLoadBalancerId}/{name}
+// composite of a listener).
+func CheckWorkRequestStatus(
+	ctx context.Context,
+	client *networkloadbalancer.NetworkLoadBalancerClient,
+	workRequestId string,
+	nativeID string,
+	operation resource.Operation,
+) (*resource.ProgressResult, error) {
+	result, err := util.PollWorkRequest(ctx, workRequestPoller{client}, workRequestId, operation)
+	if err != nil {
+		return nil, err
+	}
+	result.NativeID = nativeID
+	return result, nil
+}
+
+// workRequestPoller adapts the NetworkLoadBalancer client to util.WorkRequestPoller.
+type workRequestPoller struct {
+	client *networkloadbalancer.NetworkLoadBalancerClient
+}
+
+func (p workRequestPoller) GetWorkRequest(ctx context.Context, workRequestId string) (*util.WorkRequest, error) {
+	resp, err := p.client.GetWorkRequest(ctx, networkloadbalancer.GetWorkRequestRequest{
+		WorkRequestId: common.String(workRequestId),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work request %s: %w", workRequestId, err)
+	}
+
+	wr := &util.WorkRequest{Status: string(resp.Status)}
+	if resp.Status == networkloadbalancer.OperationStatusFailed {
+		wr.FailureMessage = getWorkRequestErrors(ctx, p.client, workRequestId, resp.CompartmentId)
+	}
+	return wr, nil
+}
+
+// getWorkRequestErrors retrieves error messages from a failed WorkRequest
+func getWorkRequestErrors(ctx context.Context, client *networkloadbalancer.NetworkLoadBalancerClient, workRequestId string, compartmentId *string) string {
+	if compartmentId == nil {
+		return "Work request failed (no compartment ID to retrieve errors)"
+	}
+
+	resp, err := client.ListWorkRequestErrors(ctx, networkloadbalancer.ListWorkRequestErrorsRequest{
+		WorkRequestId: common.String(workRequestId),
+		CompartmentId: compartmentId,
+	})
+	if err != nil {
+		return fmt.Sprintf("Work request failed (could not retrieve error details: %v)", err)
+	}
+
+	var messages []string
+	for _, item := range resp.Items {
+		if item.Message != nil {
+			messages = append(messages, *item.Message)
+		}
+	}
+
+	if len(messages) == 0 {
+		return "Work request failed (no error details available)"
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// CreateInProgressResult creates a standard in-progress result with a WorkRequest ID
+func CreateInProgressResult(operation resource.Operation, workRequestId string, nativeID string) *resource.ProgressResult {
+	return &resource.ProgressResult{
+		Operation:       operation,
+		OperationStatus: resource.OperationStatusInProgress,
+		RequestID:       workRequestId,
+		NativeID:        nativeID,
+	}
+}
@@ -0,0 +1,123 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build integration
+
+package provisioner_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/networkloadbalancer"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNLBBackendSetRead(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc := newTestNetworkLoadBalancerClient(t, map[route]canned{
+			{"GET", "/20200501/networkLoadBalancers/ocid1.networkloadbalancer..aaa/backendSets/workers"}: {200, newTestNLBBackendSetBody()},
+		})
+		p := networkloadbalancer.NewBackendSetProvisionerWithSvc(svc)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.networkloadbalancer..aaa/workers"})
+		require.NoError(t, err)
+		assert.Empty(t, result.ErrorCode)
+
+		var props map[string]any
+		require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+		assert.Equal(t, "ocid1.networkloadbalancer..aaa", props["NetworkLoadBalancerId"])
+		assert.Equal(t, "workers", props["Name"])
+		assert.Equal(t, "FIVE_TUPLE", props["Policy"])
+		assert.Equal(t, true, props["IsPreserveSource"])
+		healthChecker := props["HealthChecker"].(map[string]any)
+		assert.Equal(t, "TCP", healthChecker["protocol"])
+		assert.Equal(t, float64(443), healthChecker["port"])
+		backends := props["Backends"].([]any)
+		require.Len(t, backends, 1)
+		assert.Equal(t, "ocid1.instance..aaa", backends[0].(map[string]any)["targetId"])
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		svc := newTestNetworkLoadBalancerClient(t, map[route]canned{
+			{"GET", "/20200501/networkLoadBalancers/ocid1.networkloadbalancer..aaa/backendSets/missing"}: {404, `{"code":"NotAuthorizedOrNotFound","message":"not found"}`},
+		})
+		p := networkloadbalancer.NewBackendSetProvisionerWithSvc(svc)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.networkloadbalancer..aaa/missing"})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationErrorCodeNotFound, result.ErrorCode)
+	})
+}
+
+func TestNLBBackendSetDeleteAlreadyGone(t *testing.T) {
+	svc := newTestNetworkLoadBalancerClient(t, map[route]canned{
+		{"GET", "/20200501/networkLoadBalancers/ocid1.networkloadbalancer..aaa/backendSets/workers"}: {404, `{"code":"NotAuthorizedOrNotFound","message":"not found"}`},
+	})
+	p := networkloadbalancer.NewBackendSetProvisionerWithSvc(svc)
+
+	result, err := p.Delete(context.Background(), &resource.DeleteRequest{NativeID: "ocid1.networkloadbalancer..aaa/workers"})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+}
+
+func TestNLBBackendSetList(t *testing.T) {
+	t.Run("active", func(t *testing.T) {
+		svc := newTestNetworkLoadBalancerClient(t, map[route]canned{
+			{"GET", "/20200501/networkLoadBalancers/ocid1.networkloadbalancer..aaa"}: {200, newTestNetworkLoadBalancerBody("ACTIVE")},
+		})
+		p := networkloadbalancer.NewBackendSetProvisionerWithSvc(svc)
+
+		result, err := p.List(context.Background(), &resource.ListRequest{
+			ResourceType:         "OCI::NetworkLoadBalancer::BackendSet",
+			AdditionalProperties: map[string]string{"NetworkLoadBalancerId": "ocid1.networkloadbalancer..aaa"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ocid1.networkloadbalancer..aaa/workers"}, result.NativeIDs)
+	})
+
+	t.Run("parent_deleting", func(t *testing.T) {
+		svc := newTestNetworkLoadBalancerClient(t, map[route]canned{
+			{"GET", "/20200501/networkLoadBalancers/ocid1.networkloadbalancer..aaa"}: {200, newTestNetworkLoadBalancerBody("DELETING")},
+		})
+		p := networkloadbalancer.NewBackendSetProvisionerWithSvc(svc)
+
+		result, err := p.List(context.Background(), &resource.ListRequest{
+			ResourceType:         "OCI::NetworkLoadBalancer::BackendSet",
+			AdditionalProperties: map[string]string{"NetworkLoadBalancerId": "ocid1.networkloadbalancer..aaa"},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, result.NativeIDs)
+	})
+}
+
+// Helpers
+
+func newTestNLBBackendSetBody() string {
+	return `{
+		"name": "workers",
+		"policy": "FIVE_TUPLE",
+		"isPreserveSource": true,
+		"backends": [{
+			"name": "ocid1.instance..aaa:443",
+			"targetId": "ocid1.instance..aaa",
+			"ipAddress": "10.0.1.20",
+			"port": 443,
+			"weight": 1,
+			"isBackup": false,
+			"isDrain": false,
+			"isOffline": false
+		}],
+		"healthChecker": {
+			"protocol": "TCP",
+			"port": 443,
+			"retries": 3,
+			"timeoutInMillis": 3000,
+			"intervalInMillis": 10000
+		}
+	}`
+}
@@ -0,0 +1,150 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build integration
+
+package provisioner_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	ocinetworkloadbalancer "github.com/oracle/oci-go-sdk/v65/networkloadbalancer"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/networkloadbalancer"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNLBListenerRead(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc := newTestNetworkLoadBalancerClient(t, map[route]canned{
+			{"GET", "/20200501/networkLoadBalancers/ocid1.networkloadbalancer..aaa/listeners/tcp"}: {200, `{
+				"name": "tcp",
+				"defaultBackendSetName": "workers",
+				"port": 443,
+				"protocol": "TCP",
+				"ipVersion": "IPV4"
+			}`},
+		})
+		p := networkloadbalancer.NewListenerProvisionerWithSvc(svc)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.networkloadbalancer..aaa/tcp"})
+		require.NoError(t, err)
+		assert.Empty(t, result.ErrorCode)
+
+		var props map[string]any
+		require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+		assert.Equal(t, "ocid1.networkloadbalancer..aaa", props["NetworkLoadBalancerId"])
+		assert.Equal(t, "tcp", props["Name"])
+		assert.Equal(t, "workers", props["DefaultBackendSetName"])
+		assert.Equal(t, float64(443), props["Port"])
+		assert.Equal(t, "TCP", props["Protocol"])
+		assert.Equal(t, "IPV4", props["IpVersion"])
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		svc := newTestNetworkLoadBalancerClient(t, map[route]canned{
+			{"GET", "/20200501/networkLoadBalancers/ocid1.networkloadbalancer..aaa/listeners/missing"}: {404, `{"code":"NotAuthorizedOrNotFound","message":"not found"}`},
+		})
+		p := networkloadbalancer.NewListenerProvisionerWithSvc(svc)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.networkloadbalancer..aaa/missing"})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationErrorCodeNotFound, result.ErrorCode)
+	})
+}
+
+func TestNLBListenerStatus(t *testing.T) {
+	cases := []struct {
+		status   string
+		expected resource.OperationStatus
+	}{
+		{"SUCCEEDED", resource.OperationStatusSuccess},
+		{"IN_PROGRESS", resource.OperationStatusInProgress},
+		{"FAILED", resource.OperationStatusFailure},
+		{"CANCELED", resource.OperationStatusFailure},
+	}
+	for _, tc := range cases {
+		t.Run(tc.status, func(t *testing.T) {
+			responses := map[route]canned{
+				{"GET", "/20200501/workRequests/ocid1.nlbworkrequest..aaa"}: {200, newTestNLBWorkRequestBody(tc.status)},
+			}
+			if tc.status == "FAILED" {
+				responses[route{"GET", "/20200501/workRequests/ocid1.nlbworkrequest..aaa/errors"}] = canned{200, `{
+					"items": [{"code": "InvalidParameter", "message": "backend set workers not found", "timestamp": "2025-01-01T00:00:00.000Z"}]
+				}`}
+			}
+			p := networkloadbalancer.NewListenerProvisionerWithSvc(newTestNetworkLoadBalancerClient(t, responses))
+
+			result, err := p.Status(context.Background(), &resource.StatusRequest{
+				RequestID: "ocid1.nlbworkrequest..aaa",
+				NativeID:  "ocid1.networkloadbalancer..aaa/tcp",
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result.ProgressResult.OperationStatus)
+			assert.Equal(t, "ocid1.networkloadbalancer..aaa/tcp", result.ProgressResult.NativeID)
+			if tc.status == "FAILED" {
+				assert.Equal(t, "backend set workers not found", result.ProgressResult.StatusMessage)
+			}
+		})
+	}
+}
+
+func TestNLBListenerList(t *testing.T) {
+	svc := newTestNetworkLoadBalancerClient(t, map[route]canned{
+		{"GET", "/20200501/networkLoadBalancers/ocid1.networkloadbalancer..aaa"}: {200, newTestNetworkLoadBalancerBody("ACTIVE")},
+	})
+	p := networkloadbalancer.NewListenerProvisionerWithSvc(svc)
+
+	result, err := p.List(context.Background(), &resource.ListRequest{
+		ResourceType:         "OCI::NetworkLoadBalancer::Listener",
+		AdditionalProperties: map[string]string{"NetworkLoadBalancerId": "ocid1.networkloadbalancer..aaa"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ocid1.networkloadbalancer..aaa/tcp", "ocid1.networkloadbalancer..aaa/udp"}, result.NativeIDs)
+}
+
+// Helpers
+
+func newTestNetworkLoadBalancerClient(t *testing.T, responses map[route]canned) *ocinetworkloadbalancer.NetworkLoadBalancerClient {
+	t.Helper()
+	host := newTestDispatcher(t, responses)
+	c, err := ocinetworkloadbalancer.NewNetworkLoadBalancerClientWithConfigurationProvider(fakeOCIConfigProvider(t))
+	require.NoError(t, err)
+	applyTestRetryPolicy(&c)
+	c.Host = host
+	return &c
+}
+
+func newTestNetworkLoadBalancerBody(lifecycleState string) string {
+	return fmt.Sprintf(`{
+		"id": "ocid1.networkloadbalancer..aaa",
+		"compartmentId": "ocid1.compartment..xxx",
+		"displayName": "test-nlb",
+		"lifecycleState": %q,
+		"timeCreated": "2025-01-01T00:00:00.000Z",
+		"ipAddresses": [],
+		"subnetId": "ocid1.subnet..aaa",
+		"listeners": {
+			"udp": {"name": "udp", "defaultBackendSetName": "workers", "port": 53, "protocol": "UDP"},
+			"tcp": {"name": "tcp", "defaultBackendSetName": "workers", "port": 443, "protocol": "TCP"}
+		},
+		"backendSets": {"workers": %s}
+	}`, lifecycleState, newTestNLBBackendSetBody())
+}
+
+func newTestNLBWorkRequestBody(status string) string {
+	return fmt.Sprintf(`{
+		"id": "ocid1.nlbworkrequest..aaa",
+		"compartmentId": "ocid1.compartment..xxx",
+		"operationType": "CREATE_LISTENER",
+		"status": %q,
+		"resources": [],
+		"percentComplete": 100,
+		"timeAccepted": "2025-01-01T00:00:00.000Z"
+	}`, status)
+}
@@ -0,0 +1,115 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build integration
+
+package provisioner_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	ocicontainerengine "github.com/oracle/oci-go-sdk/v65/containerengine"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/containerengine"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodePoolCreate_ValidatesShapeConfig(t *testing.T) {
+	host, shapeLists := newTestShapesServer(t)
+	p := containerengine.NewNodePoolProvisionerWithSvc(newTestContainerEngineClientAt(t, host), newTestComputeClientAt(t, host))
+
+	// The flex shape is on the second page of ListShapes and allows 1-4 OCPUs
+	_, err := p.Create(context.Background(), &resource.CreateRequest{
+		ResourceType: "OCI::ContainerEngine::NodePool",
+		Properties:   newTestNodePoolProps(t, 8),
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "shape VM.Standard.E4.Flex allows 1-4 OCPUs, got 8")
+	assert.Equal(t, int32(2), shapeLists.Load(), "both pages of shapes should be listed")
+
+	result, err := p.Create(context.Background(), &resource.CreateRequest{
+		ResourceType: "OCI::ContainerEngine::NodePool",
+		Properties:   newTestNodePoolProps(t, 2),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+	assert.Equal(t, "ocid1.workrequest..aaa", result.ProgressResult.RequestID)
+	assert.Equal(t, int32(2), shapeLists.Load(), "the second create should use the cached shapes")
+}
+
+func TestNodePoolCreate_SkipShapeValidation(t *testing.T) {
+	host, shapeLists := newTestShapesServer(t)
+	p := containerengine.NewNodePoolProvisionerWithSvc(newTestContainerEngineClientAt(t, host), newTestComputeClientAt(t, host))
+
+	result, err := p.Create(context.Background(), &resource.CreateRequest{
+		ResourceType: "OCI::ContainerEngine::NodePool",
+		Properties:   newTestNodePoolProps(t, 8),
+		TargetConfig: json.RawMessage(`{"SkipShapeValidation": true}`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+	assert.Zero(t, shapeLists.Load())
+}
+
+// Helpers
+
+// newTestShapesServer serves ListShapes in two pages, with the flex shape on
+// the second, and accepts CreateNodePool. It counts ListShapes requests.
+func newTestShapesServer(t *testing.T) (string, *atomic.Int32) {
+	t.Helper()
+	var shapeLists atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/20160918/shapes":
+			shapeLists.Add(1)
+			if r.URL.Query().Get("page") == "" {
+				w.Header().Set("opc-next-page", "page-1")
+				fmt.Fprint(w, `[{"shape": "VM.Standard2.1"}]`)
+				return
+			}
+			fmt.Fprint(w, `[{"shape": "VM.Standard.E4.Flex",
+				"ocpuOptions": {"min": 1, "max": 4},
+				"memoryOptions": {"minInGBs": 1, "maxInGBs": 64, "minPerOcpuInGBs": 1, "maxPerOcpuInGBs": 64}}]`)
+		case r.Method == "POST" && r.URL.Path == "/20180222/nodePools":
+			w.Header().Set("opc-work-request-id", "ocid1.workrequest..aaa")
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL, &shapeLists
+}
+
+func newTestNodePoolProps(t *testing.T, ocpus int) json.RawMessage {
+	t.Helper()
+	props, err := json.Marshal(map[string]any{
+		"CompartmentId":     "ocid1.compartment..xxx",
+		"ClusterId":         "ocid1.cluster..aaa",
+		"Name":              "workers",
+		"NodeShape":         "VM.Standard.E4.Flex",
+		"NodeShapeConfig":   map[string]any{"ocpus": ocpus, "memoryInGBs": 16},
+		"NodeSourceDetails": map[string]any{"sourceType": "IMAGE", "imageId": "ocid1.image..aaa"},
+	})
+	require.NoError(t, err)
+	return props
+}
+
+func newTestContainerEngineClientAt(t *testing.T, host string) *ocicontainerengine.ContainerEngineClient {
+	t.Helper()
+	c, err := ocicontainerengine.NewContainerEngineClientWithConfigurationProvider(fakeOCIConfigProvider(t))
+	require.NoError(t, err)
+	applyTestRetryPolicy(&c)
+	c.Host = host
+	return &c
+}
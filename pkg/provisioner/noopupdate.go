@@ -0,0 +1,104 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package provisioner
+
+import (
+	"context"
+	"encoding/json"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+// noOpUpdate is a decorator that short-circuits an Update whose patch document
+// leaves the resource as it is. Resubmitting unchanged properties still costs a
+// rate-limited write, and on some services bumps etags or timestamps that then
+// show up as drift.
+//
+// The patch is applied to a fresh Read, the same way util.ApplyPatchDocument
+// does, and both documents are compared as canonical JSON. A patch that touches
+// a write-only field never matches the Read, so it always goes through. Updates
+// without a patch document carry the full desired state, write-only fields and
+// all, and are passed through untouched.
+//
+// It sits outside readAfterWrite, so a skipped Update reports the properties
+// from its own Read instead of triggering a second one, and outside
+// replaceOnChange, so a patch that changes nothing is never refused.
+type noOpUpdate struct {
+	inner Provisioner
+}
+
+func (n *noOpUpdate) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	return n.inner.Create(ctx, request)
+}
+
+func (n *noOpUpdate) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	if request.PatchDocument == nil || *request.PatchDocument == "" {
+		return n.inner.Update(ctx, request)
+	}
+
+	// The Reads ahead of the write, ours included, share one call (see sharedRead).
+	ctx = util.WithReadCache(ctx)
+
+	// Anything unexpected falls through to the real Update, which reports it.
+	existing, ok := n.unchangedBy(ctx, request)
+	if !ok {
+		return n.inner.Update(ctx, request)
+	}
+
+	return &resource.UpdateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:          resource.OperationUpdate,
+			OperationStatus:    resource.OperationStatusSuccess,
+			NativeID:           request.NativeID,
+			ResourceProperties: json.RawMessage(existing),
+		},
+	}, nil
+}
+
+func (n *noOpUpdate) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	return n.inner.Delete(ctx, request)
+}
+
+func (n *noOpUpdate) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	return n.inner.Status(ctx, request)
+}
+
+func (n *noOpUpdate) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	return n.inner.Read(ctx, request)
+}
+
+func (n *noOpUpdate) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	return n.inner.List(ctx, request)
+}
+
+// unchangedBy reports whether request's patch leaves the current properties as
+// they are, returning those properties when it does.
+func (n *noOpUpdate) unchangedBy(ctx context.Context, request *resource.UpdateRequest) (string, bool) {
+	readResult, err := n.inner.Read(ctx, &resource.ReadRequest{
+		NativeID:     request.NativeID,
+		ResourceType: request.ResourceType,
+		TargetConfig: request.TargetConfig,
+	})
+	if err != nil || readResult == nil || readResult.ErrorCode != "" || readResult.Properties == "" {
+		return "", false
+	}
+
+	patch, err := jsonpatch.DecodePatch([]byte(*request.PatchDocument))
+	if err != nil {
+		return "", false
+	}
+	existing := []byte(readResult.Properties)
+	patched, err := patch.Apply(existing)
+	if err != nil {
+		return "", false
+	}
+	if !jsonpatch.Equal(existing, patched) {
+		return "", false
+	}
+
+	return readResult.Properties, true
+}
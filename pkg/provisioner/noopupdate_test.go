@@ -0,0 +1,138 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package provisioner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+func newNoOpUpdateMock(properties string) *mockProvisioner {
+	return &mockProvisioner{
+		updateResult: &resource.UpdateResult{
+			ProgressResult: &resource.ProgressResult{
+				OperationStatus: resource.OperationStatusInProgress,
+				NativeID:        "ocid1.volume.oc1..abc",
+			},
+		},
+		readResult: &resource.ReadResult{Properties: properties},
+	}
+}
+
+func noOpUpdateRequest(resourceType, patch string) *resource.UpdateRequest {
+	return &resource.UpdateRequest{
+		NativeID:      "ocid1.volume.oc1..abc",
+		ResourceType:  resourceType,
+		PatchDocument: &patch,
+	}
+}
+
+func TestNoOpUpdate_Volume_SameValueSkipped(t *testing.T) {
+	const volume = `{"Id":"ocid1.volume.oc1..abc","DisplayName":"data","SizeInGBs":50,"FreeformTags":[{"Key":"env","Value":"prod"}]}`
+	inner := newNoOpUpdateMock(volume)
+
+	n := &noOpUpdate{inner: inner}
+	result, err := n.Update(context.Background(), noOpUpdateRequest("OCI::Core::Volume",
+		`[{"op":"replace","path":"/DisplayName","value":"data"},{"op":"replace","path":"/SizeInGBs","value":50}]`))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if inner.updateCalled {
+		t.Fatal("expected Update to be skipped for a patch that changes nothing")
+	}
+	if result.ProgressResult.OperationStatus != resource.OperationStatusSuccess {
+		t.Fatalf("expected Success, got %s", result.ProgressResult.OperationStatus)
+	}
+	if got := string(result.ProgressResult.ResourceProperties); got != volume {
+		t.Fatalf("expected properties from Read, got %s", got)
+	}
+}
+
+func TestNoOpUpdate_Subnet_ReorderedKeysSkipped(t *testing.T) {
+	inner := newNoOpUpdateMock(`{"Id":"ocid1.subnet.oc1..abc","CidrBlock":"10.0.1.0/24","DhcpOptions":{"domainNameType":"CUSTOM","searchDomain":"example.com"}}`)
+
+	n := &noOpUpdate{inner: inner}
+	_, err := n.Update(context.Background(), noOpUpdateRequest("OCI::Core::Subnet",
+		`[{"op":"add","path":"/DhcpOptions","value":{"searchDomain":"example.com","domainNameType":"CUSTOM"}}]`))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if inner.updateCalled {
+		t.Fatal("expected Update to be skipped when only key order differs")
+	}
+}
+
+func TestNoOpUpdate_ChangePassesThrough(t *testing.T) {
+	inner := newNoOpUpdateMock(`{"Id":"ocid1.volume.oc1..abc","DisplayName":"data"}`)
+
+	n := &noOpUpdate{inner: inner}
+	result, err := n.Update(context.Background(), noOpUpdateRequest("OCI::Core::Volume",
+		`[{"op":"replace","path":"/DisplayName","value":"renamed"}]`))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !inner.updateCalled {
+		t.Fatal("expected Update to be called for a real change")
+	}
+	if result.ProgressResult.OperationStatus != resource.OperationStatusInProgress {
+		t.Fatalf("expected the inner result, got %s", result.ProgressResult.OperationStatus)
+	}
+}
+
+func TestNoOpUpdate_WriteOnlyFieldPassesThrough(t *testing.T) {
+	inner := newNoOpUpdateMock(`{"Id":"ocid1.vaultsecret.oc1..abc","SecretName":"db-password"}`)
+
+	n := &noOpUpdate{inner: inner}
+	_, err := n.Update(context.Background(), noOpUpdateRequest("OCI::Vault::Secret",
+		`[{"op":"add","path":"/SecretContent","value":"c2VjcmV0"}]`))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !inner.updateCalled {
+		t.Fatal("expected Update to be called for a write-only field")
+	}
+}
+
+func TestNoOpUpdate_NoPatchPassesThrough(t *testing.T) {
+	inner := newNoOpUpdateMock(`{"Id":"ocid1.volume.oc1..abc"}`)
+
+	n := &noOpUpdate{inner: inner}
+	_, err := n.Update(context.Background(), &resource.UpdateRequest{
+		NativeID:          "ocid1.volume.oc1..abc",
+		ResourceType:      "OCI::Core::Volume",
+		DesiredProperties: []byte(`{"DisplayName":"data"}`),
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if inner.readCalled {
+		t.Fatal("expected no Read without a patch document")
+	}
+	if !inner.updateCalled {
+		t.Fatal("expected Update to be called without a patch document")
+	}
+}
+
+func TestNoOpUpdate_ReadNotFoundPassesThrough(t *testing.T) {
+	inner := newNoOpUpdateMock("")
+	inner.readResult = &resource.ReadResult{ErrorCode: resource.OperationErrorCodeNotFound}
+
+	n := &noOpUpdate{inner: inner}
+	_, err := n.Update(context.Background(), noOpUpdateRequest("OCI::Core::Volume", `[]`))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !inner.updateCalled {
+		t.Fatal("expected Update to report a missing resource itself")
+	}
+}
@@ -10,9 +10,17 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 
+	ocicore "github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/core"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -86,20 +94,257 @@ func TestNSGSecurityRuleCreate(t *testing.T) {
 	assert.Equal(t, "ocid1.nsg..aaa/rule-001", result.ProgressResult.NativeID)
 }
 
-func TestNSGSecurityRuleUpdate(t *testing.T) {
-	svc := newTestVirtualNetworkClient(t, map[route]canned{})
+func TestNSGSecurityRuleIcmpTypeOnly(t *testing.T) {
+	icmpRule := `{
+		"id": "rule-002",
+		"direction": "INGRESS",
+		"protocol": "1",
+		"source": "0.0.0.0/0",
+		"sourceType": "CIDR_BLOCK",
+		"icmpOptions": {"type": 8},
+		"isValid": true
+	}`
+	svc := newTestVirtualNetworkClient(t, map[route]canned{
+		{"POST", "/20160918/networkSecurityGroups/ocid1.nsg..aaa/actions/addSecurityRules"}: {200, fmt.Sprintf(`{"securityRules": [%s]}`, icmpRule)},
+		{"GET", "/20160918/networkSecurityGroups/ocid1.nsg..aaa/securityRules"}:             {200, fmt.Sprintf(`[%s]`, icmpRule)},
+	})
 	p := core.NewNetworkSecurityGroupSecurityRuleProvisionerWithSvc(svc)
 
-	props, err := json.Marshal(map[string]any{"Description": "updated"})
+	props, err := json.Marshal(map[string]any{
+		"NetworkSecurityGroupId": "ocid1.nsg..aaa",
+		"Direction":              "INGRESS",
+		"Protocol":               "1",
+		"Source":                 "0.0.0.0/0",
+		"IcmpOptions":            map[string]any{"type": 8},
+	})
 	require.NoError(t, err)
 
-	_, err = p.Update(context.Background(), &resource.UpdateRequest{
-		NativeID:          "ocid1.nsg..aaa/rule-001",
-		ResourceType:      "OCI::Core::NetworkSecurityGroupSecurityRule",
-		DesiredProperties: props,
+	result, err := p.Create(context.Background(), &resource.CreateRequest{
+		ResourceType: "OCI::Core::NetworkSecurityGroupSecurityRule",
+		Properties:   props,
+	})
+	require.NoError(t, err)
+	require.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+
+	read, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: result.ProgressResult.NativeID})
+	require.NoError(t, err)
+	var readProps map[string]any
+	require.NoError(t, json.Unmarshal([]byte(read.Properties), &readProps))
+	assert.Equal(t, map[string]any{"type": float64(8)}, readProps["IcmpOptions"])
+}
+
+func TestNSGSecurityRuleCreateAllProtocolRejectsOptions(t *testing.T) {
+	p := core.NewNetworkSecurityGroupSecurityRuleProvisionerWithSvc(newTestVirtualNetworkClient(t, nil))
+
+	props, err := json.Marshal(map[string]any{
+		"NetworkSecurityGroupId": "ocid1.nsg..aaa",
+		"Direction":              "EGRESS",
+		"Protocol":               "all",
+		"Destination":            "0.0.0.0/0",
+		"TcpOptions": map[string]any{
+			"destinationPortRange": map[string]any{"min": 443, "max": 443},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = p.Create(context.Background(), &resource.CreateRequest{
+		ResourceType: "OCI::Core::NetworkSecurityGroupSecurityRule",
+		Properties:   props,
+	})
+	assert.ErrorContains(t, err, `protocol "all"`)
+}
+
+func TestNSGSecurityRuleNsgSource(t *testing.T) {
+	nsgRule := `{
+		"id": "rule-003",
+		"direction": "INGRESS",
+		"protocol": "6",
+		"source": "ocid1.networksecuritygroup.oc1.phx.bbb",
+		"sourceType": "NETWORK_SECURITY_GROUP",
+		"isValid": true
+	}`
+	svc := newTestVirtualNetworkClient(t, map[route]canned{
+		{"POST", "/20160918/networkSecurityGroups/ocid1.nsg..aaa/actions/addSecurityRules"}: {200, fmt.Sprintf(`{"securityRules": [%s]}`, nsgRule)},
+		{"GET", "/20160918/networkSecurityGroups/ocid1.nsg..aaa/securityRules"}:             {200, fmt.Sprintf(`[%s]`, nsgRule)},
+	})
+	p := core.NewNetworkSecurityGroupSecurityRuleProvisionerWithSvc(svc)
+
+	props, err := json.Marshal(map[string]any{
+		"NetworkSecurityGroupId": "ocid1.nsg..aaa",
+		"Direction":              "INGRESS",
+		"Protocol":               "6",
+		"Source":                 map[string]any{"$ref": "formae://app-nsg#/Id", "$value": "ocid1.networksecuritygroup.oc1.phx.bbb"},
+		"SourceType":             "NETWORK_SECURITY_GROUP",
+	})
+	require.NoError(t, err)
+
+	result, err := p.Create(context.Background(), &resource.CreateRequest{
+		ResourceType: "OCI::Core::NetworkSecurityGroupSecurityRule",
+		Properties:   props,
+	})
+	require.NoError(t, err)
+	require.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+
+	read, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: result.ProgressResult.NativeID})
+	require.NoError(t, err)
+	var readProps map[string]any
+	require.NoError(t, json.Unmarshal([]byte(read.Properties), &readProps))
+	assert.Equal(t, "ocid1.networksecuritygroup.oc1.phx.bbb", readProps["Source"])
+	assert.Equal(t, "NETWORK_SECURITY_GROUP", readProps["SourceType"])
+}
+
+func TestNSGSecurityRuleCreateRejectsMismatchedNsgSource(t *testing.T) {
+	p := core.NewNetworkSecurityGroupSecurityRuleProvisionerWithSvc(newTestVirtualNetworkClient(t, nil))
+
+	props, err := json.Marshal(map[string]any{
+		"NetworkSecurityGroupId": "ocid1.nsg..aaa",
+		"Direction":              "INGRESS",
+		"Protocol":               "6",
+		"Source":                 "ocid1.networksecuritygroup.oc1.phx.bbb",
+		"SourceType":             "CIDR_BLOCK",
+	})
+	require.NoError(t, err)
+
+	_, err = p.Create(context.Background(), &resource.CreateRequest{
+		ResourceType: "OCI::Core::NetworkSecurityGroupSecurityRule",
+		Properties:   props,
+	})
+	assert.ErrorContains(t, err, "needs SourceType NETWORK_SECURITY_GROUP")
+}
+
+func TestNSGSecurityRuleCreateRejectsIngressDestination(t *testing.T) {
+	p := core.NewNetworkSecurityGroupSecurityRuleProvisionerWithSvc(newTestVirtualNetworkClient(t, nil))
+
+	props, err := json.Marshal(map[string]any{
+		"NetworkSecurityGroupId": "ocid1.nsg..aaa",
+		"Direction":              "INGRESS",
+		"Protocol":               "6",
+		"Source":                 "10.0.0.0/16",
+		"SourceType":             "CIDR_BLOCK",
+		"Destination":            "0.0.0.0/0",
+		"DestinationType":        "CIDR_BLOCK",
+	})
+	require.NoError(t, err)
+
+	_, err = p.Create(context.Background(), &resource.CreateRequest{
+		ResourceType: "OCI::Core::NetworkSecurityGroupSecurityRule",
+		Properties:   props,
+	})
+	assert.EqualError(t, err, "Destination cannot be set on an INGRESS rule")
+}
+
+// TestNSGSecurityRuleReadBatchListsOnce reads all 20 rules of an NSG, split
+// over two pages, the way discovery does. Without the batch's read cache that
+// is 40 list calls (two pages per Read); with it, two.
+func TestNSGSecurityRuleReadBatchListsOnce(t *testing.T) {
+	const ruleCount = 20
+	pages := [2][]string{}
+	for i := range ruleCount {
+		pages[i%2] = append(pages[i%2], fmt.Sprintf(`{"id": "rule-%03d", "direction": "INGRESS", "protocol": "all", "source": "0.0.0.0/0"}`, i))
+	}
+
+	var listCalls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/20160918/networkSecurityGroups/ocid1.nsg..aaa/securityRules" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		listCalls.Add(1)
+		page := 0
+		if r.URL.Query().Get("page") == "page-1" {
+			page = 1
+		} else {
+			w.Header().Set("opc-next-page", "page-1")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, "[%s]", strings.Join(pages[page], ","))
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := ocicore.NewVirtualNetworkClientWithConfigurationProvider(fakeOCIConfigProvider(t))
+	require.NoError(t, err)
+	applyTestRetryPolicy(&c)
+	c.Host = srv.URL
+	p := core.NewNetworkSecurityGroupSecurityRuleProvisionerWithSvc(&c)
+
+	requests := make([]*resource.ReadRequest, ruleCount)
+	for i := range requests {
+		requests[i] = &resource.ReadRequest{NativeID: fmt.Sprintf("ocid1.nsg..aaa/rule-%03d", i)}
+	}
+	results, errs := provisioner.ReadBatch(util.WithReadCache(context.Background()), requests, 4, p.Read)
+
+	for i := range requests {
+		require.NoError(t, errs[i])
+		assert.Empty(t, results[i].ErrorCode, requests[i].NativeID)
+	}
+	assert.Equal(t, int32(2), listCalls.Load())
+}
+
+func TestNSGSecurityRuleUpdate(t *testing.T) {
+	const updatePath = "/20160918/networkSecurityGroups/ocid1.nsg..aaa/actions/updateSecurityRules"
+
+	desired := func(t *testing.T) json.RawMessage {
+		props, err := json.Marshal(map[string]any{
+			"NetworkSecurityGroupId": "ocid1.nsg..aaa",
+			"Direction":              "INGRESS",
+			"Protocol":               "6",
+			"Source":                 "10.0.0.0/16",
+			"SourceType":             "CIDR_BLOCK",
+			"Description":            "updated",
+			"TcpOptions":             map[string]any{"destinationPortRange": map[string]any{"min": 443, "max": 443}},
+		})
+		require.NoError(t, err)
+		return props
+	}
+
+	t.Run("keeps_rule_id", func(t *testing.T) {
+		var sent string
+		host := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != "POST" || r.URL.Path != updatePath {
+				http.NotFound(w, r)
+				return
+			}
+			body := new(strings.Builder)
+			_, _ = io.Copy(body, r.Body)
+			sent = body.String()
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"securityRules": [{
+				"id": "rule-001", "direction": "INGRESS", "protocol": "6",
+				"source": "10.0.0.0/16", "sourceType": "CIDR_BLOCK", "description": "updated",
+				"tcpOptions": {"destinationPortRange": {"min": 443, "max": 443}}
+			}]}`))
+		}))
+		t.Cleanup(host.Close)
+		svc := newTestVirtualNetworkClientAt(t, host.URL)
+		p := core.NewNetworkSecurityGroupSecurityRuleProvisionerWithSvc(svc)
+
+		result, err := p.Update(context.Background(), &resource.UpdateRequest{
+			NativeID:          "ocid1.nsg..aaa/rule-001",
+			ResourceType:      "OCI::Core::NetworkSecurityGroupSecurityRule",
+			DesiredProperties: desired(t),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+		assert.Equal(t, "ocid1.nsg..aaa/rule-001", result.ProgressResult.NativeID)
+		assert.Contains(t, sent, `"id":"rule-001"`)
+		assert.Contains(t, sent, `"description":"updated"`)
+	})
+
+	t.Run("rejects_mismatched_result", func(t *testing.T) {
+		// OCI applied the rule without the requested port range
+		svc := newTestVirtualNetworkClient(t, map[route]canned{
+			{"POST", updatePath}: {200, fmt.Sprintf(`{"securityRules": [%s]}`, newTestNSGSecurityRuleBody())},
+		})
+		p := core.NewNetworkSecurityGroupSecurityRuleProvisionerWithSvc(svc)
+
+		_, err := p.Update(context.Background(), &resource.UpdateRequest{
+			NativeID:          "ocid1.nsg..aaa/rule-001",
+			ResourceType:      "OCI::Core::NetworkSecurityGroupSecurityRule",
+			DesiredProperties: desired(t),
+		})
+		assert.ErrorContains(t, err, "updated rule validation failed")
 	})
-	require.Error(t, err, "Update should return an error for NSG security rules")
-	assert.Contains(t, err.Error(), "update not supported")
 }
 
 func TestNSGSecurityRuleDelete(t *testing.T) {
@@ -129,6 +374,27 @@ func TestNSGSecurityRuleList(t *testing.T) {
 	assert.Equal(t, []string{"ocid1.nsg..aaa/rule-001"}, result.NativeIDs)
 }
 
+func TestNSGSecurityRuleListPaginated(t *testing.T) {
+	secondRule := strings.Replace(newTestNSGSecurityRuleBody(), "rule-001", "rule-002", 1)
+	svc := newTestVirtualNetworkClientAt(t, newTestPagedDispatcher(t, nil, map[route][]canned{
+		{"GET", "/20160918/networkSecurityGroups/ocid1.nsg..aaa/securityRules"}: {
+			{200, fmt.Sprintf(`[%s]`, newTestNSGSecurityRuleBody())},
+			{200, fmt.Sprintf(`[%s]`, secondRule)},
+		},
+	}))
+	p := core.NewNetworkSecurityGroupSecurityRuleProvisionerWithSvc(svc)
+
+	result, err := p.List(context.Background(), &resource.ListRequest{
+		ResourceType: "OCI::Core::NetworkSecurityGroupSecurityRule",
+		AdditionalProperties: map[string]string{
+			"NetworkSecurityGroupId": "ocid1.nsg..aaa",
+		},
+		TargetConfig: json.RawMessage(`{"ListPageSize":1}`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ocid1.nsg..aaa/rule-001", "ocid1.nsg..aaa/rule-002"}, result.NativeIDs)
+}
+
 // Helpers
 
 func newTestNSGSecurityRuleBody() string {
@@ -0,0 +1,212 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build integration
+
+package provisioner_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/objectstorage"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectRead(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc := newTestObjectStorageClient(t, map[route]canned{
+			{"HEAD", "/n/testnamespace/b/test-bucket/o/app.json"}: {200, ""},
+		})
+		p := objectstorage.NewObjectProvisionerWithSvc(svc)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "test-bucket/app.json"})
+		require.NoError(t, err)
+		assert.Empty(t, result.ErrorCode)
+
+		var props map[string]any
+		require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+		assert.Equal(t, "test-bucket", props["BucketName"])
+		assert.Equal(t, "app.json", props["ObjectName"])
+	})
+
+	t.Run("content_md5", func(t *testing.T) {
+		svc := newTestObjectStorageClientAt(t, newTestHeaderDispatcher(t, map[route]canned{
+			{"GET", "/n"}: {200, `"testnamespace"`},
+			{"HEAD", "/n/testnamespace/b/test-bucket/o/config/app.json"}: {200, ""},
+		}, map[route]map[string]string{
+			{"HEAD", "/n/testnamespace/b/test-bucket/o/config/app.json"}: {"content-md5": testObjectMd5},
+		}))
+		p := objectstorage.NewObjectProvisionerWithSvc(svc)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "test-bucket/config%2Fapp.json"})
+		require.NoError(t, err)
+
+		var props map[string]any
+		require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+		assert.Equal(t, "config/app.json", props["ObjectName"])
+		assert.Equal(t, testObjectMd5, props["ContentMd5"])
+		assert.NotContains(t, props, "Content")
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		svc := newTestObjectStorageClient(t, map[route]canned{
+			{"HEAD", "/n/testnamespace/b/test-bucket/o/missing.json"}: {404, ""},
+		})
+		p := objectstorage.NewObjectProvisionerWithSvc(svc)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "test-bucket/missing.json"})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationErrorCodeNotFound, result.ErrorCode)
+	})
+
+	t.Run("invalid_native_id", func(t *testing.T) {
+		svc := newTestObjectStorageClient(t, map[route]canned{})
+		p := objectstorage.NewObjectProvisionerWithSvc(svc)
+
+		_, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "test-bucket"})
+		require.Error(t, err)
+	})
+}
+
+func TestObjectCreate(t *testing.T) {
+	t.Run("plain_content", func(t *testing.T) {
+		svc := newTestObjectStorageClient(t, map[route]canned{
+			{"PUT", "/n/testnamespace/b/test-bucket/o/app.json"}: {200, ""},
+		})
+		p := objectstorage.NewObjectProvisionerWithSvc(svc)
+
+		props, err := json.Marshal(map[string]any{
+			"BucketName":  "test-bucket",
+			"ObjectName":  "app.json",
+			"Namespace":   "testnamespace",
+			"Content":     `{"debug": true}`,
+			"ContentType": "application/json",
+		})
+		require.NoError(t, err)
+
+		result, err := p.Create(context.Background(), &resource.CreateRequest{
+			ResourceType: "OCI::ObjectStorage::Object",
+			Properties:   props,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+		assert.Equal(t, "test-bucket/app.json", result.ProgressResult.NativeID)
+	})
+
+	t.Run("both_content_fields", func(t *testing.T) {
+		svc := newTestObjectStorageClient(t, map[route]canned{})
+		p := objectstorage.NewObjectProvisionerWithSvc(svc)
+
+		props, err := json.Marshal(map[string]any{
+			"BucketName":    "test-bucket",
+			"ObjectName":    "app.json",
+			"Content":       "plain",
+			"ContentBase64": "cGxhaW4=",
+		})
+		require.NoError(t, err)
+
+		_, err = p.Create(context.Background(), &resource.CreateRequest{
+			ResourceType: "OCI::ObjectStorage::Object",
+			Properties:   props,
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "only one of Content or ContentBase64")
+	})
+}
+
+func TestObjectUpdate_UsesDesiredContent(t *testing.T) {
+	var uploaded string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/n":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `"testnamespace"`)
+		case r.Method == http.MethodHead:
+			w.Header().Set("content-md5", testObjectMd5)
+		case r.Method == http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			uploaded = string(body)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	p := objectstorage.NewObjectProvisionerWithSvc(newTestObjectStorageClientAt(t, srv.URL))
+
+	patch := `[{"op":"replace","path":"/Content","value":"{\"debug\": false}"}]`
+	result, err := p.Update(context.Background(), &resource.UpdateRequest{
+		NativeID:          "test-bucket/app.json",
+		ResourceType:      "OCI::ObjectStorage::Object",
+		PatchDocument:     &patch,
+		DesiredProperties: []byte(`{"BucketName": "test-bucket", "ObjectName": "app.json", "Content": "{\"debug\": false}"}`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	assert.Equal(t, `{"debug": false}`, uploaded)
+}
+
+func TestObjectDelete(t *testing.T) {
+	svc := newTestObjectStorageClient(t, map[route]canned{
+		{"HEAD", "/n/testnamespace/b/test-bucket/o/app.json"}:   {200, ""},
+		{"DELETE", "/n/testnamespace/b/test-bucket/o/app.json"}: {204, ""},
+	})
+	p := objectstorage.NewObjectProvisionerWithSvc(svc)
+
+	result, err := p.Delete(context.Background(), &resource.DeleteRequest{NativeID: "test-bucket/app.json"})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+}
+
+func TestObjectList(t *testing.T) {
+	svc := newTestObjectStorageClient(t, map[route]canned{
+		{"GET", "/n/testnamespace/b/test-bucket/o"}: {200, `{"objects": [{"name": "app.json"}]}`},
+	})
+	p := objectstorage.NewObjectProvisionerWithSvc(svc)
+
+	result, err := p.List(context.Background(), &resource.ListRequest{
+		ResourceType:         "OCI::ObjectStorage::Object",
+		AdditionalProperties: map[string]string{"BucketName": "test-bucket"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test-bucket/app.json"}, result.NativeIDs)
+}
+
+func TestObjectListPaginated(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/n":
+			fmt.Fprint(w, `"testnamespace"`)
+		case "/n/testnamespace/b/test-bucket/o":
+			if r.URL.Query().Get("start") == "" {
+				fmt.Fprint(w, `{"objects": [{"name": "app.json"}], "nextStartWith": "config/db.json"}`)
+				return
+			}
+			assert.Equal(t, "config/db.json", r.URL.Query().Get("start"))
+			fmt.Fprint(w, `{"objects": [{"name": "config/db.json"}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	p := objectstorage.NewObjectProvisionerWithSvc(newTestObjectStorageClientAt(t, srv.URL))
+
+	result, err := p.List(context.Background(), &resource.ListRequest{
+		ResourceType:         "OCI::ObjectStorage::Object",
+		AdditionalProperties: map[string]string{"BucketName": "test-bucket"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test-bucket/app.json", "test-bucket/config%2Fdb.json"}, result.NativeIDs)
+}
+
+// md5 of {"debug": true}
+const testObjectMd5 = "1twegl3GXCNKE01oH8mDiA=="
@@ -12,6 +12,7 @@ import (
 	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/oracle/oci-go-sdk/v65/objectstorage"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
@@ -22,10 +23,14 @@ type BucketProvisioner struct {
 	svc     *objectstorage.ObjectStorageClient // nil until first use; injected in tests
 }
 
-var _ provisioner.Provisioner = &BucketProvisioner{}
+var (
+	_ provisioner.Provisioner = &BucketProvisioner{}
+	_ provisioner.Immutable   = &BucketProvisioner{}
+)
 
 func init() {
 	provisioner.Register("OCI::ObjectStorage::Bucket", NewBucketProvisioner)
+	provisioner.DependsOn("OCI::ObjectStorage::Bucket", "OCI::Identity::Compartment")
 }
 
 func NewBucketProvisioner(clients *client.Clients) provisioner.Provisioner {
@@ -38,6 +43,12 @@ func NewBucketProvisionerWithSvc(svc *objectstorage.ObjectStorageClient) *Bucket
 	return &BucketProvisioner{svc: svc}
 }
 
+// ImmutableFields lists what UpdateBucket can't change: a bucket is addressed
+// by namespace and name, and can't move between storage tiers.
+func (p *BucketProvisioner) ImmutableFields() []string {
+	return []string{"Name", "Namespace", "StorageTier"}
+}
+
 func (p *BucketProvisioner) getSvc() (*objectstorage.ObjectStorageClient, error) {
 	if p.svc != nil {
 		return p.svc, nil
@@ -47,7 +58,7 @@ func (p *BucketProvisioner) getSvc() (*objectstorage.ObjectStorageClient, error)
 
 // getNamespace fetches the Object Storage namespace for the tenancy.
 // If namespace is provided in props, it returns that; otherwise fetches dynamically.
-func (p *BucketProvisioner) getNamespace(ctx context.Context, client *objectstorage.ObjectStorageClient, props map[string]any) (string, error) {
+func getNamespace(ctx context.Context, client *objectstorage.ObjectStorageClient, props map[string]any) (string, error) {
 	if ns, ok := util.ExtractString(props, "Namespace"); ok && ns != "" {
 		return ns, nil
 	}
@@ -69,7 +80,7 @@ func (p *BucketProvisioner) Create(ctx context.Context, request *resource.Create
 		return nil, fmt.Errorf("failed to parse properties: %w", err)
 	}
 
-	namespace, err := p.getNamespace(ctx, client, props)
+	namespace, err := getNamespace(ctx, client, props)
 	if err != nil {
 		return nil, err
 	}
@@ -88,6 +99,12 @@ func (p *BucketProvisioner) Create(ctx context.Context, request *resource.Create
 	if objectEventsEnabled, ok := util.ExtractBool(props, "ObjectEventsEnabled"); ok {
 		createDetails.ObjectEventsEnabled = common.Bool(objectEventsEnabled)
 	}
+	if autoTiering, ok := util.ExtractString(props, "AutoTiering"); ok {
+		createDetails.AutoTiering = objectstorage.BucketAutoTieringEnum(autoTiering)
+	}
+	if kmsKeyId, ok := util.ExtractString(props, "KmsKeyId"); ok {
+		createDetails.KmsKeyId = common.String(kmsKeyId)
+	}
 	if versioning, ok := util.ExtractString(props, "Versioning"); ok {
 		createDetails.Versioning = objectstorage.CreateBucketDetailsVersioningEnum(versioning)
 	}
@@ -105,12 +122,37 @@ func (p *BucketProvisioner) Create(ctx context.Context, request *resource.Create
 
 	resp, err := client.CreateBucket(ctx, createReq)
 	if err != nil {
+		if config.FromTargetConfig(request.TargetConfig).AdoptsExisting(false) && util.IsNameConflict(err) {
+			if adopted := adoptExistingBucket(ctx, client, namespace, *createDetails.Name, *createDetails.CompartmentId); adopted {
+				return &resource.CreateResult{
+					ProgressResult: &resource.ProgressResult{
+						Operation:       resource.OperationCreate,
+						OperationStatus: resource.OperationStatusSuccess,
+						NativeID:        *createDetails.Name,
+					},
+				}, nil
+			}
+		}
 		if result, handleErr := util.HandleCreateError(err, "OCI::ObjectStorage::Bucket", "OCI::ObjectStorage::Bucket"); result != nil {
 			return result, handleErr
 		}
 		return nil, fmt.Errorf("failed to create Bucket: %w", err)
 	}
 
+	// The lifecycle policy is a separate call; if it fails the bucket would be
+	// left behind untracked, so roll it back rather than report a bare failure
+	if rules, ok := parseLifecycleRules(props); ok && len(rules) > 0 {
+		if err := putLifecycleRules(ctx, client, namespace, *resp.Name, rules); err != nil {
+			return util.RollbackCreate(ctx, err, *resp.Name, "OCI::ObjectStorage::Bucket", func(ctx context.Context) error {
+				_, err := client.DeleteBucket(ctx, objectstorage.DeleteBucketRequest{
+					NamespaceName: common.String(namespace),
+					BucketName:    resp.Name,
+				})
+				return err
+			}), nil
+		}
+	}
+
 	return &resource.CreateResult{
 		ProgressResult: &resource.ProgressResult{
 			Operation:       resource.OperationCreate,
@@ -120,6 +162,20 @@ func (p *BucketProvisioner) Create(ctx context.Context, request *resource.Create
 	}, nil
 }
 
+// adoptExistingBucket reports whether the bucket that blocked a create can be
+// taken over. Bucket names are unique per namespace, not per compartment, so a
+// same-named bucket elsewhere in the tenancy is left alone.
+func adoptExistingBucket(ctx context.Context, client *objectstorage.ObjectStorageClient, namespace, name, compartmentId string) bool {
+	resp, err := client.GetBucket(ctx, objectstorage.GetBucketRequest{
+		NamespaceName: common.String(namespace),
+		BucketName:    common.String(name),
+	})
+	if err != nil {
+		return false
+	}
+	return resp.CompartmentId != nil && *resp.CompartmentId == compartmentId
+}
+
 func (p *BucketProvisioner) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
 	client, err := p.getSvc()
 	if err != nil {
@@ -131,13 +187,40 @@ func (p *BucketProvisioner) Update(ctx context.Context, request *resource.Update
 		return nil, err
 	}
 
-	namespace, err := p.getNamespace(ctx, client, props)
+	namespace, err := getNamespace(ctx, client, props)
 	if err != nil {
 		return nil, err
 	}
 
+	current, err := client.GetBucket(ctx, objectstorage.GetBucketRequest{
+		NamespaceName: common.String(namespace),
+		BucketName:    common.String(request.NativeID),
+	})
+	if err != nil {
+		if result, handleErr := util.HandleUpdateError(err, "OCI::ObjectStorage::Bucket", request.NativeID, "OCI::ObjectStorage::Bucket"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to read Bucket before update: %w", err)
+	}
+
+	// UpdateBucket has no StorageTier field; report a changed tier instead of
+	// silently ignoring it and drifting forever. replaceOnChange catches it
+	// first for patch updates; this covers updates with the full desired state.
+	if storageTier, ok := util.ExtractString(props, "StorageTier"); ok {
+		if current.StorageTier != "" && string(current.StorageTier) != storageTier {
+			return util.ReplacementRequired(request.NativeID, "StorageTier"), nil
+		}
+	}
+
 	updateDetails := objectstorage.UpdateBucketDetails{}
 
+	// Setting CompartmentId moves the bucket, including one moved outside formae
+	if compartmentId, ok := util.ExtractString(props, "CompartmentId"); ok {
+		if current.CompartmentId == nil || *current.CompartmentId != compartmentId {
+			updateDetails.CompartmentId = common.String(compartmentId)
+		}
+	}
+
 	if publicAccessType, ok := util.ExtractString(props, "PublicAccessType"); ok {
 		updateDetails.PublicAccessType = objectstorage.UpdateBucketDetailsPublicAccessTypeEnum(publicAccessType)
 	}
@@ -154,6 +237,20 @@ func (p *BucketProvisioner) Update(ctx context.Context, request *resource.Update
 		updateDetails.ObjectEventsEnabled = common.Bool(objectEventsEnabled)
 	}
 
+	if autoTiering, ok := util.ExtractString(props, "AutoTiering"); ok {
+		updateDetails.AutoTiering = objectstorage.BucketAutoTieringEnum(autoTiering)
+	}
+
+	// Send KmsKeyId to set or change the customer key. When the desired state
+	// has none but the bucket is encrypted with one, send "" instead: OCI then
+	// drops the key and goes back to Oracle-managed encryption. Buckets with
+	// neither get no KmsKeyId at all.
+	if kmsKeyId, ok := util.ExtractString(props, "KmsKeyId"); ok {
+		updateDetails.KmsKeyId = common.String(kmsKeyId)
+	} else if _, ok := util.CustomerKmsKeyId(current.KmsKeyId); ok {
+		updateDetails.KmsKeyId = common.String("")
+	}
+
 	if freeformTags, ok := util.ExtractFreeformTags(props, "FreeformTags"); ok {
 		updateDetails.FreeformTags = freeformTags
 	}
@@ -176,6 +273,15 @@ func (p *BucketProvisioner) Update(ctx context.Context, request *resource.Update
 		return nil, fmt.Errorf("failed to update Bucket: %w", err)
 	}
 
+	if rules, ok := parseLifecycleRules(props); ok {
+		if err := putLifecycleRules(ctx, client, namespace, request.NativeID, rules); err != nil {
+			if result, handleErr := util.HandleUpdateError(err, "OCI::ObjectStorage::Bucket", request.NativeID, "OCI::ObjectStorage::Bucket"); result != nil {
+				return result, handleErr
+			}
+			return nil, fmt.Errorf("failed to update Bucket lifecycle policy: %w", err)
+		}
+	}
+
 	return &resource.UpdateResult{
 		ProgressResult: &resource.ProgressResult{
 			Operation:       resource.OperationUpdate,
@@ -191,7 +297,7 @@ func (p *BucketProvisioner) Delete(ctx context.Context, request *resource.Delete
 		return nil, fmt.Errorf("failed to get ObjectStorage client: %w", err)
 	}
 
-	namespace, err := p.getNamespace(ctx, client, nil)
+	namespace, err := getNamespace(ctx, client, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -214,36 +320,106 @@ func (p *BucketProvisioner) Delete(ctx context.Context, request *resource.Delete
 		}, nil
 	}
 
-	deleteReq := objectstorage.DeleteBucketRequest{
-		NamespaceName: common.String(namespace),
-		BucketName:    common.String(request.NativeID),
+	if err := deleteReplicationPolicies(ctx, client, namespace, request.NativeID, util.ListPageSize(request.TargetConfig)); err != nil {
+		if result, handleErr := util.HandleDeleteError(err, "OCI::ObjectStorage::Bucket", request.NativeID, "OCI::ObjectStorage::Bucket"); result != nil {
+			return result, handleErr
+		}
+		return nil, err
 	}
 
-	_, err = client.DeleteBucket(ctx, deleteReq)
+	var progress *resource.ProgressResult
+	if config.FromTargetConfig(request.TargetConfig).EmptyBeforeDelete {
+		progress, err = emptyAndDeleteBucket(ctx, client, namespace, request.NativeID, nil, util.ListPageSize(request.TargetConfig))
+	} else {
+		progress, err = deleteBucket(ctx, client, namespace, request.NativeID, false)
+	}
 	if err != nil {
-		if result, handleErr := util.HandleDeleteError(err, "OCI::ObjectStorage::Bucket", request.NativeID, "OCI::ObjectStorage::Bucket"); result != nil {
-			return result, handleErr
+		return nil, err
+	}
+	return &resource.DeleteResult{ProgressResult: progress}, nil
+}
+
+// emptyAndDeleteBucket runs one step of an emptyBeforeDelete Delete: it deletes
+// a batch of object versions starting at page and, while more are left,
+// reports InProgress with a {bucketName}/{page} RequestID for Status to carry
+// on from. After the last batch it aborts unfinished multipart uploads and
+// deletes the bucket.
+func emptyAndDeleteBucket(ctx context.Context, client *objectstorage.ObjectStorageClient, namespace, bucketName string, page *string, limit *int) (*resource.ProgressResult, error) {
+	next, err := emptyBucket(ctx, client, namespace, bucketName, page, limit)
+	if err == nil && next != nil {
+		return &resource.ProgressResult{
+			Operation:       resource.OperationDelete,
+			OperationStatus: resource.OperationStatusInProgress,
+			NativeID:        bucketName,
+			RequestID:       util.EncodeCompositeID(bucketName, *next),
+		}, nil
+	}
+	if err == nil {
+		err = abortMultipartUploads(ctx, client, namespace, bucketName, limit)
+	}
+	if err != nil {
+		if result, handleErr := util.HandleDeleteError(err, "OCI::ObjectStorage::Bucket", bucketName, "OCI::ObjectStorage::Bucket"); result != nil {
+			return result.ProgressResult, handleErr
+		}
+		return nil, err
+	}
+	return deleteBucket(ctx, client, namespace, bucketName, true)
+}
+
+// deleteBucket deletes the bucket itself. A bucket that still holds objects
+// fails with a hint at emptyBeforeDelete unless it was just emptied; any
+// other conflict is reported as OCI states it.
+func deleteBucket(ctx context.Context, client *objectstorage.ObjectStorageClient, namespace, bucketName string, emptied bool) (*resource.ProgressResult, error) {
+	_, err := client.DeleteBucket(ctx, objectstorage.DeleteBucketRequest{
+		NamespaceName: common.String(namespace),
+		BucketName:    common.String(bucketName),
+	})
+	if err != nil {
+		if result, handleErr := util.HandleDeleteError(err, "OCI::ObjectStorage::Bucket", bucketName, "OCI::ObjectStorage::Bucket"); result != nil {
+			if util.HasServiceErrorCode(err, "BucketNotEmpty") && !emptied {
+				result.ProgressResult.StatusMessage = fmt.Sprintf("%s; Bucket %s still contains objects or multipart uploads, delete them first or set emptyBeforeDelete on the target",
+					result.ProgressResult.StatusMessage, bucketName)
+			}
+			return result.ProgressResult, handleErr
 		}
 		return nil, fmt.Errorf("failed to delete Bucket: %w", err)
 	}
 
-	return &resource.DeleteResult{
-		ProgressResult: &resource.ProgressResult{
-			Operation:       resource.OperationDelete,
-			OperationStatus: resource.OperationStatusSuccess,
-			NativeID:        request.NativeID,
-		},
+	return &resource.ProgressResult{
+		Operation:       resource.OperationDelete,
+		OperationStatus: resource.OperationStatusSuccess,
+		NativeID:        bucketName,
 	}, nil
 }
 
+// Status carries on an emptyBeforeDelete Delete, whose RequestID is
+// {bucketName}/{page}. Every other Bucket operation is synchronous.
 func (p *BucketProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
-	return &resource.StatusResult{
-		ProgressResult: &resource.ProgressResult{
-			Operation:       resource.OperationCheckStatus,
-			OperationStatus: resource.OperationStatusSuccess,
-			RequestID:       request.RequestID,
-		},
-	}, nil
+	parts, err := util.DecodeCompositeID(request.RequestID, 2)
+	if err != nil {
+		return &resource.StatusResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationCheckStatus,
+				OperationStatus: resource.OperationStatusSuccess,
+				RequestID:       request.RequestID,
+			},
+		}, nil
+	}
+
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ObjectStorage client: %w", err)
+	}
+	namespace, err := getNamespace(ctx, client, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	progress, err := emptyAndDeleteBucket(ctx, client, namespace, parts[0], &parts[1], util.ListPageSize(request.TargetConfig))
+	if err != nil {
+		return nil, err
+	}
+	return &resource.StatusResult{ProgressResult: progress}, nil
 }
 
 func (p *BucketProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
@@ -252,7 +428,7 @@ func (p *BucketProvisioner) Read(ctx context.Context, request *resource.ReadRequ
 		return nil, fmt.Errorf("failed to get ObjectStorage client: %w", err)
 	}
 
-	namespace, err := p.getNamespace(ctx, client, nil)
+	namespace, err := getNamespace(ctx, client, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -261,10 +437,17 @@ func (p *BucketProvisioner) Read(ctx context.Context, request *resource.ReadRequ
 		NamespaceName: common.String(namespace),
 		BucketName:    common.String(request.NativeID),
 	}
+	cfg := config.FromTargetConfig(request.TargetConfig)
+	if cfg.BucketApproximateStats {
+		getReq.Fields = []objectstorage.GetBucketFieldsEnum{
+			objectstorage.GetBucketFieldsApproximatecount,
+			objectstorage.GetBucketFieldsApproximatesize,
+		}
+	}
 
 	resp, err := client.GetBucket(ctx, getReq)
 	if err != nil {
-		if serviceErr, ok := common.IsServiceError(err); ok && serviceErr.GetHTTPStatusCode() == 404 {
+		if util.IsNotFound(err) {
 			return &resource.ReadResult{
 				ResourceType: "OCI::ObjectStorage::Bucket",
 				ErrorCode:    resource.OperationErrorCodeNotFound,
@@ -291,17 +474,37 @@ func (p *BucketProvisioner) Read(ctx context.Context, request *resource.ReadRequ
 	if resp.Versioning != "" {
 		props["Versioning"] = string(resp.Versioning)
 	}
+	if resp.AutoTiering != "" {
+		props["AutoTiering"] = string(resp.AutoTiering)
+	}
+	if kmsKeyId, ok := util.CustomerKmsKeyId(resp.KmsKeyId); ok {
+		props["KmsKeyId"] = kmsKeyId
+	}
 	if resp.CreatedBy != nil {
 		props["CreatedBy"] = *resp.CreatedBy
 	}
 	if resp.TimeCreated != nil {
 		props["TimeCreated"] = resp.TimeCreated.Format("2006-01-02T15:04:05.000Z")
 	}
+	if resp.ApproximateCount != nil {
+		props["ApproximateCount"] = *resp.ApproximateCount
+	}
+	if resp.ApproximateSize != nil {
+		props["ApproximateSize"] = *resp.ApproximateSize
+	}
 	if resp.FreeformTags != nil {
 		props["FreeformTags"] = util.FreeformTagsToList(resp.FreeformTags)
 	}
 	if resp.DefinedTags != nil {
-		props["DefinedTags"] = util.DefinedTagsToList(resp.DefinedTags)
+		props["DefinedTags"] = util.DefinedTagsToList(resp.DefinedTags, cfg.HiddenTagNamespaces())
+	}
+
+	rules, err := readLifecycleRules(ctx, client, namespace, request.NativeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Bucket lifecycle policy: %w", err)
+	}
+	if len(rules) > 0 {
+		props["LifecycleRules"] = buildLifecycleRules(rules)
 	}
 
 	propBytes, err := json.Marshal(props)
@@ -321,12 +524,12 @@ func (p *BucketProvisioner) List(ctx context.Context, request *resource.ListRequ
 		return nil, fmt.Errorf("failed to get ObjectStorage client: %w", err)
 	}
 
-	compartmentId, ok := request.AdditionalProperties["CompartmentId"]
+	compartmentId, ok := util.ListCompartmentId(request)
 	if !ok {
 		return nil, fmt.Errorf("CompartmentId is required for listing Buckets")
 	}
 
-	namespace, err := p.getNamespace(ctx, client, nil)
+	namespace, err := getNamespace(ctx, client, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -334,16 +537,23 @@ func (p *BucketProvisioner) List(ctx context.Context, request *resource.ListRequ
 	listReq := objectstorage.ListBucketsRequest{
 		NamespaceName: common.String(namespace),
 		CompartmentId: common.String(compartmentId),
+		Limit:         util.ListPageSize(request.TargetConfig),
 	}
 
-	resp, err := client.ListBuckets(ctx, listReq)
+	nativeIDs, err := util.ListAllPages(func(page *string) ([]string, *string, error) {
+		listReq.Page = page
+		resp, err := client.ListBuckets(ctx, listReq)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list Buckets: %w", err)
+		}
+		ids := make([]string, 0, len(resp.Items))
+		for _, bucket := range resp.Items {
+			ids = append(ids, *bucket.Name)
+		}
+		return ids, resp.OpcNextPage, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list Buckets: %w", err)
-	}
-
-	nativeIDs := make([]string, 0, len(resp.Items))
-	for _, bucket := range resp.Items {
-		nativeIDs = append(nativeIDs, *bucket.Name)
+		return nil, err
 	}
 
 	return &resource.ListResult{
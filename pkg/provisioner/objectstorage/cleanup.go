@@ -0,0 +1,110 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package objectstorage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+)
+
+// deleteReplicationPolicies removes every replication policy on the bucket.
+// DeleteBucket is rejected while one is attached.
+func deleteReplicationPolicies(ctx context.Context, client *objectstorage.ObjectStorageClient, namespace, bucketName string, limit *int) error {
+	policies, err := util.ListAllPages(func(page *string) ([]objectstorage.ReplicationPolicySummary, *string, error) {
+		resp, err := client.ListReplicationPolicies(ctx, objectstorage.ListReplicationPoliciesRequest{
+			NamespaceName: common.String(namespace),
+			BucketName:    common.String(bucketName),
+			Page:          page,
+			Limit:         limit,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list replication policies: %w", err)
+		}
+		return resp.Items, resp.OpcNextPage, nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, policy := range policies {
+		_, err := client.DeleteReplicationPolicy(ctx, objectstorage.DeleteReplicationPolicyRequest{
+			NamespaceName: common.String(namespace),
+			BucketName:    common.String(bucketName),
+			ReplicationId: policy.Id,
+		})
+		if err != nil && !util.IsNotFound(err) {
+			return fmt.Errorf("failed to delete replication policy %s: %w", *policy.Name, err)
+		}
+	}
+	return nil
+}
+
+// emptyBatchSize caps how many object versions one emptyBucket step deletes,
+// so each Delete or Status call stays well inside the operation timeout.
+const emptyBatchSize = 100
+
+// emptyBucket deletes one batch of object versions, starting at page, and
+// returns the page to continue from, or nil once the last batch is gone.
+// Versions are listed rather than objects so versioned buckets are emptied
+// too, including previous versions and delete markers.
+func emptyBucket(ctx context.Context, client *objectstorage.ObjectStorageClient, namespace, bucketName string, page *string, limit *int) (*string, error) {
+	resp, err := client.ListObjectVersions(ctx, objectstorage.ListObjectVersionsRequest{
+		NamespaceName: common.String(namespace),
+		BucketName:    common.String(bucketName),
+		Page:          page,
+		Limit:         common.Int(min(*limit, emptyBatchSize)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects: %w", err)
+	}
+	for _, version := range resp.Items {
+		_, err := client.DeleteObject(ctx, objectstorage.DeleteObjectRequest{
+			NamespaceName: common.String(namespace),
+			BucketName:    common.String(bucketName),
+			ObjectName:    version.Name,
+			VersionId:     version.VersionId,
+		})
+		if err != nil && !util.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to delete object %s: %w", *version.Name, err)
+		}
+	}
+	return resp.OpcNextPage, nil
+}
+
+// abortMultipartUploads aborts every unfinished multipart upload in the bucket.
+// Their parts don't show up as objects, but DeleteBucket still refuses a
+// bucket that has any.
+func abortMultipartUploads(ctx context.Context, client *objectstorage.ObjectStorageClient, namespace, bucketName string, limit *int) error {
+	uploads, err := util.ListAllPages(func(page *string) ([]objectstorage.MultipartUpload, *string, error) {
+		resp, err := client.ListMultipartUploads(ctx, objectstorage.ListMultipartUploadsRequest{
+			NamespaceName: common.String(namespace),
+			BucketName:    common.String(bucketName),
+			Page:          page,
+			Limit:         limit,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list multipart uploads: %w", err)
+		}
+		return resp.Items, resp.OpcNextPage, nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, upload := range uploads {
+		_, err := client.AbortMultipartUpload(ctx, objectstorage.AbortMultipartUploadRequest{
+			NamespaceName: common.String(namespace),
+			BucketName:    common.String(bucketName),
+			ObjectName:    upload.Object,
+			UploadId:      upload.UploadId,
+		})
+		if err != nil && !util.IsNotFound(err) {
+			return fmt.Errorf("failed to abort multipart upload of %s: %w", *upload.Object, err)
+		}
+	}
+	return nil
+}
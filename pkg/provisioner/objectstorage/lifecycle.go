@@ -0,0 +1,146 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package objectstorage
+
+import (
+	"context"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+)
+
+// parseLifecycleRules converts the LifecycleRules property into SDK rules.
+// The bool is false when the property is absent, so callers can tell "no
+// opinion" apart from an empty list that clears the policy.
+func parseLifecycleRules(props map[string]any) ([]objectstorage.ObjectLifecycleRule, bool) {
+	items, ok := props["LifecycleRules"].([]any)
+	if !ok {
+		return nil, false
+	}
+	rules := make([]objectstorage.ObjectLifecycleRule, 0, len(items))
+	for _, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		rule := objectstorage.ObjectLifecycleRule{IsEnabled: common.Bool(true)}
+		if name, ok := util.ExtractString(m, "name"); ok {
+			rule.Name = common.String(name)
+		}
+		if action, ok := util.ExtractString(m, "action"); ok {
+			rule.Action = common.String(action)
+		}
+		if timeAmount, ok := m["timeAmount"].(float64); ok {
+			rule.TimeAmount = common.Int64(int64(timeAmount))
+		}
+		if timeUnit, ok := util.ExtractString(m, "timeUnit"); ok {
+			rule.TimeUnit = objectstorage.ObjectLifecycleRuleTimeUnitEnum(timeUnit)
+		}
+		if isEnabled, ok := util.ExtractBool(m, "isEnabled"); ok {
+			rule.IsEnabled = common.Bool(isEnabled)
+		}
+		if target, ok := util.ExtractString(m, "target"); ok {
+			rule.Target = common.String(target)
+		}
+		if filter, ok := m["objectNameFilter"].(map[string]any); ok {
+			rule.ObjectNameFilter = &objectstorage.ObjectNameFilter{}
+			if prefixes, ok := util.ExtractStringSlice(filter, "inclusionPrefixes"); ok {
+				rule.ObjectNameFilter.InclusionPrefixes = prefixes
+			}
+			if patterns, ok := util.ExtractStringSlice(filter, "inclusionPatterns"); ok {
+				rule.ObjectNameFilter.InclusionPatterns = patterns
+			}
+			if patterns, ok := util.ExtractStringSlice(filter, "exclusionPatterns"); ok {
+				rule.ObjectNameFilter.ExclusionPatterns = patterns
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules, true
+}
+
+func buildLifecycleRules(rules []objectstorage.ObjectLifecycleRule) []map[string]any {
+	result := make([]map[string]any, 0, len(rules))
+	for _, rule := range rules {
+		m := map[string]any{}
+		if rule.Name != nil {
+			m["name"] = *rule.Name
+		}
+		if rule.Action != nil {
+			m["action"] = *rule.Action
+		}
+		if rule.TimeAmount != nil {
+			m["timeAmount"] = *rule.TimeAmount
+		}
+		if rule.TimeUnit != "" {
+			m["timeUnit"] = string(rule.TimeUnit)
+		}
+		if rule.IsEnabled != nil {
+			m["isEnabled"] = *rule.IsEnabled
+		}
+		if rule.Target != nil {
+			m["target"] = *rule.Target
+		}
+		if f := rule.ObjectNameFilter; f != nil {
+			filter := map[string]any{}
+			if len(f.InclusionPrefixes) > 0 {
+				filter["inclusionPrefixes"] = f.InclusionPrefixes
+			}
+			if len(f.InclusionPatterns) > 0 {
+				filter["inclusionPatterns"] = f.InclusionPatterns
+			}
+			if len(f.ExclusionPatterns) > 0 {
+				filter["exclusionPatterns"] = f.ExclusionPatterns
+			}
+			if len(filter) > 0 {
+				m["objectNameFilter"] = filter
+			}
+		}
+		result = append(result, m)
+	}
+	return result
+}
+
+// putLifecycleRules replaces the bucket's lifecycle policy. An empty rule list
+// deletes the policy, since OCI keeps an empty policy object around otherwise.
+func putLifecycleRules(ctx context.Context, client *objectstorage.ObjectStorageClient, namespace, bucketName string, rules []objectstorage.ObjectLifecycleRule) error {
+	if len(rules) == 0 {
+		_, err := client.DeleteObjectLifecyclePolicy(ctx, objectstorage.DeleteObjectLifecyclePolicyRequest{
+			NamespaceName: common.String(namespace),
+			BucketName:    common.String(bucketName),
+		})
+		if util.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	_, err := client.PutObjectLifecyclePolicy(ctx, objectstorage.PutObjectLifecyclePolicyRequest{
+		NamespaceName: common.String(namespace),
+		BucketName:    common.String(bucketName),
+		PutObjectLifecyclePolicyDetails: objectstorage.PutObjectLifecyclePolicyDetails{
+			Items: rules,
+		},
+	})
+	return err
+}
+
+// readLifecycleRules returns the bucket's lifecycle rules, or nil when the
+// bucket has no policy. A caller allowed to read the bucket but not its
+// lifecycle policy gets nil too, so the Bucket Read still succeeds without
+// LifecycleRules; any other error is returned.
+func readLifecycleRules(ctx context.Context, client *objectstorage.ObjectStorageClient, namespace, bucketName string) ([]objectstorage.ObjectLifecycleRule, error) {
+	resp, err := client.GetObjectLifecyclePolicy(ctx, objectstorage.GetObjectLifecyclePolicyRequest{
+		NamespaceName: common.String(namespace),
+		BucketName:    common.String(bucketName),
+	})
+	if err != nil {
+		if util.IsNotFound(err) || util.IsNotAuthorized(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return resp.Items, nil
+}
@@ -0,0 +1,431 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package objectstorage
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+type ObjectProvisioner struct {
+	clients *client.Clients
+	svc     *objectstorage.ObjectStorageClient // nil until first use; injected in tests
+}
+
+var (
+	_ provisioner.Provisioner = &ObjectProvisioner{}
+	_ provisioner.Opaque      = &ObjectProvisioner{}
+)
+
+func init() {
+	provisioner.Register("OCI::ObjectStorage::Object", NewObjectProvisioner)
+	provisioner.DependsOn("OCI::ObjectStorage::Object", "OCI::ObjectStorage::Bucket")
+}
+
+func NewObjectProvisioner(clients *client.Clients) provisioner.Provisioner {
+	return &ObjectProvisioner{clients: clients}
+}
+
+// NewObjectProvisionerWithSvc constructs a provisioner with a pre-built SDK client,
+// for use in tests that point the client at an httptest server.
+func NewObjectProvisionerWithSvc(svc *objectstorage.ObjectStorageClient) *ObjectProvisioner {
+	return &ObjectProvisioner{svc: svc}
+}
+
+// OpaqueFields lists Metadata, the object's user-defined opc-meta- headers.
+func (p *ObjectProvisioner) OpaqueFields() []string {
+	return []string{"Metadata"}
+}
+
+func (p *ObjectProvisioner) getSvc() (*objectstorage.ObjectStorageClient, error) {
+	if p.svc != nil {
+		return p.svc, nil
+	}
+	return p.clients.GetObjectStorageClient()
+}
+
+// objectNativeID builds the NativeID {bucketName}/{objectName}; a '/' in the
+// object name is escaped by util.EncodeCompositeID.
+func objectNativeID(bucketName, objectName string) string {
+	return util.EncodeCompositeID(bucketName, objectName)
+}
+
+// parseObjectNativeID splits a NativeID built by objectNativeID.
+func parseObjectNativeID(nativeID string) (bucketName, objectName string, err error) {
+	parts, err := util.DecodeCompositeID(nativeID, 2)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid Object NativeID %q: expected {bucketName}/{objectName}", nativeID)
+	}
+	return parts[0], parts[1], nil
+}
+
+// objectContent decodes the object body from either Content (plain text) or
+// ContentBase64 (binary). Exactly one of the two must be set.
+func objectContent(props map[string]any) ([]byte, error) {
+	content, hasContent := props["Content"].(string)
+	encoded, hasEncoded := util.ExtractString(props, "ContentBase64")
+	switch {
+	case hasContent && hasEncoded:
+		return nil, fmt.Errorf("only one of Content or ContentBase64 may be set")
+	case hasEncoded:
+		body, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("ContentBase64 is not valid base64: %w", err)
+		}
+		return body, nil
+	case hasContent:
+		return []byte(content), nil
+	}
+	return nil, fmt.Errorf("one of Content or ContentBase64 is required")
+}
+
+// extractMetadata converts the Metadata mapping to the opc-meta-* header collection.
+func extractMetadata(props map[string]any) map[string]string {
+	raw, ok := props["Metadata"].(map[string]any)
+	if !ok || len(raw) == 0 {
+		return nil
+	}
+	metadata := make(map[string]string, len(raw))
+	for k, v := range raw {
+		if str, ok := v.(string); ok {
+			metadata[k] = str
+		}
+	}
+	return metadata
+}
+
+// contentMd5 returns the base64-encoded MD5 digest OCI reports in the Content-MD5 header.
+func contentMd5(body []byte) string {
+	sum := md5.Sum(body)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func putObject(ctx context.Context, client *objectstorage.ObjectStorageClient, namespace, bucketName, objectName string, props map[string]any, body []byte) error {
+	putReq := objectstorage.PutObjectRequest{
+		NamespaceName: common.String(namespace),
+		BucketName:    common.String(bucketName),
+		ObjectName:    common.String(objectName),
+		ContentLength: common.Int64(int64(len(body))),
+		ContentMD5:    common.String(contentMd5(body)),
+		PutObjectBody: io.NopCloser(bytes.NewReader(body)),
+		OpcMeta:       extractMetadata(props),
+	}
+	if contentType, ok := util.ExtractString(props, "ContentType"); ok {
+		putReq.ContentType = common.String(contentType)
+	}
+
+	_, err := client.PutObject(ctx, putReq)
+	return err
+}
+
+// HasNoTags: objects carry Metadata, not tags.
+func (p *ObjectProvisioner) HasNoTags() {}
+
+func (p *ObjectProvisioner) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ObjectStorage client: %w", err)
+	}
+
+	var props map[string]any
+	if err := json.Unmarshal(request.Properties, &props); err != nil {
+		return nil, fmt.Errorf("failed to parse properties: %w", err)
+	}
+
+	bucketName := props["BucketName"].(string)
+	objectName := props["ObjectName"].(string)
+
+	body, err := objectContent(props)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace, err := getNamespace(ctx, client, props)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := putObject(ctx, client, namespace, bucketName, objectName, props, body); err != nil {
+		if result, handleErr := util.HandleCreateError(err, "OCI::ObjectStorage::Object", "OCI::ObjectStorage::Object"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to create Object: %w", err)
+	}
+
+	return &resource.CreateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationCreate,
+			OperationStatus: resource.OperationStatusSuccess,
+			NativeID:        objectNativeID(bucketName, objectName),
+		},
+	}, nil
+}
+
+func (p *ObjectProvisioner) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ObjectStorage client: %w", err)
+	}
+
+	bucketName, objectName, err := parseObjectNativeID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Content is write-only: Read can't report it for a patch to apply to, so
+	// update from the desired state whenever formae sends it
+	if len(request.DesiredProperties) > 0 {
+		desired := *request
+		desired.PatchDocument = nil
+		request = &desired
+	}
+	props, err := util.ApplyPatchDocument(ctx, request, p.Read)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := objectContent(props)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace, err := getNamespace(ctx, client, props)
+	if err != nil {
+		return nil, err
+	}
+
+	// Objects are replaced wholesale, so skip the upload when the stored object
+	// already matches the desired content, content type and metadata
+	head, err := client.HeadObject(ctx, objectstorage.HeadObjectRequest{
+		NamespaceName: common.String(namespace),
+		BucketName:    common.String(bucketName),
+		ObjectName:    common.String(objectName),
+	})
+	if err == nil && objectUpToDate(head, props, body) {
+		return &resource.UpdateResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationUpdate,
+				OperationStatus: resource.OperationStatusSuccess,
+				NativeID:        request.NativeID,
+			},
+		}, nil
+	}
+
+	if err := putObject(ctx, client, namespace, bucketName, objectName, props, body); err != nil {
+		if result, handleErr := util.HandleUpdateError(err, "OCI::ObjectStorage::Object", request.NativeID, "OCI::ObjectStorage::Object"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to update Object: %w", err)
+	}
+
+	return &resource.UpdateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationUpdate,
+			OperationStatus: resource.OperationStatusSuccess,
+			NativeID:        request.NativeID,
+		},
+	}, nil
+}
+
+// objectUpToDate reports whether the stored object already has the desired body,
+// content type and metadata.
+func objectUpToDate(head objectstorage.HeadObjectResponse, props map[string]any, body []byte) bool {
+	if head.ContentMd5 == nil || *head.ContentMd5 != contentMd5(body) {
+		return false
+	}
+	if contentType, ok := util.ExtractString(props, "ContentType"); ok {
+		if head.ContentType == nil || *head.ContentType != contentType {
+			return false
+		}
+	}
+	return maps.Equal(extractMetadata(props), head.OpcMeta)
+}
+
+func (p *ObjectProvisioner) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ObjectStorage client: %w", err)
+	}
+
+	bucketName, objectName, err := parseObjectNativeID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace, err := getNamespace(ctx, client, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	readRes, err := p.Read(ctx, &resource.ReadRequest{NativeID: request.NativeID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Object before delete: %w", err)
+	}
+	if readRes.ErrorCode == resource.OperationErrorCodeNotFound {
+		return &resource.DeleteResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationDelete,
+				OperationStatus: resource.OperationStatusSuccess,
+				NativeID:        request.NativeID,
+			},
+		}, nil
+	}
+
+	deleteReq := objectstorage.DeleteObjectRequest{
+		NamespaceName: common.String(namespace),
+		BucketName:    common.String(bucketName),
+		ObjectName:    common.String(objectName),
+	}
+
+	_, err = client.DeleteObject(ctx, deleteReq)
+	if err != nil {
+		if result, handleErr := util.HandleDeleteError(err, "OCI::ObjectStorage::Object", request.NativeID, "OCI::ObjectStorage::Object"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to delete Object: %w", err)
+	}
+
+	return &resource.DeleteResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationDelete,
+			OperationStatus: resource.OperationStatusSuccess,
+			NativeID:        request.NativeID,
+		},
+	}, nil
+}
+
+func (p *ObjectProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	return &resource.StatusResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationCheckStatus,
+			OperationStatus: resource.OperationStatusSuccess,
+			RequestID:       request.RequestID,
+		},
+	}, nil
+}
+
+// Read uses HeadObject so drift is detected from the stored ContentMd5 and ETag
+// without downloading the object body. Content and ContentBase64 are write-only
+// and never read back.
+func (p *ObjectProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ObjectStorage client: %w", err)
+	}
+
+	bucketName, objectName, err := parseObjectNativeID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace, err := getNamespace(ctx, client, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	headReq := objectstorage.HeadObjectRequest{
+		NamespaceName: common.String(namespace),
+		BucketName:    common.String(bucketName),
+		ObjectName:    common.String(objectName),
+	}
+
+	resp, err := client.HeadObject(ctx, headReq)
+	if err != nil {
+		if util.IsNotFound(err) {
+			return &resource.ReadResult{
+				ResourceType: "OCI::ObjectStorage::Object",
+				ErrorCode:    resource.OperationErrorCodeNotFound,
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to read Object: %w", err)
+	}
+
+	props := map[string]any{
+		"Namespace":  namespace,
+		"BucketName": bucketName,
+		"ObjectName": objectName,
+	}
+
+	if resp.ContentType != nil {
+		props["ContentType"] = *resp.ContentType
+	}
+	if resp.ContentMd5 != nil {
+		props["ContentMd5"] = *resp.ContentMd5
+	}
+	if resp.ETag != nil {
+		props["ETag"] = *resp.ETag
+	}
+	if resp.ContentLength != nil {
+		props["ContentLength"] = *resp.ContentLength
+	}
+	if len(resp.OpcMeta) > 0 {
+		props["Metadata"] = resp.OpcMeta
+	}
+
+	propBytes, err := json.Marshal(props)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Object properties: %w", err)
+	}
+
+	return &resource.ReadResult{
+		ResourceType: "OCI::ObjectStorage::Object",
+		Properties:   string(propBytes),
+	}, nil
+}
+
+func (p *ObjectProvisioner) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	client, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ObjectStorage client: %w", err)
+	}
+
+	bucketName, ok := request.AdditionalProperties["BucketName"]
+	if !ok {
+		return nil, fmt.Errorf("BucketName is required for listing Objects")
+	}
+
+	namespace, err := getNamespace(ctx, client, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// ListObjects pages by object name rather than an opc-next-page token
+	nativeIDs, err := util.ListAllPages(func(start *string) ([]string, *string, error) {
+		resp, err := client.ListObjects(ctx, objectstorage.ListObjectsRequest{
+			NamespaceName: common.String(namespace),
+			BucketName:    common.String(bucketName),
+			Limit:         util.ListPageSize(request.TargetConfig),
+			Start:         start,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list Objects: %w", err)
+		}
+		ids := make([]string, 0, len(resp.Objects))
+		for _, object := range resp.Objects {
+			ids = append(ids, objectNativeID(bucketName, *object.Name))
+		}
+		return ids, resp.NextStartWith, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &resource.ListResult{
+		NativeIDs: nativeIDs,
+	}, nil
+}
@@ -10,6 +10,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 
 	ociidentity "github.com/oracle/oci-go-sdk/v65/identity"
@@ -135,11 +136,36 @@ func TestPolicyList(t *testing.T) {
 	assert.Equal(t, []string{"ocid1.policy..aaa"}, result.NativeIDs)
 }
 
+func TestPolicyListPaginated(t *testing.T) {
+	secondPolicy := strings.Replace(newTestPolicyBody("ACTIVE"), "ocid1.policy..aaa", "ocid1.policy..bbb", 1)
+	svc := newTestIdentityClientAt(t, newTestPagedDispatcher(t, nil, map[route][]canned{
+		{"GET", "/20160918/policies"}: {
+			{200, fmt.Sprintf(`[%s]`, newTestPolicyBody("ACTIVE"))},
+			{200, fmt.Sprintf(`[%s]`, secondPolicy)},
+		},
+	}))
+	p := identity.NewPolicyProvisionerWithSvc(svc)
+
+	result, err := p.List(context.Background(), &resource.ListRequest{
+		ResourceType: "OCI::Identity::Policy",
+		AdditionalProperties: map[string]string{
+			"CompartmentId": "ocid1.compartment..xxx",
+		},
+		TargetConfig: json.RawMessage(`{"ListPageSize":1}`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ocid1.policy..aaa", "ocid1.policy..bbb"}, result.NativeIDs)
+}
+
 // Helpers
 
 func newTestPolicyClient(t *testing.T, responses map[route]canned) *ociidentity.IdentityClient {
 	t.Helper()
-	host := newTestDispatcher(t, responses)
+	return newTestIdentityClientAt(t, newTestDispatcher(t, responses))
+}
+
+func newTestIdentityClientAt(t *testing.T, host string) *ociidentity.IdentityClient {
+	t.Helper()
 	c, err := ociidentity.NewIdentityClientWithConfigurationProvider(fakeOCIConfigProvider(t))
 	require.NoError(t, err)
 	applyTestRetryPolicy(&c)
@@ -0,0 +1,142 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build integration
+
+package provisioner_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/core"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublicIpPoolRead(t *testing.T) {
+	svc := newTestVirtualNetworkClient(t, withTestByoipRanges(map[route]canned{
+		{"GET", "/20160918/publicIpPools/ocid1.publicippool..aaa"}: {200, newTestPublicIpPoolBody("ACTIVE")},
+	}))
+	p := core.NewPublicIpPoolProvisionerWithSvc(svc)
+
+	result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.publicippool..aaa"})
+	require.NoError(t, err)
+	assert.Empty(t, result.ErrorCode)
+
+	var props map[string]any
+	require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+	assert.Equal(t, []any{"203.0.113.0/28"}, props["CidrBlocks"])
+	assert.Equal(t, "ocid1.byoiprange..aaa", props["ByoipRangeId"])
+}
+
+func TestPublicIpPoolCreate(t *testing.T) {
+	// No addCapacity route: capacity is added from Status once the pool settles
+	svc := newTestVirtualNetworkClient(t, map[route]canned{
+		{"POST", "/20160918/publicIpPools"}: {200, newTestPublicIpPoolBody("INACTIVE")},
+	})
+	p := core.NewPublicIpPoolProvisionerWithSvc(svc)
+
+	props, err := json.Marshal(map[string]any{
+		"CompartmentId": "ocid1.compartment..xxx",
+		"DisplayName":   "test-pool",
+		"ByoipRangeId":  "ocid1.byoiprange..aaa",
+		"CidrBlocks":    []string{"203.0.113.0/28"},
+	})
+	require.NoError(t, err)
+
+	result, err := p.Create(context.Background(), &resource.CreateRequest{
+		ResourceType: "OCI::Core::PublicIpPool",
+		Properties:   props,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+	assert.Equal(t, "ocid1.publicippool..aaa", result.ProgressResult.NativeID)
+	assert.True(t, strings.HasPrefix(result.ProgressResult.RequestID, "ocid1.publicippool..aaa/"))
+}
+
+func TestPublicIpPoolUpdate_OneCapacityChangeAtATime(t *testing.T) {
+	svc := newTestVirtualNetworkClient(t, withTestByoipRanges(map[route]canned{
+		{"GET", "/20160918/publicIpPools/ocid1.publicippool..aaa"}:                         {200, newTestPublicIpPoolBody("ACTIVE")},
+		{"PUT", "/20160918/publicIpPools/ocid1.publicippool..aaa"}:                         {200, newTestPublicIpPoolBody("ACTIVE")},
+		{"POST", "/20160918/publicIpPools/ocid1.publicippool..aaa/actions/removeCapacity"}: {200, newTestPublicIpPoolBody("UPDATING")},
+		{"POST", "/20160918/publicIpPools/ocid1.publicippool..aaa/actions/addCapacity"}:    {200, newTestPublicIpPoolBody("UPDATING")},
+	}))
+	p := core.NewPublicIpPoolProvisionerWithSvc(svc)
+
+	props, err := json.Marshal(map[string]any{
+		"CompartmentId": "ocid1.compartment..xxx",
+		"ByoipRangeId":  "ocid1.byoiprange..aaa",
+		"CidrBlocks":    []string{"203.0.113.16/28"},
+	})
+	require.NoError(t, err)
+
+	result, err := p.Update(context.Background(), &resource.UpdateRequest{
+		NativeID:          "ocid1.publicippool..aaa",
+		ResourceType:      "OCI::Core::PublicIpPool",
+		DesiredProperties: props,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+	requestID := result.ProgressResult.RequestID
+	assert.True(t, strings.HasPrefix(requestID, "ocid1.publicippool..aaa/"), requestID)
+
+	// The removal has finished; Status adds the new block
+	status, err := p.Status(context.Background(), &resource.StatusRequest{RequestID: requestID})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, status.ProgressResult.OperationStatus)
+	assert.Equal(t, "ocid1.publicippool..aaa", status.ProgressResult.RequestID)
+
+	status, err = p.Status(context.Background(), &resource.StatusRequest{RequestID: status.ProgressResult.RequestID})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, status.ProgressResult.OperationStatus)
+}
+
+func TestPublicIpPoolStatus(t *testing.T) {
+	cases := []struct {
+		state    string
+		expected resource.OperationStatus
+	}{
+		{"ACTIVE", resource.OperationStatusSuccess},
+		{"INACTIVE", resource.OperationStatusSuccess},
+		{"UPDATING", resource.OperationStatusInProgress},
+	}
+	for _, tc := range cases {
+		t.Run(tc.state, func(t *testing.T) {
+			svc := newTestVirtualNetworkClient(t, withTestByoipRanges(map[route]canned{
+				{"GET", "/20160918/publicIpPools/ocid1.publicippool..aaa"}: {200, newTestPublicIpPoolBody(tc.state)},
+			}))
+			p := core.NewPublicIpPoolProvisionerWithSvc(svc)
+
+			result, err := p.Status(context.Background(), &resource.StatusRequest{RequestID: "ocid1.publicippool..aaa"})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result.ProgressResult.OperationStatus)
+		})
+	}
+}
+
+// Helpers
+
+func newTestPublicIpPoolBody(lifecycleState string) string {
+	return fmt.Sprintf(`{
+		"id": "ocid1.publicippool..aaa",
+		"compartmentId": "ocid1.compartment..xxx",
+		"displayName": "test-pool",
+		"cidrBlocks": ["203.0.113.0/28"],
+		"timeCreated": "2025-01-01T00:00:00.000Z",
+		"lifecycleState": %q
+	}`, lifecycleState)
+}
+
+// withTestByoipRanges adds a BYOIP range whose allocation names the test pool.
+func withTestByoipRanges(responses map[route]canned) map[route]canned {
+	responses[route{"GET", "/20160918/byoipRanges"}] = canned{200, `{"items": [{"id": "ocid1.byoiprange..aaa", "lifecycleState": "ACTIVE"}]}`}
+	responses[route{"GET", "/20160918/byoipRanges/ocid1.byoiprange..aaa/byoipAllocatedRanges"}] = canned{200,
+		`{"items": [{"cidrBlock": "203.0.113.0/28", "publicIpPoolId": "ocid1.publicippool..aaa"}]}`}
+	return responses
+}
@@ -12,13 +12,18 @@ import (
 )
 
 // readAfterWrite is a decorator that wraps a Provisioner and automatically
-// calls Read() after a successful synchronous Create or Update. This ensures
-// ResourceProperties always contains the complete set of fields from the API,
-// preventing validateRequiredFields from dropping resources due to missing
+// calls Read() after a successful synchronous Create or Update, and after a
+// Status() poll that reports success. This ensures ResourceProperties always
+// contains the complete set of fields from the API, preventing
+// validateRequiredFields from dropping resources due to missing
 // schema-required fields.
 //
-// For async operations (OperationStatusInProgress), the decorator is a no-op —
-// properties will come from Status() polling instead.
+// Going through Read() for every successful result also keeps output-only
+// fields (VirtualRouterIp, DefaultRouteTableId, cluster Endpoints, ...) stable:
+// whichever path produced the result, dependents see the same property set.
+//
+// For async operations (OperationStatusInProgress), Create and Update are left
+// alone — properties will come from Status() polling instead.
 type readAfterWrite struct {
 	inner Provisioner
 }
@@ -29,17 +34,7 @@ func (w *readAfterWrite) Create(ctx context.Context, request *resource.CreateReq
 		return nil, err
 	}
 
-	pr := result.ProgressResult
-	if pr.OperationStatus == resource.OperationStatusSuccess && pr.NativeID != "" {
-		readResp, readErr := w.inner.Read(ctx, &resource.ReadRequest{
-			NativeID:     pr.NativeID,
-			ResourceType: request.ResourceType,
-			TargetConfig: request.TargetConfig,
-		})
-		if readErr == nil && readResp.ErrorCode == "" {
-			pr.ResourceProperties = json.RawMessage(readResp.Properties)
-		}
-	}
+	w.refreshProperties(ctx, result.ProgressResult, request.ResourceType, request.TargetConfig)
 
 	return result, nil
 }
@@ -50,17 +45,7 @@ func (w *readAfterWrite) Update(ctx context.Context, request *resource.UpdateReq
 		return nil, err
 	}
 
-	pr := result.ProgressResult
-	if pr.OperationStatus == resource.OperationStatusSuccess && pr.NativeID != "" {
-		readResp, readErr := w.inner.Read(ctx, &resource.ReadRequest{
-			NativeID:     pr.NativeID,
-			ResourceType: request.ResourceType,
-			TargetConfig: request.TargetConfig,
-		})
-		if readErr == nil && readResp.ErrorCode == "" {
-			pr.ResourceProperties = json.RawMessage(readResp.Properties)
-		}
-	}
+	w.refreshProperties(ctx, result.ProgressResult, request.ResourceType, request.TargetConfig)
 
 	return result, nil
 }
@@ -70,7 +55,15 @@ func (w *readAfterWrite) Delete(ctx context.Context, request *resource.DeleteReq
 }
 
 func (w *readAfterWrite) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
-	return w.inner.Status(ctx, request)
+	result, err := w.inner.Status(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	// Successful deletes fall through harmlessly: Read reports NotFound.
+	w.refreshProperties(ctx, result.ProgressResult, request.ResourceType, request.TargetConfig)
+
+	return result, nil
 }
 
 func (w *readAfterWrite) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
@@ -80,3 +73,19 @@ func (w *readAfterWrite) Read(ctx context.Context, request *resource.ReadRequest
 func (w *readAfterWrite) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
 	return w.inner.List(ctx, request)
 }
+
+// refreshProperties replaces pr.ResourceProperties with a fresh Read when pr is a
+// success with a NativeID. A failed or NotFound Read keeps the original properties.
+func (w *readAfterWrite) refreshProperties(ctx context.Context, pr *resource.ProgressResult, resourceType string, targetConfig json.RawMessage) {
+	if pr == nil || pr.OperationStatus != resource.OperationStatusSuccess || pr.NativeID == "" {
+		return
+	}
+	readResp, readErr := w.inner.Read(ctx, &resource.ReadRequest{
+		NativeID:     pr.NativeID,
+		ResourceType: resourceType,
+		TargetConfig: targetConfig,
+	})
+	if readErr == nil && readResp.ErrorCode == "" {
+		pr.ResourceProperties = json.RawMessage(readResp.Properties)
+	}
+}
@@ -18,10 +18,12 @@ type mockProvisioner struct {
 	createErr    error
 	updateResult *resource.UpdateResult
 	updateErr    error
+	statusResult *resource.StatusResult
 	readResult   *resource.ReadResult
 	readErr      error
 
-	readCalled bool
+	readCalled   bool
+	updateCalled bool
 }
 
 func (m *mockProvisioner) Create(_ context.Context, _ *resource.CreateRequest) (*resource.CreateResult, error) {
@@ -29,6 +31,7 @@ func (m *mockProvisioner) Create(_ context.Context, _ *resource.CreateRequest) (
 }
 
 func (m *mockProvisioner) Update(_ context.Context, _ *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	m.updateCalled = true
 	return m.updateResult, m.updateErr
 }
 
@@ -42,7 +45,7 @@ func (m *mockProvisioner) Delete(_ context.Context, _ *resource.DeleteRequest) (
 }
 
 func (m *mockProvisioner) Status(_ context.Context, _ *resource.StatusRequest) (*resource.StatusResult, error) {
-	return nil, nil
+	return m.statusResult, nil
 }
 
 func (m *mockProvisioner) List(_ context.Context, _ *resource.ListRequest) (*resource.ListResult, error) {
@@ -232,3 +235,79 @@ func TestReadAfterWrite_Update_ErrorPassthrough(t *testing.T) {
 		t.Fatal("Read should NOT be called when Update fails")
 	}
 }
+
+func TestReadAfterWrite_Status_SuccessRefreshesProperties(t *testing.T) {
+	inner := &mockProvisioner{
+		statusResult: &resource.StatusResult{
+			ProgressResult: &resource.ProgressResult{
+				OperationStatus: resource.OperationStatusSuccess,
+				NativeID:        "ocid1.cluster.oc1..abc",
+			},
+		},
+		readResult: &resource.ReadResult{
+			Properties: `{"Id":"ocid1.cluster.oc1..abc","Endpoints":{"Kubernetes":"10.0.0.2:6443"}}`,
+		},
+	}
+
+	w := &readAfterWrite{inner: inner}
+	result, err := w.Status(context.Background(), &resource.StatusRequest{
+		ResourceType: "OCI::ContainerEngine::Cluster",
+		RequestID:    "ocid1.clustersworkrequest.oc1..abc",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !inner.readCalled {
+		t.Fatal("expected Read to be called after successful Status")
+	}
+
+	got := string(result.ProgressResult.ResourceProperties)
+	want := `{"Id":"ocid1.cluster.oc1..abc","Endpoints":{"Kubernetes":"10.0.0.2:6443"}}`
+	if got != want {
+		t.Errorf("properties = %s, want %s", got, want)
+	}
+}
+
+func TestReadAfterWrite_Status_InProgressSkipped(t *testing.T) {
+	inner := &mockProvisioner{
+		statusResult: &resource.StatusResult{
+			ProgressResult: &resource.ProgressResult{
+				OperationStatus: resource.OperationStatusInProgress,
+				NativeID:        "ocid1.cluster.oc1..abc",
+			},
+		},
+	}
+
+	w := &readAfterWrite{inner: inner}
+	_, err := w.Status(context.Background(), &resource.StatusRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if inner.readCalled {
+		t.Fatal("Read should NOT be called while Status is in progress")
+	}
+}
+
+func TestReadAfterWrite_Status_DeletedKeepsResult(t *testing.T) {
+	inner := &mockProvisioner{
+		statusResult: &resource.StatusResult{
+			ProgressResult: &resource.ProgressResult{
+				OperationStatus: resource.OperationStatusSuccess,
+				NativeID:        "ocid1.volume.oc1..abc",
+			},
+		},
+		readResult: &resource.ReadResult{ErrorCode: resource.OperationErrorCodeNotFound},
+	}
+
+	w := &readAfterWrite{inner: inner}
+	result, err := w.Status(context.Background(), &resource.StatusRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.ProgressResult.ResourceProperties != nil {
+		t.Errorf("properties = %s, want none for a deleted resource", result.ProgressResult.ResourceProperties)
+	}
+}
@@ -6,6 +6,7 @@ package provisioner
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
 )
@@ -15,6 +16,7 @@ type ProvisionerFactory func(clients *client.Clients) Provisioner
 
 var (
 	provisioners = make(map[string]ProvisionerFactory)
+	dependencies = make(map[string][]string)
 )
 
 // Register adds a provisioner factory for a resource type
@@ -22,13 +24,32 @@ func Register(resourceType string, factory ProvisionerFactory) {
 	provisioners[resourceType] = factory
 }
 
+// DependsOn records the resource types whose resources a resourceType resource
+// can reference, and so must be created before it and deleted after it.
+// Provisioners call it from init() next to Register.
+func DependsOn(resourceType string, dependsOn ...string) {
+	dependencies[resourceType] = append(dependencies[resourceType], dependsOn...)
+}
+
+// Dependencies returns the resource types resourceType depends on, sorted, or
+// nil when it declares none.
+func Dependencies(resourceType string) []string {
+	deps := slices.Clone(dependencies[resourceType])
+	slices.Sort(deps)
+	return slices.Compact(deps)
+}
+
 // Get returns a provisioner for the given resource type
 func Get(resourceType string, clients *client.Clients) Provisioner {
 	factory, ok := provisioners[resourceType]
 	if !ok {
 		return nil
 	}
-	return &readAfterWrite{inner: factory(clients)}
+	p := factory(clients)
+	return &timed{inner: &noOpUpdate{inner: &replaceOnChange{fields: immutableFields(p), equivalent: equivalent(p), inner: &confirmDelete{skip: skipsDeleteConfirmation(p), inner: &readAfterWrite{inner: &defaultTags{untagged: untagged(p), inner: &compartmentName{
+		inner:   &canonical{inner: &sharedRead{inner: p}, spec: canonicalSpec(p)},
+		resolve: resolveCompartmentPath(clients),
+	}}}}}}}
 }
 
 // GetFactory returns the factory function for a resource type (for testing)
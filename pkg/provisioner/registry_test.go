@@ -0,0 +1,31 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package provisioner
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDependencies(t *testing.T) {
+	t.Cleanup(func() { delete(dependencies, "Test::Subnet") })
+
+	DependsOn("Test::Subnet", "Test::VCN", "Test::RouteTable")
+	DependsOn("Test::Subnet", "Test::VCN")
+
+	got := Dependencies("Test::Subnet")
+	want := []string{"Test::RouteTable", "Test::VCN"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	got[0] = "Test::Changed"
+	if deps := Dependencies("Test::Subnet"); deps[0] != "Test::RouteTable" {
+		t.Fatalf("expected the registry to be unaffected by callers, got %v", deps)
+	}
+	if deps := Dependencies("Test::Unknown"); deps != nil {
+		t.Fatalf("expected no dependencies, got %v", deps)
+	}
+}
@@ -0,0 +1,140 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build integration
+
+package provisioner_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/dns"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolverCreate_AdoptsVcnResolver(t *testing.T) {
+	vnet := newTestVirtualNetworkClient(t, map[route]canned{
+		{"GET", "/20160918/vcns/ocid1.vcn..aaa/dnsResolverAssociation"}: {200, `{"vcnId": "ocid1.vcn..aaa", "dnsResolverId": "ocid1.dnsresolver..aaa", "lifecycleState": "AVAILABLE"}`},
+	})
+	// Only the endpoint that isn't there yet is created
+	svc := newTestDnsClient(t, map[route]canned{
+		{"GET", "/20180115/resolvers/ocid1.dnsresolver..aaa/endpoints"}:  {200, `[]`},
+		{"POST", "/20180115/resolvers/ocid1.dnsresolver..aaa/endpoints"}: {201, newTestResolverEndpointBody("forwarder", "CREATING")},
+		{"PUT", "/20180115/resolvers/ocid1.dnsresolver..aaa"}:            {200, newTestResolverBody("UPDATING")},
+	})
+	p := dns.NewResolverProvisionerWithSvc(svc, vnet)
+
+	props, err := json.Marshal(map[string]any{
+		"VcnId":           "ocid1.vcn..aaa",
+		"AttachedViewIds": []string{"ocid1.dnsview..aaa"},
+		"Endpoints": []map[string]any{
+			{"name": "forwarder", "subnetId": "ocid1.subnet..aaa", "isForwarding": true, "isListening": false},
+		},
+		"Rules": []map[string]any{
+			{"action": "FORWARD", "sourceEndpointName": "forwarder", "destinationAddresses": []string{"10.0.0.53"}, "qnameCoverConditions": []string{"corp.example.com"}},
+		},
+	})
+	require.NoError(t, err)
+
+	result, err := p.Create(context.Background(), &resource.CreateRequest{
+		ResourceType: "OCI::DNS::Resolver",
+		Properties:   props,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+	assert.Equal(t, "ocid1.dnsresolver..aaa", result.ProgressResult.NativeID)
+
+	_, err = p.Create(context.Background(), &resource.CreateRequest{
+		ResourceType: "OCI::DNS::Resolver",
+		Properties:   []byte(`{"VcnId": "ocid1.vcn..aaa", "Endpoints": [{"name": "idle", "subnetId": "ocid1.subnet..aaa"}]}`),
+	})
+	assert.ErrorContains(t, err, "must be forwarding, listening or both")
+}
+
+func TestResolverRead_SurfacesEndpointAddresses(t *testing.T) {
+	svc := newTestDnsClient(t, map[route]canned{
+		{"GET", "/20180115/resolvers/ocid1.dnsresolver..aaa"}:                     {200, newTestResolverBody("ACTIVE")},
+		{"GET", "/20180115/resolvers/ocid1.dnsresolver..aaa/endpoints"}:           {200, fmt.Sprintf(`[%s]`, newTestResolverEndpointBody("forwarder", "ACTIVE"))},
+		{"GET", "/20180115/resolvers/ocid1.dnsresolver..aaa/endpoints/forwarder"}: {200, newTestResolverEndpointBody("forwarder", "ACTIVE")},
+	})
+	p := dns.NewResolverProvisionerWithSvc(svc, nil)
+
+	result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.dnsresolver..aaa"})
+	require.NoError(t, err)
+	assert.Empty(t, result.ErrorCode)
+
+	var props map[string]any
+	require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+	assert.Equal(t, "ocid1.vcn..aaa", props["VcnId"])
+	assert.Equal(t, []any{"ocid1.dnsview..aaa"}, props["AttachedViewIds"])
+	assert.Equal(t, []any{map[string]any{
+		"name":              "forwarder",
+		"subnetId":          "ocid1.subnet..aaa",
+		"isForwarding":      true,
+		"isListening":       false,
+		"forwardingAddress": "10.0.1.5",
+		"nsgIds":            []any{"ocid1.nsg..aaa"},
+	}}, props["Endpoints"])
+	assert.Equal(t, []any{map[string]any{
+		"action":               "FORWARD",
+		"sourceEndpointName":   "forwarder",
+		"destinationAddresses": []any{"10.0.0.53"},
+		"qnameCoverConditions": []any{"corp.example.com"},
+	}}, props["Rules"])
+}
+
+func TestResolverStatus_WaitsForEndpoints(t *testing.T) {
+	svc := newTestDnsClient(t, map[route]canned{
+		{"GET", "/20180115/resolvers/ocid1.dnsresolver..aaa"}:           {200, newTestResolverBody("ACTIVE")},
+		{"GET", "/20180115/resolvers/ocid1.dnsresolver..aaa/endpoints"}: {200, fmt.Sprintf(`[%s]`, newTestResolverEndpointBody("forwarder", "CREATING"))},
+	})
+	p := dns.NewResolverProvisionerWithSvc(svc, nil)
+
+	result, err := p.Status(context.Background(), &resource.StatusRequest{RequestID: "ocid1.dnsresolver..aaa"})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+	assert.Contains(t, result.ProgressResult.StatusMessage, "CREATING")
+}
+
+// Helpers
+
+func newTestResolverBody(lifecycleState string) string {
+	return fmt.Sprintf(`{
+		"id": "ocid1.dnsresolver..aaa",
+		"compartmentId": "ocid1.compartment..xxx",
+		"displayName": "vcn-resolver",
+		"attachedVcnId": "ocid1.vcn..aaa",
+		"defaultViewId": "ocid1.dnsview..default",
+		"attachedViews": [{"viewId": "ocid1.dnsview..aaa"}],
+		"rules": [{"action": "FORWARD", "sourceEndpointName": "forwarder", "destinationAddresses": ["10.0.0.53"], "qnameCoverConditions": ["corp.example.com"], "clientAddressConditions": []}],
+		"endpoints": [],
+		"isProtected": true,
+		"lifecycleState": %q,
+		"freeformTags": {},
+		"definedTags": {},
+		"timeCreated": "2025-01-01T00:00:00.000Z",
+		"timeUpdated": "2025-01-01T00:00:00.000Z"
+	}`, lifecycleState)
+}
+
+func newTestResolverEndpointBody(name, lifecycleState string) string {
+	return fmt.Sprintf(`{
+		"endpointType": "VNIC",
+		"name": %q,
+		"subnetId": "ocid1.subnet..aaa",
+		"isForwarding": true,
+		"isListening": false,
+		"forwardingAddress": "10.0.1.5",
+		"nsgIds": ["ocid1.nsg..aaa"],
+		"compartmentId": "ocid1.compartment..xxx",
+		"lifecycleState": %q,
+		"timeCreated": "2025-01-01T00:00:00.000Z",
+		"timeUpdated": "2025-01-01T00:00:00.000Z"
+	}`, name, lifecycleState)
+}
@@ -131,6 +131,24 @@ func TestRouteTableList(t *testing.T) {
 	assert.Equal(t, []string{"ocid1.routetable..aaa"}, result.NativeIDs)
 }
 
+func TestRouteTableListPaginated(t *testing.T) {
+	host := newTestPagedDispatcher(t, nil, map[route][]canned{
+		{"GET", "/20160918/routeTables"}: {
+			{200, `[{"id": "ocid1.routetable..aaa", "compartmentId": "ocid1.compartment..xxx", "vcnId": "ocid1.vcn..aaa", "routeRules": []}]`},
+			{200, `[{"id": "ocid1.routetable..bbb", "compartmentId": "ocid1.compartment..xxx", "vcnId": "ocid1.vcn..aaa", "routeRules": []}]`},
+			{200, `[{"id": "ocid1.routetable..ccc", "compartmentId": "ocid1.compartment..xxx", "vcnId": "ocid1.vcn..aaa", "routeRules": []}]`},
+		},
+	})
+	p := core.NewRouteTableProvisionerWithSvc(newTestVirtualNetworkClientAt(t, host))
+
+	result, err := p.List(context.Background(), &resource.ListRequest{
+		ResourceType:         "OCI::Core::RouteTable",
+		AdditionalProperties: map[string]string{"CompartmentId": "ocid1.compartment..xxx"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ocid1.routetable..aaa", "ocid1.routetable..bbb", "ocid1.routetable..ccc"}, result.NativeIDs)
+}
+
 // Helpers
 
 func newTestRouteTableBody(lifecycleState string) string {
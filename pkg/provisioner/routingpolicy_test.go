@@ -0,0 +1,86 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build integration
+
+package provisioner_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/loadbalancer"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoutingPolicyRead(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc := newTestLoadBalancerClient(t, map[route]canned{
+			{"GET", "/20170115/loadBalancers/ocid1.loadbalancer..aaa/routingPolicies/paths"}: {200, `{
+				"name": "paths",
+				"conditionLanguageVersion": "V1",
+				"rules": [{
+					"name": "api",
+					"condition": "any(http.request.url.path sw (i '/api'))",
+					"actions": [{"name": "FORWARD_TO_BACKENDSET", "backendSetName": "api"}]
+				}]
+			}`},
+		})
+		p := loadbalancer.NewRoutingPolicyProvisionerWithSvc(svc)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.loadbalancer..aaa/paths"})
+		require.NoError(t, err)
+		assert.Empty(t, result.ErrorCode)
+
+		var props map[string]any
+		require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+		assert.Equal(t, "paths", props["Name"])
+		assert.Equal(t, "V1", props["ConditionLanguageVersion"])
+		rule := props["Rules"].([]any)[0].(map[string]any)
+		assert.Equal(t, "api", rule["name"])
+		action := rule["actions"].([]any)[0].(map[string]any)
+		assert.Equal(t, "api", action["backendSetName"])
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		svc := newTestLoadBalancerClient(t, map[route]canned{
+			{"GET", "/20170115/loadBalancers/ocid1.loadbalancer..aaa/routingPolicies/missing"}: {404, `{"code":"NotAuthorizedOrNotFound","message":"not found"}`},
+		})
+		p := loadbalancer.NewRoutingPolicyProvisionerWithSvc(svc)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.loadbalancer..aaa/missing"})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationErrorCodeNotFound, result.ErrorCode)
+	})
+}
+
+func TestRuleSetRead(t *testing.T) {
+	svc := newTestLoadBalancerClient(t, map[route]canned{
+		{"GET", "/20170115/loadBalancers/ocid1.loadbalancer..aaa/ruleSets/headers"}: {200, `{
+			"name": "headers",
+			"items": [
+				{"action": "ADD_HTTP_REQUEST_HEADER", "header": "X-Forwarded-Proto", "value": "https"},
+				{"action": "REDIRECT", "responseCode": 301,
+				 "conditions": [{"attributeName": "PATH", "attributeValue": "/old", "operator": "PREFIX_MATCH"}],
+				 "redirectUri": {"protocol": "HTTPS", "port": 443, "path": "/new"}}
+			]
+		}`},
+	})
+	p := loadbalancer.NewRuleSetProvisionerWithSvc(svc)
+
+	result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.loadbalancer..aaa/headers"})
+	require.NoError(t, err)
+
+	var props map[string]any
+	require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+	items := props["Items"].([]any)
+	require.Len(t, items, 2)
+	assert.Equal(t, "ADD_HTTP_REQUEST_HEADER", items[0].(map[string]any)["action"])
+	redirect := items[1].(map[string]any)
+	assert.Equal(t, float64(301), redirect["responseCode"])
+	assert.Equal(t, "/new", redirect["redirectUri"].(map[string]any)["path"])
+}
@@ -0,0 +1,147 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build integration
+
+package provisioner_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/dns"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRrSetPath = "/20180115/zones/example.com/records/www.example.com/A"
+
+const testRrSetBody = `{"items": [
+	{"domain": "www.example.com", "rdata": "192.0.2.10", "rtype": "A", "ttl": 300, "recordHash": "h1", "isProtected": false, "rrsetVersion": "2"},
+	{"domain": "www.example.com", "rdata": "192.0.2.11", "rtype": "A", "ttl": 300, "recordHash": "h2", "isProtected": false, "rrsetVersion": "2"}
+]}`
+
+func TestRrSetCreate(t *testing.T) {
+	svc := newTestDnsClient(t, map[route]canned{
+		{"PATCH", "/20180115/zones/example.com/records/www.example.com"}: {200, testRrSetBody},
+	})
+	p := dns.NewRrSetProvisionerWithSvc(svc)
+
+	props, err := json.Marshal(map[string]any{
+		"ZoneNameOrId": "example.com",
+		"Domain":       "www.example.com",
+		"Rtype":        "A",
+		"Items": []map[string]any{
+			{"rdata": "192.0.2.10", "ttl": 300},
+			{"rdata": "192.0.2.11", "ttl": 300, "rtype": "A"},
+		},
+	})
+	require.NoError(t, err)
+
+	result, err := p.Create(context.Background(), &resource.CreateRequest{
+		ResourceType: "OCI::DNS::RrSet",
+		Properties:   props,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	assert.Equal(t, "example.com/www.example.com/A", result.ProgressResult.NativeID)
+}
+
+func TestRrSetCreate_RtypeMismatch(t *testing.T) {
+	p := dns.NewRrSetProvisionerWithSvc(newTestDnsClient(t, map[route]canned{}))
+
+	_, err := p.Create(context.Background(), &resource.CreateRequest{
+		ResourceType: "OCI::DNS::RrSet",
+		Properties:   []byte(`{"ZoneNameOrId": "example.com", "Domain": "www.example.com", "Rtype": "A", "Items": [{"rdata": "web.example.com.", "ttl": 300, "rtype": "CNAME"}]}`),
+	})
+	assert.ErrorContains(t, err, "rtype CNAME but the set is A")
+}
+
+func TestRrSetRead(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc := newTestDnsClient(t, map[route]canned{
+			{"GET", testRrSetPath}: {200, testRrSetBody},
+		})
+		p := dns.NewRrSetProvisionerWithSvc(svc)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "example.com/www.example.com/A"})
+		require.NoError(t, err)
+		assert.Empty(t, result.ErrorCode)
+
+		var props map[string]any
+		require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+		assert.Equal(t, "example.com", props["ZoneNameOrId"])
+		assert.Equal(t, "www.example.com", props["Domain"])
+		assert.Equal(t, "A", props["Rtype"])
+		assert.Equal(t, []any{
+			map[string]any{"rdata": "192.0.2.10", "rtype": "A", "ttl": float64(300)},
+			map[string]any{"rdata": "192.0.2.11", "rtype": "A", "ttl": float64(300)},
+		}, props["Items"])
+	})
+
+	t.Run("empty set is not found", func(t *testing.T) {
+		svc := newTestDnsClient(t, map[route]canned{
+			{"GET", testRrSetPath}: {200, `{"items": []}`},
+		})
+		p := dns.NewRrSetProvisionerWithSvc(svc)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "example.com/www.example.com/A"})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationErrorCodeNotFound, result.ErrorCode)
+	})
+}
+
+func TestRrSetUpdate(t *testing.T) {
+	svc := newTestDnsClient(t, map[route]canned{
+		{"PUT", testRrSetPath}: {200, testRrSetBody},
+	})
+	p := dns.NewRrSetProvisionerWithSvc(svc)
+
+	desired, err := json.Marshal(map[string]any{
+		"ZoneNameOrId": "example.com",
+		"Domain":       "www.example.com",
+		"Rtype":        "A",
+		"Items":        []map[string]any{{"rdata": "192.0.2.10", "ttl": 300}, {"rdata": "192.0.2.11", "ttl": 300}},
+	})
+	require.NoError(t, err)
+
+	result, err := p.Update(context.Background(), &resource.UpdateRequest{
+		NativeID:          "example.com/www.example.com/A",
+		DesiredProperties: desired,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+}
+
+func TestRrSetDelete_NotFoundIsSuccess(t *testing.T) {
+	svc := newTestDnsClient(t, map[route]canned{
+		{"DELETE", testRrSetPath}: {404, `{"code": "NotAuthorizedOrNotFound", "message": "not found"}`},
+	})
+	p := dns.NewRrSetProvisionerWithSvc(svc)
+
+	result, err := p.Delete(context.Background(), &resource.DeleteRequest{NativeID: "example.com/www.example.com/A"})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+}
+
+func TestRrSetList(t *testing.T) {
+	svc := newTestDnsClient(t, map[route]canned{
+		{"GET", "/20180115/zones/ocid1.dns-zone..aaa/records"}: {200, `{"items": [
+			{"domain": "example.com", "rdata": "ns1.p68.dns.oraclecloud.net.", "rtype": "NS", "ttl": 86400, "isProtected": true},
+			{"domain": "www.example.com", "rdata": "192.0.2.10", "rtype": "A", "ttl": 300, "isProtected": false},
+			{"domain": "www.example.com", "rdata": "192.0.2.11", "rtype": "A", "ttl": 300, "isProtected": false},
+			{"domain": "www.example.com", "rdata": "\"v=spf1 -all\"", "rtype": "TXT", "ttl": 300, "isProtected": false}
+		]}`},
+	})
+	p := dns.NewRrSetProvisionerWithSvc(svc)
+
+	result, err := p.List(context.Background(), &resource.ListRequest{
+		ResourceType:         "OCI::DNS::RrSet",
+		AdditionalProperties: map[string]string{"ZoneId": "ocid1.dns-zone..aaa"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ocid1.dns-zone..aaa/www.example.com/A", "ocid1.dns-zone..aaa/www.example.com/TXT"}, result.NativeIDs)
+}
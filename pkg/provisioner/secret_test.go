@@ -0,0 +1,211 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build integration
+
+package provisioner_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ocisecrets "github.com/oracle/oci-go-sdk/v65/secrets"
+	ocivault "github.com/oracle/oci-go-sdk/v65/vault"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/vault"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretCreate(t *testing.T) {
+	svc := newTestVaultsClient(t, map[route]canned{
+		{"POST", "/20180608/secrets"}: {200, newTestSecretBody("CREATING", "")},
+	})
+	p := vault.NewSecretProvisionerWithSvc(svc, nil)
+
+	props, err := json.Marshal(map[string]any{
+		"CompartmentId": "ocid1.compartment..xxx",
+		"VaultId":       "ocid1.vault..aaa",
+		"KeyId":         "ocid1.key..aaa",
+		"SecretName":    "db-password",
+		"SecretContent": "aHVudGVyMg==",
+	})
+	require.NoError(t, err)
+
+	result, err := p.Create(context.Background(), &resource.CreateRequest{
+		ResourceType: "OCI::Vault::Secret",
+		Properties:   props,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+	assert.Equal(t, "ocid1.vaultsecret..aaa", result.ProgressResult.RequestID)
+}
+
+func TestSecretRead_ReturnsMetadataOnly(t *testing.T) {
+	t.Run("active", func(t *testing.T) {
+		svc := newTestVaultsClient(t, map[route]canned{
+			{"GET", "/20180608/secrets/ocid1.vaultsecret..aaa"}: {200, newTestSecretBody("ACTIVE", "")},
+		})
+		p := vault.NewSecretProvisionerWithSvc(svc, nil)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.vaultsecret..aaa"})
+		require.NoError(t, err)
+
+		var props map[string]any
+		require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+		assert.Equal(t, "db-password", props["SecretName"])
+		assert.Equal(t, float64(3), props["VersionNumber"])
+		assert.NotContains(t, props, "SecretContent")
+	})
+
+	t.Run("pending deletion", func(t *testing.T) {
+		svc := newTestVaultsClient(t, map[route]canned{
+			{"GET", "/20180608/secrets/ocid1.vaultsecret..aaa"}: {200, newTestSecretBody("PENDING_DELETION", "2025-02-01T00:00:00.000Z")},
+		})
+		p := vault.NewSecretProvisionerWithSvc(svc, nil)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.vaultsecret..aaa"})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationErrorCodeNotFound, result.ErrorCode)
+	})
+}
+
+func TestSecretUpdate_SkipsUnchangedContent(t *testing.T) {
+	tests := map[string]struct {
+		bundle      canned
+		wantContent any // the secretContent UpdateSecret sends; nil means none
+	}{
+		"unchanged": {
+			bundle: canned{200, newTestSecretBundleBody("aHVudGVyMg==")},
+		},
+		"changed": {
+			bundle:      canned{200, newTestSecretBundleBody("b2xkLXBhc3N3b3Jk")},
+			wantContent: map[string]any{"contentType": "BASE64", "content": "aHVudGVyMg=="},
+		},
+		"bundle_not_readable": {
+			bundle:      canned{403, `{"code":"NotAllowed","message":"not allowed to read secret bundles"}`},
+			wantContent: map[string]any{"contentType": "BASE64", "content": "aHVudGVyMg=="},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var sent map[string]any
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.Method + " " + r.URL.Path {
+				case "GET /20190301/secretbundles/ocid1.vaultsecret..aaa":
+					w.WriteHeader(tt.bundle.status)
+					fmt.Fprint(w, tt.bundle.body)
+				case "PUT /20180608/secrets/ocid1.vaultsecret..aaa":
+					body, _ := io.ReadAll(r.Body)
+					_ = json.Unmarshal(body, &sent)
+					fmt.Fprint(w, newTestSecretBody("UPDATING", ""))
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+					fmt.Fprint(w, `{"code":"NotFound","message":"not found"}`)
+				}
+			}))
+			t.Cleanup(srv.Close)
+			p := vault.NewSecretProvisionerWithSvc(newTestVaultsClientAt(t, srv.URL), newTestSecretsClientAt(t, srv.URL))
+
+			props, err := json.Marshal(map[string]any{"SecretContent": "aHVudGVyMg==", "Description": "rotated"})
+			require.NoError(t, err)
+
+			result, err := p.Update(context.Background(), &resource.UpdateRequest{
+				NativeID:          "ocid1.vaultsecret..aaa",
+				ResourceType:      "OCI::Vault::Secret",
+				DesiredProperties: props,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+			assert.Equal(t, "rotated", sent["description"])
+			if tt.wantContent == nil {
+				assert.NotContains(t, sent, "secretContent")
+			} else {
+				assert.Equal(t, tt.wantContent, sent["secretContent"])
+			}
+		})
+	}
+}
+
+func TestSecretDelete_SchedulesDeletion(t *testing.T) {
+	svc := newTestVaultsClient(t, map[route]canned{
+		{"GET", "/20180608/secrets/ocid1.vaultsecret..aaa"}:                           {200, newTestSecretBody("ACTIVE", "")},
+		{"POST", "/20180608/secrets/ocid1.vaultsecret..aaa/actions/scheduleDeletion"}: {200, `{}`},
+	})
+	p := vault.NewSecretProvisionerWithSvc(svc, nil)
+
+	result, err := p.Delete(context.Background(), &resource.DeleteRequest{NativeID: "ocid1.vaultsecret..aaa"})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+
+	svc = newTestVaultsClient(t, map[route]canned{
+		{"GET", "/20180608/secrets/ocid1.vaultsecret..aaa"}: {200, newTestSecretBody("PENDING_DELETION", "2025-02-01T00:00:00.000Z")},
+	})
+	p = vault.NewSecretProvisionerWithSvc(svc, nil)
+
+	status, err := p.Status(context.Background(), &resource.StatusRequest{RequestID: "ocid1.vaultsecret..aaa"})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, status.ProgressResult.OperationStatus)
+	assert.Equal(t, "Secret is pending deletion until 2025-02-01T00:00:00Z", status.ProgressResult.StatusMessage)
+	assert.Nil(t, status.ProgressResult.ResourceProperties)
+}
+
+// Helpers
+
+func newTestVaultsClient(t *testing.T, responses map[route]canned) *ocivault.VaultsClient {
+	t.Helper()
+	return newTestVaultsClientAt(t, newTestDispatcher(t, responses))
+}
+
+func newTestVaultsClientAt(t *testing.T, host string) *ocivault.VaultsClient {
+	t.Helper()
+	c, err := ocivault.NewVaultsClientWithConfigurationProvider(fakeOCIConfigProvider(t))
+	require.NoError(t, err)
+	applyTestRetryPolicy(&c)
+	c.Host = host
+	return &c
+}
+
+func newTestSecretsClientAt(t *testing.T, host string) *ocisecrets.SecretsClient {
+	t.Helper()
+	c, err := ocisecrets.NewSecretsClientWithConfigurationProvider(fakeOCIConfigProvider(t))
+	require.NoError(t, err)
+	applyTestRetryPolicy(&c)
+	c.Host = host
+	return &c
+}
+
+func newTestSecretBundleBody(content string) string {
+	return fmt.Sprintf(`{
+		"secretId": "ocid1.vaultsecret..aaa",
+		"versionNumber": 3,
+		"stages": ["CURRENT", "LATEST"],
+		"secretBundleContent": {"contentType": "BASE64", "content": %q}
+	}`, content)
+}
+
+func newTestSecretBody(lifecycleState, timeOfDeletion string) string {
+	deletion := "null"
+	if timeOfDeletion != "" {
+		deletion = fmt.Sprintf("%q", timeOfDeletion)
+	}
+	return fmt.Sprintf(`{
+		"id": "ocid1.vaultsecret..aaa",
+		"compartmentId": "ocid1.compartment..xxx",
+		"vaultId": "ocid1.vault..aaa",
+		"keyId": "ocid1.key..aaa",
+		"secretName": "db-password",
+		"currentVersionNumber": 3,
+		"lifecycleState": %q,
+		"timeOfDeletion": %s,
+		"timeCreated": "2025-01-01T00:00:00.000Z"
+	}`, lifecycleState, deletion)
+}
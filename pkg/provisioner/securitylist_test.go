@@ -10,6 +10,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/core"
@@ -136,6 +137,125 @@ func TestSecurityListList(t *testing.T) {
 	assert.Equal(t, []string{"ocid1.securitylist..aaa"}, result.NativeIDs)
 }
 
+func TestSecurityListReadIgnoresRuleOrder(t *testing.T) {
+	sshRule := `{"protocol": "6", "source": "10.0.0.0/16", "tcpOptions": {"destinationPortRange": {"min": 22, "max": 22}}}`
+	httpsRule := `{"protocol": "6", "source": "0.0.0.0/0", "tcpOptions": {"destinationPortRange": {"min": 443, "max": 443}}}`
+	icmpRule := `{"protocol": "1", "source": "0.0.0.0/0", "icmpOptions": {"type": 3, "code": 4}}`
+	body := func(rules ...string) string {
+		return fmt.Sprintf(`{
+			"id": "ocid1.securitylist..aaa",
+			"compartmentId": "ocid1.compartment..xxx",
+			"vcnId": "ocid1.vcn..aaa",
+			"displayName": "test-sl",
+			"ingressSecurityRules": [%s],
+			"egressSecurityRules": [],
+			"lifecycleState": "AVAILABLE"
+		}`, strings.Join(rules, ","))
+	}
+
+	read := func(rules ...string) map[string]any {
+		svc := newTestVirtualNetworkClient(t, map[route]canned{
+			{"GET", "/20160918/securityLists/ocid1.securitylist..aaa"}: {200, body(rules...)},
+		})
+		p := core.NewSecurityListProvisionerWithSvc(svc)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.securitylist..aaa"})
+		require.NoError(t, err)
+		var props map[string]any
+		require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+		return props
+	}
+
+	first := read(sshRule, httpsRule, icmpRule)
+	second := read(icmpRule, httpsRule, sshRule)
+	assert.Equal(t, first["IngressSecurityRules"], second["IngressSecurityRules"])
+
+	rules := first["IngressSecurityRules"].([]any)
+	require.Len(t, rules, 3)
+	assert.Equal(t, "1", rules[0].(map[string]any)["protocol"])
+	assert.Equal(t, "0.0.0.0/0", rules[1].(map[string]any)["source"])
+	assert.Equal(t, "10.0.0.0/16", rules[2].(map[string]any)["source"])
+}
+
+func TestSecurityListAllProtocolAndIcmpTypeOnly(t *testing.T) {
+	t.Run("read", func(t *testing.T) {
+		svc := newTestVirtualNetworkClient(t, map[route]canned{
+			{"GET", "/20160918/securityLists/ocid1.securitylist..aaa"}: {200, `{
+				"id": "ocid1.securitylist..aaa",
+				"compartmentId": "ocid1.compartment..xxx",
+				"vcnId": "ocid1.vcn..aaa",
+				"displayName": "test-sl",
+				"ingressSecurityRules": [{"protocol": "1", "source": "0.0.0.0/0", "icmpOptions": {"type": 8}}],
+				"egressSecurityRules": [{"protocol": "all", "destination": "0.0.0.0/0", "tcpOptions": {}, "udpOptions": {}}],
+				"lifecycleState": "AVAILABLE"
+			}`},
+		})
+		p := core.NewSecurityListProvisionerWithSvc(svc)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.securitylist..aaa"})
+		require.NoError(t, err)
+		var props map[string]any
+		require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+
+		ingress := props["IngressSecurityRules"].([]any)
+		assert.Equal(t, map[string]any{"type": float64(8)}, ingress[0].(map[string]any)["icmpOptions"])
+		egress := props["EgressSecurityRules"].([]any)
+		assert.Equal(t, map[string]any{"protocol": "all", "destination": "0.0.0.0/0"}, egress[0])
+	})
+
+	t.Run("all_rejects_port_options", func(t *testing.T) {
+		p := core.NewSecurityListProvisionerWithSvc(newTestVirtualNetworkClient(t, nil))
+
+		props, err := json.Marshal(map[string]any{
+			"CompartmentId": "ocid1.compartment..xxx",
+			"VcnId":         "ocid1.vcn..aaa",
+			"EgressSecurityRules": []map[string]any{
+				{
+					"protocol":    "all",
+					"destination": "0.0.0.0/0",
+					"udpOptions":  map[string]any{"destinationPortRange": map[string]any{"min": 53, "max": 53}},
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = p.Create(context.Background(), &resource.CreateRequest{
+			ResourceType: "OCI::Core::SecurityList",
+			Properties:   props,
+		})
+		assert.ErrorContains(t, err, `protocol "all"`)
+	})
+}
+
+func TestSecurityListStatus(t *testing.T) {
+	t.Run("attaches_properties", func(t *testing.T) {
+		svc := newTestVirtualNetworkClient(t, map[route]canned{
+			{"GET", "/20160918/securityLists/ocid1.securitylist..aaa"}: {200, newTestSecurityListBody("AVAILABLE")},
+		})
+		p := core.NewSecurityListProvisionerWithSvc(svc)
+
+		result, err := p.Status(context.Background(), &resource.StatusRequest{NativeID: "ocid1.securitylist..aaa"})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+
+		var props map[string]any
+		require.NoError(t, json.Unmarshal(result.ProgressResult.ResourceProperties, &props))
+		assert.Equal(t, "test-sl", props["DisplayName"])
+	})
+
+	t.Run("deleted", func(t *testing.T) {
+		svc := newTestVirtualNetworkClient(t, map[route]canned{
+			{"GET", "/20160918/securityLists/ocid1.securitylist..aaa"}: {404, `{"code":"NotAuthorizedOrNotFound","message":"not found"}`},
+		})
+		p := core.NewSecurityListProvisionerWithSvc(svc)
+
+		result, err := p.Status(context.Background(), &resource.StatusRequest{NativeID: "ocid1.securitylist..aaa"})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+		assert.Empty(t, result.ProgressResult.ResourceProperties)
+	})
+}
+
 // Helpers
 
 func newTestSecurityListBody(lifecycleState string) string {
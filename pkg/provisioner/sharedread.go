@@ -0,0 +1,47 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package provisioner
+
+import (
+	"context"
+
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+// sharedRead is the innermost decorator. Within an Update, noOpUpdate puts a
+// read cache in the context, and every Read ahead of the write, the decorators'
+// and util.ApplyPatchDocument's alike, is served from one util.SharedRead.
+// Once the provisioner's Update returns the cached Read is stale, so it is
+// dropped and readAfterWrite reads the resource as the write left it.
+type sharedRead struct {
+	inner Provisioner
+}
+
+func (s *sharedRead) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	return s.inner.Create(ctx, request)
+}
+
+func (s *sharedRead) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	result, err := s.inner.Update(ctx, request)
+	util.Forget(ctx, util.SharedReadKey(&resource.ReadRequest{NativeID: request.NativeID, ResourceType: request.ResourceType}))
+	return result, err
+}
+
+func (s *sharedRead) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	return s.inner.Delete(ctx, request)
+}
+
+func (s *sharedRead) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	return s.inner.Status(ctx, request)
+}
+
+func (s *sharedRead) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	return util.SharedRead(ctx, request, s.inner.Read)
+}
+
+func (s *sharedRead) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	return s.inner.List(ctx, request)
+}
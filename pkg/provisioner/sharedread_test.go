@@ -0,0 +1,60 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package provisioner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+// patchingProvisioner counts its Reads and, like the real provisioners, builds
+// its Update from util.ApplyPatchDocument.
+type patchingProvisioner struct {
+	mockProvisioner
+	reads int
+}
+
+func (p *patchingProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	p.reads++
+	return p.mockProvisioner.Read(ctx, request)
+}
+
+func (p *patchingProvisioner) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	if _, err := util.ApplyPatchDocument(ctx, request, p.Read); err != nil {
+		return nil, err
+	}
+	return p.mockProvisioner.Update(ctx, request)
+}
+
+func TestSharedRead_UpdateReadsOnceBeforeWriting(t *testing.T) {
+	inner := &patchingProvisioner{mockProvisioner: mockProvisioner{
+		updateResult: &resource.UpdateResult{
+			ProgressResult: &resource.ProgressResult{
+				OperationStatus: resource.OperationStatusSuccess,
+				NativeID:        "ocid1.volume.oc1..abc",
+			},
+		},
+		readResult: &resource.ReadResult{Properties: `{"Id":"ocid1.volume.oc1..abc","DisplayName":"data","SizeInGBs":50}`},
+	}}
+
+	p := &noOpUpdate{inner: &replaceOnChange{fields: []string{"SizeInGBs"}, inner: &readAfterWrite{inner: &sharedRead{inner: inner}}}}
+	_, err := p.Update(context.Background(), noOpUpdateRequest("OCI::Core::Volume",
+		`[{"op":"replace","path":"/DisplayName","value":"renamed"}]`))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !inner.updateCalled {
+		t.Fatal("expected Update to be called for a real change")
+	}
+	// One Read shared by noOpUpdate, replaceOnChange and ApplyPatchDocument,
+	// and one by readAfterWrite after the write.
+	if inner.reads != 2 {
+		t.Fatalf("expected 2 Reads, got %d", inner.reads)
+	}
+}
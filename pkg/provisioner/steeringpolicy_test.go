@@ -0,0 +1,179 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build integration
+
+package provisioner_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	ocidns "github.com/oracle/oci-go-sdk/v65/dns"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/dns"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSteeringPolicyAnswers and testSteeringPolicyRules are a failover policy
+// in the API's JSON form, covering every rule type's fields.
+const testSteeringPolicyAnswers = `[
+	{"name": "primary", "rtype": "A", "rdata": "192.0.2.1", "pool": "primary", "isDisabled": false},
+	{"name": "secondary", "rtype": "A", "rdata": "192.0.2.2", "pool": "secondary", "isDisabled": false}
+]`
+
+const testSteeringPolicyRules = `[
+	{"ruleType": "FILTER", "defaultAnswerData": [{"answerCondition": "answer.isDisabled != true", "shouldKeep": true}]},
+	{"ruleType": "HEALTH"},
+	{"ruleType": "PRIORITY", "cases": [{"caseCondition": "query.client.geoKey in (geoKey '1')", "answerData": [{"answerCondition": "answer.pool == 'secondary'", "value": 1}]}],
+	 "defaultAnswerData": [{"answerCondition": "answer.pool == 'primary'", "value": 1}, {"answerCondition": "answer.pool == 'secondary'", "value": 2}]},
+	{"ruleType": "WEIGHTED", "defaultAnswerData": [{"answerCondition": "answer.pool == 'primary'", "value": 80}]},
+	{"ruleType": "LIMIT", "description": "one answer", "cases": [{"caseCondition": "query.client.address in (subnet '10.0.0.0/8')", "count": 2}], "defaultCount": 1}
+]`
+
+func TestSteeringPolicyCreate(t *testing.T) {
+	svc := newTestDnsClient(t, map[route]canned{
+		{"POST", "/20180115/steeringPolicies"}: {201, newTestSteeringPolicyBody("CREATING")},
+	})
+	p := dns.NewSteeringPolicyProvisionerWithSvc(svc)
+
+	var answers, rules []any
+	require.NoError(t, json.Unmarshal([]byte(testSteeringPolicyAnswers), &answers))
+	require.NoError(t, json.Unmarshal([]byte(testSteeringPolicyRules), &rules))
+	props, err := json.Marshal(map[string]any{
+		"CompartmentId": "ocid1.compartment..xxx",
+		"DisplayName":   "failover",
+		"Template":      "FAILOVER",
+		"Ttl":           30,
+		"Answers":       answers,
+		"Rules":         rules,
+	})
+	require.NoError(t, err)
+
+	result, err := p.Create(context.Background(), &resource.CreateRequest{
+		ResourceType: "OCI::DNS::SteeringPolicy",
+		Properties:   props,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+	assert.Equal(t, "ocid1.dnspolicy..aaa", result.ProgressResult.RequestID)
+
+	_, err = p.Create(context.Background(), &resource.CreateRequest{
+		ResourceType: "OCI::DNS::SteeringPolicy",
+		Properties:   []byte(`{"CompartmentId": "ocid1.compartment..xxx", "DisplayName": "x", "Template": "CUSTOM", "Rules": [{"ruleType": "LIMIT", "cases": [{}]}]}`),
+	})
+	assert.ErrorContains(t, err, "needs a count")
+}
+
+func TestSteeringPolicyRead_RoundTripsAnswersAndRules(t *testing.T) {
+	svc := newTestDnsClient(t, map[route]canned{
+		{"GET", "/20180115/steeringPolicies/ocid1.dnspolicy..aaa"}: {200, newTestSteeringPolicyBody("ACTIVE")},
+	})
+	p := dns.NewSteeringPolicyProvisionerWithSvc(svc)
+
+	result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.dnspolicy..aaa"})
+	require.NoError(t, err)
+	assert.Empty(t, result.ErrorCode)
+
+	var props map[string]any
+	require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+	assert.Equal(t, "FAILOVER", props["Template"])
+	assert.Equal(t, float64(30), props["Ttl"])
+
+	var wantAnswers, wantRules []any
+	require.NoError(t, json.Unmarshal([]byte(testSteeringPolicyAnswers), &wantAnswers))
+	require.NoError(t, json.Unmarshal([]byte(testSteeringPolicyRules), &wantRules))
+	assert.Equal(t, wantAnswers, props["Answers"])
+	assert.Equal(t, wantRules, props["Rules"])
+}
+
+func TestSteeringPolicyAttachmentStatus(t *testing.T) {
+	t.Run("creating", func(t *testing.T) {
+		svc := newTestDnsClient(t, map[route]canned{
+			{"GET", "/20180115/steeringPolicyAttachments/ocid1.dnspolicyattachment..aaa"}: {200, newTestSteeringPolicyAttachmentBody("CREATING")},
+		})
+		p := dns.NewSteeringPolicyAttachmentProvisionerWithSvc(svc)
+
+		result, err := p.Status(context.Background(), &resource.StatusRequest{RequestID: "ocid1.dnspolicyattachment..aaa"})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+	})
+
+	t.Run("active", func(t *testing.T) {
+		svc := newTestDnsClient(t, map[route]canned{
+			{"GET", "/20180115/steeringPolicyAttachments/ocid1.dnspolicyattachment..aaa"}: {200, newTestSteeringPolicyAttachmentBody("ACTIVE")},
+		})
+		p := dns.NewSteeringPolicyAttachmentProvisionerWithSvc(svc)
+
+		result, err := p.Status(context.Background(), &resource.StatusRequest{RequestID: "ocid1.dnspolicyattachment..aaa"})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+
+		var props map[string]any
+		require.NoError(t, json.Unmarshal(result.ProgressResult.ResourceProperties, &props))
+		assert.Equal(t, "www.example.com", props["DomainName"])
+		assert.Equal(t, []any{"A"}, props["Rtypes"])
+	})
+}
+
+func TestSteeringPolicyAttachmentList(t *testing.T) {
+	svc := newTestDnsClient(t, map[route]canned{
+		{"GET", "/20180115/steeringPolicies/ocid1.dnspolicy..aaa"}: {200, newTestSteeringPolicyBody("ACTIVE")},
+		{"GET", "/20180115/steeringPolicyAttachments"}: {200, fmt.Sprintf(`[%s, %s]`,
+			newTestSteeringPolicyAttachmentBody("ACTIVE"),
+			`{"id": "ocid1.dnspolicyattachment..bbb", "lifecycleState": "DELETING"}`,
+		)},
+	})
+	p := dns.NewSteeringPolicyAttachmentProvisionerWithSvc(svc)
+
+	result, err := p.List(context.Background(), &resource.ListRequest{
+		ResourceType:         "OCI::DNS::SteeringPolicyAttachment",
+		AdditionalProperties: map[string]string{"SteeringPolicyId": "ocid1.dnspolicy..aaa"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ocid1.dnspolicyattachment..aaa"}, result.NativeIDs)
+}
+
+// Helpers
+
+func newTestDnsClient(t *testing.T, responses map[route]canned) *ocidns.DnsClient {
+	t.Helper()
+	host := newTestDispatcher(t, responses)
+	c, err := ocidns.NewDnsClientWithConfigurationProvider(fakeOCIConfigProvider(t))
+	require.NoError(t, err)
+	applyTestRetryPolicy(&c)
+	c.Host = host
+	return &c
+}
+
+func newTestSteeringPolicyBody(lifecycleState string) string {
+	return fmt.Sprintf(`{
+		"id": "ocid1.dnspolicy..aaa",
+		"compartmentId": "ocid1.compartment..xxx",
+		"displayName": "failover",
+		"template": "FAILOVER",
+		"ttl": 30,
+		"answers": %s,
+		"rules": %s,
+		"lifecycleState": %q,
+		"timeCreated": "2025-01-01T00:00:00.000Z"
+	}`, testSteeringPolicyAnswers, testSteeringPolicyRules, lifecycleState)
+}
+
+func newTestSteeringPolicyAttachmentBody(lifecycleState string) string {
+	return fmt.Sprintf(`{
+		"id": "ocid1.dnspolicyattachment..aaa",
+		"compartmentId": "ocid1.compartment..xxx",
+		"steeringPolicyId": "ocid1.dnspolicy..aaa",
+		"zoneId": "ocid1.dns-zone..aaa",
+		"domainName": "www.example.com",
+		"displayName": "www",
+		"rtypes": ["A"],
+		"lifecycleState": %q,
+		"timeCreated": "2025-01-01T00:00:00.000Z"
+	}`, lifecycleState)
+}
@@ -23,7 +23,7 @@ func TestSubnetRead(t *testing.T) {
 		svc := newTestVirtualNetworkClient(t, map[route]canned{
 			{"GET", "/20160918/subnets/ocid1.subnet..aaa"}: {200, newTestSubnetBody("AVAILABLE")},
 		})
-		p := core.NewSubnetProvisionerWithSvc(svc)
+		p := core.NewSubnetProvisionerWithSvc(svc, nil)
 
 		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.subnet..aaa"})
 		require.NoError(t, err)
@@ -32,13 +32,14 @@ func TestSubnetRead(t *testing.T) {
 		var props map[string]any
 		require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
 		assert.Equal(t, "10.0.1.0/24", props["CidrBlock"])
+		assert.Equal(t, float64(253), props["UsableIpCount"])
 	})
 
 	t.Run("not_found", func(t *testing.T) {
 		svc := newTestVirtualNetworkClient(t, map[route]canned{
 			{"GET", "/20160918/subnets/ocid1.subnet..missing"}: {404, `{"code":"NotAuthorizedOrNotFound","message":"not found"}`},
 		})
-		p := core.NewSubnetProvisionerWithSvc(svc)
+		p := core.NewSubnetProvisionerWithSvc(svc, nil)
 
 		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.subnet..missing"})
 		require.NoError(t, err)
@@ -49,7 +50,7 @@ func TestSubnetRead(t *testing.T) {
 		svc := newTestVirtualNetworkClient(t, map[route]canned{
 			{"GET", "/20160918/subnets/ocid1.subnet..aaa"}: {200, newTestSubnetBody("TERMINATED")},
 		})
-		p := core.NewSubnetProvisionerWithSvc(svc)
+		p := core.NewSubnetProvisionerWithSvc(svc, nil)
 
 		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.subnet..aaa"})
 		require.NoError(t, err)
@@ -59,9 +60,11 @@ func TestSubnetRead(t *testing.T) {
 
 func TestSubnetCreate(t *testing.T) {
 	svc := newTestVirtualNetworkClient(t, map[route]canned{
-		{"POST", "/20160918/subnets"}: {200, newTestSubnetBody("AVAILABLE")},
+		{"GET", "/20160918/vcns/ocid1.vcn..aaa"}: {200, newTestVCNBody("AVAILABLE")},
+		{"GET", "/20160918/subnets"}:             {200, `[]`},
+		{"POST", "/20160918/subnets"}:            {200, newTestSubnetBody("AVAILABLE")},
 	})
-	p := core.NewSubnetProvisionerWithSvc(svc)
+	p := core.NewSubnetProvisionerWithSvc(svc, nil)
 
 	props, err := json.Marshal(map[string]any{
 		"CompartmentId": "ocid1.compartment..xxx",
@@ -80,12 +83,37 @@ func TestSubnetCreate(t *testing.T) {
 	assert.Equal(t, "ocid1.subnet..aaa", result.ProgressResult.NativeID)
 }
 
+func TestSubnetCreateOverlapping(t *testing.T) {
+	// No POST route: the dispatcher fails the test if the subnet is created
+	svc := newTestVirtualNetworkClient(t, map[route]canned{
+		{"GET", "/20160918/vcns/ocid1.vcn..aaa"}: {200, newTestVCNBody("AVAILABLE")},
+		{"GET", "/20160918/subnets"}:             {200, fmt.Sprintf(`[%s]`, newTestSubnetBody("AVAILABLE"))},
+	})
+	p := core.NewSubnetProvisionerWithSvc(svc, nil)
+
+	props, err := json.Marshal(map[string]any{
+		"CompartmentId": "ocid1.compartment..xxx",
+		"VcnId":         "ocid1.vcn..aaa",
+		"CidrBlock":     "10.0.0.0/23",
+	})
+	require.NoError(t, err)
+
+	result, err := p.Create(context.Background(), &resource.CreateRequest{
+		ResourceType: "OCI::Core::Subnet",
+		Properties:   props,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusFailure, result.ProgressResult.OperationStatus)
+	assert.Equal(t, resource.OperationErrorCodeInvalidRequest, result.ProgressResult.ErrorCode)
+	assert.Contains(t, result.ProgressResult.StatusMessage, "overlaps 10.0.1.0/24 of existing Subnet ocid1.subnet..aaa")
+}
+
 func TestSubnetUpdate(t *testing.T) {
 	svc := newTestVirtualNetworkClient(t, map[route]canned{
 		{"GET", "/20160918/subnets/ocid1.subnet..aaa"}: {200, newTestSubnetBody("AVAILABLE")},
 		{"PUT", "/20160918/subnets/ocid1.subnet..aaa"}: {200, newTestSubnetBody("AVAILABLE")},
 	})
-	p := core.NewSubnetProvisionerWithSvc(svc)
+	p := core.NewSubnetProvisionerWithSvc(svc, nil)
 
 	props, err := json.Marshal(map[string]any{"DisplayName": "updated-subnet"})
 	require.NoError(t, err)
@@ -104,7 +132,7 @@ func TestSubnetDelete(t *testing.T) {
 		{"GET", "/20160918/subnets/ocid1.subnet..aaa"}:    {200, newTestSubnetBody("AVAILABLE")},
 		{"DELETE", "/20160918/subnets/ocid1.subnet..aaa"}: {204, ""},
 	})
-	p := core.NewSubnetProvisionerWithSvc(svc)
+	p := core.NewSubnetProvisionerWithSvc(svc, nil)
 
 	result, err := p.Delete(context.Background(), &resource.DeleteRequest{NativeID: "ocid1.subnet..aaa"})
 	require.NoError(t, err)
@@ -115,7 +143,7 @@ func TestSubnetList(t *testing.T) {
 	svc := newTestVirtualNetworkClient(t, map[route]canned{
 		{"GET", "/20160918/subnets"}: {200, fmt.Sprintf(`[%s]`, newTestSubnetBody("AVAILABLE"))},
 	})
-	p := core.NewSubnetProvisionerWithSvc(svc)
+	p := core.NewSubnetProvisionerWithSvc(svc, nil)
 
 	result, err := p.List(context.Background(), &resource.ListRequest{
 		ResourceType:         "OCI::Core::Subnet",
@@ -125,6 +153,23 @@ func TestSubnetList(t *testing.T) {
 	assert.Equal(t, []string{"ocid1.subnet..aaa"}, result.NativeIDs)
 }
 
+func TestSubnetListPaginated(t *testing.T) {
+	host := newTestPagedDispatcher(t, nil, map[route][]canned{
+		{"GET", "/20160918/subnets"}: {
+			{200, `[{"id": "ocid1.subnet..aaa", "compartmentId": "ocid1.compartment..xxx", "vcnId": "ocid1.vcn..aaa", "cidrBlock": "10.0.1.0/24"}]`},
+			{200, `[{"id": "ocid1.subnet..bbb", "compartmentId": "ocid1.compartment..xxx", "vcnId": "ocid1.vcn..aaa", "cidrBlock": "10.0.2.0/24"}]`},
+		},
+	})
+	p := core.NewSubnetProvisionerWithSvc(newTestVirtualNetworkClientAt(t, host), nil)
+
+	result, err := p.List(context.Background(), &resource.ListRequest{
+		ResourceType:         "OCI::Core::Subnet",
+		AdditionalProperties: map[string]string{"CompartmentId": "ocid1.compartment..xxx"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ocid1.subnet..aaa", "ocid1.subnet..bbb"}, result.NativeIDs)
+}
+
 // Helpers
 
 func newTestSubnetBody(lifecycleState string) string {
@@ -0,0 +1,435 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package vault
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/secrets"
+	"github.com/oracle/oci-go-sdk/v65/vault"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/client"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/util"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+type SecretProvisioner struct {
+	clients *client.Clients
+	svc     *vault.VaultsClient    // nil until first use; injected in tests
+	secrets *secrets.SecretsClient // nil until first use; injected in tests
+}
+
+var _ provisioner.Provisioner = &SecretProvisioner{}
+
+func init() {
+	provisioner.Register("OCI::Vault::Secret", NewSecretProvisioner)
+	provisioner.DependsOn("OCI::Vault::Secret",
+		"OCI::Identity::Compartment",
+		"OCI::KeyManagement::Vault",
+		"OCI::KeyManagement::Key",
+	)
+}
+
+func NewSecretProvisioner(clients *client.Clients) provisioner.Provisioner {
+	return &SecretProvisioner{clients: clients}
+}
+
+// NewSecretProvisionerWithSvc constructs a provisioner with pre-built SDK clients,
+// for use in tests that point the clients at an httptest server. secrets reads
+// the current content on update and may be nil when a test doesn't change it.
+func NewSecretProvisionerWithSvc(svc *vault.VaultsClient, secrets *secrets.SecretsClient) *SecretProvisioner {
+	return &SecretProvisioner{svc: svc, secrets: secrets}
+}
+
+func (p *SecretProvisioner) getSvc() (*vault.VaultsClient, error) {
+	if p.svc != nil {
+		return p.svc, nil
+	}
+	return p.clients.GetVaultsClient()
+}
+
+func (p *SecretProvisioner) getSecrets() (*secrets.SecretsClient, error) {
+	if p.secrets != nil {
+		return p.secrets, nil
+	}
+	return p.clients.GetSecretsClient()
+}
+
+func (p *SecretProvisioner) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Vaults client: %w", err)
+	}
+
+	var props map[string]any
+	if err := json.Unmarshal(request.Properties, &props); err != nil {
+		return nil, fmt.Errorf("failed to parse properties: %w", err)
+	}
+
+	compartmentId, ok := util.ExtractString(props, "CompartmentId")
+	if !ok {
+		return nil, fmt.Errorf("CompartmentId is required")
+	}
+	vaultId, ok := util.ExtractResolvedReference(props, "VaultId")
+	if !ok {
+		return nil, fmt.Errorf("VaultId is required")
+	}
+	keyId, ok := util.ExtractResolvedReference(props, "KeyId")
+	if !ok {
+		return nil, fmt.Errorf("KeyId is required")
+	}
+	secretName, ok := util.ExtractString(props, "SecretName")
+	if !ok {
+		return nil, fmt.Errorf("SecretName is required")
+	}
+	content, ok := util.ExtractString(props, "SecretContent")
+	if !ok {
+		return nil, fmt.Errorf("SecretContent is required")
+	}
+
+	createDetails := vault.CreateSecretDetails{
+		CompartmentId: common.String(compartmentId),
+		VaultId:       common.String(vaultId),
+		KeyId:         common.String(keyId),
+		SecretName:    common.String(secretName),
+		SecretContent: vault.Base64SecretContentDetails{Content: common.String(content)},
+	}
+	if description, ok := util.ExtractString(props, "Description"); ok {
+		createDetails.Description = common.String(description)
+	}
+	if freeformTags, ok := util.ExtractFreeformTags(props, "FreeformTags"); ok {
+		createDetails.FreeformTags = freeformTags
+	}
+	if definedTags, ok := util.ExtractDefinedTags(props, "DefinedTags"); ok {
+		createDetails.DefinedTags = definedTags
+	}
+
+	resp, err := svc.CreateSecret(ctx, vault.CreateSecretRequest{
+		OpcRetryToken:       common.String(util.CreateRetryToken(request)),
+		CreateSecretDetails: createDetails,
+	})
+	if err != nil {
+		if result, handleErr := util.HandleCreateError(err, "OCI::Vault::Secret", "OCI::Vault::Secret"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to create Secret: %w", err)
+	}
+
+	// Creation is async — return in-progress, poll lifecycle in Status()
+	return &resource.CreateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationCreate,
+			OperationStatus: resource.OperationStatusInProgress,
+			NativeID:        *resp.Id,
+			RequestID:       *resp.Id,
+		},
+	}, nil
+}
+
+// Read returns the secret's metadata and current version number. The content
+// itself is never read back; it is only available from the secret bundle.
+func (p *SecretProvisioner) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Vaults client: %w", err)
+	}
+
+	resp, err := svc.GetSecret(ctx, vault.GetSecretRequest{
+		SecretId: common.String(request.NativeID),
+	})
+	if err != nil {
+		if util.IsNotFound(err) {
+			return &resource.ReadResult{
+				ResourceType: "OCI::Vault::Secret",
+				ErrorCode:    resource.OperationErrorCodeNotFound,
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to read Secret: %w", err)
+	}
+
+	// A secret scheduled for deletion is gone as far as formae is concerned
+	if isSecretDeleted(resp.LifecycleState) {
+		return &resource.ReadResult{
+			ResourceType: "OCI::Vault::Secret",
+			ErrorCode:    resource.OperationErrorCodeNotFound,
+		}, nil
+	}
+
+	properties := buildSecretProperties(resp.Secret, config.FromTargetConfig(request.TargetConfig).HiddenTagNamespaces())
+
+	propBytes, err := json.Marshal(properties)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Secret properties: %w", err)
+	}
+
+	return &resource.ReadResult{
+		ResourceType: "OCI::Vault::Secret",
+		Properties:   string(propBytes),
+	}, nil
+}
+
+// Update writes new secret content as a new version, which becomes current.
+// SecretContent is write-only and comes with every update, so it is compared
+// with the current version's content first and only sent when it differs;
+// otherwise a tag or description change would add a version too.
+func (p *SecretProvisioner) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Vaults client: %w", err)
+	}
+
+	props, err := util.ApplyPatchDocument(ctx, request, p.Read)
+	if err != nil {
+		return nil, err
+	}
+
+	updateDetails := vault.UpdateSecretDetails{}
+	if content, ok := util.ExtractString(props, "SecretContent"); ok {
+		unchanged, err := p.currentContentMatches(ctx, request.NativeID, content)
+		if err != nil {
+			return nil, err
+		}
+		if !unchanged {
+			updateDetails.SecretContent = vault.Base64SecretContentDetails{Content: common.String(content)}
+		}
+	}
+	if description, ok := util.ExtractString(props, "Description"); ok {
+		updateDetails.Description = common.String(description)
+	}
+	if freeformTags, ok := util.ExtractFreeformTags(props, "FreeformTags"); ok {
+		updateDetails.FreeformTags = freeformTags
+	}
+	if definedTags, ok := util.ExtractDefinedTags(props, "DefinedTags"); ok {
+		updateDetails.DefinedTags = definedTags
+	}
+
+	resp, err := svc.UpdateSecret(ctx, vault.UpdateSecretRequest{
+		SecretId:            common.String(request.NativeID),
+		UpdateSecretDetails: updateDetails,
+	})
+	if err != nil {
+		if result, handleErr := util.HandleUpdateError(err, "OCI::Vault::Secret", request.NativeID, "OCI::Vault::Secret"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to update Secret: %w", err)
+	}
+
+	return &resource.UpdateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationUpdate,
+			OperationStatus: resource.OperationStatusInProgress,
+			NativeID:        *resp.Id,
+			RequestID:       *resp.Id,
+		},
+	}, nil
+}
+
+// currentContentMatches reports whether the secret's current version holds
+// content, comparing content hashes. A bundle the caller may not read counts as
+// changed, so the update still writes a new version as it would without the check.
+func (p *SecretProvisioner) currentContentMatches(ctx context.Context, secretId, content string) (bool, error) {
+	client, err := p.getSecrets()
+	if err != nil {
+		return false, fmt.Errorf("failed to get Secrets client: %w", err)
+	}
+
+	resp, err := client.GetSecretBundle(ctx, secrets.GetSecretBundleRequest{
+		SecretId: common.String(secretId),
+		Stage:    secrets.GetSecretBundleStageCurrent,
+	})
+	if err != nil {
+		if util.IsNotFound(err) || util.IsNotAuthorized(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to read current Secret content: %w", err)
+	}
+
+	current, ok := resp.SecretBundleContent.(secrets.Base64SecretBundleContentDetails)
+	if !ok || current.Content == nil {
+		return false, nil
+	}
+	return sha256.Sum256([]byte(*current.Content)) == sha256.Sum256([]byte(content)), nil
+}
+
+// Delete schedules the secret's deletion. OCI keeps it in PENDING_DELETION for
+// the vault's retention window (30 days by default), during which the deletion
+// can still be cancelled; Status reports when it will be deleted for good.
+func (p *SecretProvisioner) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Vaults client: %w", err)
+	}
+
+	readRes, err := p.Read(ctx, &resource.ReadRequest{NativeID: request.NativeID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Secret before delete: %w", err)
+	}
+	if readRes.ErrorCode == resource.OperationErrorCodeNotFound {
+		return &resource.DeleteResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationDelete,
+				OperationStatus: resource.OperationStatusSuccess,
+				NativeID:        request.NativeID,
+			},
+		}, nil
+	}
+
+	_, err = svc.ScheduleSecretDeletion(ctx, vault.ScheduleSecretDeletionRequest{
+		SecretId: common.String(request.NativeID),
+	})
+	if err != nil {
+		if result, handleErr := util.HandleDeleteError(err, "OCI::Vault::Secret", request.NativeID, "OCI::Vault::Secret"); result != nil {
+			return result, handleErr
+		}
+		return nil, fmt.Errorf("failed to schedule Secret deletion: %w", err)
+	}
+
+	return &resource.DeleteResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationDelete,
+			OperationStatus: resource.OperationStatusInProgress,
+			NativeID:        request.NativeID,
+			RequestID:       request.NativeID,
+		},
+	}, nil
+}
+
+func (p *SecretProvisioner) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Vaults client: %w", err)
+	}
+
+	var timeOfDeletion *common.SDKTime
+	getSecret := func(ctx context.Context) (*util.LifecycleSnapshot, error) {
+		resp, err := svc.GetSecret(ctx, vault.GetSecretRequest{
+			SecretId: common.String(request.RequestID),
+		})
+		if err != nil {
+			if util.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to check Secret status: %w", err)
+		}
+		snapshot := &util.LifecycleSnapshot{
+			NativeID: *resp.Id,
+			State:    string(resp.LifecycleState),
+		}
+		if resp.LifecycleState == vault.SecretLifecycleStatePendingDeletion {
+			timeOfDeletion = resp.TimeOfDeletion
+		} else {
+			snapshot.Properties = buildSecretProperties(resp.Secret, config.FromTargetConfig(request.TargetConfig).HiddenTagNamespaces())
+		}
+		return snapshot, nil
+	}
+
+	// CREATING, UPDATING and SCHEDULING_DELETION stay in progress
+	result, err := util.PollLifecycle(ctx, "Secret", request.RequestID, getSecret, map[string]resource.OperationStatus{
+		string(vault.SecretLifecycleStateActive):          resource.OperationStatusSuccess,
+		string(vault.SecretLifecycleStatePendingDeletion): resource.OperationStatusSuccess,
+		string(vault.SecretLifecycleStateDeleted):         resource.OperationStatusSuccess,
+		string(vault.SecretLifecycleStateFailed):          resource.OperationStatusFailure,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if timeOfDeletion != nil {
+		result.StatusMessage = fmt.Sprintf("Secret is pending deletion until %s", timeOfDeletion.Format(time.RFC3339))
+	}
+
+	return &resource.StatusResult{ProgressResult: result}, nil
+}
+
+func (p *SecretProvisioner) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	svc, err := p.getSvc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Vaults client: %w", err)
+	}
+
+	compartmentId, ok := util.ListCompartmentId(request)
+	if !ok {
+		return nil, fmt.Errorf("CompartmentId is required for listing Secrets")
+	}
+
+	listReq := vault.ListSecretsRequest{
+		CompartmentId: common.String(compartmentId),
+		Limit:         util.ListPageSize(request.TargetConfig),
+	}
+	if vaultId, ok := request.AdditionalProperties["VaultId"]; ok {
+		listReq.VaultId = common.String(vaultId)
+	}
+
+	nativeIDs, err := util.ListAllPages(func(page *string) ([]string, *string, error) {
+		listReq.Page = page
+		resp, err := svc.ListSecrets(ctx, listReq)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list Secrets: %w", err)
+		}
+		ids := make([]string, 0, len(resp.Items))
+		for _, secret := range resp.Items {
+			if isSecretDeleted(vault.SecretLifecycleStateEnum(secret.LifecycleState)) {
+				continue
+			}
+			ids = append(ids, *secret.Id)
+		}
+		return ids, resp.OpcNextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &resource.ListResult{
+		NativeIDs: nativeIDs,
+	}, nil
+}
+
+// isSecretDeleted reports whether the secret is deleted or on its way there,
+// including the retention window of a scheduled deletion.
+func isSecretDeleted(state vault.SecretLifecycleStateEnum) bool {
+	switch state {
+	case vault.SecretLifecycleStateSchedulingDeletion, vault.SecretLifecycleStatePendingDeletion:
+		return true
+	}
+	return util.IsTerminal(string(state))
+}
+
+func buildSecretProperties(secret vault.Secret, ignoredTagNamespaces []string) map[string]any {
+	properties := map[string]any{
+		"Id": *secret.Id,
+	}
+
+	if secret.CompartmentId != nil {
+		properties["CompartmentId"] = *secret.CompartmentId
+	}
+	if secret.VaultId != nil {
+		properties["VaultId"] = *secret.VaultId
+	}
+	if secret.KeyId != nil {
+		properties["KeyId"] = *secret.KeyId
+	}
+	if secret.SecretName != nil {
+		properties["SecretName"] = *secret.SecretName
+	}
+	if secret.Description != nil {
+		properties["Description"] = *secret.Description
+	}
+	if secret.CurrentVersionNumber != nil {
+		properties["VersionNumber"] = *secret.CurrentVersionNumber
+	}
+	if secret.FreeformTags != nil {
+		properties["FreeformTags"] = util.FreeformTagsToList(secret.FreeformTags)
+	}
+	if secret.DefinedTags != nil {
+		properties["DefinedTags"] = util.DefinedTagsToList(secret.DefinedTags, ignoredTagNamespaces)
+	}
+
+	return properties
+}
@@ -10,6 +10,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 
 	ocicore "github.com/oracle/oci-go-sdk/v65/core"
@@ -82,6 +83,7 @@ func TestVCNCreate(t *testing.T) {
 
 func TestVCNUpdate(t *testing.T) {
 	svc := newTestVirtualNetworkClient(t, map[route]canned{
+		{"GET", "/20160918/vcns/ocid1.vcn..aaa"}: {200, newTestVCNBody("AVAILABLE")},
 		{"PUT", "/20160918/vcns/ocid1.vcn..aaa"}: {200, newTestVCNBody("AVAILABLE")},
 	})
 	p := core.NewVCNProvisionerWithSvc(svc)
@@ -110,6 +112,111 @@ func TestVCNDelete(t *testing.T) {
 	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
 }
 
+func TestVCNDeleteConflict(t *testing.T) {
+	svc := newTestVirtualNetworkClient(t, map[route]canned{
+		{"GET", "/20160918/vcns/ocid1.vcn..aaa"}:    {200, newTestVCNBody("AVAILABLE")},
+		{"DELETE", "/20160918/vcns/ocid1.vcn..aaa"}: {409, `{"code":"Conflict","message":"The Vcn ocid1.vcn..aaa references the Subnet ocid1.subnet..aaa"}`},
+		{"GET", "/20160918/subnets"}:                {200, `[{"id":"ocid1.subnet..aaa","lifecycleState":"AVAILABLE"}]`},
+		{"GET", "/20160918/internetGateways"}:       {200, `[{"id":"ocid1.internetgateway..aaa","lifecycleState":"AVAILABLE"}]`},
+		{"GET", "/20160918/natGateways"}:            {200, `[]`},
+		{"GET", "/20160918/serviceGateways"}:        {200, `[]`},
+		{"GET", "/20160918/networkSecurityGroups"}:  {200, `[]`},
+		{"GET", "/20160918/routeTables"}:            {200, `[{"id":"ocid1.routetable..default","lifecycleState":"AVAILABLE"}]`},
+		{"GET", "/20160918/securityLists"}:          {200, `[]`},
+		{"GET", "/20160918/dhcps"}:                  {200, `[]`},
+	})
+	p := core.NewVCNProvisionerWithSvc(svc)
+
+	result, err := p.Delete(context.Background(), &resource.DeleteRequest{NativeID: "ocid1.vcn..aaa"})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusFailure, result.ProgressResult.OperationStatus)
+	assert.Equal(t, resource.OperationErrorCodeResourceConflict, result.ProgressResult.ErrorCode)
+	assert.Contains(t, result.ProgressResult.StatusMessage, "subnets, internet gateways")
+	assert.NotContains(t, result.ProgressResult.StatusMessage, "route tables")
+}
+
+func TestVCNDeleteCascade(t *testing.T) {
+	vcn := strings.Replace(newTestVCNBody("AVAILABLE"), `"lifecycleState"`, `"freeformTags": {"formae-cascade-delete": "true"}, "lifecycleState"`, 1)
+	children := func(subnets, internetGateways string) map[route]canned {
+		return map[route]canned{
+			{"GET", "/20160918/vcns/ocid1.vcn..aaa"}:   {200, vcn},
+			{"GET", "/20160918/subnets"}:               {200, subnets},
+			{"GET", "/20160918/internetGateways"}:      {200, internetGateways},
+			{"GET", "/20160918/natGateways"}:           {200, `[]`},
+			{"GET", "/20160918/serviceGateways"}:       {200, `[]`},
+			{"GET", "/20160918/networkSecurityGroups"}: {200, `[]`},
+			{"GET", "/20160918/routeTables"}:           {200, `[{"id":"ocid1.routetable..default","lifecycleState":"AVAILABLE"}]`},
+			{"GET", "/20160918/securityLists"}:         {200, `[]`},
+			{"GET", "/20160918/dhcps"}:                 {200, `[]`},
+		}
+	}
+	internetGateway := `[{"id":"ocid1.internetgateway..aaa","lifecycleState":"AVAILABLE"}]`
+
+	t.Run("deletes subnets before anything else", func(t *testing.T) {
+		responses := children(`[{"id":"ocid1.subnet..aaa","lifecycleState":"AVAILABLE"}]`, internetGateway)
+		responses[route{"DELETE", "/20160918/subnets/ocid1.subnet..aaa"}] = canned{204, ""}
+		p := core.NewVCNProvisionerWithSvc(newTestVirtualNetworkClient(t, responses))
+
+		result, err := p.Delete(context.Background(), &resource.DeleteRequest{NativeID: "ocid1.vcn..aaa"})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+		assert.Equal(t, "ocid1.vcn..aaa/cascade-delete", result.ProgressResult.RequestID)
+	})
+
+	t.Run("waits for terminating subnets", func(t *testing.T) {
+		// No delete routes: nothing else may go while a subnet is terminating
+		p := core.NewVCNProvisionerWithSvc(newTestVirtualNetworkClient(t,
+			children(`[{"id":"ocid1.subnet..aaa","lifecycleState":"TERMINATING"}]`, internetGateway)))
+
+		result, err := p.Status(context.Background(), &resource.StatusRequest{RequestID: "ocid1.vcn..aaa/cascade-delete"})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+		assert.Equal(t, "ocid1.vcn..aaa/cascade-delete", result.ProgressResult.RequestID)
+	})
+
+	t.Run("deletes gateways after the subnets", func(t *testing.T) {
+		responses := children(`[{"id":"ocid1.subnet..aaa","lifecycleState":"TERMINATED"}]`, internetGateway)
+		responses[route{"PUT", "/20160918/routeTables/ocid1.routetable..default"}] = canned{200, `{"id":"ocid1.routetable..default","routeRules":[]}`}
+		responses[route{"DELETE", "/20160918/internetGateways/ocid1.internetgateway..aaa"}] = canned{204, ""}
+		p := core.NewVCNProvisionerWithSvc(newTestVirtualNetworkClient(t, responses))
+
+		result, err := p.Status(context.Background(), &resource.StatusRequest{RequestID: "ocid1.vcn..aaa/cascade-delete"})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+	})
+
+	t.Run("waits for terminating gateways", func(t *testing.T) {
+		p := core.NewVCNProvisionerWithSvc(newTestVirtualNetworkClient(t,
+			children(`[]`, `[{"id":"ocid1.internetgateway..aaa","lifecycleState":"TERMINATING"}]`)))
+
+		result, err := p.Status(context.Background(), &resource.StatusRequest{RequestID: "ocid1.vcn..aaa/cascade-delete"})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+	})
+
+	t.Run("deletes the VCN once every child terminated", func(t *testing.T) {
+		responses := children(`[]`, `[{"id":"ocid1.internetgateway..aaa","lifecycleState":"TERMINATED"}]`)
+		responses[route{"DELETE", "/20160918/vcns/ocid1.vcn..aaa"}] = canned{204, ""}
+		p := core.NewVCNProvisionerWithSvc(newTestVirtualNetworkClient(t, responses))
+
+		result, err := p.Status(context.Background(), &resource.StatusRequest{RequestID: "ocid1.vcn..aaa/cascade-delete"})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	})
+
+	t.Run("read reports the opt-in without the tag", func(t *testing.T) {
+		p := core.NewVCNProvisionerWithSvc(newTestVirtualNetworkClient(t, children(`[]`, `[]`)))
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.vcn..aaa"})
+		require.NoError(t, err)
+
+		var props map[string]any
+		require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+		assert.Equal(t, true, props["CascadeDelete"])
+		assert.Nil(t, props["FreeformTags"])
+	})
+}
+
 func TestVCNList(t *testing.T) {
 	svc := newTestVirtualNetworkClient(t, map[route]canned{
 		{"GET", "/20160918/vcns"}: {200, fmt.Sprintf(`[%s]`, newTestVCNBody("AVAILABLE"))},
@@ -128,7 +235,11 @@ func TestVCNList(t *testing.T) {
 
 func newTestVirtualNetworkClient(t *testing.T, responses map[route]canned) *ocicore.VirtualNetworkClient {
 	t.Helper()
-	host := newTestDispatcher(t, responses)
+	return newTestVirtualNetworkClientAt(t, newTestDispatcher(t, responses))
+}
+
+func newTestVirtualNetworkClientAt(t *testing.T, host string) *ocicore.VirtualNetworkClient {
+	t.Helper()
 	c, err := ocicore.NewVirtualNetworkClientWithConfigurationProvider(fakeOCIConfigProvider(t))
 	require.NoError(t, err)
 	applyTestRetryPolicy(&c)
@@ -141,7 +252,10 @@ func newTestVCNBody(lifecycleState string) string {
 		"id": "ocid1.vcn..aaa",
 		"compartmentId": "ocid1.compartment..xxx",
 		"cidrBlock": "10.0.0.0/16",
+		"cidrBlocks": ["10.0.0.0/16"],
 		"displayName": "test-vcn",
+		"defaultRouteTableId": "ocid1.routetable..default",
+		"defaultSecurityListId": "ocid1.securitylist..default",
 		"lifecycleState": %q
 	}`, lifecycleState)
 }
@@ -0,0 +1,72 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build integration
+
+package provisioner_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/dns"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestViewCreate(t *testing.T) {
+	svc := newTestDnsClient(t, map[route]canned{
+		{"POST", "/20180115/views"}:                   {201, newTestViewBody("ocid1.dnsview..aaa", false, "ACTIVE")},
+		{"GET", "/20180115/views/ocid1.dnsview..aaa"}: {200, newTestViewBody("ocid1.dnsview..aaa", false, "ACTIVE")},
+	})
+	p := dns.NewViewProvisionerWithSvc(svc)
+
+	result, err := p.Create(context.Background(), &resource.CreateRequest{
+		ResourceType: "OCI::DNS::View",
+		Properties:   []byte(`{"CompartmentId": "ocid1.compartment..xxx", "DisplayName": "corp"}`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+
+	status, err := p.Status(context.Background(), &resource.StatusRequest{RequestID: result.ProgressResult.RequestID})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, status.ProgressResult.OperationStatus)
+	assert.Equal(t, "ocid1.dnsview..aaa", status.ProgressResult.NativeID)
+}
+
+func TestViewList_SkipsProtectedViews(t *testing.T) {
+	svc := newTestDnsClient(t, map[route]canned{
+		{"GET", "/20180115/views"}: {200, fmt.Sprintf(`[%s, %s, %s]`,
+			newTestViewBody("ocid1.dnsview..aaa", false, "ACTIVE"),
+			newTestViewBody("ocid1.dnsview..default", true, "ACTIVE"),
+			newTestViewBody("ocid1.dnsview..gone", false, "DELETING"),
+		)},
+	})
+	p := dns.NewViewProvisionerWithSvc(svc)
+
+	result, err := p.List(context.Background(), &resource.ListRequest{
+		ResourceType:         "OCI::DNS::View",
+		AdditionalProperties: map[string]string{"CompartmentId": "ocid1.compartment..xxx"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ocid1.dnsview..aaa"}, result.NativeIDs)
+}
+
+// Helpers
+
+func newTestViewBody(id string, isProtected bool, lifecycleState string) string {
+	return fmt.Sprintf(`{
+		"id": %q,
+		"compartmentId": "ocid1.compartment..xxx",
+		"displayName": "corp",
+		"isProtected": %t,
+		"lifecycleState": %q,
+		"freeformTags": {},
+		"definedTags": {},
+		"timeCreated": "2025-01-01T00:00:00.000Z",
+		"timeUpdated": "2025-01-01T00:00:00.000Z"
+	}`, id, isProtected, lifecycleState)
+}
@@ -10,6 +10,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	ocicore "github.com/oracle/oci-go-sdk/v65/core"
@@ -24,7 +28,7 @@ func TestVolumeRead(t *testing.T) {
 		svc := newTestBlockstorageClient(t, map[route]canned{
 			{"GET", "/20160918/volumes/ocid1.volume..aaa"}: {200, newTestVolumeBody("AVAILABLE")},
 		})
-		p := core.NewVolumeProvisionerWithSvc(svc)
+		p := core.NewVolumeProvisionerWithSvc(svc, nil)
 
 		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.volume..aaa"})
 		require.NoError(t, err)
@@ -39,7 +43,7 @@ func TestVolumeRead(t *testing.T) {
 		svc := newTestBlockstorageClient(t, map[route]canned{
 			{"GET", "/20160918/volumes/ocid1.volume..missing"}: {404, `{"code":"NotAuthorizedOrNotFound","message":"not found"}`},
 		})
-		p := core.NewVolumeProvisionerWithSvc(svc)
+		p := core.NewVolumeProvisionerWithSvc(svc, nil)
 
 		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.volume..missing"})
 		require.NoError(t, err)
@@ -50,7 +54,7 @@ func TestVolumeRead(t *testing.T) {
 		svc := newTestBlockstorageClient(t, map[route]canned{
 			{"GET", "/20160918/volumes/ocid1.volume..aaa"}: {200, newTestVolumeBody("TERMINATED")},
 		})
-		p := core.NewVolumeProvisionerWithSvc(svc)
+		p := core.NewVolumeProvisionerWithSvc(svc, nil)
 
 		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.volume..aaa"})
 		require.NoError(t, err)
@@ -58,17 +62,68 @@ func TestVolumeRead(t *testing.T) {
 	})
 }
 
-func TestVolumeCreate(t *testing.T) {
+func TestVolumeReadAutotuneAndReplicas(t *testing.T) {
 	svc := newTestBlockstorageClient(t, map[route]canned{
-		{"POST", "/20160918/volumes"}: {200, newTestVolumeBody("PROVISIONING")},
+		{"GET", "/20160918/volumes/ocid1.volume..aaa"}: {200, `{
+			"id": "ocid1.volume..aaa",
+			"compartmentId": "ocid1.compartment..xxx",
+			"availabilityDomain": "US-CHICAGO-1-AD-1",
+			"lifecycleState": "AVAILABLE",
+			"autotunePolicies": [
+				{"autotuneType": "DETACHED_VOLUME"},
+				{"autotuneType": "PERFORMANCE_BASED", "maxVpusPerGB": 30}
+			],
+			"blockVolumeReplicas": [
+				{"blockVolumeReplicaId": "ocid1.blockvolumereplica..r1", "availabilityDomain": "US-CHICAGO-1-AD-2", "displayName": "dr"}
+			]
+		}`},
 	})
-	p := core.NewVolumeProvisionerWithSvc(svc)
+	p := core.NewVolumeProvisionerWithSvc(svc, nil)
+
+	result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.volume..aaa"})
+	require.NoError(t, err)
+
+	var props map[string]any
+	require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+	assert.Equal(t, []any{
+		map[string]any{"autotuneType": "DETACHED_VOLUME"},
+		map[string]any{"autotuneType": "PERFORMANCE_BASED", "maxVpusPerGB": float64(30)},
+	}, props["AutotunePolicies"])
+	assert.Equal(t, []any{
+		map[string]any{"blockVolumeReplicaId": "ocid1.blockvolumereplica..r1", "availabilityDomain": "US-CHICAGO-1-AD-2", "displayName": "dr"},
+	}, props["BlockVolumeReplicas"])
+}
+
+func TestVolumeCreate(t *testing.T) {
+	var created map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/20160918/availabilityDomains":
+			fmt.Fprint(w, `[{"name": "Twhb:US-CHICAGO-1-AD-2"}, {"name": "Twhb:US-CHICAGO-1-AD-1"}]`)
+		case "/20160918/volumes":
+			body, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(body, &created)
+			fmt.Fprint(w, newTestVolumeBody("PROVISIONING"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"code":"NotFound","message":"not found"}`)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := ocicore.NewBlockstorageClientWithConfigurationProvider(fakeOCIConfigProvider(t))
+	require.NoError(t, err)
+	applyTestRetryPolicy(&c)
+	c.Host = srv.URL
+	p := core.NewVolumeProvisionerWithSvc(&c, newTestIdentityClientAt(t, srv.URL))
 
 	props, err := json.Marshal(map[string]any{
-		"CompartmentId":      "ocid1.compartment..xxx",
-		"AvailabilityDomain": "US-CHICAGO-1-AD-1",
-		"DisplayName":        "test-volume",
-		"SizeInGBs":          50,
+		"CompartmentId":       "ocid1.compartment..xxx",
+		"AvailabilityDomain":  "US-CHICAGO-1-AD-1",
+		"DisplayName":         "test-volume",
+		"SizeInGBs":           50,
+		"BlockVolumeReplicas": []any{map[string]any{"availabilityDomain": "AD-2", "displayName": "dr"}},
 	})
 	require.NoError(t, err)
 
@@ -80,6 +135,22 @@ func TestVolumeCreate(t *testing.T) {
 	assert.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
 	assert.Equal(t, "ocid1.volume..aaa", result.ProgressResult.NativeID)
 	assert.Equal(t, "ocid1.volume..aaa", result.ProgressResult.RequestID)
+
+	assert.Equal(t, "Twhb:US-CHICAGO-1-AD-1", created["availabilityDomain"])
+	assert.Equal(t, []any{map[string]any{"availabilityDomain": "Twhb:US-CHICAGO-1-AD-2", "displayName": "dr"}}, created["blockVolumeReplicas"])
+}
+
+func TestVolumeSameValue_AvailabilityDomain(t *testing.T) {
+	host := newTestDispatcher(t, map[route]canned{
+		{"GET", "/20160918/availabilityDomains"}: {200, `[{"name": "Twhb:US-CHICAGO-1-AD-1"}, {"name": "Twhb:US-CHICAGO-1-AD-2"}]`},
+	})
+	p := core.NewVolumeProvisionerWithSvc(nil, newTestIdentityClientAt(t, host))
+	live := map[string]any{"CompartmentId": "ocid1.compartment..xxx", "AvailabilityDomain": "Twhb:US-CHICAGO-1-AD-1"}
+
+	assert.True(t, p.SameValue(context.Background(), "AvailabilityDomain", live, "US-CHICAGO-1-AD-1"))
+	assert.True(t, p.SameValue(context.Background(), "AvailabilityDomain", live, "1"))
+	assert.False(t, p.SameValue(context.Background(), "AvailabilityDomain", live, "AD-2"))
+	assert.False(t, p.SameValue(context.Background(), "AvailabilityDomain", live, "AD-7"))
 }
 
 func TestVolumeUpdate(t *testing.T) {
@@ -87,7 +158,7 @@ func TestVolumeUpdate(t *testing.T) {
 		{"GET", "/20160918/volumes/ocid1.volume..aaa"}: {200, newTestVolumeBody("AVAILABLE")},
 		{"PUT", "/20160918/volumes/ocid1.volume..aaa"}: {200, newTestVolumeBody("AVAILABLE")},
 	})
-	p := core.NewVolumeProvisionerWithSvc(svc)
+	p := core.NewVolumeProvisionerWithSvc(svc, nil)
 
 	props, err := json.Marshal(map[string]any{"DisplayName": "updated-volume"})
 	require.NoError(t, err)
@@ -101,12 +172,142 @@ func TestVolumeUpdate(t *testing.T) {
 	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
 }
 
+func TestVolumeUpdateDisableReplication(t *testing.T) {
+	replicated := `{
+		"id": "ocid1.volume..aaa",
+		"compartmentId": "ocid1.compartment..xxx",
+		"availabilityDomain": "US-CHICAGO-1-AD-1",
+		"lifecycleState": "AVAILABLE",
+		"blockVolumeReplicas": [
+			{"blockVolumeReplicaId": "ocid1.blockvolumereplica..r1", "availabilityDomain": "US-CHICAGO-1-AD-2", "displayName": "dr"}
+		]
+	}`
+	svc := newTestBlockstorageClient(t, map[route]canned{
+		{"GET", "/20160918/volumes/ocid1.volume..aaa"}: {200, replicated},
+		{"PUT", "/20160918/volumes/ocid1.volume..aaa"}: {200, newTestVolumeBody("AVAILABLE")},
+	})
+	p := core.NewVolumeProvisionerWithSvc(svc, nil)
+
+	props, err := json.Marshal(map[string]any{"DisplayName": "test-volume"})
+	require.NoError(t, err)
+
+	result, err := p.Update(context.Background(), &resource.UpdateRequest{
+		NativeID:          "ocid1.volume..aaa",
+		ResourceType:      "OCI::Core::Volume",
+		DesiredProperties: props,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+}
+
+func TestVolumeUpdateKmsKey(t *testing.T) {
+	keyed := `{
+		"id": "ocid1.volume..aaa",
+		"compartmentId": "ocid1.compartment..xxx",
+		"availabilityDomain": "US-CHICAGO-1-AD-1",
+		"displayName": "test-volume",
+		"kmsKeyId": "ocid1.key..old",
+		"lifecycleState": "AVAILABLE"
+	}`
+
+	t.Run("rekey", func(t *testing.T) {
+		svc := newTestBlockstorageClient(t, map[route]canned{
+			{"GET", "/20160918/volumes/ocid1.volume..aaa"}:        {200, keyed},
+			{"PUT", "/20160918/volumes/ocid1.volume..aaa"}:        {200, keyed},
+			{"PUT", "/20160918/volumes/ocid1.volume..aaa/kmsKey"}: {200, `{"kmsKeyId": "ocid1.key..new"}`},
+		})
+		p := core.NewVolumeProvisionerWithSvc(svc, nil)
+
+		props, err := json.Marshal(map[string]any{"DisplayName": "test-volume", "KmsKeyId": "ocid1.key..new"})
+		require.NoError(t, err)
+
+		result, err := p.Update(context.Background(), &resource.UpdateRequest{
+			NativeID:          "ocid1.volume..aaa",
+			ResourceType:      "OCI::Core::Volume",
+			DesiredProperties: props,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	})
+
+	t.Run("unset returns to Oracle-managed", func(t *testing.T) {
+		svc := newTestBlockstorageClient(t, map[route]canned{
+			{"GET", "/20160918/volumes/ocid1.volume..aaa"}:           {200, keyed},
+			{"PUT", "/20160918/volumes/ocid1.volume..aaa"}:           {200, keyed},
+			{"DELETE", "/20160918/volumes/ocid1.volume..aaa/kmsKey"}: {204, ``},
+		})
+		p := core.NewVolumeProvisionerWithSvc(svc, nil)
+
+		props, err := json.Marshal(map[string]any{"DisplayName": "test-volume"})
+		require.NoError(t, err)
+
+		result, err := p.Update(context.Background(), &resource.UpdateRequest{
+			NativeID:          "ocid1.volume..aaa",
+			ResourceType:      "OCI::Core::Volume",
+			DesiredProperties: props,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	})
+}
+
+// TestVolumeOracleManagedEncryption checks that a volume without a customer key
+// reads back without KmsKeyId however OCI spells it, and that updating it
+// leaves the encryption alone.
+func TestVolumeOracleManagedEncryption(t *testing.T) {
+	bodies := map[string]string{
+		"absent": newTestVolumeBody("AVAILABLE"),
+		"null":   strings.Replace(newTestVolumeBody("AVAILABLE"), `"sizeInGBs": 50,`, `"sizeInGBs": 50, "kmsKeyId": null,`, 1),
+		"empty":  strings.Replace(newTestVolumeBody("AVAILABLE"), `"sizeInGBs": 50,`, `"sizeInGBs": 50, "kmsKeyId": "",`, 1),
+	}
+	for name, body := range bodies {
+		t.Run(name, func(t *testing.T) {
+			// No kmsKey routes: a rekey or key removal fails the test.
+			svc := newTestBlockstorageClient(t, map[route]canned{
+				{"GET", "/20160918/volumes/ocid1.volume..aaa"}: {200, body},
+				{"PUT", "/20160918/volumes/ocid1.volume..aaa"}: {200, body},
+			})
+			p := core.NewVolumeProvisionerWithSvc(svc, nil)
+
+			result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.volume..aaa"})
+			require.NoError(t, err)
+			var props map[string]any
+			require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+			assert.NotContains(t, props, "KmsKeyId")
+
+			desired, err := json.Marshal(map[string]any{"DisplayName": "test-volume"})
+			require.NoError(t, err)
+			updateResult, err := p.Update(context.Background(), &resource.UpdateRequest{
+				NativeID:          "ocid1.volume..aaa",
+				ResourceType:      "OCI::Core::Volume",
+				DesiredProperties: desired,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, resource.OperationStatusSuccess, updateResult.ProgressResult.OperationStatus)
+		})
+	}
+
+	t.Run("customer_key", func(t *testing.T) {
+		body := strings.Replace(newTestVolumeBody("AVAILABLE"), `"sizeInGBs": 50,`, `"sizeInGBs": 50, "kmsKeyId": "ocid1.key..aaa",`, 1)
+		svc := newTestBlockstorageClient(t, map[route]canned{
+			{"GET", "/20160918/volumes/ocid1.volume..aaa"}: {200, body},
+		})
+		p := core.NewVolumeProvisionerWithSvc(svc, nil)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.volume..aaa"})
+		require.NoError(t, err)
+		var props map[string]any
+		require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+		assert.Equal(t, "ocid1.key..aaa", props["KmsKeyId"])
+	})
+}
+
 func TestVolumeDelete(t *testing.T) {
 	svc := newTestBlockstorageClient(t, map[route]canned{
 		{"GET", "/20160918/volumes/ocid1.volume..aaa"}:    {200, newTestVolumeBody("AVAILABLE")},
 		{"DELETE", "/20160918/volumes/ocid1.volume..aaa"}: {204, ""},
 	})
-	p := core.NewVolumeProvisionerWithSvc(svc)
+	p := core.NewVolumeProvisionerWithSvc(svc, nil)
 
 	result, err := p.Delete(context.Background(), &resource.DeleteRequest{NativeID: "ocid1.volume..aaa"})
 	require.NoError(t, err)
@@ -115,11 +316,36 @@ func TestVolumeDelete(t *testing.T) {
 	assert.Equal(t, "ocid1.volume..aaa", result.ProgressResult.RequestID)
 }
 
+func TestVolumeStatus(t *testing.T) {
+	cases := []struct {
+		name     string
+		response canned
+		expected resource.OperationStatus
+	}{
+		{"available", canned{200, newTestVolumeBody("AVAILABLE")}, resource.OperationStatusSuccess},
+		{"provisioning", canned{200, newTestVolumeBody("PROVISIONING")}, resource.OperationStatusInProgress},
+		{"faulty", canned{200, newTestVolumeBody("FAULTY")}, resource.OperationStatusFailure},
+		{"gone", canned{404, `{"code":"NotAuthorizedOrNotFound","message":"not found"}`}, resource.OperationStatusSuccess},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := newTestBlockstorageClient(t, map[route]canned{
+				{"GET", "/20160918/volumes/ocid1.volume..aaa"}: tc.response,
+			})
+			p := core.NewVolumeProvisionerWithSvc(svc, nil)
+
+			result, err := p.Status(context.Background(), &resource.StatusRequest{RequestID: "ocid1.volume..aaa"})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result.ProgressResult.OperationStatus)
+		})
+	}
+}
+
 func TestVolumeList(t *testing.T) {
 	svc := newTestBlockstorageClient(t, map[route]canned{
 		{"GET", "/20160918/volumes"}: {200, fmt.Sprintf(`[%s]`, newTestVolumeBody("AVAILABLE"))},
 	})
-	p := core.NewVolumeProvisionerWithSvc(svc)
+	p := core.NewVolumeProvisionerWithSvc(svc, nil)
 
 	result, err := p.List(context.Background(), &resource.ListRequest{
 		ResourceType:         "OCI::Core::Volume",
@@ -133,7 +359,11 @@ func TestVolumeList(t *testing.T) {
 
 func newTestBlockstorageClient(t *testing.T, responses map[route]canned) *ocicore.BlockstorageClient {
 	t.Helper()
-	host := newTestDispatcher(t, responses)
+	return newTestBlockstorageClientAt(t, newTestDispatcher(t, responses))
+}
+
+func newTestBlockstorageClientAt(t *testing.T, host string) *ocicore.BlockstorageClient {
+	t.Helper()
 	c, err := ocicore.NewBlockstorageClientWithConfigurationProvider(fakeOCIConfigProvider(t))
 	require.NoError(t, err)
 	applyTestRetryPolicy(&c)
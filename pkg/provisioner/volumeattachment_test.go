@@ -0,0 +1,136 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build integration
+
+package provisioner_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	ocicore "github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/core"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVolumeAttachmentCreate(t *testing.T) {
+	svc := newTestComputeClient(t, map[route]canned{
+		{"POST", "/20160918/volumeAttachments"}: {200, newTestVolumeAttachmentBody("ocid1.volumeattachment..aaa", "ocid1.instance..aaa", "ATTACHING")},
+	})
+	p := core.NewVolumeAttachmentProvisionerWithSvc(svc)
+
+	props, err := json.Marshal(map[string]any{
+		"InstanceId":  "ocid1.instance..aaa",
+		"VolumeId":    "ocid1.volume..aaa",
+		"IsShareable": true,
+	})
+	require.NoError(t, err)
+
+	result, err := p.Create(context.Background(), &resource.CreateRequest{
+		ResourceType: "OCI::Core::VolumeAttachment",
+		Properties:   props,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+	assert.Equal(t, "ocid1.volumeattachment..aaa", result.ProgressResult.NativeID)
+	assert.Equal(t, "ocid1.volumeattachment..aaa", result.ProgressResult.RequestID)
+}
+
+func TestVolumeAttachmentRead(t *testing.T) {
+	t.Run("shareable", func(t *testing.T) {
+		svc := newTestComputeClient(t, map[route]canned{
+			{"GET", "/20160918/volumeAttachments/ocid1.volumeattachment..aaa"}: {200, newTestVolumeAttachmentBody("ocid1.volumeattachment..aaa", "ocid1.instance..aaa", "ATTACHED")},
+			{"GET", "/20160918/volumeAttachments"}: {200, fmt.Sprintf(`[%s, %s, %s]`,
+				newTestVolumeAttachmentBody("ocid1.volumeattachment..bbb", "ocid1.instance..bbb", "ATTACHED"),
+				newTestVolumeAttachmentBody("ocid1.volumeattachment..aaa", "ocid1.instance..aaa", "ATTACHED"),
+				newTestVolumeAttachmentBody("ocid1.volumeattachment..ccc", "ocid1.instance..ccc", "DETACHED"),
+			)},
+		})
+		p := core.NewVolumeAttachmentProvisionerWithSvc(svc)
+
+		result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.volumeattachment..aaa"})
+		require.NoError(t, err)
+		assert.Empty(t, result.ErrorCode)
+
+		var props map[string]any
+		require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+		assert.Equal(t, "paravirtualized", props["Type"])
+		assert.Equal(t, true, props["IsShareable"])
+		assert.Equal(t, []any{"ocid1.instance..aaa", "ocid1.instance..bbb"}, props["AttachedInstanceIds"])
+	})
+
+	for _, state := range []string{"DETACHING", "DETACHED"} {
+		t.Run(state, func(t *testing.T) {
+			svc := newTestComputeClient(t, map[route]canned{
+				{"GET", "/20160918/volumeAttachments/ocid1.volumeattachment..aaa"}: {200, newTestVolumeAttachmentBody("ocid1.volumeattachment..aaa", "ocid1.instance..aaa", state)},
+			})
+			p := core.NewVolumeAttachmentProvisionerWithSvc(svc)
+
+			result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.volumeattachment..aaa"})
+			require.NoError(t, err)
+			assert.Equal(t, resource.OperationErrorCodeNotFound, result.ErrorCode)
+		})
+	}
+}
+
+func TestVolumeAttachmentList(t *testing.T) {
+	svc := newTestComputeClient(t, map[route]canned{
+		{"GET", "/20160918/volumeAttachments"}: {200, fmt.Sprintf(`[%s, %s]`,
+			newTestVolumeAttachmentBody("ocid1.volumeattachment..aaa", "ocid1.instance..aaa", "ATTACHED"),
+			newTestVolumeAttachmentBody("ocid1.volumeattachment..ccc", "ocid1.instance..ccc", "DETACHED"),
+		)},
+	})
+	p := core.NewVolumeAttachmentProvisionerWithSvc(svc)
+
+	result, err := p.List(context.Background(), &resource.ListRequest{
+		ResourceType: "OCI::Core::VolumeAttachment",
+		AdditionalProperties: map[string]string{
+			"CompartmentId": "ocid1.compartment..xxx",
+			"VolumeId":      "ocid1.volume..aaa",
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ocid1.volumeattachment..aaa"}, result.NativeIDs)
+
+	_, err = p.List(context.Background(), &resource.ListRequest{
+		ResourceType:         "OCI::Core::VolumeAttachment",
+		AdditionalProperties: map[string]string{"CompartmentId": "ocid1.compartment..xxx"},
+	})
+	assert.ErrorContains(t, err, "InstanceId or VolumeId is required")
+}
+
+// Helpers
+
+func newTestComputeClient(t *testing.T, responses map[route]canned) *ocicore.ComputeClient {
+	t.Helper()
+	return newTestComputeClientAt(t, newTestDispatcher(t, responses))
+}
+
+func newTestComputeClientAt(t *testing.T, host string) *ocicore.ComputeClient {
+	t.Helper()
+	c, err := ocicore.NewComputeClientWithConfigurationProvider(fakeOCIConfigProvider(t))
+	require.NoError(t, err)
+	applyTestRetryPolicy(&c)
+	c.Host = host
+	return &c
+}
+
+func newTestVolumeAttachmentBody(id, instanceId, lifecycleState string) string {
+	return fmt.Sprintf(`{
+		"attachmentType": "paravirtualized",
+		"id": %q,
+		"instanceId": %q,
+		"volumeId": "ocid1.volume..aaa",
+		"compartmentId": "ocid1.compartment..xxx",
+		"availabilityDomain": "US-CHICAGO-1-AD-1",
+		"isShareable": true,
+		"lifecycleState": %q,
+		"timeCreated": "2025-01-01T00:00:00.000Z"
+	}`, id, instanceId, lifecycleState)
+}
@@ -0,0 +1,108 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build integration
+
+package provisioner_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/provisioner/dns"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZoneCreate(t *testing.T) {
+	t.Run("primary", func(t *testing.T) {
+		svc := newTestDnsClient(t, map[route]canned{
+			{"POST", "/20180115/zones"}:                    {201, newTestZoneBody("PRIMARY", "CREATING")},
+			{"GET", "/20180115/zones/ocid1.dns-zone..aaa"}: {200, newTestZoneBody("PRIMARY", "ACTIVE")},
+		})
+		p := dns.NewZoneProvisionerWithSvc(svc)
+
+		result, err := p.Create(context.Background(), &resource.CreateRequest{
+			ResourceType: "OCI::DNS::Zone",
+			Properties:   []byte(`{"Name": "example.com", "CompartmentId": "ocid1.compartment..xxx"}`),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+
+		status, err := p.Status(context.Background(), &resource.StatusRequest{RequestID: result.ProgressResult.RequestID})
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusSuccess, status.ProgressResult.OperationStatus)
+
+		var props map[string]any
+		require.NoError(t, json.Unmarshal(status.ProgressResult.ResourceProperties, &props))
+		assert.Equal(t, "PRIMARY", props["ZoneType"])
+		assert.Equal(t, []any{"ns1.p68.dns.oraclecloud.net"}, props["Nameservers"])
+	})
+
+	t.Run("secondary needs external masters", func(t *testing.T) {
+		p := dns.NewZoneProvisionerWithSvc(newTestDnsClient(t, map[route]canned{}))
+
+		_, err := p.Create(context.Background(), &resource.CreateRequest{
+			ResourceType: "OCI::DNS::Zone",
+			Properties:   []byte(`{"Name": "example.com", "CompartmentId": "ocid1.compartment..xxx", "ZoneType": "SECONDARY"}`),
+		})
+		assert.ErrorContains(t, err, "ExternalMasters is required")
+	})
+}
+
+func TestZoneRead_Secondary(t *testing.T) {
+	svc := newTestDnsClient(t, map[route]canned{
+		{"GET", "/20180115/zones/ocid1.dns-zone..aaa"}: {200, newTestZoneBody("SECONDARY", "ACTIVE")},
+	})
+	p := dns.NewZoneProvisionerWithSvc(svc)
+
+	result, err := p.Read(context.Background(), &resource.ReadRequest{NativeID: "ocid1.dns-zone..aaa"})
+	require.NoError(t, err)
+
+	var props map[string]any
+	require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+	assert.Equal(t, []any{map[string]any{"address": "192.0.2.53", "port": float64(53)}}, props["ExternalMasters"])
+}
+
+func TestZoneDelete_NotFoundIsSuccess(t *testing.T) {
+	svc := newTestDnsClient(t, map[route]canned{
+		{"GET", "/20180115/zones/ocid1.dns-zone..aaa"}:    {200, newTestZoneBody("PRIMARY", "ACTIVE")},
+		{"DELETE", "/20180115/zones/ocid1.dns-zone..aaa"}: {404, `{"code": "NotAuthorizedOrNotFound", "message": "not found"}`},
+	})
+	p := dns.NewZoneProvisionerWithSvc(svc)
+
+	result, err := p.Delete(context.Background(), &resource.DeleteRequest{NativeID: "ocid1.dns-zone..aaa"})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+}
+
+// Helpers
+
+func newTestZoneBody(zoneType, lifecycleState string) string {
+	masters := "[]"
+	if zoneType == "SECONDARY" {
+		masters = `[{"address": "192.0.2.53", "port": 53}]`
+	}
+	return fmt.Sprintf(`{
+		"id": "ocid1.dns-zone..aaa",
+		"name": "example.com",
+		"zoneType": %q,
+		"compartmentId": "ocid1.compartment..xxx",
+		"scope": "GLOBAL",
+		"freeformTags": {},
+		"definedTags": {},
+		"externalMasters": %s,
+		"externalDownstreams": [],
+		"self": "https://dns.us-ashburn-1.oraclecloud.com/20180115/zones/example.com",
+		"timeCreated": "2025-01-01T00:00:00.000Z",
+		"version": "1",
+		"serial": 1,
+		"lifecycleState": %q,
+		"isProtected": false,
+		"nameservers": [{"hostname": "ns1.p68.dns.oraclecloud.net"}]
+	}`, zoneType, masters, lifecycleState)
+}
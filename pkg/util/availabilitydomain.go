@@ -0,0 +1,118 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/identity"
+)
+
+var (
+	adCacheMu sync.Mutex
+	adCache   = map[string][]string{}
+)
+
+// IsQualifiedAvailabilityDomain reports whether name is already a full AD name
+// such as "Uocm:PHX-AD-1" and needs no lookup.
+func IsQualifiedAvailabilityDomain(name string) bool {
+	return strings.Contains(name, ":")
+}
+
+// ResolveAvailabilityDomain maps a friendly availability domain reference to the
+// tenancy-specific name. It accepts the full name (returned unchanged), the name
+// without the tenancy prefix ("PHX-AD-1"), the short form ("AD-1") or a 1-based
+// index ("1").
+func ResolveAvailabilityDomain(ctx context.Context, identityClient *identity.IdentityClient, compartmentId, name string) (string, error) {
+	if IsQualifiedAvailabilityDomain(name) {
+		return name, nil
+	}
+
+	names, err := listAvailabilityDomainsCached(ctx, identityClient, compartmentId)
+	if err != nil {
+		return "", fmt.Errorf("failed to list availability domains: %w", err)
+	}
+	return matchAvailabilityDomain(names, name)
+}
+
+func matchAvailabilityDomain(names []string, name string) (string, error) {
+	if index, err := strconv.Atoi(name); err == nil {
+		if index < 1 || index > len(names) {
+			return "", fmt.Errorf("availability domain index %d out of range, region has %d", index, len(names))
+		}
+		return names[index-1], nil
+	}
+
+	want := strings.ToUpper(name)
+	for _, full := range names {
+		unqualified := strings.ToUpper(full[strings.Index(full, ":")+1:])
+		if unqualified == want || strings.HasSuffix(unqualified, "-"+want) {
+			return full, nil
+		}
+	}
+	return "", fmt.Errorf("availability domain %q not found, expected one of %s", name, strings.Join(names, ", "))
+}
+
+func listAvailabilityDomainsCached(ctx context.Context, identityClient *identity.IdentityClient, compartmentId string) ([]string, error) {
+	key := identityClient.Host + "|" + compartmentId
+
+	adCacheMu.Lock()
+	names, ok := adCache[key]
+	adCacheMu.Unlock()
+	if ok {
+		return names, nil
+	}
+
+	resp, err := identityClient.ListAvailabilityDomains(ctx, identity.ListAvailabilityDomainsRequest{
+		CompartmentId: common.String(compartmentId),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, ad := range resp.Items {
+		if ad.Name != nil {
+			names = append(names, *ad.Name)
+		}
+	}
+	sort.Strings(names)
+
+	adCacheMu.Lock()
+	adCache[key] = names
+	adCacheMu.Unlock()
+	return names, nil
+}
+
+// ValidateFaultDomain checks that faultDomain exists in the given availability
+// domain. Lookup failures are not treated as invalid; the service has the final say.
+func ValidateFaultDomain(ctx context.Context, identityClient *identity.IdentityClient, compartmentId, availabilityDomain, faultDomain string) error {
+	resp, err := identityClient.ListFaultDomains(ctx, identity.ListFaultDomainsRequest{
+		CompartmentId:      common.String(compartmentId),
+		AvailabilityDomain: common.String(availabilityDomain),
+	})
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	for _, fd := range resp.Items {
+		if fd.Name == nil {
+			continue
+		}
+		if *fd.Name == faultDomain {
+			return nil
+		}
+		names = append(names, *fd.Name)
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	return fmt.Errorf("fault domain %q not found in %s, expected one of %s", faultDomain, availabilityDomain, strings.Join(names, ", "))
+}
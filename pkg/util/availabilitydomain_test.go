@@ -0,0 +1,46 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testADs = []string{"Uocm:PHX-AD-1", "Uocm:PHX-AD-2", "Uocm:PHX-AD-3"}
+
+func TestMatchAvailabilityDomain(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"AD-2", "Uocm:PHX-AD-2"},
+		{"ad-3", "Uocm:PHX-AD-3"},
+		{"PHX-AD-1", "Uocm:PHX-AD-1"},
+		{"2", "Uocm:PHX-AD-2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := matchAvailabilityDomain(testADs, tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMatchAvailabilityDomain_Unknown(t *testing.T) {
+	_, err := matchAvailabilityDomain(testADs, "AD-4")
+	assert.Error(t, err)
+
+	_, err = matchAvailabilityDomain(testADs, "0")
+	assert.Error(t, err)
+}
+
+func TestIsQualifiedAvailabilityDomain(t *testing.T) {
+	assert.True(t, IsQualifiedAvailabilityDomain("Uocm:PHX-AD-1"))
+	assert.False(t, IsQualifiedAvailabilityDomain("AD-1"))
+}
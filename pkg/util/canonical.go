@@ -0,0 +1,187 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package util
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
+)
+
+// CanonicalSpec describes the shape the Pkl schemas give a resource's
+// properties, which is the shape formae diffs against:
+//   - top-level properties are PascalCase, from the resource classes'
+//     outputKeyTransformation;
+//   - fields of nested objects stay camelCase, since the transformation only
+//     applies at the top level;
+//   - freeform and defined tags, at any depth, are lists of {Key, Value} and
+//     {Namespace, Key, Value} entries ordered by namespace and key, with defined
+//     tag values as strings.
+//
+// A Read that builds a property in any other shape, say nested PascalCase keys
+// or tags as a map straight from the SDK, shows up as drift.
+type CanonicalSpec struct {
+	// Opaque names top-level map properties whose keys are data rather than
+	// field names, e.g. instance metadata. They are left as they are.
+	Opaque []string
+}
+
+// Canonicalize rewrites props into the canonical shape in place. When a key is
+// present under both spellings, the canonical one wins. Keys of reference
+// objects ($ref, $value) are kept as they are.
+func (s CanonicalSpec) Canonicalize(props map[string]any) {
+	for _, key := range mapKeys(props) {
+		name := capitalize(key)
+		value := props[key]
+		if !slices.Contains(s.Opaque, name) {
+			value = canonicalValue(name, value)
+		}
+		renameKey(props, key, name, value)
+	}
+}
+
+type canonicalSpecKey struct{}
+
+// WithCanonicalSpec returns a context carrying spec, so code that diffs a Read
+// against a patch, like ApplyPatchDocument, sees the Read in canonical shape.
+func WithCanonicalSpec(ctx context.Context, spec CanonicalSpec) context.Context {
+	return context.WithValue(ctx, canonicalSpecKey{}, spec)
+}
+
+func canonicalSpecFrom(ctx context.Context) (CanonicalSpec, bool) {
+	spec, ok := ctx.Value(canonicalSpecKey{}).(CanonicalSpec)
+	return spec, ok
+}
+
+func canonicalValue(key string, value any) any {
+	switch strings.ToLower(key) {
+	case "freeformtags":
+		return canonicalTags(value, freeformTagsFromMap, "Key")
+	case "definedtags":
+		return canonicalTags(value, definedTagsFromMap, "Namespace", "Key")
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		for _, k := range mapKeys(v) {
+			if strings.HasPrefix(k, "$") {
+				continue
+			}
+			name := decapitalize(k)
+			renameKey(v, k, name, canonicalValue(name, v[k]))
+		}
+	case []any:
+		for i := range v {
+			v[i] = canonicalValue("", v[i])
+		}
+	}
+	return value
+}
+
+// canonicalTags turns a tag property into its canonical list. A map, the form
+// the SDK uses, is converted by fromMap; the entries of a list get PascalCase
+// keys and string values. Either way the result is ordered by sortKeys.
+func canonicalTags(value any, fromMap func(map[string]any) []any, sortKeys ...string) any {
+	var tags []any
+	switch v := value.(type) {
+	case map[string]any:
+		tags = fromMap(v)
+	case []any:
+		tags = v
+		for _, item := range tags {
+			entry, ok := item.(map[string]any)
+			if !ok {
+				return value
+			}
+			for _, k := range mapKeys(entry) {
+				renameKey(entry, k, capitalize(k), entry[k])
+			}
+			if tagValue, ok := entry["Value"]; ok {
+				entry["Value"] = DefinedTagValue(tagValue)
+			}
+		}
+	default:
+		return value
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		a, _ := tags[i].(map[string]any)
+		b, _ := tags[j].(map[string]any)
+		for _, k := range sortKeys {
+			x, _ := a[k].(string)
+			y, _ := b[k].(string)
+			if x != y {
+				return x < y
+			}
+		}
+		return false
+	})
+	return tags
+}
+
+func freeformTagsFromMap(m map[string]any) []any {
+	tags := make(map[string]string, len(m))
+	for k, v := range m {
+		if s, ok := v.(string); ok {
+			tags[k] = s
+		}
+	}
+	var result []any
+	for _, tag := range FreeformTagsToList(tags) {
+		result = append(result, map[string]any{"Key": tag["Key"], "Value": tag["Value"]})
+	}
+	return result
+}
+
+func definedTagsFromMap(m map[string]any) []any {
+	tags := make(map[string]map[string]any, len(m))
+	for ns, v := range m {
+		if values, ok := v.(map[string]any); ok {
+			tags[ns] = values
+		}
+	}
+	var result []any
+	for _, tag := range DefinedTagsToList(tags, []string{config.OracleTagsNamespace}) {
+		result = append(result, tag)
+	}
+	return result
+}
+
+// renameKey stores value under name in place of key. If name is already a
+// separate key in m, the value there is kept and key is dropped.
+func renameKey(m map[string]any, key, name string, value any) {
+	if name != key {
+		delete(m, key)
+		if _, taken := m[name]; taken {
+			return
+		}
+	}
+	m[name] = value
+}
+
+func mapKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func decapitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
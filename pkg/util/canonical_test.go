@@ -0,0 +1,107 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func canonicalize(t *testing.T, spec CanonicalSpec, in string) string {
+	t.Helper()
+	var props map[string]any
+	require.NoError(t, json.Unmarshal([]byte(in), &props))
+	spec.Canonicalize(props)
+	out, err := json.Marshal(props)
+	require.NoError(t, err)
+	return string(out)
+}
+
+func TestCanonicalizeKeyCasing(t *testing.T) {
+	got := canonicalize(t, CanonicalSpec{}, `{
+		"displayName": "web",
+		"RouteRules": [{"NetworkEntityId": "ocid1.internetgateway..aaa", "destination": "0.0.0.0/0"}],
+		"TcpOptions": {"DestinationPortRange": {"Min": 443, "max": 443}}
+	}`)
+
+	assert.JSONEq(t, `{
+		"DisplayName": "web",
+		"RouteRules": [{"networkEntityId": "ocid1.internetgateway..aaa", "destination": "0.0.0.0/0"}],
+		"TcpOptions": {"destinationPortRange": {"min": 443, "max": 443}}
+	}`, got)
+}
+
+func TestCanonicalizeKeepsCanonicalSpelling(t *testing.T) {
+	got := canonicalize(t, CanonicalSpec{}, `{
+		"DisplayName": "kept", "displayName": "dropped",
+		"Options": {"kubernetesNetworkConfig": {"podsCidr": "kept"}, "KubernetesNetworkConfig": {"podsCidr": "dropped"}}
+	}`)
+
+	assert.JSONEq(t, `{"DisplayName": "kept", "Options": {"kubernetesNetworkConfig": {"podsCidr": "kept"}}}`, got)
+}
+
+func TestCanonicalizeLeavesReferencesAndOpaqueFields(t *testing.T) {
+	got := canonicalize(t, CanonicalSpec{Opaque: []string{"Metadata"}}, `{
+		"SubnetId": {"$ref": "formae://subnet#/Id", "$value": "ocid1.subnet..aaa"},
+		"Metadata": {"ssh_authorized_keys": "ssh-ed25519 AAAA", "User_data": "I2Nsb3Vk"},
+		"CreateVnicDetails": {"SubnetId": {"$ref": "formae://subnet#/Id"}}
+	}`)
+
+	assert.JSONEq(t, `{
+		"SubnetId": {"$ref": "formae://subnet#/Id", "$value": "ocid1.subnet..aaa"},
+		"Metadata": {"ssh_authorized_keys": "ssh-ed25519 AAAA", "User_data": "I2Nsb3Vk"},
+		"CreateVnicDetails": {"subnetId": {"$ref": "formae://subnet#/Id"}}
+	}`, got)
+}
+
+func TestCanonicalizeTags(t *testing.T) {
+	t.Run("maps become sorted lists", func(t *testing.T) {
+		got := canonicalize(t, CanonicalSpec{}, `{
+			"FreeformTags": {"team": "net", "env": "prod"},
+			"DefinedTags": {"Oracle-Tags": {"CreatedBy": "me"}, "Ops": {"Tier": 1, "CostCenter": "42"}}
+		}`)
+
+		assert.JSONEq(t, `{
+			"FreeformTags": [{"Key": "env", "Value": "prod"}, {"Key": "team", "Value": "net"}],
+			"DefinedTags": [{"Namespace": "Ops", "Key": "CostCenter", "Value": "42"}, {"Namespace": "Ops", "Key": "Tier", "Value": "1"}]
+		}`, got)
+	})
+
+	t.Run("list entries keep PascalCase when nested", func(t *testing.T) {
+		got := canonicalize(t, CanonicalSpec{}, `{
+			"NodeConfigDetails": {"FreeformTags": [{"key": "team", "value": "net"}, {"Key": "env", "Value": "prod"}]}
+		}`)
+
+		assert.JSONEq(t, `{
+			"NodeConfigDetails": {"freeformTags": [{"Key": "env", "Value": "prod"}, {"Key": "team", "Value": "net"}]}
+		}`, got)
+	})
+
+	t.Run("defined tag values become strings", func(t *testing.T) {
+		got := canonicalize(t, CanonicalSpec{}, `{"DefinedTags": [{"Namespace": "Ops", "Key": "Enabled", "Value": true}]}`)
+
+		assert.JSONEq(t, `{"DefinedTags": [{"Namespace": "Ops", "Key": "Enabled", "Value": "true"}]}`, got)
+	})
+}
+
+func TestApplyPatchDocumentCanonicalizesRead(t *testing.T) {
+	read := func(context.Context, *resource.ReadRequest) (*resource.ReadResult, error) {
+		return &resource.ReadResult{Properties: `{"DisplayName":"web","TcpOptions":{"DestinationPortRange":{"Min":80,"Max":80}}}`}, nil
+	}
+	patch := `[{"op":"replace","path":"/TcpOptions/destinationPortRange/max","value":443}]`
+	request := &resource.UpdateRequest{NativeID: "ocid1.networksecuritygroup..aaa", PatchDocument: &patch}
+
+	props, err := ApplyPatchDocument(WithCanonicalSpec(context.Background(), CanonicalSpec{}), request, read)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"min": float64(80), "max": float64(443)}, props["TcpOptions"].(map[string]any)["destinationPortRange"])
+
+	_, err = ApplyPatchDocument(context.Background(), request, read)
+	assert.Error(t, err, "without a spec the patch path doesn't match the Read")
+}
@@ -0,0 +1,75 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/identity"
+)
+
+// compartmentPathCache holds resolved compartment OCIDs keyed by endpoint,
+// tenancy and path, for lookupCacheTTL.
+var compartmentPathCache = newTTLCache(lookupCacheTTL)
+
+// ResolveCompartmentByName maps a "/"-delimited compartment path such as
+// "prod/network", relative to the tenancy, to the compartment's OCID. Each
+// resolved prefix is cached for a while, so sibling paths only look up what they don't share.
+func ResolveCompartmentByName(ctx context.Context, identityClient *identity.IdentityClient, tenancyId, path string) (string, error) {
+	keyPrefix := identityClient.Host + "|" + tenancyId + "|"
+	return walkCompartmentPath(tenancyId, path, func(parentId, name, resolved string) (string, error) {
+		key := keyPrefix + resolved
+
+		if id, ok := compartmentPathCache.get(key); ok {
+			return id, nil
+		}
+
+		id, err := findChildCompartment(ctx, identityClient, parentId, name)
+		if err != nil {
+			return "", err
+		}
+
+		compartmentPathCache.set(key, id)
+		return id, nil
+	})
+}
+
+// walkCompartmentPath resolves path one segment at a time, starting from the
+// tenancy. lookup gets the parent's OCID, the segment, and the path so far.
+func walkCompartmentPath(tenancyId, path string, lookup func(parentId, name, resolved string) (string, error)) (string, error) {
+	segments := strings.FieldsFunc(path, func(r rune) bool { return r == '/' })
+	if len(segments) == 0 {
+		return "", fmt.Errorf("compartment path %q names no compartment", path)
+	}
+
+	id := tenancyId
+	for i, name := range segments {
+		resolved := strings.Join(segments[:i+1], "/")
+		next, err := lookup(id, name, resolved)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve compartment %q: %w", resolved, err)
+		}
+		id = next
+	}
+	return id, nil
+}
+
+func findChildCompartment(ctx context.Context, identityClient *identity.IdentityClient, parentId, name string) (string, error) {
+	resp, err := identityClient.ListCompartments(ctx, identity.ListCompartmentsRequest{
+		CompartmentId:  common.String(parentId),
+		Name:           common.String(name),
+		LifecycleState: identity.CompartmentLifecycleStateActive,
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(resp.Items) == 0 || resp.Items[0].Id == nil {
+		return "", fmt.Errorf("no active compartment named %q in %s", name, parentId)
+	}
+	return *resp.Items[0].Id, nil
+}
@@ -0,0 +1,39 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package util
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalkCompartmentPath(t *testing.T) {
+	tree := map[string]string{
+		"ocid1.tenancy..root|prod":   "ocid1.compartment..prod",
+		"ocid1.compartment..prod|db": "ocid1.compartment..db",
+	}
+	var visited []string
+	lookup := func(parentId, name, resolved string) (string, error) {
+		visited = append(visited, resolved)
+		if id, ok := tree[parentId+"|"+name]; ok {
+			return id, nil
+		}
+		return "", fmt.Errorf("no active compartment named %q in %s", name, parentId)
+	}
+
+	id, err := walkCompartmentPath("ocid1.tenancy..root", "/prod/db/", lookup)
+	require.NoError(t, err)
+	assert.Equal(t, "ocid1.compartment..db", id)
+	assert.Equal(t, []string{"prod", "prod/db"}, visited)
+
+	_, err = walkCompartmentPath("ocid1.tenancy..root", "prod/web", lookup)
+	assert.ErrorContains(t, err, `failed to resolve compartment "prod/web"`)
+
+	_, err = walkCompartmentPath("ocid1.tenancy..root", "/", lookup)
+	assert.ErrorContains(t, err, "names no compartment")
+}
@@ -0,0 +1,45 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package util
+
+import (
+	"fmt"
+	"strings"
+)
+
+// compositeIDEscaper escapes the separator inside components. '%' is escaped
+// first so that a literal "%2F" in a component survives a round trip.
+var (
+	compositeIDEscaper   = strings.NewReplacer("%", "%25", "/", "%2F")
+	compositeIDUnescaper = strings.NewReplacer("%2F", "/", "%2f", "/", "%25", "%")
+)
+
+// EncodeCompositeID joins the parts of a sub-resource NativeID with '/', e.g.
+// {nsgId}/{ruleId}. A '/' or '%' inside a part is percent-escaped so the ID can
+// always be split back into the same parts. OCIDs never need escaping, so IDs
+// built from them stay human readable.
+func EncodeCompositeID(parts ...string) string {
+	escaped := make([]string, len(parts))
+	for i, part := range parts {
+		escaped[i] = compositeIDEscaper.Replace(part)
+	}
+	return strings.Join(escaped, "/")
+}
+
+// DecodeCompositeID splits a NativeID produced by EncodeCompositeID into exactly n
+// non-empty parts, unescaping each one.
+func DecodeCompositeID(id string, n int) ([]string, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != n {
+		return nil, fmt.Errorf("invalid composite ID %q: expected %d parts separated by '/', got %d", id, n, len(parts))
+	}
+	for i, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("invalid composite ID %q: part %d is empty", id, i+1)
+		}
+		parts[i] = compositeIDUnescaper.Replace(part)
+	}
+	return parts, nil
+}
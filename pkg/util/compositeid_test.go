@@ -0,0 +1,43 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeCompositeID_OCIDsUnchanged(t *testing.T) {
+	assert.Equal(t, "ocid1.networksecuritygroup.oc1..aaa/rule-001",
+		EncodeCompositeID("ocid1.networksecuritygroup.oc1..aaa", "rule-001"))
+}
+
+func TestCompositeID_RoundTrip(t *testing.T) {
+	cases := [][]string{
+		{"ocid1.nsg..aaa", "rule-001"},
+		{"lb-id", "backend/set", "10.0.0.1:80"},
+		{"literal%2Fpercent", "a%b"},
+	}
+	for _, parts := range cases {
+		got, err := DecodeCompositeID(EncodeCompositeID(parts...), len(parts))
+		require.NoError(t, err)
+		assert.Equal(t, parts, got)
+	}
+}
+
+func TestDecodeCompositeID_WrongPartCount(t *testing.T) {
+	_, err := DecodeCompositeID("ocid1.nsg..aaa", 2)
+	assert.Error(t, err)
+
+	_, err = DecodeCompositeID("a/b/c", 2)
+	assert.Error(t, err)
+}
+
+func TestDecodeCompositeID_EmptyPart(t *testing.T) {
+	_, err := DecodeCompositeID("ocid1.nsg..aaa/", 2)
+	assert.Error(t, err)
+}
@@ -7,6 +7,7 @@ package util
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
@@ -70,10 +71,51 @@ func HandleOCIServiceError(err error) (resource.OperationErrorCode, bool) {
 	return resource.OperationErrorCodeNotSet, false
 }
 
+// IsNotFound reports whether err is an OCI service error saying the resource does
+// not exist. OCI often answers with 400 or 409 and code NotAuthorizedOrNotFound for
+// deleted resources, so checking the HTTP status alone is not enough.
+func IsNotFound(err error) bool {
+	errorCode, ok := HandleOCIServiceError(err)
+	return ok && errorCode == resource.OperationErrorCodeNotFound
+}
+
+// IsNotAuthorized reports whether err is an OCI service error refusing the call
+// for lack of permissions (401 or 403), as opposed to a missing resource.
+func IsNotAuthorized(err error) bool {
+	serviceErr := extractServiceError(err)
+	if serviceErr == nil {
+		return false
+	}
+	status := serviceErr.GetHTTPStatusCode()
+	return status == 401 || status == 403
+}
+
+// IsNameConflict reports whether err says a resource with the same name already
+// exists. Services disagree on how to say so: some use a dedicated code, others
+// only a bare 409.
+func IsNameConflict(err error) bool {
+	errorCode, ok := HandleOCIServiceError(err)
+	return ok && (errorCode == resource.OperationErrorCodeAlreadyExists || errorCode == resource.OperationErrorCodeResourceConflict)
+}
+
+// HasServiceErrorCode reports whether err is an OCI service error with the
+// given code, for callers that tell apart errors HandleOCIServiceError maps to
+// the same OperationErrorCode.
+func HasServiceErrorCode(err error, code string) bool {
+	serviceErr := extractServiceError(err)
+	return serviceErr != nil && serviceErr.GetCode() == code
+}
+
 // serviceErrorMessage extracts the OCI service error message, falling back to err.Error().
+// The opc-request-id is appended when present so failures can be quoted in Oracle
+// support tickets.
 func serviceErrorMessage(err error, operationName string, action string) string {
 	if se := extractServiceError(err); se != nil {
-		return fmt.Sprintf("%s cannot be %s: %s", operationName, action, se.GetMessage())
+		msg := fmt.Sprintf("%s cannot be %s: %s", operationName, action, se.GetMessage())
+		if requestID := se.GetOpcRequestID(); requestID != "" {
+			msg += fmt.Sprintf(" (opc-request-id: %s)", requestID)
+		}
+		return msg
 	}
 	return err.Error()
 }
@@ -145,3 +187,18 @@ func HandleUpdateError(err error, resourceType string, nativeID string, operatio
 		},
 	}, nil
 }
+
+// ReplacementRequired fails an Update that changes fields OCI only sets at
+// create. The NotUpdatable code and the "replacement required: <fields>"
+// message tell formae to replace the resource instead.
+func ReplacementRequired(nativeID string, fields ...string) *resource.UpdateResult {
+	return &resource.UpdateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationUpdate,
+			OperationStatus: resource.OperationStatusFailure,
+			ErrorCode:       resource.OperationErrorCodeNotUpdatable,
+			StatusMessage:   "replacement required: " + strings.Join(fields, ", "),
+			NativeID:        nativeID,
+		},
+	}
+}
@@ -0,0 +1,108 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeServiceError implements common.ServiceError for tests.
+type fakeServiceError struct {
+	status int
+	code   string
+}
+
+func (e fakeServiceError) Error() string           { return fmt.Sprintf("%d %s", e.status, e.code) }
+func (e fakeServiceError) GetHTTPStatusCode() int  { return e.status }
+func (e fakeServiceError) GetMessage() string      { return e.code }
+func (e fakeServiceError) GetCode() string         { return e.code }
+func (e fakeServiceError) GetOpcRequestID() string { return "" }
+
+func TestIsNotFound(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"404", fakeServiceError{404, "NotFound"}, true},
+		{"400_not_authorized_or_not_found", fakeServiceError{400, "NotAuthorizedOrNotFound"}, true},
+		{"409_not_authorized_or_not_found", fakeServiceError{409, "NotAuthorizedOrNotFound"}, true},
+		{"wrapped", fmt.Errorf("failed to read: %w", fakeServiceError{404, "BucketNotFound"}), true},
+		{"409_conflict", fakeServiceError{409, "Conflict"}, false},
+		{"500", fakeServiceError{500, "InternalServerError"}, false},
+		{"non_service_error", errors.New("connection refused"), false},
+		{"nil", nil, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, IsNotFound(tc.err))
+		})
+	}
+}
+
+func TestIsNotAuthorized(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"401", fakeServiceError{401, "NotAuthenticated"}, true},
+		{"403", fakeServiceError{403, "NotAllowed"}, true},
+		{"wrapped", fmt.Errorf("failed to read: %w", fakeServiceError{403, "Forbidden"}), true},
+		{"404", fakeServiceError{404, "NotFound"}, false},
+		{"500", fakeServiceError{500, "InternalServerError"}, false},
+		{"non_service_error", errors.New("connection refused"), false},
+		{"nil", nil, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, IsNotAuthorized(tc.err))
+		})
+	}
+}
+
+func TestIsNameConflict(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"bucket_already_exists", fakeServiceError{409, "BucketAlreadyExists"}, true},
+		{"resource_already_exists", fakeServiceError{400, "ResourceAlreadyExists"}, true},
+		{"409_not_authorized_or_not_found", fakeServiceError{409, "NotAuthorizedOrNotFound"}, false},
+		{"400_invalid_parameter", fakeServiceError{400, "InvalidParameter"}, false},
+		{"non_service_error", errors.New("connection refused"), false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, IsNameConflict(tc.err))
+		})
+	}
+}
+
+type fakeServiceErrorWithRequestID struct {
+	fakeServiceError
+	requestID string
+}
+
+func (e fakeServiceErrorWithRequestID) GetOpcRequestID() string { return e.requestID }
+
+func TestHandleCreateError_IncludesOpcRequestID(t *testing.T) {
+	err := fakeServiceErrorWithRequestID{fakeServiceError{400, "InvalidParameter"}, "ABC123/DEF456"}
+
+	result, handleErr := HandleCreateError(err, "OCI::Core::Vcn", "OCI::Core::Vcn")
+	assert.NoError(t, handleErr)
+	assert.Equal(t, "OCI::Core::Vcn cannot be created: InvalidParameter (opc-request-id: ABC123/DEF456)", result.ProgressResult.StatusMessage)
+}
+
+func TestHandleDeleteError_WithoutOpcRequestID(t *testing.T) {
+	result, handleErr := HandleDeleteError(fakeServiceError{409, "Conflict"}, "OCI::Core::Vcn", "ocid1.vcn..a", "OCI::Core::Vcn")
+	assert.NoError(t, handleErr)
+	assert.Equal(t, "OCI::Core::Vcn cannot be deleted: Conflict", result.ProgressResult.StatusMessage)
+}
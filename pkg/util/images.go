@@ -0,0 +1,127 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+// imageCache holds resolved image OCIDs keyed by endpoint (i.e. region) and
+// lookup. Entries live for lookupCacheTTL, so a newer image published mid-run
+// doesn't change what a name resolves to within an apply but is picked up later.
+var imageCache = newTTLCache(lookupCacheTTL)
+
+// ImageQuery names an image by display name instead of OCID. Name is matched
+// against display names as a prefix ending at a "-" or the end of the name, and
+// an "x" component matches any number: "Oracle-Linux-8.x" matches
+// "Oracle-Linux-8.10-2024.09.30-0". OperatingSystem, OperatingSystemVersion and
+// Shape narrow the search when set.
+type ImageQuery struct {
+	Name                   string
+	OperatingSystem        string
+	OperatingSystemVersion string
+	Shape                  string
+}
+
+// ExtractImageQuery reads imageName, operatingSystem and operatingSystemVersion
+// from a source details block. It reports false when imageName isn't set.
+func ExtractImageQuery(sourceDetails map[string]any) (ImageQuery, bool) {
+	name, ok := ExtractString(sourceDetails, "imageName")
+	if !ok || name == "" {
+		return ImageQuery{}, false
+	}
+	query := ImageQuery{Name: name}
+	query.OperatingSystem, _ = ExtractString(sourceDetails, "operatingSystem")
+	query.OperatingSystemVersion, _ = ExtractString(sourceDetails, "operatingSystemVersion")
+	return query, true
+}
+
+// ResolveImageByName returns the OCID of the most recently created available
+// image that matches query.
+func ResolveImageByName(ctx context.Context, compute *core.ComputeClient, compartmentId string, query ImageQuery) (string, error) {
+	key := strings.Join([]string{compute.Host, compartmentId, query.Name, query.OperatingSystem, query.OperatingSystemVersion, query.Shape}, "|")
+
+	if id, ok := imageCache.get(key); ok {
+		return id, nil
+	}
+
+	pattern, err := imageNamePattern(query.Name)
+	if err != nil {
+		return "", err
+	}
+
+	listReq := core.ListImagesRequest{
+		CompartmentId:  common.String(compartmentId),
+		LifecycleState: core.ImageLifecycleStateAvailable,
+		SortBy:         core.ListImagesSortByTimecreated,
+		SortOrder:      core.ListImagesSortOrderDesc,
+	}
+	if query.OperatingSystem != "" {
+		listReq.OperatingSystem = common.String(query.OperatingSystem)
+	}
+	if query.OperatingSystemVersion != "" {
+		listReq.OperatingSystemVersion = common.String(query.OperatingSystemVersion)
+	}
+	if query.Shape != "" {
+		listReq.Shape = common.String(query.Shape)
+	}
+
+	for {
+		resp, err := compute.ListImages(ctx, listReq)
+		if err != nil {
+			return "", fmt.Errorf("failed to list images: %w", err)
+		}
+		if id, ok := newestMatchingImage(resp.Items, pattern); ok {
+			imageCache.set(key, id)
+			return id, nil
+		}
+		if resp.OpcNextPage == nil {
+			break
+		}
+		listReq.Page = resp.OpcNextPage
+	}
+	return "", fmt.Errorf("no available image matches %q", query.Name)
+}
+
+// imageNamePattern compiles an image name into a regexp; see ImageQuery.
+func imageNamePattern(name string) (*regexp.Regexp, error) {
+	if name == "" {
+		return nil, fmt.Errorf("image name is empty")
+	}
+	parts := regexp.MustCompile(`[.-]`).Split(name, -1)
+	separators := regexp.MustCompile(`[.-]`).FindAllString(name, -1)
+
+	var b strings.Builder
+	b.WriteString("^")
+	for i, part := range parts {
+		if strings.EqualFold(part, "x") {
+			b.WriteString(`\d+`)
+		} else {
+			b.WriteString(regexp.QuoteMeta(part))
+		}
+		if i < len(separators) {
+			b.WriteString(regexp.QuoteMeta(separators[i]))
+		}
+	}
+	b.WriteString("(-|$)")
+	return regexp.Compile(b.String())
+}
+
+// newestMatchingImage returns the first image whose display name matches, which
+// is the newest when images are sorted by creation time, newest first.
+func newestMatchingImage(images []core.Image, pattern *regexp.Regexp) (string, bool) {
+	for _, image := range images {
+		if image.Id != nil && image.DisplayName != nil && pattern.MatchString(*image.DisplayName) {
+			return *image.Id, true
+		}
+	}
+	return "", false
+}
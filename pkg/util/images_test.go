@@ -0,0 +1,56 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package util
+
+import (
+	"testing"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImageNamePattern(t *testing.T) {
+	tests := []struct {
+		name        string
+		displayName string
+		want        bool
+	}{
+		{"Oracle-Linux-8.x", "Oracle-Linux-8.10-2024.09.30-0", true},
+		{"Oracle-Linux-8.x", "Oracle-Linux-8.10-aarch64-2024.09.30-0", true},
+		{"Oracle-Linux-8.x", "Oracle-Linux-9.4-2024.09.30-0", false},
+		{"Oracle-Linux-8.10", "Oracle-Linux-8.10-2024.09.30-0", true},
+		{"Oracle-Linux-8.1", "Oracle-Linux-8.10-2024.09.30-0", false},
+		{"Canonical-Ubuntu-22.04-2024.10.04-0", "Canonical-Ubuntu-22.04-2024.10.04-0", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+"/"+tt.displayName, func(t *testing.T) {
+			pattern, err := imageNamePattern(tt.name)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, pattern.MatchString(tt.displayName))
+		})
+	}
+}
+
+func TestNewestMatchingImage(t *testing.T) {
+	// ListImages returns newest first
+	images := []core.Image{
+		{Id: common.String("ocid1.image..ol9"), DisplayName: common.String("Oracle-Linux-9.4-2024.10.01-0")},
+		{Id: common.String("ocid1.image..ol8-new"), DisplayName: common.String("Oracle-Linux-8.10-2024.09.30-0")},
+		{Id: common.String("ocid1.image..ol8-old"), DisplayName: common.String("Oracle-Linux-8.9-2024.05.29-0")},
+	}
+	pattern, err := imageNamePattern("Oracle-Linux-8.x")
+	require.NoError(t, err)
+
+	id, ok := newestMatchingImage(images, pattern)
+	assert.True(t, ok)
+	assert.Equal(t, "ocid1.image..ol8-new", id)
+
+	pattern, err = imageNamePattern("Windows-Server-2022")
+	require.NoError(t, err)
+	_, ok = newestMatchingImage(images, pattern)
+	assert.False(t, ok)
+}
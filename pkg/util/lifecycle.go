@@ -0,0 +1,108 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+// LifecycleSnapshot is a single observation of a resource taken by PollLifecycle.
+type LifecycleSnapshot struct {
+	NativeID   string
+	State      string
+	Properties map[string]any // attached to successful results; may be nil
+}
+
+// LifecycleGetFunc fetches the current state of a resource. It returns a nil
+// snapshot when the resource no longer exists.
+type LifecycleGetFunc func(ctx context.Context) (*LifecycleSnapshot, error)
+
+// PollLifecycle fetches a resource once and maps its lifecycle state to an operation
+// status using mapping. States not present in mapping are reported as in progress.
+// A resource that no longer exists counts as success, since only a delete can get
+// there. Properties are attached to successful results unless the state is terminal.
+func PollLifecycle(ctx context.Context, resourceName string, requestID string, get LifecycleGetFunc, mapping map[string]resource.OperationStatus) (*resource.ProgressResult, error) {
+	snapshot, err := get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot == nil {
+		// Resource gone — if we were deleting, that's success
+		return &resource.ProgressResult{
+			Operation:       resource.OperationCheckStatus,
+			OperationStatus: resource.OperationStatusSuccess,
+			NativeID:        requestID,
+		}, nil
+	}
+
+	switch mapping[snapshot.State] {
+	case resource.OperationStatusSuccess:
+		result := &resource.ProgressResult{
+			Operation:       resource.OperationCheckStatus,
+			OperationStatus: resource.OperationStatusSuccess,
+			NativeID:        snapshot.NativeID,
+		}
+		if snapshot.Properties != nil && !IsTerminal(snapshot.State) {
+			propertiesBytes, err := json.Marshal(snapshot.Properties)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal properties: %w", err)
+			}
+			result.ResourceProperties = json.RawMessage(propertiesBytes)
+		}
+		return result, nil
+
+	case resource.OperationStatusFailure:
+		return &resource.ProgressResult{
+			Operation:       resource.OperationCheckStatus,
+			OperationStatus: resource.OperationStatusFailure,
+			NativeID:        snapshot.NativeID,
+			StatusMessage:   fmt.Sprintf("%s is in %s state", resourceName, snapshot.State),
+		}, nil
+
+	default:
+		return &resource.ProgressResult{
+			Operation:       resource.OperationCheckStatus,
+			OperationStatus: resource.OperationStatusInProgress,
+			RequestID:       requestID,
+			StatusMessage:   fmt.Sprintf("%s lifecycle state: %s", resourceName, snapshot.State),
+		}, nil
+	}
+}
+
+// ReadFunc is a provisioner's Read, used by SyncStatus to attach properties.
+type ReadFunc func(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error)
+
+// SyncStatus answers Status for resources whose operations complete synchronously.
+// When the request names the resource, it is read back so the result carries
+// ResourceProperties without a separate Read. A resource that can't be read (gone
+// after a delete, or a transient error) still reports success, just without them.
+func SyncStatus(ctx context.Context, request *resource.StatusRequest, read ReadFunc) (*resource.StatusResult, error) {
+	result := &resource.ProgressResult{
+		Operation:       resource.OperationCheckStatus,
+		OperationStatus: resource.OperationStatusSuccess,
+		RequestID:       request.RequestID,
+	}
+
+	nativeID := request.NativeID
+	if nativeID == "" {
+		nativeID = request.RequestID
+	}
+	if nativeID != "" {
+		readResult, err := read(ctx, &resource.ReadRequest{
+			NativeID:     nativeID,
+			ResourceType: request.ResourceType,
+			TargetConfig: request.TargetConfig,
+		})
+		if err == nil && readResult.ErrorCode == "" {
+			result.ResourceProperties = json.RawMessage(readResult.Properties)
+		}
+	}
+
+	return &resource.StatusResult{ProgressResult: result}, nil
+}
@@ -0,0 +1,71 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package util
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPollLifecycle(t *testing.T) {
+	mapping := map[string]resource.OperationStatus{
+		"AVAILABLE":  resource.OperationStatusSuccess,
+		"TERMINATED": resource.OperationStatusSuccess,
+		"FAILED":     resource.OperationStatusFailure,
+	}
+	snapshot := func(state string) LifecycleGetFunc {
+		return func(ctx context.Context) (*LifecycleSnapshot, error) {
+			return &LifecycleSnapshot{NativeID: "ocid1.volume..aaa", State: state, Properties: map[string]any{"SizeInGBs": 50}}, nil
+		}
+	}
+
+	t.Run("success_carries_properties", func(t *testing.T) {
+		result, err := PollLifecycle(context.Background(), "Volume", "req-1", snapshot("AVAILABLE"), mapping)
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusSuccess, result.OperationStatus)
+		assert.Equal(t, "ocid1.volume..aaa", result.NativeID)
+		assert.JSONEq(t, `{"SizeInGBs": 50}`, string(result.ResourceProperties))
+	})
+
+	t.Run("terminal_success_omits_properties", func(t *testing.T) {
+		result, err := PollLifecycle(context.Background(), "Volume", "req-1", snapshot("TERMINATED"), mapping)
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusSuccess, result.OperationStatus)
+		assert.Empty(t, result.ResourceProperties)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		result, err := PollLifecycle(context.Background(), "Volume", "req-1", snapshot("FAILED"), mapping)
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusFailure, result.OperationStatus)
+		assert.Equal(t, "Volume is in FAILED state", result.StatusMessage)
+	})
+
+	t.Run("unmapped_state_in_progress", func(t *testing.T) {
+		result, err := PollLifecycle(context.Background(), "Volume", "req-1", snapshot("PROVISIONING"), mapping)
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusInProgress, result.OperationStatus)
+		assert.Equal(t, "req-1", result.RequestID)
+	})
+
+	t.Run("gone_is_success", func(t *testing.T) {
+		gone := func(ctx context.Context) (*LifecycleSnapshot, error) { return nil, nil }
+		result, err := PollLifecycle(context.Background(), "Volume", "req-1", gone, mapping)
+		require.NoError(t, err)
+		assert.Equal(t, resource.OperationStatusSuccess, result.OperationStatus)
+		assert.Equal(t, "req-1", result.NativeID)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		failing := func(ctx context.Context) (*LifecycleSnapshot, error) { return nil, errors.New("boom") }
+		_, err := PollLifecycle(context.Background(), "Volume", "req-1", failing, mapping)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,59 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package util
+
+import (
+	"encoding/json"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+// maxListPageSize is the largest Limit OCI list APIs accept.
+const maxListPageSize = 1000
+
+// ListPageSize returns the Limit to send with OCI list calls: the target's
+// ListPageSize, or the OCI maximum when it isn't set.
+func ListPageSize(targetConfig json.RawMessage) *int {
+	if size := config.FromTargetConfig(targetConfig).ListPageSize; size > 0 && size < maxListPageSize {
+		return common.Int(size)
+	}
+	return common.Int(maxListPageSize)
+}
+
+// ListCompartmentId returns the compartment a List call is scoped to: the
+// CompartmentId additional property when present, otherwise the target's
+// DefaultCompartmentId. The bool is false when neither is set.
+func ListCompartmentId(request *resource.ListRequest) (string, bool) {
+	if id, ok := request.AdditionalProperties["CompartmentId"]; ok {
+		return id, true
+	}
+	if id := config.FromTargetConfig(request.TargetConfig).DefaultCompartmentId; id != "" {
+		return id, true
+	}
+	return "", false
+}
+
+// ListAllPages runs fetch for every page of a list call and returns the items
+// from all of them, usually IDs or the SDK's summaries. fetch gets nil for the
+// first page, then each page token the one before returned; a nil or empty token
+// ends the listing. OCI list calls return at most ListPageSize items per call, so
+// reading only the first page silently drops the rest.
+func ListAllPages[T any](fetch func(page *string) ([]T, *string, error)) ([]T, error) {
+	items := []T{}
+	var page *string
+	for {
+		pageItems, next, err := fetch(page)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, pageItems...)
+		if next == nil || *next == "" {
+			return items, nil
+		}
+		page = next
+	}
+}
@@ -0,0 +1,109 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListCompartmentId(t *testing.T) {
+	defaultTarget := json.RawMessage(`{"DefaultCompartmentId": "ocid1.compartment..default"}`)
+
+	cases := []struct {
+		name    string
+		request *resource.ListRequest
+		want    string
+		wantOk  bool
+	}{
+		{
+			name: "explicit_wins",
+			request: &resource.ListRequest{
+				TargetConfig:         defaultTarget,
+				AdditionalProperties: map[string]string{"CompartmentId": "ocid1.compartment..explicit"},
+			},
+			want:   "ocid1.compartment..explicit",
+			wantOk: true,
+		},
+		{
+			name:    "falls_back_to_default",
+			request: &resource.ListRequest{TargetConfig: defaultTarget},
+			want:    "ocid1.compartment..default",
+			wantOk:  true,
+		},
+		{
+			name:    "neither",
+			request: &resource.ListRequest{AdditionalProperties: map[string]string{}},
+			want:    "",
+			wantOk:  false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := ListCompartmentId(tc.request)
+			assert.Equal(t, tc.wantOk, ok)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestListPageSize(t *testing.T) {
+	assert.Equal(t, 1000, *ListPageSize(nil))
+	assert.Equal(t, 50, *ListPageSize(json.RawMessage(`{"ListPageSize": 50}`)))
+	assert.Equal(t, 1000, *ListPageSize(json.RawMessage(`{"ListPageSize": 5000}`)))
+}
+
+func TestListAllPages(t *testing.T) {
+	pages := map[string][]string{
+		"":       {"a", "b"},
+		"page-1": {"c"},
+		"page-2": {},
+	}
+	next := map[string]string{"": "page-1", "page-1": "page-2"}
+
+	var requested []string
+	ids, err := ListAllPages(func(page *string) ([]string, *string, error) {
+		token := ""
+		if page != nil {
+			token = *page
+		}
+		requested = append(requested, token)
+		if n, ok := next[token]; ok {
+			return pages[token], &n, nil
+		}
+		return pages[token], nil, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, ids)
+	assert.Equal(t, []string{"", "page-1", "page-2"}, requested)
+
+	empty := ""
+	ids, err = ListAllPages(func(page *string) ([]string, *string, error) {
+		return nil, &empty, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{}, ids)
+
+	_, err = ListAllPages(func(page *string) ([]string, *string, error) {
+		return nil, nil, errors.New("boom")
+	})
+	assert.EqualError(t, err, "boom")
+
+	// Summaries page the same way as IDs
+	type summary struct{ Id string }
+	second := "page-1"
+	summaries, err := ListAllPages(func(page *string) ([]summary, *string, error) {
+		if page == nil {
+			return []summary{{Id: "a"}}, &second, nil
+		}
+		return []summary{{Id: "b"}}, nil, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []summary{{Id: "a"}, {Id: "b"}}, summaries)
+}
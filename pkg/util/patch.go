@@ -8,21 +8,39 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 
 	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
 )
 
+// ApplyPatchDocument returns the properties to update the resource to: the
+// desired properties when there is no patch, otherwise the patch applied to a
+// fresh Read. Either way the target's default tags are merged in, unless ctx
+// comes from WithoutDefaultTags, so an update never strips them; they are hidden from the Read first so patch paths line
+// up with the properties formae saw. For the same reason the Read is put in
+// canonical shape when ctx carries a CanonicalSpec. Tag maps the patch leaves
+// as they are are omitted, so the update doesn't resend them.
 func ApplyPatchDocument(
 	ctx context.Context,
 	request *resource.UpdateRequest,
 	readFunc func(ctx context.Context, readReq *resource.ReadRequest) (*resource.ReadResult, error),
 ) (map[string]any, error) {
+	cfg := config.FromTargetConfig(request.TargetConfig)
+	if defaultTagsSkipped(ctx) {
+		cfg.DefaultFreeformTags, cfg.DefaultDefinedTags = nil, nil
+	}
+
 	if request.PatchDocument == nil || *request.PatchDocument == "" {
 		var props map[string]any
 		if err := json.Unmarshal(request.DesiredProperties, &props); err != nil {
 			return nil, fmt.Errorf("failed to parse properties: %w", err)
 		}
+		MergeDefaultTags(props, cfg)
+		if err := keepHiddenDefinedTags(ctx, request, readFunc, props, cfg); err != nil {
+			return nil, err
+		}
 		return props, nil
 	}
 
@@ -31,12 +49,27 @@ func ApplyPatchDocument(
 		ResourceType: request.ResourceType,
 		TargetConfig: request.TargetConfig,
 	}
-	readResult, err := readFunc(ctx, readReq)
+	readResult, err := SharedRead(ctx, readReq, readFunc)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read existing resource: %w", err)
 	}
 
-	existingJSON := []byte(readResult.Properties)
+	var current, existing map[string]any
+	if err := json.Unmarshal([]byte(readResult.Properties), &current); err != nil {
+		return nil, fmt.Errorf("failed to parse existing properties: %w", err)
+	}
+	if err := json.Unmarshal([]byte(readResult.Properties), &existing); err != nil {
+		return nil, fmt.Errorf("failed to parse existing properties: %w", err)
+	}
+	if spec, ok := canonicalSpecFrom(ctx); ok {
+		spec.Canonicalize(current)
+		spec.Canonicalize(existing)
+	}
+	StripDefaultTags(existing, cfg)
+	existingJSON, err := json.Marshal(existing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal existing properties: %w", err)
+	}
 	patchJSON := []byte(*request.PatchDocument)
 
 	patch, err := jsonpatch.DecodePatch(patchJSON)
@@ -53,6 +86,82 @@ func ApplyPatchDocument(
 	if err := json.Unmarshal(patchedJSON, &mergedProps); err != nil {
 		return nil, fmt.Errorf("failed to parse merged properties: %w", err)
 	}
+	MergeDefaultTags(mergedProps, cfg)
+	OmitUnchangedTags(current, mergedProps)
+	if err := keepHiddenDefinedTags(ctx, request, readFunc, mergedProps, cfg); err != nil {
+		return nil, err
+	}
 
 	return mergedProps, nil
 }
+
+// keepHiddenDefinedTags adds the live resource's tags in the namespaces Read
+// hides (config.HiddenTagNamespaces) to props["DefinedTags"]. Update APIs
+// replace the whole map, so without them an update that changes any defined
+// tag would wipe Oracle-Tags and the compartment tag defaults. They are read
+// back with KeepHiddenTags set, which costs a Read only when DefinedTags is sent.
+func keepHiddenDefinedTags(
+	ctx context.Context,
+	request *resource.UpdateRequest,
+	readFunc func(ctx context.Context, readReq *resource.ReadRequest) (*resource.ReadResult, error),
+	props map[string]any,
+	cfg *config.Config,
+) error {
+	tags, ok := props["DefinedTags"].([]any)
+	if !ok {
+		return nil
+	}
+
+	var target map[string]any
+	if len(request.TargetConfig) > 0 {
+		if err := json.Unmarshal(request.TargetConfig, &target); err != nil {
+			return fmt.Errorf("failed to parse target config: %w", err)
+		}
+	}
+	if target == nil {
+		target = map[string]any{}
+	}
+	target["KeepHiddenTags"] = true
+	targetConfig, err := json.Marshal(target)
+	if err != nil {
+		return fmt.Errorf("failed to marshal target config: %w", err)
+	}
+
+	readResult, err := readFunc(ctx, &resource.ReadRequest{
+		NativeID:     request.NativeID,
+		ResourceType: request.ResourceType,
+		TargetConfig: targetConfig,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read hidden tags: %w", err)
+	}
+	if readResult == nil || readResult.Properties == "" {
+		return nil
+	}
+	var live map[string]any
+	if err := json.Unmarshal([]byte(readResult.Properties), &live); err != nil {
+		return fmt.Errorf("failed to parse existing properties: %w", err)
+	}
+
+	hidden := cfg.HiddenTagNamespaces()
+	declared := map[string]bool{}
+	for _, item := range tags {
+		if tag, ok := item.(map[string]any); ok {
+			ns, _ := tag["Namespace"].(string)
+			declared[ns] = true
+		}
+	}
+	liveTags, _ := live["DefinedTags"].([]any)
+	for _, item := range liveTags {
+		tag, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		ns, _ := tag["Namespace"].(string)
+		if slices.Contains(hidden, ns) && !declared[ns] {
+			tags = append(tags, tag)
+		}
+	}
+	props["DefinedTags"] = tags
+	return nil
+}
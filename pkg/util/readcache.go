@@ -0,0 +1,107 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package util
+
+import (
+	"context"
+	"sync"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+// readCache shares list results between the Reads of one batch. Some children,
+// e.g. NSG security rules, can only be read by listing their parent; without
+// it a batch of n such Reads lists the same parent n times. An Update uses one
+// too, so the checks ahead of the write share a single Read (see SharedRead).
+type readCache struct {
+	mu      sync.Mutex
+	entries map[string]*readCacheEntry
+}
+
+type readCacheEntry struct {
+	done  chan struct{}
+	value any
+	err   error
+}
+
+type readCacheKey struct{}
+
+// WithReadCache returns a context in which Cached calls with the same key share
+// one fetch. The cache lives as long as the context, so it should wrap a single
+// batch of Reads; anything longer would serve stale state.
+func WithReadCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readCacheKey{}, &readCache{entries: map[string]*readCacheEntry{}})
+}
+
+// Cached returns fetch's result for key, calling fetch at most once per key
+// within a context from WithReadCache. Concurrent callers wait for the fetch
+// already in flight. Errors are not kept, so a later caller fetches again.
+// Without a cache in ctx, Cached just calls fetch.
+func Cached[T any](ctx context.Context, key string, fetch func() (T, error)) (T, error) {
+	cache, ok := ctx.Value(readCacheKey{}).(*readCache)
+	if !ok {
+		return fetch()
+	}
+
+	cache.mu.Lock()
+	entry, found := cache.entries[key]
+	if !found {
+		entry = &readCacheEntry{done: make(chan struct{})}
+		cache.entries[key] = entry
+	}
+	cache.mu.Unlock()
+
+	if found {
+		select {
+		case <-entry.done:
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+		if entry.err != nil {
+			var zero T
+			return zero, entry.err
+		}
+		return entry.value.(T), nil
+	}
+
+	value, err := fetch()
+	entry.value, entry.err = value, err
+	if err != nil {
+		cache.mu.Lock()
+		delete(cache.entries, key)
+		cache.mu.Unlock()
+	}
+	close(entry.done)
+	return value, err
+}
+
+// Forget drops key from the cache in ctx, so the next Cached call fetches again.
+func Forget(ctx context.Context, key string) {
+	if cache, ok := ctx.Value(readCacheKey{}).(*readCache); ok {
+		cache.mu.Lock()
+		delete(cache.entries, key)
+		cache.mu.Unlock()
+	}
+}
+
+// SharedReadKey is the cache key SharedRead files request's result under.
+func SharedReadKey(request *resource.ReadRequest) string {
+	return "read/" + request.ResourceType + "/" + request.NativeID
+}
+
+// SharedRead calls read through Cached, so the Reads an Update makes before it
+// writes (noOpUpdate, replaceOnChange, ApplyPatchDocument) cost one API call.
+// Callers get their own copy of the result and may change it.
+func SharedRead(ctx context.Context, request *resource.ReadRequest, read func(context.Context, *resource.ReadRequest) (*resource.ReadResult, error)) (*resource.ReadResult, error) {
+	result, err := Cached(ctx, SharedReadKey(request), func() (*resource.ReadResult, error) {
+		return read(ctx, request)
+	})
+	if err != nil || result == nil {
+		return result, err
+	}
+	shared := *result
+	return &shared, nil
+}
@@ -0,0 +1,110 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package util
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCached(t *testing.T) {
+	t.Run("shares one fetch per key", func(t *testing.T) {
+		ctx := WithReadCache(context.Background())
+		var calls atomic.Int32
+		fetch := func() ([]string, error) {
+			calls.Add(1)
+			return []string{"rule-1", "rule-2"}, nil
+		}
+
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				rules, err := Cached(ctx, "nsg-a", fetch)
+				assert.NoError(t, err)
+				assert.Len(t, rules, 2)
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(1), calls.Load())
+
+		_, err := Cached(ctx, "nsg-b", fetch)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("errors are not kept", func(t *testing.T) {
+		ctx := WithReadCache(context.Background())
+		var calls int
+		fetch := func() (int, error) {
+			calls++
+			if calls == 1 {
+				return 0, errors.New("throttled")
+			}
+			return 42, nil
+		}
+
+		_, err := Cached(ctx, "key", fetch)
+		assert.EqualError(t, err, "throttled")
+		value, err := Cached(ctx, "key", fetch)
+		require.NoError(t, err)
+		assert.Equal(t, 42, value)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("no cache in context", func(t *testing.T) {
+		var calls int
+		fetch := func() (int, error) {
+			calls++
+			return calls, nil
+		}
+
+		first, _ := Cached(context.Background(), "key", fetch)
+		second, _ := Cached(context.Background(), "key", fetch)
+		assert.Equal(t, 1, first)
+		assert.Equal(t, 2, second)
+	})
+	t.Run("forget fetches again", func(t *testing.T) {
+		ctx := WithReadCache(context.Background())
+		var calls int
+		fetch := func() (int, error) {
+			calls++
+			return calls, nil
+		}
+
+		first, _ := Cached(ctx, "key", fetch)
+		Forget(ctx, "key")
+		second, _ := Cached(ctx, "key", fetch)
+		assert.Equal(t, 1, first)
+		assert.Equal(t, 2, second)
+	})
+}
+
+func TestSharedRead(t *testing.T) {
+	ctx := WithReadCache(context.Background())
+	var calls int
+	read := func(context.Context, *resource.ReadRequest) (*resource.ReadResult, error) {
+		calls++
+		return &resource.ReadResult{Properties: `{"DisplayName":"data"}`}, nil
+	}
+	request := &resource.ReadRequest{NativeID: "ocid1.volume.oc1..abc", ResourceType: "OCI::Core::Volume"}
+
+	first, err := SharedRead(ctx, request, read)
+	require.NoError(t, err)
+	first.Properties = `{}`
+	second, err := SharedRead(ctx, request, read)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, `{"DisplayName":"data"}`, second.Properties, "callers get their own copy")
+}
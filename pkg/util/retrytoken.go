@@ -0,0 +1,48 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package util
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+// CreateRetryToken returns the opc-retry-token for one Create attempt. The SDK
+// sends the same header on every retry of the call, so a create whose response
+// was lost to a 5xx or a dropped connection is answered with the resource the
+// first try made instead of a duplicate.
+//
+// The token hashes the resource type, label and desired properties with a
+// random nonce. The nonce makes every attempt unique: OCI remembers tokens for
+// 24 hours, and a token derived from the properties alone made OCI reject an
+// identical resource recreated in that window, e.g. after an out-of-band
+// delete. Properties are canonicalised first, so key order does not matter. The
+// result is a 64-character hex string, OCI's maximum token length.
+func CreateRetryToken(request *resource.CreateRequest) string {
+	properties := []byte(request.Properties)
+	var decoded any
+	if err := json.Unmarshal(request.Properties, &decoded); err == nil {
+		if canonical, err := json.Marshal(decoded); err == nil {
+			properties = canonical
+		}
+	}
+
+	nonce := make([]byte, 16)
+	_, _ = rand.Read(nonce)
+
+	h := sha256.New()
+	h.Write([]byte(request.ResourceType))
+	h.Write([]byte{0})
+	h.Write([]byte(request.Label))
+	h.Write([]byte{0})
+	h.Write(properties)
+	h.Write([]byte{0})
+	h.Write(nonce)
+	return hex.EncodeToString(h.Sum(nil))
+}
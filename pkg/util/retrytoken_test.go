@@ -0,0 +1,47 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package util
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateRetryToken_UniquePerAttempt(t *testing.T) {
+	request := &resource.CreateRequest{
+		ResourceType: "OCI::Core::VCN",
+		Label:        "main",
+		Properties:   json.RawMessage(`{"CompartmentId":"ocid1.compartment..a","CidrBlock":"10.0.0.0/16"}`),
+	}
+
+	token := CreateRetryToken(request)
+	assert.Len(t, token, 64)
+	// Recreating the same resource must not reuse the earlier attempt's token
+	assert.NotEqual(t, token, CreateRetryToken(request))
+}
+
+func TestCreateRetryToken_DiffersByInput(t *testing.T) {
+	base := &resource.CreateRequest{
+		ResourceType: "OCI::Core::VCN",
+		Label:        "main",
+		Properties:   json.RawMessage(`{"CidrBlock":"10.0.0.0/16"}`),
+	}
+	otherLabel := &resource.CreateRequest{
+		ResourceType: "OCI::Core::VCN",
+		Label:        "secondary",
+		Properties:   base.Properties,
+	}
+	otherProps := &resource.CreateRequest{
+		ResourceType: "OCI::Core::VCN",
+		Label:        "main",
+		Properties:   json.RawMessage(`{"CidrBlock":"10.1.0.0/16"}`),
+	}
+
+	assert.NotEqual(t, CreateRetryToken(base), CreateRetryToken(otherLabel))
+	assert.NotEqual(t, CreateRetryToken(base), CreateRetryToken(otherProps))
+}
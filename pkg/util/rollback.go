@@ -0,0 +1,48 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package util
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+// RollbackCreate handles a Create that needs more than one API call when a
+// follow-up call fails after the primary resource already exists. It runs
+// cleanup to delete the partially created resource and reports cause as a
+// failed create. If cleanup fails too, the NativeID is kept on the result so
+// formae tracks the leftover resource and can reconcile or delete it later,
+// instead of leaving it orphaned in the tenancy.
+func RollbackCreate(ctx context.Context, cause error, nativeID string, operationName string, cleanup func(context.Context) error) *resource.CreateResult {
+	errorCode, ok := HandleOCIServiceError(cause)
+	if !ok {
+		errorCode = resource.OperationErrorCodeServiceInternalError
+	}
+	message := serviceErrorMessage(cause, operationName, "created")
+
+	if cleanupErr := cleanup(ctx); cleanupErr != nil && !IsNotFound(cleanupErr) {
+		return &resource.CreateResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationCreate,
+				OperationStatus: resource.OperationStatusFailure,
+				ErrorCode:       errorCode,
+				StatusMessage: fmt.Sprintf("%s; rolling back %s failed, it was left in place: %v",
+					message, nativeID, cleanupErr),
+				NativeID: nativeID,
+			},
+		}
+	}
+
+	return &resource.CreateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationCreate,
+			OperationStatus: resource.OperationStatusFailure,
+			ErrorCode:       errorCode,
+			StatusMessage:   fmt.Sprintf("%s; %s was rolled back", message, nativeID),
+		},
+	}
+}
@@ -0,0 +1,53 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package util
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollbackCreate(t *testing.T) {
+	cause := fakeServiceError{status: 400, code: "InvalidParameter"}
+
+	t.Run("cleanup succeeds", func(t *testing.T) {
+		called := false
+		result := RollbackCreate(context.Background(), cause, "thing-1", "OCI::Test::Thing", func(context.Context) error {
+			called = true
+			return nil
+		})
+		assert.True(t, called)
+		assert.Equal(t, resource.OperationStatusFailure, result.ProgressResult.OperationStatus)
+		assert.Equal(t, resource.OperationErrorCodeInvalidRequest, result.ProgressResult.ErrorCode)
+		assert.Empty(t, result.ProgressResult.NativeID)
+	})
+
+	t.Run("cleanup finds nothing to delete", func(t *testing.T) {
+		result := RollbackCreate(context.Background(), cause, "thing-1", "OCI::Test::Thing", func(context.Context) error {
+			return fakeServiceError{status: 404, code: "NotFound"}
+		})
+		assert.Empty(t, result.ProgressResult.NativeID)
+	})
+
+	t.Run("cleanup fails", func(t *testing.T) {
+		result := RollbackCreate(context.Background(), cause, "thing-1", "OCI::Test::Thing", func(context.Context) error {
+			return errors.New("connection reset")
+		})
+		assert.Equal(t, resource.OperationErrorCodeInvalidRequest, result.ProgressResult.ErrorCode)
+		assert.Equal(t, "thing-1", result.ProgressResult.NativeID)
+		assert.Contains(t, result.ProgressResult.StatusMessage, "connection reset")
+	})
+
+	t.Run("non-OCI cause", func(t *testing.T) {
+		result := RollbackCreate(context.Background(), errors.New("timeout"), "thing-1", "OCI::Test::Thing", func(context.Context) error {
+			return nil
+		})
+		assert.Equal(t, resource.OperationErrorCodeServiceInternalError, result.ProgressResult.ErrorCode)
+	})
+}
@@ -0,0 +1,91 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+// serviceCache holds ListServices results keyed by endpoint (i.e. region). The
+// Oracle Services Network services of a region practically never change, so
+// entries live for the lifetime of the plugin process.
+var (
+	serviceCacheMu sync.Mutex
+	serviceCache   = map[string][]core.Service{}
+)
+
+func listServicesCached(ctx context.Context, network *core.VirtualNetworkClient) ([]core.Service, error) {
+	serviceCacheMu.Lock()
+	services, ok := serviceCache[network.Host]
+	serviceCacheMu.Unlock()
+	if ok {
+		return services, nil
+	}
+
+	services, err := ListAllPages(func(page *string) ([]core.Service, *string, error) {
+		resp, err := network.ListServices(ctx, core.ListServicesRequest{Page: page})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list services: %w", err)
+		}
+		return resp.Items, resp.OpcNextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	serviceCacheMu.Lock()
+	serviceCache[network.Host] = services
+	serviceCacheMu.Unlock()
+	return services, nil
+}
+
+// ResolveServiceId returns the OCID of an Oracle Services Network service given
+// its name in this region ("All PHX Services In Oracle Services Network") or the
+// same name without the region key ("All Services In Oracle Services Network"),
+// which works in every region. Names match case-insensitively.
+func ResolveServiceId(ctx context.Context, network *core.VirtualNetworkClient, name string) (string, error) {
+	services, err := listServicesCached(ctx, network)
+	if err != nil {
+		return "", err
+	}
+	if id, ok := matchService(services, name); ok {
+		return id, nil
+	}
+
+	names := make([]string, 0, len(services))
+	for _, service := range services {
+		if service.Name != nil {
+			names = append(names, *service.Name)
+		}
+	}
+	return "", fmt.Errorf("no service named %q in this region, available: %s", name, strings.Join(names, ", "))
+}
+
+func matchService(services []core.Service, name string) (string, bool) {
+	for _, service := range services {
+		if service.Id == nil || service.Name == nil {
+			continue
+		}
+		if strings.EqualFold(*service.Name, name) || strings.EqualFold(withoutRegionKey(*service.Name), name) {
+			return *service.Id, true
+		}
+	}
+	return "", false
+}
+
+// withoutRegionKey drops the three-letter region key OCI puts second in
+// service names: "OCI PHX Object Storage" becomes "OCI Object Storage".
+func withoutRegionKey(name string) string {
+	words := strings.Fields(name)
+	if len(words) < 3 || len(words[1]) != 3 || strings.ToUpper(words[1]) != words[1] {
+		return name
+	}
+	return strings.Join(append(words[:1:1], words[2:]...), " ")
+}
@@ -0,0 +1,40 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package util
+
+import (
+	"testing"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchService(t *testing.T) {
+	services := []core.Service{
+		{Id: common.String("ocid1.service.oc1.phx.all"), Name: common.String("All PHX Services In Oracle Services Network")},
+		{Id: common.String("ocid1.service.oc1.phx.objectstorage"), Name: common.String("OCI PHX Object Storage")},
+	}
+
+	tests := []struct {
+		name   string
+		lookup string
+		want   string
+	}{
+		{name: "regional name", lookup: "All PHX Services In Oracle Services Network", want: "ocid1.service.oc1.phx.all"},
+		{name: "region neutral name", lookup: "All Services In Oracle Services Network", want: "ocid1.service.oc1.phx.all"},
+		{name: "case insensitive", lookup: "oci object storage", want: "ocid1.service.oc1.phx.objectstorage"},
+		{name: "other region", lookup: "All IAD Services In Oracle Services Network"},
+		{name: "unknown", lookup: "OCI Streaming"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, ok := matchService(services, tt.lookup)
+			assert.Equal(t, tt.want != "", ok)
+			assert.Equal(t, tt.want, id)
+		})
+	}
+}
@@ -0,0 +1,176 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+// shapeCache holds ListShapes results keyed by endpoint (i.e. region), compartment
+// and availability domain. Shape limits change rarely, so entries live for the
+// lifetime of the plugin process.
+var (
+	shapeCacheMu sync.Mutex
+	shapeCache   = map[string][]core.Shape{}
+)
+
+func listShapesCached(ctx context.Context, compute *core.ComputeClient, compartmentId, availabilityDomain string) ([]core.Shape, error) {
+	key := compute.Host + "|" + compartmentId + "|" + availabilityDomain
+
+	shapeCacheMu.Lock()
+	shapes, ok := shapeCache[key]
+	shapeCacheMu.Unlock()
+	if ok {
+		return shapes, nil
+	}
+
+	req := core.ListShapesRequest{CompartmentId: common.String(compartmentId)}
+	if availabilityDomain != "" {
+		req.AvailabilityDomain = common.String(availabilityDomain)
+	}
+	shapes, err := ListAllPages(func(page *string) ([]core.Shape, *string, error) {
+		req.Page = page
+		resp, err := compute.ListShapes(ctx, req)
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Items, resp.OpcNextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	shapeCacheMu.Lock()
+	shapeCache[key] = shapes
+	shapeCacheMu.Unlock()
+	return shapes, nil
+}
+
+// ValidateShapeConfig checks requested OCPUs and memory for a flexible shape against
+// the ranges ListShapes reports, so an invalid combination fails before launch with a
+// precise message instead of a generic 400. availabilityDomain may be empty.
+// The check is best-effort: if the shapes cannot be listed or the shape is unknown,
+// it returns nil and leaves validation to the service.
+func ValidateShapeConfig(ctx context.Context, compute *core.ComputeClient, compartmentId, availabilityDomain, shape string, ocpus, memoryInGBs *float32) error {
+	if ocpus == nil && memoryInGBs == nil {
+		return nil
+	}
+
+	shapes, err := listShapesCached(ctx, compute, compartmentId, availabilityDomain)
+	if err != nil {
+		return nil
+	}
+
+	for _, s := range shapes {
+		if s.Shape == nil || *s.Shape != shape {
+			continue
+		}
+		return checkShapeLimits(s, ocpus, memoryInGBs)
+	}
+	return nil
+}
+
+func checkShapeLimits(s core.Shape, ocpus, memoryInGBs *float32) error {
+	if ocpus != nil && s.OcpuOptions != nil {
+		if opts := s.OcpuOptions; (opts.Min != nil && *ocpus < *opts.Min) || (opts.Max != nil && *ocpus > *opts.Max) {
+			return fmt.Errorf("shape %s allows %s OCPUs, got %g", *s.Shape, formatRange(opts.Min, opts.Max), *ocpus)
+		}
+	}
+	if memoryInGBs != nil && s.MemoryOptions != nil {
+		opts := s.MemoryOptions
+		if (opts.MinInGBs != nil && *memoryInGBs < *opts.MinInGBs) || (opts.MaxInGBs != nil && *memoryInGBs > *opts.MaxInGBs) {
+			return fmt.Errorf("shape %s allows %s GB of memory, got %g", *s.Shape, formatRange(opts.MinInGBs, opts.MaxInGBs), *memoryInGBs)
+		}
+		if ocpus != nil && *ocpus > 0 {
+			perOcpu := *memoryInGBs / *ocpus
+			if (opts.MinPerOcpuInGBs != nil && perOcpu < *opts.MinPerOcpuInGBs) || (opts.MaxPerOcpuInGBs != nil && perOcpu > *opts.MaxPerOcpuInGBs) {
+				return fmt.Errorf("shape %s allows %s GB of memory per OCPU, got %g GB for %g OCPUs",
+					*s.Shape, formatRange(opts.MinPerOcpuInGBs, opts.MaxPerOcpuInGBs), *memoryInGBs, *ocpus)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidatePlatformConfig checks a platform config (Shielded Instance and
+// confidential computing settings) against the platform config options
+// ListShapes reports for shape. Like ValidateShapeConfig it is best-effort.
+func ValidatePlatformConfig(ctx context.Context, compute *core.ComputeClient, compartmentId, availabilityDomain, shape, platformType string, config core.LaunchInstancePlatformConfig) error {
+	if config == nil {
+		return nil
+	}
+
+	shapes, err := listShapesCached(ctx, compute, compartmentId, availabilityDomain)
+	if err != nil {
+		return nil
+	}
+
+	for _, s := range shapes {
+		if s.Shape == nil || *s.Shape != shape {
+			continue
+		}
+		return checkPlatformConfig(s, platformType, config)
+	}
+	return nil
+}
+
+func checkPlatformConfig(s core.Shape, platformType string, config core.LaunchInstancePlatformConfig) error {
+	opts := s.PlatformConfigOptions
+	if opts == nil {
+		return fmt.Errorf("shape %s does not support a platformConfig", *s.Shape)
+	}
+	if string(opts.Type) != platformType {
+		return fmt.Errorf("shape %s takes platformConfig type %s, got %s", *s.Shape, opts.Type, platformType)
+	}
+
+	settings := []struct {
+		name    string
+		value   *bool
+		allowed []bool
+	}{
+		{name: "isSecureBootEnabled", value: config.GetIsSecureBootEnabled()},
+		{name: "isTrustedPlatformModuleEnabled", value: config.GetIsTrustedPlatformModuleEnabled()},
+		{name: "isMeasuredBootEnabled", value: config.GetIsMeasuredBootEnabled()},
+		{name: "isMemoryEncryptionEnabled", value: config.GetIsMemoryEncryptionEnabled()},
+	}
+	if opts.SecureBootOptions != nil {
+		settings[0].allowed = opts.SecureBootOptions.AllowedValues
+	}
+	if opts.TrustedPlatformModuleOptions != nil {
+		settings[1].allowed = opts.TrustedPlatformModuleOptions.AllowedValues
+	}
+	if opts.MeasuredBootOptions != nil {
+		settings[2].allowed = opts.MeasuredBootOptions.AllowedValues
+	}
+	if opts.MemoryEncryptionOptions != nil {
+		settings[3].allowed = opts.MemoryEncryptionOptions.AllowedValues
+	}
+
+	for _, setting := range settings {
+		if setting.value == nil || len(setting.allowed) == 0 || slices.Contains(setting.allowed, *setting.value) {
+			continue
+		}
+		return fmt.Errorf("shape %s does not allow platformConfig.%s = %t", *s.Shape, setting.name, *setting.value)
+	}
+	return nil
+}
+
+func formatRange(lower, upper *float32) string {
+	switch {
+	case lower != nil && upper != nil:
+		return fmt.Sprintf("%g-%g", *lower, *upper)
+	case lower != nil:
+		return fmt.Sprintf("at least %g", *lower)
+	case upper != nil:
+		return fmt.Sprintf("at most %g", *upper)
+	}
+	return "any"
+}
@@ -0,0 +1,64 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package util
+
+import (
+	"testing"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func testFlexShape() core.Shape {
+	return core.Shape{
+		Shape:       common.String("VM.Standard.E4.Flex"),
+		OcpuOptions: &core.ShapeOcpuOptions{Min: common.Float32(1), Max: common.Float32(64)},
+		MemoryOptions: &core.ShapeMemoryOptions{
+			MinInGBs:        common.Float32(1),
+			MaxInGBs:        common.Float32(1024),
+			MinPerOcpuInGBs: common.Float32(1),
+			MaxPerOcpuInGBs: common.Float32(64),
+		},
+	}
+}
+
+func TestCheckShapeLimits_WithinRange(t *testing.T) {
+	assert.NoError(t, checkShapeLimits(testFlexShape(), common.Float32(2), common.Float32(16)))
+}
+
+func TestCheckShapeLimits_TooManyOcpus(t *testing.T) {
+	err := checkShapeLimits(testFlexShape(), common.Float32(128), nil)
+	assert.ErrorContains(t, err, "1-64 OCPUs")
+}
+
+func TestCheckShapeLimits_MemoryPerOcpu(t *testing.T) {
+	err := checkShapeLimits(testFlexShape(), common.Float32(1), common.Float32(128))
+	assert.ErrorContains(t, err, "memory per OCPU")
+}
+
+func TestCheckPlatformConfig(t *testing.T) {
+	shielded := core.Shape{
+		Shape: common.String("VM.Standard.E4.Flex"),
+		PlatformConfigOptions: &core.ShapePlatformConfigOptions{
+			Type:                         core.ShapePlatformConfigOptionsTypeAmdVm,
+			SecureBootOptions:            &core.ShapeSecureBootOptions{AllowedValues: []bool{true, false}},
+			TrustedPlatformModuleOptions: &core.ShapeTrustedPlatformModuleOptions{AllowedValues: []bool{true, false}},
+			MemoryEncryptionOptions:      &core.ShapeMemoryEncryptionOptions{AllowedValues: []bool{false}},
+		},
+	}
+
+	assert.NoError(t, checkPlatformConfig(shielded, "AMD_VM", core.AmdVmLaunchInstancePlatformConfig{
+		IsSecureBootEnabled:            common.Bool(true),
+		IsTrustedPlatformModuleEnabled: common.Bool(true),
+		IsMeasuredBootEnabled:          common.Bool(true),
+	}))
+	assert.ErrorContains(t, checkPlatformConfig(shielded, "INTEL_VM", core.IntelVmLaunchInstancePlatformConfig{}),
+		"shape VM.Standard.E4.Flex takes platformConfig type AMD_VM, got INTEL_VM")
+	assert.ErrorContains(t, checkPlatformConfig(shielded, "AMD_VM", core.AmdVmLaunchInstancePlatformConfig{IsMemoryEncryptionEnabled: common.Bool(true)}),
+		"does not allow platformConfig.isMemoryEncryptionEnabled = true")
+	assert.ErrorContains(t, checkPlatformConfig(testFlexShape(), "AMD_VM", core.AmdVmLaunchInstancePlatformConfig{}),
+		"does not support a platformConfig")
+}
@@ -0,0 +1,316 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
+)
+
+// DeclaredDefaultsTag is the freeform tag that records which default tags a
+// resource declares itself with the default's own value. Read can't tell those
+// from a merged default, and stripping them would show up as drift; the tag
+// holds their keys ("Namespace.Key" for defined tags), sorted and comma
+// separated. It is only set when there is something to record.
+const DeclaredDefaultsTag = "formae-declared-defaults"
+
+// MergeDefaultTags adds the target's default tags to props["FreeformTags"] and
+// props["DefinedTags"]. A tag the resource already sets (same key, or same
+// namespace and key) is left untouched; one set to the default's value is
+// recorded in DeclaredDefaultsTag so StripDefaultTags keeps it.
+func MergeDefaultTags(props map[string]any, cfg *config.Config) {
+	if len(cfg.DefaultFreeformTags) == 0 && len(cfg.DefaultDefinedTags) == 0 {
+		return
+	}
+
+	var declared []string
+	freeform, _ := props["FreeformTags"].([]any)
+	for _, def := range cfg.DefaultFreeformTags {
+		if i := indexOfTag(freeform, "", def.Key); i < 0 {
+			freeform = append(freeform, map[string]any{"Key": def.Key, "Value": def.Value})
+		} else if hasTagValue(freeform[i], def.Value) {
+			declared = append(declared, def.Key)
+		}
+	}
+
+	if len(cfg.DefaultDefinedTags) > 0 {
+		tags, _ := props["DefinedTags"].([]any)
+		for _, def := range cfg.DefaultDefinedTags {
+			if i := indexOfTag(tags, def.Namespace, def.Key); i < 0 {
+				tags = append(tags, map[string]any{"Namespace": def.Namespace, "Key": def.Key, "Value": DefinedTagValue(def.Value)})
+			} else if hasTagValue(tags[i], def.Value) {
+				declared = append(declared, def.Namespace+"."+def.Key)
+			}
+		}
+		props["DefinedTags"] = tags
+	}
+
+	if i := indexOfTag(freeform, "", DeclaredDefaultsTag); i >= 0 {
+		freeform = append(freeform[:i], freeform[i+1:]...)
+	}
+	if len(declared) > 0 {
+		sort.Strings(declared)
+		freeform = append(freeform, map[string]any{"Key": DeclaredDefaultsTag, "Value": strings.Join(declared, ",")})
+	}
+	if len(freeform) > 0 {
+		props["FreeformTags"] = freeform
+	}
+}
+
+// StripDefaultTags removes the target's default tags from Read output so they
+// don't show up as drift. Only tags still carrying the default value are removed;
+// a resource that overrides a default keeps reporting its own value, and so does
+// one that declares the default value itself (see DeclaredDefaultsTag).
+func StripDefaultTags(props map[string]any, cfg *config.Config) {
+	declared := map[string]bool{}
+	if tags, ok := props["FreeformTags"].([]any); ok {
+		if i := indexOfTag(tags, "", DeclaredDefaultsTag); i >= 0 {
+			value, _ := tags[i].(map[string]any)["Value"].(string)
+			for _, key := range strings.Split(value, ",") {
+				declared[key] = true
+			}
+			removeTag(props, "FreeformTags", i)
+		}
+	}
+
+	for _, def := range cfg.DefaultFreeformTags {
+		if !declared[def.Key] {
+			stripTag(props, "FreeformTags", "", def.Key, def.Value)
+		}
+	}
+	for _, def := range cfg.DefaultDefinedTags {
+		if !declared[def.Namespace+"."+def.Key] {
+			stripTag(props, "DefinedTags", def.Namespace, def.Key, def.Value)
+		}
+	}
+}
+
+func stripTag(props map[string]any, field, namespace, key string, value any) {
+	tags, ok := props[field].([]any)
+	if !ok {
+		return
+	}
+	i := indexOfTag(tags, namespace, key)
+	if i < 0 || !hasTagValue(tags[i], value) {
+		return
+	}
+	removeTag(props, field, i)
+}
+
+// removeTag drops the i-th tag of props[field], and the field with its last tag.
+func removeTag(props map[string]any, field string, i int) {
+	tags := props[field].([]any)
+	tags = append(tags[:i], tags[i+1:]...)
+	if len(tags) == 0 {
+		delete(props, field)
+		return
+	}
+	props[field] = tags
+}
+
+// hasTagValue reports whether tag carries value. Defined tag values come back
+// from OCI as strings even when declared as numbers.
+func hasTagValue(tag any, value any) bool {
+	t, ok := tag.(map[string]any)
+	return ok && fmt.Sprint(t["Value"]) == fmt.Sprint(value)
+}
+
+type noDefaultTagsKey struct{}
+
+// WithoutDefaultTags returns a context in which ApplyPatchDocument leaves the
+// target's default tags out, for resource types that have no tags.
+func WithoutDefaultTags(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noDefaultTagsKey{}, true)
+}
+
+func defaultTagsSkipped(ctx context.Context) bool {
+	skipped, _ := ctx.Value(noDefaultTagsKey{}).(bool)
+	return skipped
+}
+
+// indexOfTag returns the position of the tag with the given key (and namespace,
+// for defined tags) in a Listing<oci.FreeformTag>/Listing<oci.DefinedTag>, or -1.
+func indexOfTag(tags []any, namespace, key string) int {
+	for i, item := range tags {
+		tag, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		ns, _ := tag["Namespace"].(string)
+		k, _ := tag["Key"].(string)
+		if ns == namespace && k == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// TagDiff lists the tags that differ between a resource's current and desired
+// tags: freeform tags by key, defined tags as "Namespace.Key".
+type TagDiff struct {
+	Added   []string
+	Changed []string
+	Removed []string
+}
+
+// Empty reports whether the tag sets are the same.
+func (d TagDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Changed) == 0 && len(d.Removed) == 0
+}
+
+// DiffFreeformTags compares two freeform tag maps as sent to and returned by OCI.
+func DiffFreeformTags(current, desired map[string]string) TagDiff {
+	cur := make(map[string]any, len(current))
+	for k, v := range current {
+		cur[k] = v
+	}
+	want := make(map[string]any, len(desired))
+	for k, v := range desired {
+		want[k] = v
+	}
+	return diffTagValues(cur, want)
+}
+
+// DiffDefinedTags compares two defined tag maps. Values are compared by their
+// string form, since OCI returns numeric defined tags as strings.
+func DiffDefinedTags(current, desired map[string]map[string]any) TagDiff {
+	flatten := func(tags map[string]map[string]any) map[string]any {
+		flat := map[string]any{}
+		for ns, keys := range tags {
+			for k, v := range keys {
+				flat[ns+"."+k] = v
+			}
+		}
+		return flat
+	}
+	return diffTagValues(flatten(current), flatten(desired))
+}
+
+func diffTagValues(current, desired map[string]any) TagDiff {
+	var diff TagDiff
+	for k, v := range desired {
+		old, ok := current[k]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, k)
+		case fmt.Sprint(old) != fmt.Sprint(v):
+			diff.Changed = append(diff.Changed, k)
+		}
+	}
+	for k := range current {
+		if _, ok := desired[k]; !ok {
+			diff.Removed = append(diff.Removed, k)
+		}
+	}
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Changed)
+	sort.Strings(diff.Removed)
+	return diff
+}
+
+// OmitUnchangedTags drops FreeformTags and DefinedTags from the update
+// properties props when they match the current properties read from OCI.
+// Update APIs replace each tag map wholesale but leave it alone when it is
+// omitted, so an update that only touches freeform tags no longer resends the
+// defined tags. When the defined tags do change, ApplyPatchDocument puts back
+// the namespaces Read hides (Oracle-Tags and IgnoredTagNamespaces).
+func OmitUnchangedTags(current, props map[string]any) {
+	if _, ok := props["FreeformTags"]; ok {
+		cur, _ := ExtractFreeformTags(current, "FreeformTags")
+		want, _ := ExtractFreeformTags(props, "FreeformTags")
+		if DiffFreeformTags(cur, want).Empty() {
+			delete(props, "FreeformTags")
+		}
+	}
+	if _, ok := props["DefinedTags"]; ok {
+		cur, _ := ExtractDefinedTags(current, "DefinedTags")
+		want, _ := ExtractDefinedTags(props, "DefinedTags")
+		if DiffDefinedTags(cur, want).Empty() {
+			delete(props, "DefinedTags")
+		}
+	}
+}
+
+// OCI limits on tag names and values. Keys and namespaces are also unique
+// regardless of case.
+const (
+	maxTagNameLength  = 100
+	maxTagValueLength = 256
+)
+
+// ValidateTags checks props["FreeformTags"] and props["DefinedTags"] against
+// OCI's tag limits, naming each offending tag, so a bad tag fails before the
+// request instead of as an opaque 400 from OCI.
+func ValidateTags(props map[string]any) error {
+	var errs []error
+
+	freeform, _ := props["FreeformTags"].([]any)
+	seen := map[string]string{}
+	for _, item := range freeform {
+		tag, _ := item.(map[string]any)
+		key, _ := tag["Key"].(string)
+		value, _ := tag["Value"].(string)
+		if err := validateTagName("key", key); err != nil {
+			errs = append(errs, fmt.Errorf("FreeformTag %q: %w", key, err))
+			continue
+		}
+		if n := utf8.RuneCountInString(value); n > maxTagValueLength {
+			errs = append(errs, fmt.Errorf("FreeformTag %q: value is %d characters, OCI allows at most %d", key, n, maxTagValueLength))
+		}
+		if other, dup := seen[strings.ToLower(key)]; dup {
+			errs = append(errs, fmt.Errorf("FreeformTag %q: duplicates %q, OCI tag keys are case-insensitive", key, other))
+		}
+		seen[strings.ToLower(key)] = key
+	}
+
+	defined, _ := props["DefinedTags"].([]any)
+	seen = map[string]string{}
+	for _, item := range defined {
+		tag, _ := item.(map[string]any)
+		namespace, _ := tag["Namespace"].(string)
+		key, _ := tag["Key"].(string)
+		name := namespace + "." + key
+		if err := validateTagName("namespace", namespace); err != nil {
+			errs = append(errs, fmt.Errorf("DefinedTag %q: %w", name, err))
+			continue
+		}
+		if err := validateTagName("key", key); err != nil {
+			errs = append(errs, fmt.Errorf("DefinedTag %q: %w", name, err))
+			continue
+		}
+		if n := utf8.RuneCountInString(fmt.Sprint(tag["Value"])); n > maxTagValueLength {
+			errs = append(errs, fmt.Errorf("DefinedTag %q: value is %d characters, OCI allows at most %d", name, n, maxTagValueLength))
+		}
+		if other, dup := seen[strings.ToLower(name)]; dup {
+			errs = append(errs, fmt.Errorf("DefinedTag %q: duplicates %q, OCI tag namespaces and keys are case-insensitive", name, other))
+		}
+		seen[strings.ToLower(name)] = name
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateTagName checks a tag key or namespace: 1 to 100 characters with no
+// periods or whitespace.
+func validateTagName(what, name string) error {
+	switch {
+	case name == "":
+		return fmt.Errorf("%s is empty", what)
+	case utf8.RuneCountInString(name) > maxTagNameLength:
+		return fmt.Errorf("%s is %d characters, OCI allows at most %d", what, utf8.RuneCountInString(name), maxTagNameLength)
+	case strings.Contains(name, "."):
+		return fmt.Errorf("%s cannot contain periods", what)
+	case strings.IndexFunc(name, unicode.IsSpace) >= 0:
+		return fmt.Errorf("%s cannot contain whitespace", what)
+	}
+	return nil
+}
@@ -0,0 +1,248 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDefaultTagsConfig() *config.Config {
+	return &config.Config{
+		DefaultFreeformTags: []config.FreeformTag{{Key: "managed-by", Value: "formae"}},
+		DefaultDefinedTags:  []config.DefinedTag{{Namespace: "Finance", Key: "CostCenter", Value: "42"}},
+	}
+}
+
+func TestMergeDefaultTags(t *testing.T) {
+	t.Run("adds_missing_defaults", func(t *testing.T) {
+		props := map[string]any{
+			"FreeformTags": []any{map[string]any{"Key": "Environment", "Value": "dev"}},
+		}
+		MergeDefaultTags(props, testDefaultTagsConfig())
+
+		assert.Equal(t, []any{
+			map[string]any{"Key": "Environment", "Value": "dev"},
+			map[string]any{"Key": "managed-by", "Value": "formae"},
+		}, props["FreeformTags"])
+		assert.Equal(t, []any{
+			map[string]any{"Namespace": "Finance", "Key": "CostCenter", "Value": "42"},
+		}, props["DefinedTags"])
+	})
+
+	t.Run("explicit_tags_win", func(t *testing.T) {
+		props := map[string]any{
+			"FreeformTags": []any{map[string]any{"Key": "managed-by", "Value": "terraform"}},
+			"DefinedTags":  []any{map[string]any{"Namespace": "Finance", "Key": "CostCenter", "Value": "7"}},
+		}
+		MergeDefaultTags(props, testDefaultTagsConfig())
+
+		assert.Equal(t, []any{map[string]any{"Key": "managed-by", "Value": "terraform"}}, props["FreeformTags"])
+		assert.Equal(t, []any{map[string]any{"Namespace": "Finance", "Key": "CostCenter", "Value": "7"}}, props["DefinedTags"])
+	})
+
+	t.Run("records_declared_defaults", func(t *testing.T) {
+		props := map[string]any{
+			"FreeformTags": []any{map[string]any{"Key": "managed-by", "Value": "formae"}},
+			"DefinedTags":  []any{map[string]any{"Namespace": "Finance", "Key": "CostCenter", "Value": 42}},
+		}
+		MergeDefaultTags(props, testDefaultTagsConfig())
+
+		assert.Equal(t, []any{
+			map[string]any{"Key": "managed-by", "Value": "formae"},
+			map[string]any{"Key": DeclaredDefaultsTag, "Value": "Finance.CostCenter,managed-by"},
+		}, props["FreeformTags"])
+	})
+
+	t.Run("no_defaults", func(t *testing.T) {
+		props := map[string]any{"DisplayName": "vcn"}
+		MergeDefaultTags(props, &config.Config{})
+
+		assert.Equal(t, map[string]any{"DisplayName": "vcn"}, props)
+	})
+}
+
+func TestStripDefaultTags(t *testing.T) {
+	t.Run("removes_defaults", func(t *testing.T) {
+		props := map[string]any{
+			"FreeformTags": []any{
+				map[string]any{"Key": "Environment", "Value": "dev"},
+				map[string]any{"Key": "managed-by", "Value": "formae"},
+			},
+			"DefinedTags": []any{map[string]any{"Namespace": "Finance", "Key": "CostCenter", "Value": "42"}},
+		}
+		StripDefaultTags(props, testDefaultTagsConfig())
+
+		assert.Equal(t, []any{map[string]any{"Key": "Environment", "Value": "dev"}}, props["FreeformTags"])
+		assert.NotContains(t, props, "DefinedTags")
+	})
+
+	t.Run("keeps_overridden_values", func(t *testing.T) {
+		props := map[string]any{
+			"FreeformTags": []any{map[string]any{"Key": "managed-by", "Value": "terraform"}},
+		}
+		StripDefaultTags(props, testDefaultTagsConfig())
+
+		assert.Equal(t, []any{map[string]any{"Key": "managed-by", "Value": "terraform"}}, props["FreeformTags"])
+	})
+
+	t.Run("keeps_declared_defaults", func(t *testing.T) {
+		props := map[string]any{
+			"FreeformTags": []any{
+				map[string]any{"Key": "managed-by", "Value": "formae"},
+				map[string]any{"Key": DeclaredDefaultsTag, "Value": "managed-by"},
+			},
+			"DefinedTags": []any{map[string]any{"Namespace": "Finance", "Key": "CostCenter", "Value": "42"}},
+		}
+		StripDefaultTags(props, testDefaultTagsConfig())
+
+		assert.Equal(t, []any{map[string]any{"Key": "managed-by", "Value": "formae"}}, props["FreeformTags"])
+		assert.NotContains(t, props, "DefinedTags")
+	})
+}
+
+func TestDiffFreeformTags(t *testing.T) {
+	diff := DiffFreeformTags(
+		map[string]string{"Env": "dev", "Team": "platform", "Old": "x"},
+		map[string]string{"Env": "prod", "Team": "platform", "New": "y"},
+	)
+	assert.Equal(t, TagDiff{Added: []string{"New"}, Changed: []string{"Env"}, Removed: []string{"Old"}}, diff)
+	assert.False(t, diff.Empty())
+
+	assert.True(t, DiffFreeformTags(nil, map[string]string{}).Empty())
+}
+
+func TestDiffDefinedTags(t *testing.T) {
+	diff := DiffDefinedTags(
+		map[string]map[string]any{"Finance": {"CostCenter": "42"}, "Ops": {"Owner": "alice"}},
+		map[string]map[string]any{"Finance": {"CostCenter": 42}, "Ops": {"Owner": "bob"}, "Security": {"Level": "high"}},
+	)
+	// 42 and "42" are the same tag value once OCI has stored it
+	assert.Equal(t, TagDiff{Added: []string{"Security.Level"}, Changed: []string{"Ops.Owner"}}, diff)
+}
+
+func TestOmitUnchangedTags(t *testing.T) {
+	current := map[string]any{
+		"FreeformTags": []any{map[string]any{"Key": "Env", "Value": "dev"}},
+		"DefinedTags":  []any{map[string]any{"Namespace": "Finance", "Key": "CostCenter", "Value": "42"}},
+	}
+
+	t.Run("unchanged_tags_are_omitted", func(t *testing.T) {
+		props := map[string]any{
+			"DisplayName":  "renamed",
+			"FreeformTags": []any{map[string]any{"Key": "Env", "Value": "dev"}},
+			"DefinedTags":  []any{map[string]any{"Namespace": "Finance", "Key": "CostCenter", "Value": 42.0}},
+		}
+		OmitUnchangedTags(current, props)
+		assert.Equal(t, map[string]any{"DisplayName": "renamed"}, props)
+	})
+
+	t.Run("changed_tags_are_kept", func(t *testing.T) {
+		props := map[string]any{
+			"FreeformTags": []any{map[string]any{"Key": "Env", "Value": "prod"}},
+			"DefinedTags":  []any{map[string]any{"Namespace": "Finance", "Key": "CostCenter", "Value": "42"}},
+		}
+		OmitUnchangedTags(current, props)
+		assert.Equal(t, []any{map[string]any{"Key": "Env", "Value": "prod"}}, props["FreeformTags"])
+		assert.NotContains(t, props, "DefinedTags")
+	})
+}
+
+func TestApplyPatchDocumentKeepsHiddenDefinedTags(t *testing.T) {
+	read := func(_ context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+		tags := map[string]map[string]any{
+			"Finance":     {"CostCenter": "42"},
+			"Oracle-Tags": {"CreatedBy": "alice"},
+			"Defaults":    {"Owner": "platform"},
+		}
+		cfg := config.FromTargetConfig(request.TargetConfig)
+		props, err := json.Marshal(map[string]any{"DefinedTags": DefinedTagsToList(tags, cfg.HiddenTagNamespaces())})
+		return &resource.ReadResult{Properties: string(props)}, err
+	}
+	targetConfig := json.RawMessage(`{"IgnoredTagNamespaces":["Defaults"]}`)
+
+	t.Run("changed_defined_tags_keep_hidden_namespaces", func(t *testing.T) {
+		patch := `[{"op":"replace","path":"/DefinedTags/0/Value","value":"7"}]`
+		props, err := ApplyPatchDocument(context.Background(), &resource.UpdateRequest{
+			NativeID:      "ocid1.vcn.oc1..abc",
+			PatchDocument: &patch,
+			TargetConfig:  targetConfig,
+		}, read)
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []any{
+			map[string]any{"Namespace": "Finance", "Key": "CostCenter", "Value": "7"},
+			map[string]any{"Namespace": "Oracle-Tags", "Key": "CreatedBy", "Value": "alice"},
+			map[string]any{"Namespace": "Defaults", "Key": "Owner", "Value": "platform"},
+		}, props["DefinedTags"])
+	})
+
+	t.Run("unchanged_defined_tags_stay_omitted", func(t *testing.T) {
+		patch := `[{"op":"add","path":"/DisplayName","value":"vcn"}]`
+		props, err := ApplyPatchDocument(context.Background(), &resource.UpdateRequest{
+			NativeID:      "ocid1.vcn.oc1..abc",
+			PatchDocument: &patch,
+			TargetConfig:  targetConfig,
+		}, read)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]any{"DisplayName": "vcn"}, props)
+	})
+}
+
+func TestValidateTags(t *testing.T) {
+	freeform := func(key, value string) map[string]any {
+		return map[string]any{"FreeformTags": []any{map[string]any{"Key": key, "Value": value}}}
+	}
+	defined := func(namespace, key string, value any) map[string]any {
+		return map[string]any{"DefinedTags": []any{map[string]any{"Namespace": namespace, "Key": key, "Value": value}}}
+	}
+
+	tests := []struct {
+		name    string
+		props   map[string]any
+		wantErr string
+	}{
+		{name: "no tags", props: map[string]any{"DisplayName": "vcn"}},
+		{name: "freeform", props: freeform("Environment", "prod")},
+		{name: "freeform empty value", props: freeform("Environment", "")},
+		{name: "freeform empty key", props: freeform("", "prod"), wantErr: `FreeformTag "": key is empty`},
+		{name: "freeform key with period", props: freeform("app.tier", "web"), wantErr: `FreeformTag "app.tier": key cannot contain periods`},
+		{name: "freeform key with space", props: freeform("cost center", "42"), wantErr: `FreeformTag "cost center": key cannot contain whitespace`},
+		{name: "freeform key too long", props: freeform(strings.Repeat("k", 101), "v"), wantErr: "key is 101 characters, OCI allows at most 100"},
+		{name: "freeform value too long", props: freeform("Notes", strings.Repeat("v", 257)), wantErr: `FreeformTag "Notes": value is 257 characters`},
+		{
+			name: "freeform keys differing in case",
+			props: map[string]any{"FreeformTags": []any{
+				map[string]any{"Key": "Env", "Value": "prod"},
+				map[string]any{"Key": "env", "Value": "dev"},
+			}},
+			wantErr: `FreeformTag "env": duplicates "Env"`,
+		},
+		{name: "defined", props: defined("Finance", "CostCenter", 42)},
+		{name: "defined empty namespace", props: defined("", "CostCenter", "42"), wantErr: `DefinedTag ".CostCenter": namespace is empty`},
+		{name: "defined namespace with space", props: defined("Fin ance", "CostCenter", "42"), wantErr: "namespace cannot contain whitespace"},
+		{name: "defined key with period", props: defined("Finance", "Cost.Center", "42"), wantErr: "key cannot contain periods"},
+		{name: "defined value too long", props: defined("Finance", "Notes", strings.Repeat("v", 300)), wantErr: `DefinedTag "Finance.Notes": value is 300 characters`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTags(tt.props)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
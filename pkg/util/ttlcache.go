@@ -0,0 +1,53 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package util
+
+import (
+	"sync"
+	"time"
+)
+
+// lookupCacheTTL bounds how long a resolved name is trusted. Names can be
+// re-pointed while the plugin runs (a compartment deleted and recreated, a new
+// image published), so lookups are repeated once an entry expires.
+const lookupCacheTTL = 15 * time.Minute
+
+// ttlCache is a process-wide string cache whose entries expire after ttl.
+type ttlCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[string]ttlCacheEntry
+}
+
+type ttlCacheEntry struct {
+	value   string
+	expires time.Time
+}
+
+func newTTLCache(ttl time.Duration) *ttlCache {
+	return &ttlCache{ttl: ttl, now: time.Now, entries: map[string]ttlCacheEntry{}}
+}
+
+// get returns the value cached under key, dropping it if it has expired.
+func (c *ttlCache) get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return "", false
+	}
+	if !c.now().Before(entry.expires) {
+		delete(c.entries, key)
+		return "", false
+	}
+	return entry.value, true
+}
+
+func (c *ttlCache) set(key, value string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = ttlCacheEntry{value: value, expires: c.now().Add(c.ttl)}
+}
@@ -0,0 +1,31 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTTLCache(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := newTTLCache(time.Minute)
+	cache.now = func() time.Time { return now }
+
+	_, ok := cache.get("prod")
+	assert.False(t, ok)
+
+	cache.set("prod", "ocid1.compartment..prod")
+	now = now.Add(59 * time.Second)
+	value, ok := cache.get("prod")
+	assert.True(t, ok)
+	assert.Equal(t, "ocid1.compartment..prod", value)
+
+	now = now.Add(time.Second)
+	_, ok = cache.get("prod")
+	assert.False(t, ok, "entry should expire after the TTL")
+}
@@ -4,7 +4,12 @@
 
 package util
 
-import "sort"
+import (
+	"encoding/json"
+	"slices"
+	"sort"
+	"strconv"
+)
 
 // IsTerminal returns true if the OCI lifecycle state indicates the
 // resource is being deleted or already deleted. OCI returns 200 for resources in
@@ -54,7 +59,7 @@ func ExtractDefinedTags(props map[string]any, key string) (map[string]map[string
 		if tag, ok := item.(map[string]any); ok {
 			ns, _ := tag["Namespace"].(string)
 			k, _ := tag["Key"].(string)
-			v := tag["Value"]
+			v := DefinedTagValue(tag["Value"])
 			if ns != "" && k != "" {
 				if result[ns] == nil {
 					result[ns] = make(map[string]any)
@@ -87,21 +92,27 @@ func FreeformTagsToList(tags map[string]string) []map[string]string {
 }
 
 // DefinedTagsToList converts OCI's map[string]map[string]any to Listing<oci.DefinedTag> format for responses.
-// Oracle-Tags (auto-generated CreatedBy/CreatedOn) are excluded since they are server-computed
-// and would cause false diffs when the forma doesn't declare them.
-func DefinedTagsToList(tags map[string]map[string]any) []map[string]any {
+// ignoredNamespaces are excluded; Read passes config.HiddenTagNamespaces, i.e. Oracle-Tags
+// (auto-generated CreatedBy/CreatedOn, which would cause false diffs when the forma doesn't
+// declare them) and any namespaces filled in by compartment tag defaults.
+//
+// Like FreeformTagsToList the result is ordered by namespace then key and is nil
+// when no tags are left, so repeated reads marshal to identical JSON. Values are
+// normalized by DefinedTagValue; encoding/json writes nested map values with
+// sorted keys.
+func DefinedTagsToList(tags map[string]map[string]any, ignoredNamespaces []string) []map[string]any {
 	if len(tags) == 0 {
 		return nil
 	}
 	namespaces := make([]string, 0, len(tags))
 	for ns := range tags {
-		if ns == "Oracle-Tags" {
+		if slices.Contains(ignoredNamespaces, ns) {
 			continue
 		}
 		namespaces = append(namespaces, ns)
 	}
 	sort.Strings(namespaces)
-	result := make([]map[string]any, 0)
+	var result []map[string]any
 	for _, ns := range namespaces {
 		keys := make([]string, 0, len(tags[ns]))
 		for k := range tags[ns] {
@@ -109,12 +120,35 @@ func DefinedTagsToList(tags map[string]map[string]any) []map[string]any {
 		}
 		sort.Strings(keys)
 		for _, k := range keys {
-			result = append(result, map[string]any{"Namespace": ns, "Key": k, "Value": tags[ns][k]})
+			result = append(result, map[string]any{"Namespace": ns, "Key": k, "Value": DefinedTagValue(tags[ns][k])})
 		}
 	}
 	return result
 }
 
+// DefinedTagValue returns the string form of a numeric or boolean defined tag
+// value, which is how OCI stores and returns it, so 42 and true round-trip as
+// "42" and "true" instead of drifting between a number and a string. Numbers
+// are written without an exponent or trailing zeros. Strings and any other
+// values are returned unchanged.
+func DefinedTagValue(v any) any {
+	switch v := v.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return v
+}
+
 // validateString checks if a value is a non-empty string or a resolved reference
 func validateString(val any) (string, bool) {
 	// Case 1: Direct string
@@ -137,6 +171,17 @@ func validateString(val any) (string, bool) {
 	return "", false
 }
 
+// CustomerKmsKeyId returns the customer-managed key an OCI encryption field
+// names. Resources on Oracle-managed keys come back with the field missing,
+// null or empty depending on the service and call; all of them report false,
+// so Reads can leave KmsKeyId out instead of flapping between null and "".
+func CustomerKmsKeyId(kmsKeyId *string) (string, bool) {
+	if kmsKeyId == nil || *kmsKeyId == "" {
+		return "", false
+	}
+	return *kmsKeyId, true
+}
+
 // ExtractString extracts an optional string property, returning it only if present and non-empty
 func ExtractString(props map[string]any, key string) (string, bool) {
 	return validateString(props[key])
@@ -169,6 +214,22 @@ func ExtractStringSlice(props map[string]any, key string) ([]string, bool) {
 	return nil, false
 }
 
+// nsgIdKeys are the property names OCI APIs use for network security group lists.
+// Nested SDK structs use camelCase while top-level properties are PascalCase, and
+// the load balancer API spells the field out in full.
+var nsgIdKeys = []string{"nsgIds", "NsgIds", "networkSecurityGroupIds", "NetworkSecurityGroupIds"}
+
+// ExtractNsgIds extracts a list of NSG OCIDs under any of the known property names.
+// Elements may be plain strings or resolved references.
+func ExtractNsgIds(props map[string]any) ([]string, bool) {
+	for _, key := range nsgIdKeys {
+		if ids, ok := ExtractStringSlice(props, key); ok {
+			return ids, true
+		}
+	}
+	return nil, false
+}
+
 // ExtractResolvedReference extracts a string value from either:
 // - A plain string
 // - A reference object with "$value" key (resolved reference from formae)
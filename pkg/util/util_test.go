@@ -5,9 +5,13 @@
 package util
 
 import (
+	"crypto/sha256"
+	"encoding/json"
 	"testing"
 
+	"github.com/platform-engineering-labs/formae-plugin-oci/pkg/config"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFreeformTagsToList_Nil(t *testing.T) {
@@ -34,7 +38,7 @@ func TestFreeformTagsToList_SortedByKey(t *testing.T) {
 }
 
 func TestDefinedTagsToList_Nil(t *testing.T) {
-	assert.Nil(t, DefinedTagsToList(nil))
+	assert.Nil(t, DefinedTagsToList(nil, nil))
 }
 
 func TestDefinedTagsToList_SortedByNamespaceThenKey(t *testing.T) {
@@ -48,7 +52,7 @@ func TestDefinedTagsToList_SortedByNamespaceThenKey(t *testing.T) {
 		},
 	}
 
-	got := DefinedTagsToList(tags)
+	got := DefinedTagsToList(tags, nil)
 
 	assert.Equal(t, []map[string]any{
 		{"Namespace": "AppConfig", "Key": "Env", "Value": "prod"},
@@ -56,3 +60,135 @@ func TestDefinedTagsToList_SortedByNamespaceThenKey(t *testing.T) {
 		{"Namespace": "Operations", "Key": "Team", "Value": "platform"},
 	}, got)
 }
+
+func TestDefinedTagsToList_IgnoredNamespaces(t *testing.T) {
+	tags := map[string]map[string]any{
+		"Oracle-Tags": {"CreatedBy": "user"},
+		"Governance":  {"Owner": "platform"},
+		"Operations":  {"CostCenter": "42"},
+	}
+
+	got := DefinedTagsToList(tags, (&config.Config{IgnoredTagNamespaces: []string{"Governance"}}).HiddenTagNamespaces())
+
+	assert.Equal(t, []map[string]any{
+		{"Namespace": "Operations", "Key": "CostCenter", "Value": "42"},
+	}, got)
+}
+
+func TestDefinedTagsToList_OnlyIgnoredNamespacesIsNil(t *testing.T) {
+	tags := map[string]map[string]any{
+		"Oracle-Tags": {"CreatedBy": "user", "CreatedOn": "2025-01-01T00:00:00Z"},
+	}
+
+	assert.Nil(t, DefinedTagsToList(tags, (&config.Config{}).HiddenTagNamespaces()))
+}
+
+func TestDefinedTagsNonStringValuesRoundTrip(t *testing.T) {
+	// As unmarshalled from a forma that declares value = 42 and value = true
+	props := map[string]any{"DefinedTags": []any{
+		map[string]any{"Namespace": "Finance", "Key": "CostCenter", "Value": float64(42)},
+		map[string]any{"Namespace": "Finance", "Key": "Billable", "Value": true},
+		map[string]any{"Namespace": "Finance", "Key": "Rate", "Value": 0.25},
+	}}
+
+	sent, ok := ExtractDefinedTags(props, "DefinedTags")
+	require.True(t, ok)
+	assert.Equal(t, map[string]map[string]any{
+		"Finance": {"CostCenter": "42", "Billable": "true", "Rate": "0.25"},
+	}, sent)
+
+	// OCI echoes the values back as strings; a JSON number or boolean from the
+	// API reads back the same way
+	assert.Equal(t, []map[string]any{
+		{"Namespace": "Finance", "Key": "Billable", "Value": "true"},
+		{"Namespace": "Finance", "Key": "CostCenter", "Value": "42"},
+		{"Namespace": "Finance", "Key": "Rate", "Value": "0.25"},
+	}, DefinedTagsToList(map[string]map[string]any{
+		"Finance": {"CostCenter": float64(42), "Billable": true, "Rate": "0.25"},
+	}, nil))
+}
+
+func TestDefinedTagValue(t *testing.T) {
+	tests := []struct {
+		in   any
+		want any
+	}{
+		{in: "42", want: "42"},
+		{in: float64(42), want: "42"},
+		{in: float64(1e21), want: "1000000000000000000000"},
+		{in: 7, want: "7"},
+		{in: json.Number("12.50"), want: "12.50"},
+		{in: false, want: "false"},
+		{in: nil, want: nil},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, DefinedTagValue(tt.in), "%#v", tt.in)
+	}
+}
+
+func TestCustomerKmsKeyId(t *testing.T) {
+	empty := ""
+	key := "ocid1.key.oc1..aaa"
+
+	_, ok := CustomerKmsKeyId(nil)
+	assert.False(t, ok)
+	_, ok = CustomerKmsKeyId(&empty)
+	assert.False(t, ok)
+	got, ok := CustomerKmsKeyId(&key)
+	assert.True(t, ok)
+	assert.Equal(t, key, got)
+}
+
+func TestTagsToList_Deterministic(t *testing.T) {
+	freeform := map[string]string{"b": "2", "a": "1", "d": "4", "c": "3", "e": "5"}
+	defined := map[string]map[string]any{
+		"Operations": {"Team": "platform", "CostCenter": 42, "Limits": map[string]any{"z": 1, "y": 2, "x": 3}},
+		"AppConfig":  {"Env": "prod", "Tier": "web"},
+		"Finance":    {"Budget": "q3", "Approver": "ops"},
+	}
+
+	hash := func() [32]byte {
+		out, err := json.Marshal(map[string]any{
+			"FreeformTags": FreeformTagsToList(freeform),
+			"DefinedTags":  DefinedTagsToList(defined, nil),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sha256.Sum256(out)
+	}
+
+	// Map iteration order is randomised per range, so repeat enough to catch any leak
+	first := hash()
+	for range 100 {
+		assert.Equal(t, first, hash())
+	}
+}
+
+func TestExtractNsgIds(t *testing.T) {
+	tests := []struct {
+		name  string
+		props map[string]any
+		want  []string
+	}{
+		{"camelCase", map[string]any{"nsgIds": []any{"ocid1.nsg.a"}}, []string{"ocid1.nsg.a"}},
+		{"PascalCase", map[string]any{"NsgIds": []any{"ocid1.nsg.a"}}, []string{"ocid1.nsg.a"}},
+		{"long form", map[string]any{"NetworkSecurityGroupIds": []any{"ocid1.nsg.a"}}, []string{"ocid1.nsg.a"}},
+		{"resolved reference", map[string]any{"nsgIds": []any{
+			"ocid1.nsg.a",
+			map[string]any{"$ref": "formae://nsg", "$value": "ocid1.nsg.b"},
+		}}, []string{"ocid1.nsg.a", "ocid1.nsg.b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ExtractNsgIds(tt.props)
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestExtractNsgIds_UnresolvedReference(t *testing.T) {
+	_, ok := ExtractNsgIds(map[string]any{"nsgIds": []any{map[string]any{"$ref": "formae://nsg"}}})
+	assert.False(t, ok)
+}
@@ -0,0 +1,74 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package util
+
+import (
+	"context"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+// WorkRequest is a single observation of a work request, in terms that don't
+// depend on the service it belongs to.
+type WorkRequest struct {
+	Status         string // the service's status, e.g. "SUCCEEDED" or "IN_PROGRESS"
+	ResourceID     string // resource the work request acted on, if the service reports it
+	FailureMessage string // why it failed; only read when Status is "FAILED"
+}
+
+// WorkRequestPoller fetches a work request from one service. Each service with
+// work requests (ContainerEngine, LoadBalancer, NetworkLoadBalancer, ...) has an
+// adapter around its own client and response shape.
+type WorkRequestPoller interface {
+	GetWorkRequest(ctx context.Context, workRequestId string) (*WorkRequest, error)
+}
+
+// PollWorkRequest fetches a work request once and converts it to a ProgressResult.
+// SUCCEEDED maps to success, FAILED and CANCELED to failure, and every other
+// status (ACCEPTED, IN_PROGRESS, CANCELING) to in progress with the work request
+// as the RequestID. NativeID is only set when the service reports the resource.
+func PollWorkRequest(ctx context.Context, poller WorkRequestPoller, workRequestId string, operation resource.Operation) (*resource.ProgressResult, error) {
+	wr, err := poller.GetWorkRequest(ctx, workRequestId)
+	if err != nil {
+		return nil, err
+	}
+
+	switch wr.Status {
+	case "SUCCEEDED":
+		return &resource.ProgressResult{
+			Operation:       operation,
+			OperationStatus: resource.OperationStatusSuccess,
+			NativeID:        wr.ResourceID,
+		}, nil
+
+	case "FAILED":
+		message := wr.FailureMessage
+		if message == "" {
+			message = "Work request failed (no error details available)"
+		}
+		return &resource.ProgressResult{
+			Operation:       operation,
+			OperationStatus: resource.OperationStatusFailure,
+			StatusMessage:   message,
+			NativeID:        wr.ResourceID,
+		}, nil
+
+	case "CANCELED":
+		return &resource.ProgressResult{
+			Operation:       operation,
+			OperationStatus: resource.OperationStatusFailure,
+			StatusMessage:   "Operation was canceled",
+			NativeID:        wr.ResourceID,
+		}, nil
+
+	default:
+		return &resource.ProgressResult{
+			Operation:       operation,
+			OperationStatus: resource.OperationStatusInProgress,
+			RequestID:       workRequestId,
+			NativeID:        wr.ResourceID,
+		}, nil
+	}
+}
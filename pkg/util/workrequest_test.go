@@ -0,0 +1,89 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package util
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeWorkRequestPoller struct {
+	wr  *WorkRequest
+	err error
+}
+
+func (f fakeWorkRequestPoller) GetWorkRequest(ctx context.Context, workRequestId string) (*WorkRequest, error) {
+	return f.wr, f.err
+}
+
+func TestPollWorkRequest(t *testing.T) {
+	cases := []struct {
+		name          string
+		wr            WorkRequest
+		wantStatus    resource.OperationStatus
+		wantNativeID  string
+		wantRequestID string
+		wantMessage   string
+	}{
+		{
+			name:         "succeeded",
+			wr:           WorkRequest{Status: "SUCCEEDED", ResourceID: "ocid1.cluster..aaa"},
+			wantStatus:   resource.OperationStatusSuccess,
+			wantNativeID: "ocid1.cluster..aaa",
+		},
+		{
+			name:        "failed",
+			wr:          WorkRequest{Status: "FAILED", FailureMessage: "quota exceeded"},
+			wantStatus:  resource.OperationStatusFailure,
+			wantMessage: "quota exceeded",
+		},
+		{
+			name:        "failed_without_details",
+			wr:          WorkRequest{Status: "FAILED"},
+			wantStatus:  resource.OperationStatusFailure,
+			wantMessage: "Work request failed (no error details available)",
+		},
+		{
+			name:        "canceled",
+			wr:          WorkRequest{Status: "CANCELED"},
+			wantStatus:  resource.OperationStatusFailure,
+			wantMessage: "Operation was canceled",
+		},
+		{
+			name:          "in_progress",
+			wr:            WorkRequest{Status: "IN_PROGRESS"},
+			wantStatus:    resource.OperationStatusInProgress,
+			wantRequestID: "ocid1.workrequest..aaa",
+		},
+		{
+			name:          "canceling",
+			wr:            WorkRequest{Status: "CANCELING"},
+			wantStatus:    resource.OperationStatusInProgress,
+			wantRequestID: "ocid1.workrequest..aaa",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := PollWorkRequest(context.Background(), fakeWorkRequestPoller{wr: &tc.wr}, "ocid1.workrequest..aaa", resource.OperationCheckStatus)
+			require.NoError(t, err)
+			assert.Equal(t, resource.OperationCheckStatus, result.Operation)
+			assert.Equal(t, tc.wantStatus, result.OperationStatus)
+			assert.Equal(t, tc.wantNativeID, result.NativeID)
+			assert.Equal(t, tc.wantRequestID, result.RequestID)
+			assert.Equal(t, tc.wantMessage, result.StatusMessage)
+		})
+	}
+}
+
+func TestPollWorkRequest_Error(t *testing.T) {
+	_, err := PollWorkRequest(context.Background(), fakeWorkRequestPoller{err: errors.New("boom")}, "ocid1.workrequest..aaa", resource.OperationCheckStatus)
+	assert.EqualError(t, err, "boom")
+}